@@ -1,7 +1,9 @@
 package database
 
 import (
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/wowsims/mop/sim/core/proto"
@@ -31,3 +33,9 @@ func Load() *proto.UIDatabase {
 
 	return db
 }
+
+// Revision returns a short hash of the embedded database, identifying the database build.
+func Revision() string {
+	sum := sha256.Sum256(dbBytes)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var aplsCmd = &cobra.Command{
+	Use:   "apls [spec] [name]",
+	Short: "list the APL presets bundled for a spec",
+	Long:  "Lists the names of the APL presets bundled for a spec, e.g. SpecFeralDruid. If a name is given, prints that preset in protojson format instead.",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, ok := proto.Spec_value[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown spec %q", args[0])
+		}
+
+		result := core.APLPresets(&proto.APLPresetsRequest{Spec: proto.Spec(spec)})
+		if result.ErrorResult != "" {
+			return fmt.Errorf("%s", result.ErrorResult)
+		}
+
+		if len(args) == 1 {
+			for _, preset := range result.Presets {
+				fmt.Println(preset.Name)
+			}
+			return nil
+		}
+
+		for _, preset := range result.Presets {
+			if preset.Name == args[1] {
+				output, err := protojson.MarshalOptions{Multiline: true}.Marshal(preset.Rotation)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+				return nil
+			}
+		}
+		return fmt.Errorf("no APL preset %q for %s", args[1], args[0])
+	},
+}
+
+// Replaces the rotation of every player with the named preset of their spec.
+func applyAPLPreset(raid *proto.Raid, name string) error {
+	for _, party := range raid.GetParties() {
+		for _, player := range party.GetPlayers() {
+			if player.GetSpec() == nil {
+				continue
+			}
+			spec := core.PlayerProtoToSpec(player)
+			rotation := core.GetAPLPreset(spec, name)
+			if rotation == nil {
+				return fmt.Errorf("no APL preset %q for %s", name, spec)
+			}
+			player.Rotation = rotation
+		}
+	}
+	return nil
+}
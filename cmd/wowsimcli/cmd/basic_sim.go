@@ -8,9 +8,17 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/report"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+var (
+	reportFile  string
+	summaryFile string
+	linkBase    string
+	aplPreset   string
+)
+
 var simCmd = &cobra.Command{
 	Use:   "sim",
 	Short: "simulate items & settings",
@@ -20,6 +28,11 @@ var simCmd = &cobra.Command{
 func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	simCmd.Flags().StringVar(&reportFile, "report", "", "optional location of a standalone HTML report of the results")
+	simCmd.Flags().StringVar(&summaryFile, "summary", "", "optional location of a compact markdown summary of the results, e.g. for Discord")
+	simCmd.Flags().StringVar(&linkBase, "linkbase", "", "sim page URL to link the settings from the summary, e.g. https://wowsims.github.io/mop/warrior/arms/")
+	simCmd.Flags().StringVar(&aplPreset, "apl", "", "optional name of a bundled APL preset to use for every player, see the apls command")
+	simCmd.Flags().StringVar(&resultCacheFile, "resultcache", "", "optional location of a result cache file, reused and updated so identical requests aren't simmed again")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
 	simCmd.MarkFlagRequired("infile")
 }
@@ -34,6 +47,13 @@ func simMain(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
+	if aplPreset != "" {
+		if err := applyAPLPreset(input.Raid, aplPreset); err != nil {
+			log.Fatalf("failed to apply APL preset: %s", err)
+		}
+	}
+
+	loadResultCache(input)
 
 	var output []byte
 	reporter := make(chan *proto.ProgressMetrics, 10)
@@ -50,6 +70,8 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	saveResultCache()
+
 	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
 	if err != nil {
 		log.Fatalf("failed to marshal final results: %s", err)
@@ -66,4 +88,42 @@ func simMain(cmd *cobra.Command, args []string) {
 			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
 		}
 	}
+
+	if reportFile != "" {
+		file, err := os.Create(reportFile)
+		if err != nil {
+			log.Fatalf("failed to create report file: %s", err)
+		}
+		defer file.Close()
+
+		if err := report.WriteHTML(file, finalResult); err != nil {
+			log.Fatalf("failed to write report: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote report file: `%s` successfully.\n", reportFile)
+		}
+	}
+	if summaryFile != "" {
+		settingsLink := ""
+		if linkBase != "" {
+			hash, err := report.SettingsHash(input)
+			if err != nil {
+				log.Fatalf("failed to encode settings link: %s", err)
+			}
+			settingsLink = linkBase + "#" + hash
+		}
+
+		file, err := os.Create(summaryFile)
+		if err != nil {
+			log.Fatalf("failed to create summary file: %s", err)
+		}
+		defer file.Close()
+
+		if err := report.WriteMarkdown(file, finalResult, settingsLink); err != nil {
+			log.Fatalf("failed to write summary: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote summary file: `%s` successfully.\n", summaryFile)
+		}
+	}
 }
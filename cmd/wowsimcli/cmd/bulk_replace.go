@@ -32,6 +32,7 @@ func init() {
 	bulkCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	bulkCmd.Flags().StringVar(&replacefile, "replacefile", "", "location of replacement items file. Writes a CSV result of the items replaced instead of JSON")
 	bulkCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
+	bulkCmd.Flags().StringVar(&resultCacheFile, "resultcache", "", "optional location of a result cache file, reused and updated so identical combos aren't simmed again")
 	bulkCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
 	bulkCmd.MarkFlagRequired("infile")
 	bulkCmd.MarkFlagRequired("replacefile")
@@ -49,7 +50,9 @@ func bulkSimMain(cmd *cobra.Command, args []string) {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
 
+	loadResultCache(input)
 	output := BulkSim(input, replacefile, verbose)
+	saveResultCache()
 
 	if outfile == "" {
 		print(string(output))
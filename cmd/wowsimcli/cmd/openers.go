@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+// Upper bound on the number of openers simmed by one run.
+const maxOpeners = 20000
+
+var (
+	openerSpells     []int32
+	openerGCDs       int
+	openerTop        int
+	openerIterations int32
+	openerSeed       int64
+)
+
+var openersCmd = &cobra.Command{
+	Use:   "openers",
+	Short: "find the best openers by simming every order of the given spells",
+	Long: "Sims every sequence of --gcds casts drawn from --spells, each placed in front of the player's APL, " +
+		"and prints the openers with the highest dps. All sims use the same random seed, so the openers are " +
+		"compared on equal rolls. Each opener is simmed from the pull.",
+	RunE: openersMain,
+}
+
+func init() {
+	openersCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	openersCmd.Flags().Int32SliceVar(&openerSpells, "spells", nil, "spell IDs of the candidate opener casts")
+	openersCmd.Flags().IntVar(&openerGCDs, "gcds", 3, "number of casts in each opener")
+	openersCmd.Flags().IntVar(&openerTop, "top", 10, "number of openers to print")
+	openersCmd.Flags().Int32Var(&openerIterations, "iterations", 100, "iterations per opener")
+	openersCmd.Flags().Int64Var(&openerSeed, "seed", 1, "random seed shared by all openers")
+	openersCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	openersCmd.MarkFlagRequired("spells")
+}
+
+type openerResult struct {
+	opener []int32
+	dps    float64
+}
+
+func openersMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+
+	if len(input.GetRaid().GetParties()) == 0 || len(input.Raid.Parties[0].Players) == 0 {
+		return errors.New("input has no player")
+	}
+	if input.Raid.Parties[0].Players[0].GetRotation().GetType() != proto.APLRotation_TypeAPL {
+		return errors.New("the player needs an APL rotation to place openers in front of")
+	}
+	if len(openerSpells) == 0 || openerGCDs <= 0 {
+		return errors.New("at least one candidate spell and gcd are required")
+	}
+	if numOpeners := math.Pow(float64(len(openerSpells)), float64(openerGCDs)); numOpeners > maxOpeners {
+		return fmt.Errorf("%d spells over %d gcds are %.0f openers, more than the maximum of %d", len(openerSpells), openerGCDs, numOpeners, maxOpeners)
+	}
+
+	if input.SimOptions == nil {
+		input.SimOptions = &proto.SimOptions{}
+	}
+	input.SimOptions.Iterations = openerIterations
+	input.SimOptions.RandomSeed = openerSeed
+	input.SimOptions.Debug = false
+	input.SimOptions.DebugFirstIteration = false
+
+	openers := enumerateOpeners(openerSpells, openerGCDs)
+	results, err := simOpeners(input, openers)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].dps > results[j].dps
+	})
+
+	for rank, result := range results[:min(len(results), openerTop)] {
+		spellIDs := make([]string, len(result.opener))
+		for i, spellID := range result.opener {
+			spellIDs[i] = strconv.Itoa(int(spellID))
+		}
+		fmt.Printf("%3d. %10.1f dps  %s\n", rank+1, result.dps, strings.Join(spellIDs, " > "))
+	}
+	return nil
+}
+
+// Returns every sequence of length casts drawn from candidates, with repetitions.
+func enumerateOpeners(candidates []int32, length int) [][]int32 {
+	openers := [][]int32{{}}
+	for i := 0; i < length; i++ {
+		next := make([][]int32, 0, len(openers)*len(candidates))
+		for _, opener := range openers {
+			for _, spellID := range candidates {
+				next = append(next, append(append(make([]int32, 0, length), opener...), spellID))
+			}
+		}
+		openers = next
+	}
+	return openers
+}
+
+func simOpeners(input *proto.RaidSimRequest, openers [][]int32) ([]openerResult, error) {
+	results := make([]openerResult, len(openers))
+	errs := make([]error, len(openers))
+
+	var wg sync.WaitGroup
+	indices := make(chan int)
+	for worker := 0; worker < runtime.NumCPU(); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				result := core.RunRaidSim(withOpener(input, openers[idx]))
+				if result.Error != nil {
+					errs[idx] = fmt.Errorf("opener %v failed: %s", openers[idx], result.Error.Message)
+					continue
+				}
+				results[idx] = openerResult{
+					opener: openers[idx],
+					dps:    result.RaidMetrics.Dps.Avg,
+				}
+				if verbose {
+					fmt.Printf("Simmed opener %v: %0.1f dps\n", openers[idx], results[idx].dps)
+				}
+			}
+		}()
+	}
+
+	for idx := range openers {
+		indices <- idx
+	}
+	close(indices)
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// Returns a copy of the request with the opener as a sequence at the top of the player's APL.
+func withOpener(input *proto.RaidSimRequest, opener []int32) *proto.RaidSimRequest {
+	request := goproto.Clone(input).(*proto.RaidSimRequest)
+	rotation := request.Raid.Parties[0].Players[0].Rotation
+
+	actions := make([]*proto.APLAction, len(opener))
+	for i, spellID := range opener {
+		actions[i] = &proto.APLAction{
+			Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+				SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: spellID}},
+			}},
+		}
+	}
+
+	rotation.PriorityList = append([]*proto.APLListItem{{
+		Action: &proto.APLAction{
+			Action: &proto.APLAction_Sequence{Sequence: &proto.APLActionSequence{
+				Name:    "Opener",
+				Actions: actions,
+			}},
+		},
+	}}, rotation.PriorityList...)
+
+	return request
+}
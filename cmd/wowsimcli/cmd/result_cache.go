@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+var resultCacheFile string
+
+// Enables the result cache for the request and loads previously exported
+// results, if a result cache file was given.
+func loadResultCache(input *proto.RaidSimRequest) {
+	if resultCacheFile == "" {
+		return
+	}
+	if input.SimOptions == nil {
+		input.SimOptions = &proto.SimOptions{}
+	}
+	input.SimOptions.UseResultCache = true
+
+	if err := core.SharedResultCache.ReadFile(resultCacheFile); err != nil {
+		log.Printf("ignoring result cache: %s", err)
+	}
+}
+
+func saveResultCache() {
+	if resultCacheFile == "" {
+		return
+	}
+	if err := core.SharedResultCache.WriteFile(resultCacheFile); err != nil {
+		log.Fatalf("failed to write result cache: %s", err)
+	}
+	if verbose {
+		log.Printf("Wrote %d cached results to `%s`.", core.SharedResultCache.Len(), resultCacheFile)
+	}
+}
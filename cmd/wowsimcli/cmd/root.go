@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
 )
 
 var rootCmd = &cobra.Command{
@@ -14,10 +15,16 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute(version string) {
+	if version != "" {
+		core.SimVersion = version
+	}
 	rootCmd.AddCommand(newVersionCommand(version))
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(openersCmd)
+	rootCmd.AddCommand(aplsCmd)
+	rootCmd.AddCommand(simcImportCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
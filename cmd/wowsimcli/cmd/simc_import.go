@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/simc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var simcSpellsFile string
+
+var simcImportCmd = &cobra.Command{
+	Use:   "simcimport",
+	Short: "convert a SimulationCraft action list into an APL",
+	Long: "Converts the action lists of a SimulationCraft profile into an APL rotation in protojson format. " +
+		"Spells are referenced by name in SimulationCraft, so --spells maps each name to its spell ID, e.g. {\"tigers_fury\": 5217}. " +
+		"Actions which can't be converted are skipped and reported on stderr.",
+	RunE: simcImportMain,
+}
+
+func init() {
+	simcImportCmd.Flags().StringVar(&infile, "infile", "profile.simc", "location of the SimulationCraft profile")
+	simcImportCmd.Flags().StringVar(&simcSpellsFile, "spells", "", "location of a json object mapping SimulationCraft spell names to spell IDs")
+	simcImportCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	simcImportCmd.MarkFlagRequired("spells")
+}
+
+func simcImportMain(cmd *cobra.Command, args []string) error {
+	profile, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load profile %q: %w", infile, err)
+	}
+
+	spellsData, err := os.ReadFile(simcSpellsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load spells file %q: %w", simcSpellsFile, err)
+	}
+	var spellIds map[string]int32
+	if err := json.Unmarshal(spellsData, &spellIds); err != nil {
+		return fmt.Errorf("failed to parse spells file: %w", err)
+	}
+	spells := make(map[string]*proto.ActionID, len(spellIds))
+	for name, spellId := range spellIds {
+		spells[name] = &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: spellId}}
+	}
+
+	rotation, warnings := simc.ImportAPL(string(profile), spells)
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	output, err := protojson.MarshalOptions{Multiline: true}.Marshal(rotation)
+	if err != nil {
+		return err
+	}
+	if outfile == "" {
+		fmt.Println(string(output))
+		return nil
+	}
+	return os.WriteFile(outfile, output, 0666)
+}
@@ -0,0 +1,24 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Every bundled preset must parse, and every spec with a UI must offer a default.
+func TestAPLPresetsBundled(t *testing.T) {
+	RegisterAll()
+
+	for _, spec := range []proto.Spec{proto.Spec_SpecFeralDruid, proto.Spec_SpecFuryWarrior, proto.Spec_SpecShadowPriest} {
+		result := core.APLPresets(&proto.APLPresetsRequest{Spec: spec})
+		if result.ErrorResult != "" {
+			t.Errorf("%s: %s", spec, result.ErrorResult)
+			continue
+		}
+		if core.GetAPLPreset(spec, "default") == nil {
+			t.Errorf("%s: no default APL preset", spec)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package mop
 import (
 	"time"
 
+	"github.com/wowsims/mop/sim/common/shared"
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
@@ -12,54 +13,47 @@ func init() {
 
 	// Permanently enchants a melee weapon to sometimes increase your critical strike, haste, or mastery by 1500
 	// for 12s when dealing damage or healing with spells and melee attacks.
-	core.NewEnchantEffect(4441, func(agent core.Agent, _ proto.ItemLevelState) {
-		character := agent.GetCharacter()
-		duration := time.Second * 12
+	shared.NewWeaponEnchantEffect(shared.WeaponEnchantEffect{
+		Name:      "Windsong",
+		EnchantID: 4441,
+		SpellID:   104561,
+		Callback:  core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt | core.CallbackOnHealDealt | core.CallbackOnPeriodicHealDealt,
+		ProcMask:  core.ProcMaskDirect | core.ProcMaskProc,
+		Rppm: core.RPPMConfig{
+			PPM: 2.2,
+		},
+		Setup: func(character *core.Character) shared.WeaponEnchantProcHandler {
+			duration := time.Second * 12
 
-		haste := character.NewTemporaryStatsAura(
-			"Windsong - Haste",
-			core.ActionID{SpellID: 104423},
-			stats.Stats{stats.HasteRating: 1500},
-			duration,
-		)
-		crit := character.NewTemporaryStatsAura(
-			"Windsong - Crit",
-			core.ActionID{SpellID: 104509},
-			stats.Stats{stats.CritRating: 1500},
-			duration,
-		)
-		mastery := character.NewTemporaryStatsAura(
-			"Windsong - Mastery",
-			core.ActionID{SpellID: 104510},
-			stats.Stats{stats.MasteryRating: 1500},
-			duration,
-		)
+			haste := character.NewTemporaryStatsAura(
+				"Windsong - Haste",
+				core.ActionID{SpellID: 104423},
+				stats.Stats{stats.HasteRating: 1500},
+				duration,
+			)
+			crit := character.NewTemporaryStatsAura(
+				"Windsong - Crit",
+				core.ActionID{SpellID: 104509},
+				stats.Stats{stats.CritRating: 1500},
+				duration,
+			)
+			mastery := character.NewTemporaryStatsAura(
+				"Windsong - Mastery",
+				core.ActionID{SpellID: 104510},
+				stats.Stats{stats.MasteryRating: 1500},
+				duration,
+			)
 
-		auras := []*core.StatBuffAura{haste, crit, mastery}
+			auras := []*core.StatBuffAura{haste, crit, mastery}
+			for _, aura := range auras {
+				character.AddStatProcBuff(4441, aura, true, core.AllWeaponSlots())
+			}
 
-		core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
-			Name:     "Enchant Weapon - Windsong",
-			Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt | core.CallbackOnHealDealt | core.CallbackOnPeriodicHealDealt,
-			Harmful:  true,
-			ActionID: core.ActionID{SpellID: 104561},
-			DPM: character.NewRPPMProcManager(
-				4441,
-				true,
-				core.ProcMaskDirect|core.ProcMaskProc,
-				core.RPPMConfig{
-					PPM: 2.2,
-				},
-			),
-			Outcome: core.OutcomeLanded,
-			Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			return func(sim *core.Simulation, _ proto.ItemSlot, _ *core.Spell, _ *core.SpellResult) {
 				aura := auras[int32(sim.RollWithLabel(0, 3, "Windsong Proc"))]
 				aura.Activate(sim)
-			},
-		})
-
-		for _, aura := range auras {
-			character.AddStatProcBuff(4441, aura, true, core.AllWeaponSlots())
-		}
+			}
+		},
 	})
 
 	// Permanently enchants a melee weapon to sometimes increase your Intellect by 0 when healing or dealing
@@ -116,55 +110,48 @@ func init() {
 	// Permanently enchants a melee weapon to sometimes increase your Strength or Agility by 0 when dealing melee
 	// damage. Your highest stat is always chosen.
 	newDancingSteelEnchant := func(name string, effectId int32, procEffectId int32, agiEffectId int32, strEffectId int32) {
-		core.NewEnchantEffect(effectId, func(agent core.Agent, _ proto.ItemLevelState) {
-			character := agent.GetCharacter()
-			duration := time.Second * 12
-
-			createDancingSteelAuras := func(tag int32) map[stats.Stat]*core.StatBuffAura {
-				labelSuffix := core.Ternary(tag == 1, " Main Hand", " (Off Hand)")
-				slot := core.Ternary(tag == 1, proto.ItemSlot_ItemSlotMainHand, proto.ItemSlot_ItemSlotOffHand)
-				auras := make(map[stats.Stat]*core.StatBuffAura, 2)
-				auras[stats.Agility] = character.NewTemporaryStatsAura(
-					name+" - Agility"+labelSuffix,
-					core.ActionID{SpellID: agiEffectId}.WithTag(tag),
-					stats.Stats{stats.Agility: 1650},
-					duration,
-				)
-				auras[stats.Strength] = character.NewTemporaryStatsAura(
-					name+" - Strength"+labelSuffix,
-					core.ActionID{SpellID: strEffectId}.WithTag(tag),
-					stats.Stats{stats.Strength: 1650},
-					duration,
-				)
-				for _, aura := range auras {
-					character.AddStatProcBuff(effectId, aura, true, []proto.ItemSlot{slot})
-					character.AddStatProcBuff(effectId, aura, true, []proto.ItemSlot{slot})
+		shared.NewWeaponEnchantEffect(shared.WeaponEnchantEffect{
+			Name:      name,
+			EnchantID: effectId,
+			SpellID:   procEffectId,
+			Callback:  core.CallbackOnSpellHitDealt,
+			ProcMask:  core.ProcMaskMelee | core.ProcMaskMeleeProc,
+			Rppm: core.RPPMConfig{
+				PPM: 2.53,
+			},
+			Setup: func(character *core.Character) shared.WeaponEnchantProcHandler {
+				duration := time.Second * 12
+
+				createDancingSteelAuras := func(tag int32) map[stats.Stat]*core.StatBuffAura {
+					labelSuffix := core.Ternary(tag == 1, " Main Hand", " (Off Hand)")
+					slot := core.Ternary(tag == 1, proto.ItemSlot_ItemSlotMainHand, proto.ItemSlot_ItemSlotOffHand)
+					auras := make(map[stats.Stat]*core.StatBuffAura, 2)
+					auras[stats.Agility] = character.NewTemporaryStatsAura(
+						name+" - Agility"+labelSuffix,
+						core.ActionID{SpellID: agiEffectId}.WithTag(tag),
+						stats.Stats{stats.Agility: 1650},
+						duration,
+					)
+					auras[stats.Strength] = character.NewTemporaryStatsAura(
+						name+" - Strength"+labelSuffix,
+						core.ActionID{SpellID: strEffectId}.WithTag(tag),
+						stats.Stats{stats.Strength: 1650},
+						duration,
+					)
+					for _, aura := range auras {
+						character.AddStatProcBuff(effectId, aura, true, []proto.ItemSlot{slot})
+					}
+					return auras
 				}
-				return auras
-			}
 
-			mhAuras := createDancingSteelAuras(1)
-			ohAuras := createDancingSteelAuras(2)
-
-			core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
-				Name:     "Enchant Weapon - " + name,
-				Callback: core.CallbackOnSpellHitDealt,
-				Harmful:  true,
-				ActionID: core.ActionID{SpellID: procEffectId},
-				DPM: character.NewRPPMProcManager(
-					effectId,
-					true,
-					core.ProcMaskMelee|core.ProcMaskMeleeProc,
-					core.RPPMConfig{
-						PPM: 2.53,
-					},
-				),
-				Outcome: core.OutcomeLanded,
-				Handler: func(sim *core.Simulation, spell *core.Spell, _ *core.SpellResult) {
-					core.Ternary(spell.IsOH(), ohAuras, mhAuras)[character.GetHighestStatType([]stats.Stat{stats.Strength, stats.Agility})].Activate(sim)
-				},
-			})
+				mhAuras := createDancingSteelAuras(1)
+				ohAuras := createDancingSteelAuras(2)
 
+				return func(sim *core.Simulation, slot proto.ItemSlot, _ *core.Spell, _ *core.SpellResult) {
+					auras := core.Ternary(slot == proto.ItemSlot_ItemSlotOffHand, ohAuras, mhAuras)
+					auras[character.GetHighestStatType([]stats.Stat{stats.Strength, stats.Agility})].Activate(sim)
+				}
+			},
 		})
 	}
 
@@ -244,44 +231,37 @@ func init() {
 
 	// Permanently enchants a melee weapon to sometimes inflict 3000 additional Elemental damage
 	// when dealing damage with spells and melee attacks.
-	core.NewEnchantEffect(4443, func(agent core.Agent, _ proto.ItemLevelState) {
-		character := agent.GetCharacter()
-
-		elementalForceSpell := character.RegisterSpell(core.SpellConfig{
-			ActionID:    core.ActionID{SpellID: 116616},
-			SpellSchool: core.SpellSchoolElemental,
-			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
-			ProcMask:    core.ProcMaskEmpty,
-
-			DamageMultiplier: 1,
-			CritMultiplier:   character.DefaultCritMultiplier(),
-			ThreatMultiplier: 1,
-
-			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				baseDamage := sim.Roll(2775, 2775+450)
-				spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
-			},
-		})
+	shared.NewWeaponEnchantEffect(shared.WeaponEnchantEffect{
+		Name:      "Elemental Force",
+		EnchantID: 4443,
+		SpellID:   104428,
+		Callback:  core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
+		ProcMask:  core.ProcMaskDirect | core.ProcMaskProc,
+		Rppm: core.RPPMConfig{
+			PPM:         9.17,
+			Coefficient: 1.0,
+		}.WithHasteMod(),
+		Setup: func(character *core.Character) shared.WeaponEnchantProcHandler {
+			elementalForceSpell := character.RegisterSpell(core.SpellConfig{
+				ActionID:    core.ActionID{SpellID: 116616},
+				SpellSchool: core.SpellSchoolElemental,
+				Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+				ProcMask:    core.ProcMaskEmpty,
+
+				DamageMultiplier: 1,
+				CritMultiplier:   character.DefaultCritMultiplier(),
+				ThreatMultiplier: 1,
+
+				ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+					baseDamage := sim.Roll(2775, 2775+450)
+					spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+				},
+			})
 
-		core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
-			Name:     "Enchant Weapon - Elemental Force",
-			Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
-			Harmful:  true,
-			ActionID: core.ActionID{SpellID: 104428},
-			DPM: character.NewRPPMProcManager(
-				4443,
-				true,
-				core.ProcMaskDirect|core.ProcMaskProc,
-				core.RPPMConfig{
-					PPM:         9.17,
-					Coefficient: 1.0,
-				}.WithHasteMod(),
-			),
-			Outcome: core.OutcomeLanded,
-			Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			return func(sim *core.Simulation, _ proto.ItemSlot, _ *core.Spell, result *core.SpellResult) {
 				elementalForceSpell.Cast(sim, result.Target)
-			},
-		})
+			}
+		},
 	})
 
 	// Synapse Springs
@@ -0,0 +1,20 @@
+package mop
+
+// This file is auto generated from database.ItemEffectRegistry
+// Changes will be overwritten on next database generation
+
+import (
+	"github.com/wowsims/mop/sim/common/shared"
+	"github.com/wowsims/mop/sim/core"
+)
+
+func init() {
+	shared.NewProcStatBonusEffect(shared.ProcStatBonusEffect{
+		Name:     "Relic of Xuen - Strength",
+		ItemID:   79327,
+		Callback: core.CallbackOnSpellHitDealt,
+		ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskMeleeProc,
+		Outcome:  core.OutcomeLanded,
+		Harmful:  true,
+	})
+}
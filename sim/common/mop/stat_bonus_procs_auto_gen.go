@@ -256,17 +256,6 @@ func RegisterAllProcs() {
 	//	Harmful:  true
 	// })
 	
-	// Your melee attacks have a chance to grant Blessing of the Celestials, increasing your Strength by 3027
-	// for 15s. ( 20% chance, 55 sec cooldown)
-	shared.NewProcStatBonusEffect(shared.ProcStatBonusEffect{
-		Name:     "Relic of Xuen - Strength",
-		ItemID:   79327,
-		Callback: core.CallbackOnSpellHitDealt,
-		ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskMeleeProc,
-		Outcome:  core.OutcomeLanded,
-		Harmful:  true,
-	})
-	
 	// When you deliver a melee or ranged critical strike, you have a chance to gain Blessing of the Celestials,
 	// increasing your Agility by 3027 for 15s.
 	shared.NewProcStatBonusEffect(shared.ProcStatBonusEffect{
@@ -0,0 +1,29 @@
+package shared
+
+import (
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Items at or below this base item level are not given effects.
+const MinEffectIlvl = 416
+
+// Raid tiers by base item level, used to group item effect coverage reports.
+var itemEffectTiers = []struct {
+	maxIlvl int32
+	label   string
+}{
+	{463, "Pre-Raid"},
+	{509, "Tier 14"},
+	{535, "Tier 15"},
+	{core.MaxIlvl, "Tier 16"},
+}
+
+// Returns the raid tier label an item of the given base item level belongs to.
+func ItemEffectTierForIlvl(ilvl int32) string {
+	for _, tier := range itemEffectTiers {
+		if ilvl <= tier.maxIlvl {
+			return tier.label
+		}
+	}
+	return itemEffectTiers[len(itemEffectTiers)-1].label
+}
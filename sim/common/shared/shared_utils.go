@@ -206,6 +206,7 @@ func factory_StatBonusEffect(config ProcStatBonusEffect, extraSpell func(agent c
 
 		if proc.IcdMs != 0 {
 			procAura.Icd = triggerAura.Icd
+			triggerAura.SetIcdMetricsAura(procAura.Aura)
 		}
 		if isEnchant {
 			character.ItemSwap.RegisterEnchantProcWithSlots(effectID, triggerAura, eligibleSlots)
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type WeaponEnchantProcHandler func(sim *core.Simulation, slot proto.ItemSlot, spell *core.Spell, result *core.SpellResult)
+
+// WeaponEnchantEffect describes an RPPM weapon enchant.
+//
+// Every weapon carrying the enchant gets its own RPPM tracker. Each hit rolls one
+// of them: melee attacks the weapon that made the attack, spells and procs the
+// first enchanted weapon. Uptime is reported through the metrics of the auras the
+// handler activates.
+type WeaponEnchantEffect struct {
+	Name      string
+	EnchantID int32
+	SpellID   int32 // Proc trigger spell
+	Callback  core.AuraCallback
+	ProcMask  core.ProcMask
+	Rppm      core.RPPMConfig
+
+	// Called once per character to register auras/spells, returns the handler that
+	// is called with the weapon whose roll succeeded.
+	Setup func(character *core.Character) WeaponEnchantProcHandler
+}
+
+func NewWeaponEnchantEffect(config WeaponEnchantEffect) {
+	core.NewEnchantEffect(config.EnchantID, func(agent core.Agent, _ proto.ItemLevelState) {
+		character := agent.GetCharacter()
+		handler := config.Setup(character)
+		dpm := character.NewRPPMProcManager(config.EnchantID, true, config.ProcMask, config.Rppm)
+		triggerName := "Enchant Weapon - " + config.Name
+
+		triggerAura := core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+			Name:     triggerName,
+			Callback: config.Callback,
+			Harmful:  true,
+			ActionID: core.ActionID{SpellID: config.SpellID},
+			Outcome:  core.OutcomeLanded,
+			Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+				if slot, procced := dpm.ProcSlot(sim, spell.ProcMask, triggerName); procced {
+					handler(sim, slot, spell, result)
+				}
+			},
+		})
+		triggerAura.Dpm = dpm
+
+		character.ItemSwap.RegisterEnchantProc(config.EnchantID, triggerAura)
+	})
+}
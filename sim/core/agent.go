@@ -1,6 +1,7 @@
 package core
 
 import (
+	"cmp"
 	"reflect"
 	"strconv"
 	"strings"
@@ -104,6 +105,16 @@ func (actionID ActionID) String() string {
 	return sb.String()
 }
 
+// Orders action IDs by spell, item and other ID, then tag, e.g. for stable metrics output.
+func (actionID ActionID) Compare(other ActionID) int {
+	return cmp.Or(
+		cmp.Compare(actionID.SpellID, other.SpellID),
+		cmp.Compare(actionID.ItemID, other.ItemID),
+		cmp.Compare(actionID.OtherID, other.OtherID),
+		cmp.Compare(actionID.Tag, other.Tag),
+	)
+}
+
 // Returns a new ActionID with the corresponding Tag value.
 func (actionID ActionID) WithTag(tag int32) ActionID {
 	newID := actionID
@@ -140,6 +151,24 @@ func ProtoToActionID(protoID *proto.ActionID) ActionID {
 	}
 }
 
+// Set of action IDs, where an ID without a tag also contains all tagged versions of it.
+type actionIDSet map[ActionID]bool
+
+func protoToActionIDSet(protoIDs []*proto.ActionID) actionIDSet {
+	if len(protoIDs) == 0 {
+		return nil
+	}
+	set := make(actionIDSet, len(protoIDs))
+	for _, protoID := range protoIDs {
+		set[ProtoToActionID(protoID)] = true
+	}
+	return set
+}
+
+func (set actionIDSet) contains(actionID ActionID) bool {
+	return set[actionID] || set[actionID.WithTag(0)]
+}
+
 type AgentFactory func(*Character, *proto.Player) Agent
 type SpecSetter func(*proto.Player, interface{})
 
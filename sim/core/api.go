@@ -87,7 +87,7 @@ func RunRaidSimAsync(request *proto.RaidSimRequest, progress chan *proto.Progres
 
 // Threading does not work in WASM!
 func RunRaidSimConcurrent(request *proto.RaidSimRequest) *proto.RaidSimResult {
-	return runSimConcurrent(request, nil, simsignals.CreateSignals())
+	return SharedResultCache.wrap(runSimConcurrentRunner)(request, nil, false, simsignals.CreateSignals())
 }
 
 // Threading does not work in WASM!
@@ -105,7 +105,7 @@ func RunRaidSimConcurrentAsync(request *proto.RaidSimRequest, progress chan *pro
 	}
 	go func() {
 		defer simsignals.UnregisterId(requestId)
-		runSimConcurrent(request, progress, signals)
+		SharedResultCache.wrap(runSimConcurrentRunner)(request, progress, false, signals)
 	}()
 }
 
@@ -131,6 +131,50 @@ func RunBulkSimAsync(request *proto.BulkSimRequest, progress chan *proto.Progres
 	}()
 }
 
+func RunGearProgression(request *proto.GearProgressionRequest) *proto.GearProgressionResult {
+	return GearProgression(simsignals.CreateSignals(), request, nil)
+}
+
+func RunGearProgressionAsync(request *proto.GearProgressionRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalGearProgressionResult: &proto.GearProgressionResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		GearProgression(signals, request, progress)
+	}()
+}
+
+func RunSeedSensitivity(request *proto.SeedSensitivityRequest) *proto.SeedSensitivityResult {
+	return SeedSensitivity(simsignals.CreateSignals(), request, nil)
+}
+
+func RunSeedSensitivityAsync(request *proto.SeedSensitivityRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalSeedSensitivityResult: &proto.SeedSensitivityResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		SeedSensitivity(signals, request, progress)
+	}()
+}
+
 var runningInWasm = false
 
 func SetRunningInWasm() {
@@ -13,6 +13,12 @@ type APLRotation struct {
 	prepullActions []*APLAction
 	priorityList   []*APLAction
 
+	// Lists replacing priorityList while their phase is active, and the ones
+	// among them which have a condition.
+	phaseLists       []*aplPhaseList
+	activePhaseLists []*aplPhaseList
+	activePhase      *aplPhaseList
+
 	// Action currently controlling this rotation (only used for certain actions, such as StrictSequence).
 	controllingActions []APLActionImpl
 
@@ -23,18 +29,34 @@ type APLRotation struct {
 	// If true, can recast channel when interrupted.
 	allowChannelRecastOnInterrupt bool
 
+	// Consumable actions which are also checked on channel ticks, while the
+	// rest of the rotation waits for the channel to end.
+	channelConsumableActions []*APLAction
+
 	// Used inside of actions/value to determine whether they will occur during the prepull or regular rotation.
 	parsingPrepull bool
 
+	// Variables declared by the rotation, by name. Encounter scoped variables
+	// are shared with the rotations of other units.
+	variables map[string]*aplVariable
+
 	// Used to avoid recursive APL loops.
 	inLoop bool
 
 	// Used to override MCD restrictions within sequences.
 	inSequence bool
 
+	// Earliest time at which an action waiting out its reaction time becomes ready.
+	nextReactionAt time.Duration
+
+	// Chance for each decision to take the second best action, see
+	// getNextActionWithHumanError.
+	humanErrorChance float64
+
 	// Validation warnings that occur during proto parsing.
 	// We return these back to the user for display in the UI.
 	curValidations          []*proto.APLValidation
+	variableValidations     []*proto.APLValidation
 	prepullValidations      [][]*proto.APLValidation
 	priorityListValidations [][]*proto.APLValidation
 	uuidValidations         map[*proto.UUID][]*proto.APLValidation
@@ -97,8 +119,14 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		prepullValidations:      make([][]*proto.APLValidation, len(config.PrepullActions)),
 		priorityListValidations: make([][]*proto.APLValidation, len(config.PriorityList)),
 		uuidValidations:         make(map[*proto.UUID][]*proto.APLValidation),
+		humanErrorChance:        config.HumanErrorChance,
 	}
 
+	// Declared first, since actions and values need the types of the variables they use.
+	rotation.doAndRecordWarnings(&rotation.variableValidations, false, func() {
+		rotation.declareVariables(config.Variables)
+	})
+
 	// Parse prepull actions
 	for i, prepullItem := range config.PrepullActions {
 		prepullIdx := i // Save to local variable for correct lambda capture behavior
@@ -141,6 +169,15 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		})
 	}
 
+	// Parsed after the main list, which phase lists can fall through to.
+	rotation.newAPLPhaseLists(config.PhaseLists)
+
+	for _, action := range rotation.priorityList {
+		if consumablesAction, ok := action.impl.(*APLActionUseResourceConsumables); ok && consumablesAction.useWhileChanneling {
+			rotation.channelConsumableActions = append(rotation.channelConsumableActions, action)
+		}
+	}
+
 	// Finalize
 	for i, action := range rotation.prepullActions {
 		rotation.doAndRecordWarnings(&rotation.prepullValidations[rotation.prepullIdxMap[i]], true, func() {
@@ -152,6 +189,7 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 			action.Finalize(rotation)
 		})
 	}
+	rotation.finalizePhaseLists()
 
 	agent := unit.Env.GetAgentFromUnit(unit)
 	if agent != nil {
@@ -220,6 +258,8 @@ func (rot *APLRotation) getStats() *proto.APLStats {
 		})
 	}
 
+	phaseListStats := rot.postFinalizePhaseLists()
+
 	uuidValidationsArr := make([]*proto.UUIDValidations, len(rot.uuidValidations))
 	i := 0
 	for uuid, validations := range rot.uuidValidations {
@@ -237,16 +277,23 @@ func (rot *APLRotation) getStats() *proto.APLStats {
 		PriorityList: MapSlice(rot.priorityListValidations, func(validations []*proto.APLValidation) *proto.APLActionStats {
 			return &proto.APLActionStats{Validations: validations}
 		}),
-		UuidValidations: uuidValidationsArr,
+		UuidValidations:     uuidValidationsArr,
+		VariableValidations: rot.variableValidations,
+		PhaseLists:          phaseListStats,
 	}
 }
 
 func (rot *APLRotation) allAPLActions() []*APLAction {
-	if rot == nil || rot.priorityList == nil {
+	if rot == nil || (rot.priorityList == nil && rot.phaseLists == nil) {
 		return []*APLAction{}
 	}
 
-	return Flatten(MapSlice(rot.priorityList, func(action *APLAction) []*APLAction {
+	priorityList := rot.priorityList
+	for _, phase := range rot.phaseLists {
+		priorityList = append(priorityList[:len(priorityList):len(priorityList)], phase.priorityList...)
+	}
+
+	return Flatten(MapSlice(priorityList, func(action *APLAction) []*APLAction {
 		// Check if action is nil before calling GetAllActions
 		if action == nil {
 			return []*APLAction{}
@@ -265,8 +312,11 @@ func (rot *APLRotation) reset(sim *Simulation) {
 	rot.inLoop = false
 	rot.interruptChannelIf = nil
 	rot.allowChannelRecastOnInterrupt = false
+	rot.resetVariables()
+	rot.resetPhaseLists()
 	for _, action := range rot.allAPLActions() {
 		action.impl.Reset(sim)
+		action.readySince = -1
 	}
 }
 
@@ -292,9 +342,10 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 
 	i := 0
 	apl.inLoop = true
+	apl.nextReactionAt = NeverExpires
 
 	apl.unit.UpdatePosition(sim)
-	for nextAction := apl.getNextAction(sim); nextAction != nil; i, nextAction = i+1, apl.getNextAction(sim) {
+	for nextAction := apl.getNextActionWithHumanError(sim); nextAction != nil; i, nextAction = i+1, apl.getNextActionWithHumanError(sim) {
 		if i > 1000 {
 			panic(fmt.Sprintf("[USER_ERROR] Infinite loop detected, current action:\n%s", nextAction))
 		}
@@ -303,13 +354,16 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 	}
 	apl.inLoop = false
 
-	if sim.Log != nil && i == 0 {
-		apl.unit.Log(sim, "No available actions!")
+	if sim.LogEnabled(LogCategoryAI, LogLevelDebug) && i == 0 {
+		apl.unit.LogAt(sim, LogCategoryAI, LogLevelDebug, "No available actions!")
 	}
 
 	// Schedule the next rotation evaluation based on either the GCD or reaction time
 	if apl.unit.RotationTimer.IsReady(sim) {
 		nextEvaluation := sim.CurrentTime + apl.unit.ReactionTime
+		if apl.nextReactionAt > sim.CurrentTime {
+			nextEvaluation = min(nextEvaluation, apl.nextReactionAt)
+		}
 
 		if !apl.unit.Moving {
 			nextEvaluation = max(nextEvaluation, apl.unit.NextGCDAt())
@@ -324,7 +378,7 @@ func (apl *APLRotation) getNextAction(sim *Simulation) *APLAction {
 		return apl.controllingActions[len(apl.controllingActions)-1].GetNextAction(sim)
 	}
 
-	for _, action := range apl.priorityList {
+	for _, action := range apl.currentPriorityList(sim) {
 		if action.IsReady(sim) {
 			return action
 		}
@@ -363,6 +417,15 @@ func (apl *APLRotation) shouldInterruptChannel(sim *Simulation) bool {
 	return true
 }
 
+// Uses consumables during a channel, without interrupting it.
+func (apl *APLRotation) useChannelConsumables(sim *Simulation) {
+	for _, action := range apl.channelConsumableActions {
+		if action.IsReady(sim) {
+			action.Execute(sim)
+		}
+	}
+}
+
 func APLRotationFromJsonString(jsonString string) *proto.APLRotation {
 	apl := &proto.APLRotation{}
 	data := []byte(jsonString)
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/wowsims/mop/sim/core/proto"
 )
@@ -9,6 +10,12 @@ import (
 type APLAction struct {
 	condition APLValue
 	impl      APLActionImpl
+
+	// See proto.APLAction.reaction_time_ms.
+	reactionTime time.Duration
+	// When the action was first seen usable since it was last seen unusable, or -1.
+	readySince time.Duration
+	rot        *APLRotation
 }
 
 func (action *APLAction) Finalize(rot *APLRotation) {
@@ -18,8 +25,25 @@ func (action *APLAction) Finalize(rot *APLRotation) {
 	}
 }
 
+// Whether the action can be used, once it was usable for its reaction time.
 func (action *APLAction) IsReady(sim *Simulation) bool {
-	return (action.condition == nil || action.condition.GetBool(sim)) && action.impl.IsReady(sim)
+	if (action.condition != nil && !action.condition.GetBool(sim)) || !action.impl.IsReady(sim) {
+		action.readySince = -1
+		return false
+	}
+	if action.reactionTime == 0 {
+		return true
+	}
+
+	if action.readySince == -1 {
+		action.readySince = sim.CurrentTime
+	}
+	reactAt := action.readySince + action.reactionTime
+	if sim.CurrentTime < reactAt {
+		action.rot.nextReactionAt = min(action.rot.nextReactionAt, reactAt)
+		return false
+	}
+	return true
 }
 
 func (action *APLAction) Execute(sim *Simulation) {
@@ -120,8 +144,16 @@ func (rot *APLRotation) newAPLAction(config *proto.APLAction) *APLAction {
 	}
 
 	action := &APLAction{
-		condition: rot.coerceTo(rot.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool),
-		impl:      rot.newAPLActionImpl(config),
+		condition:  rot.coerceTo(rot.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool),
+		impl:       rot.newAPLActionImpl(config),
+		readySince: -1,
+		rot:        rot,
+	}
+
+	if config.ReactionTimeMs < 0 {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Reaction time can't be negative, using 0")
+	} else if config.ReactionTimeMs > 0 && !rot.parsingPrepull {
+		action.reactionTime = time.Millisecond * time.Duration(config.ReactionTimeMs)
 	}
 
 	if action.impl == nil {
@@ -159,6 +191,8 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 		return rot.newActionCastAllStatBuffCooldowns(config.GetCastAllStatBuffCooldowns())
 	case *proto.APLAction_AutocastOtherCooldowns:
 		return rot.newActionAutocastOtherCooldowns(config.GetAutocastOtherCooldowns())
+	case *proto.APLAction_UseResourceConsumables:
+		return rot.newActionUseResourceConsumables(config.GetUseResourceConsumables())
 
 	// Timing
 	case *proto.APLAction_Wait:
@@ -173,12 +207,16 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 		return rot.newActionSequence(config.GetSequence())
 	case *proto.APLAction_ResetSequence:
 		return rot.newActionResetSequence(config.GetResetSequence())
+	case *proto.APLAction_SetVariable:
+		return rot.newActionSetVariable(config.GetSetVariable())
 	case *proto.APLAction_StrictSequence:
 		return rot.newActionStrictSequence(config.GetStrictSequence())
 
 	// Misc
 	case *proto.APLAction_ChangeTarget:
 		return rot.newActionChangeTarget(config.GetChangeTarget())
+	case *proto.APLAction_SelectTarget:
+		return rot.newActionSelectTarget(config.GetSelectTarget())
 	case *proto.APLAction_ActivateAura:
 		return rot.newActionActivateAura(config.GetActivateAura())
 	case *proto.APLAction_ActivateAuraWithStacks:
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type testAPLValueBool struct {
+	DefaultAPLValueImpl
+	value bool
+}
+
+func (value *testAPLValueBool) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *testAPLValueBool) GetBool(_ *Simulation) bool {
+	return value.value
+}
+func (value *testAPLValueBool) String() string {
+	return "test"
+}
+
+func TestActionReactionTime(t *testing.T) {
+	sim := &Simulation{}
+	rot := &APLRotation{nextReactionAt: NeverExpires}
+	condition := &testAPLValueBool{}
+	impl := &testAPLActionCounter{}
+	action := &APLAction{
+		condition:    condition,
+		impl:         impl,
+		reactionTime: time.Millisecond * 300,
+		readySince:   -1,
+		rot:          rot,
+	}
+
+	if action.IsReady(sim) {
+		t.Fatalf("Expected false condition not to be met")
+	}
+
+	condition.value = true
+	sim.CurrentTime = time.Second
+	if action.IsReady(sim) {
+		t.Fatalf("Expected action to wait for the reaction time")
+	}
+	if rot.nextReactionAt != time.Millisecond*1300 {
+		t.Fatalf("Expected rotation to be evaluated again at 1.3s, got %s", rot.nextReactionAt)
+	}
+
+	sim.CurrentTime = time.Millisecond * 1300
+	if !action.IsReady(sim) {
+		t.Fatalf("Expected action to be ready after the reaction time")
+	}
+
+	condition.value = false
+	action.IsReady(sim)
+	condition.value = true
+	if action.IsReady(sim) {
+		t.Fatalf("Expected reaction time to restart after the condition was false")
+	}
+
+	// Reacting to the action itself becoming ready, e.g. a cooldown or a proc
+	// enabling a spell, takes the same time.
+	sim.CurrentTime = time.Second * 2
+	impl.blocked = true
+	action.IsReady(sim)
+	impl.blocked = false
+	if action.IsReady(sim) {
+		t.Fatalf("Expected reaction time to restart after the action wasn't ready")
+	}
+
+	action.condition = nil
+	sim.CurrentTime = time.Second * 3
+	impl.blocked = true
+	action.IsReady(sim)
+	impl.blocked = false
+	if action.IsReady(sim) {
+		t.Fatalf("Expected actions without a condition to wait for the reaction time")
+	}
+	sim.CurrentTime = time.Millisecond * 3300
+	if !action.IsReady(sim) {
+		t.Fatalf("Expected action without a condition to be ready after the reaction time")
+	}
+}
+
+func TestActionCancelAuraMetrics(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Type: PlayerUnit, auraTracker: newAuraTracker()}
+	aura := unit.RegisterAura(Aura{
+		Label:    "Cancellable",
+		ActionID: ActionID{SpellID: 768},
+		Duration: NeverExpires,
+	})
+	action := &APLActionCancelAura{aura: aura}
+
+	if action.IsReady(sim) {
+		t.Fatalf("Expected cancel to wait for the aura to be active")
+	}
+
+	for range 2 {
+		aura.Activate(sim)
+		if !action.IsReady(sim) {
+			t.Fatalf("Expected cancel to be ready while the aura is active")
+		}
+		action.Execute(sim)
+		if aura.IsActive() {
+			t.Fatalf("Expected aura to be cancelled")
+		}
+	}
+
+	aura.metrics.doneIteration()
+	if cancels := aura.metrics.ToProto().AplCancelsAvg; cancels != 2 {
+		t.Fatalf("Expected 2 cancels per iteration, got %0.2f", cancels)
+	}
+}
+
+type testAPLActionCounter struct {
+	defaultAPLActionImpl
+	executions int
+	blocked    bool
+}
+
+func (action *testAPLActionCounter) IsReady(_ *Simulation) bool { return !action.blocked }
+func (action *testAPLActionCounter) Execute(_ *Simulation)      { action.executions++ }
+func (action *testAPLActionCounter) String() string             { return "counter" }
+
+func TestSequenceResetCondition(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Rotation: &APLRotation{}}
+	first, second := &testAPLActionCounter{}, &testAPLActionCounter{}
+	resetCondition := &testAPLValueBool{}
+	sequence := &APLActionSequence{
+		unit:           unit,
+		subactions:     []*APLAction{{impl: first, readySince: -1}, {impl: second, readySince: -1}},
+		resetCondition: resetCondition,
+	}
+
+	for range 2 {
+		if !sequence.IsReady(sim) {
+			t.Fatalf("Expected sequence to be ready")
+		}
+		sequence.Execute(sim)
+	}
+	if sequence.IsReady(sim) {
+		t.Fatalf("Expected completed sequence not to be ready")
+	}
+
+	resetCondition.value = true
+	if !sequence.IsReady(sim) {
+		t.Fatalf("Expected sequence to restart once the reset condition is met")
+	}
+	sequence.Execute(sim)
+	if first.executions != 2 || second.executions != 1 {
+		t.Fatalf("Expected the first sub-action to run twice and the second once, got %d and %d", first.executions, second.executions)
+	}
+}
+
+type testResourceCost struct {
+	met bool
+}
+
+func (cost *testResourceCost) MeetsRequirement(_ *Simulation, _ *Spell) bool    { return cost.met }
+func (cost *testResourceCost) CostFailureReason(_ *Simulation, _ *Spell) string { return "" }
+func (cost *testResourceCost) SpendCost(_ *Simulation, _ *Spell)                {}
+func (cost *testResourceCost) IssueRefund(_ *Simulation, _ *Spell)              {}
+
+func TestStrictSequenceWaitsForResources(t *testing.T) {
+	sim := &Simulation{}
+	cost := &testResourceCost{}
+	spell := &Spell{
+		CD:   Cooldown{Timer: new(Timer), Duration: time.Second * 10},
+		Cost: &SpellCost{ResourceCostImpl: cost},
+	}
+	sequence := &APLActionStrictSequence{
+		subactions:       []*APLAction{{impl: &APLActionCastSpell{spell: spell}}},
+		waitForResources: true,
+	}
+
+	if !sequence.isWaitingForResources(sim) {
+		t.Fatalf("Expected to wait while only the cost is missing")
+	}
+
+	cost.met = true
+	if sequence.isWaitingForResources(sim) {
+		t.Fatalf("Expected not to wait once the cost is met")
+	}
+
+	cost.met = false
+	spell.CD.Set(time.Second * 5)
+	if sequence.isWaitingForResources(sim) {
+		t.Fatalf("Expected not to wait for a spell on cooldown")
+	}
+}
+
+func TestSelectTargetSwitchMetrics(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	priority := &APLActionSelectTarget{unit: &fa.Unit, policy: proto.APLActionSelectTarget_PriorityIndex}
+	lowestHealth := &APLActionSelectTarget{unit: &fa.Unit, policy: proto.APLActionSelectTarget_LowestHealth}
+	for _, action := range []*APLActionSelectTarget{priority, priority, lowestHealth, priority} {
+		action.nextTarget = target
+		action.Execute(sim)
+	}
+	sim.CurrentTime = -time.Second
+	lowestHealth.Execute(sim)
+
+	fa.Metrics.doneIteration(&fa.Unit, sim)
+	fa.Metrics.doneIteration(&fa.Unit, sim)
+
+	// Map iteration order is random, so check the output order repeatedly.
+	for range 10 {
+		switches := fa.Metrics.ToProto().TargetSwitches
+		if len(switches) != 2 || switches[0].Policy != proto.APLActionSelectTarget_LowestHealth || switches[1].Policy != proto.APLActionSelectTarget_PriorityIndex {
+			t.Fatalf("Expected switches sorted by policy, got %v", switches)
+		}
+		if switches[0].SwitchesAvg != 0.5 || switches[1].SwitchesAvg != 1.5 {
+			t.Fatalf("Expected 0.5 and 1.5 switches per iteration without prepull switches, got %v", switches)
+		}
+	}
+}
+
+func TestCombineTargetSwitchMetrics(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	unit := &proto.UnitMetrics{}
+	rsrc.addTargetSwitchMetrics(unit, &proto.TargetSwitchMetrics{Policy: proto.APLActionSelectTarget_MissingDot, SwitchesAvg: 4}, 0.25)
+	rsrc.addTargetSwitchMetrics(unit, &proto.TargetSwitchMetrics{Policy: proto.APLActionSelectTarget_LowestHealth, SwitchesAvg: 2}, 0.75)
+	rsrc.addTargetSwitchMetrics(unit, &proto.TargetSwitchMetrics{Policy: proto.APLActionSelectTarget_MissingDot, SwitchesAvg: 8}, 0.75)
+
+	if len(unit.TargetSwitches) != 2 {
+		t.Fatalf("Expected switches to be combined per policy, got %v", unit.TargetSwitches)
+	}
+	if missingDot := unit.TargetSwitches[0]; missingDot.Policy != proto.APLActionSelectTarget_MissingDot || missingDot.SwitchesAvg != 7 {
+		t.Fatalf("Expected a weighted average of 7 switches, got %v", missingDot)
+	}
+}
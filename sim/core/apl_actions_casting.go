@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
@@ -11,6 +12,8 @@ type APLActionCastSpell struct {
 	defaultAPLActionImpl
 	spell  *Spell
 	target UnitReference
+
+	castFailures castFailureTracker
 }
 
 func (rot *APLRotation) newActionCastSpell(config *proto.APLActionCastSpell) APLActionImpl {
@@ -27,8 +30,16 @@ func (rot *APLRotation) newActionCastSpell(config *proto.APLActionCastSpell) APL
 		target: target,
 	}
 }
+func (action *APLActionCastSpell) Reset(*Simulation) {
+	action.castFailures.reset()
+}
 func (action *APLActionCastSpell) IsReady(sim *Simulation) bool {
-	return action.spell.CanCastOrQueue(sim, action.target.Get()) && (!action.spell.Flags.Matches(SpellFlagMCD) || action.spell.Flags.Matches(SpellFlagReactive) || action.spell.Unit.GCD.IsReady(sim) || action.spell.Unit.Rotation.inSequence)
+	canCast, reason := action.spell.canCastOrQueue(sim, action.target.Get())
+	action.castFailures.update(sim, action.spell, reason)
+	if !canCast {
+		return false
+	}
+	return !action.spell.Flags.Matches(SpellFlagMCD) || action.spell.Flags.Matches(SpellFlagReactive) || action.spell.Unit.GCD.IsReady(sim) || action.spell.Unit.Rotation.inSequence
 }
 func (action *APLActionCastSpell) Execute(sim *Simulation) {
 	action.spell.CastOrQueue(sim, action.target.Get())
@@ -41,6 +52,8 @@ type APLActionCastFriendlySpell struct {
 	defaultAPLActionImpl
 	spell  *Spell
 	target UnitReference
+
+	castFailures castFailureTracker
 }
 
 func (rot *APLRotation) newActionCastFriendlySpell(config *proto.APLActionCastFriendlySpell) APLActionImpl {
@@ -57,8 +70,16 @@ func (rot *APLRotation) newActionCastFriendlySpell(config *proto.APLActionCastFr
 		target: target,
 	}
 }
+func (action *APLActionCastFriendlySpell) Reset(*Simulation) {
+	action.castFailures.reset()
+}
 func (action *APLActionCastFriendlySpell) IsReady(sim *Simulation) bool {
-	return action.spell.CanCastOrQueue(sim, action.target.Get()) && (!action.spell.Flags.Matches(SpellFlagMCD) || action.spell.Flags.Matches(SpellFlagReactive) || action.spell.Unit.GCD.IsReady(sim) || action.spell.Unit.Rotation.inSequence)
+	canCast, reason := action.spell.canCastOrQueue(sim, action.target.Get())
+	action.castFailures.update(sim, action.spell, reason)
+	if !canCast {
+		return false
+	}
+	return !action.spell.Flags.Matches(SpellFlagMCD) || action.spell.Flags.Matches(SpellFlagReactive) || action.spell.Unit.GCD.IsReady(sim) || action.spell.Unit.Rotation.inSequence
 }
 func (action *APLActionCastFriendlySpell) Execute(sim *Simulation) {
 	action.spell.CastOrQueue(sim, action.target.Get())
@@ -504,4 +525,100 @@ func (action *APLActionAutocastOtherCooldowns) PostFinalize(rot *APLRotation) {
 
 		rot.ValidationMessage(proto.LogLevel_Information, "%s will cast the following spells: %s", action, StringFromActionIDs(actionIDs))
 	}
+
+	for _, mcd := range action.character.initialMajorCooldowns {
+		if mcd.stackingPolicy != proto.CooldownStackingPolicy_CooldownStackingAlignWithAura || mcd.alignAura != nil {
+			continue
+		}
+		if mcd.alignAuraID.IsEmptyAction() {
+			rot.ValidationMessage(proto.LogLevel_Warning, "%s is set to align with an aura, but none is selected. It will be used on cooldown.", mcd.Spell.ActionID)
+		} else {
+			rot.ValidationMessage(proto.LogLevel_Warning, "%s is set to align with %s, which this character doesn't have. It will be used on cooldown.", mcd.Spell.ActionID, mcd.alignAuraID)
+		}
+	}
+}
+
+type resourceConsumable struct {
+	spell          *Spell
+	shouldActivate CooldownActivationCondition
+}
+
+type APLActionUseResourceConsumables struct {
+	defaultAPLActionImpl
+	character *Character
+
+	resourceType       proto.ResourceType
+	useWhileChanneling bool
+
+	consumables    []resourceConsumable
+	nextConsumable *resourceConsumable
+}
+
+func (rot *APLRotation) newActionUseResourceConsumables(config *proto.APLActionUseResourceConsumables) APLActionImpl {
+	unit := rot.unit
+	actionImpl := &APLActionUseResourceConsumables{
+		character:          unit.Env.Raid.GetPlayerFromUnit(unit).GetCharacter(),
+		resourceType:       config.ResourceType,
+		useWhileChanneling: config.UseWhileChanneling,
+	}
+
+	unit.Env.RegisterPostFinalizeEffect(func() {
+		// Like CastAllStatBuffCooldowns, this waits for the rotation to be
+		// finalized so that manually cast consumables aren't picked up.
+		actionImpl.processMajorCooldowns()
+	})
+
+	return actionImpl
+}
+func (action *APLActionUseResourceConsumables) processMajorCooldowns() {
+	for _, mcd := range action.character.initialMajorCooldowns {
+		if slices.Contains(mcd.RestoredResources, action.resourceType) {
+			action.consumables = append(action.consumables, resourceConsumable{
+				spell:          mcd.Spell,
+				shouldActivate: mcd.ShouldActivate,
+			})
+		}
+	}
+
+	action.character.Env.RegisterPostFinalizeEffect(func() {
+		// Removed from the MCD manager so Autocast Other Cooldowns doesn't
+		// use them as well.
+		for _, consumable := range action.consumables {
+			action.character.removeInitialMajorCooldown(consumable.spell.ActionID)
+		}
+	})
+}
+func (action *APLActionUseResourceConsumables) Reset(*Simulation) {
+	action.nextConsumable = nil
+}
+func (action *APLActionUseResourceConsumables) IsReady(sim *Simulation) bool {
+	action.nextConsumable = nil
+	for i := range action.consumables {
+		consumable := &action.consumables[i]
+		if consumable.spell.Flags.Matches(SpellFlagSwapped) || !consumable.spell.CanCast(sim, action.character.CurrentTarget) {
+			continue
+		}
+		if consumable.shouldActivate(sim, action.character) {
+			action.nextConsumable = consumable
+			return true
+		}
+	}
+	return false
+}
+func (action *APLActionUseResourceConsumables) Execute(sim *Simulation) {
+	action.nextConsumable.spell.Cast(sim, action.character.CurrentTarget)
+}
+func (action *APLActionUseResourceConsumables) String() string {
+	return fmt.Sprintf("UseResourceConsumables(%s)", action.resourceType)
+}
+func (action *APLActionUseResourceConsumables) PostFinalize(rot *APLRotation) {
+	if len(action.consumables) == 0 {
+		rot.ValidationMessage(proto.LogLevel_Warning, "%s will not use any consumables! There are either none restoring this resource configured, or all of them are manually cast in the APL.", action)
+	} else {
+		actionIDs := MapSlice(action.consumables, func(consumable resourceConsumable) ActionID {
+			return consumable.spell.ActionID
+		})
+
+		rot.ValidationMessage(proto.LogLevel_Information, "%s will use the following consumables: %s", action, StringFromActionIDs(actionIDs))
+	}
 }
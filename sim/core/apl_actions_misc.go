@@ -34,8 +34,8 @@ func (action *APLActionChangeTarget) IsReady(sim *Simulation) bool {
 	return action.lastExecutedAt != sim.CurrentTime
 }
 func (action *APLActionChangeTarget) Execute(sim *Simulation) {
-	if sim.Log != nil {
-		action.unit.Log(sim, "Changing target to %s", action.newTarget.Get().Label)
+	if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+		action.unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Changing target to %s", action.newTarget.Get().Label)
 	}
 	action.unit.CurrentTarget = action.newTarget.Get()
 	action.lastExecutedAt = sim.CurrentTime
@@ -44,6 +44,113 @@ func (action *APLActionChangeTarget) String() string {
 	return fmt.Sprintf("Change Target(%s)", action.newTarget.Get().Label)
 }
 
+type APLActionSelectTarget struct {
+	defaultAPLActionImpl
+	unit     *Unit
+	policy   proto.APLActionSelectTarget_Policy
+	dotSpell *Spell
+	priority []*Unit
+
+	nextTarget     *Unit
+	lastExecutedAt time.Duration
+}
+
+func (rot *APLRotation) newActionSelectTarget(config *proto.APLActionSelectTarget) APLActionImpl {
+	action := &APLActionSelectTarget{
+		unit:   rot.unit,
+		policy: config.Policy,
+	}
+
+	switch config.Policy {
+	case proto.APLActionSelectTarget_Unknown:
+		return nil
+	case proto.APLActionSelectTarget_MissingDot:
+		action.dotSpell = rot.GetAPLMultidotSpell(config.SpellId)
+		if action.dotSpell == nil {
+			return nil
+		}
+	case proto.APLActionSelectTarget_PriorityIndex:
+		for _, targetIndex := range config.TargetIndices {
+			if targetIndex < 0 || targetIndex >= rot.unit.Env.TotalTargetCount() {
+				rot.ValidationMessage(proto.LogLevel_Warning, "Invalid target index %d in target priority list", targetIndex)
+				continue
+			}
+			action.priority = append(action.priority, rot.unit.Env.GetTargetUnitByIndex(targetIndex))
+		}
+		if len(action.priority) == 0 {
+			rot.ValidationMessage(proto.LogLevel_Warning, "No valid targets in target priority list")
+			return nil
+		}
+	}
+
+	return action
+}
+func (action *APLActionSelectTarget) Reset(sim *Simulation) {
+	action.lastExecutedAt = NeverExpires
+}
+
+// Returns the target chosen by the policy, preferring the current target on ties.
+func (action *APLActionSelectTarget) selectTarget(sim *Simulation) *Unit {
+	current := action.unit.CurrentTarget
+	if current != nil && !current.IsEnabled() {
+		current = nil
+	}
+
+	switch action.policy {
+	case proto.APLActionSelectTarget_LowestHealth, proto.APLActionSelectTarget_HighestHealth:
+		lowest := action.policy == proto.APLActionSelectTarget_LowestHealth
+		best := current
+		for _, target := range sim.Encounter.ActiveTargetUnits {
+			if best == nil {
+				best = target
+				continue
+			}
+			health, bestHealth := sim.Encounter.TargetHealthPercent(target), sim.Encounter.TargetHealthPercent(best)
+			if (lowest && health < bestHealth) || (!lowest && health > bestHealth) {
+				best = target
+			}
+		}
+		return best
+	case proto.APLActionSelectTarget_MissingDot:
+		if current != nil && !action.dotSpell.Dot(current).IsActive() {
+			return current
+		}
+		for _, target := range sim.Encounter.ActiveTargetUnits {
+			if !action.dotSpell.Dot(target).IsActive() {
+				return target
+			}
+		}
+	case proto.APLActionSelectTarget_PriorityIndex:
+		for _, target := range action.priority {
+			if target.IsEnabled() {
+				return target
+			}
+		}
+	}
+	return nil
+}
+func (action *APLActionSelectTarget) IsReady(sim *Simulation) bool {
+	// Prevent infinite loops by only allowing this action to be performed once at each timestamp.
+	if action.lastExecutedAt == sim.CurrentTime {
+		return false
+	}
+	action.nextTarget = action.selectTarget(sim)
+	return action.nextTarget != nil && action.nextTarget != action.unit.CurrentTarget
+}
+func (action *APLActionSelectTarget) Execute(sim *Simulation) {
+	if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+		action.unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Selecting target %s (%s)", action.nextTarget.Label, action.policy)
+	}
+	action.unit.CurrentTarget = action.nextTarget
+	action.lastExecutedAt = sim.CurrentTime
+	if sim.CurrentTime >= 0 {
+		action.unit.Metrics.targetSwitches[action.policy]++
+	}
+}
+func (action *APLActionSelectTarget) String() string {
+	return fmt.Sprintf("Select Target(%s)", action.policy)
+}
+
 type APLActionCancelAura struct {
 	defaultAPLActionImpl
 	aura *Aura
@@ -78,9 +185,10 @@ func (action *APLActionCancelAura) IsReady(sim *Simulation) bool {
 	return action.aura.IsActive()
 }
 func (action *APLActionCancelAura) Execute(sim *Simulation) {
-	if sim.Log != nil {
-		action.aura.Unit.Log(sim, "Cancelling aura %s", action.aura.ActionID)
+	if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+		action.aura.Unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Cancelling aura %s", action.aura.ActionID)
 	}
+	action.aura.metrics.AplCancels++
 	action.aura.Deactivate(sim)
 }
 func (action *APLActionCancelAura) String() string {
@@ -93,14 +201,14 @@ func (action *APLActionActivateAura) IsReady(sim *Simulation) bool {
 
 func (action *APLActionActivateAura) Execute(sim *Simulation) {
 	if !action.IsReady(sim) {
-		if sim.Log != nil {
-			action.aura.Unit.Log(sim, "Could not activate aura %s because it's not ready", action.aura.ActionID)
+		if sim.LogEnabled(LogCategoryAI, LogLevelDebug) {
+			action.aura.Unit.LogAt(sim, LogCategoryAI, LogLevelDebug, "Could not activate aura %s because it's not ready", action.aura.ActionID)
 		}
 		return
 	}
 
-	if sim.Log != nil {
-		action.aura.Unit.Log(sim, "Activating aura %s", action.aura.ActionID)
+	if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+		action.aura.Unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Activating aura %s", action.aura.ActionID)
 	}
 
 	action.aura.Activate(sim)
@@ -138,13 +246,13 @@ func (action *APLActionActivateAuraWithStacks) IsReady(sim *Simulation) bool {
 }
 func (action *APLActionActivateAuraWithStacks) Execute(sim *Simulation) {
 	if !action.IsReady(sim) {
-		if sim.Log != nil {
-			action.aura.Unit.Log(sim, "Could not activate aura %s (%d stacks) because it's not ready", action.aura.ActionID, action.numStacks)
+		if sim.LogEnabled(LogCategoryAI, LogLevelDebug) {
+			action.aura.Unit.LogAt(sim, LogCategoryAI, LogLevelDebug, "Could not activate aura %s (%d stacks) because it's not ready", action.aura.ActionID, action.numStacks)
 		}
 		return
 	}
-	if sim.Log != nil {
-		action.aura.Unit.Log(sim, "Activating aura %s (%d stacks)", action.aura.ActionID, action.numStacks)
+	if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+		action.aura.Unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Activating aura %s (%d stacks)", action.aura.ActionID, action.numStacks)
 	}
 	action.aura.Activate(sim)
 	action.aura.SetStacks(sim, action.numStacks)
@@ -238,8 +346,8 @@ func (action *APLActionTriggerICD) IsReady(sim *Simulation) bool {
 	return action.aura.IsActive()
 }
 func (action *APLActionTriggerICD) Execute(sim *Simulation) {
-	if sim.Log != nil {
-		action.aura.Unit.Log(sim, "Triggering ICD %s", action.aura.ActionID)
+	if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+		action.aura.Unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Triggering ICD %s", action.aura.ActionID)
 	}
 	action.aura.Icd.Use(sim)
 }
@@ -277,12 +385,12 @@ func (action *APLActionItemSwap) IsReady(sim *Simulation) bool {
 }
 func (action *APLActionItemSwap) Execute(sim *Simulation) {
 	if action.character.ItemSwap.swapSet == action.swapSet {
-		if sim.Log != nil {
-			action.character.Log(sim, "Item Swap already set to %s", action.swapSet)
+		if sim.LogEnabled(LogCategoryAI, LogLevelDebug) {
+			action.character.LogAt(sim, LogCategoryAI, LogLevelDebug, "Item Swap already set to %s", action.swapSet)
 		}
 	} else {
-		if sim.Log != nil {
-			action.character.Log(sim, "Item Swap to set %s", action.swapSet)
+		if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+			action.character.LogAt(sim, LogCategoryAI, LogLevelInfo, "Item Swap to set %s", action.swapSet)
 		}
 	}
 
@@ -310,8 +418,8 @@ func (action *APLActionMove) IsReady(sim *Simulation) bool {
 }
 func (action *APLActionMove) Execute(sim *Simulation) {
 	moveRange := action.moveRange.GetFloat(sim)
-	if sim.Log != nil {
-		action.unit.Log(sim, "[DEBUG] Moving to %.1f yards", moveRange)
+	if sim.LogEnabled(LogCategoryAI, LogLevelDebug) {
+		action.unit.LogAt(sim, LogCategoryAI, LogLevelDebug, "[DEBUG] Moving to %.1f yards", moveRange)
 	}
 
 	action.unit.MoveTo(moveRange, sim)
@@ -10,10 +10,11 @@ import (
 
 type APLActionSequence struct {
 	defaultAPLActionImpl
-	unit       *Unit
-	name       string
-	subactions []*APLAction
-	curIdx     int
+	unit           *Unit
+	name           string
+	subactions     []*APLAction
+	resetCondition APLValue
+	curIdx         int
 }
 
 func (rot *APLRotation) newActionSequence(config *proto.APLActionSequence) APLActionImpl {
@@ -26,14 +27,21 @@ func (rot *APLRotation) newActionSequence(config *proto.APLActionSequence) APLAc
 	}
 
 	return &APLActionSequence{
-		unit:       rot.unit,
-		name:       config.Name,
-		subactions: subactions,
+		unit:           rot.unit,
+		name:           config.Name,
+		subactions:     subactions,
+		resetCondition: rot.coerceTo(rot.newAPLValue(config.ResetCondition), proto.APLValueType_ValueTypeBool),
 	}
 }
 func (action *APLActionSequence) GetInnerActions() []*APLAction {
 	return Flatten(MapSlice(action.subactions, func(action *APLAction) []*APLAction { return action.GetAllActions() }))
 }
+func (action *APLActionSequence) GetAPLValues() []APLValue {
+	if action.resetCondition == nil {
+		return nil
+	}
+	return []APLValue{action.resetCondition}
+}
 func (action *APLActionSequence) Finalize(rot *APLRotation) {
 	for _, subaction := range action.subactions {
 		subaction.impl.Finalize(rot)
@@ -48,6 +56,13 @@ func (action *APLActionSequence) Reset(*Simulation) {
 	action.curIdx = 0
 }
 func (action *APLActionSequence) IsReady(sim *Simulation) bool {
+	if action.curIdx != 0 && action.resetCondition != nil && action.resetCondition.GetBool(sim) {
+		if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+			action.unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Resetting sequence '%s'", action.name)
+		}
+		action.curIdx = 0
+	}
+
 	action.unit.Rotation.inSequence = true
 	isReady := (action.curIdx < len(action.subactions)) && action.subactions[action.curIdx].IsReady(sim)
 	action.unit.Rotation.inSequence = false
@@ -114,9 +129,10 @@ func (action *APLActionResetSequence) String() string {
 
 type APLActionStrictSequence struct {
 	defaultAPLActionImpl
-	unit       *Unit
-	subactions []*APLAction
-	curIdx     int
+	unit             *Unit
+	subactions       []*APLAction
+	waitForResources bool
+	curIdx           int
 
 	subactionSpells []*Spell
 }
@@ -131,8 +147,9 @@ func (rot *APLRotation) newActionStrictSequence(config *proto.APLActionStrictSeq
 	}
 
 	return &APLActionStrictSequence{
-		unit:       rot.unit,
-		subactions: subactions,
+		unit:             rot.unit,
+		subactions:       subactions,
+		waitForResources: config.WaitForResources,
 	}
 }
 func (action *APLActionStrictSequence) GetInnerActions() []*APLAction {
@@ -209,6 +226,9 @@ func (action *APLActionStrictSequence) GetNextAction(sim *Simulation) *APLAction
 		}
 
 		return nextAction
+	} else if action.waitForResources && action.isWaitingForResources(sim) {
+		// Return nil to keep control of the rotation until the resources are there.
+		return nil
 	} else if action.unit.GCD.TimeToReady(sim) <= MaxSpellQueueWindow {
 		// If the GCD is ready when the next subaction isn't, it means the sequence is bad
 		// so reset and exit the sequence.
@@ -219,6 +239,25 @@ func (action *APLActionStrictSequence) GetNextAction(sim *Simulation) *APLAction
 		return nil
 	}
 }
+
+// Whether the next sub-action is off cooldown, and only held back by its cost.
+func (action *APLActionStrictSequence) isWaitingForResources(sim *Simulation) bool {
+	spells := action.subactions[action.curIdx].GetAllSpells()
+	if len(spells) == 0 {
+		return false
+	}
+
+	missingResources := false
+	for _, spell := range spells {
+		if !spell.IsReady(sim) {
+			return false
+		}
+		if spell.Cost != nil && !spell.Cost.MeetsRequirement(sim, spell) {
+			missingResources = true
+		}
+	}
+	return missingResources
+}
 func (action *APLActionStrictSequence) String() string {
 	return "Strict Sequence(" + strings.Join(MapSlice(action.subactions, func(subaction *APLAction) string { return fmt.Sprintf("(%s)", subaction) }), "+") + ")"
 }
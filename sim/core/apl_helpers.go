@@ -74,6 +74,7 @@ func (rot *APLRotation) GetTargetUnit(ref *proto.UnitReference) UnitReference {
 }
 
 type AuraReference struct {
+	actionID  ActionID
 	fixedAura *Aura
 
 	targetRef      UnitReference
@@ -90,24 +91,28 @@ func (ar *AuraReference) Get() *Aura {
 	}
 }
 
+// The referenced aura's ID, also when the current unit doesn't have it.
 func (ar *AuraReference) String() string {
-	return ar.Get().ActionID.String()
+	return ar.actionID.String()
 }
 
 func newAuraReferenceHelper(sourceUnit UnitReference, auraId *proto.ActionID, auraGetter func(*Unit, ActionID) *Aura) AuraReference {
+	actionID := ProtoToActionID(auraId)
 	resolvedSourceUnit := sourceUnit.Get()
 	if resolvedSourceUnit == nil {
-		return AuraReference{}
+		return AuraReference{actionID: actionID}
 	} else if sourceUnit.fixedUnit != nil {
 		return AuraReference{
-			fixedAura: auraGetter(sourceUnit.fixedUnit, ProtoToActionID(auraId)),
+			actionID:  actionID,
+			fixedAura: auraGetter(sourceUnit.fixedUnit, actionID),
 		}
 	} else {
 		auras := make([]*Aura, len(resolvedSourceUnit.Env.AllUnits))
 		for _, unit := range resolvedSourceUnit.Env.AllUnits {
-			auras[unit.UnitIndex] = auraGetter(unit, ProtoToActionID(auraId))
+			auras[unit.UnitIndex] = auraGetter(unit, actionID)
 		}
 		return AuraReference{
+			actionID:       actionID,
 			targetRef:      sourceUnit,
 			allTargetAuras: auras,
 		}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// A priority list which replaces the main one while its phase is active, see
+// proto.APLPhaseList.
+type aplPhaseList struct {
+	name         string
+	condition    APLValue
+	priorityList []*APLAction
+
+	// Actions checked while the phase is active, i.e. priorityList followed by
+	// the main priority list if the phase falls through.
+	actions []*APLAction
+
+	validations             []*proto.APLValidation
+	priorityListValidations [][]*proto.APLValidation
+	priorityListIdxMap      []int
+
+	// Values for the current iteration.
+	activeSince time.Duration
+	timeActive  time.Duration
+	switches    int32
+}
+
+func (rot *APLRotation) newAPLPhaseLists(configs []*proto.APLPhaseList) {
+	for i, config := range configs {
+		phase := &aplPhaseList{
+			name:                    config.Name,
+			priorityListValidations: make([][]*proto.APLValidation, len(config.PriorityList)),
+		}
+		if phase.name == "" {
+			phase.name = fmt.Sprintf("Phase %d", i+1)
+		}
+		rot.phaseLists = append(rot.phaseLists, phase)
+
+		rot.doAndRecordWarnings(&phase.validations, false, func() {
+			phase.condition = rot.coerceTo(rot.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool)
+			if phase.condition == nil {
+				rot.ValidationMessage(proto.LogLevel_Warning, "Phase list %s has no condition, so it is never used", phase.name)
+			}
+		})
+
+		for j, aplItem := range config.PriorityList {
+			rot.doAndRecordWarnings(&phase.priorityListValidations[j], false, func() {
+				if !aplItem.Hide {
+					action := rot.newAPLAction(aplItem.Action)
+					if action != nil {
+						phase.priorityList = append(phase.priorityList, action)
+						phase.priorityListIdxMap = append(phase.priorityListIdxMap, j)
+					}
+				}
+			})
+		}
+
+		phase.actions = phase.priorityList
+		if config.FallThrough {
+			phase.actions = append(phase.priorityList[:len(phase.priorityList):len(phase.priorityList)], rot.priorityList...)
+		}
+	}
+
+	// Lists without a condition are only kept for their validations.
+	rot.activePhaseLists = FilterSlice(rot.phaseLists, func(phase *aplPhaseList) bool {
+		return phase.condition != nil
+	})
+}
+
+func (rot *APLRotation) finalizePhaseLists() {
+	for _, phase := range rot.phaseLists {
+		rot.doAndRecordWarnings(&phase.validations, false, func() {
+			for values := []APLValue{phase.condition}; len(values) > 0; {
+				value := values[len(values)-1]
+				values = values[:len(values)-1]
+				if value != nil {
+					value.Finalize(rot)
+					values = append(values, value.GetInnerValues()...)
+				}
+			}
+		})
+		for i, action := range phase.priorityList {
+			rot.doAndRecordWarnings(&phase.priorityListValidations[phase.priorityListIdxMap[i]], false, func() {
+				action.Finalize(rot)
+			})
+		}
+	}
+}
+
+func (rot *APLRotation) postFinalizePhaseLists() []*proto.APLPhaseListStats {
+	return MapSlice(rot.phaseLists, func(phase *aplPhaseList) *proto.APLPhaseListStats {
+		for i, action := range phase.priorityList {
+			rot.doAndRecordWarnings(&phase.priorityListValidations[phase.priorityListIdxMap[i]], false, func() {
+				action.impl.PostFinalize(rot)
+			})
+		}
+		return &proto.APLPhaseListStats{
+			Validations: phase.validations,
+			PriorityList: MapSlice(phase.priorityListValidations, func(validations []*proto.APLValidation) *proto.APLActionStats {
+				return &proto.APLActionStats{Validations: validations}
+			}),
+		}
+	})
+}
+
+// Returns the actions to check, which are those of the first phase list whose
+// condition is true, or the main priority list if there is none.
+func (apl *APLRotation) currentPriorityList(sim *Simulation) []*APLAction {
+	if len(apl.activePhaseLists) == 0 {
+		return apl.priorityList
+	}
+
+	var phase *aplPhaseList
+	for _, phaseList := range apl.activePhaseLists {
+		if phaseList.condition.GetBool(sim) {
+			phase = phaseList
+			break
+		}
+	}
+	apl.switchPhase(sim, phase)
+
+	if phase == nil {
+		return apl.priorityList
+	}
+	return phase.actions
+}
+
+func (apl *APLRotation) switchPhase(sim *Simulation, phase *aplPhaseList) {
+	if phase == apl.activePhase {
+		return
+	}
+
+	if apl.activePhase != nil {
+		apl.activePhase.timeActive += sim.CurrentTime - apl.activePhase.activeSince
+	}
+	if phase != nil {
+		phase.activeSince = sim.CurrentTime
+		phase.switches++
+	}
+
+	if sim.Log != nil {
+		if phase != nil {
+			apl.unit.Log(sim, "Switching to APL phase list %s", phase.name)
+		} else {
+			apl.unit.Log(sim, "Switching to the main APL priority list")
+		}
+	}
+	apl.activePhase = phase
+}
+
+func (apl *APLRotation) resetPhaseLists() {
+	apl.activePhase = nil
+	for _, phase := range apl.phaseLists {
+		phase.timeActive = 0
+		phase.switches = 0
+	}
+}
+
+func (apl *APLRotation) doneIteration(sim *Simulation) {
+	for _, phase := range apl.activePhaseLists {
+		timeActive := phase.timeActive
+		if phase == apl.activePhase {
+			timeActive += sim.CurrentTime - phase.activeSince
+		}
+
+		metrics := apl.unit.Metrics.getAPLPhaseMetrics(phase.name)
+		metrics.timeSum += timeActive.Seconds()
+		metrics.switchesSum += phase.switches
+	}
+}
+
+type aplPhaseMetrics struct {
+	timeSum     float64
+	switchesSum int32
+}
+
+func (unitMetrics *UnitMetrics) getAPLPhaseMetrics(name string) *aplPhaseMetrics {
+	metrics, ok := unitMetrics.aplPhases[name]
+	if !ok {
+		metrics = &aplPhaseMetrics{}
+		unitMetrics.aplPhases[name] = metrics
+	}
+	return metrics
+}
+
+func (metrics *aplPhaseMetrics) ToProto(name string, n float64) *proto.APLPhaseMetrics {
+	return &proto.APLPhaseMetrics{
+		Name:        name,
+		SecondsAvg:  metrics.timeSum / n,
+		SwitchesAvg: float64(metrics.switchesSum) / n,
+	}
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestAPLPhaseListSwitching(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	main := &APLAction{impl: &testAPLActionCounter{}, readySince: -1}
+	execute := &APLAction{impl: &testAPLActionCounter{}, readySince: -1}
+	inExecute := &testAPLValueBool{}
+	phase := &aplPhaseList{
+		name:         "Execute",
+		condition:    inExecute,
+		priorityList: []*APLAction{execute},
+		actions:      []*APLAction{execute},
+	}
+	apl := &APLRotation{
+		unit:             &fa.Unit,
+		priorityList:     []*APLAction{main},
+		phaseLists:       []*aplPhaseList{phase},
+		activePhaseLists: []*aplPhaseList{phase},
+	}
+
+	if apl.getNextAction(sim) != main {
+		t.Fatalf("Expected the main list outside of the phase")
+	}
+
+	sim.CurrentTime = time.Second * 10
+	inExecute.value = true
+	if apl.getNextAction(sim) != execute {
+		t.Fatalf("Expected the phase list during the phase")
+	}
+	apl.getNextAction(sim)
+
+	sim.CurrentTime = time.Second * 15
+	inExecute.value = false
+	if apl.getNextAction(sim) != main {
+		t.Fatalf("Expected the main list after the phase")
+	}
+
+	sim.CurrentTime = time.Second * 20
+	inExecute.value = true
+	apl.getNextAction(sim)
+
+	sim.CurrentTime = time.Second * 22
+	apl.doneIteration(sim)
+
+	metrics := fa.Metrics.getAPLPhaseMetrics("Execute").ToProto("Execute", 1)
+	if metrics.SwitchesAvg != 2 {
+		t.Fatalf("Expected 2 switches to the phase list, got %0.0f", metrics.SwitchesAvg)
+	}
+	if metrics.SecondsAvg != 7 {
+		t.Fatalf("Expected 7s in the phase list, got %0.2f", metrics.SecondsAvg)
+	}
+}
+
+func TestAPLPhaseListFallThrough(t *testing.T) {
+	sim := &Simulation{}
+	notReady := &testAPLValueBool{}
+	main := &APLAction{impl: &testAPLActionCounter{}, readySince: -1}
+	phaseAction := &APLAction{impl: &testAPLActionCounter{}, condition: notReady, readySince: -1}
+
+	apl := &APLRotation{priorityList: []*APLAction{main}}
+	phase := &aplPhaseList{
+		condition:    &testAPLValueBool{value: true},
+		priorityList: []*APLAction{phaseAction},
+	}
+	phase.actions = phase.priorityList
+	apl.phaseLists = []*aplPhaseList{phase}
+	apl.activePhaseLists = apl.phaseLists
+
+	if apl.getNextAction(sim) != nil {
+		t.Fatalf("Expected no action when the phase list doesn't fall through")
+	}
+
+	phase.actions = append(phase.priorityList, apl.priorityList...)
+	if apl.getNextAction(sim) != main {
+		t.Fatalf("Expected the main list when the phase list falls through")
+	}
+}
+
+func TestAPLPhaseListParsing(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	waitAction := func() *proto.APLListItem {
+		return &proto.APLListItem{Action: &proto.APLAction{Action: &proto.APLAction_Wait{Wait: &proto.APLActionWait{
+			Duration: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "1s"}}},
+		}}}}
+	}
+	apl := fa.newAPLRotation(&proto.APLRotation{
+		Type:         proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{waitAction()},
+		PhaseLists: []*proto.APLPhaseList{
+			{
+				Name: "Execute",
+				Condition: &proto.APLValue{Value: &proto.APLValue_IsExecutePhase{IsExecutePhase: &proto.APLValueIsExecutePhase{
+					Threshold: proto.APLValueIsExecutePhase_E20,
+				}}},
+				PriorityList: []*proto.APLListItem{waitAction()},
+				FallThrough:  true,
+			},
+			{PriorityList: []*proto.APLListItem{waitAction()}},
+		},
+	})
+
+	if len(apl.phaseLists) != 2 || len(apl.activePhaseLists) != 1 {
+		t.Fatalf("Expected only the phase list with a condition to be used")
+	}
+	if apl.phaseLists[1].name != "Phase 2" || len(apl.phaseLists[1].validations) != 1 {
+		t.Fatalf("Expected a default name and a warning for the phase list without a condition")
+	}
+	if actions := apl.phaseLists[0].actions; len(actions) != 2 || actions[1] != apl.priorityList[0] {
+		t.Fatalf("Expected the phase list to fall through to the main list")
+	}
+	if len(apl.allAPLActions()) != 3 {
+		t.Fatalf("Expected the actions of all lists, got %d", len(apl.allAPLActions()))
+	}
+}
+
+func TestAPLPhaseMetricsAreSorted(t *testing.T) {
+	unitMetrics := NewUnitMetrics()
+	for _, name := range []string{"burn", "aoe", "opener"} {
+		unitMetrics.getAPLPhaseMetrics(name)
+	}
+
+	// Map iteration order is random, so check the output order repeatedly.
+	for range 10 {
+		var names []string
+		for _, phase := range unitMetrics.ToProto().AplPhases {
+			names = append(names, phase.Name)
+		}
+		if !slices.Equal(names, []string{"aoe", "burn", "opener"}) {
+			t.Fatalf("Expected phase lists sorted by name, got %v", names)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleproto "google.golang.org/protobuf/proto"
+)
+
+const aplPresetExtension = ".apl.json"
+
+var aplPresets = map[proto.Spec][]*proto.APLPreset{}
+
+// Registers the named APLs offered for a spec. Specs call this from their
+// Register function, usually with the presets returned by LoadAPLPresets.
+func RegisterAPLPresets(spec proto.Spec, presets ...*proto.APLPreset) {
+	if _, ok := aplPresets[spec]; ok {
+		panic("Already registered APL presets for spec: " + spec.String())
+	}
+
+	sorted := slices.Clone(presets)
+	slices.SortFunc(sorted, func(a, b *proto.APLPreset) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Name == sorted[i-1].Name {
+			panic(fmt.Sprintf("Duplicate APL preset %q for spec: %s", sorted[i].Name, spec))
+		}
+	}
+	aplPresets[spec] = sorted
+}
+
+// Parses every <name>.apl.json file in dir. Panics on invalid files, since
+// presets are bundled with the sim.
+func LoadAPLPresets(fsys fs.FS, dir string) []*proto.APLPreset {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		panic(fmt.Sprintf("failed to read APL presets in %s: %s", dir, err))
+	}
+
+	var presets []*proto.APLPreset
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), aplPresetExtension)
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("failed to read APL preset %s: %s", entry.Name(), err))
+		}
+		// Presets are maintained alongside the UI, which tolerates fields that
+		// have since been removed from the proto, so do the same here.
+		rotation := &proto.APLRotation{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, rotation); err != nil {
+			panic(fmt.Sprintf("failed to parse APL preset %s: %s", entry.Name(), err))
+		}
+		presets = append(presets, &proto.APLPreset{
+			Name:     name,
+			Rotation: rotation,
+		})
+	}
+	return presets
+}
+
+// Returns a copy of the named APL for the spec, or nil if there is none.
+func GetAPLPreset(spec proto.Spec, name string) *proto.APLRotation {
+	for _, preset := range aplPresets[spec] {
+		if preset.Name == name {
+			return googleproto.Clone(preset.Rotation).(*proto.APLRotation)
+		}
+	}
+	return nil
+}
+
+// Lists the APLs registered for a spec, for the UI and CLI preset selectors.
+func APLPresets(request *proto.APLPresetsRequest) *proto.APLPresetsResult {
+	presets, ok := aplPresets[request.Spec]
+	if !ok {
+		return &proto.APLPresetsResult{ErrorResult: fmt.Sprintf("No APL presets for spec: %s", request.Spec)}
+	}
+
+	result := &proto.APLPresetsResult{}
+	for _, preset := range presets {
+		result.Presets = append(result.Presets, googleproto.Clone(preset).(*proto.APLPreset))
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestAPLPresets(t *testing.T) {
+	aplJson := func(spellId int32) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(fmt.Sprintf(`{"type":"TypeAPL","priorityList":[{"action":{"castSpell":{"spellId":{"spellId":%d}}}}]}`, spellId))}
+	}
+	fsys := fstest.MapFS{
+		"test/spec/apls/default.apl.json": aplJson(1),
+		"test/spec/apls/aoe.apl.json":     aplJson(2),
+		"test/spec/apls/notes.txt":        &fstest.MapFile{Data: []byte("not an apl")},
+	}
+
+	spec := proto.Spec_SpecUnknown
+	RegisterAPLPresets(spec, LoadAPLPresets(fsys, "test/spec/apls")...)
+	defer delete(aplPresets, spec)
+
+	result := APLPresets(&proto.APLPresetsRequest{Spec: spec})
+	if result.ErrorResult != "" {
+		t.Fatalf("Unexpected error: %s", result.ErrorResult)
+	}
+	if len(result.Presets) != 2 || result.Presets[0].Name != "aoe" || result.Presets[1].Name != "default" {
+		t.Fatalf("Expected presets aoe and default, got %v", result.Presets)
+	}
+
+	rotation := GetAPLPreset(spec, "default")
+	if rotation == nil {
+		t.Fatalf("Expected default preset")
+	}
+	if spellId := rotation.PriorityList[0].Action.GetCastSpell().SpellId.GetSpellId(); spellId != 1 {
+		t.Fatalf("Expected default preset to cast spell 1, got %d", spellId)
+	}
+
+	// Callers get copies, so the registered presets can't be modified.
+	rotation.PriorityList = nil
+	if len(GetAPLPreset(spec, "default").PriorityList) != 1 {
+		t.Fatalf("Registered preset was modified through a returned copy")
+	}
+
+	if GetAPLPreset(spec, "movement") != nil {
+		t.Fatalf("Expected no preset for an unknown name")
+	}
+	if result := APLPresets(&proto.APLPresetsRequest{Spec: proto.Spec_SpecFeralDruid}); result.ErrorResult == "" {
+		t.Fatalf("Expected an error for a spec without presets")
+	}
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleproto "google.golang.org/protobuf/proto"
+)
+
+// Serializes an APL with its metadata for sharing. The sim and proto versions
+// are filled in, and a checksum is added so imports can detect modifications.
+func ExportAPL(request *proto.ExportAPLRequest) *proto.ExportAPLResult {
+	if err := validateSharedAPL(request.Apl); err != nil {
+		return &proto.ExportAPLResult{ErrorResult: err.Error()}
+	}
+
+	shared := googleproto.Clone(request.Apl).(*proto.SharedAPL)
+	shared.SimVersion = SimVersion
+	shared.ProtoVersion = GetCurrentProtoVersion()
+
+	unchecked, err := protojson.Marshal(shared)
+	if err != nil {
+		return &proto.ExportAPLResult{ErrorResult: err.Error()}
+	}
+	if shared.Checksum, err = sharedAPLChecksum(unchecked); err != nil {
+		return &proto.ExportAPLResult{ErrorResult: err.Error()}
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(shared)
+	if err != nil {
+		return &proto.ExportAPLResult{ErrorResult: err.Error()}
+	}
+	return &proto.ExportAPLResult{Data: string(data)}
+}
+
+// Parses and validates an APL exported by ExportAPL.
+func ImportAPL(request *proto.ImportAPLRequest) *proto.ImportAPLResult {
+	shared := &proto.SharedAPL{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(request.Data), shared); err != nil {
+		return &proto.ImportAPLResult{ErrorResult: fmt.Sprintf("Invalid APL export: %s", err)}
+	}
+
+	if shared.Checksum == "" {
+		return &proto.ImportAPLResult{ErrorResult: "APL export has no checksum"}
+	}
+	if checksum, _ := sharedAPLChecksum([]byte(request.Data)); checksum != shared.Checksum {
+		return &proto.ImportAPLResult{ErrorResult: "APL export checksum doesn't match, it was modified or is incomplete"}
+	}
+	if err := validateSharedAPL(shared); err != nil {
+		return &proto.ImportAPLResult{ErrorResult: err.Error()}
+	}
+
+	result := &proto.ImportAPLResult{Apl: shared}
+	if shared.SimVersion != SimVersion {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("APL was exported from sim version %s, this is %s", shared.SimVersion, SimVersion))
+	}
+	if shared.ProtoVersion < GetCurrentProtoVersion() {
+		result.Warnings = append(result.Warnings, "APL was exported with an older data format, some actions or values may have changed")
+	}
+	return result
+}
+
+func validateSharedAPL(shared *proto.SharedAPL) error {
+	if shared == nil || shared.Rotation == nil {
+		return errors.New("APL export has no rotation")
+	}
+	if shared.Rotation.Type != proto.APLRotation_TypeAPL {
+		return errors.New("only APL rotations can be shared")
+	}
+	if len(shared.Rotation.PriorityList) == 0 && len(shared.Rotation.PrepullActions) == 0 {
+		return errors.New("APL has no actions")
+	}
+	if shared.Spec == proto.Spec_SpecUnknown {
+		return errors.New("APL export has no spec")
+	}
+	return nil
+}
+
+// Checksum over all fields of a JSON export except the checksum itself. The
+// JSON is normalized by sorting its keys and dropping whitespace, and fields
+// are hashed as exported, so the checksum doesn't depend on the proto schema of
+// the importing sim, e.g. fields it doesn't know or migrates.
+func sharedAPLChecksum(data []byte) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return "", err
+	}
+	delete(fields, "checksum")
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:8]), nil
+}
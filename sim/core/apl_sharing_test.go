@@ -0,0 +1,89 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func testSharedAPL() *proto.SharedAPL {
+	return &proto.SharedAPL{
+		Name:     "Test APL",
+		Author:   "Tester",
+		Spec:     proto.Spec_SpecArmsWarrior,
+		Scenario: "Single target",
+		Rotation: &proto.APLRotation{
+			Type: proto.APLRotation_TypeAPL,
+			PriorityList: []*proto.APLListItem{{
+				Action: &proto.APLAction{
+					Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+						SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 12294}},
+					}},
+				},
+			}},
+		},
+	}
+}
+
+func TestSharedAPLRoundTrip(t *testing.T) {
+	exported := ExportAPL(&proto.ExportAPLRequest{Apl: testSharedAPL()})
+	if exported.ErrorResult != "" {
+		t.Fatalf("Export failed: %s", exported.ErrorResult)
+	}
+
+	imported := ImportAPL(&proto.ImportAPLRequest{Data: exported.Data})
+	if imported.ErrorResult != "" {
+		t.Fatalf("Import failed: %s", imported.ErrorResult)
+	}
+	if imported.Apl.Author != "Tester" || imported.Apl.SimVersion != SimVersion {
+		t.Errorf("Unexpected imported metadata: %v", imported.Apl)
+	}
+	if len(imported.Warnings) != 0 {
+		t.Errorf("Unexpected warnings: %v", imported.Warnings)
+	}
+}
+
+func TestSharedAPLModified(t *testing.T) {
+	exported := ExportAPL(&proto.ExportAPLRequest{Apl: testSharedAPL()})
+	modified := strings.Replace(exported.Data, "Tester", "Someone else", 1)
+
+	if imported := ImportAPL(&proto.ImportAPLRequest{Data: modified}); imported.ErrorResult == "" {
+		t.Fatalf("Expected modified export to fail the checksum")
+	}
+}
+
+func TestSharedAPLChecksumIgnoresFormattingAndSchema(t *testing.T) {
+	exported := ExportAPL(&proto.ExportAPLRequest{Apl: testSharedAPL()})
+
+	// Reformatting the JSON keeps the checksum.
+	compact := &bytes.Buffer{}
+	if err := json.Compact(compact, []byte(exported.Data)); err != nil {
+		t.Fatalf("Invalid export: %s", err)
+	}
+	if imported := ImportAPL(&proto.ImportAPLRequest{Data: compact.String()}); imported.ErrorResult != "" {
+		t.Fatalf("Expected a compacted export to import, got: %s", imported.ErrorResult)
+	}
+
+	// Fields from a newer schema are covered by the checksum, even though this
+	// sim discards them.
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(exported.Data), &fields); err != nil {
+		t.Fatalf("Invalid export: %s", err)
+	}
+	fields["newerField"] = "value"
+	newer, _ := json.Marshal(fields)
+	fields["checksum"], _ = sharedAPLChecksum(newer)
+	newer, _ = json.Marshal(fields)
+	if imported := ImportAPL(&proto.ImportAPLRequest{Data: string(newer)}); imported.ErrorResult != "" {
+		t.Fatalf("Expected an export with unknown fields to import, got: %s", imported.ErrorResult)
+	}
+
+	fields["newerField"] = "modified"
+	modified, _ := json.Marshal(fields)
+	if imported := ImportAPL(&proto.ImportAPLRequest{Data: string(modified)}); imported.ErrorResult == "" {
+		t.Fatalf("Expected a modified unknown field to fail the checksum")
+	}
+}
@@ -96,6 +96,16 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		value = rot.newValueIsExecutePhase(config.GetIsExecutePhase(), config.Uuid)
 	case *proto.APLValue_NumberTargets:
 		value = rot.newValueNumberTargets(config.GetNumberTargets(), config.Uuid)
+	case *proto.APLValue_EncounterDamageModifier:
+		value = rot.newValueEncounterDamageModifier(config.GetEncounterDamageModifier(), config.Uuid)
+	case *proto.APLValue_TargetImmuneToSpell:
+		value = rot.newValueTargetImmuneToSpell(config.GetTargetImmuneToSpell(), config.Uuid)
+	case *proto.APLValue_RatingPercent:
+		value = rot.newValueRatingPercent(config.GetRatingPercent(), config.Uuid)
+	case *proto.APLValue_BossHealthPercent:
+		value = rot.newValueBossHealthPercent(config.GetBossHealthPercent(), config.Uuid)
+	case *proto.APLValue_ThreatPercent:
+		value = rot.newValueThreatPercent(config.GetThreatPercent(), config.Uuid)
 
 	// Boss
 	case *proto.APLValue_BossSpellIsCasting:
@@ -160,6 +170,8 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		value = rot.newValueNextRuneCooldown(config.GetNextRuneCooldown(), config.Uuid)
 	case *proto.APLValue_RuneSlotCooldown:
 		value = rot.newValueRuneSlotCooldown(config.GetRuneSlotCooldown(), config.Uuid)
+	case *proto.APLValue_TimeToRuneCount:
+		value = rot.newValueTimeToRuneCount(config.GetTimeToRuneCount(), config.Uuid)
 
 	// Unit
 	case *proto.APLValue_UnitIsMoving:
@@ -216,12 +228,18 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		value = rot.newValueAuraRemainingTime(config.GetAuraRemainingTime(), config.Uuid)
 	case *proto.APLValue_AuraNumStacks:
 		value = rot.newValueAuraNumStacks(config.GetAuraNumStacks(), config.Uuid)
+	case *proto.APLValue_AuraNumStacksOnUnit:
+		value = rot.newValueAuraNumStacksOnUnit(config.GetAuraNumStacksOnUnit(), config.Uuid)
 	case *proto.APLValue_AuraInternalCooldown:
 		value = rot.newValueAuraInternalCooldown(config.GetAuraInternalCooldown(), config.Uuid)
 	case *proto.APLValue_AuraIcdIsReadyWithReactionTime:
 		value = rot.newValueAuraICDIsReadyWithReactionTime(config.GetAuraIcdIsReadyWithReactionTime(), config.Uuid)
 	case *proto.APLValue_AuraShouldRefresh:
 		value = rot.newValueAuraShouldRefresh(config.GetAuraShouldRefresh(), config.Uuid)
+	case *proto.APLValue_AuraExclusiveStrongestValue:
+		value = rot.newValueAuraExclusiveStrongestValue(config.GetAuraExclusiveStrongestValue(), config.Uuid)
+	case *proto.APLValue_InStance:
+		value = rot.newValueInStance(config.GetInStance(), config.Uuid)
 
 	// Aura sets
 	case *proto.APLValue_AllTrinketStatProcsActive:
@@ -254,6 +272,8 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		value = rot.newValueDotPercentIncrease(config.GetDotPercentIncrease(), config.Uuid)
 
 	// Sequences
+	case *proto.APLValue_Variable:
+		value = rot.newValueVariable(config.GetVariable(), config.Uuid)
 	case *proto.APLValue_SequenceIsComplete:
 		value = rot.newValueSequenceIsComplete(config.GetSequenceIsComplete(), config.Uuid)
 	case *proto.APLValue_SequenceIsReady:
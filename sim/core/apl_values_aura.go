@@ -174,6 +174,47 @@ func (value *APLValueAuraNumStacks) String() string {
 	return fmt.Sprintf("Aura Num Stacks(%s)", value.aura.String())
 }
 
+// Unlike APLValueAuraNumStacks, the unit is resolved on every evaluation and
+// units that don't have the aura at all simply report 0 stacks. This lets
+// multi-dot and support rotations check e.g. the next target or a specific
+// raid member. The value is only dropped during validation if no unit has the
+// aura.
+type APLValueAuraNumStacksOnUnit struct {
+	DefaultAPLValueImpl
+	aura AuraReference
+}
+
+func (rot *APLRotation) newValueAuraNumStacksOnUnit(config *proto.APLValueAuraNumStacksOnUnit, uuid *proto.UUID) APLValue {
+	if config.AuraId == nil {
+		return nil
+	}
+	unit := rot.GetTargetUnit(config.Unit)
+	if unit.Get() == nil {
+		return nil
+	}
+	aura := NewAuraReference(unit, config.AuraId)
+	if aura.fixedAura == nil && aura.allTargetAuras == nil {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "No aura found on %s for: %s", unit.Get().Label, ProtoToActionID(config.AuraId))
+		return nil
+	}
+	return &APLValueAuraNumStacksOnUnit{
+		aura: aura,
+	}
+}
+func (value *APLValueAuraNumStacksOnUnit) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueAuraNumStacksOnUnit) GetInt(sim *Simulation) int32 {
+	aura := value.aura.Get()
+	if aura == nil || !aura.IsActive() {
+		return 0
+	}
+	return aura.GetStacks()
+}
+func (value *APLValueAuraNumStacksOnUnit) String() string {
+	return fmt.Sprintf("Aura Num Stacks On Unit(%s)", value.aura.String())
+}
+
 type APLValueAuraInternalCooldown struct {
 	DefaultAPLValueImpl
 	aura AuraReference
@@ -268,3 +309,64 @@ func (value *APLValueAuraShouldRefresh) GetBool(sim *Simulation) bool {
 func (value *APLValueAuraShouldRefresh) String() string {
 	return fmt.Sprintf("Should Refresh Aura(%s)", value.aura.String())
 }
+
+type APLValueAuraExclusiveStrongestValue struct {
+	DefaultAPLValueImpl
+	aura AuraReference
+}
+
+func (rot *APLRotation) newValueAuraExclusiveStrongestValue(config *proto.APLValueAuraExclusiveStrongestValue, uuid *proto.UUID) APLValue {
+	if config.AuraId == nil {
+		return nil
+	}
+	aura := rot.GetAPLAura(rot.GetTargetUnit(config.SourceUnit), config.AuraId)
+	if aura.Get() == nil {
+		return nil
+	}
+	if len(aura.Get().ExclusiveEffects) == 0 {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "%s has no exclusive effects", ProtoToActionID(config.AuraId))
+		return nil
+	}
+	return &APLValueAuraExclusiveStrongestValue{
+		aura: aura,
+	}
+}
+func (value *APLValueAuraExclusiveStrongestValue) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueAuraExclusiveStrongestValue) GetFloat(sim *Simulation) float64 {
+	return value.aura.Get().ExclusiveEffects[0].Category.StrongestValue()
+}
+func (value *APLValueAuraExclusiveStrongestValue) String() string {
+	return fmt.Sprintf("Aura Exclusive Strongest Value(%s)", value.aura.String())
+}
+
+type APLValueInStance struct {
+	DefaultAPLValueImpl
+	unit   *Unit
+	stance *StanceConfig
+}
+
+func (rot *APLRotation) newValueInStance(config *proto.APLValueInStance, _ *proto.UUID) APLValue {
+	if config.StanceId == nil {
+		return nil
+	}
+	stance := rot.unit.getStanceByActionID(ProtoToActionID(config.StanceId))
+	if stance == nil {
+		rot.ValidationMessage(proto.LogLevel_Warning, "%s has no stance, form or presence %s", rot.unit.Label, ProtoToActionID(config.StanceId))
+		return nil
+	}
+	return &APLValueInStance{
+		unit:   rot.unit,
+		stance: stance,
+	}
+}
+func (value *APLValueInStance) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueInStance) GetBool(sim *Simulation) bool {
+	return value.unit.InStance(value.stance.Stance)
+}
+func (value *APLValueInStance) String() string {
+	return fmt.Sprintf("In Stance(%s)", value.stance.ActionID)
+}
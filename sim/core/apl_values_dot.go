@@ -218,7 +218,12 @@ func (value *APLValueDotPercentIncrease) Type() proto.APLValueType {
 }
 
 func (value *APLValueDotPercentIncrease) GetFloat(sim *Simulation) float64 {
-	expectedDamage := value.spell.ExpectedTickDamageFromCurrentSnapshot(sim, value.target)
+	var expectedDamage float64
+	if dot := value.spell.Dot(value.target); dot != nil && dot.SnapshotTracker != nil {
+		expectedDamage = dot.SnapshotTracker.CurrentSnapshotPower()
+	} else {
+		expectedDamage = value.spell.ExpectedTickDamageFromCurrentSnapshot(sim, value.target)
+	}
 	if expectedDamage == 0 {
 		return 1
 	}
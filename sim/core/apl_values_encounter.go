@@ -75,6 +75,23 @@ func (value *APLValueRemainingTimePercent) String() string {
 	return fmt.Sprintf("Remaining Time %%")
 }
 
+type APLValueBossHealthPercent struct {
+	DefaultAPLValueImpl
+}
+
+func (rot *APLRotation) newValueBossHealthPercent(config *proto.APLValueBossHealthPercent, _ *proto.UUID) APLValue {
+	return &APLValueBossHealthPercent{}
+}
+func (value *APLValueBossHealthPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueBossHealthPercent) GetFloat(sim *Simulation) float64 {
+	return sim.BossHealthPercent()
+}
+func (value *APLValueBossHealthPercent) String() string {
+	return fmt.Sprintf("Boss Health %%")
+}
+
 type APLValueNumberTargets struct {
 	DefaultAPLValueImpl
 }
@@ -92,6 +109,98 @@ func (value *APLValueNumberTargets) String() string {
 	return "Num Active Targets"
 }
 
+type APLValueEncounterDamageModifier struct {
+	DefaultAPLValueImpl
+	unit        *Unit
+	damageTaken bool
+}
+
+func (rot *APLRotation) newValueEncounterDamageModifier(config *proto.APLValueEncounterDamageModifier, _ *proto.UUID) APLValue {
+	return &APLValueEncounterDamageModifier{
+		unit:        rot.unit,
+		damageTaken: config.DamageTaken,
+	}
+}
+func (value *APLValueEncounterDamageModifier) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueEncounterDamageModifier) GetFloat(sim *Simulation) float64 {
+	damageDealt, damageTaken := sim.Encounter.DamageModifierMultipliers(value.unit)
+	if value.damageTaken {
+		return damageTaken
+	}
+	return damageDealt
+}
+func (value *APLValueEncounterDamageModifier) String() string {
+	if value.damageTaken {
+		return "Encounter Damage Taken Modifier"
+	}
+	return "Encounter Damage Dealt Modifier"
+}
+
+type APLValueTargetImmuneToSpell struct {
+	DefaultAPLValueImpl
+	targetUnit UnitReference
+	spell      *Spell
+}
+
+func (rot *APLRotation) newValueTargetImmuneToSpell(config *proto.APLValueTargetImmuneToSpell, _ *proto.UUID) APLValue {
+	spell := rot.GetAPLSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	return &APLValueTargetImmuneToSpell{
+		targetUnit: rot.GetTargetUnit(config.TargetUnit),
+		spell:      spell,
+	}
+}
+func (value *APLValueTargetImmuneToSpell) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueTargetImmuneToSpell) GetBool(sim *Simulation) bool {
+	return sim.Encounter.IsImmuneToSpell(value.spell, value.targetUnit.Get())
+}
+func (value *APLValueTargetImmuneToSpell) String() string {
+	return fmt.Sprintf("Target Immune To Spell(%s)", value.spell.ActionID)
+}
+
+type APLValueThreatPercent struct {
+	DefaultAPLValueImpl
+	unit       *Unit
+	targetUnit UnitReference
+}
+
+func (rot *APLRotation) newValueThreatPercent(config *proto.APLValueThreatPercent, _ *proto.UUID) APLValue {
+	if !rot.unit.Env.Encounter.ModelThreat {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Threat Percent is always 0 unless the encounter models threat")
+	}
+	return &APLValueThreatPercent{
+		unit:       rot.unit,
+		targetUnit: rot.GetTargetUnit(config.TargetUnit),
+	}
+}
+func (value *APLValueThreatPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueThreatPercent) GetFloat(sim *Simulation) float64 {
+	target := value.targetUnit.Get()
+	holder := target.CurrentTarget
+	if holder == nil {
+		return 0
+	}
+	if holder == value.unit {
+		return 100
+	}
+	holderThreat := target.ThreatOf(holder)
+	if holderThreat == 0 {
+		return 0
+	}
+	return target.ThreatOf(value.unit) / holderThreat * 100
+}
+func (value *APLValueThreatPercent) String() string {
+	return "Threat Percent"
+}
+
 type APLValueIsExecutePhase struct {
 	DefaultAPLValueImpl
 	threshold proto.APLValueIsExecutePhase_ExecutePhaseThreshold
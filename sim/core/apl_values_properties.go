@@ -1,9 +1,11 @@
 package core
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
 )
 
 type APLValueChannelClipDelay struct {
@@ -65,3 +67,50 @@ func (value *APLValueFrontOfTarget) GetBool(sim *Simulation) bool {
 func (value *APLValueFrontOfTarget) String() string {
 	return "Front of Target()"
 }
+
+type APLValueRatingPercent struct {
+	DefaultAPLValueImpl
+	unit    *Unit
+	rating  proto.APLValueRatingPercent_RatingType
+	convert func(rating float64) float64
+	stat    stats.Stat
+}
+
+func (rot *APLRotation) newValueRatingPercent(config *proto.APLValueRatingPercent, _ *proto.UUID) APLValue {
+	value := &APLValueRatingPercent{
+		unit:   rot.unit,
+		rating: config.Rating,
+	}
+
+	switch config.Rating {
+	case proto.APLValueRatingPercent_RatingHaste:
+		value.stat, value.convert = stats.HasteRating, Ratings.HastePercent
+	case proto.APLValueRatingPercent_RatingCrit:
+		value.stat, value.convert = stats.CritRating, Ratings.CritPercent
+	case proto.APLValueRatingPercent_RatingHit:
+		value.stat, value.convert = stats.HitRating, Ratings.PhysicalHitPercent
+	case proto.APLValueRatingPercent_RatingExpertise:
+		value.stat, value.convert = stats.ExpertiseRating, Ratings.ExpertisePercent
+	case proto.APLValueRatingPercent_RatingMastery:
+		value.stat, value.convert = stats.MasteryRating, Ratings.MasteryPoints
+	case proto.APLValueRatingPercent_RatingDodge:
+		value.stat, value.convert = stats.DodgeRating, Ratings.DodgePercent
+	case proto.APLValueRatingPercent_RatingParry:
+		value.stat, value.convert = stats.ParryRating, Ratings.ParryPercent
+	default:
+		rot.ValidationMessage(proto.LogLevel_Warning, "No rating selected")
+		return nil
+	}
+	return value
+}
+func (value *APLValueRatingPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+
+// Percentage from the unit's current rating only, or mastery points for mastery.
+func (value *APLValueRatingPercent) GetFloat(sim *Simulation) float64 {
+	return value.convert(value.unit.GetStat(value.stat))
+}
+func (value *APLValueRatingPercent) String() string {
+	return fmt.Sprintf("Rating %%(%s)", value.rating)
+}
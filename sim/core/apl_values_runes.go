@@ -231,3 +231,58 @@ func (value *APLValueRuneSlotCooldown) GetDuration(sim *Simulation) time.Duratio
 func (value *APLValueRuneSlotCooldown) String() string {
 	return fmt.Sprintf("Rune Slot Cooldown(%d)", value.runeSlot)
 }
+
+type APLValueTimeToRuneCount struct {
+	DefaultAPLValueImpl
+	unit     *Unit
+	runeType proto.APLValueRuneType
+	count    int32
+}
+
+func (rot *APLRotation) newValueTimeToRuneCount(config *proto.APLValueTimeToRuneCount, uuid *proto.UUID) APLValue {
+	unit := rot.unit
+	if !unit.HasRunicPowerBar() {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "%s does not use Runes", unit.Label)
+		return nil
+	}
+	maxCount := TernaryInt32(config.RuneType == proto.APLValueRuneType_RuneDeath, 6, 2)
+	if config.Count < 1 || config.Count > maxCount {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "Rune count must be between 1 and %d", maxCount)
+		return nil
+	}
+	return &APLValueTimeToRuneCount{
+		unit:     unit,
+		runeType: config.RuneType,
+		count:    config.Count,
+	}
+}
+func (value *APLValueTimeToRuneCount) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueTimeToRuneCount) GetDuration(sim *Simulation) time.Duration {
+	var slots []int8
+	switch value.runeType {
+	case proto.APLValueRuneType_RuneBlood:
+		slots = []int8{0, 1}
+	case proto.APLValueRuneType_RuneFrost:
+		slots = []int8{2, 3}
+	case proto.APLValueRuneType_RuneUnholy:
+		slots = []int8{4, 5}
+	case proto.APLValueRuneType_RuneDeath:
+		slots = make([]int8, 0, 6)
+		for slot := int8(0); slot < 6; slot++ {
+			if value.unit.RuneIsDeath(slot) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+
+	readyAt := value.unit.runeSlotsReadyAt(sim, slots, value.count)
+	if readyAt == NeverExpires {
+		return NeverExpires
+	}
+	return max(0, readyAt-sim.CurrentTime)
+}
+func (value *APLValueTimeToRuneCount) String() string {
+	return fmt.Sprintf("Time To Rune Count(%s, %d)", value.runeType, value.count)
+}
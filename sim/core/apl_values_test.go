@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
 )
 
 func TestValueConst(t *testing.T) {
@@ -42,3 +43,80 @@ func TestValueConst(t *testing.T) {
 		t.Fatalf("Unexpected coerced duration value %s", coercedDurVal.GetDuration(sim))
 	}
 }
+
+func TestValueAuraNumStacksOnUnit(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+			Debuffs: &proto.Debuffs{WeakenedArmor: true},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target 1", Level: 93, MobType: proto.MobType_MobTypeDemon},
+				{Name: "target 2", Level: 93, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	player := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	rot := &APLRotation{unit: player, uuidValidations: make(map[*proto.UUID][]*proto.APLValidation)}
+	auraID := ActionID{SpellID: 113746}.ToProto()
+
+	secondTargetDebuff := sim.Encounter.AllTargetUnits[1].GetAuraByID(ActionID{SpellID: 113746})
+	for _, target := range sim.Encounter.AllTargetUnits {
+		target.GetAuraByID(ActionID{SpellID: 113746}).Deactivate(sim)
+	}
+
+	currentTarget := rot.newValueAuraNumStacksOnUnit(&proto.APLValueAuraNumStacksOnUnit{AuraId: auraID}, &proto.UUID{})
+	byIndex := rot.newValueAuraNumStacksOnUnit(&proto.APLValueAuraNumStacksOnUnit{
+		Unit:   &proto.UnitReference{Type: proto.UnitReference_Target, Index: 1},
+		AuraId: auraID,
+	}, &proto.UUID{})
+	if currentTarget == nil || byIndex == nil {
+		t.Fatalf("Expected values to be created for units that have the aura")
+	}
+	if str := byIndex.String(); str != "Aura Num Stacks On Unit("+(ActionID{SpellID: 113746}).String()+")" {
+		t.Fatalf("Expected the configured aura in the description, got %s", str)
+	}
+
+	secondTargetDebuff.Activate(sim)
+	secondTargetDebuff.SetStacks(sim, 2)
+	if stacks := currentTarget.GetInt(sim); stacks != 0 {
+		t.Fatalf("Expected 0 stacks on the current target, got %d", stacks)
+	}
+	if stacks := byIndex.GetInt(sim); stacks != 2 {
+		t.Fatalf("Expected 2 stacks on target 2, got %d", stacks)
+	}
+
+	// Current target is resolved on every evaluation.
+	player.CurrentTarget = sim.Encounter.AllTargetUnits[1]
+	if stacks := currentTarget.GetInt(sim); stacks != 2 {
+		t.Fatalf("Expected 2 stacks after swapping targets, got %d", stacks)
+	}
+
+	onSelf := rot.newValueAuraNumStacksOnUnit(&proto.APLValueAuraNumStacksOnUnit{
+		Unit:   &proto.UnitReference{Type: proto.UnitReference_Self},
+		AuraId: auraID,
+	}, &proto.UUID{})
+	if onSelf != nil {
+		t.Fatalf("Expected no value for a unit that never has the aura")
+	}
+}
@@ -0,0 +1,235 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// State declared by a rotation, which Set Variable actions write and Variable
+// values read.
+type aplVariable struct {
+	name    string
+	valType proto.APLValueType
+	scope   proto.APLVariable_Scope
+
+	initialBool     bool
+	initialFloat    float64
+	initialDuration time.Duration
+
+	boolVal     bool
+	floatVal    float64
+	durationVal time.Duration
+}
+
+func (variable *aplVariable) reset() {
+	variable.boolVal = variable.initialBool
+	variable.floatVal = variable.initialFloat
+	variable.durationVal = variable.initialDuration
+}
+
+// Whether the variable holds the current result of value, which must have the variable's type.
+func (variable *aplVariable) holds(sim *Simulation, value APLValue) bool {
+	switch variable.valType {
+	case proto.APLValueType_ValueTypeBool:
+		return variable.boolVal == value.GetBool(sim)
+	case proto.APLValueType_ValueTypeFloat:
+		return variable.floatVal == value.GetFloat(sim)
+	default:
+		return variable.durationVal == value.GetDuration(sim)
+	}
+}
+
+func (variable *aplVariable) set(sim *Simulation, value APLValue) {
+	switch variable.valType {
+	case proto.APLValueType_ValueTypeBool:
+		variable.boolVal = value.GetBool(sim)
+	case proto.APLValueType_ValueTypeFloat:
+		variable.floatVal = value.GetFloat(sim)
+	default:
+		variable.durationVal = value.GetDuration(sim)
+	}
+}
+
+func (variable *aplVariable) valueString() string {
+	switch variable.valType {
+	case proto.APLValueType_ValueTypeBool:
+		return fmt.Sprintf("%t", variable.boolVal)
+	case proto.APLValueType_ValueTypeFloat:
+		return fmt.Sprintf("%.3f", variable.floatVal)
+	default:
+		return variable.durationVal.String()
+	}
+}
+
+// Parses the variables declared by the rotation. Must run before actions and
+// values are parsed, since their types depend on the variables'.
+func (rot *APLRotation) declareVariables(configs []*proto.APLVariable) {
+	rot.variables = make(map[string]*aplVariable, len(configs))
+
+	for _, config := range configs {
+		if config.Name == "" {
+			rot.ValidationMessage(proto.LogLevel_Warning, "Variables must have a name")
+			continue
+		}
+		if _, ok := rot.variables[config.Name]; ok {
+			rot.ValidationMessage(proto.LogLevel_Warning, "Variable '%s' is declared more than once", config.Name)
+			continue
+		}
+
+		variable := rot.newVariable(config)
+		if variable == nil {
+			continue
+		}
+
+		if config.Scope == proto.APLVariable_ScopeEncounter {
+			env := rot.unit.Env
+			if env.aplEncounterVariables == nil {
+				env.aplEncounterVariables = make(map[string]*aplVariable)
+			}
+			if shared, ok := env.aplEncounterVariables[config.Name]; ok {
+				if shared.valType != variable.valType {
+					rot.ValidationMessage(proto.LogLevel_Warning, "Encounter variable '%s' is declared with a different type by another unit", config.Name)
+					continue
+				}
+				variable = shared
+			} else {
+				env.aplEncounterVariables[config.Name] = variable
+			}
+		}
+
+		variable.reset()
+		rot.variables[config.Name] = variable
+	}
+}
+
+func (rot *APLRotation) newVariable(config *proto.APLVariable) *aplVariable {
+	switch config.Type {
+	case proto.APLValueType_ValueTypeBool, proto.APLValueType_ValueTypeFloat, proto.APLValueType_ValueTypeDuration:
+	default:
+		rot.ValidationMessage(proto.LogLevel_Warning, "Variable '%s' must be a bool, float or duration", config.Name)
+		return nil
+	}
+
+	variable := &aplVariable{
+		name:    config.Name,
+		valType: config.Type,
+		scope:   config.Scope,
+	}
+	if config.InitialValue == "" {
+		return variable
+	}
+
+	initial := rot.newValueConst(&proto.APLValueConst{Val: config.InitialValue}, nil).(*APLValueConst)
+	isNumber := initial.valType == proto.APLValueType_ValueTypeInt || initial.valType == proto.APLValueType_ValueTypeFloat
+
+	switch {
+	case config.Type == proto.APLValueType_ValueTypeBool && initial.valType == proto.APLValueType_ValueTypeBool:
+		variable.initialBool = initial.boolVal
+	case config.Type == proto.APLValueType_ValueTypeFloat && isNumber:
+		variable.initialFloat = initial.floatVal
+	case config.Type == proto.APLValueType_ValueTypeDuration && (isNumber || initial.valType == proto.APLValueType_ValueTypeDuration):
+		variable.initialDuration = initial.durationVal
+	default:
+		rot.ValidationMessage(proto.LogLevel_Warning, "Invalid initial value '%s' for variable '%s'", config.InitialValue, config.Name)
+		return nil
+	}
+	return variable
+}
+
+func (rot *APLRotation) getVariable(name string, uuid *proto.UUID) *aplVariable {
+	variable, ok := rot.variables[name]
+	if !ok {
+		if uuid != nil {
+			rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "No variable with name: '%s'", name)
+		} else {
+			rot.ValidationMessage(proto.LogLevel_Warning, "No variable with name: '%s'", name)
+		}
+		return nil
+	}
+	return variable
+}
+
+// Resets every variable which doesn't persist across iterations.
+func (rot *APLRotation) resetVariables() {
+	for _, variable := range rot.variables {
+		if variable.scope != proto.APLVariable_ScopePermanent {
+			variable.reset()
+		}
+	}
+}
+
+type APLActionSetVariable struct {
+	defaultAPLActionImpl
+	unit     *Unit
+	variable *aplVariable
+	value    APLValue
+}
+
+func (rot *APLRotation) newActionSetVariable(config *proto.APLActionSetVariable) APLActionImpl {
+	variable := rot.getVariable(config.Name, nil)
+	if variable == nil {
+		return nil
+	}
+
+	value := rot.coerceTo(rot.newAPLValue(config.Value), variable.valType)
+	if value == nil {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Set Variable must have a value")
+		return nil
+	}
+
+	return &APLActionSetVariable{
+		unit:     rot.unit,
+		variable: variable,
+		value:    value,
+	}
+}
+func (action *APLActionSetVariable) GetAPLValues() []APLValue {
+	return []APLValue{action.value}
+}
+
+// Only ready if the variable would change, so a Set Variable in the priority
+// list doesn't keep the rotation from moving on to the next action.
+func (action *APLActionSetVariable) IsReady(sim *Simulation) bool {
+	return !action.variable.holds(sim, action.value)
+}
+func (action *APLActionSetVariable) Execute(sim *Simulation) {
+	action.variable.set(sim, action.value)
+	if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+		action.unit.LogAt(sim, LogCategoryAI, LogLevelInfo, "Set variable '%s' to %s", action.variable.name, action.variable.valueString())
+	}
+}
+func (action *APLActionSetVariable) String() string {
+	return fmt.Sprintf("Set Variable(%s = %s)", action.variable.name, action.value)
+}
+
+type APLValueVariable struct {
+	DefaultAPLValueImpl
+	variable *aplVariable
+}
+
+func (rot *APLRotation) newValueVariable(config *proto.APLValueVariable, uuid *proto.UUID) APLValue {
+	variable := rot.getVariable(config.Name, uuid)
+	if variable == nil {
+		return nil
+	}
+	return &APLValueVariable{
+		variable: variable,
+	}
+}
+func (value *APLValueVariable) Type() proto.APLValueType {
+	return value.variable.valType
+}
+func (value *APLValueVariable) GetBool(_ *Simulation) bool {
+	return value.variable.boolVal
+}
+func (value *APLValueVariable) GetFloat(_ *Simulation) float64 {
+	return value.variable.floatVal
+}
+func (value *APLValueVariable) GetDuration(_ *Simulation) time.Duration {
+	return value.variable.durationVal
+}
+func (value *APLValueVariable) String() string {
+	return fmt.Sprintf("Variable(%s)", value.variable.name)
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type testAPLValueFloat struct {
+	DefaultAPLValueImpl
+	value float64
+}
+
+func (value *testAPLValueFloat) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *testAPLValueFloat) GetFloat(_ *Simulation) float64 {
+	return value.value
+}
+func (value *testAPLValueFloat) String() string {
+	return "test float"
+}
+
+func newTestVariableRotation(env *Environment, variables ...*proto.APLVariable) *APLRotation {
+	rot := &APLRotation{unit: &Unit{Env: env}}
+	rot.declareVariables(variables)
+	return rot
+}
+
+func TestAPLVariableDeclarations(t *testing.T) {
+	rot := newTestVariableRotation(&Environment{},
+		&proto.APLVariable{Name: "pool", Type: proto.APLValueType_ValueTypeBool, InitialValue: "true"},
+		&proto.APLVariable{Name: "energy", Type: proto.APLValueType_ValueTypeFloat, InitialValue: "1.5"},
+		&proto.APLVariable{Name: "delay", Type: proto.APLValueType_ValueTypeDuration, InitialValue: "4s"},
+		&proto.APLVariable{Name: "pool", Type: proto.APLValueType_ValueTypeBool},
+		&proto.APLVariable{Name: "label", Type: proto.APLValueType_ValueTypeString},
+		&proto.APLVariable{Name: "bad", Type: proto.APLValueType_ValueTypeFloat, InitialValue: "true"},
+		&proto.APLVariable{Type: proto.APLValueType_ValueTypeFloat},
+	)
+
+	if len(rot.variables) != 3 {
+		t.Fatalf("Expected 3 valid variables, got %d", len(rot.variables))
+	}
+	if len(rot.curValidations) != 4 {
+		t.Fatalf("Expected 4 warnings for the invalid declarations, got %v", rot.curValidations)
+	}
+	if !rot.variables["pool"].boolVal || rot.variables["energy"].floatVal != 1.5 || rot.variables["delay"].durationVal != time.Second*4 {
+		t.Fatalf("Variables don't hold their initial values")
+	}
+}
+
+func TestAPLActionSetVariable(t *testing.T) {
+	sim := &Simulation{}
+	rot := newTestVariableRotation(&Environment{},
+		&proto.APLVariable{Name: "energy", Type: proto.APLValueType_ValueTypeFloat, InitialValue: "10"},
+	)
+	newValue := &testAPLValueFloat{value: 40}
+	action := &APLActionSetVariable{
+		variable: rot.variables["energy"],
+		value:    newValue,
+	}
+	value := &APLValueVariable{variable: rot.variables["energy"]}
+
+	if !action.IsReady(sim) {
+		t.Fatalf("Expected Set Variable to be ready when it changes the variable")
+	}
+	action.Execute(sim)
+	if value.GetFloat(sim) != 40 {
+		t.Fatalf("Expected variable to be 40, got %f", value.GetFloat(sim))
+	}
+	if action.IsReady(sim) {
+		t.Fatalf("Expected Set Variable not to be ready when the variable already holds the value")
+	}
+
+	newValue.value = 20
+	if !action.IsReady(sim) {
+		t.Fatalf("Expected Set Variable to be ready once the value changes")
+	}
+}
+
+func TestAPLVariableScopes(t *testing.T) {
+	env := &Environment{}
+	declarations := []*proto.APLVariable{
+		{Name: "iteration", Type: proto.APLValueType_ValueTypeFloat, Scope: proto.APLVariable_ScopeIteration},
+		{Name: "encounter", Type: proto.APLValueType_ValueTypeFloat, Scope: proto.APLVariable_ScopeEncounter},
+		{Name: "permanent", Type: proto.APLValueType_ValueTypeFloat, Scope: proto.APLVariable_ScopePermanent},
+	}
+	rot := newTestVariableRotation(env, declarations...)
+	other := newTestVariableRotation(env, declarations...)
+
+	if rot.variables["encounter"] != other.variables["encounter"] {
+		t.Fatalf("Expected encounter variables to be shared between rotations")
+	}
+	if rot.variables["iteration"] == other.variables["iteration"] || rot.variables["permanent"] == other.variables["permanent"] {
+		t.Fatalf("Expected iteration and permanent variables to be private to each rotation")
+	}
+
+	for _, variable := range rot.variables {
+		variable.floatVal = 5
+	}
+	rot.resetVariables()
+	if rot.variables["iteration"].floatVal != 0 || rot.variables["encounter"].floatVal != 0 {
+		t.Fatalf("Expected iteration and encounter variables to be reset")
+	}
+	if rot.variables["permanent"].floatVal != 5 {
+		t.Fatalf("Expected permanent variable to keep its value across iterations")
+	}
+
+	mismatched := newTestVariableRotation(env, &proto.APLVariable{Name: "encounter", Type: proto.APLValueType_ValueTypeBool, Scope: proto.APLVariable_ScopeEncounter})
+	if _, ok := mismatched.variables["encounter"]; ok || len(mismatched.curValidations) != 1 {
+		t.Fatalf("Expected encounter variable with a different type to be rejected")
+	}
+}
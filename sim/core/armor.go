@@ -15,8 +15,9 @@ func (spell *Spell) armorMultiplier(isPeriodic bool, attackTable *AttackTable) f
 		return 1
 	}
 
-	// There are no (partial) resists in MoP
-	if !spell.SpellSchool.Matches(SpellSchoolPhysical) {
+	// There are no (partial) resists in MoP, and multi-school spells bypass
+	// armor through their magic schools.
+	if spell.SpellSchool != SpellSchoolPhysical {
 		return 1
 	}
 
@@ -876,8 +876,8 @@ func (unit *Unit) applyParryHaste() {
 
 			parryHasteReduction := min(defaultReduction, remainingTime-minRemainingTime)
 			newReadyAt := aura.Unit.AutoAttacks.mh.swingAt - parryHasteReduction
-			if sim.Log != nil {
-				aura.Unit.Log(sim, "MH Swing reduced by %s due to parry haste, will now occur at %s", parryHasteReduction, newReadyAt)
+			if sim.LogEnabled(LogCategoryOther, LogLevelDebug) {
+				aura.Unit.LogAt(sim, LogCategoryOther, LogLevelDebug, "MH Swing reduced by %s due to parry haste, will now occur at %s", parryHasteReduction, newReadyAt)
 			}
 
 			aura.Unit.AutoAttacks.mh.swingAt = newReadyAt
@@ -3,7 +3,9 @@ package core
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/exp/constraints"
@@ -31,6 +33,42 @@ type OnPeriodicDamage func(aura *Aura, sim *Simulation, spell *Spell, result *Sp
 
 const Inactive = -1
 
+// How the duration of an aura changes when it is refreshed while active.
+type RefreshPolicy int32
+
+const (
+	// Resets the remaining duration to the full duration. This is the default.
+	RefreshPolicyOverwrite RefreshPolicy = iota
+
+	// Resets to the full duration, plus the remaining duration up to
+	// PandemicDurationFraction of the full duration.
+	RefreshPolicyPandemic
+
+	// Adds the full duration to the remaining duration.
+	RefreshPolicyExtend
+
+	// Keeps the remaining duration unchanged.
+	RefreshPolicyIgnore
+)
+
+// Maximum part of the full duration carried over by RefreshPolicyPandemic.
+const PandemicDurationFraction = 0.3
+
+// Returns the duration the aura or dot should have after a refresh, given the
+// remaining duration before it.
+func (policy RefreshPolicy) refreshedDuration(duration time.Duration, remaining time.Duration) time.Duration {
+	switch policy {
+	case RefreshPolicyPandemic:
+		return duration + min(remaining, time.Duration(float64(duration)*PandemicDurationFraction))
+	case RefreshPolicyExtend:
+		return duration + remaining
+	case RefreshPolicyIgnore:
+		return remaining
+	default:
+		return duration
+	}
+}
+
 // Aura lifecycle:
 //
 // myAura := unit.RegisterAura(myAuraConfig)
@@ -51,12 +89,24 @@ type Aura struct {
 	Icd *Cooldown           // The internal cooldown if any
 	Dpm *DynamicProcManager // Dynamic Proc manager for proc trigger auras if any
 
+	icdMetricsAura *Aura // Aura which receives the ICD metrics of this trigger aura, see SetIcdMetricsAura.
+
 	Duration time.Duration // Duration of aura, upon being applied.
 
+	RefreshPolicy RefreshPolicy // How the duration changes when refreshed while active.
+
 	startTime time.Duration // Time at which the aura was applied.
 	expires   time.Duration // Time at which aura will be removed.
 	fadeTime  time.Duration // Time at which the aura was actually removed.
 
+	lastSource *Spell // Spell which most recently applied or refreshed this aura, if any.
+
+	disabledByUser bool // Set for auras in Player.disabled_auras, which never activate.
+
+	// If set, this aura is removed when the pet which applied it is dismissed.
+	// Otherwise it runs its course with the snapshot taken while the pet was active.
+	ExpiresWithOwner bool
+
 	// The unit this aura is attached to.
 	Unit *Unit
 
@@ -101,6 +151,11 @@ type Aura struct {
 	OnPeriodicHealTaken   OnPeriodicDamage // Invoked when a hot tick occurs and this unit is the target.
 	OnEncounterStart      OnEncounterStart // Invoked at the start of each encounter, after the pre-pull.
 
+	// Order in which the callbacks of this aura are invoked, relative to other
+	// auras with the same callback on this unit. Higher priorities go first, and
+	// auras with equal priorities go in order of activation.
+	CallbackPriority int32
+
 	// If non-default, stat bonuses from the OnGain callback of this aura will be
 	// included in Character Stats in the UI.
 	BuildPhase CharacterBuildPhase
@@ -111,6 +166,19 @@ type Aura struct {
 	initialized bool
 }
 
+// Reports the ICD statistics of this proc trigger aura in the metrics of the
+// aura it procs, since trigger auras usually have no metrics of their own.
+func (aura *Aura) SetIcdMetricsAura(procAura *Aura) {
+	aura.icdMetricsAura = procAura
+}
+
+func (aura *Aura) icdMetrics() *AuraMetrics {
+	if aura.icdMetricsAura != nil {
+		return &aura.icdMetricsAura.metrics
+	}
+	return &aura.metrics
+}
+
 func (aura *Aura) init(sim *Simulation) {
 	if aura.initialized {
 		return
@@ -153,6 +221,7 @@ func (aura *Aura) doneIteration(sim *Simulation) {
 
 	aura.startTime = 0
 	aura.expires = 0
+	aura.lastSource = nil
 
 	if aura.OnDoneIteration != nil {
 		aura.OnDoneIteration(aura, sim)
@@ -166,18 +235,39 @@ func (aura *Aura) IsActive() bool {
 	return aura.active
 }
 
+// Resets the duration of the aura, or changes it according to its RefreshPolicy
+// if the aura was already active.
 func (aura *Aura) Refresh(sim *Simulation) {
 	if aura.Duration == NeverExpires {
 		aura.expires = NeverExpires
 	} else {
-		aura.expires = sim.CurrentTime + aura.Duration
+		duration := aura.Duration
+		if aura.active {
+			duration = aura.RefreshPolicy.refreshedDuration(duration, aura.RemainingDuration(sim))
+		}
+		aura.expires = sim.CurrentTime + duration
 		if aura.expires < aura.Unit.minExpires {
 			aura.Unit.minExpires = aura.expires
-			sim.rescheduleTracker(aura.expires)
+			// Units whose tracker was removed from the sim aren't advanced, see addTracker.
+			if aura.Unit.tracked {
+				sim.rescheduleTracker(aura.expires)
+			}
 		}
 	}
 }
 
+// Adds extendBy to the remaining duration of the active aura, for effects which
+// extend an aura by less than its full duration. The extension is attributed to
+// the active spell, same as a refresh.
+func (aura *Aura) Extend(sim *Simulation, extendBy time.Duration) {
+	if !aura.IsActive() || aura.expires == NeverExpires {
+		return
+	}
+
+	aura.expires += extendBy
+	aura.TrackRefresh(sim)
+}
+
 func (aura *Aura) GetStacks() int32 {
 	if aura == nil {
 		return 0
@@ -202,8 +292,8 @@ func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
 		return
 	}
 
-	if sim.Log != nil {
-		aura.Unit.Log(sim, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
+	if sim.LogEnabled(LogCategoryAuras, LogLevelDebug) {
+		aura.Unit.LogAt(sim, LogCategoryAuras, LogLevelDebug, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
 	}
 	aura.stacks = newStacks
 	if aura.OnStacksChange != nil {
@@ -258,6 +348,22 @@ func (aura *Aura) RemainingDuration(sim *Simulation) time.Duration {
 	}
 }
 
+// The spell which most recently applied or refreshed this aura, or nil if it
+// was not activated as a result of a spell.
+func (aura *Aura) LastSource() *Spell {
+	return aura.lastSource
+}
+
+// Attributes a refresh of this aura to the spell whose effects are currently
+// being applied. Activate() does this automatically, so this only needs to be
+// called when an aura's duration is extended directly, e.g. via UpdateExpires().
+func (aura *Aura) TrackRefresh(sim *Simulation) {
+	if sim.activeSpell != nil {
+		aura.lastSource = sim.activeSpell
+	}
+	aura.metrics.addSource(sim.activeSpell, true)
+}
+
 func (aura *Aura) StartedAt() time.Duration {
 	return aura.startTime
 }
@@ -389,12 +495,20 @@ type auraTracker struct {
 
 	aurasByTag map[string][]*Aura
 
+	// Indexes of auras, for fast lookups by label or ActionID. If several auras
+	// share an ActionID, the first one registered is indexed.
+	aurasByLabel    map[string]*Aura
+	aurasByActionID map[ActionID]*Aura
+
 	// IDs of Auras that may expire and are currently active, in no particular order.
 	activeAuras []*Aura
 
 	// caches the minimum expires time of all active auras; might be stale (too low) after Deactivate().
 	minExpires time.Duration
 
+	// Whether the sim advances this tracker, see Simulation.addTracker.
+	tracked bool
+
 	// Auras that have a non-nil XXX function set and are currently active.
 	onApplyEffectsAuras        []*Aura
 	onCastCompleteAuras        []*Aura
@@ -407,6 +521,10 @@ type auraTracker struct {
 	onPeriodicHealDealtAuras   []*Aura
 	onPeriodicHealTakenAuras   []*Aura
 	onEncounterStartAuras      []*Aura
+
+	// Whether any aura sets a CallbackPriority, in which case the callback
+	// lists above are kept sorted.
+	hasCallbackPriorities bool
 }
 
 func newAuraTracker() auraTracker {
@@ -414,27 +532,19 @@ func newAuraTracker() auraTracker {
 		resetEffects:           []ResetEffect{},
 		ExclusiveEffectManager: &ExclusiveEffectManager{},
 		aurasByTag:             make(map[string][]*Aura),
+		aurasByLabel:           make(map[string]*Aura),
+		aurasByActionID:        make(map[ActionID]*Aura),
 	}
 }
 
 func (at *auraTracker) GetAura(label string) *Aura {
-	for _, aura := range at.auras {
-		if aura.Label == label {
-			return aura
-		}
-	}
-	return nil
+	return at.aurasByLabel[label]
 }
 func (at *auraTracker) GetAuras() []*Aura {
 	return at.auras
 }
 func (at *auraTracker) GetAuraByID(actionID ActionID) *Aura {
-	for _, aura := range at.auras {
-		if aura.ActionID.SameAction(actionID) {
-			return aura
-		}
-	}
-	return nil
+	return at.aurasByActionID[actionID]
 }
 func (at *auraTracker) GetIcdAuraByID(actionID ActionID) *Aura {
 	for _, aura := range at.auras {
@@ -475,6 +585,7 @@ func (at *auraTracker) registerAura(unit *Unit, aura Aura) *Aura {
 	newAura.Unit = unit
 	newAura.Icd = aura.Icd
 	newAura.metrics.ID = aura.ActionID
+	newAura.disabledByUser = !aura.ActionID.IsEmptyAction() && unit.disabledAuras.contains(aura.ActionID)
 	newAura.activeIndex = Inactive
 	newAura.onApplyEffectsIndex = Inactive
 	newAura.onCastCompleteIndex = Inactive
@@ -489,6 +600,11 @@ func (at *auraTracker) registerAura(unit *Unit, aura Aura) *Aura {
 	newAura.onEncounterStartIndex = Inactive
 
 	at.auras = append(at.auras, newAura)
+	at.hasCallbackPriorities = at.hasCallbackPriorities || newAura.CallbackPriority != 0
+	at.aurasByLabel[newAura.Label] = newAura
+	if _, ok := at.aurasByActionID[newAura.ActionID]; !ok {
+		at.aurasByActionID[newAura.ActionID] = newAura
+	}
 	if newAura.Tag != "" {
 		at.aurasByTag[newAura.Tag] = append(at.aurasByTag[newAura.Tag], newAura)
 	}
@@ -622,6 +738,18 @@ restart:
 	at.minExpires = NeverExpires
 }
 
+// Removes the active auras which were applied by caster and expire with it, see
+// Aura.ExpiresWithOwner.
+func (at *auraTracker) expireAurasAppliedBy(sim *Simulation, caster *Unit) {
+restart:
+	for _, aura := range at.activeAuras {
+		if aura.ExpiresWithOwner && aura.lastSource != nil && aura.lastSource.Unit == caster {
+			aura.Deactivate(sim)
+			goto restart
+		}
+	}
+}
+
 func (at *auraTracker) doneIteration(sim *Simulation) {
 	// deactivate all auras, even permanent ones
 restart:
@@ -644,10 +772,18 @@ restart:
 // Adds a new aura to the simulation. If an aura with the same ID already
 // exists it will be replaced with the new one.
 func (aura *Aura) Activate(sim *Simulation) {
-	aura.metrics.Procs++
+	if aura.disabledByUser {
+		return
+	}
+
+	aura.metrics.addProc(sim, sim.activeSpell)
+	aura.metrics.addSource(sim.activeSpell, aura.IsActive())
+	if sim.activeSpell != nil {
+		aura.lastSource = sim.activeSpell
+	}
 	if aura.IsActive() {
-		if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
-			aura.Unit.Log(sim, "Aura refreshed: %s", aura.ActionID)
+		if sim.LogEnabled(LogCategoryAuras, LogLevelDebug) && !aura.ActionID.IsEmptyAction() {
+			aura.Unit.LogAt(sim, LogCategoryAuras, LogLevelDebug, "Aura refreshed: %s", aura.ActionID)
 		}
 		aura.Refresh(sim)
 		return
@@ -672,9 +808,11 @@ func (aura *Aura) Activate(sim *Simulation) {
 		}
 	}
 
-	aura.active = true
+	aura.claimDebuffSlot(sim)
+
 	aura.startTime = sim.CurrentTime
 	aura.Refresh(sim)
+	aura.active = true
 
 	if aura.Duration != NeverExpires {
 		aura.activeIndex = int32(len(aura.Unit.activeAuras))
@@ -682,62 +820,51 @@ func (aura *Aura) Activate(sim *Simulation) {
 	}
 
 	if aura.OnApplyEffects != nil {
-		aura.onApplyEffectsIndex = int32(len(aura.Unit.onApplyEffectsAuras))
-		aura.Unit.onApplyEffectsAuras = append(aura.Unit.onApplyEffectsAuras, aura)
+		aura.Unit.onApplyEffectsAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onApplyEffectsAuras, aura, onApplyEffectsCallbacks)
 	}
 
 	if aura.OnCastComplete != nil {
-		aura.onCastCompleteIndex = int32(len(aura.Unit.onCastCompleteAuras))
-		aura.Unit.onCastCompleteAuras = append(aura.Unit.onCastCompleteAuras, aura)
+		aura.Unit.onCastCompleteAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onCastCompleteAuras, aura, onCastCompleteCallbacks)
 	}
 
 	if aura.OnSpellHitDealt != nil {
-		aura.onSpellHitDealtIndex = int32(len(aura.Unit.onSpellHitDealtAuras))
-		aura.Unit.onSpellHitDealtAuras = append(aura.Unit.onSpellHitDealtAuras, aura)
+		aura.Unit.onSpellHitDealtAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onSpellHitDealtAuras, aura, onSpellHitDealtCallbacks)
 	}
 
 	if aura.OnSpellHitTaken != nil {
-		aura.onSpellHitTakenIndex = int32(len(aura.Unit.onSpellHitTakenAuras))
-		aura.Unit.onSpellHitTakenAuras = append(aura.Unit.onSpellHitTakenAuras, aura)
+		aura.Unit.onSpellHitTakenAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onSpellHitTakenAuras, aura, onSpellHitTakenCallbacks)
 	}
 
 	if aura.OnPeriodicDamageDealt != nil {
-		aura.onPeriodicDamageDealtIndex = int32(len(aura.Unit.onPeriodicDamageDealtAuras))
-		aura.Unit.onPeriodicDamageDealtAuras = append(aura.Unit.onPeriodicDamageDealtAuras, aura)
+		aura.Unit.onPeriodicDamageDealtAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onPeriodicDamageDealtAuras, aura, onPeriodicDamageDealtCallbacks)
 	}
 
 	if aura.OnPeriodicDamageTaken != nil {
-		aura.onPeriodicDamageTakenIndex = int32(len(aura.Unit.onPeriodicDamageTakenAuras))
-		aura.Unit.onPeriodicDamageTakenAuras = append(aura.Unit.onPeriodicDamageTakenAuras, aura)
+		aura.Unit.onPeriodicDamageTakenAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onPeriodicDamageTakenAuras, aura, onPeriodicDamageTakenCallbacks)
 	}
 
 	if aura.OnHealDealt != nil {
-		aura.onHealDealtIndex = int32(len(aura.Unit.onHealDealtAuras))
-		aura.Unit.onHealDealtAuras = append(aura.Unit.onHealDealtAuras, aura)
+		aura.Unit.onHealDealtAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onHealDealtAuras, aura, onHealDealtCallbacks)
 	}
 
 	if aura.OnHealTaken != nil {
-		aura.onHealTakenIndex = int32(len(aura.Unit.onHealTakenAuras))
-		aura.Unit.onHealTakenAuras = append(aura.Unit.onHealTakenAuras, aura)
+		aura.Unit.onHealTakenAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onHealTakenAuras, aura, onHealTakenCallbacks)
 	}
 
 	if aura.OnPeriodicHealDealt != nil {
-		aura.onPeriodicHealDealtIndex = int32(len(aura.Unit.onPeriodicHealDealtAuras))
-		aura.Unit.onPeriodicHealDealtAuras = append(aura.Unit.onPeriodicHealDealtAuras, aura)
+		aura.Unit.onPeriodicHealDealtAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onPeriodicHealDealtAuras, aura, onPeriodicHealDealtCallbacks)
 	}
 
 	if aura.OnPeriodicHealTaken != nil {
-		aura.onPeriodicHealTakenIndex = int32(len(aura.Unit.onPeriodicHealTakenAuras))
-		aura.Unit.onPeriodicHealTakenAuras = append(aura.Unit.onPeriodicHealTakenAuras, aura)
+		aura.Unit.onPeriodicHealTakenAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onPeriodicHealTakenAuras, aura, onPeriodicHealTakenCallbacks)
 	}
 
 	if aura.OnEncounterStart != nil {
-		aura.onEncounterStartIndex = int32(len(aura.Unit.onEncounterStartAuras))
-		aura.Unit.onEncounterStartAuras = append(aura.Unit.onEncounterStartAuras, aura)
+		aura.Unit.onEncounterStartAuras = aura.Unit.addCallbackAura(sim, aura.Unit.onEncounterStartAuras, aura, onEncounterStartCallbacks)
 	}
 
-	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
-		aura.Unit.Log(sim, "Aura gained: %s", aura.ActionID)
+	if sim.LogEnabled(LogCategoryAuras, LogLevelInfo) && !aura.ActionID.IsEmptyAction() {
+		aura.Unit.LogAt(sim, LogCategoryAuras, LogLevelInfo, "Aura gained: %s", aura.ActionID)
 	}
 
 	// don't invoke possible callbacks until the internal state is consistent
@@ -761,12 +888,12 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		}
 	}
 
-	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
+	if sim.LogEnabled(LogCategoryAuras, LogLevelInfo) && !aura.ActionID.IsEmptyAction() {
 		// fix logging timestamps for lazy aura expiration
 		oldTime := sim.CurrentTime
 		sim.CurrentTime = min(sim.CurrentTime, aura.expires)
-		if sim.Log != nil {
-			aura.Unit.Log(sim, "Aura faded: %s", aura.ActionID)
+		if sim.LogEnabled(LogCategoryAuras, LogLevelInfo) {
+			aura.Unit.LogAt(sim, LogCategoryAuras, LogLevelInfo, "Aura faded: %s", aura.ActionID)
 		}
 		sim.CurrentTime = oldTime
 	}
@@ -783,102 +910,47 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 	}
 
 	if aura.onApplyEffectsIndex != Inactive {
-		removeOnApplyEffectsIndex := aura.onApplyEffectsIndex
-		aura.Unit.onApplyEffectsAuras = removeBySwappingToBack(aura.Unit.onApplyEffectsAuras, removeOnApplyEffectsIndex)
-		if removeOnApplyEffectsIndex < int32(len(aura.Unit.onApplyEffectsAuras)) {
-			aura.Unit.onApplyEffectsAuras[removeOnApplyEffectsIndex].onApplyEffectsIndex = removeOnApplyEffectsIndex
-		}
-		aura.onApplyEffectsIndex = Inactive
+		aura.Unit.onApplyEffectsAuras = aura.Unit.removeCallbackAura(aura.Unit.onApplyEffectsAuras, aura, onApplyEffectsCallbacks)
 	}
 
 	if aura.onCastCompleteIndex != Inactive {
-		removeOnCastCompleteIndex := aura.onCastCompleteIndex
-		aura.Unit.onCastCompleteAuras = removeBySwappingToBack(aura.Unit.onCastCompleteAuras, removeOnCastCompleteIndex)
-		if removeOnCastCompleteIndex < int32(len(aura.Unit.onCastCompleteAuras)) {
-			aura.Unit.onCastCompleteAuras[removeOnCastCompleteIndex].onCastCompleteIndex = removeOnCastCompleteIndex
-		}
-		aura.onCastCompleteIndex = Inactive
+		aura.Unit.onCastCompleteAuras = aura.Unit.removeCallbackAura(aura.Unit.onCastCompleteAuras, aura, onCastCompleteCallbacks)
 	}
 
 	if aura.onSpellHitDealtIndex != Inactive {
-		removeOnSpellHitDealtIndex := aura.onSpellHitDealtIndex
-		aura.Unit.onSpellHitDealtAuras = removeBySwappingToBack(aura.Unit.onSpellHitDealtAuras, removeOnSpellHitDealtIndex)
-		if removeOnSpellHitDealtIndex < int32(len(aura.Unit.onSpellHitDealtAuras)) {
-			aura.Unit.onSpellHitDealtAuras[removeOnSpellHitDealtIndex].onSpellHitDealtIndex = removeOnSpellHitDealtIndex
-		}
-		aura.onSpellHitDealtIndex = Inactive
+		aura.Unit.onSpellHitDealtAuras = aura.Unit.removeCallbackAura(aura.Unit.onSpellHitDealtAuras, aura, onSpellHitDealtCallbacks)
 	}
 
 	if aura.onSpellHitTakenIndex != Inactive {
-		removeOnSpellHitTakenIndex := aura.onSpellHitTakenIndex
-		aura.Unit.onSpellHitTakenAuras = removeBySwappingToBack(aura.Unit.onSpellHitTakenAuras, removeOnSpellHitTakenIndex)
-		if removeOnSpellHitTakenIndex < int32(len(aura.Unit.onSpellHitTakenAuras)) {
-			aura.Unit.onSpellHitTakenAuras[removeOnSpellHitTakenIndex].onSpellHitTakenIndex = removeOnSpellHitTakenIndex
-		}
-		aura.onSpellHitTakenIndex = Inactive
+		aura.Unit.onSpellHitTakenAuras = aura.Unit.removeCallbackAura(aura.Unit.onSpellHitTakenAuras, aura, onSpellHitTakenCallbacks)
 	}
 
 	if aura.onPeriodicDamageDealtIndex != Inactive {
-		removeOnPeriodicDamageDealt := aura.onPeriodicDamageDealtIndex
-		aura.Unit.onPeriodicDamageDealtAuras = removeBySwappingToBack(aura.Unit.onPeriodicDamageDealtAuras, removeOnPeriodicDamageDealt)
-		if removeOnPeriodicDamageDealt < int32(len(aura.Unit.onPeriodicDamageDealtAuras)) {
-			aura.Unit.onPeriodicDamageDealtAuras[removeOnPeriodicDamageDealt].onPeriodicDamageDealtIndex = removeOnPeriodicDamageDealt
-		}
-		aura.onPeriodicDamageDealtIndex = Inactive
+		aura.Unit.onPeriodicDamageDealtAuras = aura.Unit.removeCallbackAura(aura.Unit.onPeriodicDamageDealtAuras, aura, onPeriodicDamageDealtCallbacks)
 	}
 
 	if aura.onPeriodicDamageTakenIndex != Inactive {
-		removeOnPeriodicDamageTaken := aura.onPeriodicDamageTakenIndex
-		aura.Unit.onPeriodicDamageTakenAuras = removeBySwappingToBack(aura.Unit.onPeriodicDamageTakenAuras, removeOnPeriodicDamageTaken)
-		if removeOnPeriodicDamageTaken < int32(len(aura.Unit.onPeriodicDamageTakenAuras)) {
-			aura.Unit.onPeriodicDamageTakenAuras[removeOnPeriodicDamageTaken].onPeriodicDamageTakenIndex = removeOnPeriodicDamageTaken
-		}
-		aura.onPeriodicDamageTakenIndex = Inactive
+		aura.Unit.onPeriodicDamageTakenAuras = aura.Unit.removeCallbackAura(aura.Unit.onPeriodicDamageTakenAuras, aura, onPeriodicDamageTakenCallbacks)
 	}
 
 	if aura.onHealDealtIndex != Inactive {
-		removeOnHealDealtIndex := aura.onHealDealtIndex
-		aura.Unit.onHealDealtAuras = removeBySwappingToBack(aura.Unit.onHealDealtAuras, removeOnHealDealtIndex)
-		if removeOnHealDealtIndex < int32(len(aura.Unit.onHealDealtAuras)) {
-			aura.Unit.onHealDealtAuras[removeOnHealDealtIndex].onHealDealtIndex = removeOnHealDealtIndex
-		}
-		aura.onHealDealtIndex = Inactive
+		aura.Unit.onHealDealtAuras = aura.Unit.removeCallbackAura(aura.Unit.onHealDealtAuras, aura, onHealDealtCallbacks)
 	}
 
 	if aura.onHealTakenIndex != Inactive {
-		removeOnHealTakenIndex := aura.onHealTakenIndex
-		aura.Unit.onHealTakenAuras = removeBySwappingToBack(aura.Unit.onHealTakenAuras, removeOnHealTakenIndex)
-		if removeOnHealTakenIndex < int32(len(aura.Unit.onHealTakenAuras)) {
-			aura.Unit.onHealTakenAuras[removeOnHealTakenIndex].onHealTakenIndex = removeOnHealTakenIndex
-		}
-		aura.onHealTakenIndex = Inactive
+		aura.Unit.onHealTakenAuras = aura.Unit.removeCallbackAura(aura.Unit.onHealTakenAuras, aura, onHealTakenCallbacks)
 	}
 
 	if aura.onPeriodicHealDealtIndex != Inactive {
-		removeOnPeriodicHealDealt := aura.onPeriodicHealDealtIndex
-		aura.Unit.onPeriodicHealDealtAuras = removeBySwappingToBack(aura.Unit.onPeriodicHealDealtAuras, removeOnPeriodicHealDealt)
-		if removeOnPeriodicHealDealt < int32(len(aura.Unit.onPeriodicHealDealtAuras)) {
-			aura.Unit.onPeriodicHealDealtAuras[removeOnPeriodicHealDealt].onPeriodicHealDealtIndex = removeOnPeriodicHealDealt
-		}
-		aura.onPeriodicHealDealtIndex = Inactive
+		aura.Unit.onPeriodicHealDealtAuras = aura.Unit.removeCallbackAura(aura.Unit.onPeriodicHealDealtAuras, aura, onPeriodicHealDealtCallbacks)
 	}
 
 	if aura.onPeriodicHealTakenIndex != Inactive {
-		removeOnPeriodicHealTaken := aura.onPeriodicHealTakenIndex
-		aura.Unit.onPeriodicHealTakenAuras = removeBySwappingToBack(aura.Unit.onPeriodicHealTakenAuras, removeOnPeriodicHealTaken)
-		if removeOnPeriodicHealTaken < int32(len(aura.Unit.onPeriodicHealTakenAuras)) {
-			aura.Unit.onPeriodicHealTakenAuras[removeOnPeriodicHealTaken].onPeriodicHealTakenIndex = removeOnPeriodicHealTaken
-		}
-		aura.onPeriodicHealTakenIndex = Inactive
+		aura.Unit.onPeriodicHealTakenAuras = aura.Unit.removeCallbackAura(aura.Unit.onPeriodicHealTakenAuras, aura, onPeriodicHealTakenCallbacks)
 	}
 
 	if aura.onEncounterStartIndex != Inactive {
-		removeOnEncounterStart := aura.onEncounterStartIndex
-		aura.Unit.onEncounterStartAuras = removeBySwappingToBack(aura.Unit.onEncounterStartAuras, removeOnEncounterStart)
-		if removeOnEncounterStart < int32(len(aura.Unit.onEncounterStartAuras)) {
-			aura.Unit.onEncounterStartAuras[removeOnEncounterStart].onEncounterStartIndex = removeOnEncounterStart
-		}
-		aura.onEncounterStartIndex = Inactive
+		aura.Unit.onEncounterStartAuras = aura.Unit.removeCallbackAura(aura.Unit.onEncounterStartAuras, aura, onEncounterStartCallbacks)
 	}
 
 	// don't invoke possible callbacks until the internal state is consistent
@@ -902,9 +974,86 @@ func removeBySwappingToBack[T any, U constraints.Integer](arr []T, removeIdx U)
 	return arr[:len(arr)-1]
 }
 
+// One of the callback lists of an auraTracker.
+type auraCallbackList struct {
+	name  string
+	index func(aura *Aura) *int32
+}
+
+var (
+	onApplyEffectsCallbacks        = auraCallbackList{"OnApplyEffects", func(aura *Aura) *int32 { return &aura.onApplyEffectsIndex }}
+	onCastCompleteCallbacks        = auraCallbackList{"OnCastComplete", func(aura *Aura) *int32 { return &aura.onCastCompleteIndex }}
+	onSpellHitDealtCallbacks       = auraCallbackList{"OnSpellHitDealt", func(aura *Aura) *int32 { return &aura.onSpellHitDealtIndex }}
+	onSpellHitTakenCallbacks       = auraCallbackList{"OnSpellHitTaken", func(aura *Aura) *int32 { return &aura.onSpellHitTakenIndex }}
+	onPeriodicDamageDealtCallbacks = auraCallbackList{"OnPeriodicDamageDealt", func(aura *Aura) *int32 { return &aura.onPeriodicDamageDealtIndex }}
+	onPeriodicDamageTakenCallbacks = auraCallbackList{"OnPeriodicDamageTaken", func(aura *Aura) *int32 { return &aura.onPeriodicDamageTakenIndex }}
+	onHealDealtCallbacks           = auraCallbackList{"OnHealDealt", func(aura *Aura) *int32 { return &aura.onHealDealtIndex }}
+	onHealTakenCallbacks           = auraCallbackList{"OnHealTaken", func(aura *Aura) *int32 { return &aura.onHealTakenIndex }}
+	onPeriodicHealDealtCallbacks   = auraCallbackList{"OnPeriodicHealDealt", func(aura *Aura) *int32 { return &aura.onPeriodicHealDealtIndex }}
+	onPeriodicHealTakenCallbacks   = auraCallbackList{"OnPeriodicHealTaken", func(aura *Aura) *int32 { return &aura.onPeriodicHealTakenIndex }}
+	onEncounterStartCallbacks      = auraCallbackList{"OnEncounterStart", func(aura *Aura) *int32 { return &aura.onEncounterStartIndex }}
+)
+
+// Adds the aura to a callback list. Without callback priorities it is simply
+// appended, otherwise it goes after all auras with an equal or higher priority.
+func (at *auraTracker) addCallbackAura(sim *Simulation, auras []*Aura, aura *Aura, list auraCallbackList) []*Aura {
+	if !at.hasCallbackPriorities {
+		*list.index(aura) = int32(len(auras))
+		return append(auras, aura)
+	}
+
+	insertIdx := len(auras)
+	for insertIdx > 0 && auras[insertIdx-1].CallbackPriority < aura.CallbackPriority {
+		insertIdx--
+	}
+	// Copied rather than inserted in place, so that callbacks which are being
+	// invoked from the old list keep a consistent snapshot.
+	auras = slices.Concat(auras[:insertIdx], []*Aura{aura}, auras[insertIdx:])
+	for i := insertIdx; i < len(auras); i++ {
+		*list.index(auras[i]) = int32(i)
+	}
+
+	if sim.LogEnabled(LogCategoryAuras, LogLevelDebug) {
+		order := make([]string, len(auras))
+		for i, callbackAura := range auras {
+			order[i] = fmt.Sprintf("%s (%d)", callbackAura.Label, callbackAura.CallbackPriority)
+		}
+		aura.Unit.LogAt(sim, LogCategoryAuras, LogLevelDebug, "%s callback order: %s", list.name, strings.Join(order, ", "))
+	}
+	return auras
+}
+
+// Removes the aura from a callback list, keeping the remaining auras sorted if
+// callback priorities are used.
+func (at *auraTracker) removeCallbackAura(auras []*Aura, aura *Aura, list auraCallbackList) []*Aura {
+	removeIdx := *list.index(aura)
+	*list.index(aura) = Inactive
+
+	if !at.hasCallbackPriorities {
+		auras = removeBySwappingToBack(auras, removeIdx)
+		if removeIdx < int32(len(auras)) {
+			*list.index(auras[removeIdx]) = removeIdx
+		}
+		return auras
+	}
+
+	// Copied for the same reason as in addCallbackAura. Deleting in place would
+	// shift and clear elements of a list which may be iterated right now, e.g.
+	// when an aura deactivates itself in its own callback.
+	auras = slices.Concat(auras[:removeIdx], auras[removeIdx+1:])
+	for i := int(removeIdx); i < len(auras); i++ {
+		*list.index(auras[i]) = int32(i)
+	}
+	return auras
+}
+
 // Invokes the OnApplyEffects event for all tracked Auras.
 func (at *auraTracker) OnApplyEffects(sim *Simulation, target *Unit, spell *Spell) {
 	for _, aura := range at.onApplyEffectsAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
 		aura.OnApplyEffects(aura, sim, target, spell)
 	}
 }
@@ -912,6 +1061,10 @@ func (at *auraTracker) OnApplyEffects(sim *Simulation, target *Unit, spell *Spel
 // Invokes the OnCastComplete event for all tracked Auras.
 func (at *auraTracker) OnCastComplete(sim *Simulation, spell *Spell) {
 	for _, aura := range at.onCastCompleteAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
 		aura.OnCastComplete(aura, sim, spell)
 	}
 }
@@ -942,11 +1095,19 @@ func (at *auraTracker) OnSpellHitTaken(sim *Simulation, spell *Spell, result *Sp
 //	As a buff when caster's dots are ticking.
 func (at *auraTracker) OnPeriodicDamageDealt(sim *Simulation, spell *Spell, result *SpellResult) {
 	for _, aura := range at.onPeriodicDamageDealtAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
 		aura.OnPeriodicDamageDealt(aura, sim, spell, result)
 	}
 }
 func (at *auraTracker) OnPeriodicDamageTaken(sim *Simulation, spell *Spell, result *SpellResult) {
 	for _, aura := range at.onPeriodicDamageTakenAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
 		aura.OnPeriodicDamageTaken(aura, sim, spell, result)
 	}
 }
@@ -977,17 +1138,29 @@ func (at *auraTracker) OnHealTaken(sim *Simulation, spell *Spell, result *SpellR
 //	As a buff when caster's dots are ticking.
 func (at *auraTracker) OnPeriodicHealDealt(sim *Simulation, spell *Spell, result *SpellResult) {
 	for _, aura := range at.onPeriodicHealDealtAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
 		aura.OnPeriodicHealDealt(aura, sim, spell, result)
 	}
 }
 func (at *auraTracker) OnPeriodicHealTaken(sim *Simulation, spell *Spell, result *SpellResult) {
 	for _, aura := range at.onPeriodicHealTakenAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
 		aura.OnPeriodicHealTaken(aura, sim, spell, result)
 	}
 }
 
 func (at *auraTracker) OnEncounterStart(sim *Simulation) {
 	for _, aura := range at.onEncounterStartAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
 		aura.OnEncounterStart(aura, sim)
 	}
 }
@@ -1122,8 +1295,12 @@ func (aura *Aura) SaveState(sim *Simulation) AuraState {
 		return AuraState{}
 	}
 
+	remainingDuration := aura.expires - sim.CurrentTime
+	if aura.expires == NeverExpires {
+		remainingDuration = NeverExpires
+	}
 	return AuraState{
-		RemainingDuration: aura.expires - sim.CurrentTime,
+		RemainingDuration: remainingDuration,
 		Stacks:            aura.stacks,
 	}
 }
@@ -1133,7 +1310,11 @@ func (aura *Aura) RestoreState(state AuraState, sim *Simulation) {
 		aura.Activate(sim)
 	}
 
-	aura.UpdateExpires(state.RemainingDuration + sim.CurrentTime)
+	if state.RemainingDuration == NeverExpires {
+		aura.UpdateExpires(NeverExpires)
+	} else {
+		aura.UpdateExpires(state.RemainingDuration + sim.CurrentTime)
+	}
 	if aura.MaxStacks > 0 {
 		aura.SetStacks(sim, state.Stacks)
 	}
@@ -91,6 +91,11 @@ func ApplyProcTriggerCallback(unit *Unit, procAura *Aura, config ProcTrigger) {
 			return
 		}
 		if icd.Duration != 0 && !icd.IsReady(sim) {
+			if dpm != nil {
+				aura.icdMetrics().addIcdBlocked(dpm.Chance(spell.ProcMask, sim))
+			} else {
+				aura.icdMetrics().addIcdBlocked(config.ProcChance)
+			}
 			return
 		}
 		if config.ExtraCondition != nil && !config.ExtraCondition(sim, spell, result) {
@@ -105,6 +110,9 @@ func ApplyProcTriggerCallback(unit *Unit, procAura *Aura, config ProcTrigger) {
 		if icd.Duration != 0 {
 			icd.Use(sim)
 		}
+		if sim.LogEnabled(LogCategoryProcs, LogLevelDebug) {
+			unit.LogAt(sim, LogCategoryProcs, LogLevelDebug, "%s procced from %s", config.Name, spell.ActionID)
+		}
 		handler(sim, spell, result)
 	}
 
@@ -151,6 +159,9 @@ func ApplyProcTriggerCallback(unit *Unit, procAura *Aura, config ProcTrigger) {
 			if icd.Duration != 0 {
 				icd.Use(sim)
 			}
+			if sim.LogEnabled(LogCategoryProcs, LogLevelDebug) {
+				unit.LogAt(sim, LogCategoryProcs, LogLevelDebug, "%s procced from %s", config.Name, spell.ActionID)
+			}
 			handler(sim, spell, nil)
 		}
 	}
@@ -178,6 +189,9 @@ func ApplyProcTriggerCallback(unit *Unit, procAura *Aura, config ProcTrigger) {
 			if icd.Duration != 0 {
 				icd.Use(sim)
 			}
+			if sim.LogEnabled(LogCategoryProcs, LogLevelDebug) {
+				unit.LogAt(sim, LogCategoryProcs, LogLevelDebug, "%s procced from %s", config.Name, spell.ActionID)
+			}
 			handler(sim, spell, &SpellResult{Target: target})
 		}
 	}
@@ -359,8 +373,8 @@ func (character *Character) NewTemporaryStatsAuraWrapped(auraLabel string, actio
 		ActionID: actionID,
 		Duration: duration,
 		OnGain: func(aura *Aura, sim *Simulation) {
-			if sim.Log != nil {
-				character.Log(sim, "Gained %s from %s.", buffs.FlatString(), actionID)
+			if sim.LogEnabled(LogCategoryProcs, LogLevelDebug) {
+				character.LogAt(sim, LogCategoryProcs, LogLevelDebug, "Gained %s from %s.", buffs.FlatString(), actionID)
 			}
 			character.AddStatsDynamic(sim, buffs)
 
@@ -373,8 +387,8 @@ func (character *Character) NewTemporaryStatsAuraWrapped(auraLabel string, actio
 			}
 		},
 		OnExpire: func(aura *Aura, sim *Simulation) {
-			if sim.Log != nil {
-				character.Log(sim, "Lost %s from fading %s.", buffs.FlatString(), actionID)
+			if sim.LogEnabled(LogCategoryProcs, LogLevelDebug) {
+				character.LogAt(sim, LogCategoryProcs, LogLevelDebug, "Lost %s from fading %s.", buffs.FlatString(), actionID)
 			}
 			character.AddStatsDynamic(sim, buffs.Invert())
 
@@ -485,6 +499,107 @@ func (parentAura *Aura) AttachStatBuff(stat stats.Stat, value float64) *Aura {
 	return parentAura
 }
 
+type StatBonusMode uint8
+
+const (
+	// The bonus is computed once when the aura is gained and removed unchanged when it expires.
+	StatBonusSnapshot StatBonusMode = iota
+	// The bonus is tracked by the stat dependency engine and follows the source stat while the aura is active.
+	StatBonusDynamic
+)
+
+// A bonus to TargetStat equal to Ratio times the current SourceStat, e.g. "increases your Spirit
+// by 50% of your Intellect". Using the same stat for both increases it by Ratio percent.
+type AuraStatBonusConfig struct {
+	Mode       StatBonusMode
+	SourceStat stats.Stat
+	TargetStat stats.Stat
+	Ratio      float64
+
+	// With TargetStat Health, current health follows the added maximum health like
+	// UpdateMaxHealth: it's gained with the aura and removed on expire. Required for Health.
+	HealthMetrics *ResourceMetrics
+
+	// Logs the difference between the snapshotted and the dynamic bonus each time a snapshotted
+	// aura expires. Useful for verifying which behavior matches the game, only applies with logging.
+	LogSnapshotDiff bool
+}
+
+func (config AuraStatBonusConfig) currentBonus(unit *Unit) float64 {
+	return unit.GetStat(config.SourceStat) * config.Ratio
+}
+
+// Attaches a stat bonus derived from another stat to a parent Aura, see AuraStatBonusConfig.
+// Returns parent aura for chaining
+func (parentAura *Aura) AttachStatBonus(config AuraStatBonusConfig) *Aura {
+	unit := parentAura.Unit
+	if config.TargetStat == stats.Health && config.HealthMetrics == nil {
+		panic("Health bonuses require HealthMetrics")
+	}
+
+	if config.Mode == StatBonusDynamic {
+		var dep *stats.StatDependency
+		if config.SourceStat == config.TargetStat {
+			dep = unit.NewDynamicMultiplyStat(config.SourceStat, 1+config.Ratio)
+		} else {
+			dep = unit.NewDynamicStatDependency(config.SourceStat, config.TargetStat, config.Ratio)
+		}
+		if config.TargetStat != stats.Health {
+			return parentAura.AttachStatDependency(dep)
+		}
+
+		parentAura.ApplyOnGain(func(_ *Aura, sim *Simulation) {
+			maxHealth := unit.MaxHealth()
+			unit.EnableDynamicStatDep(sim, dep)
+			unit.GainHealth(sim, max(0, unit.MaxHealth()-maxHealth), config.HealthMetrics)
+		})
+		parentAura.ApplyOnExpire(func(_ *Aura, sim *Simulation) {
+			maxHealth := unit.MaxHealth()
+			unit.DisableDynamicStatDep(sim, dep)
+			unit.RemoveHealth(sim, max(0, min(maxHealth-unit.MaxHealth(), unit.CurrentHealth()-1)))
+		})
+		if parentAura.IsActive() {
+			unit.StatDependencyManager.EnableDynamicStatDep(dep)
+		}
+		return parentAura
+	}
+
+	var snapshot stats.Stats
+	addSnapshot := func(sim *Simulation, bonus stats.Stats) {
+		if config.TargetStat == stats.Health {
+			unit.UpdateMaxHealth(sim, bonus[stats.Health], config.HealthMetrics)
+		} else {
+			unit.AddStatsDynamic(sim, bonus)
+		}
+	}
+
+	parentAura.ApplyOnGain(func(aura *Aura, sim *Simulation) {
+		snapshot = stats.Stats{}
+		snapshot[config.TargetStat] = config.currentBonus(unit)
+		addSnapshot(sim, snapshot)
+	})
+
+	parentAura.ApplyOnExpire(func(aura *Aura, sim *Simulation) {
+		if config.LogSnapshotDiff && sim.LogEnabled(LogCategoryOther, LogLevelDebug) {
+			dynamicBonus := config.currentBonus(unit)
+			if config.SourceStat == config.TargetStat {
+				dynamicBonus -= snapshot[config.TargetStat] * config.Ratio
+			}
+			aura.Unit.LogAt(sim, LogCategoryOther, LogLevelDebug, "%s snapshotted %0.2f %s, a dynamic bonus would now be %0.2f (diff %0.2f)",
+				aura.Label, snapshot[config.TargetStat], config.TargetStat.StatName(), dynamicBonus, dynamicBonus-snapshot[config.TargetStat])
+		}
+		addSnapshot(sim, snapshot.Invert())
+	})
+
+	if parentAura.IsActive() {
+		snapshot = stats.Stats{}
+		snapshot[config.TargetStat] = config.currentBonus(unit)
+		unit.AddStats(snapshot)
+	}
+
+	return parentAura
+}
+
 // Attaches a multiplicative PseudoStat buff to a parent Aura
 // Returns parent aura for chaining
 func (parentAura *Aura) AttachMultiplicativePseudoStatBuff(fieldPointer *float64, multiplier float64) *Aura {
@@ -631,12 +746,11 @@ func (unit *Unit) NewDamageAbsorptionAura(config AbsorptionAuraConfig) *DamageAb
 
 	unit.AddDynamicDamageTakenModifier(func(sim *Simulation, spell *Spell, result *SpellResult, isPeriodic bool) {
 		if aura.Aura.IsActive() && (result.Damage > 0) && extraSpellCheck(sim, spell, result, isPeriodic) {
-			absorbedDamage := min(aura.ShieldStrength, result.Damage*config.DamageMultiplier)
-			result.Damage -= absorbedDamage
+			absorbedDamage := result.Absorb(min(aura.ShieldStrength, result.Damage*config.DamageMultiplier))
 			aura.ShieldStrength -= absorbedDamage
 
-			if sim.Log != nil {
-				unit.Log(sim, "%s absorbed %.1f damage, new shield strength: %.1f", aura.Label, absorbedDamage, aura.ShieldStrength)
+			if sim.LogEnabled(LogCategoryDamage, LogLevelDebug) {
+				unit.LogAt(sim, LogCategoryDamage, LogLevelDebug, "%s absorbed %.1f damage, new shield strength: %.1f", aura.Label, absorbedDamage, aura.ShieldStrength)
 			}
 
 			for _, callback := range aura.OnDamageAbsorbed {
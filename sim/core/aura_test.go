@@ -0,0 +1,356 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func newUnitWithAuras(numAuras int) *Unit {
+	unit := &Unit{Type: PlayerUnit, auraTracker: newAuraTracker()}
+	for i := range numAuras {
+		unit.RegisterAura(Aura{
+			Label:    fmt.Sprintf("Aura %d", i),
+			ActionID: ActionID{SpellID: int32(1000 + i)},
+		})
+	}
+	return unit
+}
+
+func TestAuraLookupByLabelAndID(t *testing.T) {
+	unit := newUnitWithAuras(10)
+	first := unit.RegisterAura(Aura{Label: "Shared 1", ActionID: ActionID{SpellID: 5}})
+	unit.RegisterAura(Aura{Label: "Shared 2", ActionID: ActionID{SpellID: 5}})
+
+	if aura := unit.GetAura("Aura 3"); aura == nil || aura.ActionID.SpellID != 1003 {
+		t.Fatalf("Expected to find Aura 3 by label")
+	}
+	if aura := unit.GetAuraByID(ActionID{SpellID: 1007}); aura == nil || aura.Label != "Aura 7" {
+		t.Fatalf("Expected to find Aura 7 by ActionID")
+	}
+	if aura := unit.GetAuraByID(ActionID{SpellID: 5}); aura != first {
+		t.Fatalf("Expected the first registered aura for a shared ActionID")
+	}
+	if unit.GetAura("Missing") != nil || unit.GetAuraByID(ActionID{SpellID: 1007, Tag: 1}) != nil {
+		t.Fatalf("Expected no aura for unknown labels or tags")
+	}
+}
+
+func BenchmarkAuraLookup(b *testing.B) {
+	unit := newUnitWithAuras(200)
+	label := "Aura 199"
+	actionID := ActionID{SpellID: 1199}
+
+	b.Run("GetAura", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if unit.GetAura(label) == nil {
+				b.Fatal("missing aura")
+			}
+		}
+	})
+	b.Run("GetAuraByID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if unit.GetAuraByID(actionID) == nil {
+				b.Fatal("missing aura")
+			}
+		}
+	})
+
+	// Previous implementation, for comparison.
+	b.Run("LinearScanByLabel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var found *Aura
+			for _, aura := range unit.GetAuras() {
+				if aura.Label == label {
+					found = aura
+					break
+				}
+			}
+			if found == nil {
+				b.Fatal("missing aura")
+			}
+		}
+	})
+	b.Run("LinearScanByID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var found *Aura
+			for _, aura := range unit.GetAuras() {
+				if aura.ActionID.SameAction(actionID) {
+					found = aura
+					break
+				}
+			}
+			if found == nil {
+				b.Fatal("missing aura")
+			}
+		}
+	})
+}
+
+func TestAuraCallbackPriority(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Type: PlayerUnit, auraTracker: newAuraTracker()}
+
+	var order []string
+	registerProcAura := func(label string, priority int32) *Aura {
+		return unit.RegisterAura(Aura{
+			Label:            label,
+			Duration:         NeverExpires,
+			CallbackPriority: priority,
+			OnSpellHitDealt: func(aura *Aura, _ *Simulation, _ *Spell, _ *SpellResult) {
+				order = append(order, aura.Label)
+			},
+		})
+	}
+	low := registerProcAura("Low", -1)
+	defaultA := registerProcAura("Default A", 0)
+	defaultB := registerProcAura("Default B", 0)
+	high := registerProcAura("High", 10)
+
+	for _, aura := range []*Aura{defaultB, low, high, defaultA} {
+		aura.Activate(sim)
+	}
+	unit.OnSpellHitDealt(sim, nil, &SpellResult{})
+	if expected := []string{"High", "Default B", "Default A", "Low"}; !slices.Equal(order, expected) {
+		t.Fatalf("Expected callbacks in order %v, got %v", expected, order)
+	}
+
+	order = nil
+	high.Deactivate(sim)
+	defaultB.Deactivate(sim)
+	defaultB.Activate(sim)
+	unit.OnSpellHitDealt(sim, nil, &SpellResult{})
+	if expected := []string{"Default A", "Default B", "Low"}; !slices.Equal(order, expected) {
+		t.Fatalf("Expected callbacks in order %v, got %v", expected, order)
+	}
+}
+
+func TestAuraCallbackPriorityDeactivateInCallback(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Type: PlayerUnit, auraTracker: newAuraTracker()}
+
+	var order []string
+	registerProcAura := func(label string, priority int32, deactivate bool) *Aura {
+		return unit.RegisterAura(Aura{
+			Label:            label,
+			Duration:         NeverExpires,
+			CallbackPriority: priority,
+			OnCastComplete: func(aura *Aura, sim *Simulation, _ *Spell) {
+				order = append(order, aura.Label)
+				if deactivate {
+					aura.Deactivate(sim)
+				}
+			},
+		})
+	}
+	auras := []*Aura{
+		registerProcAura("High", 10, false),
+		registerProcAura("Consumed", 5, true),
+		registerProcAura("Default", 0, false),
+		registerProcAura("Low", -1, false),
+	}
+	for _, aura := range auras {
+		aura.Activate(sim)
+	}
+
+	unit.OnCastComplete(sim, nil)
+	if expected := []string{"High", "Consumed", "Default", "Low"}; !slices.Equal(order, expected) {
+		t.Fatalf("Expected callbacks in order %v, got %v", expected, order)
+	}
+
+	order = nil
+	unit.OnCastComplete(sim, nil)
+	if expected := []string{"High", "Default", "Low"}; !slices.Equal(order, expected) {
+		t.Fatalf("Expected callbacks in order %v after the aura deactivated itself, got %v", expected, order)
+	}
+}
+
+func TestAuraRefreshPolicy(t *testing.T) {
+	testCases := []struct {
+		policy   RefreshPolicy
+		expected time.Duration
+	}{
+		{RefreshPolicyOverwrite, time.Second * 10},
+		{RefreshPolicyPandemic, time.Second * 13},
+		{RefreshPolicyExtend, time.Second * 16},
+		{RefreshPolicyIgnore, time.Second * 6},
+	}
+
+	for _, testCase := range testCases {
+		sim := &Simulation{}
+		unit := &Unit{Type: PlayerUnit, auraTracker: newAuraTracker()}
+		aura := unit.RegisterAura(Aura{
+			Label:         "Refreshed",
+			Duration:      time.Second * 10,
+			RefreshPolicy: testCase.policy,
+		})
+
+		aura.Activate(sim)
+		sim.CurrentTime = time.Second * 4
+		aura.Activate(sim)
+
+		if remaining := aura.RemainingDuration(sim); remaining != testCase.expected {
+			t.Fatalf("Expected %s remaining with policy %d, got %s", testCase.expected, testCase.policy, remaining)
+		}
+	}
+}
+
+func TestAuraExtend(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Type: PlayerUnit, auraTracker: newAuraTracker()}
+	aura := unit.RegisterAura(Aura{
+		Label:    "Extended",
+		Duration: time.Second * 10,
+	})
+
+	// Inactive auras aren't extended into being active.
+	aura.Extend(sim, time.Second*3)
+	if aura.IsActive() {
+		t.Fatalf("Expected the extension not to activate the aura")
+	}
+
+	aura.Activate(sim)
+	sim.CurrentTime = time.Second * 4
+	aura.Extend(sim, time.Second*3)
+	if remaining := aura.RemainingDuration(sim); remaining != time.Second*9 {
+		t.Fatalf("Expected 9s remaining after the extension, got %s", remaining)
+	}
+}
+
+func TestAuraSourceIsInnermostSpell(t *testing.T) {
+	var outerSpell, procSpell *Spell
+	var outerAura, procAura *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+		outerAura = fa.RegisterAura(Aura{Label: "Outer Aura", ActionID: ActionID{SpellID: 1001}, Duration: time.Second * 10})
+		procAura = fa.RegisterAura(Aura{Label: "Proc Aura", ActionID: ActionID{SpellID: 1002}, Duration: time.Second * 10})
+
+		procSpell = fa.RegisterSpell(SpellConfig{
+			ActionID: ActionID{SpellID: 1003},
+			ProcMask: ProcMaskEmpty,
+			ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
+				procAura.Activate(sim)
+			},
+		})
+		outerSpell = fa.RegisterSpell(SpellConfig{
+			ActionID: ActionID{SpellID: 1004},
+			ProcMask: ProcMaskSpellDamage,
+			ApplyEffects: func(sim *Simulation, target *Unit, _ *Spell) {
+				procSpell.Cast(sim, target)
+				outerAura.Activate(sim)
+			},
+		})
+	})
+
+	outerSpell.Cast(sim, sim.Encounter.AllTargetUnits[0])
+
+	if procAura.LastSource() != procSpell {
+		t.Fatalf("Expected the proc aura to be credited to the proc spell, got %v", procAura.LastSource())
+	}
+	if outerAura.LastSource() != outerSpell {
+		t.Fatalf("Expected the outer aura to be credited to the outer spell after the proc, got %v", outerAura.LastSource())
+	}
+	if procAura.metrics.sources[outerSpell.ActionID] != nil {
+		t.Fatalf("Expected no applications of the proc aura to be credited to the outer spell")
+	}
+}
+
+func TestAttachStatBonus(t *testing.T) {
+	var snapshotAura, dynamicAura *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+		snapshotAura = fa.RegisterAura(Aura{Label: "Snapshot Bonus", Duration: time.Second * 10}).AttachStatBonus(AuraStatBonusConfig{
+			Mode:       StatBonusSnapshot,
+			SourceStat: stats.Intellect,
+			TargetStat: stats.Spirit,
+			Ratio:      0.5,
+		})
+		dynamicAura = fa.RegisterAura(Aura{Label: "Dynamic Bonus", Duration: time.Second * 10}).AttachStatBonus(AuraStatBonusConfig{
+			Mode:       StatBonusDynamic,
+			SourceStat: stats.Stamina,
+			TargetStat: stats.Stamina,
+			Ratio:      0.5,
+		})
+	})
+	unit := snapshotAura.Unit
+
+	baseSpirit := unit.GetStat(stats.Spirit)
+	baseStamina := unit.GetStat(stats.Stamina)
+	intellect := unit.GetStat(stats.Intellect)
+
+	snapshotAura.Activate(sim)
+	dynamicAura.Activate(sim)
+	if got, want := unit.GetStat(stats.Spirit), baseSpirit+0.5*intellect; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("Expected snapshot spirit %0.2f on gain, got %0.2f", want, got)
+	}
+	if got, want := unit.GetStat(stats.Stamina), baseStamina*1.5; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("Expected dynamic stamina %0.2f on gain, got %0.2f", want, got)
+	}
+
+	unit.AddStatsDynamic(sim, stats.Stats{stats.Intellect: 1000, stats.Stamina: 1000})
+	boostedStamina := unit.GetStat(stats.Stamina)
+	if got, want := unit.GetStat(stats.Spirit), baseSpirit+0.5*intellect; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("Expected snapshot spirit to stay at %0.2f, got %0.2f", want, got)
+	}
+
+	snapshotAura.Deactivate(sim)
+	dynamicAura.Deactivate(sim)
+	if got := unit.GetStat(stats.Spirit); math.Abs(got-baseSpirit) > 1e-6 {
+		t.Fatalf("Expected spirit to return to %0.2f on expire, got %0.2f", baseSpirit, got)
+	}
+	if got, want := boostedStamina, unit.GetStat(stats.Stamina)*1.5; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("Expected dynamic stamina to follow the added stamina to %0.2f, got %0.2f", want, got)
+	}
+}
+
+func TestAttachStatBonusHealth(t *testing.T) {
+	var snapshotAura, dynamicAura *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+		fa.AddStat(stats.Stamina, 1000)
+		metrics := fa.NewHealthMetrics(ActionID{SpellID: 1})
+		snapshotAura = fa.RegisterAura(Aura{Label: "Snapshot Health", Duration: time.Second * 10}).AttachStatBonus(AuraStatBonusConfig{
+			Mode:          StatBonusSnapshot,
+			SourceStat:    stats.Health,
+			TargetStat:    stats.Health,
+			Ratio:         0.3,
+			HealthMetrics: metrics,
+		})
+		dynamicAura = fa.RegisterAura(Aura{Label: "Dynamic Health", Duration: time.Second * 10}).AttachStatBonus(AuraStatBonusConfig{
+			Mode:          StatBonusDynamic,
+			SourceStat:    stats.Health,
+			TargetStat:    stats.Health,
+			Ratio:         0.3,
+			HealthMetrics: metrics,
+		})
+	})
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	baseHealth := unit.MaxHealth()
+
+	for _, aura := range []*Aura{snapshotAura, dynamicAura} {
+		aura.Activate(sim)
+		if got, want := unit.MaxHealth(), baseHealth*1.3; math.Abs(got-want) > 1e-6 || math.Abs(unit.CurrentHealth()-got) > 1e-6 {
+			t.Fatalf("Expected %s to raise current and maximum health to %0.2f, got %0.2f and %0.2f", aura.Label, want, unit.CurrentHealth(), got)
+		}
+
+		unit.AddStatsDynamic(sim, stats.Stats{stats.Stamina: 1000})
+		boostedHealth := unit.MaxHealth()
+		unit.AddStatsDynamic(sim, stats.Stats{stats.Stamina: -1000})
+		if aura == dynamicAura {
+			if got, want := boostedHealth, baseHealth*1.3+14000*1.3; math.Abs(got-want) > 1e-6 {
+				t.Fatalf("Expected the dynamic bonus to follow Stamina to %0.2f, got %0.2f", want, got)
+			}
+		} else if got, want := boostedHealth, baseHealth*1.3+14000; math.Abs(got-want) > 1e-6 {
+			t.Fatalf("Expected the snapshot bonus to stay fixed, got %0.2f instead of %0.2f", got, want)
+		}
+
+		aura.Deactivate(sim)
+		if got := unit.MaxHealth(); math.Abs(got-baseHealth) > 1e-6 || math.Abs(unit.CurrentHealth()-baseHealth) > 1e-6 {
+			t.Fatalf("Expected %s to return health to %0.2f on expire, got %0.2f and %0.2f", aura.Label, baseHealth, unit.CurrentHealth(), got)
+		}
+	}
+}
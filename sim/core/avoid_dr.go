@@ -30,14 +30,14 @@ var AvoidanceDRByClass = map[proto.Class]DiminishingReturnsConstants{
 func (unit *Unit) GetDiminishedDodgeChance() float64 {
 	// undiminished Dodge % = D
 	// diminished Dodge % = (D * Cd)/((k*Cd) + D)
-	dodgePercent := unit.stats[stats.DodgeRating] / DodgeRatingPerDodgePercent
+	dodgePercent := Ratings.DodgePercent(unit.stats[stats.DodgeRating])
 	return (dodgePercent * unit.avoidanceParams.c_d) / (unit.avoidanceParams.k*unit.avoidanceParams.c_d + dodgePercent) / 100
 }
 
 func (unit *Unit) GetDiminishedParryChance() float64 {
 	// undiminished Parry % = P
 	// diminished Parry % = (P * Cp)/((k*Cp) + P)
-	parryPercent := unit.stats[stats.ParryRating] / ParryRatingPerParryPercent
+	parryPercent := Ratings.ParryPercent(unit.stats[stats.ParryRating])
 	return (parryPercent * unit.avoidanceParams.c_p) / (unit.avoidanceParams.k*unit.avoidanceParams.c_p + parryPercent) / 100
 }
 
@@ -212,6 +212,11 @@ func applyBuffEffects(agent Agent, raidBuffs *proto.RaidBuffs, _ *proto.PartyBuf
 		registerGuardianSpiritCD(agent, individual.GuardianSpiritCount)
 		registerRallyingCryCD(agent, individual.RallyingCryCount)
 		registerShatteringThrowCD(agent, individual.ShatteringThrowCount)
+
+		// Mana regen
+		registerInnervateCD(agent, individual.InnervateCount, individual.InnervateCasterSpirit)
+		registerHymnOfHopeCD(agent, individual.HymnOfHopeCount)
+		applyReplenishment(char, individual.ReplenishmentUptime)
 	}
 }
 
@@ -1228,6 +1233,158 @@ func ManaTideTotemAura(character *Character, actionTag int32) *Aura {
 	}).AttachStatDependency(dep)
 }
 
+var InnervateActionID = ActionID{SpellID: 29166}
+var InnervateAuraTag = "Innervate"
+
+const InnervateDuration = time.Second * 10
+const InnervateCD = time.Minute * 3
+
+// Innervate restores this fraction of the casting druid's Spirit every second.
+const InnervateSpiritMultiplier = 0.5
+
+// Spirit assumed for the druid casting external Innervates, if not set.
+const DefaultInnervateCasterSpirit = 10000
+
+func registerInnervateCD(agent Agent, numInnervates int32, casterSpirit float64) {
+	character := agent.GetCharacter()
+	if numInnervates == 0 || !character.HasManaBar() {
+		return
+	}
+	if casterSpirit <= 0 {
+		casterSpirit = DefaultInnervateCasterSpirit
+	}
+
+	innervateAura := InnervateAura(character, -1, func() float64 { return casterSpirit })
+	totalMana := InnervateSpiritMultiplier * casterSpirit * InnervateDuration.Seconds()
+
+	registerExternalConsecutiveCDApproximation(
+		agent,
+		externalConsecutiveCDApproximation{
+			ActionID:         InnervateActionID.WithTag(-1),
+			AuraTag:          InnervateAuraTag,
+			CooldownPriority: CooldownPriorityDefault,
+			RelatedSelfBuff:  innervateAura,
+			AuraDuration:     InnervateDuration,
+			AuraCD:           InnervateCD,
+			Type:             CooldownTypeMana,
+			ShouldActivate: func(sim *Simulation, character *Character) bool {
+				// Only use once the full restore fits into the mana pool.
+				return character.MaxMana()-character.CurrentMana() >= totalMana
+			},
+			AddAura: func(sim *Simulation, character *Character) {
+				innervateAura.Activate(sim)
+			},
+		},
+		numInnervates)
+}
+
+// Innervate on the character, restoring mana based on the Spirit of the
+// casting druid when it's applied.
+func InnervateAura(character *Character, actionTag int32, casterSpirit func() float64) *Aura {
+	actionID := InnervateActionID.WithTag(actionTag)
+	manaMetrics := character.NewManaMetrics(actionID)
+	return character.GetOrRegisterAura(Aura{
+		Label:    "Innervate-" + actionID.String(),
+		Tag:      InnervateAuraTag,
+		ActionID: actionID,
+		Duration: InnervateDuration,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			manaPerSecond := InnervateSpiritMultiplier * casterSpirit()
+			StartPeriodicAction(sim, PeriodicActionOptions{
+				Period:   time.Second,
+				NumTicks: int(InnervateDuration / time.Second),
+				OnAction: func(sim *Simulation) {
+					character.AddMana(sim, manaPerSecond, manaMetrics)
+				},
+			})
+		},
+	})
+}
+
+var HymnOfHopeActionID = ActionID{SpellID: 64901}
+var HymnOfHopeAuraTag = "HymnOfHope"
+
+const HymnOfHopeDuration = time.Second * 8
+const HymnOfHopeCD = time.Minute * 6
+
+func registerHymnOfHopeCD(agent Agent, numHymnsOfHope int32) {
+	character := agent.GetCharacter()
+	if numHymnsOfHope == 0 || !character.HasManaBar() {
+		return
+	}
+
+	hymnAura := HymnOfHopeAura(character, -1)
+
+	registerExternalConsecutiveCDApproximation(
+		agent,
+		externalConsecutiveCDApproximation{
+			ActionID:         HymnOfHopeActionID.WithTag(-1),
+			AuraTag:          HymnOfHopeAuraTag,
+			CooldownPriority: CooldownPriorityDefault,
+			RelatedSelfBuff:  hymnAura,
+			AuraDuration:     HymnOfHopeDuration,
+			AuraCD:           HymnOfHopeCD,
+			Type:             CooldownTypeMana,
+			ShouldActivate: func(sim *Simulation, character *Character) bool {
+				// Hymn targets players which are low on mana.
+				return character.CurrentManaPercent() < 0.5
+			},
+			AddAura: func(sim *Simulation, character *Character) {
+				hymnAura.Activate(sim)
+			},
+		},
+		numHymnsOfHope)
+}
+
+// Restores 2% mana every 2s and increases maximum mana by 15% while active.
+func HymnOfHopeAura(character *Character, actionTag int32) *Aura {
+	actionID := HymnOfHopeActionID.WithTag(actionTag)
+	manaMetrics := character.NewManaMetrics(actionID)
+	dep := character.NewDynamicMultiplyStat(stats.Mana, 1.15)
+	return character.GetOrRegisterAura(Aura{
+		Label:    "HymnOfHope-" + actionID.String(),
+		Tag:      HymnOfHopeAuraTag,
+		ActionID: actionID,
+		Duration: HymnOfHopeDuration,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			StartPeriodicAction(sim, PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: 4,
+				OnAction: func(sim *Simulation) {
+					character.AddMana(sim, 0.02*character.MaxMana(), manaMetrics)
+				},
+			})
+		},
+	}).AttachStatDependency(dep)
+}
+
+var ReplenishmentActionID = ActionID{SpellID: 57669}
+
+// Replenishment restores 1% of maximum mana every 10s. The uptime scales the
+// restore, instead of modeling when the sources apply it.
+func applyReplenishment(character *Character, uptime float64) {
+	if uptime <= 0 || !character.HasManaBar() {
+		return
+	}
+	uptime = min(uptime, 1)
+
+	manaMetrics := character.NewManaMetrics(ReplenishmentActionID)
+	MakePermanent(character.RegisterAura(Aura{
+		Label:    "Replenishment",
+		ActionID: ReplenishmentActionID,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			StartPeriodicAction(sim, PeriodicActionOptions{
+				Period: time.Second,
+				OnAction: func(sim *Simulation) {
+					if aura.IsActive() {
+						character.AddMana(sim, 0.001*uptime*character.MaxMana(), manaMetrics)
+					}
+				},
+			})
+		},
+	}))
+}
+
 const StormLashAuraTag = "StormLash"
 const StormLashDuration = time.Second * 10
 const StormLashCD = time.Minute * 5
@@ -1353,11 +1510,11 @@ func StormLashAura(character *Character, actionTag int32) *Aura {
 		min, max := ApplyVarianceMinMax(avg, 0.30)
 		damage = sim.RollWithLabel(min, max, StormLashAuraTag)
 
-		if sim.Log != nil {
+		if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
 			var chosenStat = Ternary(scaledAP > scaledSP, stats.AttackPower, stats.SpellPower)
 			var statValue = Ternary(chosenStat == stats.AttackPower, ap, sp)
 
-			character.Log(sim, "[DEBUG] Damage portion for Stormlash procced by %s: Stat=%s, BaseStatValue=%0.2f, BaseDamage=%0.2f, BaseMultiplier=%0.2f, SpeedMultiplier=%0.2f, PreOutcomeDamageAvg=%0.2f, PreOutcomeDamageMin=%0.2f, PreOutcomeDamageMax=%0.2f, PreOutcomeDamageActual=%0.2f",
+			character.LogAt(sim, LogCategoryOther, LogLevelTrace, "[DEBUG] Damage portion for Stormlash procced by %s: Stat=%s, BaseStatValue=%0.2f, BaseDamage=%0.2f, BaseMultiplier=%0.2f, SpeedMultiplier=%0.2f, PreOutcomeDamageAvg=%0.2f, PreOutcomeDamageMin=%0.2f, PreOutcomeDamageMax=%0.2f, PreOutcomeDamageActual=%0.2f",
 				spell.ActionID, chosenStat.StatName(), statValue, baseDamage, baseMultiplier, speedMultiplier, avg, min, max, damage)
 		}
 		stormlashSpell.Cast(sim, result.Target)
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// Mana restored from the source with the action ID so far.
+func manaGainFrom(unit *Unit, actionID ActionID) float64 {
+	gain := 0.0
+	for _, resourceMetrics := range unit.Metrics.resources {
+		if resourceMetrics.ActionID == actionID {
+			gain += resourceMetrics.Gain
+		}
+	}
+	return gain
+}
+
+func TestInnervateRestoresManaFromCasterSpirit(t *testing.T) {
+	casterSpirit := 8000.0
+	var innervate *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		innervate = InnervateAura(env.Raid.Parties[0].Players[0].GetCharacter(), -1, func() float64 { return casterSpirit })
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+
+	innervate.Activate(sim)
+	// The Spirit is taken when Innervate is applied.
+	casterSpirit = 20000
+	for sim.CurrentTime < InnervateDuration && !sim.Step() {
+	}
+
+	expected := InnervateSpiritMultiplier * 8000 * InnervateDuration.Seconds()
+	if gain := manaGainFrom(&character.Unit, innervate.ActionID); !WithinToleranceFloat64(expected, gain, 0.001) {
+		t.Fatalf("Expected Innervate to restore %0.0f mana, got %0.0f", expected, gain)
+	}
+}
+
+func TestHymnOfHopeRestoresMaxManaPercent(t *testing.T) {
+	var hymn *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		hymn = HymnOfHopeAura(env.Raid.Parties[0].Players[0].GetCharacter(), -1)
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	baseMaxMana := character.MaxMana()
+
+	hymn.Activate(sim)
+	if maxMana := character.MaxMana(); !WithinToleranceFloat64(baseMaxMana*1.15, maxMana, 0.001) {
+		t.Fatalf("Expected Hymn of Hope to raise maximum mana to %0.0f, got %0.0f", baseMaxMana*1.15, maxMana)
+	}
+	for sim.CurrentTime < HymnOfHopeDuration && !sim.Step() {
+	}
+
+	// 4 ticks of 2% of the raised maximum mana.
+	expected := 4 * 0.02 * baseMaxMana * 1.15
+	if gain := manaGainFrom(&character.Unit, hymn.ActionID); !WithinToleranceFloat64(expected, gain, 0.001) {
+		t.Fatalf("Expected Hymn of Hope to restore %0.0f mana, got %0.0f", expected, gain)
+	}
+}
+
+func TestReplenishmentScalesWithUptime(t *testing.T) {
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		applyReplenishment(env.Raid.Parties[0].Players[0].GetCharacter(), 0.5)
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+
+	gainBefore := manaGainFrom(&character.Unit, ReplenishmentActionID)
+	for sim.CurrentTime < time.Second*10 && !sim.Step() {
+	}
+
+	// 1% of maximum mana every 10s, at 50% uptime.
+	expected := 0.5 * 0.01 * character.MaxMana()
+	if gain := manaGainFrom(&character.Unit, ReplenishmentActionID) - gainBefore; !WithinToleranceFloat64(expected, gain, 0.001) {
+		t.Fatalf("Expected Replenishment to restore %0.0f mana over 10s, got %0.0f", expected, gain)
+	}
+}
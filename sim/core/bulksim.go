@@ -29,12 +29,16 @@ type bulkSimRunner struct {
 	SingleRaidSimRunner raidSimRunner
 	// Request used for this bulk simulation.
 	Request *proto.BulkSimRequest
+	// Optional, environments of combos which are simmed again in fast mode are reused from here.
+	EnvironmentCache *EnvironmentCache
 }
 
 func BulkSim(signals simsignals.Signals, request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics) *proto.BulkSimResult {
+	envCache := NewEnvironmentCache()
 	bulk := &bulkSimRunner{
-		SingleRaidSimRunner: runSim,
+		SingleRaidSimRunner: SharedResultCache.wrap(envCache.RunSim),
 		Request:             request,
+		EnvironmentCache:    envCache,
 	}
 
 	result := bulk.Run(signals, progress)
@@ -192,6 +196,15 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 				eq:  comb.Substitution,
 			}
 		}
+
+		// Only the remaining combos will be simmed again, free up the rest.
+		if b.EnvironmentCache != nil {
+			requests := make([]*proto.RaidSimRequest, len(validCombos))
+			for i, comb := range validCombos {
+				requests[i] = comb.req
+			}
+			b.EnvironmentCache.Retain(requests)
+		}
 	}
 
 	if baseResult == nil {
@@ -77,8 +77,8 @@ func (spell *Spell) castFailureHelper(sim *Simulation, message string, vals ...a
 	if sim.CurrentTime < 0 && spell.Unit.Rotation != nil {
 		spell.Unit.Rotation.ValidationMessage(proto.LogLevel_Warning, fmt.Sprintf(spell.ActionID.String()+" failed to cast: "+message, vals...))
 	} else {
-		if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-			spell.Unit.Log(sim, fmt.Sprintf(spell.ActionID.String()+" failed to cast: "+message, vals...))
+		if sim.LogEnabled(LogCategoryCasts, LogLevelDebug) && !spell.Flags.Matches(SpellFlagNoLogs) {
+			spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelDebug, fmt.Sprintf(spell.ActionID.String()+" failed to cast: "+message, vals...))
 		}
 	}
 	return false
@@ -110,6 +110,10 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			return spell.castFailureHelper(sim, "spell attached to an un-equipped item")
 		}
 
+		if !spell.meetsStanceRequirement(sim) {
+			return spell.castFailureHelper(sim, "not in a required stance")
+		}
+
 		if spell.ExtraCastCondition != nil {
 			if !spell.ExtraCastCondition(sim, target) {
 				return spell.castFailureHelper(sim, "extra spell condition")
@@ -118,11 +122,12 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 
 		if spell.Cost != nil {
 			if !spell.Cost.MeetsRequirement(sim, spell) {
+				spell.costBlocked = true
 				return spell.castFailureHelper(sim, spell.Cost.CostFailureReason(sim, spell))
 			}
 		}
 
-		if spell.MaxCharges > 0 && spell.charges == 0 {
+		if spell.usesCharges() && !spell.ChargedCD.IsReady() {
 			return spell.castFailureHelper(sim, "not enough charges")
 		}
 
@@ -147,6 +152,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 
 		// By panicking if spell is on CD, we force each sim to properly check for their own CDs.
 		if spell.CurCast.GCD > 0 && !spell.Unit.GCD.IsReady(sim) {
+			spell.Unit.recordGCDConflict(sim, "%s cast while the GCD is locked", spell.ActionID)
 			return spell.castFailureHelper(sim, "GCD on cooldown for %s, curTime = %s", spell.Unit.GCD.TimeToReady(sim), sim.CurrentTime)
 		}
 
@@ -158,6 +164,8 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			return spell.castFailureHelper(sim, "cannot interrupt in-progress channel of %v with a cast of %v", spell.Unit.ChanneledDot.ActionID, spell.ActionID)
 		}
 
+		switchTime := spell.enterRequiredStance(sim)
+
 		if effectiveTime := spell.CurCast.EffectiveTime(); effectiveTime != 0 {
 
 			// do not add channeled time here as they have variable cast length
@@ -166,7 +174,12 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.SpellMetrics[target.UnitIndex].TotalCastTime += effectiveTime
 			}
 
-			spell.Unit.SetGCDTimer(sim, max(sim.CurrentTime+effectiveTime, spell.Unit.NextGCDAt()))
+			// Latency applies once per player action, not again to casts made by its effects.
+			if sim.activeSpell == nil {
+				spell.Unit.SetGCDTimer(sim, spell.Unit.gcdReadyAfterLatency(sim, sim.CurrentTime+switchTime+effectiveTime))
+			} else {
+				spell.Unit.SetGCDTimer(sim, max(sim.CurrentTime+switchTime+effectiveTime, spell.Unit.NextGCDAt()))
+			}
 		}
 
 		if (spell.Flags&SpellFlagCanCastWhileMoving == 0) && (spell.CurCast.CastTime > 0) && spell.Unit.Moving {
@@ -175,8 +188,8 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 
 		// Hardcasts
 		if spell.CurCast.CastTime > 0 {
-			if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-				spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
+			if sim.LogEnabled(LogCategoryCasts, LogLevelInfo) && !spell.Flags.Matches(SpellFlagNoLogs) {
+				spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 					spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			}
 
@@ -184,19 +197,19 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				Expires:  sim.CurrentTime + spell.CurCast.CastTime,
 				ActionID: spell.ActionID,
 				OnComplete: func(sim *Simulation, target *Unit) {
-					if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-						spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
+					if sim.LogEnabled(LogCategoryCasts, LogLevelInfo) && !spell.Flags.Matches(SpellFlagNoLogs) {
+						spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Completed cast %s", spell.ActionID)
 					}
 
 					if spell.Cost != nil {
 						spell.Cost.SpendCost(sim, spell)
 					}
 
-					if spell.MaxCharges > 0 {
+					if spell.usesCharges() {
 						spell.ConsumeCharge(sim)
 					}
 
-					if config.CD.Timer != nil || spell.rechargeTimer != nil {
+					if config.CD.Timer != nil || spell.NextChargeIn(sim) > 0 {
 						spell.triggerCooldown(sim)
 					}
 
@@ -218,21 +231,21 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			return true
 		}
 
-		if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-			spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
+		if sim.LogEnabled(LogCategoryCasts, LogLevelInfo) && !spell.Flags.Matches(SpellFlagNoLogs) {
+			spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 				spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
-			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
+			spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Completed cast %s", spell.ActionID)
 		}
 
 		if spell.Cost != nil {
 			spell.Cost.SpendCost(sim, spell)
 		}
 
-		if spell.MaxCharges > 0 {
+		if spell.usesCharges() {
 			spell.ConsumeCharge(sim)
 		}
 
-		if config.CD.Timer != nil || spell.rechargeTimer != nil {
+		if config.CD.Timer != nil || spell.NextChargeIn(sim) > 0 {
 			spell.triggerCooldown(sim)
 		}
 
@@ -253,8 +266,12 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 func (spell *Spell) triggerCooldown(sim *Simulation) {
 	cd := time.Duration(float64(spell.CD.Duration) * spell.CdMultiplier)
 
+	if spell.Flags.Matches(SpellFlagMCD|SpellFlagTrackCooldownDrift) && spell.CD.Timer != nil && !spell.usesCharges() {
+		spell.recordCooldownDrift(sim, cd)
+	}
+
 	// if recharge timer is higher than the actual cooldown of the spell we use
-	if spell.MaxCharges > 0 && spell.charges == 0 {
+	if spell.usesCharges() && !spell.ChargedCD.IsReady() {
 		// spell.CdMultiplier would be considered within the the recharge time if we ever need that
 		cd = TernaryDuration(cd > spell.NextChargeIn(sim), cd, spell.NextChargeIn(sim))
 	}
@@ -264,6 +281,17 @@ func (spell *Spell) triggerCooldown(sim *Simulation) {
 	}
 }
 
+// Tracks how long after becoming ready a cooldown meant to be used on CD was
+// actually used, see CooldownUsageMetrics.drift_seconds_avg. Hardcast time
+// counts as part of the use, not as drift.
+func (spell *Spell) recordCooldownDrift(sim *Simulation, cd time.Duration) {
+	readyAt := spell.CD.ReadyAt() + spell.CurCast.CastTime
+	if sim.LogEnabled(LogCategoryAI, LogLevelDebug) && sim.CurrentTime >= 0 && sim.CurrentTime > max(readyAt, 0) {
+		spell.Unit.LogAt(sim, LogCategoryAI, LogLevelDebug, "Cooldown %s used %s after it was ready", spell.ActionID, sim.CurrentTime-max(readyAt, 0))
+	}
+	spell.Unit.Metrics.addCooldownDrift(sim, spell.ActionID, readyAt, cd)
+}
+
 func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 	return func(sim *Simulation, target *Unit) bool {
 		if target == nil {
@@ -278,6 +306,10 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 			return spell.castFailureHelper(sim, "spell attached to an un-equipped item")
 		}
 
+		if !spell.meetsStanceRequirement(sim) {
+			return spell.castFailureHelper(sim, "not in a required stance")
+		}
+
 		if spell.ExtraCastCondition != nil {
 			if !spell.ExtraCastCondition(sim, target) {
 				return spell.castFailureHelper(sim, "extra spell condition")
@@ -298,21 +330,23 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 			}
 		}
 
-		if spell.MaxCharges > 0 && spell.charges == 0 {
+		if spell.usesCharges() && !spell.ChargedCD.IsReady() {
 			return spell.castFailureHelper(sim, "not enough charges")
 		}
 
-		if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-			spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
+		spell.enterRequiredStance(sim)
+
+		if sim.LogEnabled(LogCategoryCasts, LogLevelInfo) && !spell.Flags.Matches(SpellFlagNoLogs) {
+			spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 				spell.ActionID, 0.0, "0s", "0s")
-			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
+			spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Completed cast %s", spell.ActionID)
 		}
 
-		if spell.MaxCharges > 0 {
+		if spell.usesCharges() {
 			spell.ConsumeCharge(sim)
 		}
 
-		if spell.CD.Timer != nil || spell.rechargeTimer != nil {
+		if spell.CD.Timer != nil || spell.NextChargeIn(sim) > 0 {
 			spell.triggerCooldown(sim)
 		}
 
@@ -332,10 +366,10 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 
 func (spell *Spell) makeCastFuncAutosOrProcs() CastSuccessFunc {
 	return func(sim *Simulation, target *Unit) bool {
-		if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-			spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
+		if sim.LogEnabled(LogCategoryCasts, LogLevelInfo) && !spell.Flags.Matches(SpellFlagNoLogs) {
+			spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 				spell.ActionID, 0.0, "0s", "0s")
-			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
+			spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Completed cast %s", spell.ActionID)
 		}
 
 		spell.applyEffects(sim, target)
@@ -352,10 +386,10 @@ func (spell *Spell) makeCastFuncAutosOrProcs() CastSuccessFunc {
 // Simply logging the cast and applying the effect
 // Can be used for spells that proc off other spells and are the same spell id
 func (spell *Spell) Proc(sim *Simulation, target *Unit) {
-	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-		spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
+	if sim.LogEnabled(LogCategoryCasts, LogLevelInfo) && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 			spell.ActionID, 0.0, "0s", "0s")
-		spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
+		spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Completed cast %s", spell.ActionID)
 	}
 
 	spell.applyEffects(sim, target)
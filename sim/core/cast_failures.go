@@ -0,0 +1,41 @@
+package core
+
+import (
+	"cmp"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type castFailureKey struct {
+	ActionID ActionID
+	Reason   proto.CastFailureReason
+}
+
+func (key castFailureKey) compare(other castFailureKey) int {
+	return cmp.Or(key.ActionID.Compare(other.ActionID), cmp.Compare(key.Reason, other.Reason))
+}
+
+// Tracks the cast failures of a single APL cast action. The APL re-evaluates an
+// action every time it looks for the next action, so a spell which can't be cast
+// would otherwise be counted many times for what is a single rejected attempt.
+// A failure is counted once when the action starts being rejected, and again only
+// if the reason changes or the action was ready in between.
+type castFailureTracker struct {
+	reason proto.CastFailureReason
+}
+
+func (tracker *castFailureTracker) update(sim *Simulation, spell *Spell, reason proto.CastFailureReason) {
+	if reason == tracker.reason {
+		return
+	}
+	tracker.reason = reason
+
+	if reason == proto.CastFailureReason_CastFailureNone || sim.CurrentTime < 0 {
+		return
+	}
+	spell.Unit.Metrics.castFailures[castFailureKey{ActionID: spell.ActionID, Reason: reason}]++
+}
+
+func (tracker *castFailureTracker) reset() {
+	tracker.reason = proto.CastFailureReason_CastFailureNone
+}
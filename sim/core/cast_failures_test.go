@@ -0,0 +1,120 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestCastFailuresCountedOncePerRejectedAttempt(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.CurrentTime = 0
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	conditionMet := false
+	conditionCalls := 0
+	fa.Spell.ExtraCastCondition = func(_ *Simulation, _ *Unit) bool {
+		conditionCalls++
+		return conditionMet
+	}
+
+	action := &APLActionCastSpell{spell: fa.Spell, target: UnitReference{fixedUnit: target}}
+	failures := func(reason proto.CastFailureReason) int32 {
+		return fa.Metrics.castFailures[castFailureKey{ActionID: fa.Spell.ActionID, Reason: reason}]
+	}
+
+	for i := 0; i < 5; i++ {
+		if action.IsReady(sim) {
+			t.Fatalf("Expected the action not to be ready")
+		}
+	}
+	if conditionCalls != 5 {
+		t.Fatalf("Expected the extra cast condition to be evaluated once per check, got %d calls for 5 checks", conditionCalls)
+	}
+	if got := failures(proto.CastFailureReason_CastFailureExtraCondition); got != 1 {
+		t.Fatalf("Expected 1 failure for repeated checks of the same attempt, got %d", got)
+	}
+
+	fa.Spell.Flags |= SpellFlagSwapped
+	action.IsReady(sim)
+	action.IsReady(sim)
+	fa.Spell.Flags &^= SpellFlagSwapped
+	if got := failures(proto.CastFailureReason_CastFailureSwapped); got != 1 {
+		t.Fatalf("Expected 1 failure after the reason changed, got %d", got)
+	}
+
+	conditionMet = true
+	if !action.IsReady(sim) {
+		t.Fatalf("Expected the action to be ready")
+	}
+	conditionMet = false
+	action.IsReady(sim)
+	if got := failures(proto.CastFailureReason_CastFailureExtraCondition); got != 2 {
+		t.Fatalf("Expected a new failure after the action was ready in between, got %d", got)
+	}
+
+	action.Reset(sim)
+	sim.CurrentTime = -1
+	action.IsReady(sim)
+	if got := failures(proto.CastFailureReason_CastFailureExtraCondition); got != 2 {
+		t.Fatalf("Expected no failures before the pull, got %d", got)
+	}
+
+	// Map iteration order is random, so check the output order repeatedly.
+	for range 10 {
+		castFailures := fa.Metrics.ToProto().CastFailures
+		if !slices.IsSortedFunc(castFailures, func(a, b *proto.CastFailureMetrics) int { return cmp.Compare(a.Reason, b.Reason) }) {
+			t.Fatalf("Expected cast failures to be sorted by reason, got %v", castFailures)
+		}
+	}
+}
+
+func TestCastFailuresIgnoreLastIterationMetricsSplit(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+	spell := fa.Spell
+	spell.splitSpellMetrics = append(spell.splitSpellMetrics, make([]SpellMetrics, len(spell.SpellMetrics)))
+	spell.ExtraCastCondition = func(_ *Simulation, _ *Unit) bool { return false }
+
+	// A split selected by the last cast of an iteration doesn't carry over.
+	spell.SetMetricsSplit(1)
+	sim.Cleanup()
+	sim.Reset()
+	sim.CurrentTime = 0
+
+	action := &APLActionCastSpell{spell: spell, target: UnitReference{fixedUnit: target}}
+	action.IsReady(sim)
+	if got := fa.Metrics.castFailures[castFailureKey{ActionID: spell.ActionID.WithTag(0), Reason: proto.CastFailureReason_CastFailureExtraCondition}]; got != 1 {
+		t.Fatalf("Expected the failure to be counted for the first split, got %v", fa.Metrics.castFailures)
+	}
+}
+
+func TestCombineCastFailuresAreSorted(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	base := rsrc.newUnitMetrics(&proto.UnitMetrics{})
+	newMetrics := func(keys ...castFailureKey) *proto.UnitMetrics {
+		metrics := rsrc.newUnitMetrics(&proto.UnitMetrics{})
+		for _, key := range keys {
+			metrics.CastFailures = append(metrics.CastFailures, &proto.CastFailureMetrics{Id: key.ActionID.ToProto(), Reason: key.Reason, FailuresAvg: 2})
+		}
+		return metrics
+	}
+	outOfRange := castFailureKey{ActionID{SpellID: 20}, proto.CastFailureReason_CastFailureOutOfRange}
+	cost := castFailureKey{ActionID{SpellID: 20}, proto.CastFailureReason_CastFailureCost}
+	otherSpell := castFailureKey{ActionID{SpellID: 10}, proto.CastFailureReason_CastFailureCost}
+
+	rsrc.combineUnitMetrics(base, newMetrics(cost), false, 0.5)
+	rsrc.combineUnitMetrics(base, newMetrics(outOfRange, otherSpell), true, 0.5)
+
+	var keys []castFailureKey
+	for _, failure := range base.CastFailures {
+		keys = append(keys, castFailureKey{ProtoToActionID(failure.Id), failure.Reason})
+	}
+	if !slices.IsSortedFunc(keys, castFailureKey.compare) || len(keys) != 3 {
+		t.Fatalf("Expected the combined cast failures to be sorted, got %v", keys)
+	}
+}
@@ -66,6 +66,15 @@ type Character struct {
 
 	glyphs [6]int32
 
+	// User defined auras, see applyCustomAuras.
+	customAuras []*proto.CustomAura
+
+	// Procs defined as data, see applyScriptedProcs.
+	scriptedProcs []*proto.ScriptedProc
+
+	// Aura states at the start of the encounter, see registerPrecombatAuras.
+	precombatAuraConfigs []*proto.PrecombatAura
+
 	// Used for effects like "Increased Armor Value from Items"
 	*EquipScalingManager
 
@@ -103,7 +112,12 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 
 			ReactionTime:            time.Duration(max(player.ReactionTimeMs, 10)) * time.Millisecond,
 			ChannelClipDelay:        max(0, time.Duration(player.ChannelClipDelayMs)*time.Millisecond),
+			Latency:                 max(0, time.Duration(player.LatencyMs)*time.Millisecond),
+			LatencyJitter:           max(0, time.Duration(player.LatencyJitterMs)*time.Millisecond),
 			StartDistanceFromTarget: player.DistanceFromTarget,
+
+			disabledSpells: protoToActionIDSet(player.DisabledSpells),
+			disabledAuras:  protoToActionIDSet(player.DisabledAuras),
 		},
 
 		Name:  player.Name,
@@ -121,6 +135,10 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 		Party:      party,
 		PartyIndex: partyIndex,
 
+		customAuras:          player.CustomAuras,
+		scriptedProcs:        player.ScriptedProcs,
+		precombatAuraConfigs: player.PrecombatAuras,
+
 		majorCooldownManager: newMajorCooldownManager(player.Cooldowns),
 	}
 	character.GCD = character.NewTimer()
@@ -247,6 +265,19 @@ func (character *Character) updateCachedEquipStats() {
 	}
 }
 
+// Replaces the character's bonus stats after finalization, scaled by the same item
+// stat multipliers that were applied to the original bonus stats.
+func (character *Character) setBonusStats(sim *Simulation, bonusStats stats.Stats) {
+	delta := bonusStats.Subtract(character.bonusStats)
+	if delta.Equals(stats.Stats{}) {
+		return
+	}
+
+	character.bonusStats = bonusStats
+	character.equipCacheValid = false
+	character.shiftInitialStats(sim, delta.ApplyMultipliers(character.itemStatMultipliers))
+}
+
 func (character *Character) EquipStats() stats.Stats {
 	character.updateCachedEquipStats()
 	return character.cachedEquipStats.ApplyMultipliers(character.itemStatMultipliers)
@@ -294,6 +325,7 @@ func (character *Character) applyAllEffects(agent Agent, raidBuffs *proto.RaidBu
 	character.applyEquipment()
 	character.applyItemEffects(agent)
 	character.applyItemSetBonusEffects(agent)
+	character.applyScriptedProcs(character.scriptedProcs)
 	character.applyBuildPhaseAuras(CharacterBuildPhaseGear)
 	playerStats.GearStats = measureStats()
 
@@ -302,6 +334,7 @@ func (character *Character) applyAllEffects(agent Agent, raidBuffs *proto.RaidBu
 	playerStats.TalentsStats = measureStats()
 
 	applyBuffEffects(agent, raidBuffs, partyBuffs, individualBuffs)
+	character.applyCustomAuras(character.customAuras)
 	character.applyBuildPhaseAuras(CharacterBuildPhaseBuffs)
 	playerStats.BuffsStats = measureStats()
 
@@ -337,7 +370,7 @@ func (character *Character) clearBuildPhaseAuras(phase CharacterBuildPhase) {
 	character.Env.MeasuringStats = false
 }
 func (character *Character) CalculateMasteryPoints() float64 {
-	return character.GetStat(stats.MasteryRating) / MasteryRatingPerMasteryPoint
+	return Ratings.MasteryPoints(character.GetStat(stats.MasteryRating))
 }
 
 // Apply effects from all equipped core.
@@ -428,6 +461,20 @@ func (character *Character) initialize(agent Agent) {
 	}
 }
 
+// Leaves only the buffs and passive effects of this character and its pets,
+// see Raid.focus_player.
+func (character *Character) disableActions() {
+	character.Rotation = nil
+	character.AutoAttacks.AutoSwingMelee = false
+	character.AutoAttacks.AutoSwingRanged = false
+
+	for _, pet := range character.Pets {
+		pet.Rotation = nil
+		pet.AutoAttacks.AutoSwingMelee = false
+		pet.AutoAttacks.AutoSwingRanged = false
+	}
+}
+
 func (character *Character) Finalize() {
 	if character.Env.IsFinalized() {
 		return
@@ -435,6 +482,8 @@ func (character *Character) Finalize() {
 
 	character.PseudoStats.ParryHaste = character.PseudoStats.CanParry
 
+	character.registerPrecombatAuras(character.precombatAuraConfigs)
+
 	character.Unit.finalize()
 
 	character.majorCooldownManager.finalize()
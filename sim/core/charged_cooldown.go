@@ -0,0 +1,130 @@
+package core
+
+import (
+	"time"
+)
+
+// A cooldown with several charges, which recharge one at a time. May be shared
+// by several spells, e.g. Hand of Gul'dan and Chaos Wave.
+type ChargedCooldown struct {
+	// Maximum number of charges.
+	NumCharges int
+
+	// Time to recharge a single charge.
+	RechargeTime time.Duration
+
+	charges        int
+	rechargeAction *PendingAction
+
+	// Number of spells sharing the charges each active charge mod is applied
+	// to, so a mod affecting several of them only changes the charges once.
+	chargeMods map[*SpellMod]int
+}
+
+func (unit *Unit) NewChargedCooldown(numCharges int, rechargeTime time.Duration) *ChargedCooldown {
+	cc := &ChargedCooldown{
+		NumCharges:   numCharges,
+		RechargeTime: rechargeTime,
+		charges:      numCharges,
+	}
+	unit.chargedCooldowns = append(unit.chargedCooldowns, cc)
+	return cc
+}
+
+func (cc *ChargedCooldown) reset(sim *Simulation) {
+	if cc.rechargeAction != nil {
+		cc.rechargeAction.Cancel(sim)
+		cc.rechargeAction = nil
+	}
+	cc.charges = cc.NumCharges
+}
+
+func (cc *ChargedCooldown) CurrentCharges() int {
+	return cc.charges
+}
+
+// Whether at least one charge is available.
+func (cc *ChargedCooldown) IsReady() bool {
+	return cc.charges > 0
+}
+
+// Returns the time until the next charge is available, or 0 if no recharge
+// is in progress.
+func (cc *ChargedCooldown) TimeToNextCharge(sim *Simulation) time.Duration {
+	if cc.rechargeAction == nil {
+		return 0
+	}
+	return cc.rechargeAction.NextActionAt - sim.CurrentTime
+}
+
+// Consumes a charge, starting a recharge if none is in progress.
+func (cc *ChargedCooldown) Consume(sim *Simulation) {
+	if cc.charges == 0 {
+		panic("Trying to consume charge, but there are no charges left.")
+	}
+
+	cc.charges--
+
+	if cc.rechargeAction == nil {
+		cc.scheduleRecharge(sim)
+	}
+}
+
+// Restores a single charge, without affecting the recharge in progress.
+func (cc *ChargedCooldown) RestoreCharge(_ *Simulation) {
+	if cc.charges < cc.NumCharges {
+		cc.charges++
+	}
+}
+
+func (cc *ChargedCooldown) scheduleRecharge(sim *Simulation) {
+	cc.rechargeAction = &PendingAction{
+		NextActionAt: sim.CurrentTime + cc.RechargeTime,
+		Priority:     ActionPriorityAuto,
+		OnAction: func(sim *Simulation) {
+			cc.RestoreCharge(sim)
+			cc.rechargeAction = nil
+			if cc.charges < cc.NumCharges {
+				cc.scheduleRecharge(sim)
+			}
+		},
+	}
+
+	sim.AddPendingAction(cc.rechargeAction)
+}
+
+// Changes the maximum number of charges. Added charges are available right away.
+func (cc *ChargedCooldown) addCharges(delta int) {
+	cc.NumCharges += delta
+	if cc.NumCharges < 0 {
+		panic("Reducing the charges below 0 is not supported. Something seems wrong.")
+	}
+
+	if delta > 0 {
+		cc.charges += delta
+	}
+	cc.charges = min(cc.charges, cc.NumCharges)
+}
+
+// Applies a charge mod through one of the spells using the charges.
+func (cc *ChargedCooldown) applyChargeMod(mod *SpellMod) {
+	if cc.chargeMods == nil {
+		cc.chargeMods = make(map[*SpellMod]int)
+	}
+	cc.chargeMods[mod]++
+	if cc.chargeMods[mod] == 1 {
+		cc.addCharges(int(mod.GetIntValue()))
+	}
+}
+
+// Removes a charge mod through one of the spells using the charges.
+func (cc *ChargedCooldown) removeChargeMod(mod *SpellMod) {
+	if cc.chargeMods[mod] == 0 {
+		return
+	}
+	cc.chargeMods[mod]--
+	if cc.chargeMods[mod] == 0 {
+		delete(cc.chargeMods, mod)
+		cc.addCharges(-int(mod.GetIntValue()))
+	}
+}
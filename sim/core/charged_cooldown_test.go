@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChargedCooldownRecharge(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	cc := fa.NewChargedCooldown(2, time.Second*10)
+
+	cc.Consume(sim)
+	sim.CurrentTime = time.Second * 4
+	cc.Consume(sim)
+	if cc.IsReady() || cc.TimeToNextCharge(sim) != time.Second*6 {
+		t.Fatalf("Expected no charges and the first recharge in 6s, got %d charges and %s", cc.CurrentCharges(), cc.TimeToNextCharge(sim))
+	}
+
+	sim.CurrentTime = time.Second * 10
+	cc.rechargeAction.OnAction(sim)
+	if cc.CurrentCharges() != 1 || cc.TimeToNextCharge(sim) != time.Second*10 {
+		t.Fatalf("Expected 1 charge and the next recharge in 10s, got %d charges and %s", cc.CurrentCharges(), cc.TimeToNextCharge(sim))
+	}
+
+	cc.addCharges(1)
+	if cc.NumCharges != 3 || cc.CurrentCharges() != 2 {
+		t.Fatalf("Expected added charges to be available right away, got %d/%d", cc.CurrentCharges(), cc.NumCharges)
+	}
+
+	cc.reset(sim)
+	if cc.CurrentCharges() != 3 || cc.TimeToNextCharge(sim) != 0 {
+		t.Fatalf("Expected all charges after reset, got %d", cc.CurrentCharges())
+	}
+}
+
+func TestChargedCooldownSharedChargeMod(t *testing.T) {
+	var cc *ChargedCooldown
+	var mod *SpellMod
+	setupResultPipelineSim(func(env *Environment) {
+		unit := &env.Raid.Parties[0].Players[0].GetCharacter().Unit
+		cc = unit.NewChargedCooldown(2, time.Second*10)
+		for i, spellID := range []int32{105174, 127236} {
+			unit.RegisterSpell(SpellConfig{
+				ActionID:       ActionID{SpellID: spellID},
+				ClassSpellMask: 1 << i,
+				ChargedCD:      cc,
+				ApplyEffects:   func(_ *Simulation, _ *Unit, _ *Spell) {},
+			})
+		}
+		mod = unit.AddDynamicMod(SpellModConfig{
+			ClassMask: 0b11,
+			Kind:      SpellMod_ModCharges_Flat,
+			IntValue:  1,
+		})
+	})
+
+	mod.Activate()
+	if cc.NumCharges != 3 || cc.CurrentCharges() != 3 {
+		t.Fatalf("Expected the mod to add a single shared charge, got %d/%d", cc.CurrentCharges(), cc.NumCharges)
+	}
+
+	mod.UpdateIntValue(2)
+	if cc.NumCharges != 4 {
+		t.Fatalf("Expected an updated mod to add 2 shared charges, got %d", cc.NumCharges)
+	}
+
+	mod.Deactivate()
+	if cc.NumCharges != 2 || cc.CurrentCharges() != 2 {
+		t.Fatalf("Expected the mod's charges to be removed, got %d/%d", cc.CurrentCharges(), cc.NumCharges)
+	}
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Thresholds for what counts as a rotation mistake.
+const (
+	coachMinDotUptime      = 0.85
+	coachMinCapStreak      = time.Second * 3
+	coachMaxCappedFraction = 0.05
+)
+
+// Scans the iteration which just finished for detectable rotation mistakes, and
+// stores human readable suggestions in each player's metrics. This is run for the
+// logged iteration, so suggestions can be checked against its log and timeline.
+func (sim *Simulation) analyzeRotations() {
+	duration := sim.CurrentTime
+	if duration <= 0 {
+		return
+	}
+
+	for _, party := range sim.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			character.Metrics.suggestions = character.rotationSuggestions(duration)
+		}
+	}
+}
+
+func (character *Character) rotationSuggestions(duration time.Duration) []string {
+	var suggestions []string
+
+	for _, mcd := range character.GetMajorCooldowns() {
+		spell := mcd.Spell
+		cooldown := spell.CD.Duration
+		if cooldown <= 0 {
+			continue
+		}
+
+		casts := spell.castsThisIteration()
+		possibleCasts := int32(duration/cooldown) + 1
+		if casts == 0 {
+			suggestions = append(suggestions, fmt.Sprintf("%s was never used, although it could have been cast %d times.", spell.coachName(), possibleCasts))
+		} else if casts < possibleCasts-1 {
+			suggestions = append(suggestions, fmt.Sprintf("%s was cast %d times, although its cooldown allows %d casts.", spell.coachName(), casts, possibleCasts))
+		}
+	}
+
+	if character.CurrentTarget != nil {
+		for _, spell := range character.Spellbook {
+			// Low uptime is expected while the spell's resource runs out.
+			if spell.dots == nil || !spell.Flags.Matches(SpellFlagAPL) || spell.castsThisIteration() == 0 || spell.costBlocked {
+				continue
+			}
+
+			dot := spell.Dot(character.CurrentTarget)
+			if dot == nil {
+				continue
+			}
+
+			uptime := dot.metrics.Uptime.Seconds() / duration.Seconds()
+			if uptime < coachMinDotUptime {
+				suggestions = append(suggestions, fmt.Sprintf("%s was only up %0.0f%% of the fight on %s, although its cost never held it back.", spell.coachName(), uptime*100, character.CurrentTarget.Label))
+			}
+		}
+	}
+
+	for _, capMetrics := range character.Metrics.resourceCaps {
+		if capMetrics.LongestCapped < coachMinCapStreak && capMetrics.TimeCapped.Seconds() < coachMaxCappedFraction*duration.Seconds() {
+			continue
+		}
+
+		resourceName := strings.TrimPrefix(capMetrics.Type.String(), "ResourceType")
+		suggestions = append(suggestions, fmt.Sprintf("%s was capped for %0.1fs, up to %0.1fs in a row, wasting %0.0f. Spend it earlier to avoid overflowing.",
+			resourceName, capMetrics.TimeCapped.Seconds(), capMetrics.LongestCapped.Seconds(), capMetrics.Wasted))
+	}
+
+	return suggestions
+}
+
+// Number of casts during the current iteration, on all targets.
+func (spell *Spell) castsThisIteration() int32 {
+	casts := int32(0)
+	for _, spellMetrics := range spell.splitSpellMetrics {
+		for _, targetMetrics := range spellMetrics {
+			casts += targetMetrics.Casts
+		}
+	}
+	return casts
+}
+
+// Readable name of a spell for suggestions. Spells don't have names of their
+// own, so this is the label of their buff or dot, or the name of their item.
+func (spell *Spell) coachName() string {
+	if spell.RelatedSelfBuff != nil {
+		return spell.RelatedSelfBuff.Label
+	}
+	if spell.aoeDot != nil {
+		return spell.aoeDot.Label
+	}
+	for _, dot := range spell.dots {
+		if dot != nil {
+			// Dots on targets are labelled per caster.
+			return strings.TrimSuffix(dot.Label, "-"+strconv.Itoa(int(spell.Unit.UnitIndex)))
+		}
+	}
+	if item, ok := ItemsByID[spell.ActionID.ItemID]; ok && spell.ActionID.ItemID != 0 {
+		return item.Name
+	}
+	return spell.ActionID.String()
+}
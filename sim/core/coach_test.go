@@ -0,0 +1,50 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCoachNamesUnusedCooldowns(t *testing.T) {
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		registerStackingTestCooldown(env.Raid.Parties[0].Players[0].GetCharacter(), 1, time.Second*20)
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+
+	suggestions := character.rotationSuggestions(time.Minute * 3)
+	expected := "Stacking Test Aura 1 was never used, although it could have been cast 2 times."
+	if !slices.Contains(suggestions, expected) {
+		t.Fatalf("Expected the suggestion %q, got %q", expected, suggestions)
+	}
+}
+
+func TestCoachDotUptimeIgnoresSpellsHeldBackByCost(t *testing.T) {
+	sim, _ := setupResultPipelineSim(nil)
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+	fa.CurrentTarget = target
+	fa.Spell.Flags |= SpellFlagAPL
+	fa.Spell.SpellMetrics[target.UnitIndex].Casts = 1
+
+	lowUptime := "fakedot was only up 0% of the fight on Target 1, although its cost never held it back."
+	if suggestions := fa.rotationSuggestions(time.Minute * 3); !slices.Contains(suggestions, lowUptime) {
+		t.Fatalf("Expected the suggestion %q, got %q", lowUptime, suggestions)
+	}
+
+	// Running out of any resource counts, not only mana.
+	cost := &testResourceCost{}
+	fa.Spell.Cost = &SpellCost{spell: fa.Spell, ResourceCostImpl: cost}
+	if fa.Spell.CanCast(sim, target) {
+		t.Fatalf("Expected the spell not to be castable without resources")
+	}
+	if suggestions := fa.rotationSuggestions(time.Minute * 3); slices.Contains(suggestions, lowUptime) {
+		t.Fatalf("Expected no uptime suggestion for a spell held back by its cost, got %q", suggestions)
+	}
+
+	sim.Cleanup()
+	sim.Reset()
+	if fa.Spell.costBlocked {
+		t.Fatalf("Expected the cost check to be reset with the iteration")
+	}
+}
@@ -45,6 +45,9 @@ const CombatTableCoverageCap = 1.024 // 102.4% chance to avoid an attack
 
 const NumItemSlots = proto.ItemSlot_ItemSlotOffHand + 1
 
+// Slot of effects which aren't tied to an equipped item.
+const NoItemSlot proto.ItemSlot = -1
+
 func TrinketSlots() []proto.ItemSlot {
 	return []proto.ItemSlot{proto.ItemSlot_ItemSlotTrinket1, proto.ItemSlot_ItemSlotTrinket2}
 }
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"slices"
 	"time"
 
 	"github.com/wowsims/mop/sim/core/proto"
@@ -195,6 +196,9 @@ func makePotionActivationSpellInternal(potion Consumable, character *Character,
 			} else {
 				mcd.Type = CooldownTypeDPS
 			}
+			if !slices.Contains(mcd.RestoredResources, resourceType) {
+				mcd.RestoredResources = append(mcd.RestoredResources, resourceType)
+			}
 			gains = append(gains, resourceGainConfig{
 				resType: resourceType,
 				min:     e.MinEffectSize,
@@ -277,8 +281,9 @@ func registerConjuredCD(agent Agent, consumes *proto.ConsumesSpec) {
 			},
 		})
 		character.AddMajorCooldown(MajorCooldown{
-			Spell: spell,
-			Type:  CooldownTypeMana,
+			Spell:             spell,
+			Type:              CooldownTypeMana,
+			RestoredResources: []proto.ResourceType{proto.ResourceType_ResourceTypeMana},
 			ShouldActivate: func(sim *Simulation, character *Character) bool {
 				// Only pop if we have less than the max mana provided by the potion minus 1mp5 tick.
 				totalRegen := character.ManaRegenPerSecondWhileCombat() * 5
@@ -309,8 +314,9 @@ func registerConjuredCD(agent Agent, consumes *proto.ConsumesSpec) {
 			},
 		})
 		character.AddMajorCooldown(MajorCooldown{
-			Spell: spell,
-			Type:  CooldownTypeSurvival,
+			Spell:             spell,
+			Type:              CooldownTypeSurvival,
+			RestoredResources: []proto.ResourceType{proto.ResourceType_ResourceTypeHealth},
 		})
 	}
 }
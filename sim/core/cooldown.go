@@ -27,10 +27,13 @@ func (unit *Unit) NewTimer() *Timer {
 	return newTimer
 }
 
-func (unit *Unit) resetCDs(_ *Simulation) {
+func (unit *Unit) resetCDs(sim *Simulation) {
 	for _, timer := range unit.cdTimers {
 		timer.Reset()
 	}
+	for _, cc := range unit.chargedCooldowns {
+		cc.reset(sim)
+	}
 }
 
 func (timer *Timer) ReadyAt() time.Duration {
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Aura which is active while the cooldown's effect is, if known.
+func (mcd *MajorCooldown) effectAura() *Aura {
+	if mcd.BuffAura != nil {
+		return mcd.BuffAura.Aura
+	}
+	return mcd.Spell.RelatedSelfBuff
+}
+
+func (mcd *MajorCooldown) isStackable() bool {
+	return mcd.Type.Matches(CooldownTypeDPS) && (mcd.effectAura() != nil)
+}
+
+// Whether the cooldown's stacking policy allows an automatic use right now.
+func (mcdm *majorCooldownManager) stackingAllows(sim *Simulation, mcd *MajorCooldown) bool {
+	switch mcd.stackingPolicy {
+	case proto.CooldownStackingPolicy_CooldownStackingAlways:
+		// Hold the cooldown for each other stacking cooldown which will be ready
+		// soon enough that waiting doesn't cost a use of this one.
+		for _, other := range mcdm.majorCooldowns {
+			if other.Spell == mcd.Spell || other.disabled || other.stackingPolicy != proto.CooldownStackingPolicy_CooldownStackingAlways {
+				continue
+			}
+			if aura := other.effectAura(); aura != nil && aura.IsActive() {
+				continue
+			}
+			timeToReady := other.TimeToNextCast(sim)
+			if timeToReady > 0 && timeToReady < mcd.Spell.CD.Duration && sim.CurrentTime+timeToReady < sim.Duration {
+				return false
+			}
+		}
+		return true
+
+	case proto.CooldownStackingPolicy_CooldownStackingStagger:
+		for _, other := range mcdm.majorCooldowns {
+			if other.Spell == mcd.Spell || !other.isStackable() {
+				continue
+			}
+			if aura := other.effectAura(); aura.IsActive() && aura.ExpiresAt() < sim.Duration {
+				return false
+			}
+		}
+		return true
+
+	case proto.CooldownStackingPolicy_CooldownStackingAlignWithAura:
+		if mcd.alignAura == nil || mcd.alignAura.IsActive() {
+			return true
+		}
+		// Don't hold the last use past the end of the fight.
+		return sim.GetRemainingDuration() < mcd.Spell.CD.Duration
+
+	default:
+		return true
+	}
+}
+
+// Overlap of a pair of DPS cooldowns, see proto.CooldownOverlapMetrics.
+type cooldownOverlapMetrics struct {
+	id      ActionID
+	otherID ActionID
+
+	iterationOverlap time.Duration
+
+	overlapSum           float64
+	iterationsOverlapped int32
+	n                    int32
+}
+
+// Tracks the overlap of each stacking cooldown with every other DPS cooldown.
+func (mcdm *majorCooldownManager) registerCooldownOverlaps() {
+	unitMetrics := &mcdm.character.Metrics
+
+	for i := range mcdm.initialMajorCooldowns {
+		mcd := &mcdm.initialMajorCooldowns[i]
+		if mcd.stackingPolicy != proto.CooldownStackingPolicy_CooldownStackingAlways || !mcd.isStackable() {
+			continue
+		}
+
+		for j := range mcdm.initialMajorCooldowns {
+			other := &mcdm.initialMajorCooldowns[j]
+			if i == j || !other.isStackable() {
+				continue
+			}
+			// Each pair is only tracked once.
+			if other.stackingPolicy == proto.CooldownStackingPolicy_CooldownStackingAlways && j < i {
+				continue
+			}
+
+			overlap := &cooldownOverlapMetrics{
+				id:      mcd.Spell.ActionID,
+				otherID: other.Spell.ActionID,
+			}
+			unitMetrics.cooldownOverlaps = append(unitMetrics.cooldownOverlaps, overlap)
+
+			aura, otherAura := mcd.effectAura(), other.effectAura()
+			aura.ApplyOnExpire(func(_ *Aura, sim *Simulation) {
+				overlap.addOverlap(sim, aura, otherAura)
+			})
+			otherAura.ApplyOnExpire(func(_ *Aura, sim *Simulation) {
+				overlap.addOverlap(sim, otherAura, aura)
+			})
+		}
+	}
+}
+
+// Adds the overlap of expiring with other, which is only counted once since
+// overlaps end when the first of both auras expires.
+func (overlap *cooldownOverlapMetrics) addOverlap(sim *Simulation, expiring *Aura, other *Aura) {
+	if other.IsActive() {
+		overlap.iterationOverlap += sim.CurrentTime - max(expiring.StartedAt(), other.StartedAt())
+	}
+}
+
+func (overlap *cooldownOverlapMetrics) doneIteration() {
+	overlap.overlapSum += overlap.iterationOverlap.Seconds()
+	if overlap.iterationOverlap > 0 {
+		overlap.iterationsOverlapped++
+	}
+	overlap.n++
+	overlap.iterationOverlap = 0
+}
+
+func (overlap *cooldownOverlapMetrics) reset() {
+	overlap.iterationOverlap = 0
+	overlap.overlapSum = 0
+	overlap.iterationsOverlapped = 0
+	overlap.n = 0
+}
+
+func (overlap *cooldownOverlapMetrics) ToProto() *proto.CooldownOverlapMetrics {
+	n := float64(max(overlap.n, 1))
+	return &proto.CooldownOverlapMetrics{
+		Id:                overlap.id.ToProto(),
+		OtherId:           overlap.otherID.ToProto(),
+		OverlapSecondsAvg: overlap.overlapSum / n,
+		OverlapChance:     float64(overlap.iterationsOverlapped) / n,
+	}
+}
+
+// Warnings for pairs of stacking cooldowns which never overlapped.
+func cooldownOverlapWarnings(overlaps []*proto.CooldownOverlapMetrics) []string {
+	var warnings []string
+	for _, overlap := range overlaps {
+		if overlap.OverlapChance == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is set to stack, but never overlapped with %s.",
+				ProtoToActionID(overlap.Id), ProtoToActionID(overlap.OtherId)))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,186 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func newStackingTestCooldown(spellID int32, policy proto.CooldownStackingPolicy) *MajorCooldown {
+	aura := &Aura{Label: "Stacking Test Aura"}
+	spell := &Spell{
+		ActionID:        ActionID{SpellID: spellID},
+		CD:              Cooldown{Timer: new(Timer), Duration: time.Minute * 2},
+		RelatedSelfBuff: aura,
+	}
+	return &MajorCooldown{
+		Spell:          spell,
+		Type:           CooldownTypeDPS,
+		stackingPolicy: policy,
+	}
+}
+
+func newStackingTestSim() *Simulation {
+	return &Simulation{
+		Environment: &Environment{Encounter: Encounter{Duration: time.Minute * 5}},
+		Duration:    time.Minute * 5,
+	}
+}
+
+func TestCooldownStackingAlways(t *testing.T) {
+	sim := newStackingTestSim()
+	mcd := newStackingTestCooldown(1, proto.CooldownStackingPolicy_CooldownStackingAlways)
+	other := newStackingTestCooldown(2, proto.CooldownStackingPolicy_CooldownStackingAlways)
+	mcdm := &majorCooldownManager{majorCooldowns: []*MajorCooldown{mcd, other}}
+
+	other.Spell.CD.Set(time.Second * 30)
+	if mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the cooldown to be held for the other stacking cooldown")
+	}
+
+	// Waiting would cost a use.
+	other.Spell.CD.Set(time.Minute * 3)
+	if !mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the cooldown to be used when the other one is too far away")
+	}
+
+	// Never held past the end of the fight.
+	sim.CurrentTime = time.Minute * 4
+	other.Spell.CD.Set(time.Minute*5 + time.Second*10)
+	if !mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the cooldown to be used when the other one isn't ready before the end of the fight")
+	}
+}
+
+func TestCooldownStackingStagger(t *testing.T) {
+	sim := newStackingTestSim()
+	mcd := newStackingTestCooldown(1, proto.CooldownStackingPolicy_CooldownStackingStagger)
+	other := newStackingTestCooldown(2, proto.CooldownStackingPolicy_CooldownStackingNone)
+	mcdm := &majorCooldownManager{majorCooldowns: []*MajorCooldown{mcd, other}}
+
+	if !mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the cooldown to be used while no other cooldown is active")
+	}
+
+	otherAura := other.effectAura()
+	otherAura.active = true
+	otherAura.expires = time.Second * 20
+	if mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the cooldown to be staggered with the active one")
+	}
+}
+
+func TestCooldownStackingAlignWithAura(t *testing.T) {
+	sim := newStackingTestSim()
+	mcd := newStackingTestCooldown(1, proto.CooldownStackingPolicy_CooldownStackingAlignWithAura)
+	mcd.alignAura = &Aura{Label: "Align Aura"}
+	mcdm := &majorCooldownManager{majorCooldowns: []*MajorCooldown{mcd}}
+
+	if mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the cooldown to wait for the aura")
+	}
+
+	mcd.alignAura.active = true
+	if !mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the cooldown to be used while the aura is active")
+	}
+
+	mcd.alignAura.active = false
+	sim.CurrentTime = time.Minute * 4
+	if !mcdm.stackingAllows(sim, mcd) {
+		t.Fatalf("Expected the last use not to be held past the end of the fight")
+	}
+}
+
+func TestCooldownOverlapWarnings(t *testing.T) {
+	overlaps := []*proto.CooldownOverlapMetrics{
+		{Id: ActionID{SpellID: 1}.ToProto(), OtherId: ActionID{SpellID: 2}.ToProto(), OverlapChance: 0.5},
+		{Id: ActionID{SpellID: 1}.ToProto(), OtherId: ActionID{SpellID: 3}.ToProto()},
+	}
+
+	warnings := cooldownOverlapWarnings(overlaps)
+	if len(warnings) != 1 || warnings[0] != "{SpellID: 1} is set to stack, but never overlapped with {SpellID: 3}." {
+		t.Fatalf("Expected a warning for the pair which never overlapped, got %v", warnings)
+	}
+}
+
+func registerStackingTestCooldown(character *Character, spellID int32, duration time.Duration) *Spell {
+	aura := character.RegisterAura(Aura{
+		Label:    fmt.Sprintf("Stacking Test Aura %d", spellID),
+		ActionID: ActionID{SpellID: spellID},
+		Duration: duration,
+	})
+	spell := character.RegisterSpell(SpellConfig{
+		ActionID: ActionID{SpellID: spellID},
+		Cast: CastConfig{
+			CD: Cooldown{Timer: character.NewTimer(), Duration: time.Minute * 2},
+		},
+		RelatedSelfBuff: aura,
+		ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
+			spell.RelatedSelfBuff.Activate(sim)
+		},
+	})
+	character.AddMajorCooldown(MajorCooldown{Spell: spell, Type: CooldownTypeDPS})
+	return spell
+}
+
+func TestCooldownOverlapsInSim(t *testing.T) {
+	var first, second *Spell
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		character := env.Raid.Parties[0].Players[0].GetCharacter()
+		first = registerStackingTestCooldown(character, 1, time.Second*20)
+		second = registerStackingTestCooldown(character, 2, time.Second*20)
+		character.cooldownConfigs.Cooldowns = []*proto.Cooldown{
+			{Id: first.ActionID.ToProto(), StackingPolicy: proto.CooldownStackingPolicy_CooldownStackingAlways},
+		}
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	target := sim.Encounter.ActiveTargetUnits[0]
+
+	// Overlapping from 15s to 30s, when the first one expires.
+	sim.CurrentTime = time.Second * 10
+	first.Cast(sim, target)
+	sim.CurrentTime = time.Second * 15
+	second.Cast(sim, target)
+	sim.CurrentTime = time.Second * 30
+	first.RelatedSelfBuff.Deactivate(sim)
+	sim.CurrentTime = time.Second * 35
+	second.RelatedSelfBuff.Deactivate(sim)
+
+	for _, overlap := range character.Metrics.cooldownOverlaps {
+		overlap.doneIteration()
+	}
+	overlaps := character.Metrics.ToProto().CooldownOverlaps
+	if len(overlaps) != 1 {
+		t.Fatalf("Expected the overlap of the stacking cooldown with the other one, got %d overlaps", len(overlaps))
+	}
+	if overlap := overlaps[0]; overlap.OverlapSecondsAvg != 15 || overlap.OverlapChance != 1 {
+		t.Fatalf("Expected a 15s overlap in every iteration, got %0.1fs at %0.2f", overlap.OverlapSecondsAvg, overlap.OverlapChance)
+	}
+}
+
+func TestCooldownAlignWithUnknownAuraWarns(t *testing.T) {
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		character := env.Raid.Parties[0].Players[0].GetCharacter()
+		spell := registerStackingTestCooldown(character, 1, time.Second*20)
+		character.cooldownConfigs.Cooldowns = []*proto.Cooldown{{
+			Id:             spell.ActionID.ToProto(),
+			StackingPolicy: proto.CooldownStackingPolicy_CooldownStackingAlignWithAura,
+			AlignAuraId:    ActionID{SpellID: 12345}.ToProto(),
+		}}
+	})
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+
+	rot := &APLRotation{unit: unit, uuidValidations: make(map[*proto.UUID][]*proto.APLValidation)}
+	rot.newActionAutocastOtherCooldowns(&proto.APLActionAutocastOtherCooldowns{}).PostFinalize(rot)
+
+	for _, validation := range rot.curValidations {
+		if validation.LogLevel == proto.LogLevel_Warning && strings.Contains(validation.Validation, "{SpellID: 12345}") {
+			return
+		}
+	}
+	t.Fatalf("Expected a warning for the unknown align aura, got %v", rot.curValidations)
+}
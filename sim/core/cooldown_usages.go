@@ -0,0 +1,101 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Times at which a major cooldown was used, in every iteration.
+type cooldownUsageMetrics struct {
+	// Use times in the current iteration.
+	iterationTimes []time.Duration
+
+	// Time between the cooldown being ready and being used in the current
+	// iteration, and the same divided by the cooldown duration.
+	iterationDrift    time.Duration
+	iterationLostUses float64
+
+	// Aggregate values. These are updated after each iteration.
+	usageTimes      []float32
+	iterationUsages []int32
+	hists           []map[int32]int32 // seconds to count, for each n-th use in an iteration
+	driftSum        float64
+	lostUsesSum     float64
+}
+
+func (unitMetrics *UnitMetrics) getCooldownUsages(actionID ActionID) *cooldownUsageMetrics {
+	usages, ok := unitMetrics.cooldownUsages[actionID]
+	if !ok {
+		// Iterations completed before the first use had no uses.
+		usages = &cooldownUsageMetrics{
+			iterationUsages: make([]int32, unitMetrics.dps.n),
+		}
+		unitMetrics.cooldownUsages[actionID] = usages
+	}
+	return usages
+}
+
+// Records a use of the major cooldown spell in the current iteration.
+func (unitMetrics *UnitMetrics) addCooldownUsage(sim *Simulation, actionID ActionID) {
+	usages := unitMetrics.getCooldownUsages(actionID)
+	usages.iterationTimes = append(usages.iterationTimes, sim.CurrentTime)
+}
+
+// Records the delay between a cooldown being ready at readyAt and being used
+// now. Delays before the pull don't count, since the first use is only
+// expected once combat starts.
+func (unitMetrics *UnitMetrics) addCooldownDrift(sim *Simulation, actionID ActionID, readyAt time.Duration, cooldown time.Duration) {
+	drift := sim.CurrentTime - max(readyAt, 0)
+	if sim.CurrentTime < 0 || drift <= 0 {
+		return
+	}
+
+	usages := unitMetrics.getCooldownUsages(actionID)
+	usages.iterationDrift += drift
+	if cooldown > 0 {
+		usages.iterationLostUses += float64(drift) / float64(cooldown)
+	}
+}
+
+func (usages *cooldownUsageMetrics) doneIteration() {
+	for i, usageTime := range usages.iterationTimes {
+		if i == len(usages.hists) {
+			usages.hists = append(usages.hists, make(map[int32]int32))
+		}
+		usages.hists[i][int32(math.Round(usageTime.Seconds()))]++
+		usages.usageTimes = append(usages.usageTimes, float32(usageTime.Seconds()))
+	}
+	usages.iterationUsages = append(usages.iterationUsages, int32(len(usages.iterationTimes)))
+	usages.iterationTimes = usages.iterationTimes[:0]
+
+	usages.driftSum += usages.iterationDrift.Seconds()
+	usages.lostUsesSum += usages.iterationLostUses
+	usages.iterationDrift = 0
+	usages.iterationLostUses = 0
+}
+
+// Drops uses outside of an iteration, e.g. in a fake prepull.
+func (usages *cooldownUsageMetrics) reset() {
+	usages.iterationTimes = usages.iterationTimes[:0]
+	usages.iterationDrift = 0
+	usages.iterationLostUses = 0
+}
+
+func (usages *cooldownUsageMetrics) ToProto(actionID ActionID) *proto.CooldownUsageMetrics {
+	protoMetrics := &proto.CooldownUsageMetrics{
+		Id:              actionID.ToProto(),
+		UsageTimes:      usages.usageTimes,
+		IterationUsages: usages.iterationUsages,
+		UsageHistograms: make([]*proto.CooldownUsageHistogram, len(usages.hists)),
+	}
+	if n := float64(len(usages.iterationUsages)); n > 0 {
+		protoMetrics.DriftSecondsAvg = usages.driftSum / n
+		protoMetrics.LostUsesAvg = usages.lostUsesSum / n
+	}
+	for i, hist := range usages.hists {
+		protoMetrics.UsageHistograms[i] = &proto.CooldownUsageHistogram{Hist: hist}
+	}
+	return protoMetrics
+}
@@ -0,0 +1,229 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+// Tracks the bosses of a health based fight in which several bosses have to
+// die, see proto.CouncilSettings.
+type council struct {
+	sharedHealth bool
+	bosses       []*councilBoss // In kill order.
+
+	// The first boss still alive, which execute phases are based on.
+	focus *councilBoss
+
+	// Raid units which had to switch targets because a boss died, restored on reset.
+	retargets []raidRetarget
+}
+
+type councilBoss struct {
+	target      *Target
+	health      float64
+	damageTaken float64
+	dead        bool
+}
+
+type raidRetarget struct {
+	unit     *Unit
+	previous *Unit
+}
+
+func targetHealth(options *proto.Target) float64 {
+	if int(stats.Health) >= len(options.Stats) {
+		return 0
+	}
+	return options.Stats[stats.Health]
+}
+
+func newCouncil(settings *proto.CouncilSettings, targetOptions []*proto.Target, targets []*Target) *council {
+	killOrder := settings.KillOrder
+	if len(killOrder) == 0 {
+		for targetIdx := range targetOptions {
+			killOrder = append(killOrder, int32(targetIdx))
+		}
+	}
+
+	council := &council{
+		sharedHealth: settings.SharedHealth,
+	}
+
+	for _, targetIdx := range killOrder {
+		if targetIdx < 0 || int(targetIdx) >= len(targetOptions) {
+			panic(fmt.Sprintf("Invalid target index %d in council kill order", targetIdx))
+		}
+		health := targetHealth(targetOptions[targetIdx])
+		if health <= 0 && !council.sharedHealth {
+			panic(fmt.Sprintf("Council boss %d has no health", targetIdx))
+		}
+		council.bosses = append(council.bosses, &councilBoss{
+			target: targets[targetIdx],
+			health: health,
+		})
+	}
+
+	if len(council.bosses) == 0 {
+		panic("Council fights require at least one boss")
+	}
+
+	// Bosses without health of their own share the pool of the others.
+	if council.sharedHealth {
+		poolHealth := 0.0
+		for _, boss := range council.bosses {
+			poolHealth = max(poolHealth, boss.health)
+		}
+		if poolHealth <= 0 {
+			panic("Council fights with shared health require a boss with health")
+		}
+		for _, boss := range council.bosses {
+			boss.health = poolHealth
+		}
+	}
+
+	council.focus = council.bosses[0]
+	return council
+}
+
+// The amount of damage the raid needs to deal to kill all bosses.
+func (council *council) totalHealth() float64 {
+	if council.sharedHealth {
+		return council.focus.health
+	}
+
+	totalHealth := 0.0
+	for _, boss := range council.bosses {
+		totalHealth += boss.health
+	}
+	return totalHealth
+}
+
+func (council *council) getBoss(unit *Unit) *councilBoss {
+	for _, boss := range council.bosses {
+		if &boss.target.Unit == unit {
+			return boss
+		}
+	}
+	return nil
+}
+
+func (council *council) nextAliveBoss() *councilBoss {
+	for _, boss := range council.bosses {
+		if !boss.dead {
+			return boss
+		}
+	}
+	return nil
+}
+
+func (council *council) onDamageTaken(sim *Simulation, target *Unit, damage float64) {
+	boss := council.getBoss(target)
+	if boss == nil || boss.dead {
+		return
+	}
+
+	if council.sharedHealth {
+		for _, boss := range council.bosses {
+			boss.damageTaken += damage
+		}
+		if council.focus.damageTaken >= council.focus.health {
+			for _, boss := range council.bosses {
+				boss.dead = true
+			}
+			council.endFight(sim, boss)
+		}
+		return
+	}
+
+	boss.damageTaken += damage
+	if boss.damageTaken >= boss.health {
+		council.kill(sim, boss)
+	}
+}
+
+func (council *council) kill(sim *Simulation, boss *councilBoss) {
+	boss.dead = true
+
+	next := council.nextAliveBoss()
+	if next == nil {
+		council.endFight(sim, boss)
+		return
+	}
+
+	if sim.LogEnabled(LogCategoryOther, LogLevelInfo) {
+		boss.target.LogAt(sim, LogCategoryOther, LogLevelInfo, "Died, the raid switches to %s", next.target.Label)
+	}
+
+	raidUnits := sim.Raid.AllUnits
+	previousTargets := make([]*Unit, len(raidUnits))
+	for idx, unit := range raidUnits {
+		previousTargets[idx] = unit.CurrentTarget
+	}
+
+	if len(sim.Encounter.ActiveTargets) > 1 && boss.target.IsEnabled() {
+		boss.target.Disable(sim, true)
+	}
+
+	for idx, unit := range raidUnits {
+		if previousTargets[idx] == &boss.target.Unit {
+			unit.CurrentTarget = &next.target.Unit
+		}
+		if unit.CurrentTarget != previousTargets[idx] {
+			council.retargets = append(council.retargets, raidRetarget{
+				unit:     unit,
+				previous: previousTargets[idx],
+			})
+		}
+	}
+
+	if council.focus == boss {
+		council.focus = next
+		sim.restartExecutePhases()
+	}
+}
+
+// Ends the iteration once the last boss died. Encounter.DamageTaken can't be
+// used for this, as it also counts damage to adds and overkill damage.
+func (council *council) endFight(sim *Simulation, lastBoss *councilBoss) {
+	if sim.LogEnabled(LogCategoryOther, LogLevelInfo) {
+		lastBoss.target.LogAt(sim, LogCategoryOther, LogLevelInfo, "Died, the fight ends")
+	}
+	sim.endOfCombatDuration = sim.CurrentTime
+}
+
+// Must be called before the targets are reset, so they pick up restored raid targets.
+func (council *council) reset(encounter *Encounter) {
+	for idx := len(council.retargets) - 1; idx >= 0; idx-- {
+		council.retargets[idx].unit.CurrentTarget = council.retargets[idx].previous
+	}
+	council.retargets = council.retargets[:0]
+
+	for _, boss := range council.bosses {
+		if boss.dead && !boss.target.IsEnabled() {
+			boss.target.enabled = true
+			encounter.addActiveTarget(boss.target)
+		}
+		boss.dead = false
+		boss.damageTaken = 0
+	}
+
+	council.focus = council.bosses[0]
+}
+
+// Health which execute phases are based on.
+func (encounter *Encounter) executeHealth() float64 {
+	if encounter.council != nil {
+		return encounter.council.focus.health
+	}
+	return encounter.EndFightAtHealth
+}
+
+// Damage taken which execute phases are based on.
+func (encounter *Encounter) executeDamageTaken() float64 {
+	if encounter.council != nil {
+		return encounter.council.focus.damageTaken
+	}
+	return encounter.DamageTaken
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func councilEncounter(council *proto.CouncilSettings, healths ...float64) *proto.Encounter {
+	encounter := &proto.Encounter{
+		UseHealth: true,
+		Council:   council,
+	}
+	for _, health := range healths {
+		encounter.Targets = append(encounter.Targets, &proto.Target{
+			Stats: stats.Stats{stats.Health: health}.ToProtoArray(),
+		})
+	}
+	return encounter
+}
+
+func TestCouncilIndependentHealth(t *testing.T) {
+	encounter := NewEncounter(councilEncounter(&proto.CouncilSettings{KillOrder: []int32{1, 0}}, 1000, 3000))
+
+	if encounter.EndFightAtHealth != 4000 {
+		t.Fatalf("Expected fight to end after 4000 damage, got %0.0f", encounter.EndFightAtHealth)
+	}
+	if encounter.council.focus.target != encounter.AllTargets[1] {
+		t.Fatalf("Expected the first boss in kill order to be focused")
+	}
+	if encounter.executeHealth() != 3000 {
+		t.Fatalf("Expected execute phases to be based on the focused boss, got %0.0f health", encounter.executeHealth())
+	}
+}
+
+func TestCouncilSharedHealth(t *testing.T) {
+	encounter := NewEncounter(councilEncounter(&proto.CouncilSettings{SharedHealth: true}, 2000, 2000))
+
+	if encounter.EndFightAtHealth != 2000 {
+		t.Fatalf("Expected fight to end after 2000 damage, got %0.0f", encounter.EndFightAtHealth)
+	}
+
+	encounter.council.onDamageTaken(nil, &encounter.AllTargets[1].Unit, 500)
+	if encounter.executeDamageTaken() != 500 {
+		t.Fatalf("Expected damage to any boss to count towards the shared pool, got %0.0f", encounter.executeDamageTaken())
+	}
+}
+
+func TestCouncilRejectsBossWithoutHealth(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected a boss without health to be rejected")
+		}
+	}()
+	NewEncounter(councilEncounter(&proto.CouncilSettings{}, 1000, 0))
+}
+
+func TestCouncilSharedHealthDefaultsMissingHealth(t *testing.T) {
+	encounter := NewEncounter(councilEncounter(&proto.CouncilSettings{SharedHealth: true}, 0, 2000))
+
+	if encounter.EndFightAtHealth != 2000 || encounter.executeHealth() != 2000 {
+		t.Fatalf("Expected a boss without health to share the pool of 2000, got %0.0f", encounter.executeHealth())
+	}
+}
+
+func TestRestartExecutePhasesRunsCallbacksOnce(t *testing.T) {
+	sim, _ := setupResultPipelineSim(nil)
+
+	var phases []int32
+	sim.RegisterExecutePhaseCallback(func(_ *Simulation, executePhase int32) {
+		phases = append(phases, executePhase)
+	})
+
+	sim.restartExecutePhases()
+	if len(phases) != 0 {
+		t.Fatalf("Expected no callbacks when restarting from the first phase, got %v", phases)
+	}
+
+	sim.executePhase = 20
+	sim.restartExecutePhases()
+	if len(phases) != 1 || phases[0] != 100 {
+		t.Fatalf("Expected a single callback for the first phase, got %v", phases)
+	}
+}
+
+func TestCouncilKillRetargetsAndEndsFight(t *testing.T) {
+	raidProto := SinglePlayerRaidProto(&proto.Player{
+		Name:      "Caster",
+		Class:     proto.Class_ClassShaman,
+		Buffs:     &proto.IndividualBuffs{},
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	encounterProto := councilEncounter(&proto.CouncilSettings{KillOrder: []int32{0, 1}}, 1000, 1000, 5000)
+	for _, target := range encounterProto.Targets {
+		target.Level = 90
+		target.MobType = proto.MobType_MobTypeDemon
+	}
+
+	env := &Environment{State: Created}
+	env.construct(raidProto, encounterProto)
+	raidStats := env.initialize(raidProto, encounterProto)
+
+	fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+	nuke := fa.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 44},
+		SpellSchool: SpellSchoolFire,
+		ProcMask:    ProcMaskSpellDamage,
+		Flags:       SpellFlagIgnoreModifiers,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, 600, spell.OutcomeAlwaysHit)
+		},
+	})
+
+	// Every second, the caster hits the add and its current target.
+	var hits []int32
+	fa.RegisterResetEffect(func(sim *Simulation) {
+		hits = hits[:0]
+		StartPeriodicAction(sim, PeriodicActionOptions{
+			Period: time.Second,
+			OnAction: func(sim *Simulation) {
+				nuke.Cast(sim, &sim.Encounter.AllTargets[2].Unit)
+				hits = append(hits, fa.CurrentTarget.Index)
+				nuke.Cast(sim, fa.CurrentTarget)
+			},
+		})
+	})
+
+	env.finalize(raidProto, encounterProto, raidStats, false)
+	sim := newSimWithEnv(env, &proto.SimOptions{RandomSeed: 100}, simsignals.CreateSignals())
+
+	// Run twice, to check that the kills and retargets are undone on reset.
+	for iteration := 0; iteration < 2; iteration++ {
+		sim.runOnce()
+
+		// Adds and overkill don't end the fight early, only the second boss dying does.
+		if !slices.Equal(hits, []int32{0, 0, 1, 1}) {
+			t.Fatalf("Iteration %d: expected the caster to kill both bosses in order, got hits on %v", iteration, hits)
+		}
+		if sim.CurrentTime != time.Second*4 {
+			t.Fatalf("Iteration %d: expected the fight to end when the last boss dies at 4s, got %s", iteration, sim.CurrentTime)
+		}
+		if sim.Encounter.AllTargets[0].IsEnabled() {
+			t.Fatalf("Iteration %d: expected the first boss to be disabled after dying", iteration)
+		}
+	}
+}
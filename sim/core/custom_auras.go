@@ -0,0 +1,103 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+// Registers the user defined auras of the player, which stand in for effects the
+// sim doesn't support, e.g. buffs from group members of unsupported specs.
+func (character *Character) applyCustomAuras(customAuras []*proto.CustomAura) {
+	for i, config := range customAuras {
+		character.registerCustomAura(i, config)
+	}
+}
+
+func customAuraLabel(index int, config *proto.CustomAura) string {
+	if config.Name == "" {
+		return fmt.Sprintf("Custom Aura %d", index+1)
+	}
+	return config.Name
+}
+
+// Returns an error for custom auras or scripted procs of the player which can't
+// be registered, so the request is rejected instead of panicking while the
+// environment is built.
+func validateCustomEffects(player *proto.Player) error {
+	labels := make(map[string]bool)
+	addLabel := func(label string) error {
+		if labels[label] {
+			return fmt.Errorf("%s: more than one custom aura or scripted proc is named %q", player.Name, label)
+		}
+		labels[label] = true
+		return nil
+	}
+
+	for i, config := range player.CustomAuras {
+		if err := addLabel(customAuraLabel(i, config)); err != nil {
+			return err
+		}
+	}
+
+	return validateScriptedProcs(player, addLabel)
+}
+
+func (character *Character) registerCustomAura(index int, config *proto.CustomAura) *Aura {
+	label := customAuraLabel(index, config)
+
+	var actionID ActionID
+	if config.Id != nil {
+		actionID = ProtoToActionID(config.Id)
+	}
+
+	duration := DurationFromSeconds(config.DurationSeconds)
+	permanent := duration <= 0
+
+	auraConfig := Aura{
+		Label:    label,
+		ActionID: actionID,
+		Duration: duration,
+	}
+	if permanent {
+		auraConfig.BuildPhase = CharacterBuildPhaseBuffs
+	}
+	aura := character.RegisterAura(auraConfig)
+
+	if config.Stats != nil {
+		aura.AttachStatsBuff(stats.FromUnitStatsProto(config.Stats))
+	}
+	if config.DamageMultiplier != 0 && config.DamageMultiplier != 1 {
+		aura.AttachMultiplicativePseudoStatBuff(&character.PseudoStats.DamageDealtMultiplier, config.DamageMultiplier)
+	}
+	if config.HasteMultiplier != 0 && config.HasteMultiplier != 1 {
+		aura.AttachMultiplyCastSpeed(config.HasteMultiplier)
+		aura.AttachMultiplyAttackSpeed(config.HasteMultiplier)
+	}
+
+	if permanent {
+		return MakePermanent(aura)
+	}
+
+	start := DurationFromSeconds(config.StartSeconds)
+	interval := DurationFromSeconds(config.IntervalSeconds)
+	character.RegisterResetEffect(func(sim *Simulation) {
+		sim.AddPendingAction(&PendingAction{
+			NextActionAt: start,
+			OnAction: func(sim *Simulation) {
+				aura.Activate(sim)
+				if interval > 0 {
+					StartPeriodicAction(sim, PeriodicActionOptions{
+						Period: interval,
+						OnAction: func(sim *Simulation) {
+							aura.Activate(sim)
+						},
+					})
+				}
+			},
+		})
+	})
+
+	return aura
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestValidateCustomEffects(t *testing.T) {
+	hitTrigger := []proto.ScriptedProc_Trigger{proto.ScriptedProc_TriggerSpellHitDealt}
+
+	tests := []struct {
+		name   string
+		player *proto.Player
+		err    string
+	}{
+		{
+			name: "valid",
+			player: &proto.Player{
+				CustomAuras:   []*proto.CustomAura{{Name: "Buff"}, {}},
+				ScriptedProcs: []*proto.ScriptedProc{{Name: "Proc", Triggers: hitTrigger}, {Triggers: hitTrigger}},
+			},
+		},
+		{
+			name:   "duplicate custom auras",
+			player: &proto.Player{CustomAuras: []*proto.CustomAura{{Name: "Buff"}, {Name: "Buff"}}},
+			err:    "more than one",
+		},
+		{
+			name:   "default label taken",
+			player: &proto.Player{CustomAuras: []*proto.CustomAura{{}, {Name: "Custom Aura 1"}}},
+			err:    "more than one",
+		},
+		{
+			name: "custom aura and scripted proc",
+			player: &proto.Player{
+				CustomAuras:   []*proto.CustomAura{{Name: "Proc Trigger"}},
+				ScriptedProcs: []*proto.ScriptedProc{{Name: "Proc", Triggers: hitTrigger}},
+			},
+			err: "more than one",
+		},
+		{
+			name:   "proc without triggers",
+			player: &proto.Player{ScriptedProcs: []*proto.ScriptedProc{{Name: "Proc"}}},
+			err:    "no triggers",
+		},
+	}
+
+	for _, test := range tests {
+		err := validateCustomEffects(test.player)
+		if test.err == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestCustomAuraActivatesOnSchedule(t *testing.T) {
+	var aura *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		aura = env.Raid.Parties[0].Players[0].GetCharacter().registerCustomAura(0, &proto.CustomAura{
+			Name:            "External Haste",
+			DurationSeconds: 10,
+			StartSeconds:    5,
+			IntervalSeconds: 60,
+			HasteMultiplier: 1.3,
+		})
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	baseCastSpeed := character.PseudoStats.CastSpeedMultiplier
+
+	for !aura.IsActive() && !sim.Step() {
+	}
+	if sim.CurrentTime != time.Second*5 {
+		t.Fatalf("Expected the aura to start at 5s, started at %s", sim.CurrentTime)
+	}
+	if castSpeed := character.PseudoStats.CastSpeedMultiplier; !WithinToleranceFloat64(baseCastSpeed*1.3, castSpeed, 1e-9) {
+		t.Fatalf("Expected the aura to multiply cast speed by 1.3, got %0.3f", castSpeed/baseCastSpeed)
+	}
+
+	sim.advance(time.Second * 15)
+	if aura.IsActive() || character.PseudoStats.CastSpeedMultiplier != baseCastSpeed {
+		t.Fatalf("Expected the aura to expire after 10s")
+	}
+
+	for !aura.IsActive() && !sim.Step() {
+	}
+	if sim.CurrentTime != time.Second*65 {
+		t.Fatalf("Expected the aura to return 60s after it started, returned at %s", sim.CurrentTime)
+	}
+}
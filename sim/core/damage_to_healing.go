@@ -0,0 +1,140 @@
+package core
+
+import (
+	"slices"
+)
+
+// Chooses the unit which receives healing converted from damage.
+type HealingConversionPolicy int32
+
+const (
+	// Heals the caster. This is the default.
+	HealingConversionSelf HealingConversionPolicy = iota
+
+	// Heals the raid member with the lowest health percent, the first one in
+	// raid order on ties.
+	HealingConversionLowestHealth
+
+	// Heals a random raid member.
+	HealingConversionRandom
+)
+
+type DamageToHealingConfig struct {
+	// Of the healing spell, which tracks all converted healing.
+	ActionID    ActionID
+	SpellSchool SpellSchool
+
+	// Fraction of the damage dealt which is converted into healing.
+	Ratio  float64
+	Policy HealingConversionPolicy
+
+	// If set, the damage of spells matching the mask is converted automatically,
+	// including periodic damage if ConvertPeriodic is set. Otherwise Convert()
+	// needs to be called with each damage result.
+	ClassSpellMask  int64
+	ConvertPeriodic bool
+}
+
+// Converts a portion of damage dealt into healing on another unit, e.g.
+// Atonement. The damage stays in the metrics of the spells which dealt it, and
+// the healing is tracked by a separate spell linked to them.
+type DamageToHealing struct {
+	Spell  *Spell
+	Ratio  float64
+	Policy HealingConversionPolicy
+}
+
+func (unit *Unit) RegisterDamageToHealing(config DamageToHealingConfig) *DamageToHealing {
+	dth := &DamageToHealing{
+		Ratio:  config.Ratio,
+		Policy: config.Policy,
+	}
+
+	dth.Spell = unit.RegisterSpell(SpellConfig{
+		ActionID:    config.ActionID,
+		SpellSchool: config.SpellSchool,
+		ProcMask:    ProcMaskSpellHealing,
+		Flags:       SpellFlagHelpful | SpellFlagPassiveSpell | SpellFlagNoOnCastComplete,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	if config.ClassSpellMask != 0 {
+		callback := CallbackOnSpellHitDealt
+		if config.ConvertPeriodic {
+			callback |= CallbackOnPeriodicDamageDealt
+		}
+
+		MakeProcTriggerAura(unit, ProcTrigger{
+			Name:           config.ActionID.String() + " Conversion",
+			Callback:       callback,
+			ClassSpellMask: config.ClassSpellMask,
+			Outcome:        OutcomeLanded,
+			Harmful:        true,
+			Handler: func(sim *Simulation, spell *Spell, result *SpellResult) {
+				dth.Convert(sim, spell, result)
+			},
+		})
+	}
+
+	return dth
+}
+
+// Heals the unit chosen by the policy for Ratio of the damage of the result,
+// which was dealt by source.
+func (dth *DamageToHealing) Convert(sim *Simulation, source *Spell, result *SpellResult) *SpellResult {
+	if !slices.Contains(dth.Spell.convertedFrom, source.ActionID) {
+		dth.Spell.convertedFrom = append(dth.Spell.convertedFrom, source.ActionID)
+	}
+	return dth.Spell.CalcAndDealHealingFromResult(sim, dth.healTarget(sim), result, dth.Ratio, dth.Spell.OutcomeHealing)
+}
+
+// Only enabled units with health can receive converted healing, which excludes
+// inactive pets.
+func canReceiveConvertedHealing(unit *Unit) bool {
+	return unit.HasHealthBar() && unit.IsEnabled()
+}
+
+func (dth *DamageToHealing) healTarget(sim *Simulation) *Unit {
+	caster := dth.Spell.Unit
+	switch dth.Policy {
+	case HealingConversionLowestHealth:
+		target := caster
+		lowestHealth := 2.0
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			if !canReceiveConvertedHealing(unit) {
+				continue
+			}
+			if health := unit.CurrentHealthPercent(); health < lowestHealth {
+				target = unit
+				lowestHealth = health
+			}
+		}
+		return target
+	case HealingConversionRandom:
+		numEligible := 0
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			if canReceiveConvertedHealing(unit) {
+				numEligible++
+			}
+		}
+		if numEligible == 0 {
+			return caster
+		}
+
+		idx := int(sim.RandomFloat("Healing Conversion Target") * float64(numEligible))
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			if !canReceiveConvertedHealing(unit) {
+				continue
+			}
+			if idx == 0 {
+				return unit
+			}
+			idx--
+		}
+		return caster
+	default:
+		return caster
+	}
+}
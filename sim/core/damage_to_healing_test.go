@@ -0,0 +1,125 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDamageToHealing(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	dth := fa.RegisterDamageToHealing(DamageToHealingConfig{
+		ActionID:    ActionID{SpellID: 43},
+		SpellSchool: SpellSchoolHoly,
+		Ratio:       0.5,
+		Policy:      HealingConversionLowestHealth,
+	})
+	// Registered after the sim was finalized.
+	dth.Spell.finalize()
+
+	damage := &SpellResult{Target: sim.Encounter.AllTargetUnits[0], Damage: 1000, Outcome: OutcomeHit}
+	heal := dth.Convert(sim, fa.Spell, damage)
+	if heal.Target != &fa.Unit {
+		t.Fatalf("Expected the only raid member to be healed, got %s", heal.Target.Label)
+	}
+	if expected := TernaryFloat64(heal.DidCrit(), 1000, 500); !WithinToleranceFloat64(heal.Damage, expected, 0.01) {
+		t.Fatalf("Expected %0.2f healing, got %0.2f", expected, heal.Damage)
+	}
+
+	dth.Convert(sim, fa.Spell, damage)
+	if len(dth.Spell.convertedFrom) != 1 || dth.Spell.convertedFrom[0] != fa.Spell.ActionID {
+		t.Fatalf("Expected the healing to be linked to %s once, got %v", fa.Spell.ActionID, dth.Spell.convertedFrom)
+	}
+
+	if heal := dth.Convert(sim, fa.Spell, &SpellResult{Target: damage.Target, Damage: 1000, Outcome: OutcomeMiss}); heal != nil {
+		t.Fatalf("Expected no healing from a miss")
+	}
+}
+
+func TestDamageToHealingRandomSkipsUnitsWithoutHealth(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	dth := fa.RegisterDamageToHealing(DamageToHealingConfig{
+		ActionID:    ActionID{SpellID: 43},
+		SpellSchool: SpellSchoolHoly,
+		Ratio:       0.5,
+		Policy:      HealingConversionRandom,
+	})
+
+	disabledPet := &Unit{Label: "Disabled Pet"}
+	sim.Raid.AllPlayerUnits = append(sim.Raid.AllPlayerUnits, disabledPet)
+	for range 20 {
+		if target := dth.healTarget(sim); target != &fa.Unit {
+			t.Fatalf("Expected only %s to be picked, got %s", fa.Label, target.Label)
+		}
+	}
+}
+
+func TestDamageToHealingConvertsClassSpellMask(t *testing.T) {
+	var converted, other *Spell
+	sim, callbacks := setupResultPipelineSim(func(env *Environment) {
+		fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+		registerDamageSpell := func(spellID int32, classSpellMask int64) *Spell {
+			return fa.RegisterSpell(SpellConfig{
+				ActionID:         ActionID{SpellID: spellID},
+				SpellSchool:      SpellSchoolShadow,
+				ProcMask:         ProcMaskSpellDamage,
+				ClassSpellMask:   classSpellMask,
+				DamageMultiplier: 1,
+				ThreatMultiplier: 1,
+
+				Dot: DotConfig{
+					Aura:          Aura{Label: "Converted Dot"},
+					NumberOfTicks: 2,
+					TickLength:    time.Second * 3,
+					OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+						dot.Spell.CalcAndDealPeriodicDamage(sim, target, 1000, dot.OutcomeTick)
+					},
+				},
+
+				ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+					spell.CalcAndDealDamage(sim, target, 1000, spell.OutcomeAlwaysHit)
+				},
+			})
+		}
+		converted = registerDamageSpell(50, 1)
+		other = registerDamageSpell(51, 2)
+
+		fa.RegisterDamageToHealing(DamageToHealingConfig{
+			ActionID:        ActionID{SpellID: 43},
+			SpellSchool:     SpellSchoolHoly,
+			Ratio:           0.5,
+			ClassSpellMask:  1,
+			ConvertPeriodic: true,
+		})
+	})
+	target := sim.Encounter.AllTargetUnits[0]
+
+	heals := func() int {
+		count := 0
+		for _, callback := range *callbacks {
+			if callback == "OnHealDealt" {
+				count++
+			}
+		}
+		return count
+	}
+
+	converted.Cast(sim, target)
+	if heals() != 1 {
+		t.Fatalf("Expected damage of a matching spell to be converted, got %v", *callbacks)
+	}
+	other.Cast(sim, target)
+	other.Dot(target).Apply(sim)
+	other.Dot(target).TickOnce(sim)
+	if heals() != 1 {
+		t.Fatalf("Expected damage of other spells not to be converted, got %v", *callbacks)
+	}
+	converted.Dot(target).Apply(sim)
+	converted.Dot(target).TickOnce(sim)
+	if heals() != 2 {
+		t.Fatalf("Expected periodic damage of a matching spell to be converted, got %v", *callbacks)
+	}
+}
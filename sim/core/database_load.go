@@ -12,6 +12,7 @@ import (
 func init() {
 	db := database.Load()
 	WITH_DB = true
+	DatabaseVersion = database.Revision()
 
 	simDB := &proto.SimDatabase{
 		Items:                    make([]*proto.SimItem, len(db.Items)),
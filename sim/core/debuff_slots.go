@@ -0,0 +1,71 @@
+package core
+
+import (
+	"cmp"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type debuffOverwriteKey struct {
+	ActionID ActionID
+	Reason   proto.DebuffOverwriteReason
+}
+
+func (key debuffOverwriteKey) compare(other debuffOverwriteKey) int {
+	return cmp.Or(key.ActionID.Compare(other.ActionID), cmp.Compare(key.Reason, other.Reason))
+}
+
+// Returns the unit which applied the debuff, or nil if the aura isn't a debuff
+// applied by another unit.
+func (aura *Aura) debuffCaster() *Unit {
+	if aura.Unit.Type != EnemyUnit || aura.lastSource == nil || aura.ActionID.IsEmptyAction() {
+		return nil
+	}
+	if caster := aura.lastSource.Unit; caster != aura.Unit {
+		return caster
+	}
+	return nil
+}
+
+// Should be called when a debuff is removed or suppressed by another debuff, so
+// the caster can see why their debuff had less uptime than expected.
+func (aura *Aura) recordDebuffOverwrite(sim *Simulation, reason proto.DebuffOverwriteReason) {
+	caster := aura.debuffCaster()
+	if caster == nil || sim.CurrentTime < 0 {
+		return
+	}
+
+	caster.Metrics.debuffOverwrites[debuffOverwriteKey{ActionID: aura.ActionID, Reason: reason}]++
+	if sim.LogEnabled(LogCategoryAuras, LogLevelInfo) {
+		caster.LogAt(sim, LogCategoryAuras, LogLevelInfo, "Debuff %s on %s overwritten (%s)", aura.ActionID, aura.Unit.Label, reason)
+	}
+}
+
+// Makes room for the debuff when the target's debuff slots are full, by removing
+// the oldest one.
+func (aura *Aura) claimDebuffSlot(sim *Simulation) {
+	if aura.debuffCaster() == nil {
+		return
+	}
+	slots := int(aura.Unit.Env.Encounter.DebuffSlots)
+	if slots == 0 {
+		return
+	}
+
+	var numDebuffs int
+	var oldest *Aura
+	for _, active := range aura.Unit.activeAuras {
+		if active.debuffCaster() == nil {
+			continue
+		}
+		numDebuffs++
+		if oldest == nil || active.startTime < oldest.startTime {
+			oldest = active
+		}
+	}
+
+	if numDebuffs >= slots && oldest != nil {
+		oldest.recordDebuffOverwrite(sim, proto.DebuffOverwriteReason_DebuffOverwriteSlotLimit)
+		oldest.Deactivate(sim)
+	}
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestDebuffSlotLimitRemovesOldest(t *testing.T) {
+	sim := &Simulation{}
+	env := &Environment{Encounter: Encounter{DebuffSlots: 2}}
+
+	player := &Unit{Type: PlayerUnit, Metrics: NewUnitMetrics(), auraTracker: newAuraTracker()}
+	target := &Unit{Type: EnemyUnit, Env: env, auraTracker: newAuraTracker()}
+
+	debuffs := make([]*Aura, 3)
+	for i := range debuffs {
+		actionID := ActionID{SpellID: int32(100 + i)}
+		debuffs[i] = target.RegisterAura(Aura{
+			Label:    actionID.String(),
+			ActionID: actionID,
+			Duration: time.Minute,
+		})
+	}
+	source := &Spell{Unit: player}
+
+	for i, debuff := range debuffs {
+		sim.CurrentTime = time.Duration(i) * time.Second
+		sim.activeSpell = source
+		debuff.Activate(sim)
+		sim.activeSpell = nil
+	}
+
+	if debuffs[0].IsActive() || !debuffs[1].IsActive() || !debuffs[2].IsActive() {
+		t.Fatalf("Expected the oldest debuff to be removed once the slots were full")
+	}
+
+	key := debuffOverwriteKey{ActionID: debuffs[0].ActionID, Reason: proto.DebuffOverwriteReason_DebuffOverwriteSlotLimit}
+	if overwrites := player.Metrics.debuffOverwrites[key]; overwrites != 1 {
+		t.Fatalf("Expected 1 recorded overwrite, got %d", overwrites)
+	}
+
+	sim.CurrentTime = 3 * time.Second
+	sim.activeSpell = source
+	debuffs[0].Activate(sim)
+	sim.activeSpell = nil
+
+	// Map iteration order is random, so check the output order repeatedly.
+	for range 10 {
+		overwrites := player.Metrics.ToProto().DebuffOverwrites
+		if len(overwrites) != 2 || overwrites[0].Id.GetSpellId() != 100 || overwrites[1].Id.GetSpellId() != 101 {
+			t.Fatalf("Expected overwrites of spells 100 and 101 in order, got %v", overwrites)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestDisabledAuraNeverActivates(t *testing.T) {
+	sim := &Simulation{}
+
+	unit := Unit{
+		Type:        PlayerUnit,
+		auraTracker: newAuraTracker(),
+		disabledAuras: protoToActionIDSet([]*proto.ActionID{
+			{RawId: &proto.ActionID_SpellId{SpellId: 12345}},
+		}),
+	}
+	disabled := unit.RegisterAura(Aura{
+		Label:    "Disabled",
+		ActionID: ActionID{SpellID: 12345, Tag: 1},
+		Duration: time.Second * 10,
+	})
+	enabled := unit.RegisterAura(Aura{
+		Label:    "Enabled",
+		ActionID: ActionID{SpellID: 54321},
+		Duration: time.Second * 10,
+	})
+
+	disabled.Activate(sim)
+	enabled.Activate(sim)
+
+	if disabled.IsActive() {
+		t.Fatalf("Aura disabled by the user was activated")
+	}
+	if !enabled.IsActive() {
+		t.Fatalf("Aura which isn't disabled failed to activate")
+	}
+}
+
+func TestDisabledSpellNeverAppliesEffects(t *testing.T) {
+	var spell *Spell
+	applied := 0
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		unit := &env.Raid.Parties[0].Players[0].GetCharacter().Unit
+		unit.disabledSpells = protoToActionIDSet([]*proto.ActionID{
+			{RawId: &proto.ActionID_SpellId{SpellId: 12345}},
+		})
+		spell = unit.RegisterSpell(SpellConfig{
+			ActionID: ActionID{SpellID: 12345},
+			ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {
+				applied++
+			},
+		})
+	})
+	target := sim.Encounter.AllTargetUnits[0]
+
+	if spell.Cast(sim, target) {
+		t.Fatalf("Spell disabled by the user was cast")
+	}
+	// Procs skip the cast.
+	spell.SkipCastAndApplyEffects(sim, target)
+	if applied != 0 || spell.castsThisIteration() != 0 {
+		t.Fatalf("Spell disabled by the user applied its effects %d times, with %d casts", applied, spell.castsThisIteration())
+	}
+}
@@ -9,6 +9,45 @@ import (
 type OnSnapshot func(sim *Simulation, target *Unit, dot *Dot, isRollover bool)
 type OnTick func(sim *Simulation, target *Unit, dot *Dot)
 
+// When haste changes the tick rate of a dot affected by haste.
+type TickRatePolicy int32
+
+const (
+	// The tick rate is snapshotted whenever the dot is applied, including refreshes. This is the default.
+	TickRatePolicyHybrid TickRatePolicy = iota
+
+	// The tick rate is snapshotted when the dot is first applied, and kept when it is refreshed.
+	TickRatePolicySnapshot
+
+	// The tick rate is recalculated after every tick, the remaining number of ticks stays the same.
+	TickRatePolicyDynamic
+)
+
+// How the ticks of a dot which gains ticks from haste fit into its duration.
+type TickAlignment int32
+
+const (
+	// Haste rounds the number of ticks to the nearest whole tick, which changes the duration. This is the default.
+	TickAlignmentRounded TickAlignment = iota
+
+	// The duration stays the same, and the last tick deals partial damage for the time left after the full ticks.
+	TickAlignmentPartial
+)
+
+// Tick scheduling rules which differ between expansions, and sometimes between
+// specs. When haste changes the tick rate is set per dot, see TickRatePolicy.
+type DotTickRules struct {
+	Alignment TickAlignment
+
+	// The dot ticks once when first applied, on top of its regular ticks.
+	TickOnApplication bool
+}
+
+// Rules used by dots which don't set their own.
+var DefaultDotTickRules = DotTickRules{
+	Alignment: TickAlignmentRounded,
+}
+
 type DotConfig struct {
 	// Optional, will default to the corresponding spell.
 	Spell *Spell
@@ -27,9 +66,19 @@ type DotConfig struct {
 	AffectedByRealHaste  bool // tick length are shortened based on real haste (melee/ranged but not spell)
 	HasteReducesDuration bool // does not gain additional ticks after a certain haste threshold
 
+	// Mechanic of the periodic effect alone, out of SpellFlagMechanics, e.g. the bleed of a spell
+	// with a direct hit which isn't a bleed. Mechanics of the spell apply to its dot as well.
+	Mechanic SpellFlag
+
+	RefreshPolicy  RefreshPolicy  // Ticks carried over when reapplied while active, on top of the tick in progress.
+	TickRatePolicy TickRatePolicy // When haste changes the tick rate, if affected by haste.
+	TickRules      *DotTickRules  // Optional, defaults to DefaultDotTickRules.
+
 	BonusCoefficient float64 // EffectBonusCoefficient in SpellEffect client DB table, "SP mod" on Wowhead (not necessarily shown there even if > 0)
 
 	PeriodicDamageMultiplier float64 // Multiplier for periodic damage on top of the spell's damage multiplier
+
+	TrackSnapshots bool // Records the power of each snapshot besides rollovers, see SnapshotTracker. Requires the spell's ExpectedTickDamage.
 }
 
 type Dot struct {
@@ -41,13 +90,16 @@ type Dot struct {
 	onTick     OnTick
 	tickAction *PendingAction
 
-	tickPeriod     time.Duration // hasted time between each tick, rounded to full ms
-	BaseTickLength time.Duration // time between each tick
+	tickPeriod        time.Duration // hasted time between each tick, rounded to full ms
+	partialTickPeriod time.Duration // time before a partial last tick, 0 if the last tick is a full one
+	BaseTickLength    time.Duration // time between each tick
 
 	SnapshotBaseDamage         float64
 	SnapshotCritChance         float64
 	SnapshotAttackerMultiplier float64
 
+	SnapshotTracker *SnapshotTracker // Only set for dots which track their snapshots.
+
 	BaseTickCount          int32 // base tick count without haste applied
 	remainingTicks         int32
 	tmpExtraTicks          int32   // extra ticks that are added during the runtime of the dot
@@ -61,6 +113,10 @@ type Dot struct {
 	affectedByRealHaste  bool // tick length are shortened based on real haste
 	hasteReducesDuration bool // does not gain additional ticks after a haste threshold, HasteAffectsDuration in dbc
 	isChanneled          bool
+	mechanics            SpellFlag
+	refreshPolicy        RefreshPolicy
+	tickRatePolicy       TickRatePolicy
+	tickRules            DotTickRules
 }
 
 // Takes a new snapshot of this Dot's effects.
@@ -73,13 +129,19 @@ func (dot *Dot) TakeSnapshot(sim *Simulation, doRollover bool) {
 	if dot.onSnapshot != nil {
 		dot.onSnapshot(sim, dot.Unit, dot, doRollover)
 	}
+	if dot.SnapshotTracker != nil && !doRollover {
+		dot.SnapshotTracker.Record(sim)
+	}
 }
 
 // Snapshots and activates the Dot
 // If the Dot is already active it's duration will be refreshed and the last tick from the previous application will be
 // transfered to the new one
 func (dot *Dot) Apply(sim *Simulation) {
-	if dot.Spell.Flags&SpellFlagSupressDoTApply > 0 {
+	if dot.Spell.Flags&SpellFlagSupressDoTApply > 0 || dot.targetIsImmune() {
+		return
+	}
+	if dot.refreshPolicy == RefreshPolicyIgnore && dot.IsActive() {
 		return
 	}
 
@@ -92,7 +154,10 @@ func (dot *Dot) Apply(sim *Simulation) {
 // If the Dot is already active it's duration will be refreshed and the last tick from the previous application will be
 // transfered to the new one
 func (dot *Dot) ApplyRollover(sim *Simulation) {
-	if dot.Spell.Flags&SpellFlagSupressDoTApply > 0 {
+	if dot.Spell.Flags&SpellFlagSupressDoTApply > 0 || dot.targetIsImmune() {
+		return
+	}
+	if dot.refreshPolicy == RefreshPolicyIgnore && dot.IsActive() {
 		return
 	}
 
@@ -101,6 +166,11 @@ func (dot *Dot) ApplyRollover(sim *Simulation) {
 	dot.Activate(sim)
 }
 
+// Returns whether the unit is immune to the spell, or to the mechanic of the dot alone.
+func (dot *Dot) targetIsImmune() bool {
+	return dot.Unit.IsImmuneTo(dot.Spell) || dot.Unit.MechanicImmunities&dot.mechanics != 0
+}
+
 // Calculates the current tick period the dot would have based on the affects currently present
 func (dot *Dot) CalcTickPeriod() time.Duration {
 	if dot.affectedByCastSpeed {
@@ -121,8 +191,18 @@ func (dot *Dot) CalcTickPeriod() time.Duration {
 
 func (dot *Dot) recomputeAuraDuration(sim *Simulation) {
 	nextTick := dot.TimeUntilNextTick(sim)
+	remainingAfterNextTick := TernaryDuration(dot.IsActive(), max(dot.RemainingDuration(sim)-nextTick, 0), 0)
+
+	if dot.tickRatePolicy != TickRatePolicySnapshot || !dot.IsActive() {
+		dot.tickPeriod = dot.CalcTickPeriod()
+	}
+
+	dot.partialTickPeriod = 0
+	if dot.tickRules.Alignment == TickAlignmentPartial && (dot.affectedByCastSpeed || dot.affectedByRealHaste) && !dot.hasteReducesDuration {
+		dot.recomputePartialTicks(sim, nextTick, remainingAfterNextTick)
+		return
+	}
 
-	dot.tickPeriod = dot.CalcTickPeriod()
 	dot.remainingTicks = dot.calculateTickCount(dot.BaseDuration(), dot.BaseTickLength)
 	if (dot.affectedByCastSpeed || dot.affectedByRealHaste) && !dot.hasteReducesDuration {
 		dot.remainingTicks = dot.HastedTickCount()
@@ -134,12 +214,76 @@ func (dot *Dot) recomputeAuraDuration(sim *Simulation) {
 	// we a have running dot tick
 	// the next tick never gets clipped and is added onto the dot's time for hasted dots
 	// see: https://github.com/wowsims/mop/issues/50
+	if dot.IsActive() {
+		// any further ticks are carried over according to the refresh policy, as far as they fit
+		carriedOver := dot.refreshPolicy.refreshedDuration(dot.Duration, remainingAfterNextTick) - dot.Duration
+
+		dot.Duration += nextTick
+		dot.remainingTicks++
+
+		carriedTicks := max(0, int32(carriedOver/dot.tickPeriod))
+		if carriedTicks > 0 {
+			dot.tmpExtraTicks = carriedTicks
+			dot.remainingTicks += carriedTicks
+			dot.Duration += dot.tickPeriod * time.Duration(carriedTicks)
+		}
+		dot.recordRefresh(sim, remainingAfterNextTick, dot.tickPeriod*time.Duration(carriedTicks))
+	}
+}
+
+// Fits as many full ticks as possible into the unhasted duration, followed by a
+// partial tick for the time left over.
+func (dot *Dot) recomputePartialTicks(sim *Simulation, nextTick time.Duration, remainingAfterNextTick time.Duration) {
+	duration := dot.BaseDuration()
+	if dot.IsActive() {
+		duration = dot.refreshPolicy.refreshedDuration(duration, remainingAfterNextTick)
+	}
+
+	fullTicks := int32(duration / dot.tickPeriod)
+	dot.partialTickPeriod = duration - dot.tickPeriod*time.Duration(fullTicks)
+	dot.remainingTicks = fullTicks + TernaryInt32(dot.partialTickPeriod > 0, 1, 0)
+	dot.tmpExtraTicks = 0
+	dot.Duration = duration
+
+	// the tick in progress still happens, same as with rounded ticks
 	if dot.IsActive() {
 		dot.Duration += nextTick
 		dot.remainingTicks++
+		dot.recordRefresh(sim, remainingAfterNextTick, duration-dot.BaseDuration())
 	}
 }
 
+// Tracks the remaining duration carried over and lost when refreshing the
+// active DoT, see TargetedActionMetrics.dot_clipped_refreshes.
+func (dot *Dot) recordRefresh(sim *Simulation, remaining time.Duration, carriedOver time.Duration) {
+	if dot.isChanneled || sim.CurrentTime < 0 {
+		return
+	}
+
+	spellMetrics := &dot.Spell.SpellMetrics[dot.Unit.UnitIndex]
+	spellMetrics.DotPandemicTime += carriedOver
+	if lost := remaining - carriedOver; lost > 0 {
+		spellMetrics.DotClippedRefresh++
+		spellMetrics.DotClippedTime += lost
+	}
+}
+
+// Time until the tick after the next one, shorter than the tick period before a partial last tick.
+func (dot *Dot) nextTickPeriod() time.Duration {
+	if dot.partialTickPeriod > 0 && dot.remainingTicks == 1 {
+		return dot.partialTickPeriod
+	}
+	return dot.tickPeriod
+}
+
+// Fraction of a full tick dealt by the tick in progress, less than 1 for a partial last tick.
+func (dot *Dot) tickFraction() float64 {
+	if dot.partialTickPeriod > 0 && dot.remainingTicks == 0 {
+		return float64(dot.partialTickPeriod) / float64(dot.tickPeriod)
+	}
+	return 1
+}
+
 // TickPeriod is how fast the snapshotted dot ticks.
 func (dot *Dot) TickPeriod() time.Duration {
 	return dot.tickPeriod
@@ -187,7 +331,15 @@ func (dot *Dot) TickCount() int32 {
 }
 
 func (dot *Dot) OutstandingDmg() float64 {
-	return TernaryFloat64(dot.IsActive(), dot.SnapshotBaseDamage*float64(dot.remainingTicks), 0)
+	if !dot.IsActive() {
+		return 0
+	}
+
+	outstandingTicks := float64(dot.remainingTicks)
+	if dot.partialTickPeriod > 0 && dot.remainingTicks > 0 {
+		outstandingTicks -= 1 - float64(dot.partialTickPeriod)/float64(dot.tickPeriod)
+	}
+	return dot.SnapshotBaseDamage * outstandingTicks
 }
 
 func (dot *Dot) BaseDuration() time.Duration {
@@ -212,6 +364,7 @@ func (dot *Dot) CopyDotAndApply(sim *Simulation, originaldot *Dot) {
 	dot.SnapshotBaseDamage = originaldot.SnapshotBaseDamage
 
 	dot.tickPeriod = originaldot.tickPeriod
+	dot.partialTickPeriod = originaldot.partialTickPeriod
 	dot.remainingTicks = originaldot.remainingTicks
 	dot.tmpExtraTicks = 0
 
@@ -248,6 +401,7 @@ func (dot *Dot) DurationExtendSnapshot(sim *Simulation, extendBy time.Duration)
 
 	previousTick := dot.tickAction.NextActionAt - dot.tickPeriod
 	dot.tickPeriod = dot.CalcTickPeriod()
+	dot.partialTickPeriod = 0
 
 	// ensure the tick is at least scheduled for the future ..
 	nextTick := max(previousTick+dot.tickPeriod, sim.CurrentTime+1*time.Millisecond)
@@ -272,17 +426,36 @@ func (dot *Dot) DurationExtendSnapshot(sim *Simulation, extendBy time.Duration)
 // Forces an instant tick. Does not reset the tick timer or aura duration,
 // the tick is simply an extra tick.
 func (dot *Dot) TickOnce(sim *Simulation) {
+	if audit := sim.varianceAudit; audit != nil {
+		outerSpell := audit.spell
+		audit.spell = dot.Spell
+		defer func() { audit.spell = outerSpell }()
+	}
+
 	dot.onTick(sim, dot.Unit, dot)
 }
 
 func (dot *Dot) periodicTick(sim *Simulation) {
+	if !dot.isChanneled && sim.CurrentTime > 0 {
+		// Before the decrement this is the period leading up to the current tick.
+		dot.Spell.SpellMetrics[dot.Unit.UnitIndex].DotTickTime += dot.nextTickPeriod()
+	}
 	dot.remainingTicks--
+	outerSpell := sim.activeSpell
+	sim.activeSpell = dot.Spell
 	dot.TickOnce(sim)
+	sim.activeSpell = outerSpell
 	if dot.isChanneled {
+		// Units without an APL, e.g. pets with custom rotations, don't use consumables or interrupt channels.
+		rot := dot.Spell.Unit.Rotation
+		if rot != nil {
+			rot.useChannelConsumables(sim)
+		}
+
 		// Note: even if the clip delay is 0ms, need a WaitUntil so that APL is called after the channel aura fades.
 		if dot.remainingTicks == 0 && dot.Spell.Unit.GCD.IsReady(sim) {
 			dot.Spell.Unit.WaitUntil(sim, sim.CurrentTime+dot.getChannelClipDelay(sim))
-		} else if dot.Spell.Unit.Rotation.shouldInterruptChannel(sim) {
+		} else if rot != nil && rot.shouldInterruptChannel(sim) {
 			dot.tickAction.NextActionAt = NeverExpires // don't tick again in ApplyOnExpire
 			dot.Deactivate(sim)
 			if dot.Spell.Unit.GCD.IsReady(sim) {
@@ -293,9 +466,23 @@ func (dot *Dot) periodicTick(sim *Simulation) {
 		}
 	}
 
-	// Dot might have been disabled in tick
+	// Dot might have been disabled in tick. Dots of dismissed pets keep the tick rate
+	// they had while the pet was active.
+	if dot.IsActive() && dot.tickRatePolicy == TickRatePolicyDynamic && dot.remainingTicks > 0 && dot.Spell.Unit.IsEnabled() {
+		if tickPeriod := dot.CalcTickPeriod(); tickPeriod != dot.tickPeriod {
+			// a partial last tick keeps its fraction of the tick period
+			dot.partialTickPeriod = dot.partialTickPeriod * tickPeriod / dot.tickPeriod
+			dot.tickPeriod = tickPeriod
+			dot.Duration = dot.tickPeriod * time.Duration(dot.remainingTicks)
+			if dot.partialTickPeriod > 0 {
+				dot.Duration -= dot.tickPeriod - dot.partialTickPeriod
+			}
+			dot.Refresh(sim)
+		}
+	}
+
 	if dot.IsActive() {
-		dot.tickAction.NextActionAt = sim.CurrentTime + dot.tickPeriod
+		dot.tickAction.NextActionAt = sim.CurrentTime + dot.nextTickPeriod()
 		sim.AddPendingAction(dot.tickAction)
 	}
 }
@@ -306,7 +493,12 @@ func (dot *Dot) getChannelClipDelay(sim *Simulation) time.Duration {
 		return dot.Spell.Unit.ChannelClipDelay
 	}
 
-	nextAction := dot.Spell.Unit.Rotation.getNextAction(sim)
+	rot := dot.Spell.Unit.Rotation
+	if rot == nil {
+		return dot.Spell.Unit.ChannelClipDelay
+	}
+
+	nextAction := rot.getNextAction(sim)
 	if nextAction == nil {
 		return dot.Spell.Unit.ChannelClipDelay
 	}
@@ -342,15 +534,22 @@ func (dot *Dot) ChannelCanBeInterrupted(sim *Simulation) bool {
 	return true
 }
 
-func newDot(config Dot) *Dot {
+func newDot(config Dot, trackSnapshots bool) *Dot {
 	dot := &config
 
 	dot.tickPeriod = dot.BaseTickLength
 	dot.Duration = dot.tickPeriod * time.Duration(dot.BaseTickCount)
 
+	if trackSnapshots {
+		dot.SnapshotTracker = &SnapshotTracker{dot: dot}
+		dot.ApplyOnReset(func(_ *Aura, _ *Simulation) {
+			dot.SnapshotTracker.reset()
+		})
+	}
+
 	dot.ApplyOnGain(func(aura *Aura, sim *Simulation) {
 		dot.tickAction = &PendingAction{
-			NextActionAt: sim.CurrentTime + dot.tickPeriod,
+			NextActionAt: sim.CurrentTime + dot.nextTickPeriod(),
 			// Priority:     ActionPriorityDOT,
 			OnAction: dot.periodicTick,
 		}
@@ -358,6 +557,9 @@ func newDot(config Dot) *Dot {
 		if dot.isChanneled {
 			dot.Spell.Unit.ChanneledDot = dot
 		}
+		if dot.tickRules.TickOnApplication {
+			dot.TickOnce(sim)
+		}
 	})
 	dot.ApplyOnExpire(func(aura *Aura, sim *Simulation) {
 		// the core scheduling fails to process ticks first so we need to apply the last tick
@@ -374,8 +576,10 @@ func newDot(config Dot) *Dot {
 		dot.tickAction = nil
 		if dot.isChanneled {
 			dot.Spell.Unit.ChanneledDot = nil
-			dot.Spell.Unit.Rotation.interruptChannelIf = nil
-			dot.Spell.Unit.Rotation.allowChannelRecastOnInterrupt = false
+			if rot := dot.Spell.Unit.Rotation; rot != nil {
+				rot.interruptChannelIf = nil
+				rot.allowChannelRecastOnInterrupt = false
+			}
 			// track time metrics for channels
 			dot.Spell.SpellMetrics[aura.Unit.UnitIndex].TotalCastTime += dot.fadeTime - dot.StartedAt()
 		}
@@ -383,6 +587,7 @@ func newDot(config Dot) *Dot {
 		dot.SnapshotAttackerMultiplier = 0
 		dot.SnapshotBaseDamage = 0
 		dot.SnapshotCritChance = 0
+		dot.partialTickPeriod = 0
 	})
 
 	return dot
@@ -406,6 +611,12 @@ func (spell *Spell) createDots(config DotConfig, isHot bool) {
 	if config.Spell == nil {
 		config.Spell = spell
 	}
+	if config.TickRules == nil {
+		config.TickRules = &DefaultDotTickRules
+	}
+	if config.TrackSnapshots && config.Spell.expectedTickDamageInternal == nil {
+		panic("Dots tracking their snapshots need ExpectedTickDamage: " + config.Spell.ActionID.String())
+	}
 	dot := Dot{
 		Spell: config.Spell,
 
@@ -418,6 +629,10 @@ func (spell *Spell) createDots(config DotConfig, isHot bool) {
 		affectedByRealHaste:  config.AffectedByRealHaste,
 		hasteReducesDuration: config.HasteReducesDuration,
 		isChanneled:          config.Spell.Flags.Matches(SpellFlagChanneled),
+		mechanics:            (config.Mechanic | config.Spell.Flags) & SpellFlagMechanics,
+		refreshPolicy:        config.RefreshPolicy,
+		tickRatePolicy:       config.TickRatePolicy,
+		tickRules:            *config.TickRules,
 
 		BonusCoefficient:         config.BonusCoefficient,
 		BaseDurationMultiplier:   1,
@@ -425,6 +640,8 @@ func (spell *Spell) createDots(config DotConfig, isHot bool) {
 	}
 
 	auraConfig := config.Aura
+	// Refreshes are handled by the dot, which fits the carried over duration into whole ticks.
+	auraConfig.RefreshPolicy = RefreshPolicyOverwrite
 	if auraConfig.ActionID.IsEmptyAction() {
 		auraConfig.ActionID = dot.Spell.ActionID
 	}
@@ -432,7 +649,7 @@ func (spell *Spell) createDots(config DotConfig, isHot bool) {
 	caster := dot.Spell.Unit
 	if config.IsAOE || config.SelfOnly {
 		dot.Aura = caster.GetOrRegisterAura(auraConfig)
-		spell.aoeDot = newDot(dot)
+		spell.aoeDot = newDot(dot, config.TrackSnapshots)
 	} else {
 		auraConfig.Label += "-" + strconv.Itoa(int(caster.UnitIndex))
 		if spell.dots == nil {
@@ -441,7 +658,7 @@ func (spell *Spell) createDots(config DotConfig, isHot bool) {
 		for _, target := range caster.Env.AllUnits {
 			if isHot != caster.IsOpponent(target) {
 				dot.Aura = target.GetOrRegisterAura(auraConfig)
-				spell.dots[target.UnitIndex] = newDot(dot)
+				spell.dots[target.UnitIndex] = newDot(dot, config.TrackSnapshots)
 			}
 		}
 	}
@@ -456,6 +673,7 @@ type DotState struct {
 	TicksRemaining             int32
 	ExtraTicks                 int32
 	TickPeriod                 time.Duration
+	PartialTickPeriod          time.Duration
 	NextTickIn                 time.Duration
 }
 
@@ -469,12 +687,14 @@ func (dot *Dot) SaveState(sim *Simulation) DotState {
 		TicksRemaining:             dot.remainingTicks,
 		ExtraTicks:                 dot.tmpExtraTicks,
 		TickPeriod:                 dot.tickPeriod,
+		PartialTickPeriod:          dot.partialTickPeriod,
 		NextTickIn:                 dot.NextTickAt() - sim.CurrentTime,
 	}
 }
 
 func (dot *Dot) RestoreState(state DotState, sim *Simulation) {
 	dot.tickPeriod = state.TickPeriod
+	dot.partialTickPeriod = state.PartialTickPeriod
 	dot.remainingTicks = state.TicksRemaining
 	dot.tmpExtraTicks = state.ExtraTicks
 	dot.SnapshotBaseDamage = state.SnapshotBaseDamage
@@ -171,3 +171,295 @@ func TestDotSnapshotSpellMultiplier(t *testing.T) {
 	fa.Dot.Apply(sim)
 	expectDotTickDamage(t, sim, fa.Dot, 300) // (100) * 1.5 * 2
 }
+
+func TestDotTickRatePolicy(t *testing.T) {
+	testCases := []struct {
+		policy          TickRatePolicy
+		expectedRefresh time.Duration
+		expectedTicks   int32
+	}{
+		// 18s of ticks after the refresh, on top of the tick in progress.
+		{TickRatePolicyHybrid, time.Second * 2, 10},
+		{TickRatePolicySnapshot, time.Second * 3, 7},
+		{TickRatePolicyDynamic, time.Second * 2, 10},
+	}
+
+	for _, testCase := range testCases {
+		sim := SetupFakeSim()
+		fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+		fa.Dot.tickRatePolicy = testCase.policy
+
+		fa.Dot.Apply(sim)
+		if fa.Dot.TickPeriod() != time.Second*3 {
+			t.Fatalf("Expected an unhasted tick period of 3s, got %s", fa.Dot.TickPeriod())
+		}
+
+		// Haste gained mid-dot only changes the tick rate of dynamic dots, on the next tick.
+		fa.MultiplyCastSpeed(sim, 1.5)
+		fa.Dot.periodicTick(sim)
+		expectedAfterTick := TernaryDuration(testCase.policy == TickRatePolicyDynamic, time.Second*2, time.Second*3)
+		if fa.Dot.TickPeriod() != expectedAfterTick {
+			t.Fatalf("Policy %d: expected a tick period of %s after a tick, got %s", testCase.policy, expectedAfterTick, fa.Dot.TickPeriod())
+		}
+		if testCase.policy == TickRatePolicyDynamic {
+			if remaining := fa.Dot.RemainingDuration(sim); remaining != time.Second*10 {
+				t.Fatalf("Expected the 5 remaining ticks to take 10s, got %s", remaining)
+			}
+		}
+
+		fa.Dot.Apply(sim)
+		if fa.Dot.TickPeriod() != testCase.expectedRefresh {
+			t.Fatalf("Policy %d: expected a tick period of %s after a refresh, got %s", testCase.policy, testCase.expectedRefresh, fa.Dot.TickPeriod())
+		}
+		if fa.Dot.RemainingTicks() != testCase.expectedTicks {
+			t.Fatalf("Policy %d: expected %d ticks after a refresh, got %d", testCase.policy, testCase.expectedTicks, fa.Dot.RemainingTicks())
+		}
+
+		// The tick rate doesn't change the damage of each tick.
+		damageBefore := fa.Spell.SpellMetrics[0].TotalDamage
+		for fa.Dot.RemainingTicks() > 0 {
+			fa.Dot.periodicTick(sim)
+		}
+		expectedDamage := 150 * float64(testCase.expectedTicks)
+		if damage := fa.Spell.SpellMetrics[0].TotalDamage - damageBefore; !WithinToleranceFloat64(expectedDamage, damage, 0.01) {
+			t.Fatalf("Policy %d: expected %0.1f damage from the remaining ticks, got %0.1f", testCase.policy, expectedDamage, damage)
+		}
+	}
+}
+
+func TestDotTickRules(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rules               DotTickRules
+		expectedTicks       int32
+		expectedDuration    time.Duration
+		expectedApplyDamage float64
+		expectedLastTickDmg float64
+	}{
+		{"Rounded", DotTickRules{Alignment: TickAlignmentRounded}, 8, time.Millisecond * 19200, 0, 150},
+		{"Partial", DotTickRules{Alignment: TickAlignmentPartial}, 8, time.Second * 18, 0, 75},
+		{"TickOnApplication", DotTickRules{Alignment: TickAlignmentRounded, TickOnApplication: true}, 8, time.Millisecond * 19200, 150, 150},
+	}
+
+	for _, testCase := range testCases {
+		sim := SetupFakeSim()
+		fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+		fa.Dot.tickRules = testCase.rules
+
+		// 3s ticks become 2.4s ticks, so 7.5 ticks fit into the 18s base duration.
+		fa.MultiplyCastSpeed(sim, 1.25)
+
+		damageBefore := fa.Spell.SpellMetrics[0].TotalDamage
+		fa.Dot.Apply(sim)
+		if applyDamage := fa.Spell.SpellMetrics[0].TotalDamage - damageBefore; !WithinToleranceFloat64(testCase.expectedApplyDamage, applyDamage, 0.01) {
+			t.Fatalf("%s: expected %0.1f damage on application, got %0.1f", testCase.name, testCase.expectedApplyDamage, applyDamage)
+		}
+		if fa.Dot.RemainingTicks() != testCase.expectedTicks {
+			t.Fatalf("%s: expected %d ticks, got %d", testCase.name, testCase.expectedTicks, fa.Dot.RemainingTicks())
+		}
+		if fa.Dot.Duration != testCase.expectedDuration {
+			t.Fatalf("%s: expected a duration of %s, got %s", testCase.name, testCase.expectedDuration, fa.Dot.Duration)
+		}
+
+		for fa.Dot.RemainingTicks() > 1 {
+			fa.Dot.periodicTick(sim)
+		}
+
+		damageBefore = fa.Spell.SpellMetrics[0].TotalDamage
+		fa.Dot.periodicTick(sim)
+		if lastTickDamage := fa.Spell.SpellMetrics[0].TotalDamage - damageBefore; !WithinToleranceFloat64(testCase.expectedLastTickDmg, lastTickDamage, 0.01) {
+			t.Fatalf("%s: expected %0.1f damage on the last tick, got %0.1f", testCase.name, testCase.expectedLastTickDmg, lastTickDamage)
+		}
+	}
+}
+
+func TestDotRefreshPolicy(t *testing.T) {
+	testCases := []struct {
+		name              string
+		policy            RefreshPolicy
+		alignment         TickAlignment
+		expectedTicks     int32
+		expectedRemaining time.Duration
+		expectedDamage    float64
+	}{
+		// 9s are left after the tick in progress, which is always kept.
+		{"Overwrite", RefreshPolicyOverwrite, TickAlignmentRounded, 7, time.Second * 21, 7 * 150},
+		{"Pandemic", RefreshPolicyPandemic, TickAlignmentRounded, 8, time.Second * 24, 8 * 150},
+		{"Extend", RefreshPolicyExtend, TickAlignmentRounded, 10, time.Second * 30, 10 * 150},
+		{"Ignore", RefreshPolicyIgnore, TickAlignmentRounded, 4, time.Second * 12, 4 * 150},
+		// 5.4s are carried over, the last 2.4s as a partial tick.
+		{"PartialPandemic", RefreshPolicyPandemic, TickAlignmentPartial, 9, time.Millisecond * 26400, 8*150 + 120},
+	}
+
+	for _, testCase := range testCases {
+		sim := SetupFakeSim()
+		fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+		fa.Dot.refreshPolicy = testCase.policy
+		fa.Dot.tickRules.Alignment = testCase.alignment
+
+		sim.CurrentTime = time.Second
+		fa.Dot.Apply(sim)
+		for range 2 {
+			sim.CurrentTime += time.Second * 3
+			fa.Dot.periodicTick(sim)
+		}
+		fa.Dot.Apply(sim)
+
+		if fa.Dot.RemainingTicks() != testCase.expectedTicks {
+			t.Fatalf("%s: expected %d ticks after the refresh, got %d", testCase.name, testCase.expectedTicks, fa.Dot.RemainingTicks())
+		}
+		if remaining := fa.Dot.RemainingDuration(sim); remaining != testCase.expectedRemaining {
+			t.Fatalf("%s: expected %s remaining after the refresh, got %s", testCase.name, testCase.expectedRemaining, remaining)
+		}
+
+		damageBefore := fa.Spell.SpellMetrics[0].TotalDamage
+		for fa.Dot.RemainingTicks() > 0 {
+			fa.Dot.periodicTick(sim)
+		}
+		if damage := fa.Spell.SpellMetrics[0].TotalDamage - damageBefore; !WithinToleranceFloat64(testCase.expectedDamage, damage, 0.01) {
+			t.Fatalf("%s: expected %0.1f damage from the remaining ticks, got %0.1f", testCase.name, testCase.expectedDamage, damage)
+		}
+	}
+}
+
+func TestDotRefreshMetrics(t *testing.T) {
+	testCases := []struct {
+		policy           RefreshPolicy
+		expectedClips    int32
+		expectedClipped  time.Duration
+		expectedPandemic time.Duration
+	}{
+		// The 3 ticks left after the one in progress are dropped, or carried over as
+		// far as whole ticks fit into 30% of 18s.
+		{RefreshPolicyOverwrite, 1, time.Second * 9, 0},
+		{RefreshPolicyPandemic, 1, time.Second * 6, time.Second * 3},
+		{RefreshPolicyExtend, 0, 0, time.Second * 9},
+	}
+
+	for _, testCase := range testCases {
+		sim := SetupFakeSim()
+		fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+		fa.Dot.refreshPolicy = testCase.policy
+
+		sim.CurrentTime = time.Second
+		fa.Dot.Apply(sim)
+		for range 2 {
+			sim.CurrentTime += time.Second * 3
+			fa.Dot.periodicTick(sim)
+		}
+		fa.Dot.Apply(sim)
+
+		spellMetrics := fa.Spell.SpellMetrics[0]
+		if spellMetrics.DotTickTime != time.Second*6 {
+			t.Fatalf("Policy %d: expected 6s covered by ticks, got %s", testCase.policy, spellMetrics.DotTickTime)
+		}
+		if spellMetrics.DotClippedRefresh != testCase.expectedClips || spellMetrics.DotClippedTime != testCase.expectedClipped {
+			t.Fatalf("Policy %d: expected %d clipped refreshes losing %s, got %d losing %s", testCase.policy,
+				testCase.expectedClips, testCase.expectedClipped, spellMetrics.DotClippedRefresh, spellMetrics.DotClippedTime)
+		}
+		if spellMetrics.DotPandemicTime != testCase.expectedPandemic {
+			t.Fatalf("Policy %d: expected %s carried over, got %s", testCase.policy, testCase.expectedPandemic, spellMetrics.DotPandemicTime)
+		}
+
+		sim.CurrentTime = time.Second * 60
+		fa.Spell.doneIteration(sim)
+		if maxTicks := fa.Spell.SpellMetrics[0].DotMaxTicks; !WithinToleranceFloat64(20, maxTicks, 0.0001) {
+			t.Fatalf("Policy %d: expected 20 possible ticks in 60s, got %0.2f", testCase.policy, maxTicks)
+		}
+	}
+}
+
+func TestDotMaxTicksForEachMetricSplit(t *testing.T) {
+	sim := SetupFakeSim()
+	spell := sim.Raid.Parties[0].Players[0].(*FakeAgent).Spell
+	spell.splitSpellMetrics = append(spell.splitSpellMetrics, make([]SpellMetrics, len(spell.SpellMetrics)))
+
+	// 2 ticks over 6s before the split changes, 1 tick over 3s after.
+	spell.splitSpellMetrics[0][0].Ticks = 2
+	spell.splitSpellMetrics[0][0].DotTickTime = time.Second * 6
+	spell.SetMetricsSplit(1)
+	spell.SpellMetrics[0].Ticks = 1
+	spell.SpellMetrics[0].DotTickTime = time.Second * 3
+
+	sim.CurrentTime = time.Second * 60
+	spell.doneIteration(sim)
+	for i, spellMetrics := range spell.splitSpellMetrics {
+		if maxTicks := spellMetrics[0].DotMaxTicks; !WithinToleranceFloat64(20, maxTicks, 0.0001) {
+			t.Fatalf("Split %d: expected 20 possible ticks in 60s, got %0.2f", i, maxTicks)
+		}
+	}
+}
+
+func TestDotExpiresWithOwner(t *testing.T) {
+	for _, expiresWithOwner := range []bool{false, true} {
+		sim := SetupFakeSim()
+		fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+		fa.Dot.ExpiresWithOwner = expiresWithOwner
+
+		sim.activeSpell = fa.Dot.Spell
+		fa.Dot.Apply(sim)
+		sim.activeSpell = nil
+
+		fa.Dot.Unit.expireAurasAppliedBy(sim, &fa.Unit)
+		if fa.Dot.IsActive() == expiresWithOwner {
+			t.Fatalf("ExpiresWithOwner %t: expected the dot to be active = %t", expiresWithOwner, !expiresWithOwner)
+		}
+	}
+
+	// Auras applied by other units are kept.
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.Dot.ExpiresWithOwner = true
+	sim.activeSpell = fa.Dot.Spell
+	fa.Dot.Apply(sim)
+	sim.activeSpell = nil
+
+	fa.Dot.Unit.expireAurasAppliedBy(sim, fa.Dot.Unit)
+	if !fa.Dot.IsActive() {
+		t.Fatalf("Expected the dot applied by another unit to stay active")
+	}
+}
+
+func TestChannelWithoutRotation(t *testing.T) {
+	var channel *Spell
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+		channel = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 43},
+			SpellSchool: SpellSchoolArcane,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagChanneled | SpellFlagAPL,
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+
+			Dot: DotConfig{
+				Aura: Aura{
+					Label: "Fake Channel",
+				},
+				NumberOfTicks: 3,
+				TickLength:    time.Second,
+				OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+					dot.Spell.CalcAndDealPeriodicDamage(sim, target, 100, dot.OutcomeTick)
+				},
+			},
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.Dot(target).Apply(sim)
+			},
+		})
+	})
+
+	// Pets with custom rotations and non-focused players have no APL.
+	channel.Unit.Rotation = nil
+	channel.Cast(sim, channel.Unit.CurrentTarget)
+	for sim.CurrentTime < time.Second*4 && !sim.Step() {
+	}
+
+	if ticks := channel.SpellMetrics[0].Ticks; ticks != 3 {
+		t.Fatalf("Expected 3 channel ticks, got %d", ticks)
+	}
+	if channel.CurDot().IsActive() {
+		t.Fatalf("Expected the channel to have finished")
+	}
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// A raid wide damage modifier phase scripted by an encounter, e.g. a window in
+// which all players deal 100% increased damage, or take 50% reduced damage.
+type EncounterDamageModifierConfig struct {
+	Label    string
+	ActionID ActionID
+
+	// When each phase starts, relative to the start of the encounter.
+	StartTimes []time.Duration
+	Duration   time.Duration
+
+	// Multipliers applied to all players and pets while a phase is active. 0 leaves the value unchanged.
+	DamageDealtMultiplier float64
+	DamageTakenMultiplier float64
+}
+
+type encounterDamageModifier struct {
+	EncounterDamageModifierConfig
+	auras AuraArray
+}
+
+// Registers a raid wide damage modifier phase, which is applied to every player
+// and pet as an aura. Meant to be called by TargetAIs during initialization.
+func (env *Environment) RegisterEncounterDamageModifier(config EncounterDamageModifierConfig) {
+	if config.DamageDealtMultiplier == 0 {
+		config.DamageDealtMultiplier = 1
+	}
+	if config.DamageTakenMultiplier == 0 {
+		config.DamageTakenMultiplier = 1
+	}
+
+	modifier := &encounterDamageModifier{EncounterDamageModifierConfig: config}
+	env.Encounter.damageModifiers = append(env.Encounter.damageModifiers, modifier)
+
+	env.RegisterPreFinalizeEffect(func() {
+		modifier.auras = make(AuraArray, len(env.AllUnits))
+		for _, unit := range env.Raid.AllUnits {
+			modifier.auras[unit.UnitIndex] = modifier.registerAura(unit)
+		}
+	})
+}
+
+// Registers the damage modifier phases configured on the encounter, see proto.EncounterDamageModifier.
+func (env *Environment) registerEncounterDamageModifiers(modifiersProto []*proto.EncounterDamageModifier) {
+	for modifierIdx, modifierProto := range modifiersProto {
+		if len(modifierProto.StartTimes) == 0 || modifierProto.Duration <= 0 {
+			continue
+		}
+
+		startTimes := make([]time.Duration, len(modifierProto.StartTimes))
+		for idx, startTime := range modifierProto.StartTimes {
+			startTimes[idx] = DurationFromSeconds(max(startTime, 0))
+		}
+
+		env.RegisterEncounterDamageModifier(EncounterDamageModifierConfig{
+			Label:                 fmt.Sprintf("Encounter Damage Modifier-%d", modifierIdx),
+			StartTimes:            startTimes,
+			Duration:              DurationFromSeconds(modifierProto.Duration),
+			DamageDealtMultiplier: modifierProto.DamageDealtMultiplier,
+			DamageTakenMultiplier: modifierProto.DamageTakenMultiplier,
+		})
+	}
+}
+
+func (modifier *encounterDamageModifier) registerAura(unit *Unit) *Aura {
+	return unit.RegisterAura(Aura{
+		Label:    modifier.Label,
+		ActionID: modifier.ActionID,
+		Duration: modifier.Duration,
+
+		OnReset: func(aura *Aura, sim *Simulation) {
+			for _, startTime := range modifier.StartTimes {
+				pa := sim.GetConsumedPendingActionFromPool()
+				pa.NextActionAt = startTime
+				pa.Priority = ActionPriorityDOT
+
+				pa.OnAction = func(sim *Simulation) {
+					aura.Activate(sim)
+				}
+
+				sim.AddPendingAction(pa)
+			}
+		},
+	}).AttachMultiplicativePseudoStatBuff(
+		&unit.PseudoStats.DamageDealtMultiplier, modifier.DamageDealtMultiplier,
+	).AttachMultiplicativePseudoStatBuff(
+		&unit.PseudoStats.DamageTakenMultiplier, modifier.DamageTakenMultiplier,
+	)
+}
+
+// Returns the product of all encounter damage modifiers currently active on the unit.
+func (encounter *Encounter) DamageModifierMultipliers(unit *Unit) (damageDealt float64, damageTaken float64) {
+	damageDealt, damageTaken = 1, 1
+	for _, modifier := range encounter.damageModifiers {
+		if aura := modifier.auras.Get(unit); aura != nil && aura.IsActive() {
+			damageDealt *= modifier.DamageDealtMultiplier
+			damageTaken *= modifier.DamageTakenMultiplier
+		}
+	}
+	return damageDealt, damageTaken
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestEncounterDamageModifierPhases(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+			DamageModifiers: []*proto.EncounterDamageModifier{
+				{StartTimes: []float64{10}, Duration: 5, DamageDealtMultiplier: 2, DamageTakenMultiplier: 0.5},
+				{Duration: 5, DamageDealtMultiplier: 3},
+			},
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	if len(sim.Encounter.damageModifiers) != 1 {
+		t.Fatalf("Expected modifiers without start times to be skipped, got %d modifiers", len(sim.Encounter.damageModifiers))
+	}
+
+	player := sim.Raid.AllPlayerUnits[0]
+	aura := sim.Encounter.damageModifiers[0].auras.Get(player)
+	baseDamageDealt := player.PseudoStats.DamageDealtMultiplier
+	baseDamageTaken := player.PseudoStats.DamageTakenMultiplier
+
+	for !aura.IsActive() && !sim.Step() {
+	}
+	if sim.CurrentTime != time.Second*10 {
+		t.Fatalf("Expected the phase to start at 10s, started at %s", sim.CurrentTime)
+	}
+	if damageDealt, damageTaken := sim.Encounter.DamageModifierMultipliers(player); damageDealt != 2 || damageTaken != 0.5 {
+		t.Fatalf("Expected multipliers of 2 and 0.5 during the phase, got %0.2f and %0.2f", damageDealt, damageTaken)
+	}
+	if player.PseudoStats.DamageDealtMultiplier != baseDamageDealt*2 || player.PseudoStats.DamageTakenMultiplier != baseDamageTaken*0.5 {
+		t.Fatalf("Expected the phase to modify the player's damage dealt and taken")
+	}
+
+	sim.advance(time.Second * 15)
+	if aura.IsActive() {
+		t.Fatalf("Expected the phase to end at 15s")
+	}
+	if damageDealt, damageTaken := sim.Encounter.DamageModifierMultipliers(player); damageDealt != 1 || damageTaken != 1 {
+		t.Fatalf("Expected multipliers of 1 after the phase, got %0.2f and %0.2f", damageDealt, damageTaken)
+	}
+	if player.PseudoStats.DamageDealtMultiplier != baseDamageDealt || player.PseudoStats.DamageTakenMultiplier != baseDamageTaken {
+		t.Fatalf("Expected the player's damage dealt and taken to be restored after the phase")
+	}
+}
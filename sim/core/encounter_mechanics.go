@@ -0,0 +1,83 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Recurring windows of forced movement or downtime, see proto.EncounterMechanic.
+type encounterMechanic struct {
+	mechanicType      proto.EncounterMechanic_MechanicType
+	interval          time.Duration
+	duration          time.Duration
+	intervalVariation time.Duration
+
+	// One immunity aura per target, for untargetable windows.
+	untargetableAuras []*Aura
+}
+
+func (env *Environment) registerEncounterMechanics(mechanicsProto []*proto.EncounterMechanic) {
+	for mechanicIdx, mechanicProto := range mechanicsProto {
+		if mechanicProto.Interval <= 0 || mechanicProto.Duration <= 0 {
+			continue
+		}
+
+		mechanic := &encounterMechanic{
+			mechanicType:      mechanicProto.Type,
+			interval:          DurationFromSeconds(mechanicProto.Interval),
+			duration:          DurationFromSeconds(mechanicProto.Duration),
+			intervalVariation: DurationFromSeconds(min(max(mechanicProto.IntervalVariation, 0), mechanicProto.Interval)),
+		}
+
+		if mechanic.mechanicType == proto.EncounterMechanic_MechanicUntargetable {
+			for _, target := range env.Encounter.AllTargetUnits {
+				mechanic.untargetableAuras = append(mechanic.untargetableAuras, env.RegisterTargetSchoolImmunity(TargetSchoolImmunityConfig{
+					Label:    fmt.Sprintf("Untargetable-%d", mechanicIdx),
+					Target:   target,
+					Schools:  SpellSchoolPhysical | SpellSchoolChaos,
+					Duration: mechanic.duration,
+				}))
+			}
+		}
+
+		env.Encounter.mechanics = append(env.Encounter.mechanics, mechanic)
+	}
+}
+
+func (mechanic *encounterMechanic) reset(sim *Simulation) {
+	mechanic.scheduleNextWindow(sim)
+}
+
+func (mechanic *encounterMechanic) scheduleNextWindow(sim *Simulation) {
+	nextWindowAt := sim.CurrentTime + mechanic.interval
+	if mechanic.intervalVariation > 0 {
+		nextWindowAt += DurationFromSeconds(sim.RollWithLabel(-mechanic.intervalVariation.Seconds(), mechanic.intervalVariation.Seconds(), "Encounter Mechanic"))
+	}
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = nextWindowAt
+	pa.Priority = ActionPriorityDOT
+
+	pa.OnAction = func(sim *Simulation) {
+		mechanic.startWindow(sim)
+		mechanic.scheduleNextWindow(sim)
+	}
+
+	sim.AddPendingAction(pa)
+}
+
+func (mechanic *encounterMechanic) startWindow(sim *Simulation) {
+	switch mechanic.mechanicType {
+	case proto.EncounterMechanic_MechanicMovement:
+		// Players are notified through their movement callbacks, see Unit.OnMovement.
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			unit.MoveDuration(mechanic.duration, sim)
+		}
+	case proto.EncounterMechanic_MechanicUntargetable:
+		for _, aura := range mechanic.untargetableAuras {
+			aura.Activate(sim)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestEncounterMechanicsRegistration(t *testing.T) {
+	env := &Environment{
+		Encounter: NewEncounter(&proto.Encounter{
+			Targets: []*proto.Target{{}, {}},
+		}),
+	}
+
+	env.registerEncounterMechanics([]*proto.EncounterMechanic{
+		{Type: proto.EncounterMechanic_MechanicMovement, Interval: 30, Duration: 3, IntervalVariation: 60},
+		{Type: proto.EncounterMechanic_MechanicUntargetable, Interval: 90, Duration: 10},
+		{Type: proto.EncounterMechanic_MechanicUntargetable, Interval: 0, Duration: 10},
+	})
+
+	if len(env.Encounter.mechanics) != 2 {
+		t.Fatalf("Expected mechanics without an interval to be skipped, got %d mechanics", len(env.Encounter.mechanics))
+	}
+
+	movement := env.Encounter.mechanics[0]
+	if movement.intervalVariation != time.Second*30 {
+		t.Fatalf("Expected the variation to be capped at the interval, got %s", movement.intervalVariation)
+	}
+
+	untargetable := env.Encounter.mechanics[1]
+	if len(untargetable.untargetableAuras) != 2 {
+		t.Fatalf("Expected an untargetable aura on each target, got %d", len(untargetable.untargetableAuras))
+	}
+
+	target := untargetable.untargetableAuras[0].Unit
+	if env.Encounter.IsImmuneToSpell(&Spell{SpellSchool: SpellSchoolPhysical}, target) {
+		t.Fatalf("Expected targets to take damage outside of untargetable windows")
+	}
+
+	untargetable.untargetableAuras[0].Activate(&Simulation{})
+	if !env.Encounter.IsImmuneToSpell(&Spell{SpellSchool: SpellSchoolPhysical}, target) || !env.Encounter.IsImmuneToSpell(&Spell{SpellSchool: SpellSchoolShadowFrost}, target) {
+		t.Fatalf("Expected targets to take no damage during untargetable windows")
+	}
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+const defaultPresetDuration = 300.0
+
+// Builds an encounter for one of the preset fight archetypes, so benchmarks
+// don't need hand-crafted targets.
+func BuildEncounterPreset(request *proto.EncounterPresetRequest) *proto.EncounterPresetResult {
+	duration := request.Duration
+	if duration == 0 {
+		duration = defaultPresetDuration
+	}
+	if duration < 0 {
+		return &proto.EncounterPresetResult{ErrorResult: "Encounter duration must be positive"}
+	}
+	if request.NumTargets < 0 {
+		return &proto.EncounterPresetResult{ErrorResult: "Number of targets can't be negative"}
+	}
+
+	baseTarget := request.BaseTarget
+	if baseTarget == nil {
+		baseTarget = FreshDefaultTargetConfig()
+	}
+
+	encounter := &proto.Encounter{
+		ApiVersion:           GetCurrentProtoVersion(),
+		Duration:             duration,
+		ExecuteProportion_20: 0.2,
+		ExecuteProportion_25: 0.25,
+		ExecuteProportion_35: 0.35,
+		ExecuteProportion_45: 0.45,
+		ExecuteProportion_90: 0.90,
+	}
+
+	switch request.Preset {
+	case proto.EncounterPresetType_EncounterPresetDualBoss:
+		if request.BossHealth <= 0 {
+			return &proto.EncounterPresetResult{ErrorResult: "Dual boss presets need boss health"}
+		}
+		numTargets := max(request.NumTargets, 2)
+
+		encounter.UseHealth = true
+		encounter.Council = &proto.CouncilSettings{SharedHealth: true}
+		for range numTargets {
+			encounter.Targets = append(encounter.Targets, presetTarget(baseTarget, request.BossHealth))
+		}
+
+	case proto.EncounterPresetType_EncounterPresetCouncil:
+		numTargets := TernaryInt32(request.NumTargets == 0, 3, request.NumTargets)
+
+		// Each boss takes over execute phases when the previous one dies, and
+		// goes through them as if its health went down evenly until it dies.
+		for targetIdx := range numTargets {
+			target := presetTarget(baseTarget, request.BossHealth)
+			bossesLeft := float64(numTargets - targetIdx)
+			target.ExecuteProportions = &proto.ExecuteProportions{
+				ExecuteProportion_20: 1 - 0.80/bossesLeft,
+				ExecuteProportion_25: 1 - 0.75/bossesLeft,
+				ExecuteProportion_35: 1 - 0.65/bossesLeft,
+				ExecuteProportion_45: 1 - 0.55/bossesLeft,
+				ExecuteProportion_90: 1 - 0.10/bossesLeft,
+			}
+			encounter.Targets = append(encounter.Targets, target)
+
+			if targetIdx < numTargets-1 {
+				encounter.ScriptEvents = append(encounter.ScriptEvents, &proto.EncounterScriptEvent{
+					TargetIndex: targetIdx,
+					Despawn:     true,
+					Time:        duration * float64(targetIdx+1) / float64(numTargets),
+				})
+			}
+		}
+
+	case proto.EncounterPresetType_EncounterPresetBurnPhase:
+		if request.NumTargets > 1 {
+			return &proto.EncounterPresetResult{ErrorResult: "Burn phase presets have a single boss"}
+		}
+
+		// Down to 45% over the first 70% of the fight, then burned down over the rest.
+		target := presetTarget(baseTarget, request.BossHealth)
+		target.ExecuteProportions = &proto.ExecuteProportions{
+			ExecuteProportion_20: 0.15,
+			ExecuteProportion_25: 0.20,
+			ExecuteProportion_35: 0.25,
+			ExecuteProportion_45: 0.30,
+			ExecuteProportion_90: 0.85,
+		}
+		encounter.Targets = append(encounter.Targets, target)
+
+	default:
+		return &proto.EncounterPresetResult{ErrorResult: fmt.Sprintf("Unknown encounter preset %s", request.Preset)}
+	}
+
+	return &proto.EncounterPresetResult{Encounter: encounter}
+}
+
+func presetTarget(baseTarget *proto.Target, health float64) *proto.Target {
+	target := googleProto.Clone(baseTarget).(*proto.Target)
+	target.DisabledAtStart = false
+	if health > 0 {
+		targetStats := stats.FromProtoArray(target.Stats)
+		targetStats[stats.Health] = health
+		target.Stats = targetStats.ToProtoArray()
+	}
+	return target
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestBuildEncounterPresetErrors(t *testing.T) {
+	requests := map[string]*proto.EncounterPresetRequest{
+		"unknown preset":        {},
+		"negative duration":     {Preset: proto.EncounterPresetType_EncounterPresetCouncil, Duration: -1},
+		"dual boss, no health":  {Preset: proto.EncounterPresetType_EncounterPresetDualBoss},
+		"burn phase, 2 targets": {Preset: proto.EncounterPresetType_EncounterPresetBurnPhase, NumTargets: 2},
+	}
+
+	for name, request := range requests {
+		if result := BuildEncounterPreset(request); result.ErrorResult == "" || result.Encounter != nil {
+			t.Fatalf("%s: expected an error, got %v", name, result)
+		}
+	}
+}
+
+func TestBuildEncounterPresetDualBoss(t *testing.T) {
+	result := BuildEncounterPreset(&proto.EncounterPresetRequest{
+		Preset:     proto.EncounterPresetType_EncounterPresetDualBoss,
+		BossHealth: 1000,
+	})
+	if result.ErrorResult != "" {
+		t.Fatalf("Unexpected error: %s", result.ErrorResult)
+	}
+
+	encounter := NewEncounter(result.Encounter)
+	if len(encounter.ActiveTargets) != 2 || encounter.council == nil || !encounter.council.sharedHealth {
+		t.Fatalf("Expected 2 bosses sharing their health")
+	}
+	if encounter.executeHealth() != 1000 {
+		t.Fatalf("Expected an execute health of 1000, got %0.0f", encounter.executeHealth())
+	}
+}
+
+func TestCouncilPresetExecutePhases(t *testing.T) {
+	result := BuildEncounterPreset(&proto.EncounterPresetRequest{
+		Preset:   proto.EncounterPresetType_EncounterPresetCouncil,
+		Duration: 300,
+	})
+	if result.ErrorResult != "" {
+		t.Fatalf("Unexpected error: %s", result.ErrorResult)
+	}
+	if len(result.Encounter.ScriptEvents) != 2 {
+		t.Fatalf("Expected 2 bosses to die before the end, got %d events", len(result.Encounter.ScriptEvents))
+	}
+
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: result.Encounter,
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	// The first boss leaves 90% a tenth of its 100s life in.
+	if sim.executePhase != 100 || !WithinToleranceFloat64(10, sim.nextExecuteDuration.Seconds(), 0.001) {
+		t.Fatalf("Expected to leave 90%% at 10s, got phase %d until %s", sim.executePhase, sim.nextExecuteDuration)
+	}
+
+	// The second boss takes over at 100s, and leaves 90% a tenth of its life later.
+	first := sim.Encounter.AllTargets[0]
+	sim.CurrentTime = time.Second * 100
+	sim.Encounter.onTargetDespawned(sim, first)
+	if sim.Encounter.executeFocus != sim.Encounter.AllTargets[1] {
+		t.Fatalf("Expected the second boss to become the execute focus")
+	}
+	if sim.executePhase != 100 || !WithinToleranceFloat64(110, sim.nextExecuteDuration.Seconds(), 0.001) {
+		t.Fatalf("Expected to leave 90%% at 110s, got phase %d until %s", sim.executePhase, sim.nextExecuteDuration)
+	}
+	if health := sim.BossHealthPercent(); health != 1 {
+		t.Fatalf("Expected the second boss to start at full health, got %0.3f", health)
+	}
+
+	// Halfway through its life, i.e. at 150s, the second boss is at 50%.
+	sim.CurrentTime = time.Second * 150
+	if health := sim.BossHealthPercent(); !WithinToleranceFloat64(0.5, health, 0.0001) {
+		t.Fatalf("Expected the second boss to be at 50%% at 150s, got %0.4f", health)
+	}
+
+	sim.Cleanup()
+	sim.Reset()
+	if sim.Encounter.executeFocus != first || sim.executeStart != 0 {
+		t.Fatalf("Expected execute phases to follow the first boss again after reset")
+	}
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"time"
+)
+
+// A window scripted by an encounter in which a target is immune to, or reflects,
+// spells of certain schools, e.g. a boss shield which forces dot specs to hold
+// their dots or switch schools.
+type TargetSchoolImmunityConfig struct {
+	Label    string
+	ActionID ActionID
+	Target   *Unit
+
+	// Spells whose schools are all among these deal no damage to the target
+	// while the window is active. Multi-school spells go through the others.
+	Schools SpellSchool
+
+	// When each window starts, relative to the start of the encounter.
+	StartTimes []time.Duration
+	Duration   time.Duration
+
+	// If set, the damage is dealt to the caster instead.
+	Reflect bool
+}
+
+type targetSchoolImmunity struct {
+	TargetSchoolImmunityConfig
+	aura *Aura
+
+	// Deals the reflected damage, so it's in the target's metrics.
+	reflectSpell *Spell
+}
+
+// Registers windows in which the target is immune to, or reflects, the given
+// schools. Meant to be called by TargetAIs during initialization.
+func (env *Environment) RegisterTargetSchoolImmunity(config TargetSchoolImmunityConfig) *Aura {
+	immunity := &targetSchoolImmunity{TargetSchoolImmunityConfig: config}
+	env.Encounter.schoolImmunities = append(env.Encounter.schoolImmunities, immunity)
+
+	immunity.aura = config.Target.RegisterAura(Aura{
+		Label:    config.Label,
+		ActionID: config.ActionID,
+		Duration: config.Duration,
+
+		OnReset: func(aura *Aura, sim *Simulation) {
+			for _, startTime := range config.StartTimes {
+				pa := sim.GetConsumedPendingActionFromPool()
+				pa.NextActionAt = startTime
+				pa.Priority = ActionPriorityDOT
+
+				pa.OnAction = func(sim *Simulation) {
+					aura.Activate(sim)
+				}
+
+				sim.AddPendingAction(pa)
+			}
+		},
+	})
+
+	if config.Reflect {
+		immunity.reflectSpell = config.Target.RegisterSpell(SpellConfig{
+			ActionID:         config.ActionID,
+			SpellSchool:      config.Schools,
+			ProcMask:         ProcMaskEmpty,
+			Flags:            SpellFlagIgnoreModifiers | SpellFlagIgnoreArmor | SpellFlagNoOnCastComplete,
+			DamageMultiplier: 1,
+		})
+	}
+	return immunity.aura
+}
+
+// Returns the active immunity window of the target which matches the spell, if any.
+func (encounter *Encounter) activeSchoolImmunity(spell *Spell, target *Unit) *targetSchoolImmunity {
+	for _, immunity := range encounter.schoolImmunities {
+		if immunity.Target == target && immunity.aura.IsActive() && immunity.Schools.ContainsAll(spell.SpellSchool) {
+			return immunity
+		}
+	}
+	return nil
+}
+
+// Returns whether the target is currently immune to the spell, either to its
+// school or to its mechanic.
+func (encounter *Encounter) IsImmuneToSpell(spell *Spell, target *Unit) bool {
+	return target.IsImmuneTo(spell) || encounter.activeSchoolImmunity(spell, target) != nil
+}
+
+// Removes the damage of a result against an immune target, and returns the
+// amount lost. The result no longer counts as landed, so on-hit effects don't
+// trigger.
+func (immunity *targetSchoolImmunity) absorb(sim *Simulation, spell *Spell, result *SpellResult) float64 {
+	damage := result.Damage
+	result.Damage = 0
+	result.Threat = 0
+	result.Outcome = OutcomeImmune
+
+	if sim.Log != nil {
+		spell.Unit.Log(sim, "%s %s lost %0.3f damage to %s.", result.Target.LogLabel(), spell.ActionID, damage, immunity.Label)
+	}
+
+	if immunity.reflectSpell != nil {
+		immunity.reflectSpell.CalcAndDealDamage(sim, spell.Unit, damage, immunity.reflectSpell.OutcomeAlwaysHit)
+	}
+	return damage
+}
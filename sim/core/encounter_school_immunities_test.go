@@ -0,0 +1,184 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestSchoolImmunityMatchesActiveSchools(t *testing.T) {
+	sim := &Simulation{}
+	env := &Environment{}
+
+	target := &Unit{Type: EnemyUnit, Env: env, auraTracker: newAuraTracker()}
+	otherTarget := &Unit{Type: EnemyUnit, Env: env, auraTracker: newAuraTracker()}
+
+	aura := env.RegisterTargetSchoolImmunity(TargetSchoolImmunityConfig{
+		Label:    "Shadow Shield",
+		ActionID: ActionID{SpellID: 100},
+		Target:   target,
+		Schools:  SpellSchoolShadow | SpellSchoolFire,
+		Duration: time.Second * 10,
+	})
+
+	shadowSpell := &Spell{SpellSchool: SpellSchoolShadow}
+	frostSpell := &Spell{SpellSchool: SpellSchoolFrost}
+
+	if env.Encounter.IsImmuneToSpell(shadowSpell, target) {
+		t.Fatalf("Expected no immunity before the window starts")
+	}
+
+	aura.Activate(sim)
+	if !env.Encounter.IsImmuneToSpell(shadowSpell, target) {
+		t.Fatalf("Expected immunity to shadow during the window")
+	}
+	if env.Encounter.IsImmuneToSpell(frostSpell, target) {
+		t.Fatalf("Expected no immunity to frost during the window")
+	}
+	if env.Encounter.IsImmuneToSpell(shadowSpell, otherTarget) {
+		t.Fatalf("Expected no immunity on other targets")
+	}
+	if !env.Encounter.IsImmuneToSpell(&Spell{SpellSchool: SpellSchoolShadowFlame}, target) {
+		t.Fatalf("Expected immunity to shadowflame when both schools are blocked")
+	}
+	if env.Encounter.IsImmuneToSpell(&Spell{SpellSchool: SpellSchoolShadowFrost}, target) {
+		t.Fatalf("Expected shadowfrost to go through the frost school")
+	}
+}
+
+func TestMechanicImmunity(t *testing.T) {
+	env := &Environment{}
+	target := NewTarget(&proto.Target{BleedImmune: true}, 0)
+
+	bleed := &Spell{SpellSchool: SpellSchoolPhysical, Flags: SpellFlagBleed}
+	poison := &Spell{SpellSchool: SpellSchoolNature, Flags: SpellFlagPoison}
+
+	if !env.Encounter.IsImmuneToSpell(bleed, &target.Unit) {
+		t.Fatalf("Expected a bleed immune target to be immune to bleeds")
+	}
+	if env.Encounter.IsImmuneToSpell(poison, &target.Unit) {
+		t.Fatalf("Expected a bleed immune target not to be immune to poisons")
+	}
+}
+
+func TestDotMechanicImmunityOnlyBlocksTheDot(t *testing.T) {
+	sim, _ := setupResultPipelineSim(nil)
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+	target.MechanicImmunities = SpellFlagBleed
+	fa.Dot.mechanics = SpellFlagBleed
+
+	// The direct hit of a spell whose dot is a bleed still lands.
+	if !fa.Spell.CanCast(sim, target) {
+		t.Fatalf("Expected a spell with a bleed dot to be castable on a bleed immune target")
+	}
+	if result := fa.Spell.CalcAndDealDamage(sim, target, 100, fa.Spell.OutcomeAlwaysHit); result.Damage <= 0 || result.Outcome == OutcomeImmune {
+		t.Fatalf("Expected the direct hit to deal damage, got %0.1f with outcome %s", result.Damage, result.Outcome)
+	}
+	fa.Dot.Apply(sim)
+	if fa.Dot.IsActive() {
+		t.Fatalf("Expected the bleed not to be applied")
+	}
+
+	// Spells which are bleeds as a whole are blocked entirely.
+	fa.Spell.Flags |= SpellFlagBleed
+	if fa.Spell.CanCast(sim, target) {
+		t.Fatalf("Expected a bleed not to be castable on a bleed immune target")
+	}
+}
+
+func TestPermanentSchoolImmunity(t *testing.T) {
+	env := &Environment{}
+	target := NewTarget(&proto.Target{
+		SchoolImmunities: []proto.SpellSchool{proto.SpellSchool_SpellSchoolFire, proto.SpellSchool_SpellSchoolFrost},
+	}, 0)
+
+	if !env.Encounter.IsImmuneToSpell(&Spell{SpellSchool: SpellSchoolFire | SpellSchoolFrost}, &target.Unit) {
+		t.Fatalf("Expected immunity to fire and frost spells when both schools are immune")
+	}
+	if env.Encounter.IsImmuneToSpell(&Spell{SpellSchool: SpellSchoolFirestorm}, &target.Unit) {
+		t.Fatalf("Expected firestorm to go through the nature school")
+	}
+	if target.IsImmuneTo(&Spell{}) {
+		t.Fatalf("Expected spells without a school to go through")
+	}
+}
+
+func TestDebuffImmunity(t *testing.T) {
+	target := NewTarget(&proto.Target{
+		DebuffImmunities: []*proto.ActionID{ActionID{SpellID: 113746}.ToProto()},
+	}, 0)
+
+	immuneDebuff := target.RegisterAura(Aura{Label: "Weakened Armor", ActionID: ActionID{SpellID: 113746}, Duration: NeverExpires})
+	otherDebuff := target.RegisterAura(Aura{Label: "Physical Vulnerability", ActionID: ActionID{SpellID: 81326}, Duration: NeverExpires})
+
+	if !immuneDebuff.disabledByUser || otherDebuff.disabledByUser {
+		t.Fatalf("Expected only the debuff in the immunity list to be blocked")
+	}
+
+	// Blocked auras never activate.
+	immuneDebuff.Activate(nil)
+	if immuneDebuff.IsActive() {
+		t.Fatalf("Expected the debuff not to be applied to an immune target")
+	}
+}
+
+func TestSchoolImmunityReflectsDamageWithoutLanding(t *testing.T) {
+	var window *Aura
+	var spell *Spell
+	procs := 0
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		window = env.RegisterTargetSchoolImmunity(TargetSchoolImmunityConfig{
+			Label:    "Fire Reflection",
+			ActionID: ActionID{SpellID: 100},
+			Target:   env.Encounter.AllTargetUnits[0],
+			Schools:  SpellSchoolFire,
+			Duration: time.Second * 10,
+			Reflect:  true,
+		})
+
+		character := env.Raid.Parties[0].Players[0].GetCharacter()
+		spell = character.RegisterSpell(SpellConfig{
+			ActionID:         ActionID{SpellID: 1},
+			SpellSchool:      SpellSchoolFire,
+			ProcMask:         ProcMaskSpellDamage,
+			Flags:            SpellFlagIgnoreModifiers,
+			DamageMultiplier: 1,
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, 1000, spell.OutcomeAlwaysHit)
+			},
+		})
+		MakeProcTriggerAura(&character.Unit, ProcTrigger{
+			Name:     "On Hit",
+			Callback: CallbackOnSpellHitDealt,
+			ProcMask: ProcMaskSpellDamage,
+			Outcome:  OutcomeLanded,
+			Handler: func(_ *Simulation, _ *Spell, _ *SpellResult) {
+				procs++
+			},
+		})
+	})
+	player := sim.Raid.Parties[0].Players[0].GetCharacter()
+	target := sim.Encounter.AllTargetUnits[0]
+
+	window.Activate(sim)
+	spell.SkipCastAndApplyEffects(sim, target)
+
+	if procs != 0 {
+		t.Fatalf("Expected hits on an immune target not to trigger on-hit effects")
+	}
+	if lost := spell.SpellMetrics[target.UnitIndex].TotalImmuneDamage; lost != 1000 {
+		t.Fatalf("Expected 1000 damage lost to immunity, got %0.1f", lost)
+	}
+	reflectSpell := sim.Encounter.schoolImmunities[0].reflectSpell
+	if reflected := reflectSpell.SpellMetrics[player.UnitIndex].TotalDamage; reflected != 1000 {
+		t.Fatalf("Expected 1000 reflected damage in the target's metrics, got %0.1f", reflected)
+	}
+
+	window.Deactivate(sim)
+	spell.SkipCastAndApplyEffects(sim, target)
+	if procs != 1 {
+		t.Fatalf("Expected hits after the window to trigger on-hit effects")
+	}
+}
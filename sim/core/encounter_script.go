@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Adds which spawn and despawn during the fight, at fixed times or once a
+// target drops below a health threshold, see proto.EncounterScriptEvent.
+type encounterScript struct {
+	events []*encounterScriptEvent
+
+	// Whether each target is enabled at the start of an iteration, restored on reset.
+	enabledAtStart []bool
+
+	// Raid units which had to switch targets because an add despawned, restored on reset.
+	retargets []raidRetarget
+}
+
+type encounterScriptEvent struct {
+	target  *Target
+	despawn bool
+
+	time            float64
+	healthThreshold float64
+	triggerTarget   *Target
+
+	fired bool
+}
+
+func newEncounterScript(events []*proto.EncounterScriptEvent, targets []*Target) *encounterScript {
+	script := &encounterScript{
+		enabledAtStart: make([]bool, len(targets)),
+	}
+
+	for idx, target := range targets {
+		script.enabledAtStart[idx] = target.IsEnabled()
+	}
+
+	for _, eventOptions := range events {
+		if eventOptions.TargetIndex < 0 || int(eventOptions.TargetIndex) >= len(targets) {
+			panic(fmt.Sprintf("Invalid target index %d in encounter script", eventOptions.TargetIndex))
+		}
+
+		event := &encounterScriptEvent{
+			target:          targets[eventOptions.TargetIndex],
+			despawn:         eventOptions.Despawn,
+			time:            max(eventOptions.Time, 0),
+			healthThreshold: eventOptions.HealthThreshold,
+		}
+
+		if event.healthThreshold > 0 {
+			if eventOptions.TriggerTargetIndex < 0 || int(eventOptions.TriggerTargetIndex) >= len(targets) {
+				panic(fmt.Sprintf("Invalid trigger target index %d in encounter script", eventOptions.TriggerTargetIndex))
+			}
+			event.triggerTarget = targets[eventOptions.TriggerTargetIndex]
+		}
+
+		script.events = append(script.events, event)
+	}
+
+	return script
+}
+
+// Must be called before the targets are reset, so they pick up restored raid targets.
+func (script *encounterScript) reset(sim *Simulation, encounter *Encounter) {
+	for idx := len(script.retargets) - 1; idx >= 0; idx-- {
+		script.retargets[idx].unit.CurrentTarget = script.retargets[idx].previous
+	}
+	script.retargets = script.retargets[:0]
+
+	// Enable targets first, so there is always an active target left to disable the others.
+	for idx, target := range encounter.AllTargets {
+		if script.enabledAtStart[idx] && !target.IsEnabled() {
+			target.enabled = true
+			encounter.addActiveTarget(target)
+		}
+	}
+	for idx, target := range encounter.AllTargets {
+		if !script.enabledAtStart[idx] && target.IsEnabled() {
+			target.enabled = false
+			encounter.removeInactiveTarget(target)
+		}
+	}
+
+	for _, event := range script.events {
+		event.fired = false
+
+		if event.triggerTarget != nil {
+			continue
+		}
+
+		pa := sim.GetConsumedPendingActionFromPool()
+		pa.NextActionAt = DurationFromSeconds(event.time)
+		pa.Priority = ActionPriorityDOT
+
+		pa.OnAction = func(sim *Simulation) {
+			script.fire(sim, event)
+		}
+
+		sim.AddPendingAction(pa)
+	}
+}
+
+func (script *encounterScript) onDamageTaken(sim *Simulation, target *Unit) {
+	for _, event := range script.events {
+		if event.fired || event.triggerTarget == nil || &event.triggerTarget.Unit != target {
+			continue
+		}
+		if sim.Encounter.TargetHealthPercent(target) < event.healthThreshold {
+			script.fire(sim, event)
+		}
+	}
+}
+
+func (script *encounterScript) fire(sim *Simulation, event *encounterScriptEvent) {
+	event.fired = true
+
+	if !event.despawn {
+		if !event.target.IsEnabled() {
+			if sim.LogEnabled(LogCategoryOther, LogLevelInfo) {
+				event.target.LogAt(sim, LogCategoryOther, LogLevelInfo, "Spawned")
+			}
+			event.target.Enable(sim)
+		}
+		return
+	}
+
+	// The encounter always needs an active target, so the last one stays.
+	if !event.target.IsEnabled() || len(sim.Encounter.ActiveTargets) == 1 {
+		return
+	}
+
+	if sim.LogEnabled(LogCategoryOther, LogLevelInfo) {
+		event.target.LogAt(sim, LogCategoryOther, LogLevelInfo, "Despawned")
+	}
+
+	// Disable already retargets the despawned target's own target, so snapshot beforehand.
+	raidUnits := sim.Raid.AllUnits
+	previousTargets := make([]*Unit, len(raidUnits))
+	for idx, unit := range raidUnits {
+		previousTargets[idx] = unit.CurrentTarget
+	}
+
+	event.target.Disable(sim, true)
+	sim.Encounter.onTargetDespawned(sim, event.target)
+
+	for idx, unit := range raidUnits {
+		if previousTargets[idx] == &event.target.Unit {
+			unit.CurrentTarget = &event.target.NextActiveTarget().Unit
+		}
+		if unit.CurrentTarget != previousTargets[idx] {
+			script.retargets = append(script.retargets, raidRetarget{
+				unit:     unit,
+				previous: previousTargets[idx],
+			})
+		}
+	}
+}
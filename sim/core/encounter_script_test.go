@@ -0,0 +1,84 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestEncounterScriptRestoresTargetsOnReset(t *testing.T) {
+	encounter := NewEncounter(&proto.Encounter{
+		Targets: []*proto.Target{
+			{Stats: stats.Stats{stats.Health: 1000}.ToProtoArray()},
+			{DisabledAtStart: true},
+		},
+		ScriptEvents: []*proto.EncounterScriptEvent{
+			{TargetIndex: 1, HealthThreshold: 0.5},
+		},
+	})
+
+	event := encounter.script.events[0]
+	if event.triggerTarget != encounter.AllTargets[0] {
+		t.Fatalf("Expected the event to be triggered by the boss")
+	}
+	if len(encounter.ActiveTargets) != 1 {
+		t.Fatalf("Expected the add to start disabled, got %d active targets", len(encounter.ActiveTargets))
+	}
+
+	add := encounter.AllTargets[1]
+	add.enabled = true
+	encounter.addActiveTarget(add)
+	event.fired = true
+
+	encounter.script.reset(&Simulation{}, &encounter)
+	if add.IsEnabled() || len(encounter.ActiveTargets) != 1 {
+		t.Fatalf("Expected the add to be disabled again after reset")
+	}
+	if event.fired {
+		t.Fatalf("Expected the event to fire again in the next iteration")
+	}
+}
+
+func TestEncounterScriptRestoresTankTargetOnReset(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Tank",
+			Class:     proto.Class_ClassShaman,
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "boss", Level: 93},
+				{Name: "add", Level: 93},
+			},
+			ScriptEvents: []*proto.EncounterScriptEvent{
+				{TargetIndex: 1, Despawn: true, Time: 5},
+			},
+			Duration: 180,
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	tank := sim.Raid.Parties[0].Players[0].GetCharacter()
+	add := sim.Encounter.AllTargets[1]
+	tank.CurrentTarget = &add.Unit
+	add.CurrentTarget = &tank.Unit
+
+	// Disabling the add already switches the tank it was attacking.
+	sim.Encounter.script.fire(sim, sim.Encounter.script.events[0])
+	if add.IsEnabled() || tank.CurrentTarget != &sim.Encounter.AllTargets[0].Unit {
+		t.Fatalf("Expected the tank to switch to the boss once the add despawns")
+	}
+
+	sim.Encounter.script.reset(sim, &sim.Encounter)
+	if tank.CurrentTarget != &add.Unit {
+		t.Fatalf("Expected the tank's target to be restored on reset, got %s", tank.CurrentTarget.Label)
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Resolves the scripted parts of an encounter into a concrete timeline for the
+// UI's fight preview, without constructing or running a sim. Health based
+// events are placed assuming the targets lose health evenly over the fight.
+func EncounterTimeline(request *proto.EncounterTimelineRequest) *proto.EncounterTimelineResult {
+	encounter := request.Encounter
+	if encounter == nil {
+		return &proto.EncounterTimelineResult{ErrorResult: "No encounter provided"}
+	}
+
+	duration := request.Duration
+	if duration <= 0 {
+		duration = encounter.Duration
+	}
+	if duration <= 0 {
+		return &proto.EncounterTimelineResult{ErrorResult: "Encounter duration must be positive"}
+	}
+
+	timeline := &encounterTimeline{
+		duration: duration,
+	}
+	if request.RandomSeed != 0 {
+		timeline.rand = NewSplitMix(uint64(request.RandomSeed))
+	}
+
+	timeline.addExecutePhases(encounter)
+	if err := timeline.addScriptEvents(encounter); err != nil {
+		return &proto.EncounterTimelineResult{ErrorResult: err.Error()}
+	}
+	timeline.addMechanics(encounter.Mechanics)
+
+	result := &proto.EncounterTimelineResult{
+		Duration: duration,
+	}
+	if incomingDamage := encounter.IncomingDamage; incomingDamage != nil {
+		result.IncomingDps = max(incomingDamage.Dps, 0)
+		if incomingDamage.SpikeDamage > 0 && incomingDamage.SpikeInterval > 0 {
+			timeline.addRecurring(incomingDamage.SpikeInterval, incomingDamage.SpikeIntervalVariation, func(time float64) *proto.EncounterTimelineEvent {
+				return &proto.EncounterTimelineEvent{
+					Type:  proto.EncounterTimelineEvent_EventDamageSpike,
+					Time:  time,
+					Value: incomingDamage.SpikeDamage,
+				}
+			})
+		}
+	}
+
+	// Stable, so events at the same time keep the order they were added in.
+	slices.SortStableFunc(timeline.events, func(a, b *proto.EncounterTimelineEvent) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+	result.Events = timeline.events
+	return result
+}
+
+type encounterTimeline struct {
+	duration float64
+	rand     Rand // Nil if intervals are resolved without variation.
+	events   []*proto.EncounterTimelineEvent
+}
+
+func (timeline *encounterTimeline) add(event *proto.EncounterTimelineEvent) {
+	if event.Time >= 0 && event.Time < timeline.duration {
+		timeline.events = append(timeline.events, event)
+	}
+}
+
+// Time at which targets drop below the given fraction of their health.
+func (timeline *encounterTimeline) healthTime(health float64) float64 {
+	return (1 - health) * timeline.duration
+}
+
+// Mirrors Simulation.nextExecutePhase, up to the first despawn of the execute focus.
+func (timeline *encounterTimeline) addExecutePhases(encounter *proto.Encounter) {
+	proportions := &proto.ExecuteProportions{
+		ExecuteProportion_20: encounter.ExecuteProportion_20,
+		ExecuteProportion_25: encounter.ExecuteProportion_25,
+		ExecuteProportion_35: encounter.ExecuteProportion_35,
+		ExecuteProportion_45: encounter.ExecuteProportion_45,
+		ExecuteProportion_90: encounter.ExecuteProportion_90,
+	}
+	if len(encounter.Targets) > 0 && encounter.Targets[0].ExecuteProportions != nil {
+		proportions = encounter.Targets[0].ExecuteProportions
+	}
+
+	phases := []struct {
+		percent    float64
+		proportion float64
+	}{
+		{90, proportions.ExecuteProportion_90},
+		{45, proportions.ExecuteProportion_45},
+		{35, proportions.ExecuteProportion_35},
+		{25, proportions.ExecuteProportion_25},
+		{20, proportions.ExecuteProportion_20},
+	}
+
+	for _, phase := range phases {
+		event := &proto.EncounterTimelineEvent{
+			Type:  proto.EncounterTimelineEvent_EventExecutePhase,
+			Value: phase.percent,
+		}
+		if encounter.UseHealth {
+			event.Time = timeline.healthTime(phase.percent / 100)
+			event.Estimated = true
+		} else {
+			event.Time = timeline.healthTime(phase.proportion)
+		}
+		timeline.add(event)
+	}
+}
+
+// Validates the events like newEncounterScript, but returns an error instead of panicking.
+func (timeline *encounterTimeline) addScriptEvents(encounter *proto.Encounter) error {
+	numTargets := int32(len(encounter.Targets))
+
+	for _, scriptEvent := range encounter.ScriptEvents {
+		if scriptEvent.TargetIndex < 0 || scriptEvent.TargetIndex >= numTargets {
+			return fmt.Errorf("Invalid target index %d in encounter script", scriptEvent.TargetIndex)
+		}
+
+		event := &proto.EncounterTimelineEvent{
+			Type:        proto.EncounterTimelineEvent_EventTargetSpawn,
+			TargetIndex: scriptEvent.TargetIndex,
+			Time:        max(scriptEvent.Time, 0),
+		}
+		if scriptEvent.Despawn {
+			event.Type = proto.EncounterTimelineEvent_EventTargetDespawn
+		}
+
+		if scriptEvent.HealthThreshold > 0 {
+			if scriptEvent.TriggerTargetIndex < 0 || scriptEvent.TriggerTargetIndex >= numTargets {
+				return fmt.Errorf("Invalid trigger target index %d in encounter script", scriptEvent.TriggerTargetIndex)
+			}
+			event.Time = timeline.healthTime(scriptEvent.HealthThreshold)
+			event.Estimated = true
+		}
+
+		timeline.add(event)
+	}
+
+	return nil
+}
+
+// Mirrors registerEncounterMechanics.
+func (timeline *encounterTimeline) addMechanics(mechanics []*proto.EncounterMechanic) {
+	for _, mechanic := range mechanics {
+		if mechanic.Interval <= 0 || mechanic.Duration <= 0 {
+			continue
+		}
+
+		eventType := proto.EncounterTimelineEvent_EventMovement
+		if mechanic.Type == proto.EncounterMechanic_MechanicUntargetable {
+			eventType = proto.EncounterTimelineEvent_EventUntargetable
+		}
+
+		timeline.addRecurring(mechanic.Interval, mechanic.IntervalVariation, func(time float64) *proto.EncounterTimelineEvent {
+			return &proto.EncounterTimelineEvent{
+				Type:     eventType,
+				Time:     time,
+				Duration: mechanic.Duration,
+			}
+		})
+	}
+}
+
+// Adds an event every interval seconds, starting one interval into the fight,
+// with the same variation as the sim's scheduling.
+func (timeline *encounterTimeline) addRecurring(interval float64, variation float64, makeEvent func(time float64) *proto.EncounterTimelineEvent) {
+	variation = min(max(variation, 0), interval)
+
+	for time := 0.0; ; {
+		time += interval
+		if variation > 0 && timeline.rand != nil {
+			time += variation * (2*timeline.rand.NextFloat64() - 1)
+		}
+		if time >= timeline.duration {
+			return
+		}
+		timeline.add(makeEvent(time))
+	}
+}
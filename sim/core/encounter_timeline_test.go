@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestEncounterTimeline(t *testing.T) {
+	result := EncounterTimeline(&proto.EncounterTimelineRequest{
+		Encounter: &proto.Encounter{
+			Duration:             100,
+			ExecuteProportion_20: 0.2,
+			ExecuteProportion_35: 0.3,
+			Targets:              []*proto.Target{{}, {DisabledAtStart: true}},
+			ScriptEvents: []*proto.EncounterScriptEvent{
+				{TargetIndex: 1, Time: 30},
+				{TargetIndex: 1, Despawn: true, HealthThreshold: 0.5, TriggerTargetIndex: 0},
+			},
+			Mechanics: []*proto.EncounterMechanic{
+				{Type: proto.EncounterMechanic_MechanicMovement, Interval: 40, Duration: 5},
+			},
+			IncomingDamage: &proto.IncomingDamageSchedule{Dps: 1000, SpikeDamage: 5000, SpikeInterval: 45},
+		},
+	})
+	if result.ErrorResult != "" {
+		t.Fatalf("Unexpected error: %s", result.ErrorResult)
+	}
+
+	type expectedEvent struct {
+		eventType proto.EncounterTimelineEvent_EventType
+		time      float64
+		estimated bool
+	}
+	expected := []expectedEvent{
+		{proto.EncounterTimelineEvent_EventTargetSpawn, 30, false},
+		{proto.EncounterTimelineEvent_EventMovement, 40, false},
+		{proto.EncounterTimelineEvent_EventDamageSpike, 45, false},
+		{proto.EncounterTimelineEvent_EventTargetDespawn, 50, true},
+		{proto.EncounterTimelineEvent_EventExecutePhase, 70, false},
+		{proto.EncounterTimelineEvent_EventExecutePhase, 80, false},
+		{proto.EncounterTimelineEvent_EventMovement, 80, false},
+		{proto.EncounterTimelineEvent_EventDamageSpike, 90, false},
+	}
+	if len(result.Events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(result.Events), result.Events)
+	}
+	for i, event := range result.Events {
+		if event.Type != expected[i].eventType || !WithinToleranceFloat64(event.Time, expected[i].time, 1e-9) || event.Estimated != expected[i].estimated {
+			t.Fatalf("Unexpected event %d: %v", i, event)
+		}
+	}
+	if result.IncomingDps != 1000 {
+		t.Fatalf("Expected 1000 incoming dps, got %0.0f", result.IncomingDps)
+	}
+}
+
+func TestEncounterTimelineInvalidScript(t *testing.T) {
+	result := EncounterTimeline(&proto.EncounterTimelineRequest{
+		Encounter: &proto.Encounter{
+			Duration:     100,
+			Targets:      []*proto.Target{{}},
+			ScriptEvents: []*proto.EncounterScriptEvent{{TargetIndex: 3, Time: 10}},
+		},
+	})
+	if result.ErrorResult == "" {
+		t.Fatalf("Expected an error for an invalid target index")
+	}
+}
@@ -28,6 +28,7 @@ type energyBar struct {
 	regenMetrics          *ResourceMetrics
 	EncounterStartMetrics *ResourceMetrics
 	EnergyRefundMetrics   *ResourceMetrics
+	capMetrics            *ResourceCapMetrics
 
 	ownerClass              proto.Class
 	comboPointsResourceName string // "chi" or "combo points"
@@ -56,6 +57,7 @@ func (unit *Unit) EnableEnergyBar(options EnergyBarOptions) {
 		regenMetrics:            unit.NewEnergyMetrics(ActionID{OtherID: proto.OtherAction_OtherActionEnergyRegen}),
 		EncounterStartMetrics:   unit.getEncounterStartComboMetrics(options.UnitClass),
 		EnergyRefundMetrics:     unit.NewEnergyMetrics(ActionID{OtherID: proto.OtherAction_OtherActionRefund}),
+		capMetrics:              unit.Metrics.NewResourceCapMetrics(proto.ResourceType_ResourceTypeEnergy),
 		ownerClass:              options.UnitClass,
 		comboPointsResourceName: Ternary(options.UnitClass == proto.Class_ClassMonk, "chi", "combo points"),
 		hasNoRegen:              options.HasNoRegen,
@@ -116,9 +118,10 @@ func (eb *energyBar) AddEnergy(sim *Simulation, amount float64, metrics *Resourc
 
 	newEnergy := min(eb.currentEnergy+amount, eb.maxEnergy)
 	metrics.AddEvent(amount, newEnergy-eb.currentEnergy)
+	eb.capMetrics.Update(sim, newEnergy >= eb.maxEnergy, amount-(newEnergy-eb.currentEnergy))
 
-	if sim.Log != nil {
-		eb.unit.Log(sim, "Gained %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		eb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
 	}
 
 	eb.currentEnergy = newEnergy
@@ -131,9 +134,10 @@ func (eb *energyBar) SpendEnergy(sim *Simulation, amount float64, metrics *Resou
 
 	newEnergy := eb.currentEnergy - amount
 	metrics.AddEvent(-amount, -amount)
+	eb.capMetrics.Update(sim, newEnergy >= eb.maxEnergy, 0)
 
-	if sim.Log != nil {
-		eb.unit.Log(sim, "Spent %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		eb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
 	}
 
 	eb.currentEnergy = newEnergy
@@ -185,7 +189,7 @@ func (eb *energyBar) processDynamicHasteRatingChange(sim *Simulation) {
 	}
 
 	eb.ResetEnergyTick(sim)
-	eb.hasteRatingMultiplier = 1.0 + eb.unit.GetStat(stats.HasteRating)/(100*HasteRatingPerHastePercent)
+	eb.hasteRatingMultiplier = Ratings.HasteMultiplier(eb.unit.GetStat(stats.HasteRating))
 }
 
 // Used for dynamic updates to maximum Energy, such as from the Druid Primal Madness talent
@@ -215,8 +219,8 @@ func (eb *energyBar) AddComboPoints(sim *Simulation, pointsToAdd int32, metrics
 	newComboPoints := min(eb.comboPoints+pointsToAdd, eb.maxComboPoints)
 	metrics.AddEvent(float64(pointsToAdd), float64(newComboPoints-eb.comboPoints))
 
-	if sim.Log != nil {
-		eb.unit.Log(sim, "Gained %d %s from %s (%d --> %d) of %0.0f total.", pointsToAdd, eb.comboPointsResourceName, metrics.ActionID, eb.comboPoints, newComboPoints, eb.maxComboPoints)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		eb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %d %s from %s (%d --> %d) of %0.0f total.", pointsToAdd, eb.comboPointsResourceName, metrics.ActionID, eb.comboPoints, newComboPoints, eb.maxComboPoints)
 	}
 
 	eb.comboPoints = newComboPoints
@@ -233,8 +237,8 @@ func (eb *energyBar) SpendComboPoints(sim *Simulation, metrics *ResourceMetrics)
 func (eb *energyBar) spendComboPointsInternal(sim *Simulation, pointsToSpend int32, metrics *ResourceMetrics) {
 	pointsToSpend = min(pointsToSpend, eb.comboPoints)
 	newComboPoints := eb.comboPoints - pointsToSpend
-	if sim.Log != nil {
-		eb.unit.Log(sim, "Spent %d %s from %s (%d --> %d) of %0.0f total.", pointsToSpend, eb.comboPointsResourceName, metrics.ActionID, eb.comboPoints, newComboPoints, eb.maxComboPoints)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		eb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent %d %s from %s (%d --> %d) of %0.0f total.", pointsToSpend, eb.comboPointsResourceName, metrics.ActionID, eb.comboPoints, newComboPoints, eb.maxComboPoints)
 	}
 	metrics.AddEvent(float64(-pointsToSpend), float64(-pointsToSpend))
 	eb.comboPoints = newComboPoints
@@ -267,7 +271,7 @@ func (eb *energyBar) reset(sim *Simulation) {
 	eb.comboPoints = 0
 
 	if eb.hasHasteRatingScaling {
-		eb.hasteRatingMultiplier = 1.0 + eb.unit.GetStat(stats.HasteRating)/(100*HasteRatingPerHastePercent)
+		eb.hasteRatingMultiplier = Ratings.HasteMultiplier(eb.unit.GetStat(stats.HasteRating))
 	} else {
 		eb.hasteRatingMultiplier = 1
 	}
@@ -280,6 +284,8 @@ func (eb *energyBar) reset(sim *Simulation) {
 }
 
 func (eb *energyBar) enable(sim *Simulation, startAt time.Duration) {
+	eb.capMetrics.Update(sim, eb.currentEnergy >= eb.maxEnergy, 0)
+
 	if eb.hasNoRegen {
 		return
 	}
@@ -290,6 +296,9 @@ func (eb *energyBar) enable(sim *Simulation, startAt time.Duration) {
 }
 
 func (eb *energyBar) disable(sim *Simulation) {
+	if eb.unit != nil {
+		eb.capMetrics.Update(sim, false, 0)
+	}
 	eb.nextEnergyTick = NeverExpires
 	sim.RemoveTask(eb)
 }
@@ -51,11 +51,34 @@ type Environment struct {
 
 	// Whether the current environment is simulating a Challenge Mode fight
 	IsChallengeMode bool
+
+	// Alternate spell tuning, applied when spells are registered.
+	spellDataset SpellDataset
+
+	// Handlers subscribed to events of any raid member.
+	raidEvents raidEventHandlers
+
+	// APL variables shared by the rotations of all units, by name.
+	aplEncounterVariables map[string]*aplVariable
+
+	// Execute phase trackers registered by any unit, updated as the sim advances.
+	executePhaseTrackers []*ExecutePhaseTracker
 }
 
 func NewEnvironment(raidProto *proto.Raid, encounterProto *proto.Encounter, runFakePrepull bool) (*Environment, *proto.RaidStats, *proto.EncounterStats) {
+	var spellDataset SpellDataset
+	if raidProto.SpellDataset != nil {
+		spellDataset = NewSpellDatasetFromProto(raidProto.SpellDataset)
+	}
+	return NewEnvironmentWithSpellDataset(raidProto, encounterProto, runFakePrepull, spellDataset)
+}
+
+// Like NewEnvironment, but spells are tuned with the given dataset instead of
+// the one in the request. The dataset may be nil to use built-in values only.
+func NewEnvironmentWithSpellDataset(raidProto *proto.Raid, encounterProto *proto.Encounter, runFakePrepull bool, spellDataset SpellDataset) (*Environment, *proto.RaidStats, *proto.EncounterStats) {
 	env := &Environment{
-		State: Created,
+		State:        Created,
+		spellDataset: spellDataset,
 	}
 
 	env.construct(raidProto, encounterProto)
@@ -135,6 +158,10 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 		}
 	}
 
+	env.registerEncounterMechanics(encounterProto.Mechanics)
+	env.registerIncomingDamageSchedule(encounterProto.IncomingDamage)
+	env.registerEncounterDamageModifiers(encounterProto.DamageModifiers)
+
 	for _, party := range env.Raid.Parties {
 		for _, playerOrPet := range party.PlayersAndPets {
 			playerOrPet.GetCharacter().initialize(playerOrPet)
@@ -178,6 +205,7 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 		}
 	}
 
+	focusUnit := env.GetUnit(raidProto.FocusPlayer, nil)
 	for partyIdx, party := range env.Raid.Parties {
 		partyProto := raidProto.Parties[partyIdx]
 		for playerIdx, player := range party.Players {
@@ -188,6 +216,10 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 			playerProto := partyProto.Players[playerIdx]
 			char := player.GetCharacter()
 			char.Rotation = char.newAPLRotation(playerProto.Rotation)
+
+			if focusUnit != nil && &char.Unit != focusUnit {
+				char.disableActions()
+			}
 		}
 	}
 
@@ -203,6 +235,7 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 	})
 
 	env.setupAttackTables()
+	env.setupThreatTables()
 
 	env.State = Finalized
 
@@ -279,6 +312,19 @@ func (env *Environment) reset(sim *Simulation) {
 
 	// Reset primary targets damage taken for tracking health fights.
 	env.Encounter.DamageTaken = 0
+	clear(env.Encounter.targetDamageTaken)
+	if env.Encounter.council != nil {
+		env.Encounter.council.reset(&env.Encounter)
+	}
+	if env.Encounter.script != nil {
+		env.Encounter.script.reset(sim, &env.Encounter)
+	}
+	for _, mechanic := range env.Encounter.mechanics {
+		mechanic.reset(sim)
+	}
+	if env.Encounter.incomingDamage != nil {
+		env.Encounter.incomingDamage.reset(sim)
+	}
 
 	// Targets need to be reset before the raid, so that players can check for
 	// the presence of permanent target auras in their Reset handlers.
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Default maximum number of idle environments kept by an EnvironmentCache.
+const defaultMaxCachedEnvironments = 256
+
+// EnvironmentCache keeps finalized Environments around after a sim has finished
+// with them, so later requests with an identical raid and encounter can skip
+// constructing, initializing and finalizing all units, auras and spells.
+//
+// An Environment is only ever used by one sim at a time. Idle environments have
+// their aggregate metrics cleared before they are handed out again.
+type EnvironmentCache struct {
+	mu   sync.Mutex
+	idle map[string][]*cachedEnvironment
+	size int
+
+	MaxEnvironments int
+
+	// If set, requests which only differ in the players' bonus stats share environments.
+	// The bonus stats are applied to reused environments through the stat dependency
+	// engine instead of rebuilding the characters, which is what stat weights rely on.
+	ShiftBonusStats bool
+}
+
+type cachedEnvironment struct {
+	env *Environment
+
+	// Values which are adjusted while running a sim, captured right after construction.
+	baseDuration       time.Duration
+	durationVariation  time.Duration
+	durationIsEstimate bool
+}
+
+func NewEnvironmentCache() *EnvironmentCache {
+	return &EnvironmentCache{
+		idle:            make(map[string][]*cachedEnvironment),
+		MaxEnvironments: defaultMaxCachedEnvironments,
+	}
+}
+
+// Returns the key of the environments which can be reused for the given request.
+// NewEnvironment builds everything from the raid and encounter alone, so the key
+// is their exact serialized form rather than a hash, which could let two different
+// requests share an environment. Requests which can't be serialized get an empty
+// key and are never cached.
+func (cache *EnvironmentCache) key(rsr *proto.RaidSimRequest) string {
+	raid := rsr.Raid
+	if cache.ShiftBonusStats {
+		raid = googleProto.Clone(raid).(*proto.Raid)
+		for _, party := range raid.Parties {
+			for _, player := range party.Players {
+				if player.BonusStats != nil {
+					player.BonusStats.Stats = nil
+				}
+			}
+		}
+	}
+
+	data, err := googleProto.MarshalOptions{Deterministic: true}.Marshal(&proto.RaidSimRequest{
+		Raid:      raid,
+		Encounter: rsr.Encounter,
+	})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// Returns an environment for the given request, either reused from the cache or
+// newly built, along with a function which returns it to the cache.
+// The release function must only be called if the sim finished without errors.
+func (cache *EnvironmentCache) acquire(rsr *proto.RaidSimRequest) (*Environment, func()) {
+	key := cache.key(rsr)
+
+	cache.mu.Lock()
+	var cached *cachedEnvironment
+	if idle := cache.idle[key]; len(idle) > 0 {
+		cached = idle[len(idle)-1]
+		cache.idle[key] = idle[:len(idle)-1]
+		cache.size--
+	}
+	cache.mu.Unlock()
+
+	if cached != nil {
+		cached.restore()
+	} else {
+		env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+		cached = &cachedEnvironment{
+			env:                env,
+			baseDuration:       env.BaseDuration,
+			durationVariation:  env.DurationVariation,
+			durationIsEstimate: env.Encounter.DurationIsEstimate,
+		}
+	}
+
+	return cached.env, func() {
+		if key != "" {
+			cache.release(key, cached)
+		}
+	}
+}
+
+func (cache *EnvironmentCache) release(key string, cached *cachedEnvironment) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.size >= cache.MaxEnvironments {
+		return
+	}
+	cache.idle[key] = append(cache.idle[key], cached)
+	cache.size++
+}
+
+// Drops all idle environments whose request is not in the given list.
+func (cache *EnvironmentCache) Retain(requests []*proto.RaidSimRequest) {
+	keep := make(map[string]bool, len(requests))
+	for _, rsr := range requests {
+		keep[cache.key(rsr)] = true
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key, idle := range cache.idle {
+		if !keep[key] {
+			cache.size -= len(idle)
+			delete(cache.idle, key)
+		}
+	}
+}
+
+// Puts the environment back into the state it had right after NewEnvironment.
+func (cached *cachedEnvironment) restore() {
+	env := cached.env
+	env.BaseDuration = cached.baseDuration
+	env.DurationVariation = cached.durationVariation
+	env.Encounter.DurationIsEstimate = cached.durationIsEstimate
+
+	env.Raid.clearAggregates()
+	for _, unit := range env.AllUnits {
+		unit.Metrics.clearAggregates()
+		for _, aura := range unit.auras {
+			aura.metrics.clearAggregates()
+		}
+		// Including permanent ones, which would otherwise carry over from the previous sim.
+		if unit.Rotation != nil {
+			for _, variable := range unit.Rotation.variables {
+				variable.reset()
+			}
+		}
+	}
+}
+
+// RunSim runs a raid sim like the package level RunSim, but reuses environments from this cache.
+func (cache *EnvironmentCache) RunSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) *proto.RaidSimResult {
+	return runSimWithCache(rsr, progress, skipPresim, signals, cache)
+}
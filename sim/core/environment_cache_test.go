@@ -0,0 +1,146 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func bonusStatsRequest(bonusStat stats.Stat, amount float64, iterations int32) *proto.RaidSimRequest {
+	bonusStats := &proto.UnitStats{Stats: make([]float64, stats.ProtoStatsLen)}
+	bonusStats.Stats[bonusStat] = amount
+
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{
+			Name:       "Player",
+			BonusStats: bonusStats,
+		}}}}},
+		Encounter:  &proto.Encounter{Duration: 180},
+		SimOptions: &proto.SimOptions{Iterations: iterations},
+	}
+}
+
+func TestEnvironmentCacheKeyIgnoresSimOptions(t *testing.T) {
+	cache := NewEnvironmentCache()
+
+	if cache.key(bonusStatsRequest(stats.HasteRating, 0, 1000)) != cache.key(bonusStatsRequest(stats.HasteRating, 0, 2000)) {
+		t.Fatalf("Requests only differing in sim options should share environments")
+	}
+	if cache.key(bonusStatsRequest(stats.HasteRating, 0, 1000)) == cache.key(bonusStatsRequest(stats.HasteRating, 320, 1000)) {
+		t.Fatalf("Requests with different bonus stats should not share environments")
+	}
+}
+
+func TestEnvironmentCacheKeyWithShiftedBonusStats(t *testing.T) {
+	cache := NewEnvironmentCache()
+	cache.ShiftBonusStats = true
+
+	base := bonusStatsRequest(stats.HasteRating, 0, 1000)
+	if cache.key(base) != cache.key(bonusStatsRequest(stats.CritRating, -320, 1000)) {
+		t.Fatalf("Requests only differing in bonus stats should share environments")
+	}
+	if base.Raid.Parties[0].Players[0].BonusStats.Stats == nil {
+		t.Fatalf("Computing the key must not modify the request")
+	}
+}
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_FireMage{},
+		proto.Spec_SpecFireMage,
+		newMasteryTestAgent,
+		func(player *proto.Player, spec interface{}) {
+			player.Spec = spec.(*proto.Player_FireMage)
+		},
+	)
+}
+
+// Agent with a mastery value computed at Initialize and kept up to date by an
+// OnMasteryStatChanged callback, like the mastery spell mods of most specs.
+type masteryTestAgent struct {
+	FakeAgent
+	masteryValue float64
+}
+
+func newMasteryTestAgent(char *Character, _ *proto.Player) Agent {
+	agent := &masteryTestAgent{FakeAgent: FakeAgent{Character: *char}}
+	agent.Init = func() {
+		agent.masteryValue = agent.GetStat(stats.MasteryRating)
+		agent.AddOnMasteryStatChanged(func(_ *Simulation, _ float64, newMastery float64) {
+			agent.masteryValue = newMastery
+		})
+	}
+	return agent
+}
+
+// Provides a raid buff, like most classes do for their own buffs.
+func (agent *masteryTestAgent) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
+	raidBuffs.ArcaneBrilliance = true
+}
+
+func masteryTestRequest(mastery float64) *proto.RaidSimRequest {
+	rsr := bonusStatsRequest(stats.MasteryRating, mastery, 1)
+	player := rsr.Raid.Parties[0].Players[0]
+	player.Class = proto.Class_ClassMage
+	player.Spec = &proto.Player_FireMage{}
+	player.Equipment = &proto.EquipmentSpec{}
+	rsr.SimOptions.RandomSeed = 1
+	rsr.SimOptions.IsTest = true
+	return rsr
+}
+
+func TestEnvironmentCacheShiftsMasteryDerivedValues(t *testing.T) {
+	masteryValue := func(env *Environment) float64 {
+		return env.Raid.Parties[0].Players[0].(*masteryTestAgent).masteryValue
+	}
+
+	cache := NewEnvironmentCache()
+	cache.ShiftBonusStats = true
+
+	cache.RunSim(masteryTestRequest(0), nil, true, simsignals.CreateSignals())
+	shifted := masteryTestRequest(600)
+	env, release := cache.acquire(shifted)
+	defer release()
+	if cache.size != 0 {
+		t.Fatalf("Expected the baseline environment to be reused")
+	}
+
+	sim := newSimWithEnv(env, shifted.SimOptions, simsignals.CreateSignals())
+	env.Raid.setBonusStats(sim, shifted.Raid)
+
+	fresh := NewSim(masteryTestRequest(600), simsignals.CreateSignals())
+	if expected := masteryValue(fresh.Environment); expected != 600 || masteryValue(env) != expected {
+		t.Fatalf("Expected the shifted environment to have mastery value %0.0f like a new one, got %0.0f", expected, masteryValue(env))
+	}
+
+	// Stat weights shift back and forth between the baseline and each stat.
+	env.Raid.setBonusStats(sim, masteryTestRequest(0).Raid)
+	if masteryValue(env) != 0 {
+		t.Fatalf("Expected the mastery value to shift back to 0, got %0.0f", masteryValue(env))
+	}
+}
+
+func TestEnvironmentCacheMissesOnDifferentEnvironments(t *testing.T) {
+	cache := NewEnvironmentCache()
+	cache.ShiftBonusStats = true
+
+	base := masteryTestRequest(0)
+	cache.RunSim(base, nil, true, simsignals.CreateSignals())
+	cachedEnv := cache.idle[cache.key(base)][0]
+
+	longer := masteryTestRequest(0)
+	longer.Encounter.Duration = 300
+	buffed := masteryTestRequest(0)
+	buffed.Raid.Buffs = &proto.RaidBuffs{BlessingOfKings: true}
+	for _, rsr := range []*proto.RaidSimRequest{longer, buffed} {
+		if env, _ := cache.acquire(rsr); env == cachedEnv.env || cache.size != 1 {
+			t.Fatalf("Expected a new environment for a request with a different raid or encounter")
+		}
+	}
+
+	if env, _ := cache.acquire(masteryTestRequest(0)); env != cachedEnv.env {
+		t.Fatalf("Expected the environment to be reused for an identical request")
+	}
+}
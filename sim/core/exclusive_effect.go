@@ -2,6 +2,8 @@ package core
 
 import (
 	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
 )
 
 // An Exclusive effect is one which may not be active at the same time as other
@@ -19,6 +21,11 @@ type ExclusiveEffect struct {
 
 	Category  *ExclusiveCategory
 	isEnabled bool
+
+	// When this enabled effect was last overshadowed by a stronger one in its
+	// category, if it currently is.
+	overshadowedSince time.Duration
+	overshadowed      bool
 }
 
 func (ee *ExclusiveEffect) IsActive() bool {
@@ -48,6 +55,27 @@ func (ec *ExclusiveCategory) GetActiveAura() *Aura {
 	}
 }
 
+// Returns the value of the effect currently applied in this category, or 0 if
+// there is none.
+func (ec *ExclusiveCategory) StrongestValue() float64 {
+	if ec.activeEffect == nil {
+		return 0
+	}
+	return ec.activeEffect.Priority
+}
+
+// Returns the enabled effects in this category which are not applied, because
+// a stronger effect is.
+func (ec *ExclusiveCategory) Contenders() []*ExclusiveEffect {
+	var contenders []*ExclusiveEffect
+	for _, effect := range ec.effects {
+		if effect.isEnabled && effect != ec.activeEffect {
+			contenders = append(contenders, effect)
+		}
+	}
+	return contenders
+}
+
 func (ec *ExclusiveCategory) GetHighestPrioActiveEffect() *ExclusiveEffect {
 	var effect *ExclusiveEffect
 	for _, curEffect := range ec.effects {
@@ -65,13 +93,37 @@ func (ec *ExclusiveCategory) SetActive(sim *Simulation, newActiveEffect *Exclusi
 
 	if ec.activeEffect != nil {
 		ec.activeEffect.OnExpire(ec.activeEffect, sim)
+		if ec.activeEffect.isEnabled {
+			ec.activeEffect.startOvershadow(sim)
+		}
 	}
 	ec.activeEffect = newActiveEffect
 	if newActiveEffect != nil {
+		newActiveEffect.endOvershadow(sim)
 		newActiveEffect.OnGain(newActiveEffect, sim)
 	}
 }
 
+func (ee *ExclusiveEffect) startOvershadow(sim *Simulation) {
+	if !ee.overshadowed {
+		ee.overshadowed = true
+		ee.overshadowedSince = sim.CurrentTime
+	}
+}
+
+// Adds the time this effect spent overshadowed to the metrics of its aura.
+func (ee *ExclusiveEffect) endOvershadow(sim *Simulation) {
+	if !ee.overshadowed {
+		return
+	}
+	ee.overshadowed = false
+
+	if !ee.Aura.ActionID.IsEmptyAction() {
+		endTime := min(sim.CurrentTime, max(ee.Aura.expires, ee.overshadowedSince))
+		ee.Aura.metrics.Overshadowed += max(0, endTime-max(ee.overshadowedSince, 0))
+	}
+}
+
 type ExclusiveEffectManager struct {
 	categories []*ExclusiveCategory
 }
@@ -138,7 +190,12 @@ func (ee *ExclusiveEffect) Activate(sim *Simulation) bool {
 
 	if ee.Category.activeEffect == nil {
 		ee.Category.SetActive(sim, ee)
-	} else if ee.Priority >= ee.Category.activeEffect.Priority {
+	} else if ee.Priority < ee.Category.activeEffect.Priority {
+		ee.startOvershadow(sim)
+	} else {
+		if replaced := ee.Category.activeEffect.Aura; ee.Priority > ee.Category.activeEffect.Priority && replaced != ee.Aura {
+			replaced.recordDebuffOverwrite(sim, proto.DebuffOverwriteReason_DebuffOverwriteStronger)
+		}
 		if ee.Category.SingleAura && ee.Category.activeEffect != ee {
 			ee.Category.activeEffect.Aura.Deactivate(sim)
 		}
@@ -152,6 +209,7 @@ func (ee *ExclusiveEffect) Deactivate(sim *Simulation) {
 		return
 	}
 	ee.isEnabled = false
+	ee.endOvershadow(sim)
 
 	if ee.Category.activeEffect == ee {
 		ee.Category.SetActive(sim, ee.Category.GetHighestPrioActiveEffect())
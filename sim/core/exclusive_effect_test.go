@@ -54,3 +54,37 @@ func TestSingleAuraExclusiveDurationOverwrite(t *testing.T) {
 		t.Fatalf("longer duration exclusive aura failed to overwrite")
 	}
 }
+
+func TestExclusiveEffectOvershadowed(t *testing.T) {
+	sim := &Simulation{}
+
+	target := Unit{
+		Type:        EnemyUnit,
+		auraTracker: newAuraTracker(),
+	}
+	weak := target.RegisterAura(Aura{Label: "Weak", ActionID: ActionID{SpellID: 1}, Duration: time.Second * 30})
+	weak.NewExclusiveEffect("Test", false, ExclusiveEffect{Priority: 5})
+	strong := target.RegisterAura(Aura{Label: "Strong", ActionID: ActionID{SpellID: 2}, Duration: time.Second * 10})
+	strong.NewExclusiveEffect("Test", false, ExclusiveEffect{Priority: 10})
+	category := weak.ExclusiveEffects[0].Category
+
+	weak.Activate(sim)
+	sim.CurrentTime = time.Second * 2
+	strong.Activate(sim)
+
+	if category.StrongestValue() != 10 || category.GetActiveAura() != strong {
+		t.Fatalf("Expected the strong aura to hold the category with 10, got %0.1f", category.StrongestValue())
+	}
+	if contenders := category.Contenders(); len(contenders) != 1 || contenders[0].Aura != weak {
+		t.Fatalf("Expected the weak aura to be the only contender")
+	}
+
+	sim.CurrentTime = time.Second * 5
+	strong.Deactivate(sim)
+	if weak.metrics.Overshadowed != time.Second*3 {
+		t.Fatalf("Expected the weak aura to be overshadowed for 3s, got %s", weak.metrics.Overshadowed)
+	}
+	if category.StrongestValue() != 5 || len(category.Contenders()) != 0 {
+		t.Fatalf("Expected the weak aura to hold the category again")
+	}
+}
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type ExecutePhaseTrackerConfig struct {
+	Label string
+
+	// Health fraction of the boss below which the phase starts, e.g. 0.35.
+	Threshold float64
+
+	// How far above Threshold the boss health has to rise again before the
+	// phase ends, e.g. 0.02 to end a 35% phase only above 37%. Keeps the phase
+	// from flickering when the health estimate hovers around the threshold.
+	Hysteresis float64
+
+	OnEnter func(sim *Simulation)
+	OnExit  func(sim *Simulation)
+}
+
+// Tracks an execute range of the boss health, like RegisterExecutePhaseCallback
+// but for any threshold, and with a hysteresis band. Unlike execute phase
+// callbacks, the phase also ends if the boss health goes back up, e.g. when a
+// council fight moves on to the next boss.
+type ExecutePhaseTracker struct {
+	ExecutePhaseTrackerConfig
+	unit *Unit
+
+	active    bool
+	enteredAt time.Duration
+
+	// Values for the current iteration.
+	timeInPhase time.Duration
+	entries     int32
+}
+
+// Registers a tracker which is updated whenever the sim advances. Its time in
+// the phase is reported in the unit's metrics, by label.
+func (unit *Unit) RegisterExecutePhaseTracker(config ExecutePhaseTrackerConfig) *ExecutePhaseTracker {
+	if config.Label == "" {
+		panic("Execute phase trackers need a label")
+	}
+	if config.Threshold <= 0 || config.Threshold > 1 {
+		panic("Execute phase tracker threshold must be in (0, 1]")
+	}
+
+	tracker := &ExecutePhaseTracker{
+		ExecutePhaseTrackerConfig: config,
+		unit:                      unit,
+	}
+	unit.Env.executePhaseTrackers = append(unit.Env.executePhaseTrackers, tracker)
+	return tracker
+}
+
+// Returns the unit's tracker for the execute phase below the given percent of
+// boss health, e.g. 20 for Execute, registering it on first use. All abilities
+// of a unit usable in the same range share it, and its metrics.
+func (unit *Unit) ExecutePhase(percent int32) *ExecutePhaseTracker {
+	if tracker, ok := unit.executePhases[percent]; ok {
+		return tracker
+	}
+	if unit.executePhases == nil {
+		unit.executePhases = make(map[int32]*ExecutePhaseTracker)
+	}
+	tracker := unit.RegisterExecutePhaseTracker(ExecutePhaseTrackerConfig{
+		Label:     fmt.Sprintf("Execute %d%%", percent),
+		Threshold: float64(percent) / 100,
+	})
+	unit.executePhases[percent] = tracker
+	return tracker
+}
+
+func (tracker *ExecutePhaseTracker) IsActive() bool {
+	return tracker.active
+}
+
+// Total time spent in the phase this iteration, including the current stay.
+func (tracker *ExecutePhaseTracker) TimeInPhase(sim *Simulation) time.Duration {
+	if tracker.active {
+		return tracker.timeInPhase + sim.CurrentTime - tracker.enteredAt
+	}
+	return tracker.timeInPhase
+}
+
+func (tracker *ExecutePhaseTracker) reset(sim *Simulation) {
+	tracker.active = false
+	tracker.timeInPhase = 0
+	tracker.entries = 0
+	tracker.update(sim)
+}
+
+func (tracker *ExecutePhaseTracker) update(sim *Simulation) {
+	health := sim.BossHealthPercent()
+
+	// The tolerance absorbs rounding in the health estimate of health based
+	// fights, so a tracker at one of the sim's execute thresholds enters along
+	// with its execute phase.
+	if !tracker.active && health <= tracker.Threshold+1e-14 {
+		tracker.active = true
+		tracker.enteredAt = sim.CurrentTime
+		tracker.entries++
+		if tracker.OnEnter != nil {
+			tracker.OnEnter(sim)
+		}
+	} else if tracker.active && health > tracker.Threshold+tracker.Hysteresis {
+		tracker.active = false
+		tracker.timeInPhase += sim.CurrentTime - tracker.enteredAt
+		if tracker.OnExit != nil {
+			tracker.OnExit(sim)
+		}
+	}
+}
+
+func (tracker *ExecutePhaseTracker) doneIteration(sim *Simulation) {
+	metrics := tracker.unit.Metrics.getExecutePhaseMetrics(tracker.Label, tracker.Threshold)
+	metrics.timeSum += tracker.TimeInPhase(sim).Seconds()
+	metrics.entriesSum += tracker.entries
+}
+
+type executePhaseMetrics struct {
+	threshold  float64
+	timeSum    float64
+	entriesSum int32
+}
+
+func (unitMetrics *UnitMetrics) getExecutePhaseMetrics(label string, threshold float64) *executePhaseMetrics {
+	metrics, ok := unitMetrics.executePhases[label]
+	if !ok {
+		metrics = &executePhaseMetrics{threshold: threshold}
+		unitMetrics.executePhases[label] = metrics
+	}
+	return metrics
+}
+
+func (metrics *executePhaseMetrics) ToProto(label string, n float64) *proto.ExecutePhaseMetrics {
+	return &proto.ExecutePhaseMetrics{
+		Label:      label,
+		Threshold:  metrics.threshold,
+		SecondsAvg: metrics.timeSum / n,
+		EntriesAvg: float64(metrics.entriesSum) / n,
+	}
+}
+
+// Estimated health fraction of the boss, from 1 at the pull to 0 at the end of
+// the fight. Health based fights use the damage taken by the boss, or by the
+// current boss of a council. Duration based fights interpolate between the
+// times at which the execute phases start, so the estimate agrees with them,
+// and start over at 1 when the execute focus despawns.
+func (sim *Simulation) BossHealthPercent() float64 {
+	if sim.Encounter.EndFightAtHealth > 0 {
+		executeHealth := sim.Encounter.executeHealth()
+		if executeHealth <= 0 {
+			return 0
+		}
+		return max(0, 1-sim.Encounter.executeDamageTaken()/executeHealth)
+	}
+
+	start := float64(sim.executeStart)
+	span := float64(sim.Duration - sim.executeStart)
+	if span <= 0 {
+		return 1
+	}
+
+	phases := [...]struct {
+		health    float64
+		threshold int32
+	}{
+		{0.90, 90},
+		{0.45, 45},
+		{0.35, 35},
+		{0.25, 25},
+		{0.20, 20},
+	}
+
+	now := float64(sim.CurrentTime)
+	prevHealth, prevTime := 1.0, start
+	for _, phase := range phases {
+		// Phases are entered in order, even if the proportions aren't. Rounded to
+		// the nanosecond like the sim's execute phases, so both start together.
+		proportion := sim.Encounter.executeProportion(phase.threshold)
+		phaseTime := max(prevTime, float64(sim.executeStart+time.Duration((1-proportion)*float64(sim.Duration-sim.executeStart))))
+		if now < phaseTime {
+			return prevHealth - (prevHealth-phase.health)*(now-prevTime)/(phaseTime-prevTime)
+		}
+		prevHealth, prevTime = phase.health, phaseTime
+	}
+
+	end := start + span
+	if now < end {
+		return prevHealth * (end - now) / (end - prevTime)
+	}
+	return 0
+}
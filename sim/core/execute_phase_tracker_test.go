@@ -0,0 +1,142 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBossHealthPercentFromExecuteProportions(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.Duration = time.Second * 100
+	sim.Encounter.ExecuteProportion_90 = 0.9
+	sim.Encounter.ExecuteProportion_45 = 0.5
+	sim.Encounter.ExecuteProportion_35 = 0.3
+	sim.Encounter.ExecuteProportion_25 = 0.2
+	sim.Encounter.ExecuteProportion_20 = 0.1
+
+	testCases := []struct {
+		time   time.Duration
+		health float64
+	}{
+		{0, 1},
+		{time.Second * 5, 0.95},   // Halfway to 90% at 10s.
+		{time.Second * 30, 0.675}, // Halfway from 90% at 10s to 45% at 50s.
+		{time.Second * 70, 0.35},
+		{time.Second * 95, 0.10}, // Halfway from 20% at 90s to 0% at the end.
+		{time.Second * 100, 0},
+	}
+
+	for _, testCase := range testCases {
+		sim.CurrentTime = testCase.time
+		if health := sim.BossHealthPercent(); !WithinToleranceFloat64(testCase.health, health, 0.0001) {
+			t.Fatalf("At %s: expected a boss health of %0.4f, got %0.4f", testCase.time, testCase.health, health)
+		}
+	}
+}
+
+func TestExecutePhaseTrackerHysteresis(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.Encounter.EndFightAtHealth = 1000
+
+	var enters, exits int
+	tracker := sim.Raid.Parties[0].Players[0].GetCharacter().RegisterExecutePhaseTracker(ExecutePhaseTrackerConfig{
+		Label:      "Execute",
+		Threshold:  0.35,
+		Hysteresis: 0.05,
+		OnEnter:    func(_ *Simulation) { enters++ },
+		OnExit:     func(_ *Simulation) { exits++ },
+	})
+	tracker.reset(sim)
+
+	// Health estimate hovering around 35%, but never rising above 40%.
+	for i, health := range []float64{0.5, 0.34, 0.36, 0.34, 0.39, 0.30} {
+		sim.CurrentTime = time.Second * time.Duration(i)
+		sim.Encounter.DamageTaken = (1 - health) * 1000
+		tracker.update(sim)
+	}
+	if enters != 1 || exits != 0 {
+		t.Fatalf("Expected 1 enter and 0 exits, got %d and %d", enters, exits)
+	}
+
+	// Only leaving the band ends the phase, e.g. the next boss of a council.
+	sim.CurrentTime = time.Second * 10
+	sim.Encounter.DamageTaken = 0
+	tracker.update(sim)
+	if enters != 1 || exits != 1 || tracker.IsActive() {
+		t.Fatalf("Expected the phase to end above 40%%, got %d enters and %d exits", enters, exits)
+	}
+
+	// In the phase from 1s to 10s.
+	if timeInPhase := tracker.TimeInPhase(sim); timeInPhase != time.Second*9 {
+		t.Fatalf("Expected 9s in the phase, got %s", timeInPhase)
+	}
+
+	tracker.doneIteration(sim)
+	phase := tracker.unit.Metrics.executePhases["Execute"]
+	if phase == nil || phase.timeSum != 9 || phase.entriesSum != 1 {
+		t.Fatalf("Expected 9s and 1 entry in the unit metrics, got %+v", phase)
+	}
+
+	for _, label := range []string{"Execute 20", "Execute 35", "Execute 25"} {
+		tracker.unit.Metrics.getExecutePhaseMetrics(label, 0.2)
+	}
+	// Map iteration order is random, so check the output order repeatedly.
+	for range 10 {
+		var labels []string
+		for _, phase := range tracker.unit.Metrics.ToProto().ExecutePhases {
+			labels = append(labels, phase.Label)
+		}
+		if !slices.Equal(labels, []string{"Execute", "Execute 20", "Execute 25", "Execute 35"}) {
+			t.Fatalf("Expected execute phases sorted by label, got %v", labels)
+		}
+	}
+}
+
+func TestExecutePhaseAgreesWithSimExecutePhase(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.Duration = time.Second * 100
+	sim.Encounter.ExecuteProportion_90 = 0.9
+	sim.Encounter.ExecuteProportion_45 = 0.5
+	sim.Encounter.ExecuteProportion_35 = 0.3
+	sim.Encounter.ExecuteProportion_25 = 0.2
+	sim.Encounter.ExecuteProportion_20 = 0.1
+
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	tracker := unit.ExecutePhase(20)
+	if unit.ExecutePhase(20) != tracker {
+		t.Fatalf("Expected abilities of a unit to share its execute phase tracker")
+	}
+	if tracker.Label != "Execute 20%" || tracker.Threshold != 0.2 {
+		t.Fatalf("Unexpected execute phase tracker %s at %0.2f", tracker.Label, tracker.Threshold)
+	}
+
+	sim.CurrentTime = 0
+	sim.executePhase = 0
+	sim.nextExecutePhase()
+	tracker.reset(sim)
+	for _, now := range []time.Duration{time.Second * 50, time.Second*90 - 1, time.Second * 90, time.Second * 95} {
+		sim.advance(now)
+		if tracker.IsActive() != sim.IsExecutePhase20() {
+			t.Fatalf("At %s: expected the tracker to agree with the sim's 20%% execute phase", now)
+		}
+	}
+	if !tracker.IsActive() {
+		t.Fatalf("Expected the tracker to be active at the end of the fight")
+	}
+	// Health based fights enter at exactly 20% health as well.
+	sim.Encounter.EndFightAtHealth = 3e6
+	for _, damageTaken := range []float64{2e6, 2.4e6 - 1, 2.4e6} {
+		sim.executePhase = 0
+		sim.nextExecutePhase()
+		sim.Encounter.DamageTaken = damageTaken
+		tracker.reset(sim)
+		sim.advance(sim.CurrentTime)
+		if tracker.IsActive() != sim.IsExecutePhase20() {
+			t.Fatalf("With %0.0f damage taken: expected the tracker to agree with the sim's 20%% execute phase", damageTaken)
+		}
+	}
+	if !tracker.IsActive() {
+		t.Fatalf("Expected the tracker to be active at 20%% health")
+	}
+}
@@ -1,7 +1,10 @@
 package core
 
 import (
+	"math/bits"
+
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
 )
 
 //go:generate stringer -type=ProcMask
@@ -114,6 +117,10 @@ const (
 	// These bits are set by the crit and damage rolls.
 	OutcomeCrit
 	OutcomeCrush
+
+	// Replaces the outcome of results against a target immune to the spell,
+	// which deal no damage and don't count as landed.
+	OutcomeImmune
 )
 
 const (
@@ -121,7 +128,9 @@ const (
 )
 
 func (ho HitOutcome) String() string {
-	if ho.Matches(OutcomeMiss) {
+	if ho.Matches(OutcomeImmune) {
+		return "Immune"
+	} else if ho.Matches(OutcomeMiss) {
 		return "Miss"
 	} else if ho.Matches(OutcomeDodge) {
 		return "Dodge"
@@ -168,6 +177,8 @@ const (
 	SpellFlagChanneled                                     // Spell is channeled
 	SpellFlagCastWhileChanneling                           // Spell can be cast while channeling. If SpellFlagChanneled and SpellFlagCastWhileChanneling are both set, it means that other spells with the SpellFlagCastWhileChanneling flag can be cast without interrupting the channeled spell.
 	SpellFlagDisease                                       // Spell is categorized as disease
+	SpellFlagBleed                                         // Spell is categorized as bleed
+	SpellFlagPoison                                        // Spell is categorized as poison
 	SpellFlagHelpful                                       // For healing spells / buffs.
 	SpellFlagMeleeMetrics                                  // Marks a spell as a melee ability for metrics.
 	SpellFlagNoOnCastComplete                              // Disables the OnCastComplete callback.
@@ -190,6 +201,7 @@ const (
 	SpellFlagAoE                                           // Indicates that this spell is an AoE spell. Spells flagged with this will use the AoE Cap multiplier when calculating damage.
 	SpellFlagRanged                                        // Indicates that this spell is a ranged spell. Spells flagged with this will have increased damage when Hunters Mark is active.
 	SpellFlagReadinessTrinket                              // Indicates that this spell part of Readiness. Used by Siege of Orgrimmar CDR trinkets.
+	SpellFlagTrackCooldownDrift                            // Indicates this spell is meant to be used on cooldown, so delays in using it are tracked like for MCDs.
 
 	// Used to let agents categorize their spells.
 	SpellFlagAgentReserved1
@@ -198,6 +210,10 @@ const (
 	SpellFlagAgentReserved4
 
 	SpellFlagIgnoreModifiers = SpellFlagIgnoreAttackerModifiers | SpellFlagIgnoreTargetModifiers
+
+	// Mechanics which targets can be immune to. Spells flagged with a mechanic are
+	// blocked entirely, see DotConfig.Mechanic for spells where only the dot has it.
+	SpellFlagMechanics = SpellFlagDisease | SpellFlagBleed | SpellFlagPoison
 )
 
 type SpellSchool byte
@@ -224,6 +240,25 @@ func (ss SpellSchool) Matches(other SpellSchool) bool {
 	return (ss & other) != 0
 }
 
+// Returns whether every school of other is also in this mask.
+func (ss SpellSchool) ContainsAll(other SpellSchool) bool {
+	return (ss & other) == other
+}
+
+// Returns whether this mask combines several schools, e.g. Frostfire.
+func (ss SpellSchool) IsMultiSchool() bool {
+	return bits.OnesCount8(uint8(ss)) > 1
+}
+
+// Returns the index of each school in this mask, in school index order.
+func (ss SpellSchool) SchoolIndices() []stats.SchoolIndex {
+	indices := make([]stats.SchoolIndex, 0, bits.OnesCount8(uint8(ss)))
+	for remaining := uint8(ss); remaining != 0; remaining &= remaining - 1 {
+		indices = append(indices, stats.SchoolIndex(bits.TrailingZeros8(remaining)))
+	}
+	return indices
+}
+
 func SpellSchoolFromProto(p proto.SpellSchool) SpellSchool {
 	switch p {
 	case proto.SpellSchool_SpellSchoolPhysical:
@@ -1,7 +1,10 @@
 package core
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/wowsims/mop/sim/core/stats"
 )
 
 func TestProcMasks(t *testing.T) {
@@ -18,3 +21,15 @@ func TestOutcomesMasks(t *testing.T) {
 		t.Fatalf("Miss should not match Dodge or Parry!")
 	}
 }
+
+func TestSpellSchoolIndices(t *testing.T) {
+	if indices := SpellSchoolShadowFlame.SchoolIndices(); !slices.Equal(indices, []stats.SchoolIndex{stats.SchoolIndexFire, stats.SchoolIndexShadow}) {
+		t.Fatalf("Expected Shadowflame to be Fire and Shadow, got %v", indices)
+	}
+	if SpellSchoolFire.IsMultiSchool() || !SpellSchoolShadowFlame.IsMultiSchool() {
+		t.Fatalf("Expected only Shadowflame to be multi-school")
+	}
+	if SpellSchoolShadow.ContainsAll(SpellSchoolShadowFlame) || !SpellSchoolShadowFlame.ContainsAll(SpellSchoolShadow) {
+		t.Fatalf("Expected Shadowflame to contain Shadow but not the reverse")
+	}
+}
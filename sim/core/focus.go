@@ -27,6 +27,7 @@ type focusBar struct {
 
 	regenMetrics       *ResourceMetrics
 	focusRefundMetrics *ResourceMetrics
+	capMetrics         *ResourceCapMetrics
 
 	OnFocusGain OnFocusGain
 }
@@ -44,6 +45,7 @@ func (unit *Unit) EnableFocusBar(maxFocus float64, baseFocusPerSecond float64, i
 		baseFocusPerSecond:    baseFocusPerSecond,
 		regenMetrics:          unit.NewFocusMetrics(ActionID{OtherID: proto.OtherAction_OtherActionFocusRegen}),
 		focusRefundMetrics:    unit.NewFocusMetrics(ActionID{OtherID: proto.OtherAction_OtherActionRefund}),
+		capMetrics:            unit.Metrics.NewResourceCapMetrics(proto.ResourceType_ResourceTypeFocus),
 		OnFocusGain:           onFocusGain,
 		hasHasteRatingScaling: hasHasteRatingScaling,
 	}
@@ -96,12 +98,13 @@ func (fb *focusBar) AddFocus(sim *Simulation, amount float64, metrics *ResourceM
 		panic("Trying to add negative focus!")
 	}
 	newFocus := min(fb.currentFocus+amount, fb.maxFocus)
-	if (fb.isPlayer || fb.currentFocus != newFocus) && sim.Log != nil {
-		fb.unit.Log(sim, "Gained %0.3f focus from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, fb.currentFocus, newFocus, fb.maxFocus)
+	if (fb.isPlayer || fb.currentFocus != newFocus) && sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		fb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %0.3f focus from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, fb.currentFocus, newFocus, fb.maxFocus)
 	}
 	if fb.isPlayer {
 		metrics.AddEvent(amount, newFocus-fb.currentFocus)
 	}
+	fb.capMetrics.Update(sim, newFocus >= fb.maxFocus, amount-(newFocus-fb.currentFocus))
 
 	if fb.OnFocusGain != nil {
 		fb.OnFocusGain(sim, newFocus)
@@ -117,9 +120,10 @@ func (fb *focusBar) SpendFocus(sim *Simulation, amount float64, metrics *Resourc
 
 	newFocus := fb.currentFocus - amount
 	metrics.AddEvent(-amount, -amount)
+	fb.capMetrics.Update(sim, newFocus >= fb.maxFocus, 0)
 
-	if sim.Log != nil {
-		fb.unit.Log(sim, "Spent %0.3f focus from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, fb.currentFocus, newFocus, fb.maxFocus)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		fb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent %0.3f focus from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, fb.currentFocus, newFocus, fb.maxFocus)
 	}
 
 	fb.currentFocus = newFocus
@@ -152,7 +156,7 @@ func (fb *focusBar) processDynamicHasteRatingChange(sim *Simulation) {
 	}
 
 	fb.ResetFocusTick(sim)
-	fb.hasteRatingMultiplier = 1.0 + fb.unit.GetStat(stats.HasteRating)/(100*HasteRatingPerHastePercent)
+	fb.hasteRatingMultiplier = Ratings.HasteMultiplier(fb.unit.GetStat(stats.HasteRating))
 }
 
 func (fb *focusBar) RunTask(sim *Simulation) time.Duration {
@@ -173,7 +177,7 @@ func (fb *focusBar) reset(sim *Simulation) {
 	fb.focusRegenMultiplier = 1.0
 
 	if fb.hasHasteRatingScaling {
-		fb.hasteRatingMultiplier = 1.0 + fb.unit.GetStat(stats.HasteRating)/(100*HasteRatingPerHastePercent)
+		fb.hasteRatingMultiplier = Ratings.HasteMultiplier(fb.unit.GetStat(stats.HasteRating))
 	} else {
 		fb.hasteRatingMultiplier = 1.0
 	}
@@ -184,12 +188,16 @@ func (fb *focusBar) reset(sim *Simulation) {
 }
 
 func (fb *focusBar) enable(sim *Simulation, startAt time.Duration) {
+	fb.capMetrics.Update(sim, fb.currentFocus >= fb.maxFocus, 0)
 	sim.AddTask(fb)
 	fb.nextFocusTick = startAt + time.Duration(sim.RandomFloat("Focus Tick")*float64(fb.focusTickDuration))
 	sim.RescheduleTask(fb.nextFocusTick)
 }
 
 func (fb *focusBar) disable(sim *Simulation) {
+	if fb.unit != nil {
+		fb.capMetrics.Update(sim, false, 0)
+	}
 	fb.nextFocusTick = NeverExpires
 	sim.RemoveTask(fb)
 }
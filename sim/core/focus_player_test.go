@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestFocusPlayer(t *testing.T) {
+	newPlayer := func(name string) *proto.Player {
+		return &proto.Player{
+			Name:      name,
+			Class:     proto.Class_ClassShaman,
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			Rotation:  &proto.APLRotation{},
+		}
+	}
+
+	raidProto := &proto.Raid{
+		Parties: []*proto.Party{
+			{
+				Players: []*proto.Player{newPlayer("Buffer"), newPlayer("Focus")},
+				Buffs:   &proto.PartyBuffs{},
+			},
+		},
+		Buffs:       &proto.RaidBuffs{},
+		Debuffs:     &proto.Debuffs{},
+		FocusPlayer: &proto.UnitReference{Type: proto.UnitReference_Player, Index: 1},
+	}
+	encounterProto := &proto.Encounter{
+		Targets: []*proto.Target{
+			{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon},
+		},
+		Duration: 20,
+	}
+
+	env := &Environment{State: Created}
+	env.construct(raidProto, encounterProto)
+	raidStats := env.initialize(raidProto, encounterProto)
+
+	// Effects of non-focused players keep running, e.g. a proc that channels a spell.
+	buffer := env.Raid.Parties[0].Players[0].GetCharacter()
+	channel := buffer.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 43},
+		SpellSchool: SpellSchoolArcane,
+		ProcMask:    ProcMaskSpellDamage,
+		Flags:       SpellFlagChanneled,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Dot: DotConfig{
+			Aura: Aura{
+				Label: "Fake Channel",
+			},
+			NumberOfTicks: 3,
+			TickLength:    time.Second,
+			OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+				dot.Spell.CalcAndDealPeriodicDamage(sim, target, 100, dot.OutcomeTick)
+			},
+		},
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.Dot(target).Apply(sim)
+		},
+	})
+	buffer.RegisterResetEffect(func(sim *Simulation) {
+		sim.AddPendingAction(NewDelayedAction(DelayedActionOptions{
+			DoAt: time.Second,
+			OnAction: func(sim *Simulation) {
+				channel.Cast(sim, buffer.CurrentTarget)
+			},
+		}))
+	})
+
+	env.finalize(raidProto, encounterProto, raidStats, false)
+	sim := newSimWithEnv(env, &proto.SimOptions{RandomSeed: 100}, simsignals.CreateSignals())
+
+	focus := env.Raid.Parties[0].Players[1].GetCharacter()
+	if buffer.Rotation != nil {
+		t.Fatalf("Expected players other than the focus player to have no rotation")
+	}
+	if focus.Rotation == nil {
+		t.Fatalf("Expected the focus player to keep its rotation")
+	}
+
+	sim.runOnce()
+	if ticks := channel.SpellMetrics[0].Ticks; ticks != 3 {
+		t.Fatalf("Expected the non-focused player to channel 3 ticks, got %d", ticks)
+	}
+}
@@ -32,6 +32,32 @@ func (unit *Unit) newHardcastAction(sim *Simulation) {
 	sim.AddPendingAction(unit.hardcastAction)
 }
 
+// Returns the latency before the next action after a cast or GCD, see Unit.Latency.
+func (unit *Unit) rollLatency(sim *Simulation) time.Duration {
+	if unit.Latency == 0 && unit.LatencyJitter == 0 {
+		return 0
+	}
+
+	latency := unit.Latency
+	if unit.LatencyJitter > 0 {
+		latency = max(0, DurationFromSeconds(sim.RollWithLabel((latency-unit.LatencyJitter).Seconds(), (latency+unit.LatencyJitter).Seconds(), "Latency")))
+	}
+	return latency.Round(time.Millisecond)
+}
+
+// Returns when the GCD is ready after an action which takes until readyAt,
+// delayed by server latency. Only the part of the latency which delays the
+// next action past the running GCD is counted in the unit's metrics.
+func (unit *Unit) gcdReadyAfterLatency(sim *Simulation, readyAt time.Duration) time.Duration {
+	withoutLatency := max(readyAt, unit.NextGCDAt())
+	withLatency := max(readyAt+unit.rollLatency(sim), unit.NextGCDAt())
+
+	if sim.CurrentTime >= 0 {
+		unit.Metrics.LatencyTime += withLatency - withoutLatency
+	}
+	return withLatency
+}
+
 func (unit *Unit) NextGCDAt() time.Duration {
 	return unit.GCD.ReadyAt()
 }
@@ -45,10 +71,26 @@ func (unit *Unit) SetGCDTimer(sim *Simulation, gcdReadyAt time.Duration) {
 		return
 	}
 
+	if !unit.GCD.IsReady(sim) && gcdReadyAt < unit.GCD.ReadyAt() {
+		unit.recordGCDConflict(sim, "GCD running until %s shortened to %s", unit.GCD.ReadyAt(), gcdReadyAt)
+	}
+
 	unit.GCD.Set(gcdReadyAt)
 	unit.SetRotationTimer(sim, gcdReadyAt)
 }
 
+// Counts two systems acting on the GCD at once, e.g. a manual rotation kick
+// cutting a running GCD short, or a proc casting a GCD spell while it's locked.
+// These are double scheduling bugs, see UnitMetrics.gcd_conflicts_avg.
+func (unit *Unit) recordGCDConflict(sim *Simulation, message string, vals ...any) {
+	if sim.CurrentTime >= 0 {
+		unit.Metrics.GCDConflicts++
+	}
+	if sim.LogEnabled(LogCategoryAI, LogLevelDebug) {
+		unit.LogAt(sim, LogCategoryAI, LogLevelDebug, "GCD conflict: "+message, vals...)
+	}
+}
+
 func (unit *Unit) SetRotationTimer(sim *Simulation, rotationReadyAt time.Duration) {
 	if unit.rotationAction == nil {
 		return
@@ -67,6 +109,11 @@ func (unit *Unit) SetRotationTimer(sim *Simulation, rotationReadyAt time.Duratio
 
 // Call this when reacting to events that occur before the next scheduled rotation action
 func (unit *Unit) ReactToEvent(sim *Simulation) {
+	// Units without a rotation, e.g. static buff providers, have nothing to react with.
+	if unit.Rotation == nil {
+		return
+	}
+
 	// If the next rotation action was already scheduled for this timestep then execute it now
 	unit.Rotation.DoNextAction(sim)
 
@@ -79,12 +126,21 @@ func (unit *Unit) ReactToEvent(sim *Simulation) {
 // Call this to stop the GCD loop for a unit.
 // This is mostly used for pets that get summoned / expire.
 func (unit *Unit) CancelGCDTimer(sim *Simulation) {
+	// Targets without an AI have no GCD loop, but can still despawn.
+	if unit.rotationAction == nil {
+		return
+	}
 	unit.rotationAction.Cancel(sim)
 }
 
 func (unit *Unit) CancelHardcast(sim *Simulation) {
 	unit.Hardcast.Expires = startingCDTime
-	unit.SetGCDTimer(sim, sim.CurrentTime+unit.ReactionTime)
+
+	// Cutting the GCD of the cancelled cast short is intended, so this isn't a conflict.
+	if unit.rotationAction != nil {
+		unit.GCD.Set(sim.CurrentTime + unit.ReactionTime)
+	}
+	unit.SetRotationTimer(sim, sim.CurrentTime+unit.ReactionTime)
 }
 
 func (unit *Unit) WaitUntil(sim *Simulation, readyTime time.Duration) {
@@ -92,8 +148,8 @@ func (unit *Unit) WaitUntil(sim *Simulation, readyTime time.Duration) {
 		panic(unit.Label + ": cannot wait negative time")
 	}
 	unit.SetRotationTimer(sim, readyTime)
-	if sim.Log != nil && readyTime > sim.CurrentTime {
-		unit.Log(sim, "Pausing rotation for %s due to resources / CDs.", readyTime-sim.CurrentTime)
+	if sim.LogEnabled(LogCategoryAI, LogLevelTrace) && readyTime > sim.CurrentTime {
+		unit.LogAt(sim, LogCategoryAI, LogLevelTrace, "Pausing rotation for %s due to resources / CDs.", readyTime-sim.CurrentTime)
 	}
 }
 
@@ -102,7 +158,7 @@ func (unit *Unit) ExtendGCDUntil(sim *Simulation, readyTime time.Duration) {
 		panic(unit.Label + ": cannot wait negative time")
 	}
 	unit.SetGCDTimer(sim, readyTime)
-	if sim.Log != nil && readyTime > sim.CurrentTime {
-		unit.Log(sim, "Extending GCD for %s due to rotation / CDs.", readyTime-sim.CurrentTime)
+	if sim.LogEnabled(LogCategoryAI, LogLevelTrace) && readyTime > sim.CurrentTime {
+		unit.LogAt(sim, LogCategoryAI, LogLevelTrace, "Extending GCD for %s due to rotation / CDs.", readyTime-sim.CurrentTime)
 	}
 }
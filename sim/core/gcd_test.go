@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRollLatency(t *testing.T) {
+	sim := &Simulation{deterministic: true}
+	unit := &Unit{Metrics: NewUnitMetrics()}
+
+	if latency := unit.rollLatency(sim); latency != 0 {
+		t.Fatalf("Expected no latency by default, got %s", latency)
+	}
+
+	unit.Latency = time.Millisecond * 100
+	unit.LatencyJitter = time.Millisecond * 150
+	if latency := unit.rollLatency(sim); latency != time.Millisecond*100 {
+		t.Fatalf("Expected deterministic latency of 100ms, got %s", latency)
+	}
+}
+
+func TestLatencyOncePerAction(t *testing.T) {
+	var gcdSpell *Spell
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+		gcdSpell = fa.RegisterSpell(SpellConfig{
+			ActionID: ActionID{SpellID: 1001},
+			ProcMask: ProcMaskEmpty,
+			Cast: CastConfig{
+				DefaultCast: Cast{GCD: GCDDefault},
+			},
+			ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {},
+		})
+	})
+	sim.deterministic = true
+	unit := gcdSpell.Unit
+	unit.Latency = time.Millisecond * 100
+	target := sim.Encounter.AllTargetUnits[0]
+
+	gcdSpell.Cast(sim, target)
+	if unit.NextGCDAt() != time.Millisecond*1600 || unit.Metrics.LatencyTime != time.Millisecond*100 {
+		t.Fatalf("Expected the GCD to end after 1.6s with 100ms latency, got %s and %s", unit.NextGCDAt(), unit.Metrics.LatencyTime)
+	}
+
+	// Casts made by the effects of another spell don't add latency again.
+	sim.CurrentTime = time.Second * 2
+	sim.activeSpell = gcdSpell
+	gcdSpell.Cast(sim, target)
+	sim.activeSpell = nil
+	if unit.NextGCDAt() != time.Millisecond*3500 || unit.Metrics.LatencyTime != time.Millisecond*100 {
+		t.Fatalf("Expected the nested cast's GCD to end after 3.5s without latency, got %s and %s", unit.NextGCDAt(), unit.Metrics.LatencyTime)
+	}
+
+	// Latency hidden behind a longer running GCD isn't counted.
+	unit.GCD.Set(time.Second * 5)
+	if readyAt := unit.gcdReadyAfterLatency(sim, time.Second*3); readyAt != time.Second*5 || unit.Metrics.LatencyTime != time.Millisecond*100 {
+		t.Fatalf("Expected the running GCD to hide the latency, got %s and %s", readyAt, unit.Metrics.LatencyTime)
+	}
+
+	sim.CurrentTime = -time.Second
+	unit.GCD.Set(0)
+	unit.gcdReadyAfterLatency(sim, 0)
+	if unit.Metrics.LatencyTime != time.Millisecond*100 {
+		t.Fatalf("Expected prepull latency not to be tracked, got %s", unit.Metrics.LatencyTime)
+	}
+}
+
+func TestGCDConflicts(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{
+		Metrics:        NewUnitMetrics(),
+		GCD:            new(Timer),
+		RotationTimer:  new(Timer),
+		rotationAction: &PendingAction{consumed: true},
+	}
+
+	unit.SetGCDTimer(sim, time.Millisecond*1500)
+	unit.SetGCDTimer(sim, time.Millisecond*1800)
+	if unit.Metrics.GCDConflicts != 0 {
+		t.Fatalf("Expected extending the GCD not to conflict, got %d conflicts", unit.Metrics.GCDConflicts)
+	}
+
+	unit.SetGCDTimer(sim, time.Second)
+	if unit.Metrics.GCDConflicts != 1 {
+		t.Fatalf("Expected shortening a running GCD to conflict, got %d conflicts", unit.Metrics.GCDConflicts)
+	}
+
+	unit.CancelHardcast(sim)
+	if unit.Metrics.GCDConflicts != 1 {
+		t.Fatalf("Expected cancelling a hardcast not to conflict, got %d conflicts", unit.Metrics.GCDConflicts)
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+// Sims several complete gear sets of the same character, e.g. pre-raid, tier N
+// and BiS, and returns a progression table with one row per gear set.
+func GearProgression(signals simsignals.Signals, request *proto.GearProgressionRequest, progress chan *proto.ProgressMetrics) *proto.GearProgressionResult {
+	result := runGearProgression(signals, request, progress)
+
+	if progress != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalGearProgressionResult: result,
+		}
+		close(progress)
+	}
+
+	return result
+}
+
+func runGearProgression(signals simsignals.Signals, request *proto.GearProgressionRequest, progress chan *proto.ProgressMetrics) (result *proto.GearProgressionResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.GearProgressionResult{
+				Error: &proto.ErrorOutcome{
+					Message: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+				},
+			}
+		}
+		signals.Abort.Trigger()
+	}()
+
+	baseSettings := request.GetBaseSettings()
+	if len(baseSettings.GetRaid().GetParties()) == 0 || len(baseSettings.Raid.Parties[0].Players) == 0 {
+		return &proto.GearProgressionResult{
+			Error: &proto.ErrorOutcome{Message: "gear progression: no player found"},
+		}
+	}
+	if len(request.GearSets) == 0 {
+		return &proto.GearProgressionResult{
+			Error: &proto.ErrorOutcome{Message: "gear progression: no gear sets to compare"},
+		}
+	}
+
+	player := baseSettings.Raid.Parties[0].Players[0]
+	if player.GetDatabase() != nil {
+		addToDatabase(player.GetDatabase())
+	}
+	// reduce to just the player, like bulk sims.
+	baseSettings.Raid.Parties = []*proto.Party{baseSettings.Raid.Parties[0]}
+	baseSettings.Raid.Parties[0].Players = []*proto.Player{player}
+	// clean to reduce memory
+	player.Database = nil
+
+	iterations := request.IterationsPerSet
+	if iterations <= 0 {
+		iterations = defaultIterationsPerCombo
+	}
+
+	rows := make([]*proto.GearProgressionRow, len(request.GearSets))
+	combos := make([]singleBulkSim, len(request.GearSets))
+	rowsByRequest := make(map[*proto.RaidSimRequest]*proto.GearProgressionRow, len(request.GearSets))
+
+	for i, gearSet := range request.GearSets {
+		simRequest := goproto.Clone(baseSettings).(*proto.RaidSimRequest)
+		simRequest.Raid.Parties[0].Players[0].Equipment = gearSet.Gear
+
+		// Stats are computed up front, as the environments of the sims aren't exposed.
+		_, raidStats, _ := NewEnvironment(simRequest.Raid, simRequest.Encounter, true)
+		finalStats := raidStats.Parties[0].Players[0].FinalStats
+
+		rows[i] = &proto.GearProgressionRow{
+			Name:           gearSet.Name,
+			FinalStats:     finalStats,
+			BreakpointsMet: breakpointsMet(finalStats, request.Breakpoints),
+		}
+		rowsByRequest[simRequest] = rows[i]
+
+		combos[i] = singleBulkSim{
+			req: simRequest,
+			cl:  &raidSimRequestChangeLog{},
+			eq:  &equipmentSubstitution{},
+		}
+	}
+
+	bulk := &bulkSimRunner{
+		SingleRaidSimRunner: runSim,
+	}
+	simResults, _, errorOutcome := bulk.getRankedResults(signals, combos, iterations, progress)
+	if errorOutcome != nil {
+		return &proto.GearProgressionResult{Error: errorOutcome}
+	}
+
+	for _, simResult := range simResults {
+		um := simResult.Result.GetRaidMetrics().GetParties()[0].GetPlayers()[0]
+		um.Actions = nil
+		um.Auras = nil
+		um.Resources = nil
+		um.Pets = nil
+		rowsByRequest[simResult.Request].UnitMetrics = um
+	}
+
+	for i := 1; i < len(rows); i++ {
+		rows[i].DpsGain = rows[i].UnitMetrics.GetDps().GetAvg() - rows[i-1].UnitMetrics.GetDps().GetAvg()
+	}
+
+	return &proto.GearProgressionResult{
+		Rows: rows,
+	}
+}
+
+func breakpointsMet(finalStats *proto.UnitStats, breakpoints []*proto.StatBreakpoint) []string {
+	var met []string
+	for _, breakpoint := range breakpoints {
+		statIdx := int(breakpoint.Stat)
+		if statIdx < len(finalStats.GetStats()) && finalStats.Stats[statIdx] >= breakpoint.Value {
+			met = append(met, breakpoint.Name)
+		}
+	}
+	return met
+}
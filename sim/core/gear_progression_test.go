@@ -0,0 +1,23 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestGearProgressionBreakpointsMet(t *testing.T) {
+	finalStats := &proto.UnitStats{
+		Stats: stats.Stats{stats.HitRating: 2550, stats.ExpertiseRating: 1000}.ToProtoArray(),
+	}
+	breakpoints := []*proto.StatBreakpoint{
+		{Name: "Hit Cap", Stat: proto.Stat_StatHitRating, Value: 2550},
+		{Name: "Expertise Cap", Stat: proto.Stat_StatExpertiseRating, Value: 2550},
+	}
+
+	if diff := cmp.Diff([]string{"Hit Cap"}, breakpointsMet(finalStats, breakpoints)); diff != "" {
+		t.Errorf("Unexpected breakpoints met (-want +got):\n%s", diff)
+	}
+}
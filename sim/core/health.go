@@ -53,9 +53,11 @@ func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *Resour
 	oldHealth := hb.currentHealth
 	newHealth := min(oldHealth+amount, hb.unit.MaxHealth())
 	metrics.AddEvent(amount, newHealth-oldHealth)
+	hb.unit.Metrics.HealingReceived += newHealth - oldHealth
+	hb.unit.Metrics.OverhealingReceived += amount - (newHealth - oldHealth)
 
-	if sim.Log != nil {
-		hb.unit.Log(sim, "Gained %0.3f health from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldHealth, newHealth, hb.MaxHealth())
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		hb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %0.3f health from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldHealth, newHealth, hb.MaxHealth())
 	}
 
 	hb.currentHealth = newHealth
@@ -70,6 +72,7 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 	newHealth := max(oldHealth-amount, 0)
 	metrics := hb.DamageTakenHealthMetrics
 	metrics.AddEvent(-amount, newHealth-oldHealth)
+	hb.unit.Metrics.DamageTaken += oldHealth - newHealth
 
 	// TMI calculations need timestamps and Max HP information for each damage taken event
 	if hb.unit.Metrics.isTanking {
@@ -80,8 +83,8 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 		hb.unit.Metrics.tmiList = append(hb.unit.Metrics.tmiList, entry)
 	}
 
-	if sim.Log != nil {
-		hb.unit.Log(sim, "Spent %0.3f health from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldHealth, newHealth, hb.MaxHealth())
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		hb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent %0.3f health from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldHealth, newHealth, hb.MaxHealth())
 	}
 
 	hb.currentHealth = newHealth
@@ -173,8 +176,8 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 func (character *Character) Died(sim *Simulation) {
 	aura := character.GetAura(ChanceOfDeathAuraLabel)
 	aura.Unit.Metrics.Died = true
-	if sim.Log != nil {
-		character.Log(sim, "Dead")
+	if sim.LogEnabled(LogCategoryOther, LogLevelInfo) {
+		character.LogAt(sim, LogCategoryOther, LogLevelInfo, "Dead")
 	}
 }
 
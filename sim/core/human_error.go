@@ -0,0 +1,109 @@
+package core
+
+import (
+	"math"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// z-score of the 95% confidence range of the DPS loss.
+const humanErrorConfidenceZ = 1.96
+
+// Picks the next action like getNextAction, except that with a chance of
+// humanErrorChance the second best ready action is picked instead, see
+// APLRotation.human_error_chance. Falls back to the best action when no other
+// action is ready.
+func (apl *APLRotation) getNextActionWithHumanError(sim *Simulation) *APLAction {
+	if apl.humanErrorChance == 0 || len(apl.controllingActions) != 0 {
+		return apl.getNextAction(sim)
+	}
+
+	var best *APLAction
+	for _, action := range apl.currentPriorityList(sim) {
+		if !action.IsReady(sim) {
+			continue
+		}
+
+		if best == nil {
+			best = action
+			if !sim.Proc(apl.humanErrorChance, "APL Human Error") {
+				return best
+			}
+			continue
+		}
+
+		if sim.Log != nil {
+			apl.unit.Log(sim, "Human error: taking %s instead of %s", action.impl, best.impl)
+		}
+		return action
+	}
+
+	return best
+}
+
+func hasHumanError(raid *proto.Raid) bool {
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			if player.GetRotation().GetHumanErrorChance() > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Copy of the request with perfect play, i.e. without human errors. Its logs
+// aren't needed.
+func withoutHumanError(rsr *proto.RaidSimRequest) *proto.RaidSimRequest {
+	perfectRsr := googleProto.Clone(rsr).(*proto.RaidSimRequest)
+	for _, party := range perfectRsr.Raid.Parties {
+		for _, player := range party.Players {
+			if player.GetRotation() != nil {
+				player.Rotation.HumanErrorChance = 0
+			}
+		}
+	}
+	perfectRsr.SimOptions.Debug = false
+	perfectRsr.SimOptions.DebugFirstIteration = false
+	return perfectRsr
+}
+
+// Adds the comparison with the perfect play result to each player whose
+// rotation has a human error chance.
+func (sim *Simulation) addHumanErrorMetrics(result *proto.RaidMetrics, perfect *proto.RaidMetrics) {
+	for partyIdx, party := range sim.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			if character.Rotation == nil || character.Rotation.humanErrorChance == 0 {
+				continue
+			}
+
+			playerMetrics := result.Parties[partyIdx].Players[character.PartyIndex]
+			playerMetrics.HumanError = &proto.HumanErrorMetrics{
+				HumanErrorChance: character.Rotation.humanErrorChance,
+				PerfectDps:       perfect.Parties[partyIdx].Players[character.PartyIndex].Dps,
+			}
+			setHumanErrorLossRange(playerMetrics.HumanError, playerMetrics.Dps)
+		}
+	}
+}
+
+// Sets the 95% confidence range of the difference between the mean DPS with
+// perfect play and the mean DPS with human errors.
+func setHumanErrorLossRange(humanError *proto.HumanErrorMetrics, dps *proto.DistributionMetrics) {
+	perfectDps := humanError.PerfectDps
+	loss := perfectDps.Avg - dps.Avg
+
+	variance := 0.0
+	if n := perfectDps.GetAggregatorData().GetN(); n > 0 {
+		variance += perfectDps.Stdev * perfectDps.Stdev / float64(n)
+	}
+	if n := dps.GetAggregatorData().GetN(); n > 0 {
+		variance += dps.Stdev * dps.Stdev / float64(n)
+	}
+	halfWidth := humanErrorConfidenceZ * math.Sqrt(variance)
+
+	humanError.DpsLossLow = loss - halfWidth
+	humanError.DpsLossHigh = loss + halfWidth
+}
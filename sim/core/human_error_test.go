@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestHumanErrorTakesSecondBestAction(t *testing.T) {
+	sim := &Simulation{}
+	best := &APLAction{impl: &testAPLActionCounter{}}
+	second := &APLAction{impl: &testAPLActionCounter{}}
+	apl := &APLRotation{priorityList: []*APLAction{best, second}}
+
+	if apl.getNextActionWithHumanError(sim) != best {
+		t.Fatalf("Expected the best action without a human error chance")
+	}
+
+	apl.humanErrorChance = 1
+	if apl.getNextActionWithHumanError(sim) != second {
+		t.Fatalf("Expected the second best action on a human error")
+	}
+
+	apl.priorityList = []*APLAction{best}
+	if apl.getNextActionWithHumanError(sim) != best {
+		t.Fatalf("Expected the best action when no other action is ready")
+	}
+}
+
+func TestHumanErrorLossRange(t *testing.T) {
+	humanError := &proto.HumanErrorMetrics{
+		PerfectDps: &proto.DistributionMetrics{Avg: 1100, Stdev: 100, AggregatorData: &proto.AggregatorData{N: 100}},
+	}
+	dps := &proto.DistributionMetrics{Avg: 1000, Stdev: 100, AggregatorData: &proto.AggregatorData{N: 100}}
+
+	setHumanErrorLossRange(humanError, dps)
+
+	// Standard error of the difference is sqrt(100 + 100).
+	if !WithinToleranceFloat64(humanError.DpsLossLow, 72.28, 0.01) || !WithinToleranceFloat64(humanError.DpsLossHigh, 127.72, 0.01) {
+		t.Fatalf("Expected a DPS loss range of 72.28 - 127.72, got %0.2f - %0.2f", humanError.DpsLossLow, humanError.DpsLossHigh)
+	}
+}
+
+func TestWithoutHumanError(t *testing.T) {
+	rsr := &proto.RaidSimRequest{
+		Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{
+			{Rotation: &proto.APLRotation{HumanErrorChance: 0.1}},
+			{},
+		}}}},
+		SimOptions: &proto.SimOptions{DebugFirstIteration: true},
+	}
+
+	if !hasHumanError(rsr.Raid) {
+		t.Fatalf("Expected the request to have human errors")
+	}
+
+	perfectRsr := withoutHumanError(rsr)
+	if hasHumanError(perfectRsr.Raid) || perfectRsr.SimOptions.DebugFirstIteration {
+		t.Fatalf("Expected the perfect play request to have no human errors or logs")
+	}
+	if rsr.Raid.Parties[0].Players[0].Rotation.HumanErrorChance != 0.1 {
+		t.Fatalf("Expected the original request to be unchanged")
+	}
+}
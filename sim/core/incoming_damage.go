@@ -0,0 +1,93 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Damage dealt to all players by the primary target, as steady ticks and
+// periodic spikes, see proto.IncomingDamageSchedule. Dealt through spells, so
+// absorbs and damage taken modifiers apply as they would to boss damage.
+type incomingDamageSchedule struct {
+	tickInterval   time.Duration
+	spikeInterval  time.Duration
+	spikeVariation time.Duration
+
+	tickSpell  *Spell
+	spikeSpell *Spell
+}
+
+func (env *Environment) registerIncomingDamageSchedule(config *proto.IncomingDamageSchedule) {
+	if config == nil || (config.Dps <= 0 && config.SpikeDamage <= 0) {
+		return
+	}
+
+	tickInterval := config.TickInterval
+	if tickInterval <= 0 {
+		tickInterval = 2
+	}
+
+	schedule := &incomingDamageSchedule{
+		tickInterval: DurationFromSeconds(tickInterval),
+	}
+
+	boss := &env.Encounter.AllTargets[0].Unit
+	school := SpellSchoolFromProto(config.School)
+
+	if config.Dps > 0 {
+		schedule.tickSpell = registerIncomingDamageSpell(boss, 1, school, config.Dps*tickInterval)
+	}
+
+	if config.SpikeDamage > 0 && config.SpikeInterval > 0 {
+		schedule.spikeInterval = DurationFromSeconds(config.SpikeInterval)
+		schedule.spikeVariation = DurationFromSeconds(min(max(config.SpikeIntervalVariation, 0), config.SpikeInterval))
+		schedule.spikeSpell = registerIncomingDamageSpell(boss, 2, school, config.SpikeDamage)
+	}
+
+	env.Encounter.incomingDamage = schedule
+}
+
+func registerIncomingDamageSpell(boss *Unit, tag int32, school SpellSchool, damage float64) *Spell {
+	return boss.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{OtherID: proto.OtherAction_OtherActionDamageTaken, Tag: tag},
+		SpellSchool:      school,
+		ProcMask:         ProcMaskEmpty,
+		Flags:            SpellFlagNoOnCastComplete,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, damage, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (schedule *incomingDamageSchedule) reset(sim *Simulation) {
+	if schedule.tickSpell != nil {
+		schedule.scheduleNext(sim, schedule.tickSpell, schedule.tickInterval, 0)
+	}
+	if schedule.spikeSpell != nil {
+		schedule.scheduleNext(sim, schedule.spikeSpell, schedule.spikeInterval, schedule.spikeVariation)
+	}
+}
+
+func (schedule *incomingDamageSchedule) scheduleNext(sim *Simulation, spell *Spell, interval time.Duration, variation time.Duration) {
+	nextAt := sim.CurrentTime + interval
+	if variation > 0 {
+		nextAt += DurationFromSeconds(sim.RollWithLabel(-variation.Seconds(), variation.Seconds(), "Incoming Damage"))
+	}
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = nextAt
+	pa.Priority = ActionPriorityDOT
+
+	pa.OnAction = func(sim *Simulation) {
+		// Skips the cast, so damage keeps coming while the boss is casting or stunned.
+		for _, player := range sim.Raid.AllPlayerUnits {
+			spell.SkipCastAndApplyEffects(sim, player)
+		}
+		schedule.scheduleNext(sim, spell, interval, variation)
+	}
+
+	sim.AddPendingAction(pa)
+}
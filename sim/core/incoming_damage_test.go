@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestIncomingDamageSchedule(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+			IncomingDamage: &proto.IncomingDamageSchedule{
+				Dps:           1000,
+				SpikeDamage:   5000,
+				SpikeInterval: 30,
+				School:        proto.SpellSchool_SpellSchoolShadow,
+			},
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	schedule := sim.Encounter.incomingDamage
+	if schedule == nil || schedule.tickSpell == nil || schedule.spikeSpell == nil {
+		t.Fatalf("Expected both steady and spike damage to be registered")
+	}
+
+	player := sim.Raid.AllPlayerUnits[0]
+	// Without gear there's no health to lose.
+	player.stats[stats.Health] = 100000
+	player.healthBar.reset(sim)
+
+	schedule.tickSpell.SkipCastAndApplyEffects(sim, player)
+	if damage := schedule.tickSpell.SpellMetrics[player.UnitIndex].TotalDamage; !WithinToleranceFloat64(2000, damage, 0.01) {
+		t.Fatalf("Expected a 2s tick to deal 2000 damage, got %0.1f", damage)
+	}
+	if player.Metrics.DamageTaken <= 0 {
+		t.Fatalf("Expected incoming damage to be taken by the player")
+	}
+}
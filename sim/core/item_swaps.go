@@ -340,7 +340,7 @@ func (swap *ItemSwap) SwapItems(sim *Simulation, swapSet proto.APLActionItemSwap
 		statsToSwap = statsToSwap.Invert()
 	}
 
-	if sim.Log != nil {
+	if sim.LogEnabled(LogCategoryOther, LogLevelDebug) {
 		sim.Log("Item Swap - Stats Change: %v", statsToSwap.FlatString())
 	}
 	character.AddDynamicEquipStats(sim, statsToSwap)
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Category of a combat log message. Categories can be filtered separately, see
+// SimOptions.log_categories.
+type LogCategory uint8
+
+// Values match proto.LogCategory.
+const (
+	LogCategoryOther     LogCategory = iota // Messages without a more specific category, e.g. from class code.
+	LogCategoryResources                    // Resource gains and spends.
+	LogCategoryAuras                        // Aura gains, fades, refreshes and stack changes.
+	LogCategoryCasts                        // Cast starts, completions and failures.
+	LogCategoryProcs                        // Proc triggers and the stats they grant.
+	LogCategoryAI                           // Rotation decisions, e.g. APL actions, queued spells and GCD pauses.
+	LogCategoryDamage                       // Damage, healing and shielding results.
+
+	NumLogCategories
+)
+
+// Verbosity of a combat log message. Values match proto.LogVerbosity.
+type LogLevel uint8
+
+const (
+	LogLevelTrace LogLevel = iota // Sim internals, e.g. dynamic stat changes.
+	LogLevelDebug                 // Details, e.g. resource changes, aura refreshes and periodic ticks.
+	LogLevelInfo                  // Key events, e.g. casts, aura gains and direct damage.
+)
+
+type logFilter struct {
+	minLevel   LogLevel
+	categories [NumLogCategories]bool
+}
+
+func newLogFilter(simOptions *proto.SimOptions) logFilter {
+	filter := logFilter{
+		minLevel: LogLevel(simOptions.LogVerbosity),
+	}
+
+	if len(simOptions.LogCategories) == 0 {
+		for i := range filter.categories {
+			filter.categories[i] = true
+		}
+	}
+	for _, category := range simOptions.LogCategories {
+		if int(category) < len(filter.categories) {
+			filter.categories[category] = true
+		}
+	}
+	return filter
+}
+
+func (filter *logFilter) allows(category LogCategory, level LogLevel) bool {
+	return level >= filter.minLevel && filter.categories[category]
+}
+
+// Returns whether messages of the given category and level are logged. Check
+// this before building expensive log messages.
+func (sim *Simulation) LogEnabled(category LogCategory, level LogLevel) bool {
+	return sim.Log != nil && sim.logFilter.allows(category, level)
+}
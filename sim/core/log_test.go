@@ -0,0 +1,28 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestLogFilter(t *testing.T) {
+	filter := newLogFilter(&proto.SimOptions{})
+	if !filter.allows(LogCategoryOther, LogLevelTrace) || !filter.allows(LogCategoryDamage, LogLevelInfo) {
+		t.Fatalf("Default filter should allow all messages")
+	}
+
+	filter = newLogFilter(&proto.SimOptions{
+		LogVerbosity:  proto.LogVerbosity_LogVerbosityDebug,
+		LogCategories: []proto.LogCategory{proto.LogCategory_LogCategoryCasts, proto.LogCategory_LogCategoryAI},
+	})
+	if !filter.allows(LogCategoryCasts, LogLevelInfo) || !filter.allows(LogCategoryAI, LogLevelDebug) {
+		t.Fatalf("Filter should allow selected categories at or above the verbosity")
+	}
+	if filter.allows(LogCategoryAI, LogLevelTrace) {
+		t.Fatalf("Filter should drop messages below the verbosity")
+	}
+	if filter.allows(LogCategoryResources, LogLevelInfo) || filter.allows(LogCategoryOther, LogLevelInfo) {
+		t.Fatalf("Filter should drop unselected categories")
+	}
+}
@@ -53,6 +53,10 @@ type MajorCooldown struct {
 	// all DPS cooldowns during their regen rotation.
 	Type CooldownType
 
+	// Resources restored by this cooldown, for consumables like mana potions.
+	// Used by the APL action for resource consumables.
+	RestoredResources []proto.ResourceType
+
 	// Whether the cooldown meets all optional conditions for activation. These
 	// conditions will be ignored when the user specifies their own activation time.
 	// This is for things like mana thresholds, which are optimizations for better
@@ -63,6 +67,11 @@ type MajorCooldown struct {
 	// are used instead of ShouldActivate.
 	timings []time.Duration
 
+	// How automatic uses are timed against other DPS cooldowns.
+	stackingPolicy proto.CooldownStackingPolicy
+	alignAuraID    ActionID
+	alignAura      *Aura
+
 	// Number of times this MCD was used so far in the current iteration.
 	numUsages int
 
@@ -134,6 +143,10 @@ func (mcd *MajorCooldown) shouldActivateHelper(sim *Simulation, character *Chara
 		return sim.CurrentTime >= mcd.timings[mcd.numUsages]
 	}
 
+	if !character.majorCooldownManager.stackingAllows(sim, mcd) {
+		return false
+	}
+
 	if mcd.Type.Matches(CooldownTypeSurvival) && character.cooldownConfigs.HpPercentForDefensives != 0 {
 		if character.CurrentHealthPercent() > character.cooldownConfigs.HpPercentForDefensives {
 			return false
@@ -156,8 +169,8 @@ func (mcd *MajorCooldown) tryActivateHelper(sim *Simulation, character *Characte
 		}
 
 		mcd.numUsages++
-		if sim.Log != nil {
-			character.Log(sim, "Major cooldown used: %s", mcd.Spell.ActionID)
+		if sim.LogEnabled(LogCategoryAI, LogLevelInfo) {
+			character.LogAt(sim, LogCategoryAI, LogLevelInfo, "Major cooldown used: %s", mcd.Spell.ActionID)
 		}
 	}
 
@@ -222,12 +235,18 @@ func (mcdm *majorCooldownManager) finalize() {
 				for t, timing := range cooldownConfig.Timings {
 					mcd.timings[t] = DurationFromSeconds(timing)
 				}
+				mcd.stackingPolicy = cooldownConfig.StackingPolicy
+				if cooldownConfig.AlignAuraId != nil {
+					mcd.alignAuraID = ProtoToActionID(cooldownConfig.AlignAuraId)
+					mcd.alignAura = mcdm.character.GetAuraByID(mcd.alignAuraID)
+				}
 				break
 			}
 		}
 	}
 
 	mcdm.majorCooldowns = make([]*MajorCooldown, len(mcdm.initialMajorCooldowns))
+	mcdm.registerCooldownOverlaps()
 }
 
 func (mcdm *majorCooldownManager) reset(_ *Simulation) {
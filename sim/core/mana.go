@@ -95,8 +95,8 @@ func (unit *Unit) AddMana(sim *Simulation, amount float64, metrics *ResourceMetr
 
 	metrics.AddEvent(amount, newMana-oldMana)
 
-	if sim.Log != nil {
-		unit.Log(sim, "Gained %0.3f mana from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldMana, newMana, unit.MaxMana())
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %0.3f mana from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldMana, newMana, unit.MaxMana())
 	}
 
 	unit.currentMana = newMana
@@ -111,8 +111,8 @@ func (unit *Unit) SpendMana(sim *Simulation, amount float64, metrics *ResourceMe
 	newMana := unit.CurrentMana() - amount
 	metrics.AddEvent(-amount, -amount)
 
-	if sim.Log != nil {
-		unit.Log(sim, "Spent %0.3f mana from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, unit.CurrentMana(), newMana, unit.MaxMana())
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent %0.3f mana from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, unit.CurrentMana(), newMana, unit.MaxMana())
 	}
 
 	unit.currentMana = newMana
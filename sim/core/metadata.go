@@ -0,0 +1,34 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	googleproto "google.golang.org/protobuf/proto"
+)
+
+// Version of the running sim build, set by the binaries on startup.
+var SimVersion = "development"
+
+// Revision of the embedded database, empty when built without the with_db tag.
+var DatabaseVersion = ""
+
+func NewSimMetadata(request *proto.RaidSimRequest) *proto.SimMetadata {
+	return &proto.SimMetadata{
+		SimVersion:      SimVersion,
+		ProtoVersion:    GetCurrentProtoVersion(),
+		DatabaseVersion: DatabaseVersion,
+		RequestHash:     HashRequest(request),
+	}
+}
+
+// Returns a short, stable hash identifying the given request.
+func HashRequest(request googleproto.Message) string {
+	data, err := googleproto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestMetadataOnErrorResults(t *testing.T) {
+	invalid := masteryTestRequest(0)
+	invalid.Raid.Parties[0].Players[0].ScriptedProcs = []*proto.ScriptedProc{{Name: "Proc"}}
+
+	aborted := masteryTestRequest(0)
+	aborted.SimOptions.Iterations = 10
+	abortedSignals := func() simsignals.Signals {
+		signals := simsignals.CreateSignals()
+		signals.Abort.Trigger()
+		return signals
+	}
+
+	results := map[string]*proto.RaidSimResult{
+		"invalid":            runSim(invalid, nil, true, simsignals.CreateSignals()),
+		"invalid concurrent": runSimConcurrent(invalid, nil, simsignals.CreateSignals()),
+		"aborted":            runSim(aborted, nil, true, abortedSignals()),
+		"aborted concurrent": runSimConcurrent(aborted, nil, abortedSignals()),
+	}
+	for name, result := range results {
+		if result.Error == nil {
+			t.Fatalf("%s: expected an error result", name)
+		}
+		if result.Metadata == nil || result.Metadata.SimVersion != SimVersion {
+			t.Fatalf("%s: expected the result to carry metadata, got %v", name, result.Metadata)
+		}
+	}
+
+	if results["aborted concurrent"].Metadata.RequestHash != HashRequest(aborted) {
+		t.Fatalf("Expected concurrent results to describe the original request")
+	}
+}
+
+func TestRunningSimKeepsRequestHash(t *testing.T) {
+	request := masteryTestRequest(0)
+	request.Raid.Buffs = &proto.RaidBuffs{}
+	request.Encounter.ExecuteProportion_35 = 0.35
+	hash := HashRequest(request)
+
+	runSim(request, nil, true, simsignals.CreateSignals())
+	if HashRequest(request) != hash || request.Raid.Buffs.ArcaneBrilliance || request.Encounter.ExecuteProportion_45 != 0 {
+		t.Fatalf("Expected running a sim to leave the request unchanged")
+	}
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"maps"
 	"math"
+	"slices"
 	"time"
 
 	"github.com/wowsims/mop/sim/core/proto"
@@ -58,7 +60,7 @@ func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 	mean, stdev := distMetrics.meanAndStdDev()
 
-	return &proto.DistributionMetrics{
+	protoMetrics := &proto.DistributionMetrics{
 		Avg:       mean,
 		Stdev:     stdev,
 		Max:       distMetrics.max,
@@ -73,6 +75,57 @@ func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 			SumSq: distMetrics.sumSq,
 		},
 	}
+	setPercentiles(protoMetrics)
+	return protoMetrics
+}
+
+// Sets the percentile fields, from the exact values when every iteration was
+// saved (SimOptions.save_all_values) and otherwise from the histogram, which
+// rounds each value to a multiple of 25. Also used after combining the results
+// of concurrent sims, as percentiles can't be combined directly.
+func setPercentiles(protoMetrics *proto.DistributionMetrics) {
+	var n int32
+	for _, count := range protoMetrics.Hist {
+		n += count
+	}
+	if n == 0 {
+		return
+	}
+
+	var valueAtRank func(rank int32) float64
+	if len(protoMetrics.AllValues) == int(n) {
+		values := slices.Sorted(slices.Values(protoMetrics.AllValues))
+		valueAtRank = func(rank int32) float64 {
+			return values[rank-1]
+		}
+	} else {
+		buckets := slices.Sorted(maps.Keys(protoMetrics.Hist))
+		valueAtRank = func(rank int32) float64 {
+			var seen int32
+			for _, bucket := range buckets {
+				seen += protoMetrics.Hist[bucket]
+				if seen >= rank {
+					return float64(bucket)
+				}
+			}
+			return float64(buckets[len(buckets)-1])
+		}
+	}
+
+	percentile := func(p float64) float64 {
+		return valueAtRank(int32(math.Ceil(p * float64(n))))
+	}
+
+	protoMetrics.P05 = percentile(0.05)
+	protoMetrics.P25 = percentile(0.25)
+	protoMetrics.P50 = percentile(0.5)
+	protoMetrics.P75 = percentile(0.75)
+	protoMetrics.P95 = percentile(0.95)
+}
+
+// Clears all aggregate values, so the metrics can be reused for another sim.
+func (distMetrics *DistributionMetrics) clearAggregates() {
+	*distMetrics = NewDistributionMetrics()
 }
 
 func NewDistributionMetrics() DistributionMetrics {
@@ -84,6 +137,7 @@ func NewDistributionMetrics() DistributionMetrics {
 
 type UnitMetrics struct {
 	dps    DistributionMetrics
+	ownDps DistributionMetrics // Like dps, but without damage from pets.
 	threat DistributionMetrics
 	dtps   DistributionMetrics
 	tmi    DistributionMetrics
@@ -97,10 +151,46 @@ type UnitMetrics struct {
 	CharacterIterationMetrics
 
 	// Aggregate values. These are updated after each iteration.
-	numItersDead int32
-	oomTimeSum   float64
+	numItersDead  int32
+	oomTimeSum    float64
+	activeTimeSum float64
+	aggroPullsSum int32
+	latencySum    float64
+	gcdConflicts  int32
+
+	healingReceivedSum     float64
+	overhealingReceivedSum float64
+	damageTakenSum         float64
+
 	actions      map[ActionID]*ActionMetrics
 	resources    []*ResourceMetrics
+	resourceCaps []*ResourceCapMetrics
+	runes        []*RuneMetrics
+	castFailures map[castFailureKey]int32
+
+	// Debuffs applied by this unit which were overwritten by other debuffs.
+	debuffOverwrites map[debuffOverwriteKey]int32
+
+	// Target switches made by each APL target selection policy.
+	targetSwitches map[proto.APLActionSelectTarget_Policy]int32
+
+	// Use times of each major cooldown of this unit.
+	cooldownUsages map[ActionID]*cooldownUsageMetrics
+
+	// Overlaps of stacking cooldowns with other DPS cooldowns.
+	cooldownOverlaps []*cooldownOverlapMetrics
+
+	// Estimated value of this unit's DoTs on each target besides its current one.
+	multiDotValues map[multiDotValueKey]*multiDotValueMetrics
+
+	// Time spent in the phases of this unit's execute phase trackers, by label.
+	executePhases map[string]*executePhaseMetrics
+
+	// Use of the rotation's phase lists, by name.
+	aplPhases map[string]*aplPhaseMetrics
+
+	// Rotation suggestions from analyzing the logged iteration.
+	suggestions []string
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -115,6 +205,19 @@ type CharacterIterationMetrics struct {
 	OOMTime time.Duration // time spent not casting and waiting for regen.
 
 	FirstOOMTimestamp time.Duration // Timestamp at which unit first went OOM.
+
+	ActiveTime  time.Duration // Time spent summoned, only tracked for pets.
+	activeSince time.Duration
+
+	AggroPulls int32 // # of times this unit pulled aggro from the aggro holder, when threat is modeled.
+
+	LatencyTime time.Duration // Time spent waiting on server latency, see Unit.Latency.
+
+	GCDConflicts int32 // # of times two systems acted on the GCD at once, see Unit.recordGCDConflict.
+
+	HealingReceived     float64 // Healing received which restored health.
+	OverhealingReceived float64 // Healing received while at maximum health.
+	DamageTaken         float64 // Health lost to damage.
 }
 
 type ActionMetrics struct {
@@ -122,6 +225,12 @@ type ActionMetrics struct {
 	IsPassive   bool // True if action is applied/cast as a result of another action
 	SpellSchool SpellSchool
 
+	// Name of the SpellDataset the action's tuning came from, if any.
+	SpellDataset string
+
+	// Actions whose damage was converted into this action's healing, if any.
+	ConvertedFrom []ActionID
+
 	// Metrics for this action, for each possible target.
 	Targets []TargetedActionMetrics
 }
@@ -138,11 +247,15 @@ func (actionMetrics *ActionMetrics) ToProto(actionID ActionID) *proto.ActionMetr
 	}
 
 	return &proto.ActionMetrics{
-		Id:          actionID.ToProto(),
-		IsMelee:     actionMetrics.IsMelee,
-		IsPassive:   actionMetrics.IsPassive,
-		Targets:     targetMetrics,
-		SpellSchool: int32(actionMetrics.SpellSchool),
+		Id:           actionID.ToProto(),
+		IsMelee:      actionMetrics.IsMelee,
+		IsPassive:    actionMetrics.IsPassive,
+		Targets:      targetMetrics,
+		SpellSchool:  int32(actionMetrics.SpellSchool),
+		SpellDataset: actionMetrics.SpellDataset,
+		ConvertedFrom: MapSlice(actionMetrics.ConvertedFrom, func(actionID ActionID) *proto.ActionID {
+			return actionID.ToProto()
+		}),
 	}
 }
 
@@ -175,6 +288,44 @@ type SpellMetrics struct {
 	TotalCritHealing       float64 // Healing done by all critical casts of this spell.
 	TotalShielding         float64 // Shielding done by all casts of this spell.
 	TotalCastTime          time.Duration
+	TotalImmuneDamage      float64 // Damage lost to immunity windows of the target.
+	TotalAbsorbedDamage    float64 // Damage absorbed by shields of the target.
+
+	// Direct (non-periodic) results, used to estimate the damage lost to avoidance.
+	DirectLanded            int32   // # of direct results which landed.
+	DirectAvoided           int32   // # of direct results which were missed, dodged or parried.
+	TotalDirectLandedDamage float64 // Damage done by all landed direct results.
+
+	AvoidanceStreak        int32 // Current # of consecutive avoided direct results.
+	LongestAvoidanceStreak int32 // Longest # of consecutive avoided direct results.
+
+	DotTickTime       time.Duration // Time covered by the ticks of this spell's DoT, i.e. the tick periods.
+	DotMaxTicks       float64       // Ticks possible with 100% DoT uptime, set at the end of the iteration.
+	DotClippedRefresh int32         // Refreshes of the active DoT which lost remaining duration.
+	DotClippedTime    time.Duration // Remaining DoT duration lost to refreshes.
+	DotPandemicTime   time.Duration // Remaining DoT duration carried over by refreshes.
+}
+
+// Records the outcome of a direct result, for avoidance streak tracking.
+func (spellMetrics *SpellMetrics) addDirectResult(result *SpellResult) {
+	if result.Outcome.Matches(OutcomeMiss | OutcomeDodge | OutcomeParry) {
+		spellMetrics.DirectAvoided++
+		spellMetrics.AvoidanceStreak++
+		spellMetrics.LongestAvoidanceStreak = max(spellMetrics.LongestAvoidanceStreak, spellMetrics.AvoidanceStreak)
+	} else if result.Landed() {
+		spellMetrics.DirectLanded++
+		spellMetrics.TotalDirectLandedDamage += result.Damage
+		spellMetrics.AvoidanceStreak = 0
+	}
+}
+
+// Estimate of the damage lost to avoided direct results, assuming they would
+// have done the average damage of the landed ones.
+func (spellMetrics *SpellMetrics) AvoidedDamage() float64 {
+	if spellMetrics.DirectLanded == 0 {
+		return 0
+	}
+	return float64(spellMetrics.DirectAvoided) * spellMetrics.TotalDirectLandedDamage / float64(spellMetrics.DirectLanded)
 }
 
 type TargetedActionMetrics struct {
@@ -206,6 +357,23 @@ type TargetedActionMetrics struct {
 	CritHealing       float64
 	Shielding         float64
 	CastTime          time.Duration
+
+	// Longest streak of avoided direct results in any iteration.
+	MaxAvoidanceStreak int32
+	// Sum over iterations of the longest avoidance streak in each iteration.
+	AvoidanceStreakSum int32
+	// Estimated damage lost to avoided direct results.
+	AvoidedDamage float64
+	// Damage lost to immunity windows of the target.
+	ImmuneDamage float64
+	// Damage absorbed by shields of the target.
+	AbsorbedDamage float64
+
+	DotUptime           time.Duration
+	DotMaxTicks         float64
+	DotClippedRefreshes int32
+	DotClippedTime      time.Duration
+	DotPandemicTime     time.Duration
 }
 
 func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
@@ -237,18 +405,39 @@ func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
 		CritHealing:       tam.CritHealing,
 		Shielding:         tam.Shielding,
 		CastTimeMs:        float64(tam.CastTime.Milliseconds()),
+
+		MaxAvoidanceStreak: tam.MaxAvoidanceStreak,
+		AvoidanceStreakSum: tam.AvoidanceStreakSum,
+		AvoidedDamage:      tam.AvoidedDamage,
+		ImmuneDamage:       tam.ImmuneDamage,
+		AbsorbedDamage:     tam.AbsorbedDamage,
+
+		DotUptimeSeconds:    tam.DotUptime.Seconds(),
+		DotMaxTicks:         tam.DotMaxTicks,
+		DotClippedRefreshes: tam.DotClippedRefreshes,
+		DotClippedSeconds:   tam.DotClippedTime.Seconds(),
+		DotPandemicSeconds:  tam.DotPandemicTime.Seconds(),
 	}
 }
 
 func NewUnitMetrics() UnitMetrics {
 	return UnitMetrics{
 		dps:     NewDistributionMetrics(),
+		ownDps:  NewDistributionMetrics(),
 		threat:  NewDistributionMetrics(),
 		dtps:    NewDistributionMetrics(),
 		tmi:     NewDistributionMetrics(),
 		hps:     NewDistributionMetrics(),
 		tto:     NewDistributionMetrics(),
 		actions: make(map[ActionID]*ActionMetrics),
+
+		castFailures:     make(map[castFailureKey]int32),
+		debuffOverwrites: make(map[debuffOverwriteKey]int32),
+		targetSwitches:   make(map[proto.APLActionSelectTarget_Policy]int32),
+		cooldownUsages:   make(map[ActionID]*cooldownUsageMetrics),
+		multiDotValues:   make(map[multiDotValueKey]*multiDotValueMetrics),
+		executePhases:    make(map[string]*executePhaseMetrics),
+		aplPhases:        make(map[string]*aplPhaseMetrics),
 	}
 }
 
@@ -292,6 +481,89 @@ func (resourceMetrics *ResourceMetrics) AddEvent(gain float64, actualGain float6
 	resourceMetrics.ActualGain += actualGain
 }
 
+// Tracks time spent at maximum resource, and how much resource generation was
+// lost to it, for a single resource type.
+type ResourceCapMetrics struct {
+	Type proto.ResourceType
+
+	// If set, capped time is converted into lost generation using this rate,
+	// for resources which stop regenerating rather than overflow (e.g. runes).
+	RegenPerSecond func() float64
+
+	// Metrics for the current iteration.
+	TimeCapped    time.Duration
+	LongestCapped time.Duration // Longest uninterrupted time spent capped.
+	Wasted        float64
+	cappedAt      time.Duration // NeverExpires when not capped.
+
+	// Aggregate values. These are updated after each iteration.
+	timeCappedSum float64
+	wastedSum     float64
+	n             int32
+}
+
+func (capMetrics *ResourceCapMetrics) reset() {
+	capMetrics.TimeCapped = 0
+	capMetrics.LongestCapped = 0
+	capMetrics.Wasted = 0
+	capMetrics.cappedAt = NeverExpires
+}
+
+// Should be called whenever the resource changes, with whether it is now at
+// maximum and how much of the change was lost to the cap.
+func (capMetrics *ResourceCapMetrics) Update(sim *Simulation, isCapped bool, wasted float64) {
+	now := max(sim.CurrentTime, 0)
+	if sim.CurrentTime >= 0 {
+		capMetrics.Wasted += wasted
+	}
+
+	if isCapped {
+		if capMetrics.cappedAt == NeverExpires {
+			capMetrics.cappedAt = now
+		}
+	} else if capMetrics.cappedAt != NeverExpires {
+		capMetrics.closeWindow(now)
+	}
+}
+
+func (capMetrics *ResourceCapMetrics) closeWindow(now time.Duration) {
+	window := now - capMetrics.cappedAt
+	capMetrics.TimeCapped += window
+	capMetrics.LongestCapped = max(capMetrics.LongestCapped, window)
+	if capMetrics.RegenPerSecond != nil {
+		capMetrics.Wasted += window.Seconds() * capMetrics.RegenPerSecond()
+	}
+	capMetrics.cappedAt = NeverExpires
+}
+
+// This should be called when a Sim iteration is complete.
+func (capMetrics *ResourceCapMetrics) doneIteration(sim *Simulation) {
+	if capMetrics.cappedAt != NeverExpires {
+		capMetrics.closeWindow(sim.CurrentTime)
+	}
+	capMetrics.timeCappedSum += capMetrics.TimeCapped.Seconds()
+	capMetrics.wastedSum += capMetrics.Wasted
+	capMetrics.n++
+}
+
+func (capMetrics *ResourceCapMetrics) ToProto() *proto.ResourceCapMetrics {
+	n := float64(max(capMetrics.n, 1))
+	return &proto.ResourceCapMetrics{
+		Type:             capMetrics.Type,
+		SecondsCappedAvg: capMetrics.timeCappedSum / n,
+		WastedAvg:        capMetrics.wastedSum / n,
+	}
+}
+
+func (unitMetrics *UnitMetrics) NewResourceCapMetrics(resourceType proto.ResourceType) *ResourceCapMetrics {
+	newMetrics := &ResourceCapMetrics{
+		Type:     resourceType,
+		cappedAt: NeverExpires,
+	}
+	unitMetrics.resourceCaps = append(unitMetrics.resourceCaps, newMetrics)
+	return newMetrics
+}
+
 func (unitMetrics *UnitMetrics) NewResourceMetrics(actionID ActionID, resourceType proto.ResourceType) *ResourceMetrics {
 	newMetrics := &ResourceMetrics{
 		ActionID: actionID,
@@ -354,12 +626,14 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 
 	if !ok {
 		actionMetrics = &ActionMetrics{
-			IsMelee:     spell.Flags.Matches(SpellFlagMeleeMetrics),
-			IsPassive:   spell.Flags.Matches(SpellFlagPassiveSpell),
-			SpellSchool: spell.SpellSchool,
+			IsMelee:      spell.Flags.Matches(SpellFlagMeleeMetrics),
+			IsPassive:    spell.Flags.Matches(SpellFlagPassiveSpell),
+			SpellSchool:  spell.SpellSchool,
+			SpellDataset: spell.spellDataset,
 		}
 		unitMetrics.actions[actionID] = actionMetrics
 	}
+	actionMetrics.ConvertedFrom = spell.convertedFrom
 
 	if len(actionMetrics.Targets) == 0 {
 		actionMetrics.Targets = make([]TargetedActionMetrics, len(spellMetrics))
@@ -400,12 +674,23 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
 			tam.CastTime += spellTargetMetrics.TotalCastTime
 		}
+		tam.MaxAvoidanceStreak = max(tam.MaxAvoidanceStreak, spellTargetMetrics.LongestAvoidanceStreak)
+		tam.AvoidanceStreakSum += spellTargetMetrics.LongestAvoidanceStreak
+		tam.AvoidedDamage += spellTargetMetrics.AvoidedDamage()
+		tam.ImmuneDamage += spellTargetMetrics.TotalImmuneDamage
+		tam.AbsorbedDamage += spellTargetMetrics.TotalAbsorbedDamage
+		tam.DotUptime += spellTargetMetrics.DotTickTime
+		tam.DotMaxTicks += spellTargetMetrics.DotMaxTicks
+		tam.DotClippedRefreshes += spellTargetMetrics.DotClippedRefresh
+		tam.DotClippedTime += spellTargetMetrics.DotClippedTime
+		tam.DotPandemicTime += spellTargetMetrics.DotPandemicTime
 
 		target := spell.Unit.AttackTables[i].Defender
 		target.Metrics.dtps.Total += spellTargetMetrics.TotalDamage
 
 		if spell.Unit.IsOpponent(target) {
 			unitMetrics.dps.Total += spellTargetMetrics.TotalDamage
+			unitMetrics.ownDps.Total += spellTargetMetrics.TotalDamage
 			unitMetrics.threat.Total += spellTargetMetrics.TotalThreat
 		} else {
 			unitMetrics.hps.Total += spellTargetMetrics.TotalHealing + spellTargetMetrics.TotalShielding
@@ -420,6 +705,15 @@ func (unitMetrics *UnitMetrics) AddFinalPetMetrics(petMetrics *UnitMetrics) {
 	unitMetrics.dps.Total += petMetrics.dps.Total
 }
 
+// Marks the start of a window in which a unit that can be summoned mid-fight is
+// active, e.g. a pet.
+func (unitMetrics *UnitMetrics) markActive(sim *Simulation) {
+	unitMetrics.activeSince = max(sim.CurrentTime, 0)
+}
+func (unitMetrics *UnitMetrics) markInactive(sim *Simulation) {
+	unitMetrics.ActiveTime += max(sim.CurrentTime, 0) - unitMetrics.activeSince
+}
+
 func (unitMetrics *UnitMetrics) AddOOMTime(sim *Simulation, dur time.Duration) {
 	if dur > 0 {
 		unitMetrics.CharacterIterationMetrics.OOMTime += dur
@@ -438,6 +732,7 @@ func (unitMetrics *UnitMetrics) IsTanking() bool {
 
 func (unitMetrics *UnitMetrics) reset() {
 	unitMetrics.dps.reset()
+	unitMetrics.ownDps.reset()
 	unitMetrics.threat.reset()
 	unitMetrics.dtps.reset()
 	unitMetrics.tmi.reset()
@@ -449,6 +744,67 @@ func (unitMetrics *UnitMetrics) reset() {
 	for _, resourceMetrics := range unitMetrics.resources {
 		resourceMetrics.reset()
 	}
+	for _, capMetrics := range unitMetrics.resourceCaps {
+		capMetrics.reset()
+	}
+	for _, runeMetrics := range unitMetrics.runes {
+		runeMetrics.reset()
+	}
+	for _, usages := range unitMetrics.cooldownUsages {
+		usages.reset()
+	}
+}
+
+// Clears all aggregate values, so the metrics can be reused for another sim.
+// Resource metrics are cleared in place, as spells and resource bars hold on to them.
+func (unitMetrics *UnitMetrics) clearAggregates() {
+	unitMetrics.dps.clearAggregates()
+	unitMetrics.ownDps.clearAggregates()
+	unitMetrics.threat.clearAggregates()
+	unitMetrics.dtps.clearAggregates()
+	unitMetrics.tmi.clearAggregates()
+	unitMetrics.hps.clearAggregates()
+	unitMetrics.tto.clearAggregates()
+
+	unitMetrics.numItersDead = 0
+	unitMetrics.oomTimeSum = 0
+	unitMetrics.activeTimeSum = 0
+	unitMetrics.aggroPullsSum = 0
+	unitMetrics.latencySum = 0
+	unitMetrics.gcdConflicts = 0
+	unitMetrics.healingReceivedSum = 0
+	unitMetrics.overhealingReceivedSum = 0
+	unitMetrics.damageTakenSum = 0
+	clear(unitMetrics.actions)
+	clear(unitMetrics.castFailures)
+	clear(unitMetrics.debuffOverwrites)
+	clear(unitMetrics.targetSwitches)
+	clear(unitMetrics.cooldownUsages)
+	clear(unitMetrics.multiDotValues)
+	clear(unitMetrics.executePhases)
+	clear(unitMetrics.aplPhases)
+	unitMetrics.suggestions = nil
+
+	for _, resourceMetrics := range unitMetrics.resources {
+		*resourceMetrics = ResourceMetrics{
+			ActionID: resourceMetrics.ActionID,
+			Type:     resourceMetrics.Type,
+		}
+	}
+	for _, overlap := range unitMetrics.cooldownOverlaps {
+		overlap.reset()
+	}
+	for _, capMetrics := range unitMetrics.resourceCaps {
+		capMetrics.timeCappedSum = 0
+		capMetrics.wastedSum = 0
+		capMetrics.n = 0
+	}
+	for _, runeMetrics := range unitMetrics.runes {
+		runeMetrics.timeDepletedSum = 0
+		runeMetrics.regenTimeSum = 0
+		runeMetrics.regensSum = 0
+		runeMetrics.n = 0
+	}
 }
 
 // This should be called when a Sim iteration is complete.
@@ -487,13 +843,34 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	}
 
 	unitMetrics.dps.doneIteration(sim)
+	unitMetrics.ownDps.doneIteration(sim)
 	unitMetrics.threat.doneIteration(sim)
 	unitMetrics.dtps.doneIteration(sim)
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
 
+	for _, capMetrics := range unitMetrics.resourceCaps {
+		capMetrics.doneIteration(sim)
+	}
+	for _, runeMetrics := range unitMetrics.runes {
+		runeMetrics.doneIteration(sim)
+	}
+	for _, usages := range unitMetrics.cooldownUsages {
+		usages.doneIteration()
+	}
+	for _, overlap := range unitMetrics.cooldownOverlaps {
+		overlap.doneIteration()
+	}
+
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
+	unitMetrics.activeTimeSum += unitMetrics.ActiveTime.Seconds()
+	unitMetrics.aggroPullsSum += unitMetrics.AggroPulls
+	unitMetrics.latencySum += unitMetrics.LatencyTime.Seconds()
+	unitMetrics.gcdConflicts += unitMetrics.GCDConflicts
+	unitMetrics.healingReceivedSum += unitMetrics.HealingReceived
+	unitMetrics.overhealingReceivedSum += unitMetrics.OverhealingReceived
+	unitMetrics.damageTakenSum += unitMetrics.DamageTaken
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
@@ -575,6 +952,7 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 	n := float64(unitMetrics.dps.n)
 	protoMetrics := &proto.UnitMetrics{
 		Dps:           unitMetrics.dps.ToProto(),
+		OwnDps:        unitMetrics.ownDps.ToProto(),
 		Threat:        unitMetrics.threat.ToProto(),
 		Dtps:          unitMetrics.dtps.ToProto(),
 		Tmi:           unitMetrics.tmi.ToProto(),
@@ -582,8 +960,20 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+
+		ActiveSecondsAvg: unitMetrics.activeTimeSum / n,
+		AggroPullsAvg:    float64(unitMetrics.aggroPullsSum) / n,
+
+		LatencySecondsAvg: unitMetrics.latencySum / n,
+		GcdConflictsAvg:   float64(unitMetrics.gcdConflicts) / n,
+
+		HealingReceivedAvg:     unitMetrics.healingReceivedSum / n,
+		OverhealingReceivedAvg: unitMetrics.overhealingReceivedSum / n,
+		DamageTakenAvg:         unitMetrics.damageTakenSum / n,
 	}
 
+	protoMetrics.SurvivabilityScore = survivabilityScore(protoMetrics)
+
 	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(unitMetrics.actions))
 	for actionID, action := range unitMetrics.actions {
 		protoMetrics.Actions = append(protoMetrics.Actions, action.ToProto(actionID))
@@ -596,9 +986,84 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		}
 	}
 
+	protoMetrics.ResourceCaps = make([]*proto.ResourceCapMetrics, 0, len(unitMetrics.resourceCaps))
+	for _, capMetrics := range unitMetrics.resourceCaps {
+		protoMetrics.ResourceCaps = append(protoMetrics.ResourceCaps, capMetrics.ToProto())
+	}
+
+	for _, runeMetrics := range unitMetrics.runes {
+		protoMetrics.Runes = append(protoMetrics.Runes, runeMetrics.ToProto())
+	}
+
+	protoMetrics.CastFailures = make([]*proto.CastFailureMetrics, 0, len(unitMetrics.castFailures))
+	for _, key := range slices.SortedFunc(maps.Keys(unitMetrics.castFailures), castFailureKey.compare) {
+		failures := unitMetrics.castFailures[key]
+		protoMetrics.CastFailures = append(protoMetrics.CastFailures, &proto.CastFailureMetrics{
+			Id:          key.ActionID.ToProto(),
+			Reason:      key.Reason,
+			FailuresAvg: float64(failures) / n,
+		})
+	}
+
+	protoMetrics.DebuffOverwrites = make([]*proto.DebuffOverwriteMetrics, 0, len(unitMetrics.debuffOverwrites))
+	for _, key := range slices.SortedFunc(maps.Keys(unitMetrics.debuffOverwrites), debuffOverwriteKey.compare) {
+		overwrites := unitMetrics.debuffOverwrites[key]
+		protoMetrics.DebuffOverwrites = append(protoMetrics.DebuffOverwrites, &proto.DebuffOverwriteMetrics{
+			Id:            key.ActionID.ToProto(),
+			Reason:        key.Reason,
+			OverwritesAvg: float64(overwrites) / n,
+		})
+	}
+
+	protoMetrics.TargetSwitches = make([]*proto.TargetSwitchMetrics, 0, len(unitMetrics.targetSwitches))
+	for _, policy := range slices.Sorted(maps.Keys(unitMetrics.targetSwitches)) {
+		switches := unitMetrics.targetSwitches[policy]
+		protoMetrics.TargetSwitches = append(protoMetrics.TargetSwitches, &proto.TargetSwitchMetrics{
+			Policy:      policy,
+			SwitchesAvg: float64(switches) / n,
+		})
+	}
+
+	protoMetrics.CooldownUsages = make([]*proto.CooldownUsageMetrics, 0, len(unitMetrics.cooldownUsages))
+	for _, actionID := range slices.SortedFunc(maps.Keys(unitMetrics.cooldownUsages), ActionID.Compare) {
+		protoMetrics.CooldownUsages = append(protoMetrics.CooldownUsages, unitMetrics.cooldownUsages[actionID].ToProto(actionID))
+	}
+
+	for _, overlap := range unitMetrics.cooldownOverlaps {
+		protoMetrics.CooldownOverlaps = append(protoMetrics.CooldownOverlaps, overlap.ToProto())
+	}
+	protoMetrics.CooldownWarnings = cooldownOverlapWarnings(protoMetrics.CooldownOverlaps)
+
+	protoMetrics.MultiDotValues = make([]*proto.MultiDotValueMetrics, 0, len(unitMetrics.multiDotValues))
+	for _, key := range slices.SortedFunc(maps.Keys(unitMetrics.multiDotValues), multiDotValueKey.compare) {
+		protoMetrics.MultiDotValues = append(protoMetrics.MultiDotValues, unitMetrics.multiDotValues[key].ToProto(key))
+	}
+
+	protoMetrics.ExecutePhases = make([]*proto.ExecutePhaseMetrics, 0, len(unitMetrics.executePhases))
+	for _, label := range slices.Sorted(maps.Keys(unitMetrics.executePhases)) {
+		protoMetrics.ExecutePhases = append(protoMetrics.ExecutePhases, unitMetrics.executePhases[label].ToProto(label, n))
+	}
+
+	protoMetrics.AplPhases = make([]*proto.APLPhaseMetrics, 0, len(unitMetrics.aplPhases))
+	for _, name := range slices.Sorted(maps.Keys(unitMetrics.aplPhases)) {
+		protoMetrics.AplPhases = append(protoMetrics.AplPhases, unitMetrics.aplPhases[name].ToProto(name, n))
+	}
+
+	protoMetrics.Suggestions = unitMetrics.suggestions
+
 	return protoMetrics
 }
 
+// Computed from the averages rather than averaged itself, so results combined
+// across threads score the same as a single threaded run.
+func survivabilityScore(metrics *proto.UnitMetrics) float64 {
+	if metrics.DamageTakenAvg <= 0 {
+		return 0
+	}
+	healedShare := min(metrics.HealingReceivedAvg/metrics.DamageTakenAvg, 1)
+	return healedShare * (1 - metrics.ChanceOfDeath)
+}
+
 type AuraMetrics struct {
 	ID ActionID
 
@@ -606,35 +1071,175 @@ type AuraMetrics struct {
 	Uptime time.Duration
 	Procs  int32
 
+	// Time the aura was active while a stronger effect of its exclusive
+	// category was applied instead.
+	Overshadowed time.Duration
+
+	IcdBlocked     int32   // Proc attempts blocked by the internal cooldown.
+	IcdWastedProcs float64 // Sum of the proc chances of the blocked attempts.
+	ChainedProcs   int32   // Procs caused by a spell which is itself a proc.
+	AplCancels     int32   // Times the aura was cancelled by the APL.
+
+	lastProcAt    time.Duration
+	procInterval  time.Duration // Sum of the times between two procs.
+	procIntervals int32
+
 	// Aggregate values. These are updated after each iteration.
 	aggregator
-	procsSum int32
+	procsSum          int32
+	overshadowedSum   float64
+	icdBlockedSum     int32
+	icdWastedProcsSum float64
+	chainedProcsSum   int32
+	aplCancelsSum     int32
+	procIntervalSum   time.Duration
+	procIntervalsSum  int32
+
+	// Totals across all iterations, keyed by the spell which applied the aura.
+	sources map[ActionID]*auraSourceCounts
+}
+
+type auraSourceCounts struct {
+	// Counts for the current iteration.
+	applications int32
+	refreshes    int32
+
+	Applications int32
+	Refreshes    int32
+}
+
+func (auraMetrics *AuraMetrics) addSource(source *Spell, isRefresh bool) {
+	if source == nil || auraMetrics.ID.IsEmptyAction() {
+		return
+	}
+
+	if auraMetrics.sources == nil {
+		auraMetrics.sources = make(map[ActionID]*auraSourceCounts)
+	}
+	counts, ok := auraMetrics.sources[source.ActionID]
+	if !ok {
+		counts = &auraSourceCounts{}
+		auraMetrics.sources[source.ActionID] = counts
+	}
+
+	if isRefresh {
+		counts.refreshes++
+	} else {
+		counts.applications++
+	}
+}
+
+// Records an activation of the aura by source, which may be nil.
+func (auraMetrics *AuraMetrics) addProc(sim *Simulation, source *Spell) {
+	if auraMetrics.Procs > 0 {
+		auraMetrics.procInterval += sim.CurrentTime - auraMetrics.lastProcAt
+		auraMetrics.procIntervals++
+	}
+	auraMetrics.Procs++
+	auraMetrics.lastProcAt = sim.CurrentTime
+
+	if source != nil && source.ProcMask.Matches(ProcMaskProc) {
+		auraMetrics.ChainedProcs++
+	}
+}
+
+// Records a proc attempt with the given chance which was blocked by the ICD.
+func (auraMetrics *AuraMetrics) addIcdBlocked(chance float64) {
+	auraMetrics.IcdBlocked++
+	auraMetrics.IcdWastedProcs += chance
 }
 
 func (auraMetrics *AuraMetrics) reset() {
 	auraMetrics.Uptime = 0
 	auraMetrics.Procs = 0
+	auraMetrics.Overshadowed = 0
+	auraMetrics.IcdBlocked = 0
+	auraMetrics.IcdWastedProcs = 0
+	auraMetrics.ChainedProcs = 0
+	auraMetrics.AplCancels = 0
+	auraMetrics.procInterval = 0
+	auraMetrics.procIntervals = 0
+	for _, counts := range auraMetrics.sources {
+		counts.applications = 0
+		counts.refreshes = 0
+	}
+}
+
+// Clears all aggregate values, so the metrics can be reused for another sim.
+func (auraMetrics *AuraMetrics) clearAggregates() {
+	auraMetrics.aggregator = aggregator{}
+	auraMetrics.procsSum = 0
+	auraMetrics.overshadowedSum = 0
+	auraMetrics.icdBlockedSum = 0
+	auraMetrics.icdWastedProcsSum = 0
+	auraMetrics.chainedProcsSum = 0
+	auraMetrics.aplCancelsSum = 0
+	auraMetrics.procIntervalSum = 0
+	auraMetrics.procIntervalsSum = 0
+	auraMetrics.sources = nil
 }
 
 // This should be called when a Sim iteration is complete.
 func (auraMetrics *AuraMetrics) doneIteration() {
 	auraMetrics.add(auraMetrics.Uptime.Seconds())
 	auraMetrics.procsSum += auraMetrics.Procs
+	auraMetrics.overshadowedSum += auraMetrics.Overshadowed.Seconds()
+	auraMetrics.icdBlockedSum += auraMetrics.IcdBlocked
+	auraMetrics.icdWastedProcsSum += auraMetrics.IcdWastedProcs
+	auraMetrics.chainedProcsSum += auraMetrics.ChainedProcs
+	auraMetrics.aplCancelsSum += auraMetrics.AplCancels
+	auraMetrics.procIntervalSum += auraMetrics.procInterval
+	auraMetrics.procIntervalsSum += auraMetrics.procIntervals
+	for _, counts := range auraMetrics.sources {
+		counts.Applications += counts.applications
+		counts.Refreshes += counts.refreshes
+	}
 }
 
 func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
 	mean, stdev := auraMetrics.meanAndStdDev()
 
+	n := float64(auraMetrics.n)
+
+	procIntervalAvg := 0.0
+	if auraMetrics.procIntervalsSum > 0 {
+		procIntervalAvg = auraMetrics.procIntervalSum.Seconds() / float64(auraMetrics.procIntervalsSum)
+	}
+
+	sources := make([]*proto.AuraSourceMetrics, 0, len(auraMetrics.sources))
+	for _, actionID := range slices.SortedFunc(maps.Keys(auraMetrics.sources), ActionID.Compare) {
+		counts := auraMetrics.sources[actionID]
+		if counts.Applications == 0 && counts.Refreshes == 0 {
+			continue
+		}
+		sources = append(sources, &proto.AuraSourceMetrics{
+			Id:              actionID.ToProto(),
+			ApplicationsAvg: float64(counts.Applications) / n,
+			RefreshesAvg:    float64(counts.Refreshes) / n,
+		})
+	}
+
 	return &proto.AuraMetrics{
 		Id: auraMetrics.ID.ToProto(),
 
 		UptimeSecondsAvg:   mean,
 		UptimeSecondsStdev: stdev,
-		ProcsAvg:           float64(auraMetrics.procsSum) / float64(auraMetrics.n),
+		ProcsAvg:           float64(auraMetrics.procsSum) / n,
+
+		OvershadowedSecondsAvg: auraMetrics.overshadowedSum / n,
+
+		IcdBlockedAvg:          float64(auraMetrics.icdBlockedSum) / n,
+		IcdWastedProcsAvg:      auraMetrics.icdWastedProcsSum / n,
+		ProcIntervalSecondsAvg: procIntervalAvg,
+		ProcIntervals:          auraMetrics.procIntervalsSum,
+		ChainedProcsAvg:        float64(auraMetrics.chainedProcsSum) / n,
+		AplCancelsAvg:          float64(auraMetrics.aplCancelsSum) / n,
 
 		AggregatorData: &proto.AggregatorData{
 			N:     int32(auraMetrics.n),
 			SumSq: auraMetrics.sumSq,
 		},
+
+		Sources: sources,
 	}
 }
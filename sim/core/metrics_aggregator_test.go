@@ -0,0 +1,367 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestAvoidanceStreak(t *testing.T) {
+	var spellMetrics SpellMetrics
+	for _, outcome := range []HitOutcome{OutcomeHit, OutcomeMiss, OutcomeDodge, OutcomeCrit, OutcomeParry, OutcomeMiss, OutcomeMiss, OutcomeHit} {
+		spellMetrics.addDirectResult(&SpellResult{Outcome: outcome, Damage: 100})
+	}
+
+	if spellMetrics.LongestAvoidanceStreak != 3 {
+		t.Fatalf("Expected longest streak 3, got %d", spellMetrics.LongestAvoidanceStreak)
+	}
+	if spellMetrics.AvoidanceStreak != 0 {
+		t.Fatalf("Expected streak to reset on a landed hit, got %d", spellMetrics.AvoidanceStreak)
+	}
+	if avoided := spellMetrics.AvoidedDamage(); avoided != 500 {
+		t.Fatalf("Expected 500 avoided damage, got %0.1f", avoided)
+	}
+}
+
+func TestCooldownUsages(t *testing.T) {
+	sim := &Simulation{}
+	unitMetrics := NewUnitMetrics()
+	actionID := ActionID{SpellID: 12345}
+
+	// Uses before the first iteration, e.g. in a fake prepull, are dropped.
+	sim.CurrentTime = -time.Second
+	unitMetrics.addCooldownUsage(sim, actionID)
+	unitMetrics.reset()
+
+	// First iteration without uses, then two uses.
+	unitMetrics.dps.add(0)
+	unitMetrics.cooldownUsages[actionID].doneIteration()
+	sim.CurrentTime = time.Second * 2
+	unitMetrics.addCooldownUsage(sim, actionID)
+	sim.CurrentTime = time.Second * 182
+	unitMetrics.addCooldownUsage(sim, actionID)
+	unitMetrics.cooldownUsages[actionID].doneIteration()
+
+	protoMetrics := unitMetrics.cooldownUsages[actionID].ToProto(actionID)
+	if !slices.Equal(protoMetrics.IterationUsages, []int32{0, 2}) {
+		t.Fatalf("Expected uses per iteration [0 2], got %v", protoMetrics.IterationUsages)
+	}
+	if !slices.Equal(protoMetrics.UsageTimes, []float32{2, 182}) {
+		t.Fatalf("Expected use times [2 182], got %v", protoMetrics.UsageTimes)
+	}
+	if len(protoMetrics.UsageHistograms) != 2 || protoMetrics.UsageHistograms[1].Hist[182] != 1 {
+		t.Fatalf("Expected a histogram for the second use with 1 use at 182s")
+	}
+
+	otherActionIDs := []ActionID{{SpellID: 300}, {ItemID: 77}, {SpellID: 20}}
+	for _, otherActionID := range otherActionIDs {
+		unitMetrics.addCooldownUsage(sim, otherActionID)
+	}
+	// Map iteration order is random, so check the output order repeatedly.
+	for range 10 {
+		var usageIDs []int32
+		for _, usages := range unitMetrics.ToProto().CooldownUsages {
+			usageIDs = append(usageIDs, usages.Id.GetSpellId()+usages.Id.GetItemId())
+		}
+		if !slices.Equal(usageIDs, []int32{77, 20, 300, 12345}) {
+			t.Fatalf("Expected cooldown usages sorted by action ID, got %v", usageIDs)
+		}
+	}
+}
+
+func TestCombineCooldownUsagesAreSorted(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	base := rsrc.newUnitMetrics(&proto.UnitMetrics{})
+	newMetrics := func(spellID int32) *proto.UnitMetrics {
+		metrics := rsrc.newUnitMetrics(&proto.UnitMetrics{})
+		metrics.Dps.AggregatorData.N = 1
+		metrics.CooldownUsages = []*proto.CooldownUsageMetrics{{Id: ActionID{SpellID: spellID}.ToProto(), IterationUsages: []int32{1}}}
+		return metrics
+	}
+	rsrc.combineUnitMetrics(base, newMetrics(20), false, 0.5)
+	rsrc.combineUnitMetrics(base, newMetrics(10), true, 0.5)
+
+	if len(base.CooldownUsages) != 2 || base.CooldownUsages[0].Id.GetSpellId() != 10 {
+		t.Fatalf("Expected the combined cooldown usages to be sorted, got %v", base.CooldownUsages)
+	}
+	if !slices.Equal(base.CooldownUsages[0].IterationUsages, []int32{0, 1}) {
+		t.Fatalf("Expected no uses of the second cooldown in the first sim, got %v", base.CooldownUsages[0].IterationUsages)
+	}
+}
+
+func TestCooldownDrift(t *testing.T) {
+	sim := &Simulation{}
+	unitMetrics := NewUnitMetrics()
+	actionID := ActionID{SpellID: 5217}
+
+	// Prepull uses and uses on time don't drift.
+	sim.CurrentTime = -time.Second
+	unitMetrics.addCooldownDrift(sim, actionID, startingCDTime, time.Second*30)
+	sim.CurrentTime = time.Second * 30
+	unitMetrics.addCooldownDrift(sim, actionID, time.Second*30, time.Second*30)
+
+	// Held for 3s, then 6s.
+	sim.CurrentTime = time.Second * 63
+	unitMetrics.addCooldownDrift(sim, actionID, time.Second*60, time.Second*30)
+	sim.CurrentTime = time.Second * 99
+	unitMetrics.addCooldownDrift(sim, actionID, time.Second*93, time.Second*30)
+	unitMetrics.dps.add(0)
+	unitMetrics.cooldownUsages[actionID].doneIteration()
+
+	protoMetrics := unitMetrics.cooldownUsages[actionID].ToProto(actionID)
+	if protoMetrics.DriftSecondsAvg != 9 {
+		t.Fatalf("Expected 9s of drift, got %0.2f", protoMetrics.DriftSecondsAvg)
+	}
+	if !WithinToleranceFloat64(0.3, protoMetrics.LostUsesAvg, 0.0001) {
+		t.Fatalf("Expected 0.3 lost uses, got %0.4f", protoMetrics.LostUsesAvg)
+	}
+}
+
+func TestResourceCapMetrics(t *testing.T) {
+	sim := &Simulation{}
+	unitMetrics := NewUnitMetrics()
+	capMetrics := unitMetrics.NewResourceCapMetrics(proto.ResourceType_ResourceTypeRage)
+	capMetrics.reset()
+
+	// Overflow before the pull is ignored, but the capped time starts at 0.
+	sim.CurrentTime = -time.Second
+	capMetrics.Update(sim, true, 10)
+
+	// Capped for 5s, then 3s, with 25 generation lost.
+	sim.CurrentTime = time.Second * 2
+	capMetrics.Update(sim, true, 15)
+	sim.CurrentTime = time.Second * 5
+	capMetrics.Update(sim, false, 0)
+	sim.CurrentTime = time.Second * 10
+	capMetrics.Update(sim, true, 10)
+	capMetrics.Update(sim, true, 0)
+	sim.CurrentTime = time.Second * 13
+	capMetrics.Update(sim, false, 0)
+
+	if capMetrics.TimeCapped != time.Second*8 || capMetrics.LongestCapped != time.Second*5 || capMetrics.Wasted != 25 {
+		t.Fatalf("Expected 8s capped, at most 5s at once, with 25 wasted, got %s, %s and %0.1f",
+			capMetrics.TimeCapped, capMetrics.LongestCapped, capMetrics.Wasted)
+	}
+
+	// A window still open at the end of the iteration counts until then.
+	sim.CurrentTime = time.Second * 18
+	capMetrics.Update(sim, true, 0)
+	sim.CurrentTime = time.Second * 20
+	capMetrics.doneIteration(sim)
+
+	// Resources which stop regenerating waste their regeneration rate while capped.
+	capMetrics.reset()
+	capMetrics.RegenPerSecond = func() float64 { return 2 }
+	sim.CurrentTime = 0
+	capMetrics.Update(sim, true, 0)
+	sim.CurrentTime = time.Second * 4
+	capMetrics.Update(sim, false, 0)
+	capMetrics.doneIteration(sim)
+	if capMetrics.Wasted != 8 {
+		t.Fatalf("Expected 8 regeneration lost to the cap, got %0.1f", capMetrics.Wasted)
+	}
+
+	protoMetrics := capMetrics.ToProto()
+	if protoMetrics.SecondsCappedAvg != 7 || protoMetrics.WastedAvg != 16.5 {
+		t.Fatalf("Expected 7s capped and 16.5 wasted on average, got %0.2f and %0.2f", protoMetrics.SecondsCappedAvg, protoMetrics.WastedAvg)
+	}
+}
+
+func TestCombineResourceCapMetrics(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	unit := &proto.UnitMetrics{}
+	rsrc.addResourceCapMetrics(unit, &proto.ResourceCapMetrics{Type: proto.ResourceType_ResourceTypeRage, SecondsCappedAvg: 10, WastedAvg: 40}, 0.5)
+	rsrc.addResourceCapMetrics(unit, &proto.ResourceCapMetrics{Type: proto.ResourceType_ResourceTypeFocus, SecondsCappedAvg: 4, WastedAvg: 12}, 0.5)
+	rsrc.addResourceCapMetrics(unit, &proto.ResourceCapMetrics{Type: proto.ResourceType_ResourceTypeRage, SecondsCappedAvg: 20, WastedAvg: 60}, 0.5)
+
+	if len(unit.ResourceCaps) != 2 {
+		t.Fatalf("Expected one entry per resource type, got %v", unit.ResourceCaps)
+	}
+	if rage := unit.ResourceCaps[0]; rage.SecondsCappedAvg != 15 || rage.WastedAvg != 50 {
+		t.Fatalf("Expected the rage cap metrics to be averaged, got %v", rage)
+	}
+}
+
+func TestAuraProcStatistics(t *testing.T) {
+	sim := &Simulation{}
+	unit := newUnitWithAuras(0)
+	buff := unit.RegisterAura(Aura{Label: "Buff", ActionID: ActionID{SpellID: 1}, Duration: time.Second * 10})
+	trigger := unit.RegisterAura(Aura{Label: "Trigger", Duration: NeverExpires})
+	trigger.SetIcdMetricsAura(buff)
+
+	enchantProc := &Spell{ProcMask: ProcMaskSpellProc}
+	sim.CurrentTime = time.Second * 5
+	buff.metrics.addProc(sim, nil)
+	trigger.icdMetrics().addIcdBlocked(0.2)
+	trigger.icdMetrics().addIcdBlocked(0.2)
+	sim.CurrentTime = time.Second * 50
+	buff.metrics.addProc(sim, enchantProc)
+	sim.CurrentTime = time.Second * 110
+	buff.metrics.addProc(sim, nil)
+
+	buff.metrics.doneIteration()
+	protoMetrics := buff.metrics.ToProto()
+	if protoMetrics.IcdBlockedAvg != 2 || !WithinToleranceFloat64(0.4, protoMetrics.IcdWastedProcsAvg, 0.0001) {
+		t.Fatalf("Expected 2 blocked attempts worth 0.4 procs, got %0.1f worth %0.2f", protoMetrics.IcdBlockedAvg, protoMetrics.IcdWastedProcsAvg)
+	}
+	if protoMetrics.ProcIntervalSecondsAvg != 52.5 {
+		t.Fatalf("Expected 52.5s between procs, got %0.2f", protoMetrics.ProcIntervalSecondsAvg)
+	}
+	if protoMetrics.ChainedProcsAvg != 1 {
+		t.Fatalf("Expected 1 chained proc, got %0.1f", protoMetrics.ChainedProcsAvg)
+	}
+}
+
+func TestCombineAuraProcIntervals(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	base := &proto.AuraMetrics{AggregatorData: &proto.AggregatorData{}}
+	rsrc.combineAuraMetrics(base, &proto.AuraMetrics{ProcIntervalSecondsAvg: 10, ProcIntervals: 3, AggregatorData: &proto.AggregatorData{}}, 0.5, false)
+	rsrc.combineAuraMetrics(base, &proto.AuraMetrics{AggregatorData: &proto.AggregatorData{}}, 0.25, false)
+	rsrc.combineAuraMetrics(base, &proto.AuraMetrics{ProcIntervalSecondsAvg: 30, ProcIntervals: 1, AggregatorData: &proto.AggregatorData{}}, 0.25, true)
+
+	// Averaged over the 4 intervals, sims without any are ignored.
+	if base.ProcIntervals != 4 || base.ProcIntervalSecondsAvg != 15 {
+		t.Fatalf("Expected 4 intervals of 15s on average, got %d of %0.2fs", base.ProcIntervals, base.ProcIntervalSecondsAvg)
+	}
+}
+
+func TestAuraSourcesAreSorted(t *testing.T) {
+	unit := newUnitWithAuras(0)
+	buff := unit.RegisterAura(Aura{Label: "Buff", ActionID: ActionID{SpellID: 1}, Duration: time.Second * 10})
+
+	for _, actionID := range []ActionID{{SpellID: 30}, {ItemID: 5}, {SpellID: 10, Tag: 2}, {SpellID: 10, Tag: 1}} {
+		buff.metrics.addSource(&Spell{ActionID: actionID}, false)
+	}
+	buff.metrics.doneIteration()
+
+	var sourceIDs []string
+	for _, source := range buff.metrics.ToProto().Sources {
+		sourceIDs = append(sourceIDs, ProtoToActionID(source.Id).String())
+	}
+	expected := []string{"{ItemID: 5}", "{SpellID: 10, Tag: 1}", "{SpellID: 10, Tag: 2}", "{SpellID: 30}"}
+	if !slices.Equal(sourceIDs, expected) {
+		t.Fatalf("Expected sources %v, got %v", expected, sourceIDs)
+	}
+}
+
+func TestAuraSourcesOnlyCountIterations(t *testing.T) {
+	unit := newUnitWithAuras(0)
+	buff := unit.RegisterAura(Aura{Label: "Buff", ActionID: ActionID{SpellID: 1}, Duration: time.Second * 10})
+
+	// Applications outside an iteration, e.g. in a fake prepull, are dropped on reset.
+	buff.metrics.addSource(&Spell{ActionID: ActionID{SpellID: 10}}, false)
+	buff.metrics.reset()
+	buff.metrics.addSource(&Spell{ActionID: ActionID{SpellID: 20}}, true)
+	buff.metrics.doneIteration()
+
+	sources := buff.metrics.ToProto().Sources
+	if len(sources) != 1 || sources[0].Id.GetSpellId() != 20 || sources[0].RefreshesAvg != 1 {
+		t.Fatalf("Expected a single refresh by spell 20, got %v", sources)
+	}
+}
+
+func TestCombineAuraSourcesAreSorted(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	base := &proto.AuraMetrics{AggregatorData: &proto.AggregatorData{}}
+	newMetrics := func(spellIDs ...int32) *proto.AuraMetrics {
+		metrics := &proto.AuraMetrics{AggregatorData: &proto.AggregatorData{}}
+		for _, spellID := range spellIDs {
+			metrics.Sources = append(metrics.Sources, &proto.AuraSourceMetrics{Id: ActionID{SpellID: spellID}.ToProto(), ApplicationsAvg: 2})
+		}
+		return metrics
+	}
+	rsrc.combineAuraMetrics(base, newMetrics(20, 30), 0.5, false)
+	rsrc.combineAuraMetrics(base, newMetrics(10, 30), 0.5, true)
+
+	var spellIDs []int32
+	for _, source := range base.Sources {
+		spellIDs = append(spellIDs, source.Id.GetSpellId())
+	}
+	if !slices.Equal(spellIDs, []int32{10, 20, 30}) || base.Sources[2].ApplicationsAvg != 2 {
+		t.Fatalf("Expected the combined sources to be sorted, got %v", base.Sources)
+	}
+}
+
+func TestHealingReceived(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Metrics: NewUnitMetrics()}
+	unit.stats[stats.Health] = 1000
+	unit.EnableHealthBar()
+	unit.healthBar.reset(sim)
+
+	healthMetrics := unit.NewHealthMetrics(ActionID{ItemID: 12345})
+	unit.RemoveHealth(sim, 400)
+	unit.GainHealth(sim, 300, healthMetrics)
+	unit.GainHealth(sim, 300, healthMetrics)
+
+	if unit.Metrics.HealingReceived != 400 || unit.Metrics.OverhealingReceived != 200 {
+		t.Fatalf("Expected 400 healing and 200 overhealing, got %0.1f and %0.1f", unit.Metrics.HealingReceived, unit.Metrics.OverhealingReceived)
+	}
+
+	unit.Metrics.dps.add(0)
+	unit.Metrics.healingReceivedSum += unit.Metrics.HealingReceived
+	unit.Metrics.overhealingReceivedSum += unit.Metrics.OverhealingReceived
+	unit.Metrics.damageTakenSum += unit.Metrics.DamageTaken
+
+	protoMetrics := unit.Metrics.ToProto()
+	if protoMetrics.SurvivabilityScore != 1 {
+		t.Fatalf("Expected a survivability score of 1 with all damage healed back, got %0.3f", protoMetrics.SurvivabilityScore)
+	}
+
+	// Overkill damage isn't health lost.
+	unit.RemoveHealth(sim, 1500)
+	if unit.Metrics.DamageTaken != 1400 {
+		t.Fatalf("Expected 1400 damage taken without overkill, got %0.1f", unit.Metrics.DamageTaken)
+	}
+}
+
+func TestCombineSurvivabilityScore(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	base := rsrc.newUnitMetrics(&proto.UnitMetrics{})
+	newMetrics := func(healingReceived float64, damageTaken float64) *proto.UnitMetrics {
+		metrics := rsrc.newUnitMetrics(&proto.UnitMetrics{})
+		metrics.HealingReceivedAvg = healingReceived
+		metrics.DamageTakenAvg = damageTaken
+		metrics.SurvivabilityScore = survivabilityScore(metrics)
+		return metrics
+	}
+	rsrc.combineUnitMetrics(base, newMetrics(100, 100), false, 0.5)
+	rsrc.combineUnitMetrics(base, newMetrics(0, 300), true, 0.5)
+
+	// 50 of 200 damage healed back, not the average of the scores.
+	if base.SurvivabilityScore != 0.25 {
+		t.Fatalf("Expected a combined survivability score of 0.25, got %0.3f", base.SurvivabilityScore)
+	}
+}
+
+func TestHistPercentiles(t *testing.T) {
+	distMetrics := NewDistributionMetrics()
+	for i := int32(1); i <= 20; i++ {
+		distMetrics.hist[i*100] = 5
+	}
+
+	protoMetrics := distMetrics.ToProto()
+	percentiles := []float64{protoMetrics.P05, protoMetrics.P25, protoMetrics.P50, protoMetrics.P75, protoMetrics.P95}
+	if !slices.Equal(percentiles, []float64{100, 500, 1000, 1500, 1900}) {
+		t.Fatalf("Expected percentiles [100 500 1000 1500 1900], got %v", percentiles)
+	}
+}
+
+func TestExactPercentiles(t *testing.T) {
+	distMetrics := NewDistributionMetrics()
+	for i := 1; i <= 20; i++ {
+		value := float64(i)*100 + 7
+		distMetrics.sample = append(distMetrics.sample, value)
+		distMetrics.hist[int32(math.Round(value/25)*25)]++
+	}
+
+	protoMetrics := distMetrics.ToProto()
+	percentiles := []float64{protoMetrics.P05, protoMetrics.P25, protoMetrics.P50, protoMetrics.P75, protoMetrics.P95}
+	if !slices.Equal(percentiles, []float64{107, 507, 1007, 1507, 1907}) {
+		t.Fatalf("Expected percentiles [107 507 1007 1507 1907], got %v", percentiles)
+	}
+}
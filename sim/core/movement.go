@@ -159,8 +159,8 @@ func (unit *Unit) MultiplyMovementSpeed(sim *Simulation, amount float64) {
 	oldMultiplier := unit.PseudoStats.MovementSpeedMultiplier
 	oldSpeed := unit.GetMovementSpeed()
 	unit.PseudoStats.MovementSpeedMultiplier *= amount
-	if sim.Log != nil {
-		unit.Log(sim, "[DEBUG] Movement speed changed from %.2f (%.2f%%) to %.2f (%.2f%%)", oldSpeed, (oldMultiplier-1)*100.0, unit.GetMovementSpeed(), (unit.PseudoStats.MovementSpeedMultiplier-1)*100.0)
+	if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
+		unit.LogAt(sim, LogCategoryOther, LogLevelTrace, "[DEBUG] Movement speed changed from %.2f (%.2f%%) to %.2f (%.2f%%)", oldSpeed, (oldMultiplier-1)*100.0, unit.GetMovementSpeed(), (unit.PseudoStats.MovementSpeedMultiplier-1)*100.0)
 	}
 
 	// we have a pending movement action that depends on our movement speed
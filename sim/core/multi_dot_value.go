@@ -0,0 +1,103 @@
+package core
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type multiDotValueKey struct {
+	ActionID    ActionID
+	TargetIndex int32
+}
+
+func (key multiDotValueKey) compare(other multiDotValueKey) int {
+	return cmp.Or(key.ActionID.Compare(other.ActionID), cmp.Compare(key.TargetIndex, other.TargetIndex))
+}
+
+// Estimated value of keeping a DoT up on a target other than the unit's
+// primary target, summed over the iterations in which it was estimated.
+type multiDotValueMetrics struct {
+	dotDpsSum     float64
+	gcdCostDpsSum float64
+	n             int32
+}
+
+func (values *multiDotValueMetrics) ToProto(key multiDotValueKey) *proto.MultiDotValueMetrics {
+	n := float64(values.n)
+	return &proto.MultiDotValueMetrics{
+		Id:             key.ActionID.ToProto(),
+		TargetIndex:    key.TargetIndex,
+		DotDpsAvg:      values.dotDpsSum / n,
+		GcdCostDpsAvg:  values.gcdCostDpsSum / n,
+		MarginalDpsAvg: (values.dotDpsSum - values.gcdCostDpsSum) / n,
+	}
+}
+
+// Expected damage of a full application of the spell's DoT on target,
+// including the initial hit, and how long the application lasts. Returns 0
+// damage if the spell has no DoT or no expected tick damage.
+func (spell *Spell) ExpectedDotApplicationDamage(sim *Simulation, target *Unit) (float64, time.Duration) {
+	if spell.dots == nil || spell.expectedTickDamageInternal == nil {
+		return 0, 0
+	}
+
+	dot := spell.dots.Get(target)
+	tickCount := dot.ExpectedTickCount()
+	tickPeriod := dot.CalcTickPeriod()
+	duration := time.Duration(tickCount) * tickPeriod
+
+	tickDamage := spell.ExpectedTickDamage(sim, target)
+	if spell.expectedDamagePerSecond {
+		tickDamage *= tickPeriod.Seconds()
+	}
+
+	damage := tickDamage * float64(tickCount)
+	if spell.expectedInitialDamageInternal != nil {
+		damage += spell.ExpectedInitialDamage(sim, target)
+	}
+	return damage, duration
+}
+
+// Estimates, for each DoT the unit cast this iteration and each active target
+// besides its current one, the DPS gained by keeping the DoT up there. The
+// GCD spent on each application is charged at the unit's own DPS, i.e. the
+// damage the rotation would have done with that time instead.
+//
+// Uses the state at the end of the iteration, so temporary buffs are only
+// reflected as often as they happen to be up then.
+func (unit *Unit) estimateMultiDotValues(sim *Simulation) {
+	targets := unit.Env.GetActiveTargetUnits()
+	if len(targets) < 2 || sim.CurrentTime <= 0 {
+		return
+	}
+	ownDps := unit.Metrics.ownDps.Total / sim.CurrentTime.Seconds()
+
+	for _, spell := range unit.Spellbook {
+		if spell.casts == 0 || spell.DefaultCast.GCD == 0 || spell.Flags.Matches(SpellFlagNoMetrics|SpellFlagChanneled) {
+			continue
+		}
+
+		for _, target := range targets {
+			if target == unit.CurrentTarget {
+				continue
+			}
+
+			damage, duration := spell.ExpectedDotApplicationDamage(sim, target)
+			if duration <= 0 {
+				continue
+			}
+
+			key := multiDotValueKey{ActionID: spell.ActionID, TargetIndex: target.UnitIndex}
+			values, ok := unit.Metrics.multiDotValues[key]
+			if !ok {
+				values = &multiDotValueMetrics{}
+				unit.Metrics.multiDotValues[key] = values
+			}
+			values.dotDpsSum += damage / duration.Seconds()
+			values.gcdCostDpsSum += ownDps * spell.EffectiveCastTime().Seconds() / duration.Seconds()
+			values.n++
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func setupMultiDotSim(tickDamage float64, perSecond bool) (*Simulation, *FakeAgent) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 90, MobType: proto.MobType_MobTypeDemon},
+				{Name: "add", Level: 90, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.Spell.DefaultCast.GCD = GCDDefault
+	fa.Spell.expectedDamagePerSecond = perSecond
+	fa.Spell.expectedTickDamageInternal = func(sim *Simulation, target *Unit, spell *Spell, _ bool) *SpellResult {
+		result := spell.CalcPeriodicDamage(sim, target, 0, spell.OutcomeExpectedTick)
+		result.Damage = tickDamage
+		return result
+	}
+	return sim, fa
+}
+
+func TestExpectedDotApplicationDamage(t *testing.T) {
+	for _, perSecond := range []bool{false, true} {
+		tickDamage := TernaryFloat64(perSecond, 100.0/3, 100)
+		sim, fa := setupMultiDotSim(tickDamage, perSecond)
+
+		// 6 ticks, 3s apart.
+		damage, duration := fa.Spell.ExpectedDotApplicationDamage(sim, sim.Encounter.ActiveTargetUnits[1])
+		if duration != time.Second*18 {
+			t.Fatalf("Per second %t: expected a duration of 18s, got %s", perSecond, duration)
+		}
+		if !WithinToleranceFloat64(600, damage, 0.001) {
+			t.Fatalf("Per second %t: expected 600 damage, got %0.3f", perSecond, damage)
+		}
+	}
+}
+
+func TestMultiDotValues(t *testing.T) {
+	sim, fa := setupMultiDotSim(100, false)
+	unit := &fa.Unit
+
+	sim.CurrentTime = time.Second * 180
+	fa.Spell.casts = 1
+	unit.Metrics.ownDps.Total = 50 * 180
+	unit.estimateMultiDotValues(sim)
+
+	// Only the target besides the current one is estimated.
+	if len(unit.Metrics.multiDotValues) != 1 {
+		t.Fatalf("Expected values for 1 target, got %d", len(unit.Metrics.multiDotValues))
+	}
+	key := multiDotValueKey{ActionID: fa.Spell.ActionID, TargetIndex: sim.Encounter.ActiveTargetUnits[1].UnitIndex}
+	values, ok := unit.Metrics.multiDotValues[key]
+	if !ok {
+		t.Fatalf("No values for the second target")
+	}
+
+	protoValues := values.ToProto(key)
+	// 600 damage over 18s, and one 1.5s GCD at 50 DPS per 18s.
+	expectedDotDps := 600.0 / 18
+	expectedCost := 50 * 1.5 / 18
+	if !WithinToleranceFloat64(expectedDotDps, protoValues.DotDpsAvg, 0.001) {
+		t.Fatalf("Expected a DoT DPS of %0.3f, got %0.3f", expectedDotDps, protoValues.DotDpsAvg)
+	}
+	if !WithinToleranceFloat64(expectedCost, protoValues.GcdCostDpsAvg, 0.001) {
+		t.Fatalf("Expected a GCD cost of %0.3f, got %0.3f", expectedCost, protoValues.GcdCostDpsAvg)
+	}
+	if !WithinToleranceFloat64(expectedDotDps-expectedCost, protoValues.MarginalDpsAvg, 0.001) {
+		t.Fatalf("Expected a marginal DPS of %0.3f, got %0.3f", expectedDotDps-expectedCost, protoValues.MarginalDpsAvg)
+	}
+
+	for _, targetIndex := range []int32{9, 3, 7} {
+		unit.Metrics.multiDotValues[multiDotValueKey{ActionID: fa.Spell.ActionID, TargetIndex: targetIndex}] = &multiDotValueMetrics{n: 1}
+	}
+	// Map iteration order is random, so check the output order repeatedly.
+	for range 10 {
+		var targetIndices []int32
+		for _, protoValues := range unit.Metrics.ToProto().MultiDotValues {
+			targetIndices = append(targetIndices, protoValues.TargetIndex)
+		}
+		if !slices.IsSorted(targetIndices) {
+			t.Fatalf("Expected values sorted by target index, got %v", targetIndices)
+		}
+	}
+
+	// Spells which weren't cast this iteration aren't estimated.
+	clear(unit.Metrics.multiDotValues)
+	fa.Spell.casts = 0
+	unit.estimateMultiDotValues(sim)
+	if len(unit.Metrics.multiDotValues) != 0 {
+		t.Fatalf("Expected no values for an uncast spell, got %d", len(unit.Metrics.multiDotValues))
+	}
+}
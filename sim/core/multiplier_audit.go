@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// A named factor of a damage multiplier, for SimOptions.debug_multipliers_spell.
+type multiplierFactor struct {
+	name  string
+	value float64
+}
+
+func debugMultipliersSpellFromOptions(simOptions *proto.SimOptions) ActionID {
+	if simOptions.DebugMultipliersSpell == nil {
+		return ActionID{}
+	}
+	return ProtoToActionID(simOptions.DebugMultipliersSpell)
+}
+
+func (sim *Simulation) shouldLogMultipliers(spell *Spell) bool {
+	return sim.Log != nil && !sim.debugMultipliersSpell.IsEmptyAction() && spell.ActionID.SameActionIgnoreTag(sim.debugMultipliersSpell)
+}
+
+// Logs every named factor of the multipliers applied to a damage result, so
+// modifiers which are counted twice, or stack additively where they should
+// multiply, stand out. Snapshotted dots show the current attacker factors,
+// next to the snapshotted product actually used.
+func (spell *Spell) logMultiplierChain(sim *Simulation, result *SpellResult, attackerMultiplier float64, isPeriodic bool) {
+	attackTable := spell.Unit.AttackTables[result.Target.UnitIndex]
+	pseudoStats := &spell.Unit.PseudoStats
+	targetStats := &attackTable.Defender.PseudoStats
+
+	var attacker []multiplierFactor
+	if !spell.Flags.Matches(SpellFlagIgnoreAttackerModifiers) {
+		attacker = append(attacker,
+			multiplierFactor{"DamageDealtMultiplier", pseudoStats.DamageDealtMultiplier},
+			multiplierFactor{"SchoolDamageDealtMultiplier", spell.schoolMultiplier(&pseudoStats.SchoolDamageDealtMultiplier)},
+			multiplierFactor{"AttackTable.DamageDealtMultiplier", attackTable.DamageDealtMultiplier},
+		)
+	}
+	attacker = append(attacker, multiplierFactor{"Spell.DamageMultiplier", spell.DamageMultiplier})
+
+	additive := spell.DamageMultiplierAdditive
+	if isPeriodic && !spell.Flags.Matches(SpellFlagIgnoreAttackerModifiers) {
+		additive += pseudoStats.DotDamageMultiplierAdditive - 1
+		attacker = append(attacker, multiplierFactor{
+			fmt.Sprintf("Additive(Spell %0.4f + Dot %0.4f - 1)", spell.DamageMultiplierAdditive, pseudoStats.DotDamageMultiplierAdditive),
+			additive,
+		})
+	} else {
+		attacker = append(attacker, multiplierFactor{"Spell.DamageMultiplierAdditive", additive})
+	}
+
+	if isPeriodic {
+		if dot := spell.aoeDot; dot != nil {
+			attacker = append(attacker, multiplierFactor{"Dot.PeriodicDamageMultiplier", dot.PeriodicDamageMultiplier})
+		} else if dot := spell.Dot(result.Target); dot != nil {
+			attacker = append(attacker, multiplierFactor{"Dot.PeriodicDamageMultiplier", dot.PeriodicDamageMultiplier})
+		}
+	}
+
+	target := []multiplierFactor{{"Armor", result.ArmorMultiplier}}
+	if !spell.Flags.Matches(SpellFlagIgnoreTargetModifiers) {
+		target = append(target,
+			multiplierFactor{"DamageTakenMultiplier", targetStats.DamageTakenMultiplier},
+			multiplierFactor{"SchoolDamageTakenMultiplier", spell.schoolMultiplier(&targetStats.SchoolDamageTakenMultiplier)},
+			multiplierFactor{"AttackTable.DamageTakenMultiplier", attackTable.DamageTakenMultiplier},
+		)
+		if spell.Flags.Matches(SpellFlagDisease) {
+			target = append(target, multiplierFactor{"DiseaseDamageTakenMultiplier", targetStats.DiseaseDamageTakenMultiplier})
+		}
+		if isPeriodic && spell.SpellSchool.Matches(SpellSchoolPhysical) {
+			target = append(target, multiplierFactor{"PeriodicPhysicalDamageTakenMultiplier", targetStats.PeriodicPhysicalDamageTakenMultiplier})
+		}
+		if spell.Flags.Matches(SpellFlagRanged) {
+			target = append(target, multiplierFactor{"AttackTable.RangedDamageTakenMultiplier", attackTable.RangedDamageTakenMultiplier})
+		}
+		if attackTable.DamageDoneByCasterMultiplier != nil {
+			target = append(target, multiplierFactor{"AttackTable.DamageDoneByCasterMultiplier", attackTable.DamageDoneByCasterMultiplier(sim, spell, attackTable)})
+		}
+		for i, extraMultiplier := range attackTable.DamageDoneByCasterExtraMultiplier {
+			if extraMultiplier != nil {
+				target = append(target, multiplierFactor{fmt.Sprintf("AttackTable.DamageDoneByCasterExtraMultiplier[%d]", i), extraMultiplier(sim, spell, attackTable)})
+			}
+		}
+	}
+
+	spell.Unit.LogAt(sim, LogCategoryDamage, LogLevelDebug, "%s %s [MULTIPLIERS] Attacker: %s = %0.4f (used: %0.4f)",
+		result.Target.LogLabel(), spell.ActionID, formatMultiplierFactors(attacker), productOfFactors(attacker), attackerMultiplier)
+	spell.Unit.LogAt(sim, LogCategoryDamage, LogLevelDebug, "%s %s [MULTIPLIERS] Target: %s = %0.4f",
+		result.Target.LogLabel(), spell.ActionID, formatMultiplierFactors(target), productOfFactors(target))
+
+	for _, mod := range spell.Unit.spellMods {
+		if !mod.IsActive || !mod.affects(spell) {
+			continue
+		}
+
+		switch mod.Kind {
+		case SpellMod_DamageDone_Pct:
+			spell.Unit.LogAt(sim, LogCategoryDamage, LogLevelDebug, "%s [MULTIPLIERS] SpellMod DamageDone_Pct: x%0.4f", spell.ActionID, 1+mod.floatValue)
+		case SpellMod_DamageDone_Flat:
+			spell.Unit.LogAt(sim, LogCategoryDamage, LogLevelDebug, "%s [MULTIPLIERS] SpellMod DamageDone_Flat: %+0.4f", spell.ActionID, mod.floatValue)
+		case SpellMod_DotDamageDone_Pct:
+			spell.Unit.LogAt(sim, LogCategoryDamage, LogLevelDebug, "%s [MULTIPLIERS] SpellMod DotDamageDone_Pct: x%0.4f", spell.ActionID, 1+mod.floatValue)
+		}
+	}
+}
+
+func formatMultiplierFactors(factors []multiplierFactor) string {
+	if len(factors) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, len(factors))
+	for i, factor := range factors {
+		parts[i] = fmt.Sprintf("%s %0.4f", factor.name, factor.value)
+	}
+	return strings.Join(parts, " x ")
+}
+
+func productOfFactors(factors []multiplierFactor) float64 {
+	product := 1.0
+	for _, factor := range factors {
+		product *= factor.value
+	}
+	return product
+}
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLogMultiplierChain(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.ActiveTargetUnits[0]
+
+	var logs []string
+	sim.Log = func(message string, vals ...interface{}) {
+		logs = append(logs, fmt.Sprintf(message, vals...))
+	}
+	sim.debugMultipliersSpell = fa.Spell.ActionID
+
+	fa.Spell.DamageMultiplier = 1.5
+	fa.Spell.CalcDamage(sim, target, 100, fa.Spell.OutcomeAlwaysHit)
+
+	found := false
+	for _, log := range logs {
+		if strings.Contains(log, "[MULTIPLIERS] Attacker:") && strings.Contains(log, "Spell.DamageMultiplier 1.5000") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected the attacker multiplier chain to be logged, got %v", logs)
+	}
+}
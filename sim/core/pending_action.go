@@ -1,7 +1,6 @@
 package core
 
 import (
-	"slices"
 	"time"
 )
 
@@ -37,6 +36,9 @@ type PendingAction struct {
 	cancelled bool
 	consumed  bool
 	canPool   bool // Flags the PA as safe to use in shared object pools.
+
+	queueIndex int    // 1-based position in the pending action queue, 0 if not queued.
+	queueSeq   uint64 // Insertion order, see pendingActionQueue.
 }
 
 func (pa *PendingAction) IsConsumed() bool {
@@ -55,9 +57,7 @@ func (pa *PendingAction) Cancel(sim *Simulation) {
 
 	pa.cancelled = true
 
-	if i := slices.Index(sim.pendingActions, pa); i != -1 {
-		sim.pendingActions = append(sim.pendingActions[:i], sim.pendingActions[i+1:]...)
-	}
+	sim.pendingActions.remove(pa)
 }
 
 func (pa *PendingAction) dispose(sim *Simulation) {
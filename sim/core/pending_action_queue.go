@@ -0,0 +1,142 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Binary min-heap of pending actions. Actions run in order of time, then
+// highest priority first, then in the order they were added.
+type pendingActionQueue struct {
+	actions []*PendingAction
+
+	// Insertion counter, to keep actions with the same time and priority in order.
+	nextSeq uint64
+}
+
+func (queue *pendingActionQueue) len() int {
+	return len(queue.actions)
+}
+
+// Returns the next action to run, or nil if the queue is empty.
+func (queue *pendingActionQueue) peek() *PendingAction {
+	if len(queue.actions) == 0 {
+		return nil
+	}
+	return queue.actions[0]
+}
+
+func (queue *pendingActionQueue) push(pa *PendingAction) {
+	pa.queueSeq = queue.nextSeq
+	queue.nextSeq++
+
+	queue.actions = append(queue.actions, pa)
+	pa.queueIndex = len(queue.actions)
+	queue.up(len(queue.actions) - 1)
+}
+
+func (queue *pendingActionQueue) pop() *PendingAction {
+	pa := queue.actions[0]
+	queue.removeAt(0)
+	return pa
+}
+
+// Removes pa if it's queued, and returns whether it was. Actions can still be
+// marked as queued by the previous sim of a reused environment, so the index
+// is only trusted if it points back at pa.
+func (queue *pendingActionQueue) remove(pa *PendingAction) bool {
+	i := pa.queueIndex - 1
+	if i < 0 || i >= len(queue.actions) || queue.actions[i] != pa {
+		pa.queueIndex = 0
+		return false
+	}
+	queue.removeAt(i)
+	return true
+}
+
+// Empties the queue, leaving the actions it held unqueued.
+func (queue *pendingActionQueue) clear() {
+	for _, pa := range queue.actions {
+		pa.queueIndex = 0
+	}
+	clear(queue.actions)
+	queue.actions = queue.actions[:0]
+}
+
+func (queue *pendingActionQueue) removeAt(i int) {
+	last := len(queue.actions) - 1
+	removed := queue.actions[i]
+	if i != last {
+		queue.swap(i, last)
+	}
+	queue.actions[last] = nil
+	queue.actions = queue.actions[:last]
+	removed.queueIndex = 0
+
+	if i != last {
+		if !queue.down(i) {
+			queue.up(i)
+		}
+	}
+}
+
+// Returns the queued actions, latest first.
+func (queue *pendingActionQueue) sortedLatestFirst() []*PendingAction {
+	sorted := slices.Clone(queue.actions)
+	slices.SortFunc(sorted, func(a, b *PendingAction) int {
+		return comparePendingActions(b, a)
+	})
+	return sorted
+}
+
+func comparePendingActions(a, b *PendingAction) int {
+	if a.NextActionAt != b.NextActionAt {
+		return cmp.Compare(a.NextActionAt, b.NextActionAt)
+	}
+	if a.Priority != b.Priority {
+		return cmp.Compare(b.Priority, a.Priority)
+	}
+	return cmp.Compare(a.queueSeq, b.queueSeq)
+}
+
+func (queue *pendingActionQueue) less(i, j int) bool {
+	return comparePendingActions(queue.actions[i], queue.actions[j]) < 0
+}
+
+func (queue *pendingActionQueue) swap(i, j int) {
+	queue.actions[i], queue.actions[j] = queue.actions[j], queue.actions[i]
+	queue.actions[i].queueIndex = i + 1
+	queue.actions[j].queueIndex = j + 1
+}
+
+func (queue *pendingActionQueue) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !queue.less(i, parent) {
+			return
+		}
+		queue.swap(i, parent)
+		i = parent
+	}
+}
+
+// Returns whether the action at i moved.
+func (queue *pendingActionQueue) down(i int) bool {
+	start := i
+	n := len(queue.actions)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && queue.less(right, child) {
+			child = right
+		}
+		if !queue.less(child, i) {
+			break
+		}
+		queue.swap(i, child)
+		i = child
+	}
+	return i > start
+}
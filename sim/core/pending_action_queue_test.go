@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPendingActionQueueOrder(t *testing.T) {
+	var queue pendingActionQueue
+
+	late := &PendingAction{NextActionAt: time.Second * 2}
+	gcd := &PendingAction{NextActionAt: time.Second, Priority: ActionPriorityGCD}
+	dot := &PendingAction{NextActionAt: time.Second, Priority: ActionPriorityDOT}
+	secondGcd := &PendingAction{NextActionAt: time.Second, Priority: ActionPriorityGCD}
+	cancelled := &PendingAction{NextActionAt: time.Millisecond}
+
+	for _, pa := range []*PendingAction{late, gcd, dot, secondGcd, cancelled} {
+		queue.push(pa)
+	}
+	queue.remove(cancelled)
+
+	for _, expected := range []*PendingAction{dot, gcd, secondGcd, late} {
+		if pa := queue.pop(); pa != expected {
+			t.Fatalf("Expected %+v next, got %+v", expected, pa)
+		}
+	}
+	if queue.peek() != nil {
+		t.Fatalf("Expected the queue to be empty")
+	}
+}
+
+func TestPendingActionQueueIgnoresOtherQueues(t *testing.T) {
+	var previous, queue pendingActionQueue
+
+	// Left queued by the previous sim of a reused environment.
+	stale := &PendingAction{NextActionAt: time.Second}
+	previous.push(stale)
+	other := &PendingAction{NextActionAt: time.Second}
+	queue.push(other)
+
+	if queue.remove(stale) {
+		t.Fatalf("Expected an action from another queue not to be removed")
+	}
+	queue.push(stale)
+	if queue.len() != 2 || queue.pop() != other || queue.pop() != stale {
+		t.Fatalf("Expected both actions to be queued once")
+	}
+}
+
+// Roughly the number of queued actions in a 25 man raid, with dots, pets and rotations.
+func BenchmarkPendingActionQueue(b *testing.B) {
+	var queue pendingActionQueue
+	actions := make([]*PendingAction, 200)
+	for i := range actions {
+		actions[i] = &PendingAction{NextActionAt: time.Duration(i*7919%1000) * time.Millisecond}
+		queue.push(actions[i])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pa := queue.pop()
+		pa.NextActionAt += time.Second
+		queue.push(pa)
+	}
+}
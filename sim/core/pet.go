@@ -98,6 +98,9 @@ func NewPet(config PetConfig) Pet {
 
 				ReactionTime: config.Owner.ReactionTime,
 
+				disabledSpells: config.Owner.disabledSpells,
+				disabledAuras:  config.Owner.disabledAuras,
+
 				StartDistanceFromTarget: MaxMeleeRange, // TODO: Match to owner and add movement logic to pet rotations
 			},
 			Name:       config.Name,
@@ -216,8 +219,8 @@ func (pet *Pet) IsGuardian() bool {
 // petAgent should be the PetAgent which embeds this Pet.
 func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 	if pet.enabled {
-		if sim.Log != nil {
-			pet.Log(sim, "Pet already summoned")
+		if sim.LogEnabled(LogCategoryOther, LogLevelDebug) {
+			pet.LogAt(sim, LogCategoryOther, LogLevelDebug, "Pet already summoned")
 		}
 		return
 	}
@@ -259,13 +262,14 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 		pet.AutoAttacks.StopMeleeUntil(sim, max(SpellBatchWindow, sim.CurrentTime+pet.startAttackDelay)-pet.AutoAttacks.MainhandSwingSpeed())
 	}
 
-	if sim.Log != nil {
-		pet.Log(sim, "Pet stats: %s", pet.GetStats().FlatString())
-		pet.Log(sim, "Pet inherited stats: %s", pet.ApplyStatDependencies(pet.inheritedStats).FlatString())
-		pet.Log(sim, "Pet summoned")
+	if sim.LogEnabled(LogCategoryOther, LogLevelDebug) {
+		pet.LogAt(sim, LogCategoryOther, LogLevelDebug, "Pet stats: %s", pet.GetStats().FlatString())
+		pet.LogAt(sim, LogCategoryOther, LogLevelDebug, "Pet inherited stats: %s", pet.ApplyStatDependencies(pet.inheritedStats).FlatString())
+		pet.LogAt(sim, LogCategoryOther, LogLevelDebug, "Pet summoned")
 	}
 
-	sim.addTracker(&pet.auraTracker)
+	pet.joinFight(sim)
+	pet.Metrics.markActive(sim)
 
 	if pet.HasFocusBar() {
 		// make sure to reset it to refresh focus
@@ -387,8 +391,8 @@ func (pet *Pet) enableResourceRegenInheritance() {
 
 func (pet *Pet) Disable(sim *Simulation) {
 	if !pet.enabled {
-		if sim.Log != nil {
-			pet.Log(sim, "No pet summoned")
+		if sim.LogEnabled(LogCategoryOther, LogLevelDebug) {
+			pet.LogAt(sim, LogCategoryOther, LogLevelDebug, "No pet summoned")
 		}
 		return
 	}
@@ -413,13 +417,16 @@ func (pet *Pet) Disable(sim *Simulation) {
 		pet.OnPetDisable(sim)
 	}
 
-	pet.auraTracker.expireAll(sim)
+	for _, unit := range pet.Env.AllUnits {
+		unit.expireAurasAppliedBy(sim, &pet.Unit)
+	}
 
-	sim.removeTracker(&pet.auraTracker)
+	pet.leaveFight(sim, true)
+	pet.Metrics.markInactive(sim)
 
-	if sim.Log != nil {
-		pet.Log(sim, "Pet dismissed")
-		pet.Log(sim, pet.GetStats().FlatString())
+	if sim.LogEnabled(LogCategoryOther, LogLevelInfo) {
+		pet.LogAt(sim, LogCategoryOther, LogLevelInfo, "Pet dismissed")
+		pet.LogAt(sim, LogCategoryOther, LogLevelInfo, pet.GetStats().FlatString())
 	}
 }
 
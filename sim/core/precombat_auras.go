@@ -0,0 +1,52 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type precombatAura struct {
+	aura  *Aura
+	state AuraState
+}
+
+// Resolves the auras which the request wants active at the start of the
+// encounter. Must run after all of the character's auras are registered.
+func (character *Character) registerPrecombatAuras(configs []*proto.PrecombatAura) {
+	for _, config := range configs {
+		if config.AuraId == nil {
+			panic("Precombat auras need an aura ID")
+		}
+
+		actionID := ProtoToActionID(config.AuraId)
+		aura := character.GetAuraByID(actionID)
+		if aura == nil {
+			panic(fmt.Sprintf("No aura %s for precombat aura state", actionID))
+		}
+
+		state := AuraState{
+			RemainingDuration: DurationFromSeconds(config.RemainingSeconds),
+			Stacks:            config.Stacks,
+		}
+		if state.RemainingDuration <= 0 || (aura.Duration > 0 && state.RemainingDuration > aura.Duration) {
+			state.RemainingDuration = aura.Duration
+		}
+		if aura.MaxStacks > 0 {
+			state.Stacks = min(max(state.Stacks, 1), aura.MaxStacks)
+		}
+
+		character.precombatAuras = append(character.precombatAuras, precombatAura{
+			aura:  aura,
+			state: state,
+		})
+	}
+}
+
+// Puts the precombat auras into their configured state, e.g. for chain pulls
+// where buffs were stacked on trash. Overrides whatever the pre-pull did to them.
+func (unit *Unit) restorePrecombatAuras(sim *Simulation) {
+	for _, precombat := range unit.precombatAuras {
+		precombat.aura.RestoreState(precombat.state, sim)
+	}
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestPrecombatAuras(t *testing.T) {
+	auraID := &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 1234}}
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+							CustomAuras: []*proto.CustomAura{
+								{Name: "Trash Buff", Id: auraID, DurationSeconds: 30, StartSeconds: 100},
+							},
+							PrecombatAuras: []*proto.PrecombatAura{
+								{AuraId: auraID, RemainingSeconds: 8},
+							},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 90, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	aura := unit.GetAuraByID(ActionID{SpellID: 1234})
+	if aura.IsActive() {
+		t.Fatalf("Precombat aura shouldn't be active before the encounter starts")
+	}
+
+	unit.onEncounterStart(sim)
+	if !aura.IsActive() {
+		t.Fatalf("Precombat aura should be active at the start of the encounter")
+	}
+	if remaining := aura.RemainingDuration(sim); remaining != time.Second*8 {
+		t.Fatalf("Expected 8s remaining, got %s", remaining)
+	}
+}
+
+func TestPrecombatAuraStateIsClamped(t *testing.T) {
+	var aura *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		aura = env.Raid.Parties[0].Players[0].GetCharacter().RegisterAura(Aura{
+			Label:     "Stacking Trash Buff",
+			ActionID:  ActionID{SpellID: 1234},
+			Duration:  time.Second * 20,
+			MaxStacks: 5,
+		})
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	auraID := ActionID{SpellID: 1234}.ToProto()
+
+	for _, test := range []struct {
+		config    *proto.PrecombatAura
+		remaining time.Duration
+		stacks    int32
+	}{
+		{&proto.PrecombatAura{AuraId: auraID, RemainingSeconds: 8, Stacks: 3}, time.Second * 8, 3},
+		{&proto.PrecombatAura{AuraId: auraID, RemainingSeconds: 60, Stacks: 9}, time.Second * 20, 5},
+		{&proto.PrecombatAura{AuraId: auraID}, time.Second * 20, 1},
+	} {
+		aura.Deactivate(sim)
+		character.precombatAuras = nil
+		character.registerPrecombatAuras([]*proto.PrecombatAura{test.config})
+		character.restorePrecombatAuras(sim)
+
+		if remaining := aura.RemainingDuration(sim); remaining != test.remaining {
+			t.Fatalf("Expected %s remaining for %v, got %s", test.remaining, test.config, remaining)
+		}
+		if stacks := aura.GetStacks(); stacks != test.stacks {
+			t.Fatalf("Expected %d stacks for %v, got %d", test.stacks, test.config, stacks)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 type DynamicProcManager struct {
 	procMasks   []ProcMask
 	procChances []DynamicProc
+	procSlots   []proto.ItemSlot // Only set for managers created per equipped item, see NewRPPMProcManager
 }
 
 type DynamicProc interface {
@@ -32,6 +33,25 @@ func (dpm *DynamicProcManager) Proc(sim *Simulation, procMask ProcMask, label st
 	return false
 }
 
+// Like Proc, but also returns the item slot of the proc which was rolled. Like
+// Proc, only the first proc matching the mask is rolled, so an effect equipped
+// on both weapons procs at most once per hit: melee attacks roll the weapon they
+// were made with, spells and procs the first enchanted weapon. The slot is
+// NoItemSlot if nothing matched, or the manager isn't per equipped item.
+func (dpm *DynamicProcManager) ProcSlot(sim *Simulation, procMask ProcMask, label string) (proto.ItemSlot, bool) {
+	for i, m := range dpm.procMasks {
+		if m.Matches(procMask) {
+			slot := NoItemSlot
+			if i < len(dpm.procSlots) {
+				slot = dpm.procSlots[i]
+			}
+			return slot, dpm.procChances[i].Proc(sim, label)
+		}
+	}
+
+	return NoItemSlot, false
+}
+
 func (dpm *DynamicProcManager) Chance(procMask ProcMask, sim *Simulation) float64 {
 	for i, m := range dpm.procMasks {
 		if m.Matches(procMask) {
@@ -233,6 +253,7 @@ func (character *Character) NewRPPMProcManager(effectID int32, isEnchant bool, p
 
 			manager.procMasks = append(manager.procMasks, mask)
 			manager.procChances = append(manager.procChances, proc)
+			manager.procSlots = append(manager.procSlots, slot)
 		}
 
 		return manager
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type testDynamicProc struct {
+	rolls int
+}
+
+func (proc *testDynamicProc) Reset()                       {}
+func (proc *testDynamicProc) Chance(_ *Simulation) float64 { return 1 }
+func (proc *testDynamicProc) Proc(_ *Simulation, _ string) bool {
+	proc.rolls++
+	return true
+}
+
+func TestDynamicProcManagerProcSlotRollsOncePerHit(t *testing.T) {
+	mhProc, ohProc := &testDynamicProc{}, &testDynamicProc{}
+	dpm := DynamicProcManager{
+		procMasks:   []ProcMask{ProcMaskMeleeMH | ProcMaskSpellDamage, ProcMaskMeleeOH | ProcMaskSpellDamage},
+		procChances: []DynamicProc{mhProc, ohProc},
+		procSlots:   []proto.ItemSlot{proto.ItemSlot_ItemSlotMainHand, proto.ItemSlot_ItemSlotOffHand},
+	}
+
+	procs := 0
+	for _, procMask := range []ProcMask{ProcMaskMeleeMHAuto, ProcMaskMeleeOHAuto, ProcMaskSpellDamage} {
+		if _, procced := dpm.ProcSlot(nil, procMask, "test"); procced {
+			procs++
+		}
+	}
+	if procs != 3 || mhProc.rolls+ohProc.rolls != 3 {
+		t.Fatalf("Expected 3 procs from 3 rolls for 3 hits, got %d procs from %d rolls", procs, mhProc.rolls+ohProc.rolls)
+	}
+
+	if slot, _ := dpm.ProcSlot(nil, ProcMaskMeleeOHAuto, "test"); slot != proto.ItemSlot_ItemSlotOffHand {
+		t.Fatalf("Expected an off hand attack to roll the off hand, got %s", slot)
+	}
+	if slot, _ := dpm.ProcSlot(nil, ProcMaskSpellDamage, "test"); slot != proto.ItemSlot_ItemSlotMainHand {
+		t.Fatalf("Expected a spell to roll the main hand, got %s", slot)
+	}
+	if slot, procced := dpm.ProcSlot(nil, ProcMaskEmpty, "test"); procced || slot != NoItemSlot {
+		t.Fatalf("Expected no proc and no slot for an unmatched mask, got %v in %s", procced, slot)
+	}
+
+	legacy := DynamicProcManager{procMasks: []ProcMask{ProcMaskMelee}, procChances: []DynamicProc{mhProc}}
+	if slot, procced := legacy.ProcSlot(nil, ProcMaskMeleeMHAuto, "test"); !procced || slot != NoItemSlot {
+		t.Fatalf("Expected a proc without a slot from a manager not created per item, got %v in %s", procced, slot)
+	}
+}
@@ -85,7 +85,7 @@ func applyRaceEffects(agent Agent) {
 			},
 		})
 	case proto.Race_RaceDraenei:
-		character.AddStat(stats.HitRating, PhysicalHitRatingPerHitPercent)
+		character.AddStat(stats.HitRating, Ratings.RatingForPercent(stats.HitRating, 1))
 		character.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] *= 0.99
 
 		classSpellIDs := map[proto.Class]ActionID{
@@ -153,10 +153,10 @@ func applyRaceEffects(agent Agent) {
 		if ranged != nil && (ranged.RangedWeaponType == proto.RangedWeaponType_RangedWeaponTypeBow ||
 			ranged.RangedWeaponType == proto.RangedWeaponType_RangedWeaponTypeGun ||
 			ranged.RangedWeaponType == proto.RangedWeaponType_RangedWeaponTypeCrossbow) {
-			character.AddStat(stats.ExpertiseRating, ExpertisePerQuarterPercentReduction*4)
+			character.AddStat(stats.ExpertiseRating, Ratings.RatingForPercent(stats.ExpertiseRating, 1))
 		}
 
-		applyWeaponSpecialization(character, 4*ExpertisePerQuarterPercentReduction,
+		applyWeaponSpecialization(character, Ratings.RatingForPercent(stats.ExpertiseRating, 1),
 			proto.WeaponType_WeaponTypeMace)
 
 		actionID := ActionID{SpellID: 20594}
@@ -191,11 +191,11 @@ func applyRaceEffects(agent Agent) {
 	case proto.Race_RaceGnome:
 		character.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexArcane] *= 0.99
 		character.MultiplyStat(stats.Mana, 1.05)
-		applyOneHandWeaponSpecialization(character, 4*ExpertisePerQuarterPercentReduction,
+		applyOneHandWeaponSpecialization(character, Ratings.RatingForPercent(stats.ExpertiseRating, 1),
 			proto.WeaponType_WeaponTypeSword, proto.WeaponType_WeaponTypeDagger)
 	case proto.Race_RaceHuman:
 		character.MultiplyStat(stats.Spirit, 1.03)
-		applyWeaponSpecialization(character, 4*ExpertisePerQuarterPercentReduction,
+		applyWeaponSpecialization(character, Ratings.RatingForPercent(stats.ExpertiseRating, 1),
 			proto.WeaponType_WeaponTypeMace, proto.WeaponType_WeaponTypeSword)
 	case proto.Race_RaceNightElf:
 		character.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexNature] *= 0.99
@@ -283,7 +283,7 @@ func applyRaceEffects(agent Agent) {
 		})
 
 		// Axe specialization
-		applyWeaponSpecialization(character, 4*ExpertisePerQuarterPercentReduction,
+		applyWeaponSpecialization(character, Ratings.RatingForPercent(stats.ExpertiseRating, 1),
 			proto.WeaponType_WeaponTypeAxe, proto.WeaponType_WeaponTypeFist)
 	case proto.Race_RaceTauren:
 		character.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexNature] *= 0.99
@@ -294,7 +294,7 @@ func applyRaceEffects(agent Agent) {
 		if ranged != nil && (ranged.RangedWeaponType == proto.RangedWeaponType_RangedWeaponTypeBow ||
 			ranged.RangedWeaponType == proto.RangedWeaponType_RangedWeaponTypeGun ||
 			ranged.RangedWeaponType == proto.RangedWeaponType_RangedWeaponTypeCrossbow) {
-			character.AddStat(stats.ExpertiseRating, ExpertisePerQuarterPercentReduction*4)
+			character.AddStat(stats.ExpertiseRating, Ratings.RatingForPercent(stats.ExpertiseRating, 1))
 		}
 
 		// Beast Slaying (+5% damage to beasts)
@@ -23,6 +23,7 @@ type rageBar struct {
 
 	RageRefundMetrics     *ResourceMetrics
 	EncounterStartMetrics *ResourceMetrics
+	capMetrics            *ResourceCapMetrics
 }
 
 type RageBarOptions struct {
@@ -87,6 +88,7 @@ func (unit *Unit) EnableRageBar(options RageBarOptions) {
 		startingHitFactor:     BaseRageHitFactor * options.BaseRageMultiplier,
 		RageRefundMetrics:     unit.NewRageMetrics(ActionID{OtherID: proto.OtherAction_OtherActionRefund}),
 		EncounterStartMetrics: unit.NewRageMetrics(encounterStartActionID),
+		capMetrics:            unit.Metrics.NewResourceCapMetrics(proto.ResourceType_ResourceTypeRage),
 	}
 }
 
@@ -115,9 +117,10 @@ func (rb *rageBar) AddRage(sim *Simulation, amount float64, metrics *ResourceMet
 
 	newRage := min(rb.currentRage+amount, rb.maxRage)
 	metrics.AddEvent(amount, newRage-rb.currentRage)
+	rb.capMetrics.Update(sim, newRage >= rb.maxRage, amount-(newRage-rb.currentRage))
 
-	if sim.Log != nil {
-		rb.unit.Log(sim, "Gained %0.3f rage from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rb.currentRage, newRage, 100.0)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		rb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %0.3f rage from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rb.currentRage, newRage, 100.0)
 	}
 
 	rb.currentRage = newRage
@@ -133,9 +136,10 @@ func (rb *rageBar) SpendRage(sim *Simulation, amount float64, metrics *ResourceM
 
 	newRage := rb.currentRage - amount
 	metrics.AddEvent(-amount, -amount)
+	rb.capMetrics.Update(sim, newRage >= rb.maxRage, 0)
 
-	if sim.Log != nil {
-		rb.unit.Log(sim, "Spent %0.3f rage from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rb.currentRage, newRage, 100.0)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		rb.unit.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent %0.3f rage from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rb.currentRage, newRage, 100.0)
 	}
 
 	rb.currentRage = newRage
@@ -255,7 +255,9 @@ func (raid *Raid) GetRaidBuffs(baseRaidBuffs *proto.RaidBuffs) *proto.RaidBuffs
 	// Compute the full raid buffs from the raid.
 	raidBuffs := &proto.RaidBuffs{}
 	if baseRaidBuffs != nil {
-		raidBuffs = baseRaidBuffs
+		// Copied so the request isn't changed, which would change its hash for
+		// the next sim of the same request.
+		raidBuffs = googleProto.Clone(baseRaidBuffs).(*proto.RaidBuffs)
 	}
 	for _, party := range raid.Parties {
 		for _, player := range party.Players {
@@ -406,6 +408,36 @@ func (raid *Raid) reset(sim *Simulation) {
 	raid.hpsMetrics.reset()
 }
 
+// Clears the raid and party wide aggregate metrics, so the raid can be reused for another sim.
+func (raid *Raid) clearAggregates() {
+	for _, party := range raid.Parties {
+		party.dpsMetrics.clearAggregates()
+		party.hpsMetrics.clearAggregates()
+	}
+	raid.dpsMetrics.clearAggregates()
+	raid.hpsMetrics.clearAggregates()
+}
+
+// Applies the bonus stats from raidProto to an already finalized raid.
+func (raid *Raid) setBonusStats(sim *Simulation, raidProto *proto.Raid) {
+	for _, party := range raid.Parties {
+		partyConfig := raidProto.Parties[party.Index]
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			if character.PartyIndex >= len(partyConfig.Players) {
+				// This happens for target dummies.
+				continue
+			}
+
+			var bonusStats stats.Stats
+			if playerConfig := partyConfig.Players[character.PartyIndex]; playerConfig.BonusStats != nil && playerConfig.BonusStats.Stats != nil {
+				bonusStats = stats.FromUnitStatsProto(playerConfig.BonusStats)
+			}
+			character.setBonusStats(sim, bonusStats)
+		}
+	}
+}
+
 func (raid *Raid) doneIteration(sim *Simulation) {
 	for _, party := range raid.Parties {
 		party.doneIteration(sim)
@@ -0,0 +1,67 @@
+package core
+
+// Invoked when any raid member (player or pet) completes a cast.
+type AllyCastCompleteHandler func(sim *Simulation, ally *Unit, spell *Spell)
+
+// Invoked when any raid member (player or pet) takes direct or periodic damage.
+// Misses are also reported, so handlers should check result.Landed() if needed.
+type RaidDamageTakenHandler func(sim *Simulation, victim *Unit, spell *Spell, result *SpellResult)
+
+type raidEventHandlers struct {
+	allyCastComplete []AllyCastCompleteHandler
+	raidDamageTaken  []RaidDamageTakenHandler
+
+	// Whether the forwarding auras have been registered on the raid units.
+	registered bool
+}
+
+// Subscribes to casts completed by every raid member, including this unit and
+// all pets. Must be called during Initialize or from a pre-finalize effect.
+func (unit *Unit) OnAllyCastComplete(handler AllyCastCompleteHandler) {
+	env := unit.Env
+	env.registerRaidEventAuras()
+	env.raidEvents.allyCastComplete = append(env.raidEvents.allyCastComplete, handler)
+}
+
+// Subscribes to damage taken by every raid member, including this unit and all
+// pets. Must be called during Initialize or from a pre-finalize effect.
+func (unit *Unit) OnRaidDamageTaken(handler RaidDamageTakenHandler) {
+	env := unit.Env
+	env.registerRaidEventAuras()
+	env.raidEvents.raidDamageTaken = append(env.raidEvents.raidDamageTaken, handler)
+}
+
+// Registers one hidden aura per raid unit which forwards that unit's events to
+// the environment wide handler lists. This is only done once, and only when a
+// spec actually subscribes, so sims without raid hooks pay nothing for them.
+func (env *Environment) registerRaidEventAuras() {
+	if env.IsFinalized() {
+		panic("Raid event handlers may not be added once finalized!")
+	}
+	if env.raidEvents.registered {
+		return
+	}
+	env.raidEvents.registered = true
+
+	events := &env.raidEvents
+	for _, unit := range env.Raid.AllUnits {
+		MakePermanent(unit.RegisterAura(Aura{
+			Label: "Raid Event Hooks",
+			OnCastComplete: func(aura *Aura, sim *Simulation, spell *Spell) {
+				for _, handler := range events.allyCastComplete {
+					handler(sim, aura.Unit, spell)
+				}
+			},
+			OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+				for _, handler := range events.raidDamageTaken {
+					handler(sim, aura.Unit, spell, result)
+				}
+			},
+			OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+				for _, handler := range events.raidDamageTaken {
+					handler(sim, aura.Unit, spell, result)
+				}
+			},
+		}))
+	}
+}
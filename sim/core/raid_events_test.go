@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestRaidEventHooks(t *testing.T) {
+	newPlayer := func(name string) *proto.Player {
+		return &proto.Player{
+			Name:      name,
+			Class:     proto.Class_ClassShaman,
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}
+	}
+	raidProto := &proto.Raid{
+		Parties: []*proto.Party{
+			{
+				Players: []*proto.Player{newPlayer("Listener"), newPlayer("Ally")},
+				Buffs:   &proto.PartyBuffs{},
+			},
+		},
+	}
+	encounterProto := &proto.Encounter{
+		Targets: []*proto.Target{
+			{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon},
+		},
+		Duration: 180,
+	}
+
+	env := &Environment{State: Created}
+	env.construct(raidProto, encounterProto)
+	raidStats := env.initialize(raidProto, encounterProto)
+
+	listener := env.Raid.Parties[0].Players[0].(*FakeAgent)
+	ally := env.Raid.Parties[0].Players[1].(*FakeAgent)
+
+	var casters []*Unit
+	listener.OnAllyCastComplete(func(_ *Simulation, caster *Unit, spell *Spell) {
+		casters = append(casters, caster)
+	})
+	var victims []*Unit
+	listener.OnRaidDamageTaken(func(_ *Simulation, victim *Unit, _ *Spell, _ *SpellResult) {
+		victims = append(victims, victim)
+	})
+
+	env.finalize(raidProto, encounterProto, raidStats, false)
+	sim := newSimWithEnv(env, &proto.SimOptions{RandomSeed: 100}, simsignals.CreateSignals())
+	sim.Reset()
+
+	ally.Spell.Cast(sim, sim.Encounter.AllTargetUnits[0])
+	listener.Spell.Cast(sim, sim.Encounter.AllTargetUnits[0])
+	ally.Spell.CalcAndDealDamage(sim, &listener.Unit, 100, ally.Spell.OutcomeAlwaysHit)
+
+	if len(casters) != 2 || casters[0] != &ally.Unit || casters[1] != &listener.Unit {
+		t.Fatalf("Expected cast completions from the ally and then the listener, got %d", len(casters))
+	}
+	if len(victims) != 1 || victims[0] != &listener.Unit {
+		t.Fatalf("Expected the listener to be reported as the only raid member hit, got %d", len(victims))
+	}
+}
@@ -71,3 +71,45 @@ func (sm *SplitMix64) Int63() int64 {
 func (sm *SplitMix64) Uint64() uint64 {
 	return sm.Next()
 }
+
+// Not random at all: steps through [0, 1) by the golden ratio, which spreads
+// values as evenly as possible. Any fraction p of the values is below p after
+// very few calls, so procs using it fire evenly spaced at their expected rate.
+// Used for deterministic sims, see SimOptions.deterministic.
+type GoldenRatioSequence struct {
+	state uint64
+}
+
+// Each sequence starts at 0.5, so the first roll of every label is the median one.
+const goldenRatioSequenceStart = uint64(1) << 63
+
+func NewGoldenRatioSequence() *GoldenRatioSequence {
+	return &GoldenRatioSequence{state: goldenRatioSequenceStart}
+}
+
+func (grs *GoldenRatioSequence) Next() uint64 {
+	result := grs.state
+	grs.state += 0x9e3779b97f4a7c15 // 2^64 / golden ratio
+	return result
+}
+
+func (grs *GoldenRatioSequence) NextFloat64() float64 {
+	return float64(grs.Next()>>11) * 0x1p-53
+}
+
+// The seed is ignored, so every iteration is the same.
+func (grs *GoldenRatioSequence) Seed(_ int64) {
+	grs.state = goldenRatioSequenceStart
+}
+
+func (grs *GoldenRatioSequence) GetSeed() int64 {
+	return 0
+}
+
+func (grs *GoldenRatioSequence) Int63() int64 {
+	return int64(grs.Next() & math.MaxInt64)
+}
+
+func (grs *GoldenRatioSequence) Uint64() uint64 {
+	return grs.Next()
+}
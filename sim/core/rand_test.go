@@ -3,6 +3,11 @@ package core
 import (
 	"math"
 	"testing"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
 )
 
 func TestSplitMix64(t *testing.T) {
@@ -34,6 +39,196 @@ func TestSplitMix64(t *testing.T) {
 	t.Logf("chiSquare = %.1f", chiSquare)
 }
 
+func TestGoldenRatioSequence(t *testing.T) {
+	for _, p := range []float64{0.05, 0.2, 0.5, 0.85} {
+		x := NewGoldenRatioSequence()
+		n := 1000
+		procs := 0
+		for i := 0; i < n; i++ {
+			if x.NextFloat64() < p {
+				procs++
+			}
+		}
+		if expected := p * float64(n); math.Abs(float64(procs)-expected) > 3 {
+			t.Errorf("p = %.2f: expected %.0f procs, got %d", p, expected, procs)
+		}
+	}
+
+	x := NewGoldenRatioSequence()
+	first := x.NextFloat64()
+	x.NextFloat64()
+	x.Seed(1234)
+	if x.NextFloat64() != first || first != 0.5 {
+		t.Fatalf("expected the sequence to restart at 0.5 when seeded")
+	}
+}
+
+func TestAntitheticSampling(t *testing.T) {
+	sim := &Simulation{
+		Options: &proto.SimOptions{AntitheticSampling: true, RandomSeed: 1234},
+		rand:    NewSplitMix(1234),
+		rseed:   1234,
+	}
+
+	for i := int64(0); i < 10; i += 2 {
+		sim.reseedRands(i)
+		first := sim.RandomFloat("test")
+		sim.reseedRands(i + 1)
+		mirrored := sim.RandomFloat("test")
+
+		if math.Abs(first+mirrored-1) > 1e-12 {
+			t.Errorf("iteration %d: expected mirrored roll of %f, got %f", i+1, 1-first, mirrored)
+		}
+	}
+}
+
+func TestAntitheticSplitsKeepPairs(t *testing.T) {
+	splitRes := SplitSimRequestForConcurrency(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			Iterations:         11,
+			RandomSeed:         100,
+			AntitheticSampling: true,
+		},
+	}, 3)
+
+	if splitRes.SplitsDone != 3 {
+		t.Fatalf("Expected 3 splits, got %d", splitRes.SplitsDone)
+	}
+
+	nextSeed := int64(100)
+	for i, req := range splitRes.Requests {
+		if req.SimOptions.RandomSeed != nextSeed {
+			t.Fatalf("split %d: expected start seed %d, got %d", i, nextSeed, req.SimOptions.RandomSeed)
+		}
+		if i < len(splitRes.Requests)-1 && req.SimOptions.Iterations%2 != 0 {
+			t.Fatalf("split %d: expected whole antithetic pairs, got %d iterations", i, req.SimOptions.Iterations)
+		}
+		nextSeed += int64(req.SimOptions.Iterations)
+	}
+	if nextSeed != 111 {
+		t.Fatalf("Expected 11 iterations over all splits, got %d", nextSeed-100)
+	}
+}
+
+func TestAntitheticSamplingReducesVariance(t *testing.T) {
+	// Standard deviation of the mean DTPS of each pair of iterations, which is
+	// what antithetic sampling reduces.
+	pairMeanStdev := func(antithetic bool) float64 {
+		request := incomingDamageTestRequest(200)
+		request.SimOptions.AntitheticSampling = antithetic
+		dtps := NewSim(request, simsignals.CreateSignals()).run().RaidMetrics.Parties[0].Players[0].Dtps.AllValues
+
+		pairMeans := make([]float64, 0, len(dtps)/2)
+		for i := 0; i+1 < len(dtps); i += 2 {
+			pairMeans = append(pairMeans, (dtps[i]+dtps[i+1])/2)
+		}
+		var sum, sumSquares float64
+		for _, mean := range pairMeans {
+			sum += mean
+			sumSquares += mean * mean
+		}
+		n := float64(len(pairMeans))
+		return math.Sqrt(sumSquares/n - (sum/n)*(sum/n))
+	}
+
+	plain, antithetic := pairMeanStdev(false), pairMeanStdev(true)
+	t.Logf("stdev of pair means: plain %0.2f, antithetic %0.2f", plain, antithetic)
+	if antithetic >= plain*0.8 {
+		t.Fatalf("Expected antithetic sampling to reduce the stdev of pair means by at least 20%%, got %0.2f vs %0.2f", antithetic, plain)
+	}
+}
+
+func TestDeterministicSim(t *testing.T) {
+	rolls := func(seed int64) []float64 {
+		options := googleProto.Clone(DeterministicSimTestOptions).(*proto.SimOptions)
+		options.RandomSeed = seed
+		sim := newSimWithEnv(nil, options, simsignals.CreateSignals())
+
+		var result []float64
+		for i := int64(0); i < 3; i++ {
+			sim.reseedRands(i)
+			result = append(result, sim.Roll(100, 200), sim.RandomExpFloat("test"), sim.RandomFloat("test"))
+		}
+		return result
+	}
+
+	first, second := rolls(1), rolls(1234)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected the same rolls for every seed, got %v and %v", first, second)
+		}
+	}
+	if first[0] != 150 || first[1] != 1 || first[2] != 0.5 {
+		t.Fatalf("expected expected value rolls of [150 1 0.5], got %v", first[:3])
+	}
+
+	sim := newSimWithEnv(nil, DeterministicSimTestOptions, simsignals.CreateSignals())
+	procs := 0
+	for i := 0; i < 1000; i++ {
+		if sim.Proc(0.2, "test") {
+			procs++
+		}
+	}
+	if math.Abs(float64(procs)-200) > 3 {
+		t.Errorf("expected 200 procs at p = 0.2, got %d", procs)
+	}
+}
+
+func incomingDamageTestRequest(iterations int32) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			Iterations:    iterations,
+			RandomSeed:    100,
+			SaveAllValues: true,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+			IncomingDamage: &proto.IncomingDamageSchedule{
+				SpikeDamage:            5000,
+				SpikeInterval:          10,
+				SpikeIntervalVariation: 5,
+			},
+		},
+	}
+}
+
+func TestReplayIteration(t *testing.T) {
+	request := incomingDamageTestRequest(5)
+
+	fullResult := NewSim(request, simsignals.CreateSignals()).run()
+	dtps := fullResult.RaidMetrics.Parties[0].Players[0].Dtps.AllValues
+
+	replayRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+	replayRequest.SimOptions.ReplayIteration = 3
+	replayResult := NewSim(replayRequest, simsignals.CreateSignals()).run()
+
+	if replayResult.IterationsDone != 1 || replayResult.Logs == "" {
+		t.Fatalf("Expected a single logged iteration, got %d iterations", replayResult.IterationsDone)
+	}
+	if replayed := replayResult.RaidMetrics.Parties[0].Players[0].Dtps.Avg; replayed != dtps[3] {
+		t.Fatalf("Expected the replay to match iteration 3 with %0.3f dtps, got %0.3f", dtps[3], replayed)
+	}
+}
+
 var result float64
 
 func BenchmarkRnds(b *testing.B) {
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+// Amount of each combat rating needed for 1% of its effect, or for one point
+// of mastery.
+type RatingConversions struct {
+	HastePerPercent       float64
+	CritPerPercent        float64
+	PhysicalHitPerPercent float64
+	SpellHitPerPercent    float64
+	ExpertisePerPercent   float64
+	DodgePerPercent       float64
+	ParryPerPercent       float64
+	MasteryPerPoint       float64
+}
+
+// Conversions by unit level. Supporting another level only takes adding its row.
+var ratingConversionsByLevel = map[int32]*RatingConversions{
+	CharacterLevel: {
+		HastePerPercent:       HasteRatingPerHastePercent,
+		CritPerPercent:        CritRatingPerCritPercent,
+		PhysicalHitPerPercent: PhysicalHitRatingPerHitPercent,
+		SpellHitPerPercent:    SpellHitRatingPerHitPercent,
+		ExpertisePerPercent:   ExpertisePerQuarterPercentReduction * 4,
+		DodgePerPercent:       DodgeRatingPerDodgePercent,
+		ParryPerPercent:       ParryRatingPerParryPercent,
+		MasteryPerPoint:       MasteryRatingPerMasteryPoint,
+	},
+}
+
+// Conversions at CharacterLevel, which all players and pets are.
+var Ratings = RatingConversionsAtLevel(CharacterLevel)
+
+// Returns the conversions at the given level, or at CharacterLevel for levels
+// without their own.
+func RatingConversionsAtLevel(level int32) *RatingConversions {
+	if conversions, ok := ratingConversionsByLevel[level]; ok {
+		return conversions
+	}
+	return ratingConversionsByLevel[CharacterLevel]
+}
+
+func (rc *RatingConversions) HastePercent(rating float64) float64 {
+	return rating / rc.HastePerPercent
+}
+
+// Speed multiplier from haste rating, e.g. 1.1 for 10% haste.
+func (rc *RatingConversions) HasteMultiplier(rating float64) float64 {
+	return 1 + rc.HastePercent(rating)/100
+}
+
+func (rc *RatingConversions) CritPercent(rating float64) float64 {
+	return rating / rc.CritPerPercent
+}
+
+func (rc *RatingConversions) PhysicalHitPercent(rating float64) float64 {
+	return rating / rc.PhysicalHitPerPercent
+}
+
+func (rc *RatingConversions) SpellHitPercent(rating float64) float64 {
+	return rating / rc.SpellHitPerPercent
+}
+
+func (rc *RatingConversions) ExpertisePercent(rating float64) float64 {
+	return rating / rc.ExpertisePerPercent
+}
+
+func (rc *RatingConversions) DodgePercent(rating float64) float64 {
+	return rating / rc.DodgePerPercent
+}
+
+func (rc *RatingConversions) ParryPercent(rating float64) float64 {
+	return rating / rc.ParryPerPercent
+}
+
+func (rc *RatingConversions) MasteryPoints(rating float64) float64 {
+	return rating / rc.MasteryPerPoint
+}
+
+// Rating needed for the given percentage of the stat's effect, or for the
+// given mastery points. Hit rating converts at the physical hit rate.
+func (rc *RatingConversions) RatingForPercent(stat stats.Stat, percent float64) float64 {
+	switch stat {
+	case stats.HasteRating:
+		return percent * rc.HastePerPercent
+	case stats.CritRating:
+		return percent * rc.CritPerPercent
+	case stats.HitRating:
+		return percent * rc.PhysicalHitPerPercent
+	case stats.ExpertiseRating:
+		return percent * rc.ExpertisePerPercent
+	case stats.DodgeRating:
+		return percent * rc.DodgePerPercent
+	case stats.ParryRating:
+		return percent * rc.ParryPerPercent
+	case stats.MasteryRating:
+		return percent * rc.MasteryPerPoint
+	default:
+		panic("Not a combat rating: " + stat.StatName())
+	}
+}
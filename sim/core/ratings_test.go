@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestRatingConversionsRoundTrip(t *testing.T) {
+	conversions := map[stats.Stat]func(float64) float64{
+		stats.HasteRating:     Ratings.HastePercent,
+		stats.CritRating:      Ratings.CritPercent,
+		stats.HitRating:       Ratings.PhysicalHitPercent,
+		stats.ExpertiseRating: Ratings.ExpertisePercent,
+		stats.DodgeRating:     Ratings.DodgePercent,
+		stats.ParryRating:     Ratings.ParryPercent,
+		stats.MasteryRating:   Ratings.MasteryPoints,
+	}
+
+	for stat, toPercent := range conversions {
+		if percent := toPercent(Ratings.RatingForPercent(stat, 7.5)); !WithinToleranceFloat64(7.5, percent, 1e-9) {
+			t.Fatalf("%s: expected 7.5%% back, got %0.4f", stat.StatName(), percent)
+		}
+	}
+
+	if multiplier := Ratings.HasteMultiplier(HasteRatingPerHastePercent * 10); !WithinToleranceFloat64(1.1, multiplier, 1e-9) {
+		t.Fatalf("Expected a 1.1 haste multiplier for 10%% haste, got %0.4f", multiplier)
+	}
+	if percent := Ratings.ExpertisePercent(ExpertisePerQuarterPercentReduction); !WithinToleranceFloat64(0.25, percent, 1e-9) {
+		t.Fatalf("Expected 0.25%% per quarter percent of expertise rating, got %0.4f", percent)
+	}
+}
+
+func TestRatingConversionsFallBackToCharacterLevel(t *testing.T) {
+	if RatingConversionsAtLevel(CharacterLevel+3) != Ratings {
+		t.Fatalf("Expected levels without their own conversions to use the player level ones")
+	}
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Default maximum number of results kept by a ResultCache.
+const defaultMaxCachedResults = 4096
+
+// ResultCache keeps the results of finished sims, keyed by a hash of the
+// complete request, so identical requests are never simmed twice. This is
+// common in bulk comparisons, where many combos share the same base setup.
+//
+// Only requests with SimOptions.use_result_cache set are looked up or stored.
+type ResultCache struct {
+	mu      sync.Mutex
+	results map[string]*proto.RaidSimResult
+
+	MaxResults int
+}
+
+// Result cache shared by all sims of this process.
+var SharedResultCache = NewResultCache()
+
+func NewResultCache() *ResultCache {
+	return &ResultCache{
+		results:    make(map[string]*proto.RaidSimResult),
+		MaxResults: defaultMaxCachedResults,
+	}
+}
+
+func (cache *ResultCache) key(rsr *proto.RaidSimRequest, skipPresim bool) string {
+	key := HashRequest(rsr)
+	if skipPresim {
+		key += "-nopresim"
+	}
+	return key
+}
+
+// Returns a copy of the cached result for the given request, or nil.
+func (cache *ResultCache) Get(rsr *proto.RaidSimRequest, skipPresim bool) *proto.RaidSimResult {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	result, ok := cache.results[cache.key(rsr, skipPresim)]
+	if !ok {
+		return nil
+	}
+	return googleProto.Clone(result).(*proto.RaidSimResult)
+}
+
+// Stores a copy of the result for the given request. Failed or aborted sims and
+// results beyond MaxResults are not stored.
+func (cache *ResultCache) Put(rsr *proto.RaidSimRequest, skipPresim bool, result *proto.RaidSimResult) {
+	if result == nil || result.Error != nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if len(cache.results) >= cache.MaxResults {
+		return
+	}
+	cache.results[cache.key(rsr, skipPresim)] = googleProto.Clone(result).(*proto.RaidSimResult)
+}
+
+func (cache *ResultCache) Len() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return len(cache.results)
+}
+
+// Wraps runner so that it's skipped for requests with a cached result. The
+// progress channel receives the cached result as the final result and is closed
+// like the runner would.
+func (cache *ResultCache) wrap(runner raidSimRunner) raidSimRunner {
+	return func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) *proto.RaidSimResult {
+		if !rsr.SimOptions.UseResultCache {
+			return runner(rsr, progress, skipPresim, signals)
+		}
+
+		if result := cache.Get(rsr, skipPresim); result != nil {
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					TotalIterations:     rsr.SimOptions.Iterations,
+					CompletedIterations: rsr.SimOptions.Iterations,
+					Dps:                 result.RaidMetrics.GetDps().GetAvg(),
+					Hps:                 result.RaidMetrics.GetHps().GetAvg(),
+					FinalRaidResult:     result,
+				}
+				if !rsr.SimOptions.IsTest {
+					close(progress)
+				}
+			}
+			return result
+		}
+
+		result := runner(rsr, progress, skipPresim, signals)
+		if !signals.Abort.IsTriggered() {
+			cache.Put(rsr, skipPresim, result)
+		}
+		return result
+	}
+}
+
+// Exports all cached results, tagged with the current sim and database versions.
+func (cache *ResultCache) Export() *proto.ResultCacheFile {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	file := &proto.ResultCacheFile{
+		SimVersion:      SimVersion,
+		DatabaseVersion: DatabaseVersion,
+	}
+	for key, result := range cache.results {
+		file.Entries = append(file.Entries, &proto.ResultCacheEntry{
+			RequestHash: key,
+			Result:      result,
+		})
+	}
+	return file
+}
+
+// Adds the results of an exported cache. Results from a different sim or
+// database version are rejected, as they may no longer be accurate.
+func (cache *ResultCache) Import(file *proto.ResultCacheFile) error {
+	if file.SimVersion != SimVersion || file.DatabaseVersion != DatabaseVersion {
+		return fmt.Errorf("result cache was exported by sim version %q with database %q, but this is sim version %q with database %q",
+			file.SimVersion, file.DatabaseVersion, SimVersion, DatabaseVersion)
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for _, entry := range file.Entries {
+		if len(cache.results) >= cache.MaxResults {
+			break
+		}
+		cache.results[entry.RequestHash] = entry.Result
+	}
+	return nil
+}
+
+// Writes the exported cache to the given file.
+func (cache *ResultCache) WriteFile(path string) error {
+	data, err := googleProto.Marshal(cache.Export())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Imports the cache from the given file. A missing file is not an error.
+func (cache *ResultCache) ReadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	file := &proto.ResultCacheFile{}
+	if err := googleProto.Unmarshal(data, file); err != nil {
+		return fmt.Errorf("failed to parse result cache %s: %w", path, err)
+	}
+	return cache.Import(file)
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func newResultCacheTestRequest(iterations int32) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{Iterations: iterations, UseResultCache: true, IsTest: true},
+	}
+}
+
+func TestResultCacheSkipsIdenticalRequests(t *testing.T) {
+	cache := NewResultCache()
+	runs := 0
+	runner := cache.wrap(func(rsr *proto.RaidSimRequest, _ chan *proto.ProgressMetrics, _ bool, _ simsignals.Signals) *proto.RaidSimResult {
+		runs++
+		return &proto.RaidSimResult{RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: float64(rsr.SimOptions.Iterations)}}}
+	})
+
+	runner(newResultCacheTestRequest(10), nil, false, simsignals.CreateSignals())
+	result := runner(newResultCacheTestRequest(10), nil, false, simsignals.CreateSignals())
+	if runs != 1 || result.RaidMetrics.Dps.Avg != 10 {
+		t.Fatalf("Expected the identical request to reuse the cached result, got %d runs", runs)
+	}
+
+	// Changes to the returned result don't affect the cache.
+	result.RaidMetrics.Dps.Avg = 0
+	if cache.Get(newResultCacheTestRequest(10), false).RaidMetrics.Dps.Avg != 10 {
+		t.Fatalf("Expected the cached result to be unchanged")
+	}
+
+	runner(newResultCacheTestRequest(20), nil, false, simsignals.CreateSignals())
+	runner(newResultCacheTestRequest(10), nil, true, simsignals.CreateSignals())
+	if runs != 3 {
+		t.Fatalf("Expected different requests to be simmed, got %d runs", runs)
+	}
+
+	uncached := newResultCacheTestRequest(10)
+	uncached.SimOptions.UseResultCache = false
+	runner(uncached, nil, false, simsignals.CreateSignals())
+	if runs != 4 || cache.Len() != 3 {
+		t.Fatalf("Expected requests without use_result_cache to bypass the cache")
+	}
+}
+
+func TestResultCacheSkipsErrors(t *testing.T) {
+	cache := NewResultCache()
+	cache.Put(newResultCacheTestRequest(10), false, &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: "error"}})
+	if cache.Len() != 0 {
+		t.Fatalf("Expected failed results not to be cached")
+	}
+}
+
+func TestResultCacheExport(t *testing.T) {
+	cache := NewResultCache()
+	cache.Put(newResultCacheTestRequest(10), false, &proto.RaidSimResult{RaidMetrics: &proto.RaidMetrics{}})
+
+	imported := NewResultCache()
+	if err := imported.Import(cache.Export()); err != nil {
+		t.Fatalf("Failed to import the exported cache: %s", err)
+	}
+	if imported.Get(newResultCacheTestRequest(10), false) == nil {
+		t.Fatalf("Expected the imported cache to contain the result")
+	}
+
+	outdated := cache.Export()
+	outdated.SimVersion = "outdated"
+	if err := NewResultCache().Import(outdated); err == nil {
+		t.Fatalf("Expected results of another sim version to be rejected")
+	}
+}
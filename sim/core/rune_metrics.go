@@ -0,0 +1,85 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Tracks how a pair of rune slots regenerates, to diagnose rune starvation.
+// Runes lost while the pair is full are tracked by its ResourceCapMetrics.
+type RuneMetrics struct {
+	Type proto.ResourceType
+
+	// Metrics for the current iteration.
+	TimeDepleted time.Duration // Time with both runes of the pair spent.
+	RegenTime    time.Duration // Total time taken by the runes which finished regenerating.
+	Regens       int32
+	depletedAt   time.Duration // NeverExpires when not depleted.
+
+	// Aggregate values. These are updated after each iteration.
+	timeDepletedSum float64
+	regenTimeSum    float64
+	regensSum       int64
+	n               int32
+}
+
+func (runeMetrics *RuneMetrics) reset() {
+	runeMetrics.TimeDepleted = 0
+	runeMetrics.RegenTime = 0
+	runeMetrics.Regens = 0
+	runeMetrics.depletedAt = NeverExpires
+}
+
+// Should be called whenever a rune of the pair is spent or regenerated.
+func (runeMetrics *RuneMetrics) update(sim *Simulation, isDepleted bool) {
+	now := max(sim.CurrentTime, 0)
+	if isDepleted {
+		if runeMetrics.depletedAt == NeverExpires {
+			runeMetrics.depletedAt = now
+		}
+	} else if runeMetrics.depletedAt != NeverExpires {
+		runeMetrics.TimeDepleted += now - runeMetrics.depletedAt
+		runeMetrics.depletedAt = NeverExpires
+	}
+}
+
+// Records a rune of the pair which finished regenerating after regenTime.
+func (runeMetrics *RuneMetrics) addRegen(sim *Simulation, regenTime time.Duration) {
+	if sim.CurrentTime < 0 {
+		return
+	}
+	runeMetrics.RegenTime += regenTime
+	runeMetrics.Regens++
+}
+
+// This should be called when a Sim iteration is complete.
+func (runeMetrics *RuneMetrics) doneIteration(sim *Simulation) {
+	if runeMetrics.depletedAt != NeverExpires {
+		runeMetrics.TimeDepleted += sim.CurrentTime - runeMetrics.depletedAt
+		runeMetrics.depletedAt = NeverExpires
+	}
+	runeMetrics.timeDepletedSum += runeMetrics.TimeDepleted.Seconds()
+	runeMetrics.regenTimeSum += runeMetrics.RegenTime.Seconds()
+	runeMetrics.regensSum += int64(runeMetrics.Regens)
+	runeMetrics.n++
+}
+
+func (runeMetrics *RuneMetrics) ToProto() *proto.RuneMetrics {
+	n := float64(max(runeMetrics.n, 1))
+	return &proto.RuneMetrics{
+		Type:                runeMetrics.Type,
+		SecondsDepletedAvg:  runeMetrics.timeDepletedSum / n,
+		RuneCooldownAvg:     runeMetrics.regenTimeSum / float64(max(runeMetrics.regensSum, 1)),
+		RunesRegeneratedAvg: float64(runeMetrics.regensSum) / n,
+	}
+}
+
+func (unitMetrics *UnitMetrics) NewRuneMetrics(resourceType proto.ResourceType) *RuneMetrics {
+	newMetrics := &RuneMetrics{
+		Type:       resourceType,
+		depletedAt: NeverExpires,
+	}
+	unitMetrics.runes = append(unitMetrics.runes, newMetrics)
+	return newMetrics
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestRuneMetrics(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.EnableRunicPowerBar(time.Second*10, nil, nil)
+	rp := &fa.runicPowerBar
+	rp.reset(sim)
+	sim.CurrentTime = 0
+
+	metrics := fa.NewBloodRuneMetrics(ActionID{SpellID: 1})
+	rp.spendRune(sim, 0, metrics)
+	rp.spendRune(sim, 0, metrics)
+
+	timeToRunes := func(runeType proto.APLValueRuneType, count int32) time.Duration {
+		value := &APLValueTimeToRuneCount{unit: &fa.Unit, runeType: runeType, count: count}
+		return value.GetDuration(sim)
+	}
+	if timeToRunes(proto.APLValueRuneType_RuneBlood, 1) != time.Second*10 || timeToRunes(proto.APLValueRuneType_RuneBlood, 2) != time.Second*20 {
+		t.Fatalf("Expected Blood runes in 10s and 20s, got %s and %s",
+			timeToRunes(proto.APLValueRuneType_RuneBlood, 1), timeToRunes(proto.APLValueRuneType_RuneBlood, 2))
+	}
+	if timeToRunes(proto.APLValueRuneType_RuneFrost, 2) != 0 {
+		t.Fatalf("Expected both Frost runes to be ready")
+	}
+	if timeToRunes(proto.APLValueRuneType_RuneDeath, 1) != NeverExpires {
+		t.Fatalf("Expected no Death runes")
+	}
+
+	sim.CurrentTime = time.Second * 10
+	rp.regenRune(sim, sim.CurrentTime, 0)
+	rp.runeMetrics[0].doneIteration(sim)
+
+	runeMetrics := rp.runeMetrics[0].ToProto()
+	if runeMetrics.SecondsDepletedAvg != 10 || runeMetrics.RuneCooldownAvg != 10 || runeMetrics.RunesRegeneratedAvg != 1 {
+		t.Fatalf("Expected 10s depleted and 1 rune regenerated in 10s, got %v", runeMetrics)
+	}
+}
+
+func TestCombineRuneMetrics(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	unit := &proto.UnitMetrics{}
+	rsrc.addRuneMetrics(unit, &proto.RuneMetrics{SecondsDepletedAvg: 10, RuneCooldownAvg: 10, RunesRegeneratedAvg: 30}, 0.5)
+	rsrc.addRuneMetrics(unit, &proto.RuneMetrics{SecondsDepletedAvg: 20, RuneCooldownAvg: 8, RunesRegeneratedAvg: 10}, 0.5)
+
+	runeMetrics := unit.Runes[0]
+	if runeMetrics.SecondsDepletedAvg != 15 || runeMetrics.RunesRegeneratedAvg != 20 || runeMetrics.RuneCooldownAvg != 9.5 {
+		t.Fatalf("Expected the rune cooldown to be averaged over the regenerated runes, got %v", runeMetrics)
+	}
+}
@@ -31,6 +31,7 @@ type OnRunicPowerGain func(sim *Simulation)
 type RuneMeta struct {
 	regenMulti        float64
 	regenAt           time.Duration // time at which the rune will no longer be spent.
+	regenStartedAt    time.Duration // time at which the rune started regenerating.
 	unscaledRegenLeft time.Duration // time which the rune spent in regen (Unscaled)
 
 	revertAt time.Duration // time at which rune will no longer be kind death.
@@ -54,6 +55,10 @@ type runicPowerBar struct {
 	deathRuneGainMetrics            *ResourceMetrics
 	encounterStartRunicPowerMetrics *ResourceMetrics
 
+	runicPowerCapMetrics *ResourceCapMetrics
+	runeCapMetrics       [3]*ResourceCapMetrics // One per rune pair: blood, frost, unholy.
+	runeMetrics          [3]*RuneMetrics
+
 	spellRunicPowerMetrics map[ActionID]*ResourceMetrics
 	spellBloodRuneMetrics  map[ActionID]*ResourceMetrics
 	spellFrostRuneMetrics  map[ActionID]*ResourceMetrics
@@ -137,6 +142,18 @@ func (rp *runicPowerBar) reset(sim *Simulation) {
 	}
 
 	rp.currentRunicPower = 0
+	rp.runicPowerCapMetrics.Update(sim, false, 0)
+	for pair := range rp.runeCapMetrics {
+		rp.updateRunePairMetrics(sim, int8(pair*2))
+	}
+}
+
+// Updates the capped and depleted states of the rune pair containing slot.
+func (rp *runicPowerBar) updateRunePairMetrics(sim *Simulation, slot int8) {
+	pair := slot / 2
+	pairSpent := rp.runeStates & (isSpents[pair*2] | isSpents[pair*2+1])
+	rp.runeCapMetrics[pair].Update(sim, pairSpent == 0, 0)
+	rp.runeMetrics[pair].update(sim, pairSpent == isSpents[pair*2]|isSpents[pair*2+1])
 }
 
 func (character *Character) EnableRunicPowerBar(runeCD time.Duration, onRuneChange OnRuneChange, onRunicPowerGain OnRunicPowerGain) {
@@ -170,6 +187,17 @@ func (character *Character) EnableRunicPowerBar(runeCD time.Duration, onRuneChan
 	character.runicPowerBar.unholyRuneGainMetrics = character.NewUnholyRuneMetrics(ActionID{OtherID: proto.OtherAction_OtherActionUnholyRuneGain, Tag: 1})
 	character.runicPowerBar.deathRuneGainMetrics = character.NewDeathRuneMetrics(ActionID{OtherID: proto.OtherAction_OtherActionDeathRuneGain, Tag: 1})
 	character.runicPowerBar.encounterStartRunicPowerMetrics = character.NewRunicPowerMetrics(ActionID{OtherID: proto.OtherAction_OtherActionEncounterStart, Tag: 1})
+
+	rp := &character.runicPowerBar
+	rp.runicPowerCapMetrics = character.Metrics.NewResourceCapMetrics(proto.ResourceType_ResourceTypeRunicPower)
+	for pair, resourceType := range []proto.ResourceType{proto.ResourceType_ResourceTypeBloodRune, proto.ResourceType_ResourceTypeFrostRune, proto.ResourceType_ResourceTypeUnholyRune} {
+		rp.runeCapMetrics[pair] = character.Metrics.NewResourceCapMetrics(resourceType)
+		rp.runeMetrics[pair] = character.Metrics.NewRuneMetrics(resourceType)
+		// A pair with both runes ready stops regenerating, losing one rune per rune CD.
+		rp.runeCapMetrics[pair].RegenPerSecond = func() float64 {
+			return 1 / (rp.runeCD.Seconds() * rp.getTotalRegenMultiplier())
+		}
+	}
 }
 
 func (unit *Unit) HasRunicPowerBar() bool {
@@ -215,9 +243,10 @@ func (rp *runicPowerBar) addRunicPowerInternal(sim *Simulation, amount float64,
 	if sim.CurrentTime > 0 {
 		metrics.AddEvent(amount, newRunicPower-rp.currentRunicPower)
 	}
+	rp.runicPowerCapMetrics.Update(sim, newRunicPower >= rp.maxRunicPower, amount-(newRunicPower-rp.currentRunicPower))
 
-	if sim.Log != nil {
-		rp.character.Log(sim, "Gained %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		rp.character.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
 	}
 
 	rp.currentRunicPower = newRunicPower
@@ -249,9 +278,10 @@ func (rp *runicPowerBar) spendRunicPower(sim *Simulation, amount float64, metric
 	if sim.CurrentTime > 0 {
 		metrics.AddEvent(-amount, -amount)
 	}
+	rp.runicPowerCapMetrics.Update(sim, newRunicPower >= rp.maxRunicPower, 0)
 
-	if sim.Log != nil {
-		rp.character.Log(sim, "Spent %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		rp.character.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
 	}
 
 	rp.currentRunicPower = newRunicPower
@@ -373,6 +403,40 @@ func (rp *runicPowerBar) bothRunesReadyAt(sim *Simulation, slot int8) time.Durat
 	}
 }
 
+// Returns when count of the given rune slots are ready at the current regen
+// rate, or NeverExpires if there are fewer slots.
+func (rp *runicPowerBar) runeSlotsReadyAt(sim *Simulation, slots []int8, count int32) time.Duration {
+	if count <= 0 {
+		return sim.CurrentTime
+	}
+	if int(count) > len(slots) {
+		return NeverExpires
+	}
+
+	fullRegen := DurationFromSeconds(rp.runeCD.Seconds() * rp.getTotalRegenMultiplier())
+	var readyAts [6]time.Duration
+	for i, slot := range slots {
+		readyAts[i] = rp.runeSlotReadyAt(sim, slot, fullRegen)
+	}
+	slices.Sort(readyAts[:len(slots)])
+	return readyAts[count-1]
+}
+
+// A spent rune which waits for the other rune of its pair starts regenerating
+// once that one is ready.
+func (rp *runicPowerBar) runeSlotReadyAt(sim *Simulation, slot int8, fullRegen time.Duration) time.Duration {
+	if rp.runeStates&isSpents[slot] == 0 {
+		return sim.CurrentTime
+	}
+	if regenAt := rp.runeMeta[slot].regenAt; regenAt != NeverExpires {
+		return regenAt
+	}
+	if otherRegenAt := rp.runeMeta[slot^1].regenAt; otherRegenAt != NeverExpires {
+		return otherRegenAt + fullRegen
+	}
+	return sim.CurrentTime + fullRegen
+}
+
 func (rp *runicPowerBar) NextBloodRuneReadyAt(sim *Simulation) time.Duration {
 	return rp.bothRunesReadyAt(sim, 0)
 }
@@ -612,9 +676,9 @@ func (rp *runicPowerBar) typeAmount(metrics *ResourceMetrics) (string, int8) {
 func (rp *runicPowerBar) gainRuneMetrics(sim *Simulation, metrics *ResourceMetrics, gainAmount int8) {
 	metrics.AddEvent(float64(gainAmount), float64(gainAmount))
 
-	if sim.Log != nil {
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
 		name, currRunes := rp.typeAmount(metrics)
-		rp.character.Log(sim, "Gained %0.3f %s rune from %s (%d --> %d).", float64(gainAmount), name, metrics.ActionID, currRunes-gainAmount, currRunes)
+		rp.character.LogAt(sim, LogCategoryResources, LogLevelDebug, "Gained %0.3f %s rune from %s (%d --> %d).", float64(gainAmount), name, metrics.ActionID, currRunes-gainAmount, currRunes)
 	}
 }
 
@@ -622,13 +686,14 @@ func (rp *runicPowerBar) gainRuneMetrics(sim *Simulation, metrics *ResourceMetri
 func (rp *runicPowerBar) spendRuneMetrics(sim *Simulation, metrics *ResourceMetrics, spendAmount int8) {
 	metrics.AddEvent(-float64(spendAmount), -float64(spendAmount))
 
-	if sim.Log != nil {
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
 		name, currRunes := rp.typeAmount(metrics)
-		rp.character.Log(sim, "Spent 1.000 %s rune from %s (%d --> %d).", name, metrics.ActionID, currRunes+spendAmount, currRunes)
+		rp.character.LogAt(sim, LogCategoryResources, LogLevelDebug, "Spent 1.000 %s rune from %s (%d --> %d).", name, metrics.ActionID, currRunes+spendAmount, currRunes)
 	}
 }
 
 func (rp *runicPowerBar) regenRune(sim *Simulation, regenAt time.Duration, slot int8) {
+	rp.runeMetrics[slot/2].addRegen(sim, regenAt-rp.runeMeta[slot].regenStartedAt)
 	rp.regenRuneInternal(sim, regenAt, slot)
 
 	metrics := rp.bloodRuneGainMetrics
@@ -647,6 +712,7 @@ func (rp *runicPowerBar) regenRuneInternal(sim *Simulation, regenAt time.Duratio
 	rp.lastRegen = append(rp.lastRegen, slot)
 	rp.runeStates ^= isSpents[slot] // unset spent flag for this rune.
 	rp.runeMeta[slot].regenAt = NeverExpires
+	rp.updateRunePairMetrics(sim, slot)
 
 	// if other slot rune is spent start and not regening start regen
 	otherSlot := (slot/2)*2 + (slot+1)%2
@@ -945,7 +1011,7 @@ func (rp *runicPowerBar) GetRuneRegenMultiplier() float64 {
 }
 
 func (rp *runicPowerBar) getTotalRegenMultiplier() float64 {
-	hasteMultiplier := 1.0 + rp.character.GetStat(stats.HasteRating)/(100*HasteRatingPerHastePercent)
+	hasteMultiplier := Ratings.HasteMultiplier(rp.character.GetStat(stats.HasteRating))
 	totalMultiplier := 1 / (hasteMultiplier * rp.runeRegenMultiplier)
 	return totalMultiplier
 }
@@ -977,6 +1043,7 @@ func (rp *runicPowerBar) launchRuneRegen(sim *Simulation, slot int8) {
 	totalMultiplier := rp.getTotalRegenMultiplier()
 
 	rp.runeMeta[slot].regenMulti = totalMultiplier
+	rp.runeMeta[slot].regenStartedAt = sim.CurrentTime
 	rp.runeMeta[slot].regenAt = sim.CurrentTime + DurationFromSeconds(rp.runeCD.Seconds()*totalMultiplier)
 	rp.runeMeta[slot].unscaledRegenLeft = rp.runeCD
 
@@ -1044,6 +1111,7 @@ func (rp *runicPowerBar) Advance(sim *Simulation, newTime time.Duration) {
 func (rp *runicPowerBar) spendRune(sim *Simulation, firstSlot int8, metrics *ResourceMetrics) int8 {
 	slot := rp.findReadyRune(firstSlot)
 	rp.runeStates |= isSpents[slot]
+	rp.updateRunePairMetrics(sim, slot)
 
 	rp.spendRuneMetrics(sim, metrics, 1)
 
@@ -1074,6 +1142,7 @@ func (rp *runicPowerBar) spendDeathRune(sim *Simulation, order []int8, metrics *
 
 	// mark spent bit to spend
 	rp.runeStates |= isSpents[slot]
+	rp.updateRunePairMetrics(sim, slot)
 
 	rp.spendRuneMetrics(sim, metrics, 1)
 
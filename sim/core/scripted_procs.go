@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+var scriptedProcCallbacks = map[proto.ScriptedProc_Trigger]AuraCallback{
+	proto.ScriptedProc_TriggerSpellHitDealt:       CallbackOnSpellHitDealt,
+	proto.ScriptedProc_TriggerPeriodicDamageDealt: CallbackOnPeriodicDamageDealt,
+	proto.ScriptedProc_TriggerHealDealt:           CallbackOnHealDealt,
+	proto.ScriptedProc_TriggerCastComplete:        CallbackOnCastComplete,
+	proto.ScriptedProc_TriggerSpellHitTaken:       CallbackOnSpellHitTaken,
+}
+
+var scriptedProcMasks = map[proto.ScriptedProc_Source]ProcMask{
+	proto.ScriptedProc_SourceMelee:  ProcMaskMelee,
+	proto.ScriptedProc_SourceRanged: ProcMaskRanged,
+	proto.ScriptedProc_SourceSpell:  ProcMaskSpellDamage,
+}
+
+// Registers the procs of the player which are defined as data, see
+// proto.ScriptedProc. These cover the common stat procs so new trinkets can be
+// tried out without writing an item effect.
+func (character *Character) applyScriptedProcs(scriptedProcs []*proto.ScriptedProc) {
+	for i, config := range scriptedProcs {
+		if config.ItemId != 0 && !slices.ContainsFunc(character.Equipment[:], func(item Item) bool { return item.ID == config.ItemId }) {
+			continue
+		}
+		character.registerScriptedProc(i, config)
+	}
+}
+
+func scriptedProcLabel(index int, config *proto.ScriptedProc) string {
+	if config.Name == "" {
+		return fmt.Sprintf("Scripted Proc %d", index+1)
+	}
+	return config.Name
+}
+
+// Returns an error for scripted procs of the player which can't be registered.
+// Labels of the procs are added with addLabel, which rejects labels in use.
+func validateScriptedProcs(player *proto.Player, addLabel func(label string) error) error {
+	for i, config := range player.ScriptedProcs {
+		label := scriptedProcLabel(i, config)
+		if scriptedProcCallback(config) == 0 {
+			return fmt.Errorf("%s: scripted proc %q has no triggers", player.Name, label)
+		}
+		if err := addLabel(label); err != nil {
+			return err
+		}
+		if err := addLabel(label + " Trigger"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Callbacks of all triggers of the proc, 0 if it has no known triggers.
+func scriptedProcCallback(config *proto.ScriptedProc) AuraCallback {
+	var callback AuraCallback
+	for _, trigger := range config.Triggers {
+		callback |= scriptedProcCallbacks[trigger]
+	}
+	return callback
+}
+
+// Procs without triggers are rejected with the request, see validateScriptedProcs.
+func (character *Character) registerScriptedProc(index int, config *proto.ScriptedProc) *Aura {
+	label := scriptedProcLabel(index, config)
+
+	var actionID ActionID
+	if config.Id != nil {
+		actionID = ProtoToActionID(config.Id)
+	}
+
+	callback := scriptedProcCallback(config)
+	if callback == 0 {
+		return nil
+	}
+
+	var procMask ProcMask
+	for _, source := range config.Sources {
+		procMask |= scriptedProcMasks[source]
+	}
+
+	var bonus stats.Stats
+	if config.Stats != nil {
+		bonus = stats.FromUnitStatsProto(config.Stats)
+	}
+	duration := DurationFromSeconds(config.DurationSeconds)
+
+	var activate func(sim *Simulation)
+	if config.MaxStacks > 1 {
+		stackingAura, _ := character.NewTemporaryStatBuffWithStacks(TemporaryStatBuffWithStacksConfig{
+			AuraLabel:     label,
+			ActionID:      actionID,
+			BonusPerStack: bonus,
+			MaxStacks:     config.MaxStacks,
+			Duration:      duration,
+		})
+		activate = func(sim *Simulation) {
+			stackingAura.Activate(sim)
+			stackingAura.AddStack(sim)
+		}
+	} else {
+		statAura := character.NewTemporaryStatsAura(label, actionID, bonus, duration)
+		activate = func(sim *Simulation) {
+			statAura.Activate(sim)
+		}
+	}
+
+	outcome := OutcomeLanded
+	if config.RequireCrit {
+		outcome = OutcomeCrit
+	}
+	if callback.Matches(CallbackOnCastComplete) {
+		// Cast callbacks have no result to check.
+		outcome = OutcomeEmpty
+	}
+
+	var dpm *DynamicProcManager
+	if config.Ppm > 0 {
+		dpm = character.NewLegacyPPMManager(config.Ppm, Ternary(procMask != 0, procMask, ProcMaskMeleeOrRanged))
+	}
+
+	return MakeProcTriggerAura(&character.Unit, ProcTrigger{
+		Name:       label + " Trigger",
+		ActionID:   actionID,
+		Callback:   callback,
+		ProcMask:   procMask,
+		Outcome:    outcome,
+		ProcChance: Ternary(dpm == nil, config.ProcChance, 0),
+		DPM:        dpm,
+		ICD:        DurationFromSeconds(config.IcdSeconds),
+		Handler: func(sim *Simulation, _ *Spell, _ *SpellResult) {
+			activate(sim)
+		},
+	})
+}
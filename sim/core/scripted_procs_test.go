@@ -0,0 +1,83 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestScriptedProcGrantsStats(t *testing.T) {
+	var spell *Spell
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		character := env.Raid.Parties[0].Players[0].GetCharacter()
+		for i, source := range []proto.ScriptedProc_Source{proto.ScriptedProc_SourceSpell, proto.ScriptedProc_SourceMelee} {
+			character.registerScriptedProc(i, &proto.ScriptedProc{
+				Triggers:        []proto.ScriptedProc_Trigger{proto.ScriptedProc_TriggerSpellHitDealt},
+				Sources:         []proto.ScriptedProc_Source{source},
+				ProcChance:      1,
+				IcdSeconds:      20,
+				DurationSeconds: 10,
+				Stats:           &proto.UnitStats{Stats: stats.Stats{stats.MasteryRating: 100}.ToProtoArray()},
+			})
+		}
+		spell = character.RegisterSpell(SpellConfig{
+			ActionID:         ActionID{SpellID: 1},
+			SpellSchool:      SpellSchoolFire,
+			ProcMask:         ProcMaskSpellDamage,
+			DamageMultiplier: 1,
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, 100, spell.OutcomeAlwaysHit)
+			},
+		})
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	target := sim.Encounter.AllTargetUnits[0]
+	spellProc := character.GetAura("Scripted Proc 1")
+	meleeProc := character.GetAura("Scripted Proc 2")
+	baseMastery := character.GetStat(stats.MasteryRating)
+
+	spell.SkipCastAndApplyEffects(sim, target)
+	if !spellProc.IsActive() || meleeProc.IsActive() {
+		t.Fatalf("Expected only the spell proc to trigger from a spell hit")
+	}
+	if mastery := character.GetStat(stats.MasteryRating); mastery != baseMastery+100 {
+		t.Fatalf("Expected the proc to grant 100 Mastery, got %0.1f", mastery-baseMastery)
+	}
+
+	// The ICD outlasts the buff.
+	sim.advance(time.Second * 15)
+	spell.SkipCastAndApplyEffects(sim, target)
+	if spellProc.IsActive() {
+		t.Fatalf("Expected no proc during the ICD")
+	}
+	sim.advance(time.Second * 20)
+	spell.SkipCastAndApplyEffects(sim, target)
+	if !spellProc.IsActive() {
+		t.Fatalf("Expected a proc after the ICD")
+	}
+}
+
+func TestRaidSimRejectsInvalidScriptedProc(t *testing.T) {
+	rsr := &proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:          "Caster",
+			Class:         proto.Class_ClassShaman,
+			Spec:          &proto.Player_ElementalShaman{},
+			Equipment:     &proto.EquipmentSpec{},
+			ScriptedProcs: []*proto.ScriptedProc{{Name: "Proc"}},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 180,
+			Targets:  []*proto.Target{{Name: "target", Level: 90}},
+		},
+		SimOptions: &proto.SimOptions{Iterations: 1},
+	}
+
+	result := RunRaidSim(rsr)
+	if result.Error == nil || !strings.Contains(result.Error.Message, "no triggers") {
+		t.Fatalf("Expected the request to be rejected, got %v", result.Error)
+	}
+}
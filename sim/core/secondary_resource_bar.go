@@ -54,9 +54,9 @@ func (bar *DefaultSecondaryResourceBarImpl) Gain(sim *Simulation, amount int32,
 	amountGained := bar.value - oldValue
 	metrics := bar.GetMetric(action)
 	metrics.AddEvent(float64(amount), float64(amountGained))
-	if sim.Log != nil {
-		bar.unit.Log(
-			sim,
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		bar.unit.LogAt(
+			sim, LogCategoryResources, LogLevelDebug,
 			"Gained %d %s from %s (%d --> %d) of %d total.",
 			amountGained,
 			proto.SecondaryResourceType_name[int32(bar.config.Type)],
@@ -99,9 +99,9 @@ func (bar *DefaultSecondaryResourceBarImpl) Spend(sim *Simulation, amount int32,
 	}
 
 	metrics := bar.GetMetric(action)
-	if sim.Log != nil {
-		bar.unit.Log(
-			sim,
+	if sim.LogEnabled(LogCategoryResources, LogLevelDebug) {
+		bar.unit.LogAt(
+			sim, LogCategoryResources, LogLevelDebug,
 			"Spent %d %s from %s (%d --> %d) of %d total.",
 			amount,
 			proto.SecondaryResourceType_name[int32(bar.config.Type)],
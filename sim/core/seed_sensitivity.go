@@ -0,0 +1,149 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"time"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+const defaultSeedSensitivitySeeds = 10
+
+// Runs the same request under several master seeds, and reports how much the
+// mean dps moves between them. Helps tell real differences between builds
+// apart from seed noise in low iteration comparisons.
+func SeedSensitivity(signals simsignals.Signals, request *proto.SeedSensitivityRequest, progress chan *proto.ProgressMetrics) *proto.SeedSensitivityResult {
+	result := runSeedSensitivity(signals, request, progress)
+
+	if progress != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalSeedSensitivityResult: result,
+		}
+		close(progress)
+	}
+
+	return result
+}
+
+func runSeedSensitivity(signals simsignals.Signals, request *proto.SeedSensitivityRequest, progress chan *proto.ProgressMetrics) (result *proto.SeedSensitivityResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.SeedSensitivityResult{
+				Error: &proto.ErrorOutcome{
+					Message: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+				},
+			}
+		}
+		signals.Abort.Trigger()
+	}()
+
+	baseSettings := request.GetBaseSettings()
+	if len(baseSettings.GetRaid().GetParties()) == 0 || len(baseSettings.Raid.Parties[0].Players) == 0 {
+		return &proto.SeedSensitivityResult{
+			Error: &proto.ErrorOutcome{Message: "seed sensitivity: no player found"},
+		}
+	}
+
+	player := baseSettings.Raid.Parties[0].Players[0]
+	if player.GetDatabase() != nil {
+		addToDatabase(player.GetDatabase())
+	}
+	// reduce to just the player, like bulk sims.
+	baseSettings.Raid.Parties = []*proto.Party{baseSettings.Raid.Parties[0]}
+	baseSettings.Raid.Parties[0].Players = []*proto.Player{player}
+	// clean to reduce memory
+	player.Database = nil
+
+	if baseSettings.SimOptions == nil {
+		baseSettings.SimOptions = &proto.SimOptions{}
+	}
+	if baseSettings.SimOptions.Deterministic {
+		return &proto.SeedSensitivityResult{
+			Error: &proto.ErrorOutcome{Message: "seed sensitivity: deterministic sims do not depend on the seed"},
+		}
+	}
+
+	numSeeds := request.NumSeeds
+	if numSeeds <= 0 {
+		numSeeds = defaultSeedSensitivitySeeds
+	}
+	iterations := request.IterationsPerSeed
+	if iterations <= 0 {
+		iterations = defaultIterationsPerCombo
+	}
+
+	baseSeed := baseSettings.SimOptions.RandomSeed
+	if baseSeed == 0 {
+		baseSeed = time.Now().UnixNano()
+	}
+
+	runs := make([]*proto.SeedSensitivityRun, numSeeds)
+	combos := make([]singleBulkSim, numSeeds)
+	runsByRequest := make(map[*proto.RaidSimRequest]*proto.SeedSensitivityRun, numSeeds)
+
+	for i := range runs {
+		simRequest := goproto.Clone(baseSettings).(*proto.RaidSimRequest)
+		// Seeds are spaced by the iterations, same as the splits of concurrent sims.
+		simRequest.SimOptions.RandomSeed = baseSeed + int64(i)*int64(iterations)
+
+		runs[i] = &proto.SeedSensitivityRun{
+			RandomSeed: simRequest.SimOptions.RandomSeed,
+		}
+		runsByRequest[simRequest] = runs[i]
+
+		combos[i] = singleBulkSim{
+			req: simRequest,
+			cl:  &raidSimRequestChangeLog{},
+			eq:  &equipmentSubstitution{},
+		}
+	}
+
+	bulk := &bulkSimRunner{
+		SingleRaidSimRunner: runSim,
+	}
+	simResults, _, errorOutcome := bulk.getRankedResults(signals, combos, iterations, progress)
+	if errorOutcome != nil {
+		return &proto.SeedSensitivityResult{Error: errorOutcome}
+	}
+
+	expectedStdev := 0.0
+	for _, simResult := range simResults {
+		dps := simResult.Result.GetRaidMetrics().GetParties()[0].GetPlayers()[0].GetDps()
+		runsByRequest[simResult.Request].Dps = dps.GetAvg()
+		expectedStdev += dps.GetStdev() / math.Sqrt(float64(iterations))
+	}
+
+	result = summarizeSeedSensitivity(runs)
+	result.ExpectedStdev = expectedStdev / float64(len(runs))
+	return result
+}
+
+func summarizeSeedSensitivity(runs []*proto.SeedSensitivityRun) *proto.SeedSensitivityResult {
+	result := &proto.SeedSensitivityResult{
+		Runs:   runs,
+		DpsMin: math.Inf(1),
+		DpsMax: math.Inf(-1),
+	}
+
+	for _, run := range runs {
+		result.DpsAvg += run.Dps
+		result.DpsMin = min(result.DpsMin, run.Dps)
+		result.DpsMax = max(result.DpsMax, run.Dps)
+	}
+	result.DpsAvg /= float64(len(runs))
+
+	if len(runs) > 1 {
+		for _, run := range runs {
+			result.DpsStdev += (run.Dps - result.DpsAvg) * (run.Dps - result.DpsAvg)
+		}
+		// Sample standard deviation, as each run is a sample of the possible seeds.
+		result.DpsStdev = math.Sqrt(result.DpsStdev / float64(len(runs)-1))
+	}
+
+	return result
+}
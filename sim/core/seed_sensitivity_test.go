@@ -0,0 +1,25 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestSummarizeSeedSensitivity(t *testing.T) {
+	result := summarizeSeedSensitivity([]*proto.SeedSensitivityRun{
+		{RandomSeed: 1, Dps: 1000},
+		{RandomSeed: 2, Dps: 1020},
+		{RandomSeed: 3, Dps: 1040},
+	})
+
+	if result.DpsAvg != 1020 {
+		t.Errorf("Expected an average of 1020 dps, got %0.2f", result.DpsAvg)
+	}
+	if result.DpsMin != 1000 || result.DpsMax != 1040 {
+		t.Errorf("Expected a range of 1000-1040 dps, got %0.2f-%0.2f", result.DpsMin, result.DpsMax)
+	}
+	if !WithinToleranceFloat64(20, result.DpsStdev, 0.0001) {
+		t.Errorf("Expected a sample stdev of 20 dps, got %0.4f", result.DpsStdev)
+	}
+}
@@ -39,8 +39,8 @@ func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
 	shield.Spell.SpellMetrics[target.UnitIndex].Hits++
 
-	if sim.Log != nil {
-		caster.Log(sim, "%s %s Hit for %0.3f shielding. (Threat: %0.3f)", target.LogLabel(), shield.Spell.ActionID, shieldAmount, threat)
+	if sim.LogEnabled(LogCategoryDamage, LogLevelInfo) {
+		caster.LogAt(sim, LogCategoryDamage, LogLevelInfo, "%s %s Hit for %0.3f shielding. (Threat: %0.3f)", target.LogLabel(), shield.Spell.ActionID, shieldAmount, threat)
 	}
 }
 
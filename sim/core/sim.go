@@ -15,6 +15,7 @@ import (
 
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
 )
 
 type Task interface {
@@ -33,8 +34,14 @@ type Simulation struct {
 	isTest    bool
 	testRands map[string]Rand
 
+	// See SimOptions.deterministic.
+	deterministic bool
+
+	// Whether RandomFloat returns 1 - u, for the second iteration of each antithetic pair.
+	mirrorRands bool
+
 	// Current Simulation State
-	pendingActions    []*PendingAction
+	pendingActions    pendingActionQueue
 	pendingActionPool *sync.Pool
 	CurrentTime       time.Duration // duration that has elapsed in the sim since starting
 	Duration          time.Duration // Duration of current iteration
@@ -43,7 +50,19 @@ type Simulation struct {
 	ProgressReport func(*proto.ProgressMetrics)
 	Signals        simsignals.Signals
 
-	Log func(string, ...interface{})
+	Log       func(string, ...interface{})
+	logFilter logFilter
+
+	// Spell whose damage multipliers are broken down in the logs, see
+	// SimOptions.debug_multipliers_spell.
+	debugMultipliersSpell ActionID
+
+	// Damage rolls of the logged iteration, see SimOptions.audit_damage_variance.
+	varianceAudit *varianceAudit
+
+	// Result of the same request without human errors, see
+	// APLRotation.human_error_chance.
+	perfectPlayResult *proto.RaidSimResult
 
 	executePhase int32 // 20, 25, 35, 45 or 90 for the respective execute range, 100 otherwise
 
@@ -52,6 +71,10 @@ type Simulation struct {
 	nextExecuteDuration time.Duration
 	nextExecuteDamage   float64
 
+	// Start of the span duration based execute phases are spread over, which
+	// moves up when the execute focus despawns.
+	executeStart time.Duration
+
 	endOfCombatDuration time.Duration
 	endOfCombatDamage   float64
 
@@ -65,6 +88,10 @@ type Simulation struct {
 	tasks       []Task
 
 	isInPrepull bool
+
+	// Innermost spell whose effects are currently being applied. Used to
+	// attribute aura applications to the spell which directly caused them.
+	activeSpell *Spell
 }
 
 func (sim *Simulation) rescheduleTracker(trackerTime time.Duration) {
@@ -72,13 +99,23 @@ func (sim *Simulation) rescheduleTracker(trackerTime time.Duration) {
 }
 
 func (sim *Simulation) addTracker(tracker *auraTracker) {
-	sim.trackers = append(sim.trackers, tracker)
+	if !tracker.tracked {
+		tracker.tracked = true
+		sim.trackers = append(sim.trackers, tracker)
+	}
 	sim.rescheduleTracker(tracker.minExpires)
 }
 
 func (sim *Simulation) removeTracker(tracker *auraTracker) {
 	if idx := slices.Index(sim.trackers, tracker); idx != -1 {
+		tracker.tracked = false
 		sim.trackers = removeBySwappingToBack(sim.trackers, idx)
+
+		// Drop the removed tracker's expiration, so it doesn't wake the sim up.
+		sim.minTrackerTime = NeverExpires
+		for _, t := range sim.trackers {
+			sim.minTrackerTime = min(sim.minTrackerTime, t.minExpires)
+		}
 	}
 }
 
@@ -93,6 +130,11 @@ func (sim *Simulation) addWeaponAttack(weaponAttack *WeaponAttack) {
 func (sim *Simulation) removeWeaponAttack(weaponAttack *WeaponAttack) {
 	if idx := slices.Index(sim.weaponAttacks, weaponAttack); idx != -1 {
 		sim.weaponAttacks = removeBySwappingToBack(sim.weaponAttacks, idx)
+
+		sim.minWeaponAttackTime = NeverExpires
+		for _, wa := range sim.weaponAttacks {
+			sim.minWeaponAttackTime = min(sim.minWeaponAttackTime, wa.swingAt)
+		}
 	}
 }
 
@@ -111,10 +153,26 @@ func (sim *Simulation) RemoveTask(task Task) {
 }
 
 func RunSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.RaidSimResult {
-	return runSim(rsr, progress, false, signals)
+	return SharedResultCache.wrap(runSimOnWorkers)(rsr, progress, false, signals)
+}
+
+func runSimOnWorkers(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) *proto.RaidSimResult {
+	if rsr.SimOptions.NumWorkerThreads > 1 && !IsRunningInWasm() {
+		// See SimOptions.num_worker_threads.
+		return runSimConcurrent(rsr, progress, signals)
+	}
+	return runSim(rsr, progress, skipPresim, signals)
+}
+
+func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) *proto.RaidSimResult {
+	return runSimWithCache(rsr, progress, skipPresim, signals, nil)
 }
 
-func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) (result *proto.RaidSimResult) {
+// Runs a sim, reusing an environment from envCache if one is provided.
+func runSimWithCache(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals, envCache *EnvironmentCache) (result *proto.RaidSimResult) {
+	// Attached to every final result, including errors and aborted sims.
+	metadata := NewSimMetadata(rsr)
+
 	if !rsr.SimOptions.IsTest {
 		defer func() {
 			if err := recover(); err != nil {
@@ -128,7 +186,8 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 
 				errStr += "\nStack Trace:\n" + string(debug.Stack())
 				result = &proto.RaidSimResult{
-					Error: &proto.ErrorOutcome{Message: errStr},
+					Error:    &proto.ErrorOutcome{Message: errStr},
+					Metadata: metadata,
 				}
 				if progress != nil {
 					progress <- &proto.ProgressMetrics{
@@ -142,7 +201,26 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}()
 	}
 
-	sim := NewSim(rsr, signals)
+	if errorResult := validateRaidSimRequest(rsr); errorResult != nil {
+		errorResult.Metadata = metadata
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{FinalRaidResult: errorResult}
+		}
+		return errorResult
+	}
+
+	var sim *Simulation
+	var releaseEnv func()
+	if envCache != nil {
+		var env *Environment
+		env, releaseEnv = envCache.acquire(rsr)
+		sim = newSimWithEnv(env, rsr.SimOptions, signals)
+		if envCache.ShiftBonusStats {
+			env.Raid.setBonusStats(sim, rsr.Raid)
+		}
+	} else {
+		sim = NewSim(rsr, signals)
+	}
 
 	if !skipPresim {
 		if progress != nil {
@@ -154,6 +232,7 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}
 		presimResult := sim.runPresims(rsr)
 		if presimResult != nil && presimResult.Error != nil {
+			presimResult.Metadata = metadata
 			if progress != nil {
 				progress <- &proto.ProgressMetrics{
 					TotalIterations: sim.Options.Iterations,
@@ -168,6 +247,9 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 				PresimRunning:   false,
 			}
 			sim.ProgressReport = func(progMetric *proto.ProgressMetrics) {
+				if progMetric.FinalRaidResult != nil {
+					progMetric.FinalRaidResult.Metadata = metadata
+				}
 				progress <- progMetric
 			}
 			runtime.Gosched() // allow time for message to make it back out.
@@ -180,12 +262,38 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}
 	}
 
+	if hasHumanError(rsr.Raid) {
+		sim.perfectPlayResult = runSim(withoutHumanError(rsr), nil, skipPresim, signals)
+	}
+
 	// using a variable here allows us to mutate it in the deferred recover, sending out error info
 	result = sim.run()
+	if result.Metadata == nil {
+		// Already set if the result was reported as progress.
+		result.Metadata = metadata
+	}
+
+	// Aborted sims may have stopped mid iteration, don't hand those environments out again.
+	if releaseEnv != nil && result.Error == nil {
+		releaseEnv()
+	}
 
 	return result
 }
 
+// Returns an error result for requests containing user defined data which can't
+// be simmed, or nil if the request is valid.
+func validateRaidSimRequest(rsr *proto.RaidSimRequest) *proto.RaidSimResult {
+	for _, party := range rsr.GetRaid().GetParties() {
+		for _, player := range party.Players {
+			if err := validateCustomEffects(player); err != nil {
+				return &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+			}
+		}
+	}
+	return nil
+}
+
 func NewSim(rsr *proto.RaidSimRequest, signals simsignals.Signals) *Simulation {
 	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
 	return newSimWithEnv(env, rsr.SimOptions, signals)
@@ -197,6 +305,18 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions, signals simsi
 		rseed = time.Now().UnixNano()
 	}
 
+	if simOptions.Deterministic && simOptions.Iterations != 1 {
+		// Every iteration would be the same.
+		simOptions = googleProto.Clone(simOptions).(*proto.SimOptions)
+		simOptions.Iterations = 1
+	}
+	if simOptions.ReplayIteration > 0 && (simOptions.Iterations != 1 || !simOptions.Debug) {
+		// Only the replayed iteration is run, see SimOptions.replay_iteration.
+		simOptions = googleProto.Clone(simOptions).(*proto.SimOptions)
+		simOptions.Iterations = 1
+		simOptions.Debug = true
+	}
+
 	return &Simulation{
 		Environment: env,
 		Options:     simOptions,
@@ -204,9 +324,15 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions, signals simsi
 		rand:  NewSplitMix(uint64(rseed)),
 		rseed: rseed,
 
-		isTest:    simOptions.IsTest || simOptions.UseLabeledRands,
+		isTest:    simOptions.IsTest || simOptions.UseLabeledRands || simOptions.Deterministic,
 		testRands: make(map[string]Rand),
 
+		deterministic: simOptions.Deterministic,
+
+		logFilter:             newLogFilter(simOptions),
+		debugMultipliersSpell: debugMultipliersSpellFromOptions(simOptions),
+		varianceAudit:         newVarianceAudit(simOptions),
+
 		Signals: signals,
 
 		pendingActionPool: &sync.Pool{
@@ -226,6 +352,10 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions, signals simsi
 // testing we use a separate rand object for each RandomFloat callsite,
 // distinguished by the label string.
 func (sim *Simulation) RandomFloat(label string) float64 {
+	if sim.mirrorRands {
+		// Largest float64 below 1, so the result stays within [0, 1).
+		return (1 - 0x1p-53) - sim.labelRand(label).NextFloat64()
+	}
 	return sim.labelRand(label).NextFloat64()
 }
 
@@ -235,7 +365,10 @@ func (sim *Simulation) labelRand(label string) Rand {
 	}
 
 	labelRng, ok := sim.testRands[label]
-	if !ok {
+	if !ok && sim.deterministic {
+		labelRng = NewGoldenRatioSequence()
+		sim.testRands[label] = labelRng
+	} else if !ok {
 		// Add rseed to the label, so we still have run-run variance for stat weights.
 		labelRng = NewSplitMix(uint64(makeTestRandSeed(sim.rand.GetSeed(), label)))
 		sim.testRands[label] = labelRng
@@ -244,6 +377,12 @@ func (sim *Simulation) labelRand(label string) Rand {
 }
 
 func (sim *Simulation) reseedRands(i int64) {
+	if sim.Options.AntitheticSampling {
+		// Odd iterations mirror the rolls of the previous iteration, see SimOptions.antithetic_sampling.
+		sim.mirrorRands = i%2 == 1
+		i -= i % 2
+	}
+
 	rseed := sim.Options.RandomSeed + i
 	sim.rand.Seed(rseed)
 
@@ -259,6 +398,9 @@ func makeTestRandSeed(rseed int64, label string) int64 {
 }
 
 func (sim *Simulation) RandomExpFloat(label string) float64 {
+	if sim.deterministic {
+		return 1 // The expected value.
+	}
 	return rand.New(sim.labelRand(label)).ExpFloat64()
 }
 
@@ -268,7 +410,16 @@ func (sim *Simulation) Roll(min float64, max float64) float64 {
 	return sim.RollWithLabel(min, max, "Damage Roll")
 }
 func (sim *Simulation) RollWithLabel(min float64, max float64, label string) float64 {
-	return min + (max-min)*sim.RandomFloat(label)
+	var roll float64
+	if sim.deterministic {
+		roll = (min + max) / 2
+	} else {
+		roll = min + (max-min)*sim.RandomFloat(label)
+	}
+	if sim.varianceAudit != nil {
+		sim.varianceAudit.record(sim, min, max, roll)
+	}
+	return roll
 }
 
 func (sim *Simulation) Proc(p float64, label string) bool {
@@ -296,9 +447,14 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	t0 := time.Now()
 
 	logsBuffer := &strings.Builder{}
+	// Streaming needs somewhere to send the chunks to.
+	streamLogs := sim.Options.StreamLogs && sim.ProgressReport != nil
 	if sim.Options.Debug || sim.Options.DebugFirstIteration {
 		sim.Log = func(message string, vals ...interface{}) {
 			logsBuffer.WriteString(fmt.Sprintf("[%0.2f] "+message+"\n", append([]interface{}{sim.CurrentTime.Seconds()}, vals...)...))
+			if streamLogs && logsBuffer.Len() >= logChunkSize {
+				sim.flushLogs(logsBuffer)
+			}
 		}
 	}
 
@@ -308,6 +464,11 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	// 	fmt.Printf(fmt.Sprintf("[%0.1f] "+message+"\n", append([]interface{}{sim.CurrentTime.Seconds()}, vals...)...))
 	// }
 
+	if sim.Options.ReplayIteration > 0 {
+		// Same rolls as that iteration of a regular run, see the loop below.
+		sim.reseedRands(int64(sim.Options.ReplayIteration))
+	}
+
 	sim.runOnce()
 	firstIterationDuration := sim.Duration
 	if sim.Encounter.EndFightAtHealth != 0 {
@@ -315,6 +476,14 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	}
 	totalDuration := firstIterationDuration
 
+	if sim.Options.Debug || sim.Options.DebugFirstIteration {
+		sim.analyzeRotations()
+	}
+	if sim.varianceAudit != nil {
+		sim.varianceAudit.logTable(sim)
+		sim.varianceAudit = nil
+	}
+
 	if !sim.Options.Debug {
 		sim.Log = nil
 	}
@@ -351,6 +520,10 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		}
 		totalDuration += iterDuration
 	}
+	if streamLogs {
+		sim.flushLogs(logsBuffer)
+	}
+
 	result := &proto.RaidSimResult{
 		RaidMetrics:      sim.Raid.GetMetrics(),
 		EncounterMetrics: sim.Encounter.GetMetricsProto(),
@@ -361,6 +534,10 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		IterationsDone:         sim.Options.Iterations,
 	}
 
+	if sim.perfectPlayResult != nil && sim.perfectPlayResult.Error == nil {
+		sim.addHumanErrorMetrics(result.RaidMetrics, sim.perfectPlayResult.RaidMetrics)
+	}
+
 	// Final progress report
 	if sim.ProgressReport != nil {
 		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
@@ -373,6 +550,18 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	return result
 }
 
+// Size in bytes from which buffered logs are sent as a chunk, when streaming logs.
+const logChunkSize = 64 * 1024
+
+// Sends the buffered logs as the next chunk of a streamed log.
+func (sim *Simulation) flushLogs(logsBuffer *strings.Builder) {
+	if logsBuffer.Len() == 0 {
+		return
+	}
+	sim.ProgressReport(&proto.ProgressMetrics{LogChunk: logsBuffer.String()})
+	logsBuffer.Reset()
+}
+
 // RunOnce is the main event loop. It will run the simulation for number of seconds.
 func (sim *Simulation) runOnce() {
 	sim.isInPrepull = true
@@ -400,15 +589,16 @@ func (sim *Simulation) reset() {
 		sim.Encounter.DurationIsEstimate = false
 	}
 	sim.Duration = sim.BaseDuration
-	if sim.DurationVariation != 0 {
+	if sim.DurationVariation != 0 && !sim.deterministic {
 		variation := sim.DurationVariation * 2
 		sim.Duration += time.Duration(sim.RandomFloat("sim duration")*float64(variation)) - sim.DurationVariation
 	}
 
-	sim.pendingActions = sim.pendingActions[:0]
-	sim.pendingActions = append(sim.pendingActions, sentinelPendingAction)
+	sim.pendingActions.clear()
 
 	sim.executePhase = 0
+	sim.executeStart = 0
+	sim.Encounter.executeFocus = sim.Encounter.initialExecuteFocus
 	sim.nextExecutePhase()
 	sim.executePhaseCallbacks = nil
 
@@ -418,10 +608,18 @@ func (sim *Simulation) reset() {
 	if sim.Encounter.EndFightAtHealth > 0 {
 		sim.endOfCombatDuration = NeverExpires
 		sim.endOfCombatDamage = sim.Encounter.EndFightAtHealth
+
+		// Council fights end once the last boss died, see council.endFight.
+		if sim.Encounter.council != nil {
+			sim.endOfCombatDamage = math.MaxFloat64
+		}
 	}
 
 	sim.CurrentTime = 0
 
+	for _, tracker := range sim.trackers {
+		tracker.tracked = false
+	}
 	sim.trackers = sim.trackers[:0]
 	sim.minTrackerTime = NeverExpires
 
@@ -431,8 +629,22 @@ func (sim *Simulation) reset() {
 	sim.tasks = sim.tasks[:0]
 	sim.minTaskTime = NeverExpires
 
+	sim.activeSpell = nil
+
 	sim.Environment.reset(sim)
 
+	for _, tracker := range sim.executePhaseTrackers {
+		tracker.reset(sim)
+	}
+
+	if sim.LogEnabled(LogCategoryOther, LogLevelDebug) {
+		for _, player := range sim.Raid.AllPlayerUnits {
+			for _, target := range sim.Encounter.AllTargetUnits {
+				player.AttackTables[target.UnitIndex].logValues(sim)
+			}
+		}
+	}
+
 	sim.initManaTickAction()
 }
 
@@ -479,7 +691,7 @@ func (sim *Simulation) Cleanup() {
 		sim.Duration = sim.CurrentTime
 	}
 
-	for _, pa := range sim.pendingActions {
+	for _, pa := range sim.pendingActions.sortedLatestFirst() {
 		if pa.CleanUp != nil {
 			pa.CleanUp(sim)
 		}
@@ -487,6 +699,10 @@ func (sim *Simulation) Cleanup() {
 		pa.dispose(sim)
 	}
 
+	for _, tracker := range sim.executePhaseTrackers {
+		tracker.doneIteration(sim)
+	}
+
 	sim.Raid.doneIteration(sim)
 	sim.Encounter.doneIteration(sim)
 
@@ -507,8 +723,11 @@ func (sim *Simulation) runPendingActions() {
 }
 
 func (sim *Simulation) Step() bool {
-	last := len(sim.pendingActions) - 1
-	pa := sim.pendingActions[last]
+	pa := sim.pendingActions.peek()
+	if pa == nil {
+		// Never runs, as it's after the end of the fight.
+		pa = sentinelPendingAction
+	}
 
 	if pa.NextActionAt >= sim.minWeaponAttackTime && sim.minWeaponAttackTime <= sim.minTaskTime {
 		if sim.minWeaponAttackTime > sim.endOfCombatDuration || sim.Encounter.DamageTaken > sim.endOfCombatDamage {
@@ -526,7 +745,7 @@ func (sim *Simulation) Step() bool {
 		return false
 	}
 
-	sim.pendingActions = sim.pendingActions[:last]
+	sim.pendingActions.pop()
 	pa.consumed = true
 
 	if pa.cancelled {
@@ -581,12 +800,15 @@ func (sim *Simulation) advance(nextTime time.Duration) {
 
 	// this is a loop to handle duplicate ExecuteProportions, e.g. if they're all set to 100%, you reach
 	// execute phases 90%, 45%, 35%, 25%, and 20% in the first advance() call.
-	for sim.CurrentTime >= sim.nextExecuteDuration || sim.Encounter.DamageTaken >= sim.nextExecuteDamage {
+	for sim.CurrentTime >= sim.nextExecuteDuration || sim.Encounter.executeDamageTaken() >= sim.nextExecuteDamage {
 		sim.nextExecutePhase()
 		for _, callback := range sim.executePhaseCallbacks {
 			callback(sim, sim.executePhase)
 		}
 	}
+	for _, tracker := range sim.executePhaseTrackers {
+		tracker.update(sim)
+	}
 
 	if sim.CurrentTime >= sim.minTrackerTime {
 		sim.minTrackerTime = NeverExpires
@@ -603,12 +825,13 @@ func (sim *Simulation) setupReverseExecute(phase int32, activeProportion float64
 
 // nextExecutePhase updates nextExecuteDuration and nextExecuteDamage based on executePhase.
 func (sim *Simulation) nextExecutePhase() {
-	setup := func(phase int32, damage float64, health float64) {
+	setup := func(phase int32, damage float64, threshold int32) {
 		sim.executePhase = phase
 		if sim.Encounter.EndFightAtHealth > 0 {
-			sim.nextExecuteDamage = (1 - damage) * sim.Encounter.EndFightAtHealth
+			sim.nextExecuteDamage = (1 - damage) * sim.Encounter.executeHealth()
 		} else {
-			sim.nextExecuteDuration = time.Duration((1 - health) * float64(sim.Duration))
+			proportion := sim.Encounter.executeProportion(threshold)
+			sim.nextExecuteDuration = sim.executeStart + time.Duration((1-proportion)*float64(sim.Duration-sim.executeStart))
 		}
 	}
 
@@ -617,15 +840,15 @@ func (sim *Simulation) nextExecutePhase() {
 
 	switch sim.executePhase {
 	case 0: // initially waiting for 90%
-		setup(100, 0.90, sim.Encounter.ExecuteProportion_90)
+		setup(100, 0.90, 90)
 	case 100: // at 90%, waiting for 45%
-		setup(90, 0.45, sim.Encounter.ExecuteProportion_45)
+		setup(90, 0.45, 45)
 	case 90: // at 45%, waiting for 35%
-		setup(45, 0.35, sim.Encounter.ExecuteProportion_35)
+		setup(45, 0.35, 35)
 	case 45: // at 35%, waiting for 25%
-		setup(35, 0.25, sim.Encounter.ExecuteProportion_25)
+		setup(35, 0.25, 25)
 	case 35: // at 25%, waiting for 20%
-		setup(25, 0.20, sim.Encounter.ExecuteProportion_20)
+		setup(25, 0.20, 20)
 	case 25: // at 20%, done waiting
 		sim.executePhase = 20 // could also be used for end of fight handling
 	default:
@@ -638,24 +861,10 @@ func (sim *Simulation) AddPendingAction(pa *PendingAction) {
 	//	panic(fmt.Sprintf("Cant add action in the past: %s", pa.NextActionAt))
 	//}
 	pa.consumed = false
-	for index, v := range sim.pendingActions[1:] {
-		if v.NextActionAt < pa.NextActionAt || (v.NextActionAt == pa.NextActionAt && v.Priority >= pa.Priority) {
-			//if sim.Log != nil {
-			//	sim.Log("Adding action at index %d for time %s", index - len(sim.pendingActions), pa.NextActionAt)
-			//	for i := index; i < len(sim.pendingActions); i++ {
-			//		sim.Log("Upcoming action at %s", sim.pendingActions[i].NextActionAt)
-			//	}
-			//}
-			sim.pendingActions = append(sim.pendingActions, pa)
-			copy(sim.pendingActions[index+2:], sim.pendingActions[index+1:])
-			sim.pendingActions[index+1] = pa
-			return
-		}
-	}
-	//if sim.Log != nil {
-	//	sim.Log("Adding action at end for time %s", pa.NextActionAt)
-	//}
-	sim.pendingActions = append(sim.pendingActions, pa)
+
+	// An action can only be queued once, so re-adding it moves it to its new time.
+	sim.pendingActions.remove(pa)
+	sim.pendingActions.push(pa)
 }
 
 // Use this for any "fire and forget" delayed actions where your code does not
@@ -673,6 +882,26 @@ func (sim *Simulation) GetConsumedPendingActionFromPool() *PendingAction {
 	return pa
 }
 
+// Starts over from the first execute phase, e.g. when the raid switches to the
+// next boss of a council fight.
+func (sim *Simulation) restartExecutePhases() {
+	previousPhase := sim.executePhase
+	sim.executePhase = 0
+	sim.nextExecutePhase()
+
+	for _, tracker := range sim.executePhaseTrackers {
+		tracker.update(sim)
+	}
+
+	// Nothing changed for callbacks if the previous focus never left the first phase.
+	if sim.executePhase == previousPhase {
+		return
+	}
+	for _, callback := range sim.executePhaseCallbacks {
+		callback(sim, sim.executePhase)
+	}
+}
+
 func (sim *Simulation) RegisterExecutePhaseCallback(callback func(sim *Simulation, isExecute int32)) {
 	sim.executePhaseCallbacks = append(sim.executePhaseCallbacks, callback)
 }
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"runtime"
 	"runtime/debug"
+	"slices"
 
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
@@ -27,25 +28,43 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 		return res
 	}
 
-	splitCount = min(splitCount, request.SimOptions.Iterations)
+	// Antithetic pairs must not straddle two splits, so every split but the last
+	// runs whole pairs, see SimOptions.antithetic_sampling.
+	iterUnit := int32(1)
+	if request.SimOptions.AntitheticSampling {
+		iterUnit = 2
+	}
+	units := request.SimOptions.Iterations / iterUnit
+
+	splitCount = max(min(splitCount, units), 1)
+	if request.SimOptions.Deterministic || request.SimOptions.ReplayIteration > 0 {
+		// A single iteration is run, see SimOptions.deterministic and SimOptions.replay_iteration.
+		splitCount = 1
+	}
 
 	split := make([]*proto.RaidSimRequest, splitCount)
-	iterPerSplit := request.SimOptions.Iterations / splitCount
+	unitsPerSplit := units / splitCount
 
 	split[0] = googleProto.Clone(request).(*proto.RaidSimRequest)
-	split[0].SimOptions.Iterations = iterPerSplit + request.SimOptions.Iterations%splitCount
+	split[0].SimOptions.Iterations = (unitsPerSplit + units%splitCount) * iterUnit
+	split[0].SimOptions.NumWorkerThreads = 0 // Each split runs on a single thread.
 
 	// Sims increment their seed each iteration. Offset starting seed of each split to emulate that.
 	nextStartSeed := split[0].SimOptions.RandomSeed + int64(split[0].SimOptions.Iterations)
 
 	for i := 1; i < int(splitCount); i++ {
 		split[i] = googleProto.Clone(request).(*proto.RaidSimRequest)
-		split[i].SimOptions.Iterations = iterPerSplit
+		split[i].SimOptions.Iterations = unitsPerSplit * iterUnit
+		split[i].SimOptions.NumWorkerThreads = 0
 		split[i].SimOptions.DebugFirstIteration = false // No logs
+		split[i].SimOptions.StreamLogs = false          // Chunks of several splits would interleave
 		split[i].SimOptions.RandomSeed = nextStartSeed
 		nextStartSeed += int64(split[i].SimOptions.Iterations)
 	}
 
+	// The unpaired final iteration of an odd antithetic run.
+	split[splitCount-1].SimOptions.Iterations += request.SimOptions.Iterations % iterUnit
+
 	res.SplitsDone = splitCount
 	res.Requests = split
 	return res
@@ -71,6 +90,7 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		Name:      baseUnit.Name,
 		UnitIndex: baseUnit.UnitIndex,
 		Dps:       rsrc.newDistMetrics(),
+		OwnDps:    rsrc.newDistMetrics(),
 		Threat:    rsrc.newDistMetrics(),
 		Dtps:      rsrc.newDistMetrics(),
 		Tmi:       rsrc.newDistMetrics(),
@@ -80,6 +100,9 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		Auras:     make([]*proto.AuraMetrics, len(baseUnit.Auras)),
 		Resources: make([]*proto.ResourceMetrics, 0, len(baseUnit.Resources)),
 		Pets:      make([]*proto.UnitMetrics, len(baseUnit.Pets)),
+
+		// Only the base result contains the logged iteration.
+		Suggestions: baseUnit.Suggestions,
 	}
 
 	for i, aura := range baseUnit.Auras {
@@ -135,6 +158,7 @@ func (rsrc *raidSimResultCombiner) combineDistMetrics(base *proto.DistributionMe
 	base.AggregatorData.SumSq += add.AggregatorData.SumSq
 	if isLast {
 		base.Stdev = math.Sqrt(base.AggregatorData.SumSq/float64(base.AggregatorData.N) - base.Avg*base.Avg)
+		setPercentiles(base)
 	}
 }
 
@@ -151,11 +175,12 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 
 	if am == nil {
 		am = &proto.ActionMetrics{
-			Id:          add.Id,
-			IsMelee:     add.IsMelee,
-			IsPassive:   add.IsPassive,
-			Targets:     make([]*proto.TargetedActionMetrics, len(add.Targets)),
-			SpellSchool: add.SpellSchool,
+			Id:           add.Id,
+			IsMelee:      add.IsMelee,
+			IsPassive:    add.IsPassive,
+			Targets:      make([]*proto.TargetedActionMetrics, len(add.Targets)),
+			SpellSchool:  add.SpellSchool,
+			SpellDataset: add.SpellDataset,
 		}
 		for i, addTgt := range add.Targets {
 			am.Targets[i] = &proto.TargetedActionMetrics{
@@ -165,6 +190,12 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		unit.Actions = append(unit.Actions, am)
 	}
 
+	for _, addSource := range add.ConvertedFrom {
+		if !slices.ContainsFunc(am.ConvertedFrom, func(source *proto.ActionID) bool { return googleProto.Equal(source, addSource) }) {
+			am.ConvertedFrom = append(am.ConvertedFrom, addSource)
+		}
+	}
+
 	for i, baseTgt := range am.Targets {
 		addTgt := add.Targets[i]
 		if baseTgt.UnitIndex != addTgt.UnitIndex {
@@ -195,16 +226,56 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.CritHealing += addTgt.CritHealing
 		baseTgt.Shielding += addTgt.Shielding
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
+		baseTgt.MaxAvoidanceStreak = max(baseTgt.MaxAvoidanceStreak, addTgt.MaxAvoidanceStreak)
+		baseTgt.AvoidanceStreakSum += addTgt.AvoidanceStreakSum
+		baseTgt.AvoidedDamage += addTgt.AvoidedDamage
+		baseTgt.ImmuneDamage += addTgt.ImmuneDamage
+		baseTgt.AbsorbedDamage += addTgt.AbsorbedDamage
+		baseTgt.DotUptimeSeconds += addTgt.DotUptimeSeconds
+		baseTgt.DotMaxTicks += addTgt.DotMaxTicks
+		baseTgt.DotClippedRefreshes += addTgt.DotClippedRefreshes
+		baseTgt.DotClippedSeconds += addTgt.DotClippedSeconds
+		baseTgt.DotPandemicSeconds += addTgt.DotPandemicSeconds
 	}
 }
 
 func (rsrc *raidSimResultCombiner) combineAuraMetrics(base *proto.AuraMetrics, add *proto.AuraMetrics, weight float64, isLast bool) {
 	base.UptimeSecondsAvg += add.UptimeSecondsAvg * weight
 	base.ProcsAvg += add.ProcsAvg * weight
+	base.OvershadowedSecondsAvg += add.OvershadowedSecondsAvg * weight
+	base.IcdBlockedAvg += add.IcdBlockedAvg * weight
+	base.IcdWastedProcsAvg += add.IcdWastedProcsAvg * weight
+	// Intervals are averaged over the procs, not the iterations.
+	if procIntervals := base.ProcIntervals + add.ProcIntervals; procIntervals > 0 {
+		base.ProcIntervalSecondsAvg = (base.ProcIntervalSecondsAvg*float64(base.ProcIntervals) + add.ProcIntervalSecondsAvg*float64(add.ProcIntervals)) / float64(procIntervals)
+		base.ProcIntervals = procIntervals
+	}
+	base.ChainedProcsAvg += add.ChainedProcsAvg * weight
+	base.AplCancelsAvg += add.AplCancelsAvg * weight
+
+	for _, addSource := range add.Sources {
+		var sm *proto.AuraSourceMetrics
+		for _, baseSource := range base.Sources {
+			if baseSource.Id.String() == addSource.Id.String() {
+				sm = baseSource
+				break
+			}
+		}
+		if sm == nil {
+			sm = &proto.AuraSourceMetrics{Id: addSource.Id}
+			base.Sources = append(base.Sources, sm)
+		}
+		sm.ApplicationsAvg += addSource.ApplicationsAvg * weight
+		sm.RefreshesAvg += addSource.RefreshesAvg * weight
+	}
 
 	base.AggregatorData.N += add.AggregatorData.N
 	base.AggregatorData.SumSq += add.AggregatorData.SumSq
 	if isLast {
+		// Sources only seen by some of the sims are appended, so restore the order of AuraMetrics.ToProto.
+		slices.SortFunc(base.Sources, func(a, b *proto.AuraSourceMetrics) int {
+			return ProtoToActionID(a.Id).Compare(ProtoToActionID(b.Id))
+		})
 		base.UptimeSecondsStdev = math.Sqrt(base.AggregatorData.SumSq/float64(base.AggregatorData.N) - base.UptimeSecondsAvg*base.UptimeSecondsAvg)
 	}
 }
@@ -236,8 +307,241 @@ func (rsrc *raidSimResultCombiner) addResourceMetrics(unit *proto.UnitMetrics, a
 	rm.ActualGain += add.ActualGain
 }
 
+func (rsrc *raidSimResultCombiner) addRuneMetrics(unit *proto.UnitMetrics, add *proto.RuneMetrics, weight float64) {
+	var rm *proto.RuneMetrics
+
+	for _, baseRunes := range unit.Runes {
+		if baseRunes.Type == add.Type {
+			rm = baseRunes
+			break
+		}
+	}
+
+	if rm == nil {
+		rm = &proto.RuneMetrics{
+			Type: add.Type,
+		}
+		unit.Runes = append(unit.Runes, rm)
+	}
+
+	// The rune cooldown is averaged over the regenerated runes rather than the iterations.
+	regens := rm.RunesRegeneratedAvg + add.RunesRegeneratedAvg*weight
+	if regens > 0 {
+		rm.RuneCooldownAvg = (rm.RuneCooldownAvg*rm.RunesRegeneratedAvg + add.RuneCooldownAvg*add.RunesRegeneratedAvg*weight) / regens
+	}
+	rm.RunesRegeneratedAvg = regens
+	rm.SecondsDepletedAvg += add.SecondsDepletedAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) addResourceCapMetrics(unit *proto.UnitMetrics, add *proto.ResourceCapMetrics, weight float64) {
+	var rcm *proto.ResourceCapMetrics
+
+	for _, baseCap := range unit.ResourceCaps {
+		if baseCap.Type == add.Type {
+			rcm = baseCap
+			break
+		}
+	}
+
+	if rcm == nil {
+		rcm = &proto.ResourceCapMetrics{
+			Type: add.Type,
+		}
+		unit.ResourceCaps = append(unit.ResourceCaps, rcm)
+	}
+
+	rcm.SecondsCappedAvg += add.SecondsCappedAvg * weight
+	rcm.WastedAvg += add.WastedAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) addCastFailureMetrics(unit *proto.UnitMetrics, add *proto.CastFailureMetrics, weight float64) {
+	var cfm *proto.CastFailureMetrics
+
+	for _, baseFailure := range unit.CastFailures {
+		if baseFailure.Reason == add.Reason && baseFailure.Id.String() == add.Id.String() {
+			cfm = baseFailure
+			break
+		}
+	}
+
+	if cfm == nil {
+		cfm = &proto.CastFailureMetrics{
+			Id:     add.Id,
+			Reason: add.Reason,
+		}
+		unit.CastFailures = append(unit.CastFailures, cfm)
+	}
+
+	cfm.FailuresAvg += add.FailuresAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) addTargetSwitchMetrics(unit *proto.UnitMetrics, add *proto.TargetSwitchMetrics, weight float64) {
+	var tsm *proto.TargetSwitchMetrics
+
+	for _, baseSwitches := range unit.TargetSwitches {
+		if baseSwitches.Policy == add.Policy {
+			tsm = baseSwitches
+			break
+		}
+	}
+
+	if tsm == nil {
+		tsm = &proto.TargetSwitchMetrics{
+			Policy: add.Policy,
+		}
+		unit.TargetSwitches = append(unit.TargetSwitches, tsm)
+	}
+
+	tsm.SwitchesAvg += add.SwitchesAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) addCooldownUsageMetrics(unit *proto.UnitMetrics, add *proto.CooldownUsageMetrics, baseIterations int, weight float64) {
+	var cum *proto.CooldownUsageMetrics
+
+	for _, baseUsages := range unit.CooldownUsages {
+		if baseUsages.Id.String() == add.Id.String() {
+			cum = baseUsages
+			break
+		}
+	}
+
+	if cum == nil {
+		cum = &proto.CooldownUsageMetrics{
+			Id:              add.Id,
+			IterationUsages: make([]int32, baseIterations),
+		}
+		unit.CooldownUsages = append(unit.CooldownUsages, cum)
+	}
+
+	cum.DriftSecondsAvg += add.DriftSecondsAvg * weight
+	cum.LostUsesAvg += add.LostUsesAvg * weight
+	cum.UsageTimes = append(cum.UsageTimes, add.UsageTimes...)
+	cum.IterationUsages = append(cum.IterationUsages, add.IterationUsages...)
+
+	for i, addHist := range add.UsageHistograms {
+		if i == len(cum.UsageHistograms) {
+			cum.UsageHistograms = append(cum.UsageHistograms, &proto.CooldownUsageHistogram{Hist: make(map[int32]int32)})
+		}
+		for seconds, count := range addHist.Hist {
+			cum.UsageHistograms[i].Hist[seconds] += count
+		}
+	}
+}
+
+func (rsrc *raidSimResultCombiner) addCooldownOverlapMetrics(unit *proto.UnitMetrics, add *proto.CooldownOverlapMetrics, weight float64) {
+	var com *proto.CooldownOverlapMetrics
+
+	for _, baseOverlap := range unit.CooldownOverlaps {
+		if baseOverlap.Id.String() == add.Id.String() && baseOverlap.OtherId.String() == add.OtherId.String() {
+			com = baseOverlap
+			break
+		}
+	}
+
+	if com == nil {
+		com = &proto.CooldownOverlapMetrics{
+			Id:      add.Id,
+			OtherId: add.OtherId,
+		}
+		unit.CooldownOverlaps = append(unit.CooldownOverlaps, com)
+	}
+
+	com.OverlapSecondsAvg += add.OverlapSecondsAvg * weight
+	com.OverlapChance += add.OverlapChance * weight
+}
+
+func (rsrc *raidSimResultCombiner) addMultiDotValueMetrics(unit *proto.UnitMetrics, add *proto.MultiDotValueMetrics, weight float64) {
+	var mdv *proto.MultiDotValueMetrics
+
+	for _, baseValues := range unit.MultiDotValues {
+		if baseValues.TargetIndex == add.TargetIndex && baseValues.Id.String() == add.Id.String() {
+			mdv = baseValues
+			break
+		}
+	}
+
+	if mdv == nil {
+		mdv = &proto.MultiDotValueMetrics{
+			Id:          add.Id,
+			TargetIndex: add.TargetIndex,
+		}
+		unit.MultiDotValues = append(unit.MultiDotValues, mdv)
+	}
+
+	mdv.DotDpsAvg += add.DotDpsAvg * weight
+	mdv.GcdCostDpsAvg += add.GcdCostDpsAvg * weight
+	mdv.MarginalDpsAvg += add.MarginalDpsAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) addExecutePhaseMetrics(unit *proto.UnitMetrics, add *proto.ExecutePhaseMetrics, weight float64) {
+	var epm *proto.ExecutePhaseMetrics
+
+	for _, basePhase := range unit.ExecutePhases {
+		if basePhase.Label == add.Label {
+			epm = basePhase
+			break
+		}
+	}
+
+	if epm == nil {
+		epm = &proto.ExecutePhaseMetrics{
+			Label:     add.Label,
+			Threshold: add.Threshold,
+		}
+		unit.ExecutePhases = append(unit.ExecutePhases, epm)
+	}
+
+	epm.SecondsAvg += add.SecondsAvg * weight
+	epm.EntriesAvg += add.EntriesAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) addAPLPhaseMetrics(unit *proto.UnitMetrics, add *proto.APLPhaseMetrics, weight float64) {
+	var apm *proto.APLPhaseMetrics
+
+	for _, basePhase := range unit.AplPhases {
+		if basePhase.Name == add.Name {
+			apm = basePhase
+			break
+		}
+	}
+
+	if apm == nil {
+		apm = &proto.APLPhaseMetrics{
+			Name: add.Name,
+		}
+		unit.AplPhases = append(unit.AplPhases, apm)
+	}
+
+	apm.SecondsAvg += add.SecondsAvg * weight
+	apm.SwitchesAvg += add.SwitchesAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) addDebuffOverwriteMetrics(unit *proto.UnitMetrics, add *proto.DebuffOverwriteMetrics, weight float64) {
+	var dom *proto.DebuffOverwriteMetrics
+
+	for _, baseOverwrite := range unit.DebuffOverwrites {
+		if baseOverwrite.Reason == add.Reason && baseOverwrite.Id.String() == add.Id.String() {
+			dom = baseOverwrite
+			break
+		}
+	}
+
+	if dom == nil {
+		dom = &proto.DebuffOverwriteMetrics{
+			Id:     add.Id,
+			Reason: add.Reason,
+		}
+		unit.DebuffOverwrites = append(unit.DebuffOverwrites, dom)
+	}
+
+	dom.OverwritesAvg += add.OverwritesAvg * weight
+}
+
 func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, add *proto.UnitMetrics, isLast bool, weight float64) {
+	baseIterations := int(base.Dps.AggregatorData.N)
+
 	rsrc.combineDistMetrics(base.Dps, add.Dps, isLast, weight)
+	rsrc.combineDistMetrics(base.OwnDps, add.OwnDps, isLast, weight)
 	rsrc.combineDistMetrics(base.Threat, add.Threat, isLast, weight)
 	rsrc.combineDistMetrics(base.Dtps, add.Dtps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tmi, add.Tmi, isLast, weight)
@@ -246,6 +550,16 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 
 	base.SecondsOomAvg += add.SecondsOomAvg * weight
 	base.ChanceOfDeath += add.ChanceOfDeath * weight
+	base.ActiveSecondsAvg += add.ActiveSecondsAvg * weight
+	base.AggroPullsAvg += add.AggroPullsAvg * weight
+	base.LatencySecondsAvg += add.LatencySecondsAvg * weight
+	base.GcdConflictsAvg += add.GcdConflictsAvg * weight
+	base.HealingReceivedAvg += add.HealingReceivedAvg * weight
+	base.OverhealingReceivedAvg += add.OverhealingReceivedAvg * weight
+	base.DamageTakenAvg += add.DamageTakenAvg * weight
+	if isLast {
+		base.SurvivabilityScore = survivabilityScore(base)
+	}
 
 	for _, addAction := range add.Actions {
 		rsrc.addActionMetrics(base, addAction)
@@ -259,6 +573,79 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 		rsrc.addResourceMetrics(base, addResource)
 	}
 
+	for _, addCap := range add.ResourceCaps {
+		rsrc.addResourceCapMetrics(base, addCap, weight)
+	}
+
+	for _, addRunes := range add.Runes {
+		rsrc.addRuneMetrics(base, addRunes, weight)
+	}
+
+	for _, addFailure := range add.CastFailures {
+		rsrc.addCastFailureMetrics(base, addFailure, weight)
+	}
+	if isLast {
+		// Failures only seen by some of the sims are appended, so restore the order of UnitMetrics.ToProto.
+		slices.SortFunc(base.CastFailures, func(a, b *proto.CastFailureMetrics) int {
+			return castFailureKey{ProtoToActionID(a.Id), a.Reason}.compare(castFailureKey{ProtoToActionID(b.Id), b.Reason})
+		})
+	}
+
+	for _, addOverwrite := range add.DebuffOverwrites {
+		rsrc.addDebuffOverwriteMetrics(base, addOverwrite, weight)
+	}
+
+	for _, addSwitches := range add.TargetSwitches {
+		rsrc.addTargetSwitchMetrics(base, addSwitches, weight)
+	}
+
+	for _, addUsages := range add.CooldownUsages {
+		rsrc.addCooldownUsageMetrics(base, addUsages, baseIterations, weight)
+	}
+	// Cooldowns which weren't used in any of the added iterations.
+	for _, baseUsages := range base.CooldownUsages {
+		for len(baseUsages.IterationUsages) < int(base.Dps.AggregatorData.N) {
+			baseUsages.IterationUsages = append(baseUsages.IterationUsages, 0)
+		}
+	}
+	if isLast {
+		slices.SortFunc(base.CooldownUsages, func(a, b *proto.CooldownUsageMetrics) int {
+			return ProtoToActionID(a.Id).Compare(ProtoToActionID(b.Id))
+		})
+	}
+
+	for _, addOverlap := range add.CooldownOverlaps {
+		rsrc.addCooldownOverlapMetrics(base, addOverlap, weight)
+	}
+	if isLast {
+		base.CooldownWarnings = cooldownOverlapWarnings(base.CooldownOverlaps)
+	}
+
+	if add.HumanError != nil {
+		if base.HumanError == nil {
+			base.HumanError = &proto.HumanErrorMetrics{
+				HumanErrorChance: add.HumanError.HumanErrorChance,
+				PerfectDps:       rsrc.newDistMetrics(),
+			}
+		}
+		rsrc.combineDistMetrics(base.HumanError.PerfectDps, add.HumanError.PerfectDps, isLast, weight)
+		if isLast {
+			setHumanErrorLossRange(base.HumanError, base.Dps)
+		}
+	}
+
+	for _, addValues := range add.MultiDotValues {
+		rsrc.addMultiDotValueMetrics(base, addValues, weight)
+	}
+
+	for _, addPhase := range add.ExecutePhases {
+		rsrc.addExecutePhaseMetrics(base, addPhase, weight)
+	}
+
+	for _, addPhase := range add.AplPhases {
+		rsrc.addAPLPhaseMetrics(base, addPhase, weight)
+	}
+
 	for i, addPet := range add.Pets {
 		rsrc.combineUnitMetrics(base.Pets[i], addPet, isLast, weight)
 	}
@@ -401,7 +788,21 @@ func (csd *concurrentSimData) MakeProgressMetrics() *proto.ProgressMetrics {
 }
 
 // Run sim on multiple threads concurrently by splitting interations over multiple sims, transparently combining results into the progress channel.
-func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) (result *proto.RaidSimResult) {
+func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.RaidSimResult {
+	return runSimConcurrentWithCache(request, progress, signals, nil)
+}
+
+// runSimConcurrent as a raidSimRunner. Concurrent sims always run their presims.
+func runSimConcurrentRunner(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, _ bool, signals simsignals.Signals) *proto.RaidSimResult {
+	return runSimConcurrent(request, progress, signals)
+}
+
+// Like runSimConcurrent, but each split reuses environments from envCache if one is provided.
+func runSimConcurrentWithCache(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals, envCache *EnvironmentCache) (result *proto.RaidSimResult) {
+	// Attached to every final result, including errors and aborted sims. Split
+	// requests hash differently, so this describes the original request instead.
+	metadata := NewSimMetadata(request)
+
 	defer func() {
 		if !request.SimOptions.IsTest {
 			if err := recover(); err != nil {
@@ -414,7 +815,7 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 				}
 
 				errStr += "\nStack Trace:\n" + string(debug.Stack())
-				result = &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: errStr}}
+				result = &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: errStr}, Metadata: metadata}
 
 				if progress != nil {
 					progress <- &proto.ProgressMetrics{FinalRaidResult: result}
@@ -429,7 +830,20 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		}
 	}()
 
-	splitRes := SplitSimRequestForConcurrency(request, TernaryInt32(request.SimOptions.IsTest, 3, int32(runtime.NumCPU())))
+	if errorResult := validateRaidSimRequest(request); errorResult != nil {
+		errorResult.Metadata = metadata
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{FinalRaidResult: errorResult}
+		}
+		return errorResult
+	}
+
+	threadCount := TernaryInt32(request.SimOptions.IsTest, 3, int32(runtime.NumCPU()))
+	if request.SimOptions.NumWorkerThreads > 0 {
+		threadCount = request.SimOptions.NumWorkerThreads
+	}
+
+	splitRes := SplitSimRequestForConcurrency(request, threadCount)
 
 	if splitRes.ErrorResult != "" {
 		panic(splitRes.ErrorResult)
@@ -459,7 +873,11 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 	}
 
 	for i, req := range splitRes.Requests {
-		go RunSim(req, substituteChannels[i], signals)
+		if envCache != nil {
+			go envCache.RunSim(req, substituteChannels[i], false, signals)
+		} else {
+			go RunSim(req, substituteChannels[i], signals)
+		}
 	}
 
 	progressCounter := 0
@@ -468,7 +886,7 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		i, val, ok := reflect.Select(substituteCases)
 
 		if signals.Abort.IsTriggered() {
-			quitResult := &proto.RaidSimResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}}
+			quitResult := &proto.RaidSimResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}, Metadata: metadata}
 			if progress != nil {
 				progress <- &proto.ProgressMetrics{FinalRaidResult: quitResult}
 			}
@@ -482,14 +900,23 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		}
 
 		msg := val.Interface().(*proto.ProgressMetrics)
+		if msg.LogChunk != "" {
+			if progress != nil {
+				progress <- msg
+			}
+			continue
+		}
 		if csd.UpdateProgress(i, msg) {
 			if msg.FinalRaidResult != nil && msg.FinalRaidResult.Error != nil {
+				// The split may still read its own result, so describe the original request on a copy.
+				errorResult := googleProto.Clone(msg.FinalRaidResult).(*proto.RaidSimResult)
+				errorResult.Metadata = metadata
 				if progress != nil {
-					progress <- msg
+					progress <- &proto.ProgressMetrics{FinalRaidResult: errorResult}
 				}
 				log.Printf("Thread %d had an error. Cancelling all sims!", i)
 				signals.Abort.Trigger()
-				return msg.FinalRaidResult
+				return errorResult
 			}
 			substituteCases[i].Chan = reflect.ValueOf(nil)
 			running -= 1
@@ -515,6 +942,7 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 	}
 
 	result = CombineConcurrentSimResults(csd.FinalResults, request.SimOptions.Debug)
+	result.Metadata = metadata
 
 	if progress != nil {
 		pm := csd.MakeProgressMetrics()
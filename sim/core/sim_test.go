@@ -0,0 +1,41 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestStreamedLogsMatchBufferedLogs(t *testing.T) {
+	request := incomingDamageTestRequest(20)
+	request.SimOptions.Debug = true
+	buffered := NewSim(request, simsignals.CreateSignals()).run().Logs
+
+	request.SimOptions.StreamLogs = true
+	sim := NewSim(request, simsignals.CreateSignals())
+	var chunks []string
+	sim.ProgressReport = func(progress *proto.ProgressMetrics) {
+		if progress.LogChunk != "" {
+			chunks = append(chunks, progress.LogChunk)
+		}
+	}
+	result := sim.run()
+
+	if len(chunks) < 2 {
+		t.Fatalf("Expected the %d bytes of logs to be streamed in several chunks, got %d", len(buffered), len(chunks))
+	}
+	for i, chunk := range chunks[:len(chunks)-1] {
+		// Chunks are sent once they pass the chunk size, and end with a whole line.
+		if len(chunk) < logChunkSize || !strings.HasSuffix(chunk, "\n") {
+			t.Fatalf("Chunk %d: expected at least %d bytes of whole lines, got %d", i, logChunkSize, len(chunk))
+		}
+	}
+	if streamed := strings.Join(chunks, ""); streamed != buffered {
+		t.Fatalf("Expected the streamed logs to match the %d bytes of buffered logs, got %d bytes", len(buffered), len(streamed))
+	}
+	if result.Logs != "" {
+		t.Fatalf("Expected no logs in the result once streamed, got %d bytes", len(result.Logs))
+	}
+}
@@ -0,0 +1,60 @@
+package core
+
+// Records the power of a dot's snapshot, i.e. its expected tick damage when it
+// was applied, so rotations can tell whether a recast would snapshot a
+// stronger dot.
+type SnapshotTracker struct {
+	dot *Dot
+
+	// Power of the last snapshot taken this iteration. Kept after the dot
+	// expires, so a reapplication can be compared with the previous one.
+	power float64
+
+	// Power a recast would snapshot, as of the last snapshot or call to
+	// UpdateNewSnapshotPower.
+	newPower float64
+}
+
+// Expected tick damage snapshotted by the last application of the dot, or 0
+// if it wasn't applied yet this iteration.
+func (tracker *SnapshotTracker) CurrentSnapshotPower() float64 {
+	return tracker.power
+}
+
+// Expected tick damage a recast would snapshot, as of the last snapshot or
+// call to UpdateNewSnapshotPower. Rotations update it when snapshotted buffs
+// change, so it doesn't follow every small stat change.
+func (tracker *SnapshotTracker) NewSnapshotPower() float64 {
+	return tracker.newPower
+}
+
+// Expected tick damage gained by re-snapshotting, negative if the current
+// snapshot is stronger.
+func (tracker *SnapshotTracker) RecastGain() float64 {
+	return tracker.newPower - tracker.power
+}
+
+// Recomputes the power a recast would snapshot right now.
+func (tracker *SnapshotTracker) UpdateNewSnapshotPower(sim *Simulation) {
+	tracker.newPower = tracker.dot.Spell.ExpectedTickDamage(sim, tracker.dot.Unit)
+
+	if sim.Log != nil {
+		tracker.dot.Spell.Unit.Log(sim, "%s projected power: %.1f", tracker.dot.Label, tracker.newPower)
+	}
+}
+
+// Records a snapshot taken right now. Called for every snapshot besides
+// rollovers, dots which re-snapshot on rollovers call it themselves.
+func (tracker *SnapshotTracker) Record(sim *Simulation) {
+	tracker.power = tracker.dot.Spell.ExpectedTickDamage(sim, tracker.dot.Unit)
+	tracker.newPower = tracker.power
+
+	if sim.Log != nil {
+		tracker.dot.Spell.Unit.Log(sim, "%s snapshot power: %.1f", tracker.dot.Label, tracker.power)
+	}
+}
+
+func (tracker *SnapshotTracker) reset() {
+	tracker.power = 0
+	tracker.newPower = 0
+}
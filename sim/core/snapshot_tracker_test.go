@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func snapshotTrackerTestSpell(unit *Unit, expectedTickDamage ExpectedDamageCalculator) *Spell {
+	return unit.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: 1822},
+		SpellSchool:      SpellSchoolPhysical,
+		ProcMask:         ProcMaskMeleeMHSpecial,
+		Flags:            SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Dot: DotConfig{
+			Aura:           Aura{Label: "Tracked Bleed"},
+			NumberOfTicks:  5,
+			TickLength:     time.Second * 3,
+			TrackSnapshots: true,
+
+			OnSnapshot: func(_ *Simulation, target *Unit, dot *Dot, _ bool) {
+				dot.Snapshot(target, 100)
+			},
+			OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
+			},
+		},
+
+		ExpectedTickDamage: expectedTickDamage,
+	})
+}
+
+func TestSnapshotTracker(t *testing.T) {
+	var spell *Spell
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		spell = snapshotTrackerTestSpell(&env.Raid.Parties[0].Players[0].GetCharacter().Unit, func(sim *Simulation, target *Unit, spell *Spell, _ bool) *SpellResult {
+			return spell.CalcPeriodicDamage(sim, target, 100, spell.OutcomeExpectedMagicAlwaysHit)
+		})
+	})
+	dot := spell.CurDot()
+	tracker := dot.SnapshotTracker
+
+	dot.Apply(sim)
+	power := tracker.CurrentSnapshotPower()
+	if power <= 0 || tracker.NewSnapshotPower() != power || tracker.RecastGain() != 0 {
+		t.Fatalf("Expected a new snapshot to also be the projected power, got %0.3f and %0.3f", power, tracker.NewSnapshotPower())
+	}
+
+	// The projected power only changes when it's updated.
+	spell.DamageMultiplier *= 2
+	if tracker.RecastGain() != 0 {
+		t.Fatalf("Expected no recast gain before updating the projected power, got %0.3f", tracker.RecastGain())
+	}
+	tracker.UpdateNewSnapshotPower(sim)
+	if gain := tracker.RecastGain(); !WithinToleranceFloat64(power, gain, 0.001) {
+		t.Fatalf("Expected a recast gain of %0.3f, got %0.3f", power, gain)
+	}
+
+	// Rollovers keep the previous snapshot.
+	dot.TakeSnapshot(sim, true)
+	if tracker.CurrentSnapshotPower() != power {
+		t.Fatalf("Expected a rollover to keep the snapshot power of %0.3f, got %0.3f", power, tracker.CurrentSnapshotPower())
+	}
+
+	dot.TakeSnapshot(sim, false)
+	if !WithinToleranceFloat64(2*power, tracker.CurrentSnapshotPower(), 0.001) || tracker.RecastGain() != 0 {
+		t.Fatalf("Expected a new snapshot power of %0.3f, got %0.3f", 2*power, tracker.CurrentSnapshotPower())
+	}
+
+	// The power is kept after the dot expires, until the next iteration.
+	dot.Deactivate(sim)
+	if !WithinToleranceFloat64(2*power, tracker.CurrentSnapshotPower(), 0.001) {
+		t.Fatalf("Expected the snapshot power to outlive the dot")
+	}
+	sim.Cleanup()
+	sim.Reset()
+	if tracker.CurrentSnapshotPower() != 0 || tracker.NewSnapshotPower() != 0 {
+		t.Fatalf("Expected the snapshot powers to be reset with the iteration, got %0.3f and %0.3f", tracker.CurrentSnapshotPower(), tracker.NewSnapshotPower())
+	}
+}
+
+func TestSnapshotTrackerNeedsExpectedTickDamage(t *testing.T) {
+	setupResultPipelineSim(func(env *Environment) {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Expected tracking snapshots without ExpectedTickDamage to panic")
+			}
+		}()
+		snapshotTrackerTestSpell(&env.Raid.Parties[0].Players[0].GetCharacter().Unit, nil)
+	})
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+// Settings for long running soak tests, which run a single sim for a large number of
+// iterations to find state (auras, pending actions, ...) leaking between iterations.
+type SoakConfig struct {
+	Iterations  int32
+	SampleEvery int32
+
+	// Maximum allowed heap growth per iteration after warmup, in bytes.
+	MaxHeapGrowthPerIteration float64
+	// Maximum allowed live heap at any sample, in bytes.
+	MaxHeapBytes uint64
+}
+
+// Returns the soak config if soak tests are enabled through SOAK_ITERATIONS.
+// SOAK_MAX_HEAP_MB optionally overrides the heap ceiling.
+func SoakConfigFromEnv() (SoakConfig, bool) {
+	iterations, err := strconv.ParseInt(os.Getenv("SOAK_ITERATIONS"), 10, 32)
+	if err != nil || iterations <= 0 {
+		return SoakConfig{}, false
+	}
+
+	config := SoakConfig{
+		Iterations:                int32(iterations),
+		SampleEvery:               max(int32(iterations)/100, 1),
+		MaxHeapGrowthPerIteration: 1,
+		MaxHeapBytes:              512 << 20,
+	}
+
+	if maxHeapMb, err := strconv.ParseUint(os.Getenv("SOAK_MAX_HEAP_MB"), 10, 64); err == nil && maxHeapMb > 0 {
+		config.MaxHeapBytes = maxHeapMb << 20
+	}
+
+	return config, true
+}
+
+type soakSample struct {
+	iteration int32
+	heapBytes uint64
+}
+
+// Soaks the first raid sim of a test suite, named Soak so it can be selected with -run '/^Soak$'.
+func runSuiteSoakTest(t *testing.T, generators []TestGenerator, config SoakConfig) {
+	for _, generator := range generators {
+		for i := 0; i < generator.NumTests(); i++ {
+			testName, _, _, rsr := generator.GetTest(i)
+			if rsr == nil || strings.Contains(testName, "Casts") {
+				continue
+			}
+
+			t.Run("Soak", func(t *testing.T) {
+				RaidSoakTest(t, rsr, config)
+			})
+			return
+		}
+	}
+}
+
+func RaidSoakTest(t *testing.T, rsr *proto.RaidSimRequest, config SoakConfig) {
+	sim := NewSim(rsr, simsignals.CreateSignals())
+	if presimResult := sim.runPresims(rsr); presimResult != nil && presimResult.Error != nil {
+		t.Fatalf("Presim failed: %s", presimResult.Error.Message)
+	}
+	sim.Log = nil
+
+	var memStats runtime.MemStats
+	var samples []soakSample
+	var startMallocs uint64
+	var peakRssBytes uint64
+
+	for i := int32(0); i < config.Iterations; i++ {
+		sim.reseedRands(int64(i))
+		sim.runOnce()
+
+		if i%config.SampleEvery != 0 && i != config.Iterations-1 {
+			continue
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&memStats)
+		if i == 0 {
+			startMallocs = memStats.Mallocs
+		}
+
+		samples = append(samples, soakSample{iteration: i, heapBytes: memStats.HeapAlloc})
+		rssBytes := residentSetBytes()
+		peakRssBytes = max(peakRssBytes, rssBytes)
+		if memStats.HeapAlloc > config.MaxHeapBytes {
+			t.Fatalf("Heap of %d bytes (rss %d bytes) at iteration %d exceeds ceiling of %d bytes", memStats.HeapAlloc, rssBytes, i, config.MaxHeapBytes)
+		}
+	}
+
+	t.Logf("%d iterations, %0.1f allocations/iteration, final heap %d bytes, sys %d bytes, rss %d bytes (peak %d bytes)",
+		config.Iterations, float64(memStats.Mallocs-startMallocs)/float64(max(config.Iterations-1, 1)), memStats.HeapAlloc, memStats.Sys, residentSetBytes(), peakRssBytes)
+
+	// Skip the first half as warmup, caches and pools are still growing there.
+	growth := heapGrowthPerIteration(samples[len(samples)/2:])
+	if growth > config.MaxHeapGrowthPerIteration {
+		t.Errorf("Heap grows by %0.2f bytes per iteration (max %0.2f), state is likely leaking between iterations", growth, config.MaxHeapGrowthPerIteration)
+	}
+}
+
+// Least squares slope of heap size over iterations.
+func heapGrowthPerIteration(samples []soakSample) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sample := range samples {
+		x := float64(sample.iteration)
+		y := float64(sample.heapBytes)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
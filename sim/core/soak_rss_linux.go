@@ -0,0 +1,28 @@
+//go:build linux
+
+package core
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Returns the resident set size of the process in bytes, or 0 if it can't be read.
+func residentSetBytes() uint64 {
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0
+	}
+
+	// Total program size, then resident size, both in pages.
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return pages * uint64(os.Getpagesize())
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package core
+
+// Resident set size is only read on Linux, see soak_rss_linux.go.
+func residentSetBytes() uint64 {
+	return 0
+}
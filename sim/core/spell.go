@@ -32,12 +32,24 @@ type SpellConfig struct {
 	Cast               CastConfig
 	ExtraCastCondition CanCastCondition
 
+	// Optional stances, forms or presences the spell can only be cast in.
+	RequiredStance StanceMask
+	// If set, casting the spell outside of RequiredStance switches into the first
+	// registered matching stance which can be entered right now. The switch happens
+	// once the cast passes all its checks, so failed casts never change stance.
+	AutoSwitchStance bool
+
 	// Optional range constraints. If supplied, these are used to modify the ExtraCastCondition above to additionally check for DistanceFromTarget.
 	MinRange     float64
 	MaxRange     float64
 	Charges      int // The maximum amount of charges this spell can have
 	RechargeTime time.Duration
 
+	// Charges shared with other spells. Takes precedence over Charges and
+	// RechargeTime. Charge spell mods apply once, however many of the spells
+	// sharing it they affect.
+	ChargedCD *ChargedCooldown
+
 	BonusHitPercent      float64
 	BonusCritPercent     float64
 	BonusSpellPower      float64
@@ -61,6 +73,14 @@ type SpellConfig struct {
 	ExpectedInitialDamage ExpectedDamageCalculator
 	ExpectedTickDamage    ExpectedDamageCalculator
 
+	// Set if ExpectedTickDamage returns damage per second instead of per tick.
+	ExpectedDamagePerSecond bool
+
+	// "Variance" of the spell's damage roll in the SpellEffect DB2 table, if
+	// any. Only checked by SimOptions.audit_damage_variance, and usually set
+	// from a SpellDataset generated from the DB2 tables rather than by hand.
+	DamageVariance float64
+
 	Dot    DotConfig
 	Hot    DotConfig
 	Shield ShieldConfig
@@ -81,6 +101,10 @@ type Spell struct {
 	SpellSchool SpellSchool
 	SchoolIndex stats.SchoolIndex
 
+	// Indices of each school for multi-school spells, nil otherwise. School
+	// modifiers use the most favorable of these schools.
+	schoolIndices []stats.SchoolIndex
+
 	// Controls which effects can proc from this spell.
 	ProcMask ProcMask
 
@@ -104,20 +128,33 @@ type Spell struct {
 	SharedCD           Cooldown
 	ExtraCastCondition CanCastCondition
 
+	RequiredStance   StanceMask
+	AutoSwitchStance bool
+
 	// Optional range constraints. If supplied, these are used to modify the ExtraCastCondition above to additionally check for DistanceFromTarget.
-	MinRange     float64
-	MaxRange     float64
-	MaxCharges   int // Maximum amount of charges the spell can have
-	charges      int // Current amount of charges the spell has
-	RechargeTime time.Duration
+	MinRange float64
+	MaxRange float64
 
-	rechargeTimer *PendingAction // used for the recharge timer
+	// Charges of the spell, nil if it doesn't use charges.
+	ChargedCD *ChargedCooldown
 
 	castTimeFn func(spell *Spell) time.Duration // allows to override CastTime()
 
 	// Performs a cast of this spell.
 	castFn CastSuccessFunc
 
+	// Set for spells in Player.disabled_spells, which are never cast.
+	disabledByUser bool
+
+	// Whether the spell couldn't be cast for its cost this iteration.
+	costBlocked bool
+
+	// Name of the SpellDataset the spell's tuning came from, if any.
+	spellDataset string
+
+	// Actions whose damage was converted into this spell's healing, see DamageToHealing.
+	convertedFrom []ActionID
+
 	SpellMetrics      []SpellMetrics
 	splitSpellMetrics [][]SpellMetrics // Used to split metrics by some condition.
 	casts             int              // Sum of casts on all targets, for efficient CPM calculation
@@ -128,6 +165,9 @@ type Spell struct {
 	// Optional field. Calculates expected average damage.
 	expectedInitialDamageInternal ExpectedDamageCalculator
 	expectedTickDamageInternal    ExpectedDamageCalculator
+	expectedDamagePerSecond       bool
+
+	damageVariance float64
 
 	// The current or most recent cast data.
 	CurCast Cast
@@ -179,6 +219,8 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		panic(fmt.Sprintf("Over 100 registered spells when registering %s! There is probably a spell being registered every iteration.", config.ActionID))
 	}
 
+	spellDataset := unit.Env.applySpellDataset(&config)
+
 	// Default the other damage multiplier to 1 if only one or the other is set.
 	if config.DamageMultiplier != 0 && config.DamageMultiplierAdditive == 0 {
 		config.DamageMultiplierAdditive = 1
@@ -226,12 +268,17 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		SharedCD:           config.Cast.SharedCD,
 		ExtraCastCondition: config.ExtraCastCondition,
 
+		RequiredStance:   config.RequiredStance,
+		AutoSwitchStance: config.AutoSwitchStance,
+
 		castTimeFn: config.Cast.CastTime,
 
 		ApplyEffects: config.ApplyEffects,
 
 		expectedInitialDamageInternal: config.ExpectedInitialDamage,
 		expectedTickDamageInternal:    config.ExpectedTickDamage,
+		expectedDamagePerSecond:       config.ExpectedDamagePerSecond,
+		damageVariance:                config.DamageVariance,
 
 		BonusHitPercent:          config.BonusHitPercent,
 		BonusCritPercent:         config.BonusCritPercent,
@@ -255,29 +302,19 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		RelatedDotSpell:   config.RelatedDotSpell,
 		RelatedSelfBuff:   config.RelatedSelfBuff,
 
-		charges:      config.Charges,
-		MaxCharges:   config.Charges,
-		RechargeTime: config.RechargeTime,
+		ChargedCD: config.ChargedCD,
+
+		spellDataset: spellDataset,
 
 		resultCache: make(SpellResultCache, 1),
 		resultSlice: make(SpellResultSlice, 0, 1),
 	}
 
-	switch {
-	case spell.SpellSchool.Matches(SpellSchoolPhysical):
-		spell.SchoolIndex = stats.SchoolIndexPhysical
-	case spell.SpellSchool.Matches(SpellSchoolArcane):
-		spell.SchoolIndex = stats.SchoolIndexArcane
-	case spell.SpellSchool.Matches(SpellSchoolFire):
-		spell.SchoolIndex = stats.SchoolIndexFire
-	case spell.SpellSchool.Matches(SpellSchoolFrost):
-		spell.SchoolIndex = stats.SchoolIndexFrost
-	case spell.SpellSchool.Matches(SpellSchoolHoly):
-		spell.SchoolIndex = stats.SchoolIndexHoly
-	case spell.SpellSchool.Matches(SpellSchoolNature):
-		spell.SchoolIndex = stats.SchoolIndexNature
-	case spell.SpellSchool.Matches(SpellSchoolShadow):
-		spell.SchoolIndex = stats.SchoolIndexShadow
+	if schoolIndices := spell.SpellSchool.SchoolIndices(); len(schoolIndices) > 0 {
+		spell.SchoolIndex = schoolIndices[0]
+		if len(schoolIndices) > 1 {
+			spell.schoolIndices = schoolIndices
+		}
 	}
 
 	if config.ManaCost.BaseCostPercent != 0 || config.ManaCost.FlatCost != 0 {
@@ -292,8 +329,12 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		spell.Cost = newFocusCost(spell, config.FocusCost)
 	}
 
+	if spell.ChargedCD == nil && config.RechargeTime > 0 {
+		spell.ChargedCD = unit.NewChargedCooldown(config.Charges, config.RechargeTime)
+	}
+
 	// Create timer to track recharge time if none given
-	if spell.CD.Timer == nil && spell.RechargeTime > 0 {
+	if spell.CD.Timer == nil && spell.ChargedCD != nil {
 		spell.CD.Timer = spell.Unit.NewTimer()
 	}
 
@@ -316,7 +357,7 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 	}
 
 	if spell.DefaultCast == emptyCast {
-		if config.ExtraCastCondition == nil && config.Cast.CD.Timer == nil && config.Cast.SharedCD.Timer == nil {
+		if config.ExtraCastCondition == nil && config.RequiredStance == StanceNone && config.Cast.CD.Timer == nil && config.Cast.SharedCD.Timer == nil {
 			spell.castFn = spell.makeCastFuncAutosOrProcs()
 		} else {
 			spell.castFn = spell.makeCastFuncSimple()
@@ -337,7 +378,7 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		spell.MaxRange = config.MaxRange
 		oldExtraCastCondition := spell.ExtraCastCondition
 		spell.ExtraCastCondition = func(sim *Simulation, target *Unit) bool {
-			if ((spell.MinRange != 0) && (spell.Unit.DistanceFromTarget < spell.MinRange)) || ((spell.MaxRange != 0) && (spell.Unit.DistanceFromTarget > spell.MaxRange)) {
+			if spell.isOutOfRange() {
 				/*if sim.Log != nil {
 					sim.Log("Cannot cast spell %s, out of range!", spell.ActionID)
 				}*/
@@ -349,6 +390,13 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		}
 	}
 
+	if unit.disabledSpells.contains(spell.ActionID) {
+		spell.disabledByUser = true
+		spell.castFn = func(sim *Simulation, _ *Unit) bool {
+			return spell.castFailureHelper(sim, "disabled by user")
+		}
+	}
+
 	unit.Spellbook = append(unit.Spellbook, spell)
 
 	for _, handler := range unit.spellRegistrationHandlers {
@@ -499,12 +547,13 @@ func (spell *Spell) reset(sim *Simulation) {
 			spell.splitSpellMetrics[i][j] = SpellMetrics{}
 		}
 	}
-	spell.casts = 0
-	if spell.rechargeTimer != nil {
-		spell.rechargeTimer.Cancel(sim)
-		spell.rechargeTimer = nil
-		spell.charges = spell.MaxCharges
+	// The split selected last iteration would otherwise tag the cast failures
+	// and dots of the next one, before the spell selects its own.
+	if len(spell.splitSpellMetrics) > 1 {
+		spell.SetMetricsSplit(0)
 	}
+	spell.casts = 0
+	spell.costBlocked = false
 }
 
 func (spell *Spell) SetMetricsSplit(splitIdx int32) {
@@ -528,11 +577,21 @@ func (spell *Spell) GetMetricSplitCount() int {
 	return len(spell.splitSpellMetrics)
 }
 
-func (spell *Spell) doneIteration() {
+func (spell *Spell) doneIteration(sim *Simulation) {
 	if spell.Flags.Matches(SpellFlagNoMetrics) {
 		return
 	}
 
+	// Scale the ticks done to the whole fight, at the average tick period. Each
+	// split has its own ticks, not only the one currently selected.
+	for _, spellMetrics := range spell.splitSpellMetrics {
+		for i := range spellMetrics {
+			if tm := &spellMetrics[i]; tm.DotTickTime > 0 {
+				tm.DotMaxTicks = float64(tm.Ticks) * float64(sim.CurrentTime) / float64(tm.DotTickTime)
+			}
+		}
+	}
+
 	if len(spell.splitSpellMetrics) == 1 {
 		spell.Unit.Metrics.addSpellMetrics(spell, spell.ActionID, spell.SpellMetrics)
 	} else {
@@ -578,6 +637,10 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	if spell.disabledByUser {
+		return false
+	}
+
 	if spell.Flags.Matches(SpellFlagSwapped) {
 		//if sim.Log != nil {
 		//	sim.Log("Cant cast because of item swap")
@@ -585,6 +648,20 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	if !spell.meetsStanceRequirement(sim) {
+		//if sim.Log != nil {
+		//	sim.Log("Cant cast because of stance")
+		//}
+		return false
+	}
+
+	if target.IsImmuneTo(spell) {
+		//if sim.Log != nil {
+		//	sim.Log("Cant cast because the target is immune")
+		//}
+		return false
+	}
+
 	if spell.ExtraCastCondition != nil && !spell.ExtraCastCondition(sim, target) {
 		//if sim.Log != nil {
 		//	sim.Log("Cant cast because of extra condition")
@@ -608,7 +685,7 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
-	if ((spell.DefaultCast.GCD > 0) || (spell.Flags.Matches(SpellFlagMCD) && spell.Unit.Rotation.inSequence)) && !spell.Unit.GCD.IsReady(sim) {
+	if ((spell.DefaultCast.GCD > 0) || (spell.Flags.Matches(SpellFlagMCD) && spell.Unit.Rotation != nil && spell.Unit.Rotation.inSequence)) && !spell.Unit.GCD.IsReady(sim) {
 		//if sim.Log != nil {
 		//	sim.Log("Cant cast because of GCD")
 		//}
@@ -627,18 +704,23 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 			//if sim.Log != nil {
 			//	sim.Log("Cant cast because of resource cost")
 			//}
+			spell.costBlocked = true
 			return false
 		}
 	}
 
 	// Spell uses charges but has none
-	if spell.MaxCharges > 0 && spell.charges == 0 {
+	if spell.usesCharges() && !spell.ChargedCD.IsReady() {
 		return false
 	}
 
 	return true
 }
 
+func (spell *Spell) isOutOfRange() bool {
+	return ((spell.MinRange != 0) && (spell.Unit.DistanceFromTarget < spell.MinRange)) || ((spell.MaxRange != 0) && (spell.Unit.DistanceFromTarget > spell.MaxRange))
+}
+
 func (spell *Spell) CanCastDuringChannel(sim *Simulation) bool {
 	// Don't allow bypassing of channel clip logic for re-casts of the same channel
 	if spell == spell.Unit.ChanneledDot.Spell {
@@ -672,17 +754,26 @@ func (spell *Spell) CastOnAllOtherTargets(sim *Simulation, mainTarget *Unit) {
 
 // Skips the actual cast and applies spell effects immediately.
 func (spell *Spell) SkipCastAndApplyEffects(sim *Simulation, target *Unit) {
-	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-		spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s)",
+	if sim.LogEnabled(LogCategoryCasts, LogLevelInfo) && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Casting %s (Cost = %0.03f, Cast Time = %s)",
 			spell.ActionID, spell.DefaultCast.Cost, time.Duration(0))
-		spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
+		spell.Unit.LogAt(sim, LogCategoryCasts, LogLevelInfo, "Completed cast %s", spell.ActionID)
 	}
 	spell.applyEffects(sim, target)
 }
 
 func (spell *Spell) applyEffects(sim *Simulation, target *Unit) {
+	// Also stops procs which skip the cast. Procs dealing damage straight from
+	// their aura don't get here, those are disabled through the aura instead.
+	if spell.disabledByUser {
+		return
+	}
+
 	spell.SpellMetrics[target.UnitIndex].Casts++
 	spell.casts++
+	if spell.Flags.Matches(SpellFlagMCD | SpellFlagTrackCooldownDrift) {
+		spell.Unit.Metrics.addCooldownUsage(sim, spell.ActionID)
+	}
 
 	// Not sure if we want to split this flag into its own?
 	// Both are used to optimize away unneccesery calls and 99%
@@ -691,7 +782,16 @@ func (spell *Spell) applyEffects(sim *Simulation, target *Unit) {
 		spell.Unit.OnApplyEffects(sim, target, spell)
 	}
 
+	if audit := sim.varianceAudit; audit != nil {
+		outerSpell := audit.spell
+		audit.spell = spell
+		defer func() { audit.spell = outerSpell }()
+	}
+
+	outerSpell := sim.activeSpell
+	sim.activeSpell = spell
 	spell.ApplyEffects(sim, target, spell)
+	sim.activeSpell = outerSpell
 }
 
 func (spell *Spell) ApplyAOEThreatIgnoreMultipliers(threatAmount float64) {
@@ -792,59 +892,41 @@ func (spell *Spell) IssueRefund(sim *Simulation) {
 	spell.Cost.IssueRefund(sim, spell)
 }
 
-func (spell *Spell) ConsumeCharge(sim *Simulation) {
-	if spell.MaxCharges == 0 {
-		return
-	}
-
-	if spell.charges == 0 {
-		panic("Trying to consume charge, but spell has no charges left.")
-	}
-
-	spell.charges--
-
-	// No recharge in progress yet, start timer
-	if spell.rechargeTimer == nil {
-		spell.scheduleRechargeAction(sim)
-	}
+// Whether the spell currently has charges, which can be added by spell mods
+// to spells without charges by default.
+func (spell *Spell) usesCharges() bool {
+	return spell.ChargedCD != nil && spell.ChargedCD.NumCharges > 0
 }
 
-func (spell *Spell) scheduleRechargeAction(sim *Simulation) {
-	spell.rechargeTimer = &PendingAction{
-		NextActionAt: sim.CurrentTime + spell.RechargeTime,
-		Priority:     ActionPriorityAuto,
-		OnAction: func(sim *Simulation) {
-			spell.RefreshCharge(sim)
-			spell.rechargeTimer = nil
-			if spell.charges < spell.MaxCharges {
-				spell.scheduleRechargeAction(sim)
-			}
-		},
+func (spell *Spell) ConsumeCharge(sim *Simulation) {
+	if !spell.usesCharges() {
+		return
 	}
 
-	sim.AddPendingAction(spell.rechargeTimer)
+	spell.ChargedCD.Consume(sim)
 }
 
 func (spell *Spell) GetNumCharges() int {
-	return spell.charges
+	if spell.ChargedCD == nil {
+		return 0
+	}
+	return spell.ChargedCD.CurrentCharges()
 }
 
 // Calculates the time until the next charge is available.
 // Will return 0 if no recharge is in progress
 func (spell *Spell) NextChargeIn(sim *Simulation) time.Duration {
-	if spell.rechargeTimer == nil {
+	if spell.ChargedCD == nil {
 		return 0
 	}
-
-	return spell.rechargeTimer.NextActionAt - sim.CurrentTime
+	return spell.ChargedCD.TimeToNextCharge(sim)
 }
 
 // Refreshes a charge of the spell
 // Can be called if the spell has max charges
 func (spell *Spell) RefreshCharge(sim *Simulation) {
-	if spell.charges == spell.MaxCharges {
+	if spell.ChargedCD == nil {
 		return
 	}
-
-	spell.charges++
+	spell.ChargedCD.RestoreCharge(sim)
 }
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Source of spell tuning values which replace the ones hardcoded in the class
+// code, e.g. coefficients datamined from a PTR build. Supplied when the
+// Environment is constructed, see NewEnvironmentWithSpellDataset.
+type SpellDataset interface {
+	// Shown in the metrics of the spells using this dataset.
+	Name() string
+
+	// Returns the tuning of the spell, and whether the dataset contains it.
+	SpellTuning(actionID ActionID) (SpellTuning, bool)
+}
+
+// Tuning values of a spell.
+type SpellTuning struct {
+	// Values of numeric SpellConfig fields by their path, e.g. "Dot.NumberOfTicks".
+	// Durations are in seconds.
+	Overrides map[string]float64
+
+	// Only checked by SimOptions.audit_damage_variance, 0 if unknown.
+	Variance float64
+}
+
+type protoSpellDataset struct {
+	name   string
+	spells map[ActionID]SpellTuning
+}
+
+// Creates a SpellDataset from the values in the request, see Raid.spell_dataset.
+func NewSpellDatasetFromProto(datasetProto *proto.SpellDataset) SpellDataset {
+	dataset := &protoSpellDataset{
+		name:   datasetProto.Name,
+		spells: make(map[ActionID]SpellTuning, len(datasetProto.Spells)),
+	}
+	for _, tuningProto := range datasetProto.Spells {
+		tuning := SpellTuning{
+			Overrides: make(map[string]float64, len(tuningProto.Overrides)+3),
+			Variance:  tuningProto.Variance,
+		}
+		for path, value := range map[string]float64{
+			"BonusCoefficient":     tuningProto.BonusCoefficient,
+			"DamageMultiplier":     tuningProto.DamageMultiplier,
+			"Dot.BonusCoefficient": tuningProto.DotBonusCoefficient,
+		} {
+			if value != 0 {
+				tuning.Overrides[path] = value
+			}
+		}
+		maps.Copy(tuning.Overrides, tuningProto.Overrides)
+		dataset.spells[ProtoToActionID(tuningProto.Id)] = tuning
+	}
+	return dataset
+}
+
+func (dataset *protoSpellDataset) Name() string {
+	return dataset.name
+}
+
+func (dataset *protoSpellDataset) SpellTuning(actionID ActionID) (SpellTuning, bool) {
+	if tuning, ok := dataset.spells[actionID]; ok {
+		return tuning, true
+	}
+	tuning, ok := dataset.spells[actionID.WithTag(0)]
+	return tuning, ok
+}
+
+// Replaces the values of the config with the ones from the environment's
+// dataset. Returns the name of the dataset if it changed the spell's tuning.
+func (env *Environment) applySpellDataset(config *SpellConfig) string {
+	if env == nil || env.spellDataset == nil {
+		return ""
+	}
+
+	tuning, ok := env.spellDataset.SpellTuning(config.ActionID)
+	if !ok {
+		return ""
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(tuning.Overrides)) {
+		if err := overrideSpellConfigField(config, path, tuning.Overrides[path]); err != nil {
+			panic(fmt.Sprintf("Invalid override of %s in spell dataset %s: %s", config.ActionID, env.spellDataset.Name(), err))
+		}
+	}
+	if tuning.Variance != 0 {
+		config.DamageVariance = tuning.Variance
+	}
+
+	// Variances are only audited, so they don't make the spell's tuning come
+	// from the dataset.
+	if len(tuning.Overrides) == 0 {
+		return ""
+	}
+	return env.spellDataset.Name()
+}
+
+// Sets the numeric field of the config at the path of exported field names.
+// Fields behind pointers are shared with other spells, so they can't be set.
+func overrideSpellConfigField(config *SpellConfig, path string, value float64) error {
+	field := reflect.ValueOf(config).Elem()
+	for _, name := range strings.Split(path, ".") {
+		if field.Kind() != reflect.Struct {
+			return fmt.Errorf("%s doesn't lead through struct fields", path)
+		}
+		structField, ok := field.Type().FieldByName(name)
+		if !ok || !structField.IsExported() {
+			return fmt.Errorf("unknown field %s", path)
+		}
+		field = field.FieldByIndex(structField.Index)
+	}
+
+	switch {
+	case field.Type() == reflect.TypeOf(time.Duration(0)):
+		field.SetInt(int64(DurationFromSeconds(value)))
+	case field.CanFloat():
+		field.SetFloat(value)
+	case field.CanInt():
+		field.SetInt(int64(math.Round(value)))
+	default:
+		return fmt.Errorf("%s is not numeric", path)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestSpellDatasetOverridesConfig(t *testing.T) {
+	env := &Environment{
+		spellDataset: NewSpellDatasetFromProto(&proto.SpellDataset{
+			Name: "PTR",
+			Spells: []*proto.SpellTuning{
+				{
+					Id:                  ActionID{SpellID: 100}.ToProto(),
+					BonusCoefficient:    1.5,
+					DotBonusCoefficient: 0.25,
+				},
+				{
+					Id:       ActionID{SpellID: 300}.ToProto(),
+					Variance: 0.5,
+				},
+			},
+		}),
+	}
+
+	config := SpellConfig{
+		ActionID:         ActionID{SpellID: 100, Tag: 2},
+		BonusCoefficient: 1,
+		DamageMultiplier: 1.1,
+	}
+	if name := env.applySpellDataset(&config); name != "PTR" {
+		t.Fatalf("Expected tagged spell to use the untagged tuning, got dataset %q", name)
+	}
+	if config.BonusCoefficient != 1.5 || config.Dot.BonusCoefficient != 0.25 {
+		t.Fatalf("Expected coefficients from the dataset, got %f and %f", config.BonusCoefficient, config.Dot.BonusCoefficient)
+	}
+	if config.DamageMultiplier != 1.1 {
+		t.Fatalf("Expected unset values to keep the built-in value, got %f", config.DamageMultiplier)
+	}
+
+	other := SpellConfig{ActionID: ActionID{SpellID: 200}, BonusCoefficient: 1}
+	if name := env.applySpellDataset(&other); name != "" || other.BonusCoefficient != 1 {
+		t.Fatalf("Expected spells missing from the dataset to be unchanged")
+	}
+
+	audited := SpellConfig{ActionID: ActionID{SpellID: 300}, BonusCoefficient: 1}
+	if name := env.applySpellDataset(&audited); name != "" || audited.DamageVariance != 0.5 {
+		t.Fatalf("Expected only the variance to be taken from a variance-only entry, got dataset %q and variance %f", name, audited.DamageVariance)
+	}
+}
+
+func TestSpellDatasetOverridesAnyNumericField(t *testing.T) {
+	env := &Environment{
+		spellDataset: NewSpellDatasetFromProto(&proto.SpellDataset{
+			Name: "PTR",
+			Spells: []*proto.SpellTuning{{
+				Id:               ActionID{SpellID: 100}.ToProto(),
+				BonusCoefficient: 1.5,
+				Overrides: map[string]float64{
+					"BonusCoefficient":  2,
+					"CritMultiplier":    0,
+					"Dot.NumberOfTicks": 8,
+					"Cast.CD.Duration":  1.5,
+				},
+			}},
+		}),
+	}
+
+	config := SpellConfig{
+		ActionID:       ActionID{SpellID: 100},
+		CritMultiplier: 2,
+		Dot:            DotConfig{NumberOfTicks: 6},
+		Cast:           CastConfig{CD: Cooldown{Duration: time.Second * 6}},
+	}
+	if name := env.applySpellDataset(&config); name != "PTR" {
+		t.Fatalf("Expected the spell to use the dataset, got %q", name)
+	}
+	if config.BonusCoefficient != 2 {
+		t.Fatalf("Expected overrides to take precedence over the shorthand fields, got %f", config.BonusCoefficient)
+	}
+	if config.CritMultiplier != 0 || config.Dot.NumberOfTicks != 8 || config.Cast.CD.Duration != time.Millisecond*1500 {
+		t.Fatalf("Expected the overridden fields, got %f, %d and %s", config.CritMultiplier, config.Dot.NumberOfTicks, config.Cast.CD.Duration)
+	}
+
+	for _, path := range []string{"Unknown", "ActionID", "Dot.TickRules.TickOnApplication", "Dot.Aura.Label"} {
+		if err := overrideSpellConfigField(&SpellConfig{}, path, 1); err == nil {
+			t.Errorf("Expected overriding %s to fail", path)
+		}
+	}
+}
@@ -105,6 +105,7 @@ func buildMod(unit *Unit, config SpellModConfig) *SpellMod {
 		OnReset:      resetFn,
 	}
 
+	unit.spellMods = append(unit.spellMods, mod)
 	unit.OnSpellRegistered(func(spell *Spell) {
 		if shouldApply(spell, mod) {
 			mod.AffectedSpells = append(mod.AffectedSpells, spell)
@@ -123,6 +124,7 @@ func buildMod(unit *Unit, config SpellModConfig) *SpellMod {
 
 	if config.ShouldApplyToPets {
 		for _, pet := range unit.PetAgents {
+			pet.GetPet().spellMods = append(pet.GetPet().spellMods, mod)
 			pet.GetPet().OnSpellRegistered(func(spell *Spell) {
 				if shouldApply(spell, mod) {
 					mod.AffectedSpells = append(mod.AffectedSpells, spell)
@@ -233,6 +235,10 @@ func (mod *SpellMod) GetTimeValue() time.Duration {
 	return mod.timeValue
 }
 
+func (mod *SpellMod) affects(spell *Spell) bool {
+	return slices.Contains(mod.AffectedSpells, spell)
+}
+
 func (mod *SpellMod) Activate() {
 	if mod.IsActive {
 		return
@@ -352,7 +358,8 @@ const (
 	// Uses: ApplyCustom | RemoveCustom
 	SpellMod_Custom
 
-	// Used to modify the amount of charges a spell has
+	// Used to modify the amount of charges a spell has. Spells sharing charges
+	// only get them once.
 	// Uses: IntValue
 	SpellMod_ModCharges_Flat
 
@@ -755,32 +762,14 @@ func removeBuffDurationFlat(mod *SpellMod, spell *Spell) {
 }
 
 func applyModChargesFlat(mod *SpellMod, spell *Spell) {
-	spell.MaxCharges += int(mod.GetIntValue())
-	if spell.MaxCharges < 0 {
-		panic("Reducing the charges below 0 is not supported. Something seems wrong.")
-	}
-
-	if mod.GetIntValue() > 0 {
-		spell.charges += int(mod.GetIntValue())
-	}
-
-	if spell.charges > spell.MaxCharges {
-		spell.charges = spell.MaxCharges
+	if spell.ChargedCD != nil {
+		spell.ChargedCD.applyChargeMod(mod)
 	}
 }
 
 func removeModChargesFlat(mod *SpellMod, spell *Spell) {
-	spell.MaxCharges -= int(mod.GetIntValue())
-	if spell.MaxCharges < 0 {
-		panic("Reducing the charges below 0 is not supported. Something seems wrong.")
-	}
-
-	if mod.GetIntValue() < 0 {
-		spell.charges -= int(mod.GetIntValue())
-	}
-
-	if spell.charges > spell.MaxCharges {
-		spell.charges = spell.MaxCharges
+	if spell.ChargedCD != nil {
+		spell.ChargedCD.removeChargeMod(mod)
 	}
 }
 
@@ -2,6 +2,8 @@ package core
 
 import (
 	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
 )
 
 type QueuedSpell struct {
@@ -62,8 +64,8 @@ func (unit *Unit) QueueSpell(sim *Simulation, spell *Spell, target *Unit, queueA
 	fireAt := queueAt + time.Duration(1) // 1ns artificial delay guarantees last-second cancellation if desired
 	unit.QueuedSpell.InitiateQueue(sim, spell, target, fireAt)
 
-	if sim.Log != nil {
-		unit.Log(sim, "Queueing up %s to cast at %s.", spell.ActionID, fireAt)
+	if sim.LogEnabled(LogCategoryAI, LogLevelTrace) {
+		unit.LogAt(sim, LogCategoryAI, LogLevelTrace, "Queueing up %s to cast at %s.", spell.ActionID, fireAt)
 	}
 }
 
@@ -76,47 +78,62 @@ func (unit *Unit) CanQueueSpell(sim *Simulation) bool {
 // game's spell queueing functionality. Assumes the maximum spell queue window of 400ms
 // that the game allows.
 func (spell *Spell) CanQueue(sim *Simulation, target *Unit) bool {
+	canQueue, _ := spell.canQueue(sim, target)
+	return canQueue
+}
+
+// Same as CanQueue, but also returns why the spell can't be queued. Failures which
+// are expected to happen regularly (GCD, cooldowns, an ongoing cast) are not
+// reported, so the reason is CastFailureNone for those.
+func (spell *Spell) canQueue(sim *Simulation, target *Unit) (bool, proto.CastFailureReason) {
 	if spell == nil {
-		return false
+		return false, proto.CastFailureReason_CastFailureNone
 	}
 
 	if target == nil || !target.IsEnabled() {
-		return false
+		return false, proto.CastFailureReason_CastFailureTargetDisabled
 	}
 
 	if spell.Flags.Matches(SpellFlagSwapped) {
-		return false
+		return false, proto.CastFailureReason_CastFailureSwapped
 	}
 
 	// Same extra cast conditions apply as if we were casting right now
+	if !spell.meetsStanceRequirement(sim) {
+		return false, proto.CastFailureReason_CastFailureWrongStance
+	}
 	if spell.ExtraCastCondition != nil && !spell.ExtraCastCondition(sim, target) {
-		return false
+		if spell.isOutOfRange() {
+			return false, proto.CastFailureReason_CastFailureOutOfRange
+		}
+		return false, proto.CastFailureReason_CastFailureExtraCondition
 	}
 
 	// Apply SQW leniency to any pending hardcasts
 	if (spell.Unit.Hardcast.Expires > sim.CurrentTime+MaxSpellQueueWindow) || (spell.Unit.IsCastingDuringChannel() && !spell.CanCastDuringChannel(sim)) {
-		return false
+		return false, proto.CastFailureReason_CastFailureNone
 	}
 
 	// Apply SQW leniency to GCD timer
 	if spell.DefaultCast.GCD > 0 && spell.Unit.GCD.TimeToReady(sim) > MaxSpellQueueWindow {
-		return false
+		return false, proto.CastFailureReason_CastFailureNone
 	}
 
 	// Spells that are within one SQW of coming off cooldown can also be queued
 	if MaxTimeToReady(spell.CD.Timer, spell.SharedCD.Timer, sim) > MaxSpellQueueWindow {
-		return false
+		return false, proto.CastFailureReason_CastFailureNone
 	}
 
 	// By contrast, spells that are waiting on resources to cast *cannot* be queued
 	if spell.Cost != nil {
 		spell.CurCast.Cost = spell.Cost.GetCurrentCost()
 		if !spell.Cost.MeetsRequirement(sim, spell) {
-			return false
+			spell.costBlocked = true
+			return false, proto.CastFailureReason_CastFailureCost
 		}
 	}
 
-	return true
+	return true, proto.CastFailureReason_CastFailureNone
 }
 
 // Helper function for APL checks to prevent infinite loops
@@ -124,6 +141,14 @@ func (spell *Spell) CanCastOrQueue(sim *Simulation, target *Unit) bool {
 	return spell.Unit.CanQueueSpell(sim) && spell.CanQueue(sim, target)
 }
 
+// Same as CanCastOrQueue, but also returns why the spell can't be cast, see canQueue.
+func (spell *Spell) canCastOrQueue(sim *Simulation, target *Unit) (bool, proto.CastFailureReason) {
+	if !spell.Unit.CanQueueSpell(sim) {
+		return false, proto.CastFailureReason_CastFailureNone
+	}
+	return spell.canQueue(sim, target)
+}
+
 func (spell *Spell) CastOrQueue(sim *Simulation, target *Unit) {
 	if spell.CanCast(sim, target) {
 		spell.Cast(sim, target)
@@ -7,15 +7,28 @@ import (
 	"github.com/wowsims/mop/sim/core/stats"
 )
 
+// Kind of amount carried by a SpellResult. Damage and healing results go
+// through the same calculation and deal functions, which pick the stages and
+// callbacks matching the type.
+type SpellResultType byte
+
+const (
+	SpellResultTypeDamage SpellResultType = iota
+	SpellResultTypeHealing
+)
+
 type SpellResult struct {
 	// Target of the spell.
 	Target *Unit
 
 	// Results
+	Type    SpellResultType
 	Outcome HitOutcome
 	Damage  float64 // Damage done by this cast.
 	Threat  float64 // The amount of threat generated by this cast.
 
+	Absorbed float64 // Damage of this cast absorbed by shields of the target, see Absorb.
+
 	ArmorMultiplier  float64 // Armor multiplier
 	PreOutcomeDamage float64 // Damage done by this cast before Outcome is applied
 
@@ -67,8 +80,10 @@ func (resultCache SpellResultCache) Get(target *Unit) *SpellResult {
 func (spell *Spell) NewResult(target *Unit) *SpellResult {
 	result := spell.resultCache.Get(target)
 	result.Target = target
+	result.Type = SpellResultTypeDamage
 	result.Damage = 0
 	result.Threat = 0
+	result.Absorbed = 0
 	result.Outcome = OutcomeEmpty // for blocks
 	result.inUse = true
 	result.PreOutcomeDamage = 0
@@ -79,6 +94,16 @@ func (spell *Spell) DisposeResult(result *SpellResult) {
 	result.inUse = false
 }
 
+// Absorbs up to amount of the result's damage, for shields of the target. Should
+// be called from a dynamic damage taken modifier. Returns the absorbed damage,
+// which is tracked in the result and in the spell's metrics.
+func (result *SpellResult) Absorb(amount float64) float64 {
+	absorbed := max(0, min(amount, result.Damage))
+	result.Damage -= absorbed
+	result.Absorbed += absorbed
+	return absorbed
+}
+
 func (result *SpellResult) Landed() bool {
 	return result.Outcome.Matches(OutcomeLanded)
 }
@@ -100,6 +125,9 @@ func (result *SpellResult) DamageString() string {
 	if !result.Landed() {
 		return outcomeStr
 	}
+	if result.Absorbed > 0 {
+		return fmt.Sprintf("%s for %0.3f damage (%0.3f absorbed)", outcomeStr, result.Damage, result.Absorbed)
+	}
 	return fmt.Sprintf("%s for %0.3f damage", outcomeStr, result.Damage)
 }
 func (result *SpellResult) HealingString() string {
@@ -134,7 +162,7 @@ func (spell *Spell) RangedAttackPower() float64 {
 
 func (spell *Spell) DodgeSuppression() float64 {
 	expertiseRating := spell.Unit.stats[stats.ExpertiseRating] + spell.BonusExpertiseRating
-	return expertiseRating / ExpertisePerQuarterPercentReduction / 400
+	return Ratings.ExpertisePercent(expertiseRating) / 100
 }
 
 // MoP reworked Parry. Rather than being innately ~2x Dodge chance, expertise now applies to Dodge first (down to 0), and then Parry.
@@ -238,43 +266,81 @@ func (spell *Spell) CalcOutcome(sim *Simulation, target *Unit, outcomeApplier Ou
 }
 
 func (spell *Spell) calcDamageInternal(sim *Simulation, target *Unit, baseDamage float64, attackerMultiplier float64, isPeriodic bool, outcomeApplier OutcomeApplier) *SpellResult {
+	return spell.calcResultInternal(sim, target, SpellResultTypeDamage, baseDamage, attackerMultiplier, isPeriodic, outcomeApplier)
+}
+
+// Shared modifier and outcome resolution for damage and healing results. The
+// stages which differ between the two are selected by the result type. The
+// amount after each stage is only tracked for the debug log when logging is on.
+func (spell *Spell) calcResultInternal(sim *Simulation, target *Unit, resultType SpellResultType, baseAmount float64, attackerMultiplier float64, isPeriodic bool, outcomeApplier OutcomeApplier) *SpellResult {
 	attackTable := spell.Unit.AttackTables[target.UnitIndex]
 
 	result := spell.NewResult(target)
-	result.Damage = baseDamage
+	result.Type = resultType
+	result.Damage = baseAmount * attackerMultiplier
 
 	if sim.Log == nil {
-		result.Damage *= attackerMultiplier
-		result.applyArmor(spell, isPeriodic, attackTable)
-		result.applyTargetModifiers(sim, spell, attackTable, isPeriodic)
-
+		spell.applyResultArmor(result, isPeriodic, attackTable)
+		spell.applyResultTargetModifiers(sim, result, isPeriodic, attackTable)
 		outcomeApplier(sim, result, attackTable)
-
-		spell.ApplyPostOutcomeDamageModifiers(sim, result, isPeriodic)
+		spell.applyResultPostOutcomeModifiers(sim, result, isPeriodic)
 	} else {
-		result.Damage *= attackerMultiplier
-		afterAttackMods := result.Damage
-		result.applyArmor(spell, isPeriodic, attackTable)
+		afterAttackerMods := result.Damage
+		spell.applyResultArmor(result, isPeriodic, attackTable)
 		afterArmor := result.Damage
-		result.applyTargetModifiers(sim, spell, attackTable, isPeriodic)
+		spell.applyResultTargetModifiers(sim, result, isPeriodic, attackTable)
 		afterTargetMods := result.Damage
-
 		outcomeApplier(sim, result, attackTable)
 		afterOutcome := result.Damage
-
-		spell.ApplyPostOutcomeDamageModifiers(sim, result, isPeriodic)
+		spell.applyResultPostOutcomeModifiers(sim, result, isPeriodic)
 		afterPostOutcome := result.Damage
 
-		spell.Unit.Log(
-			sim,
-			"%s %s [DEBUG] MAP: %0.01f, RAP: %0.01f, SP: %0.01f, BaseDamage:%0.01f, AfterAttackerMods:%0.01f, AfterArmor:%0.01f, AfterTargetMods:%0.01f, AfterOutcome:%0.01f, AfterPostOutcome:%0.01f",
-			target.LogLabel(), spell.ActionID, spell.Unit.GetStat(stats.AttackPower), spell.Unit.GetStat(stats.RangedAttackPower), spell.SpellPower(), baseDamage, afterAttackMods, afterArmor, afterTargetMods, afterOutcome, afterPostOutcome)
+		if resultType == SpellResultTypeDamage && sim.shouldLogMultipliers(spell) {
+			spell.logMultiplierChain(sim, result, attackerMultiplier, isPeriodic)
+		}
+
+		if resultType == SpellResultTypeHealing {
+			spell.Unit.Log(
+				sim,
+				"%s %s [DEBUG] HealingPower: %0.01f, BaseHealing:%0.01f, AfterCasterMods:%0.01f, AfterTargetMods:%0.01f, AfterOutcome:%0.01f, AfterPostOutcome:%0.01f",
+				target.LogLabel(), spell.ActionID, spell.HealingPower(target), baseAmount, afterAttackerMods, afterTargetMods, afterOutcome, afterPostOutcome)
+		} else {
+			spell.Unit.Log(
+				sim,
+				"%s %s [DEBUG] MAP: %0.01f, RAP: %0.01f, SP: %0.01f, BaseDamage:%0.01f, AfterAttackerMods:%0.01f, AfterArmor:%0.01f, AfterTargetMods:%0.01f, AfterOutcome:%0.01f, AfterPostOutcome:%0.01f",
+				target.LogLabel(), spell.ActionID, spell.Unit.GetStat(stats.AttackPower), spell.Unit.GetStat(stats.RangedAttackPower), spell.SpellPower(), baseAmount, afterAttackerMods, afterArmor, afterTargetMods, afterOutcome, afterPostOutcome)
+		}
 	}
 
 	result.Threat = spell.ThreatFromDamage(sim, result.Outcome, result.Damage, attackTable)
 
 	return result
 }
+
+// Healing ignores armor.
+func (spell *Spell) applyResultArmor(result *SpellResult, isPeriodic bool, attackTable *AttackTable) {
+	if result.Type == SpellResultTypeDamage {
+		result.applyArmor(spell, isPeriodic, attackTable)
+	}
+}
+
+func (spell *Spell) applyResultTargetModifiers(sim *Simulation, result *SpellResult, isPeriodic bool, attackTable *AttackTable) {
+	if result.Type == SpellResultTypeHealing {
+		result.Damage = spell.applyTargetHealingModifiers(result.Damage, attackTable)
+	} else {
+		result.applyTargetModifiers(sim, spell, attackTable, isPeriodic)
+	}
+}
+
+// Absorbs of the target are part of the dynamic damage taken modifiers, see
+// SpellResult.Absorb.
+func (spell *Spell) applyResultPostOutcomeModifiers(sim *Simulation, result *SpellResult, isPeriodic bool) {
+	if result.Type == SpellResultTypeHealing {
+		spell.ApplyPostOutcomeHealingModifiers(sim, result)
+	} else {
+		spell.ApplyPostOutcomeDamageModifiers(sim, result, isPeriodic)
+	}
+}
 func (spell *Spell) CalcDamage(sim *Simulation, target *Unit, baseDamage float64, outcomeApplier OutcomeApplier) *SpellResult {
 	attackerMultiplier := spell.AttackerDamageMultiplier(spell.Unit.AttackTables[target.UnitIndex], false)
 	if spell.BonusCoefficient > 0 {
@@ -295,10 +361,10 @@ func (spell *Spell) CalcPeriodicDamage(sim *Simulation, target *Unit, baseDamage
 		baseDamage += dot.BonusCoefficient * spell.BonusDamage()
 	}
 	attackerMultiplier *= dot.PeriodicDamageMultiplier
-	return spell.calcDamageInternal(sim, target, baseDamage, attackerMultiplier, true, outcomeApplier)
+	return spell.calcDamageInternal(sim, target, baseDamage*dot.tickFraction(), attackerMultiplier, true, outcomeApplier)
 }
 func (dot *Dot) CalcSnapshotDamage(sim *Simulation, target *Unit, outcomeApplier OutcomeApplier) *SpellResult {
-	return dot.Spell.calcDamageInternal(sim, target, dot.SnapshotBaseDamage, dot.SnapshotAttackerMultiplier, true, outcomeApplier)
+	return dot.Spell.calcDamageInternal(sim, target, dot.SnapshotBaseDamage*dot.tickFraction(), dot.SnapshotAttackerMultiplier, true, outcomeApplier)
 }
 
 func (spell *Spell) DealOutcome(sim *Simulation, result *SpellResult) {
@@ -311,9 +377,67 @@ func (spell *Spell) CalcAndDealOutcome(sim *Simulation, target *Unit, outcomeApp
 }
 
 // Applies the fully computed spell result to the sim.
-func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
+func (spell *Spell) dealResultInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
+	if result.Type == SpellResultTypeHealing {
+		spell.applyHealingResult(sim, result)
+	} else {
+		spell.applyDamageResult(sim, isPeriodic, result)
+	}
+
+	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		logLevel := Ternary(isPeriodic, LogLevelDebug, LogLevelInfo)
+		tickStr := Ternary(isPeriodic, " tick", "")
+		if result.Type == SpellResultTypeHealing {
+			spell.Unit.LogAt(sim, LogCategoryDamage, logLevel, "%s %s%s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, tickStr, result.HealingString(), result.Threat)
+		} else {
+			spell.Unit.LogAt(sim, LogCategoryDamage, logLevel, "%s %s%s %s (SpellSchool: %d). (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, tickStr, result.DamageString(), spell.SpellSchool, result.Threat)
+		}
+	}
+
+	switch {
+	case result.Type == SpellResultTypeHealing && isPeriodic:
+		spell.Unit.OnPeriodicHealDealt(sim, spell, result)
+		result.Target.OnPeriodicHealTaken(sim, spell, result)
+	case result.Type == SpellResultTypeHealing:
+		spell.Unit.OnHealDealt(sim, spell, result)
+		result.Target.OnHealTaken(sim, spell, result)
+	case spell.Flags.Matches(SpellFlagNoOnDamageDealt):
+	case isPeriodic:
+		spell.Unit.OnPeriodicDamageDealt(sim, spell, result)
+		result.Target.OnPeriodicDamageTaken(sim, spell, result)
+	default:
+		spell.Unit.OnSpellHitDealt(sim, spell, result)
+		result.Target.OnSpellHitTaken(sim, spell, result)
+	}
+
+	spell.DisposeResult(result)
+}
+
+func (spell *Spell) applyDamageResult(sim *Simulation, isPeriodic bool, result *SpellResult) {
+	if result.Damage > 0 && result.Target.IsImmuneTo(spell) {
+		if sim.Log != nil {
+			spell.Unit.Log(sim, "%s %s lost %0.3f damage to immunity.", result.Target.LogLabel(), spell.ActionID, result.Damage)
+		}
+		if sim.CurrentTime >= 0 {
+			spell.SpellMetrics[result.Target.UnitIndex].TotalImmuneDamage += result.Damage
+		}
+		result.Damage = 0
+		result.Threat = 0
+		result.Outcome = OutcomeImmune
+	}
+
+	if len(sim.Encounter.schoolImmunities) > 0 && result.Damage > 0 {
+		if immunity := sim.Encounter.activeSchoolImmunity(spell, result.Target); immunity != nil {
+			lostDamage := immunity.absorb(sim, spell, result)
+			if sim.CurrentTime >= 0 {
+				spell.SpellMetrics[result.Target.UnitIndex].TotalImmuneDamage += lostDamage
+			}
+		}
+	}
+
 	if sim.CurrentTime >= 0 {
 		spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += result.Damage
+		spell.SpellMetrics[result.Target.UnitIndex].TotalAbsorbedDamage += result.Absorbed
 		if isPeriodic {
 			spell.SpellMetrics[result.Target.UnitIndex].TotalTickDamage += result.Damage
 		}
@@ -337,39 +461,47 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.SpellMetrics[result.Target.UnitIndex].TotalBlockDamage += result.Damage
 		}
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+		if !isPeriodic {
+			spell.SpellMetrics[result.Target.UnitIndex].addDirectResult(result)
+		}
 	}
 
+	spell.addResultThreat(sim, result)
+
 	// Mark total damage done in raid so far for health based fights.
 	// Don't include damage done by EnemyUnits to Players
 	if result.Target.Type == EnemyUnit {
 		sim.Encounter.DamageTaken += result.Damage
-	}
-
-	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-		if isPeriodic {
-			spell.Unit.Log(sim, "%s %s tick %s (SpellSchool: %d). (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.DamageString(), spell.SpellSchool, result.Threat)
-		} else {
-			spell.Unit.Log(sim, "%s %s %s (SpellSchool: %d). (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.DamageString(), spell.SpellSchool, result.Threat)
+		if int(result.Target.Index) < len(sim.Encounter.targetDamageTaken) {
+			sim.Encounter.targetDamageTaken[result.Target.Index] += result.Damage
 		}
-	}
-
-	if !spell.Flags.Matches(SpellFlagNoOnDamageDealt) {
-		if isPeriodic {
-			spell.Unit.OnPeriodicDamageDealt(sim, spell, result)
-			result.Target.OnPeriodicDamageTaken(sim, spell, result)
-		} else {
-			spell.Unit.OnSpellHitDealt(sim, spell, result)
-			result.Target.OnSpellHitTaken(sim, spell, result)
+		if sim.Encounter.council != nil {
+			sim.Encounter.council.onDamageTaken(sim, result.Target, result.Damage)
+		}
+		if sim.Encounter.script != nil {
+			sim.Encounter.script.onDamageTaken(sim, result.Target)
 		}
 	}
+}
 
-	spell.DisposeResult(result)
+func (spell *Spell) applyHealingResult(sim *Simulation, result *SpellResult) {
+	if result.DidCrit() {
+		spell.SpellMetrics[result.Target.UnitIndex].TotalCritHealing += result.Damage
+	}
+	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
+	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+	spell.addResultThreat(sim, result)
+	if result.Target.HasHealthBar() {
+		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
+	}
 }
 func (spell *Spell) DealDamage(sim *Simulation, result *SpellResult) {
-	spell.dealDamageInternal(sim, false, result)
+	result.Type = SpellResultTypeDamage
+	spell.dealResultInternal(sim, false, result)
 }
 func (spell *Spell) DealPeriodicDamage(sim *Simulation, result *SpellResult) {
-	spell.dealDamageInternal(sim, true, result)
+	result.Type = SpellResultTypeDamage
+	spell.dealResultInternal(sim, true, result)
 }
 
 func (spell *Spell) CalcAndDealDamage(sim *Simulation, target *Unit, baseDamage float64, outcomeApplier OutcomeApplier) *SpellResult {
@@ -496,36 +628,7 @@ func (dot *Dot) SnapshotPhysical(target *Unit, baseDamage float64) {
 }
 
 func (spell *Spell) calcHealingInternal(sim *Simulation, target *Unit, baseHealing float64, casterMultiplier float64, outcomeApplier OutcomeApplier) *SpellResult {
-	attackTable := spell.Unit.AttackTables[target.UnitIndex]
-
-	result := spell.NewResult(target)
-	result.Damage = baseHealing
-
-	if sim.Log == nil {
-		result.Damage *= casterMultiplier
-		result.Damage = spell.applyTargetHealingModifiers(result.Damage, attackTable)
-		outcomeApplier(sim, result, attackTable)
-		spell.ApplyPostOutcomeHealingModifiers(sim, result)
-	} else {
-		result.Damage *= casterMultiplier
-		afterCasterMods := result.Damage
-		result.Damage = spell.applyTargetHealingModifiers(result.Damage, attackTable)
-		afterTargetMods := result.Damage
-		outcomeApplier(sim, result, attackTable)
-		afterOutcome := result.Damage
-
-		spell.ApplyPostOutcomeHealingModifiers(sim, result)
-		afterPostOutcome := result.Damage
-
-		spell.Unit.Log(
-			sim,
-			"%s %s [DEBUG] HealingPower: %0.01f, BaseHealing:%0.01f, AfterCasterMods:%0.01f, AfterTargetMods:%0.01f, AfterOutcome:%0.01f, AfterPostOutcome:%0.01f",
-			target.LogLabel(), spell.ActionID, spell.HealingPower(target), baseHealing, afterCasterMods, afterTargetMods, afterOutcome, afterPostOutcome)
-	}
-
-	result.Threat = spell.ThreatFromDamage(sim, result.Outcome, result.Damage, attackTable)
-
-	return result
+	return spell.calcResultInternal(sim, target, SpellResultTypeHealing, baseHealing, casterMultiplier, false, outcomeApplier)
 }
 func (spell *Spell) calcHealing(sim *Simulation, target *Unit, baseHealing float64, outcomeApplier OutcomeApplier, isPeriodic bool) *SpellResult {
 	if spell.BonusCoefficient > 0 {
@@ -540,7 +643,7 @@ func (spell *Spell) CalcPeriodicHealing(sim *Simulation, target *Unit, baseHeali
 	return spell.calcHealing(sim, target, baseHealing, outcomeApplier, true)
 }
 func (dot *Dot) CalcSnapshotHealing(sim *Simulation, target *Unit, outcomeApplier OutcomeApplier) *SpellResult {
-	return dot.Spell.calcHealingInternal(sim, target, dot.SnapshotBaseDamage, dot.SnapshotAttackerMultiplier, outcomeApplier)
+	return dot.Spell.calcHealingInternal(sim, target, dot.SnapshotBaseDamage*dot.tickFraction(), dot.SnapshotAttackerMultiplier, outcomeApplier)
 }
 
 func (dot *Dot) SnapshotHeal(target *Unit, baseHealing float64) {
@@ -552,40 +655,13 @@ func (dot *Dot) SnapshotHeal(target *Unit, baseHealing float64) {
 	dot.SnapshotAttackerMultiplier = dot.CasterPeriodicHealingMultiplier()
 }
 
-// Applies the fully computed spell result to the sim.
-func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
-	if result.DidCrit() {
-		spell.SpellMetrics[result.Target.UnitIndex].TotalCritHealing += result.Damage
-	}
-	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
-	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
-	if result.Target.HasHealthBar() {
-		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
-	}
-
-	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
-		if isPeriodic {
-			spell.Unit.Log(sim, "%s %s tick %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.HealingString(), result.Threat)
-		} else {
-			spell.Unit.Log(sim, "%s %s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.HealingString(), result.Threat)
-		}
-	}
-
-	if isPeriodic {
-		spell.Unit.OnPeriodicHealDealt(sim, spell, result)
-		result.Target.OnPeriodicHealTaken(sim, spell, result)
-	} else {
-		spell.Unit.OnHealDealt(sim, spell, result)
-		result.Target.OnHealTaken(sim, spell, result)
-	}
-
-	spell.DisposeResult(result)
-}
 func (spell *Spell) DealHealing(sim *Simulation, result *SpellResult) {
-	spell.dealHealingInternal(sim, false, result)
+	result.Type = SpellResultTypeHealing
+	spell.dealResultInternal(sim, false, result)
 }
 func (spell *Spell) DealPeriodicHealing(sim *Simulation, result *SpellResult) {
-	spell.dealHealingInternal(sim, true, result)
+	result.Type = SpellResultTypeHealing
+	spell.dealResultInternal(sim, true, result)
 }
 
 func (spell *Spell) CalcAndDealHealing(sim *Simulation, target *Unit, baseHealing float64, outcomeApplier OutcomeApplier) *SpellResult {
@@ -598,6 +674,17 @@ func (spell *Spell) CalcAndDealPeriodicHealing(sim *Simulation, target *Unit, ba
 	spell.DealPeriodicHealing(sim, result)
 	return result
 }
+
+// Heals the target for a portion of the damage of a result, for effects which
+// convert damage dealt into healing. Should be called before the damage result
+// is dealt. The heal goes through the healing pipeline of this spell, so
+// healing modifiers and heal callbacks apply as usual.
+func (spell *Spell) CalcAndDealHealingFromResult(sim *Simulation, target *Unit, result *SpellResult, fraction float64, outcomeApplier OutcomeApplier) *SpellResult {
+	if !result.Landed() || result.Damage <= 0 {
+		return nil
+	}
+	return spell.CalcAndDealHealing(sim, target, result.Damage*fraction, outcomeApplier)
+}
 func (dot *Dot) CalcAndDealPeriodicSnapshotHealing(sim *Simulation, target *Unit, outcomeApplier OutcomeApplier) *SpellResult {
 	result := dot.CalcSnapshotHealing(sim, target, outcomeApplier)
 	dot.Spell.DealPeriodicHealing(sim, result)
@@ -627,10 +714,24 @@ func (spell *Spell) attackerDamageMultiplierInternal(attackTable *AttackTable) f
 	}
 
 	return spell.Unit.PseudoStats.DamageDealtMultiplier *
-		spell.Unit.PseudoStats.SchoolDamageDealtMultiplier[spell.SchoolIndex] *
+		spell.schoolMultiplier(&spell.Unit.PseudoStats.SchoolDamageDealtMultiplier) *
 		attackTable.DamageDealtMultiplier
 }
 
+// Returns the multiplier of the spell's school, or the most favorable one of
+// its schools for multi-school spells.
+func (spell *Spell) schoolMultiplier(multipliers *[stats.SchoolLen]float64) float64 {
+	if spell.schoolIndices == nil {
+		return multipliers[spell.SchoolIndex]
+	}
+
+	multiplier := multipliers[spell.schoolIndices[0]]
+	for _, schoolIndex := range spell.schoolIndices[1:] {
+		multiplier = max(multiplier, multipliers[schoolIndex])
+	}
+	return multiplier
+}
+
 func (result *SpellResult) applyTargetModifiers(sim *Simulation, spell *Spell, attackTable *AttackTable, isPeriodic bool) {
 	if spell.Flags.Matches(SpellFlagIgnoreTargetModifiers) {
 		return
@@ -644,7 +745,7 @@ func (spell *Spell) TargetDamageMultiplier(sim *Simulation, attackTable *AttackT
 	}
 
 	multiplier := attackTable.Defender.PseudoStats.DamageTakenMultiplier *
-		attackTable.Defender.PseudoStats.SchoolDamageTakenMultiplier[spell.SchoolIndex] *
+		spell.schoolMultiplier(&attackTable.Defender.PseudoStats.SchoolDamageTakenMultiplier) *
 		attackTable.DamageTakenMultiplier
 
 	if spell.Flags.Matches(SpellFlagDisease) {
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+type resultPipelineCase struct {
+	name     string
+	deal     func(sim *Simulation, spell *Spell, target *Unit) *SpellResult
+	amount   float64
+	metric   func(metrics *SpellMetrics) float64
+	callback string
+	logLines []string
+}
+
+// Damage, healing and their periodic variants all go through calcResultInternal
+// and dealResultInternal. Each case checks the resolved amount, the metrics,
+// the callbacks and the log lines the separate damage and healing functions
+// produced before they were merged, both with and without logging.
+func TestResultPipeline(t *testing.T) {
+	cases := []resultPipelineCase{
+		{
+			name: "damage",
+			deal: func(sim *Simulation, spell *Spell, target *Unit) *SpellResult {
+				return spell.CalcAndDealDamage(sim, target, 100, spell.OutcomeAlwaysHit)
+			},
+			amount:   150,
+			metric:   func(metrics *SpellMetrics) float64 { return metrics.TotalDamage },
+			callback: "OnSpellHitDealt",
+			logLines: []string{
+				"[Caster (#1)] [Target 1] {SpellID: 42} [DEBUG] MAP: 0.0, RAP: 0.0, SP: 0.0, BaseDamage:100.0, AfterAttackerMods:150.0, AfterArmor:150.0, AfterTargetMods:150.0, AfterOutcome:150.0, AfterPostOutcome:150.0",
+				"[Caster (#1)] [Target 1] {SpellID: 42} Hit for 150.000 damage (SpellSchool: 128). (Threat: 150.000)",
+			},
+		},
+		{
+			name: "damage tick",
+			deal: func(sim *Simulation, spell *Spell, target *Unit) *SpellResult {
+				return spell.CalcAndDealPeriodicDamage(sim, target, 100, spell.OutcomeAlwaysHit)
+			},
+			amount:   150,
+			metric:   func(metrics *SpellMetrics) float64 { return metrics.TotalTickDamage },
+			callback: "OnPeriodicDamageDealt",
+			logLines: []string{
+				"[Caster (#1)] [Target 1] {SpellID: 42} [DEBUG] MAP: 0.0, RAP: 0.0, SP: 0.0, BaseDamage:100.0, AfterAttackerMods:150.0, AfterArmor:150.0, AfterTargetMods:150.0, AfterOutcome:150.0, AfterPostOutcome:150.0",
+				"[Caster (#1)] [Target 1] {SpellID: 42} tick Hit for 150.000 damage (SpellSchool: 128). (Threat: 150.000)",
+			},
+		},
+		{
+			name: "healing",
+			deal: func(sim *Simulation, spell *Spell, _ *Unit) *SpellResult {
+				return spell.CalcAndDealHealing(sim, &spell.Unit.Env.Raid.Parties[0].Players[0].GetCharacter().Unit, 100, spell.OutcomeHealing)
+			},
+			amount:   150,
+			metric:   func(metrics *SpellMetrics) float64 { return metrics.TotalHealing },
+			callback: "OnHealDealt",
+			logLines: []string{
+				"[Caster (#1)] [Caster (#1)] {SpellID: 42} [DEBUG] HealingPower: 0.0, BaseHealing:100.0, AfterCasterMods:150.0, AfterTargetMods:150.0, AfterOutcome:150.0, AfterPostOutcome:150.0",
+				"[Caster (#1)] Gained 150.000 health from {SpellID: 42} (-260.000 --> -260.000) of -260 total.",
+				"[Caster (#1)] [Caster (#1)] {SpellID: 42} Hit for 150.000 healing. (Threat: 150.000)",
+			},
+		},
+		{
+			name: "healing tick",
+			deal: func(sim *Simulation, spell *Spell, _ *Unit) *SpellResult {
+				return spell.CalcAndDealPeriodicHealing(sim, &spell.Unit.Env.Raid.Parties[0].Players[0].GetCharacter().Unit, 100, spell.OutcomeHealing)
+			},
+			amount:   150,
+			metric:   func(metrics *SpellMetrics) float64 { return metrics.TotalHealing },
+			callback: "OnPeriodicHealDealt",
+			logLines: []string{
+				"[Caster (#1)] [Caster (#1)] {SpellID: 42} [DEBUG] HealingPower: 0.0, BaseHealing:100.0, AfterCasterMods:150.0, AfterTargetMods:150.0, AfterOutcome:150.0, AfterPostOutcome:150.0",
+				"[Caster (#1)] Gained 150.000 health from {SpellID: 42} (-260.000 --> -260.000) of -260 total.",
+				"[Caster (#1)] [Caster (#1)] {SpellID: 42} tick Hit for 150.000 healing. (Threat: 150.000)",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		for _, logging := range []bool{false, true} {
+			sim, callbacks := setupResultPipelineSim(nil)
+			fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+			target := sim.Encounter.AllTargetUnits[0]
+
+			var logLines []string
+			if logging {
+				sim.Log = func(message string, vals ...interface{}) {
+					logLines = append(logLines, fmt.Sprintf(message, vals...))
+				}
+			}
+
+			result := tc.deal(sim, fa.Spell, target)
+			if total := tc.metric(&fa.Spell.SpellMetrics[result.Target.UnitIndex]); !WithinToleranceFloat64(total, tc.amount, 0.001) {
+				t.Errorf("%s (logging %t): expected %0.3f in the metrics, got %0.3f", tc.name, logging, tc.amount, total)
+			}
+			if !WithinToleranceFloat64(result.Damage, tc.amount, 0.001) {
+				t.Errorf("%s (logging %t): expected %0.3f, got %0.3f", tc.name, logging, tc.amount, result.Damage)
+			}
+			if !slices.Equal(*callbacks, []string{tc.callback}) {
+				t.Errorf("%s (logging %t): expected callbacks %v, got %v", tc.name, logging, []string{tc.callback}, *callbacks)
+			}
+			if logging && !slices.Equal(logLines, tc.logLines) {
+				t.Errorf("%s: expected log lines\n%q\ngot\n%q", tc.name, tc.logLines, logLines)
+			}
+		}
+	}
+}
+
+// Like SetupFakeSim, but records the damage and healing callbacks of the player.
+// beforeFinalize can register additional effects.
+func setupResultPipelineSim(beforeFinalize func(env *Environment)) (*Simulation, *[]string) {
+	raidProto := SinglePlayerRaidProto(&proto.Player{
+		Name:      "Caster",
+		Class:     proto.Class_ClassShaman,
+		Buffs:     &proto.IndividualBuffs{},
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	encounterProto := &proto.Encounter{
+		Targets: []*proto.Target{
+			{Name: "target", Level: 90, MobType: proto.MobType_MobTypeDemon},
+		},
+		Duration: 180,
+	}
+
+	env := &Environment{State: Created}
+	env.construct(raidProto, encounterProto)
+	raidStats := env.initialize(raidProto, encounterProto)
+
+	fa := env.Raid.Parties[0].Players[0].(*FakeAgent)
+	callbacks := &[]string{}
+	record := func(name string) func(*Aura, *Simulation, *Spell, *SpellResult) {
+		return func(_ *Aura, _ *Simulation, _ *Spell, _ *SpellResult) {
+			*callbacks = append(*callbacks, name)
+		}
+	}
+	MakePermanent(fa.RegisterAura(Aura{
+		Label:                 "Callback Recorder",
+		OnSpellHitDealt:       record("OnSpellHitDealt"),
+		OnPeriodicDamageDealt: record("OnPeriodicDamageDealt"),
+		OnHealDealt:           record("OnHealDealt"),
+		OnPeriodicHealDealt:   record("OnPeriodicHealDealt"),
+	}))
+
+	if beforeFinalize != nil {
+		beforeFinalize(env)
+	}
+
+	env.finalize(raidProto, encounterProto, raidStats, false)
+	sim := newSimWithEnv(env, &proto.SimOptions{RandomSeed: 100}, simsignals.CreateSignals())
+	sim.Reset()
+	sim.CurrentTime = 0
+	return sim, callbacks
+}
+
+func TestResultPipelineTracksAbsorbs(t *testing.T) {
+	var shield *DamageAbsorptionAura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		shield = env.Encounter.AllTargetUnits[0].NewDamageAbsorptionAura(AbsorptionAuraConfig{
+			Aura: Aura{Label: "Test Shield"},
+			ShieldStrengthCalculator: func(_ *Unit) float64 {
+				return 100
+			},
+		})
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	var logLines []string
+	sim.Log = func(message string, vals ...interface{}) {
+		logLines = append(logLines, fmt.Sprintf(message, vals...))
+	}
+
+	shield.Activate(sim)
+	result := fa.Spell.CalcAndDealDamage(sim, target, 100, fa.Spell.OutcomeAlwaysHit)
+	if !WithinToleranceFloat64(result.Damage, 50, 0.001) || !WithinToleranceFloat64(result.Absorbed, 100, 0.001) {
+		t.Fatalf("Expected 50 damage with 100 absorbed, got %0.3f with %0.3f absorbed", result.Damage, result.Absorbed)
+	}
+	if shield.IsActive() {
+		t.Fatalf("Expected the depleted shield to expire")
+	}
+
+	metrics := fa.Spell.SpellMetrics[target.UnitIndex]
+	if !WithinToleranceFloat64(metrics.TotalDamage, 50, 0.001) || !WithinToleranceFloat64(metrics.TotalAbsorbedDamage, 100, 0.001) {
+		t.Fatalf("Expected 50 damage and 100 absorbed damage in the metrics, got %0.3f and %0.3f", metrics.TotalDamage, metrics.TotalAbsorbedDamage)
+	}
+
+	expectedLine := "[Caster (#1)] [Target 1] {SpellID: 42} Hit for 50.000 damage (100.000 absorbed) (SpellSchool: 128). (Threat: 50.000)"
+	if !slices.Contains(logLines, expectedLine) {
+		t.Fatalf("Expected the absorbed damage in the log, got\n%q", logLines)
+	}
+}
@@ -0,0 +1,143 @@
+package core
+
+import "time"
+
+// StanceMask identifies stances, forms and presences. The meaning of each bit
+// is up to the class registering them.
+type StanceMask uint16
+
+const StanceNone StanceMask = 0
+
+func (mask StanceMask) Matches(other StanceMask) bool {
+	return (mask & other) != 0
+}
+
+type StanceConfig struct {
+	Stance StanceMask
+
+	// Identifies the stance for APL conditions, defaults to the ActionID of Aura
+	// or Spell.
+	ActionID ActionID
+
+	// If set, the unit is in the stance while this aura is active. Otherwise the
+	// class is responsible for calling SetStance.
+	Aura *Aura
+
+	// Optional spell entering the stance. Automatic stance switches cast it, so
+	// its costs and cooldowns apply, and its GCD delays the switching spell's.
+	Spell *Spell
+
+	// Optional free way of entering the stance, used when there is no Spell,
+	// e.g. dropping druid forms.
+	Enter func(sim *Simulation)
+}
+
+func (config *StanceConfig) canSwitch(sim *Simulation, unit *Unit) bool {
+	if config.Spell != nil {
+		return config.Spell.CanCast(sim, unit)
+	}
+	return config.Enter != nil
+}
+
+type stanceTracker struct {
+	stance        StanceMask
+	defaultStance StanceMask
+	stances       []*StanceConfig
+}
+
+// Registers a stance so spells can require it and switch into it, see SpellConfig.RequiredStance.
+func (unit *Unit) RegisterStance(config StanceConfig) *StanceConfig {
+	if config.Stance == StanceNone {
+		panic("Stance mask required")
+	}
+	if config.ActionID.IsEmptyAction() && config.Aura != nil {
+		config.ActionID = config.Aura.ActionID
+	}
+	if config.ActionID.IsEmptyAction() && config.Spell != nil {
+		config.ActionID = config.Spell.ActionID
+	}
+
+	stance := &config
+	if stance.Aura != nil {
+		stance.Aura.ApplyOnGain(func(_ *Aura, _ *Simulation) {
+			unit.stance = stance.Stance
+		}).ApplyOnExpire(func(_ *Aura, _ *Simulation) {
+			if unit.stance == stance.Stance {
+				unit.stance = unit.defaultStance
+			}
+		})
+	}
+
+	unit.stances = append(unit.stances, stance)
+	return stance
+}
+
+// Sets the stance the unit is in at the start of each iteration, and after leaving an aura based stance.
+func (unit *Unit) SetDefaultStance(stance StanceMask) {
+	unit.defaultStance = stance
+}
+
+func (unit *Unit) SetStance(stance StanceMask) {
+	unit.stance = stance
+}
+
+func (unit *Unit) Stance() StanceMask {
+	return unit.stance
+}
+
+func (unit *Unit) InStance(mask StanceMask) bool {
+	return unit.stance.Matches(mask)
+}
+
+func (unit *Unit) getStanceByActionID(actionID ActionID) *StanceConfig {
+	for _, stance := range unit.stances {
+		if stance.ActionID.SameAction(actionID) {
+			return stance
+		}
+	}
+	return nil
+}
+
+// Returns the first registered stance matching mask which the unit can switch into right now.
+func (unit *Unit) getSwitchableStance(sim *Simulation, mask StanceMask) *StanceConfig {
+	for _, stance := range unit.stances {
+		if stance.Stance.Matches(mask) && stance.canSwitch(sim, unit) {
+			return stance
+		}
+	}
+	return nil
+}
+
+// Called by the cast functions once a cast is committed, i.e. all its checks
+// passed, but before its cost is spent and its effects are applied. Returns
+// the GCD time spent on the switch, which the cast adds to its own.
+func (spell *Spell) enterRequiredStance(sim *Simulation) time.Duration {
+	if spell.AutoSwitchStance && spell.RequiredStance != StanceNone && !spell.Unit.InStance(spell.RequiredStance) {
+		return spell.Unit.switchStance(sim, spell.RequiredStance)
+	}
+	return 0
+}
+
+func (unit *Unit) switchStance(sim *Simulation, mask StanceMask) time.Duration {
+	stance := unit.getSwitchableStance(sim, mask)
+	if stance == nil {
+		return 0
+	}
+
+	if stance.Spell == nil {
+		stance.Enter(sim)
+		return 0
+	}
+	if !stance.Spell.Cast(sim, unit) {
+		return 0
+	}
+	return stance.Spell.CurCast.EffectiveTime()
+}
+
+// Whether the spell's stance requirement is met, or can be met by switching automatically.
+func (spell *Spell) meetsStanceRequirement(sim *Simulation) bool {
+	if spell.RequiredStance == StanceNone || spell.Unit.InStance(spell.RequiredStance) {
+		return true
+	}
+	return spell.AutoSwitchStance && spell.Unit.getSwitchableStance(sim, spell.RequiredStance) != nil
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoSwitchStanceOnlyOnCommittedCast(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.CurrentTime = 0
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	const stanceA, stanceB StanceMask = 1, 2
+	var events []string
+	fa.RegisterStance(StanceConfig{
+		Stance:   stanceA,
+		ActionID: ActionID{SpellID: 1},
+		Enter: func(_ *Simulation) {
+			events = append(events, "enter")
+			fa.SetStance(stanceA)
+		},
+	})
+	fa.SetStance(stanceB)
+
+	spell := fa.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: 43},
+		RequiredStance:   stanceA,
+		AutoSwitchStance: true,
+		Cast: CastConfig{
+			DefaultCast: Cast{GCD: GCDDefault},
+			CD: Cooldown{
+				Timer:    fa.NewTimer(),
+				Duration: time.Minute,
+			},
+		},
+		ExtraCastCondition: func(_ *Simulation, _ *Unit) bool {
+			events = append(events, "condition")
+			return true
+		},
+		ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {
+			if !fa.InStance(stanceA) {
+				t.Fatalf("Expected the stance to be switched before the effects are applied")
+			}
+			events = append(events, "effects")
+		},
+	})
+
+	spell.CD.Set(time.Minute)
+	if spell.Cast(sim, target) {
+		t.Fatalf("Expected the cast to fail while on cooldown")
+	}
+	if !fa.InStance(stanceB) {
+		t.Fatalf("Expected a failed cast not to switch stance")
+	}
+
+	spell.CD.Reset()
+	events = nil
+	if !spell.Cast(sim, target) {
+		t.Fatalf("Expected the cast to succeed")
+	}
+	if len(events) != 3 || events[0] != "condition" || events[1] != "enter" || events[2] != "effects" {
+		t.Fatalf("Expected the stance switch between the cast checks and the effects, got %v", events)
+	}
+}
+
+func TestRequiredStanceWithoutAutoSwitch(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.CurrentTime = 0
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	const stanceA, stanceB StanceMask = 1, 2
+	fa.RegisterStance(StanceConfig{
+		Stance:   stanceA,
+		ActionID: ActionID{SpellID: 1},
+		Enter:    func(_ *Simulation) { fa.SetStance(stanceA) },
+	})
+	fa.SetStance(stanceB)
+
+	spell := fa.RegisterSpell(SpellConfig{
+		ActionID:       ActionID{SpellID: 43},
+		RequiredStance: stanceA,
+		Cast: CastConfig{
+			DefaultCast: Cast{GCD: GCDDefault},
+		},
+	})
+
+	if spell.CanCast(sim, target) || spell.Cast(sim, target) {
+		t.Fatalf("Expected the spell not to be castable outside of its required stance")
+	}
+	if !fa.InStance(stanceB) {
+		t.Fatalf("Expected the stance to be unchanged")
+	}
+
+	fa.SetStance(stanceA)
+	if !spell.Cast(sim, target) {
+		t.Fatalf("Expected the spell to be castable in its required stance")
+	}
+}
+
+func TestAutoSwitchStanceCastsStanceSpell(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.CurrentTime = 0
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	const stanceA, stanceB StanceMask = 1, 2
+	switches := 0
+	stanceSpell := fa.RegisterSpell(SpellConfig{
+		ActionID: ActionID{SpellID: 1},
+		Cast: CastConfig{
+			DefaultCast: Cast{GCD: time.Second},
+			CD: Cooldown{
+				Timer:    fa.NewTimer(),
+				Duration: time.Second * 10,
+			},
+			IgnoreHaste: true,
+		},
+		ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {
+			switches++
+			fa.SetStance(stanceA)
+		},
+	})
+	fa.RegisterStance(StanceConfig{
+		Stance: stanceA,
+		Spell:  stanceSpell,
+	})
+	fa.SetStance(stanceB)
+
+	spell := fa.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: 43},
+		RequiredStance:   stanceA,
+		AutoSwitchStance: true,
+		Cast: CastConfig{
+			DefaultCast: Cast{GCD: GCDDefault},
+			IgnoreHaste: true,
+		},
+	})
+
+	if !spell.Cast(sim, target) {
+		t.Fatalf("Expected the cast to succeed")
+	}
+	if !fa.InStance(stanceA) || switches != 1 {
+		t.Fatalf("Expected the switch to cast the stance spell")
+	}
+	if stanceSpell.CD.IsReady(sim) {
+		t.Fatalf("Expected the switch to trigger the stance spell's cooldown")
+	}
+	if readyAt := fa.NextGCDAt(); readyAt != time.Second+GCDDefault {
+		t.Fatalf("Expected the switch's GCD to delay the spell's, got the GCD ready at %s", readyAt)
+	}
+
+	// Without the stance spell available, the requirement can't be met.
+	fa.SetStance(stanceB)
+	fa.GCD.Reset()
+	if spell.CanCast(sim, target) {
+		t.Fatalf("Expected no automatic switch while the stance spell is on cooldown")
+	}
+}
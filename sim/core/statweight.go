@@ -3,7 +3,9 @@ package core
 import (
 	"fmt"
 	"math"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/wowsims/mop/sim/core/proto"
@@ -51,11 +53,11 @@ func (s *UnitStats) ToProto() *proto.UnitStats {
 // than just stat weights.
 func (s *UnitStats) ExportWeights() *proto.UnitStats {
 	if s.Stats[stats.HitRating] == 0 {
-		s.Stats[stats.HitRating] = s.PseudoStats[proto.PseudoStat_PseudoStatPhysicalHitPercent]/PhysicalHitRatingPerHitPercent + s.PseudoStats[proto.PseudoStat_PseudoStatSpellHitPercent]/SpellHitRatingPerHitPercent
+		s.Stats[stats.HitRating] = Ratings.PhysicalHitPercent(s.PseudoStats[proto.PseudoStat_PseudoStatPhysicalHitPercent]) + Ratings.SpellHitPercent(s.PseudoStats[proto.PseudoStat_PseudoStatSpellHitPercent])
 	}
 
 	if s.Stats[stats.CritRating] == 0 {
-		s.Stats[stats.CritRating] = (s.PseudoStats[proto.PseudoStat_PseudoStatPhysicalCritPercent] + s.PseudoStats[proto.PseudoStat_PseudoStatSpellCritPercent]) / CritRatingPerCritPercent
+		s.Stats[stats.CritRating] = Ratings.CritPercent(s.PseudoStats[proto.PseudoStat_PseudoStatPhysicalCritPercent] + s.PseudoStats[proto.PseudoStat_PseudoStatSpellCritPercent])
 	}
 
 	return s.ToProto()
@@ -186,11 +188,11 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 		statMod := defaultStatMod
 
 		if stat.EqualsPseudoStat(proto.PseudoStat_PseudoStatPhysicalHitPercent) {
-			statMod /= PhysicalHitRatingPerHitPercent
+			statMod /= Ratings.PhysicalHitPerPercent
 		} else if stat.EqualsPseudoStat(proto.PseudoStat_PseudoStatSpellHitPercent) {
-			statMod /= SpellHitRatingPerHitPercent
+			statMod /= Ratings.SpellHitPerPercent
 		} else if strings.Contains(statName, "Crit") {
-			statMod /= CritRatingPerCritPercent
+			statMod /= Ratings.CritPerPercent
 		} else if strings.Contains(statName, "Dps") {
 			statMod *= 0.5
 		} else {
@@ -325,80 +327,140 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatWeightsResult {
 	requestData := buildStatWeightRequests(request)
 
-	var iterationsTotal int32 = requestData.BaseRequest.SimOptions.Iterations
-	var iterationsDone int32 = 0
-	var simsTotal int32 = 1
-	var simsCompleted int32 = 0
-
+	requests := []*proto.RaidSimRequest{requestData.BaseRequest}
 	for _, reqData := range requestData.StatSimRequests {
-		iterationsTotal += reqData.RequestLow.SimOptions.Iterations
-		iterationsTotal += reqData.RequestHigh.SimOptions.Iterations
-		simsTotal += 2
+		requests = append(requests, reqData.RequestLow, reqData.RequestHigh)
 	}
 
-	waitForResult := func(srcProgressChannel chan *proto.ProgressMetrics) *proto.RaidSimResult {
-		var lastCompleted int32 = 0
-		for metrics := range srcProgressChannel {
-			iterationsDone += metrics.CompletedIterations - lastCompleted
-			lastCompleted = metrics.CompletedIterations
-
-			if progress != nil {
-				progress <- &proto.ProgressMetrics{
-					TotalIterations:     iterationsTotal,
-					CompletedIterations: iterationsDone,
-					CompletedSims:       simsCompleted,
-					TotalSims:           simsTotal,
-				}
-			}
+	results, err := runStatWeightSims(requests, progress, signals)
+	if err != nil {
+		return &proto.StatWeightsResult{Error: err}
+	}
 
-			if metrics.FinalRaidResult != nil {
-				simsCompleted++
-				return metrics.FinalRaidResult
-			}
-		}
-		return nil
+	statResults := []*proto.StatWeightsStatResultData{}
+	for i, reqData := range requestData.StatSimRequests {
+		statResults = append(statResults, &proto.StatWeightsStatResultData{
+			StatData:   reqData.StatData,
+			ResultLow:  results[1+2*i],
+			ResultHigh: results[2+2*i],
+		})
 	}
 
-	simFunc := runSimConcurrent
+	return computeStatWeights(&proto.StatWeightsCalcRequest{
+		BaseResult:      results[0],
+		EpReferenceStat: requestData.EpReferenceStat,
+		StatSimResults:  statResults,
+	})
+}
+
+// Runs the baseline and stat sims as a single batch. The iterations are split
+// the same way for every sim, and each worker runs its split of every sim in
+// turn, baseline first. The sims of a split then share a worker and its cached
+// environment, and workers never wait on each other between sims.
+//
+// The splits of each sim are combined in order, so the per-iteration values of
+// all results still line up with the baseline's.
+func runStatWeightSims(requests []*proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) ([]*proto.RaidSimResult, *proto.ErrorOutcome) {
+	options := requests[0].SimOptions
+	threadCount := TernaryInt32(options.IsTest, 3, int32(runtime.NumCPU()))
+	if options.NumWorkerThreads > 0 {
+		threadCount = options.NumWorkerThreads
+	}
 	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
 	if IsRunningInWasm() {
-		simFunc = RunSim
+		threadCount = 1
 	}
 
-	baseProgress := make(chan *proto.ProgressMetrics, 100)
-	go simFunc(requestData.BaseRequest, baseProgress, signals)
-	baselineResult := waitForResult(baseProgress)
-	if baselineResult.Error != nil {
-		return &proto.StatWeightsResult{Error: baselineResult.Error}
+	splits := make([][]*proto.RaidSimRequest, len(requests))
+	var iterationsTotal int32
+	for i, rsr := range requests {
+		splitRes := SplitSimRequestForConcurrency(rsr, threadCount)
+		if splitRes.ErrorResult != "" {
+			return nil, &proto.ErrorOutcome{Message: splitRes.ErrorResult}
+		}
+		splits[i] = splitRes.Requests
+		iterationsTotal += rsr.SimOptions.Iterations
 	}
+	splitCount := len(splits[0])
 
-	statResults := []*proto.StatWeightsStatResultData{}
+	// All stat sims only differ in bonus stats, so they can shift the baseline's
+	// environments instead of constructing new ones.
+	envCache := NewEnvironmentCache()
+	envCache.ShiftBonusStats = true
 
-	for _, reqData := range requestData.StatSimRequests {
-		lowProgress := make(chan *proto.ProgressMetrics, 100)
-		go simFunc(reqData.RequestLow, lowProgress, signals)
-		lowRes := waitForResult(lowProgress)
-		if lowRes.Error != nil {
-			return &proto.StatWeightsResult{Error: lowRes.Error}
+	splitResults := make([][]*proto.RaidSimResult, len(requests))
+	for i := range splitResults {
+		splitResults[i] = make([]*proto.RaidSimResult, splitCount)
+	}
+
+	var mu sync.Mutex
+	var iterationsDone int32
+	var firstError *proto.ErrorOutcome
+	simsCompleted := make([]int32, len(requests))
+
+	runSplit := func(splitIdx int) {
+		for i := range requests {
+			if signals.Abort.IsTriggered() {
+				return
+			}
+
+			result := envCache.RunSim(splits[i][splitIdx], nil, false, signals)
+
+			mu.Lock()
+			splitResults[i][splitIdx] = result
+			if result.Error != nil {
+				if firstError == nil {
+					firstError = result.Error
+				}
+				mu.Unlock()
+				signals.Abort.Trigger()
+				return
+			}
+
+			iterationsDone += result.IterationsDone
+			simsCompleted[i]++
+			if progress != nil {
+				completed := int32(0)
+				for _, done := range simsCompleted {
+					if done == int32(splitCount) {
+						completed++
+					}
+				}
+				progress <- &proto.ProgressMetrics{
+					TotalIterations:     iterationsTotal,
+					CompletedIterations: iterationsDone,
+					CompletedSims:       completed,
+					TotalSims:           int32(len(requests)),
+				}
+			}
+			mu.Unlock()
 		}
+	}
 
-		highProgress := make(chan *proto.ProgressMetrics, 100)
-		go simFunc(reqData.RequestHigh, highProgress, signals)
-		highRes := waitForResult(highProgress)
-		if highRes.Error != nil {
-			return &proto.StatWeightsResult{Error: highRes.Error}
+	if splitCount == 1 {
+		runSplit(0)
+	} else {
+		var wg sync.WaitGroup
+		for splitIdx := range splitCount {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				runSplit(splitIdx)
+			}()
 		}
+		wg.Wait()
+	}
 
-		statResults = append(statResults, &proto.StatWeightsStatResultData{
-			StatData:   reqData.StatData,
-			ResultLow:  lowRes,
-			ResultHigh: highRes,
-		})
+	if firstError != nil {
+		return nil, firstError
+	}
+	if signals.Abort.IsTriggered() {
+		return nil, &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}
 	}
 
-	return computeStatWeights(&proto.StatWeightsCalcRequest{
-		BaseResult:      baselineResult,
-		EpReferenceStat: requestData.EpReferenceStat,
-		StatSimResults:  statResults,
-	})
+	results := make([]*proto.RaidSimResult, len(requests))
+	for i := range requests {
+		results[i] = CombineConcurrentSimResults(splitResults[i], options.Debug)
+	}
+	return results, nil
 }
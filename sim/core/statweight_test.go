@@ -0,0 +1,83 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func tankedStatWeightRequest(armor float64) *proto.RaidSimRequest {
+	bonusStats := &proto.UnitStats{Stats: make([]float64, stats.ProtoStatsLen)}
+	bonusStats.Stats[stats.Armor] = armor
+
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{
+				Players: []*proto.Player{{
+					Name:       "Tank",
+					Class:      proto.Class_ClassShaman,
+					Buffs:      &proto.IndividualBuffs{},
+					Spec:       &proto.Player_ElementalShaman{},
+					Equipment:  &proto.EquipmentSpec{},
+					BonusStats: bonusStats,
+				}},
+				Buffs: &proto.PartyBuffs{},
+			}},
+			Tanks: []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{{
+				Level:         CharacterLevel + 3,
+				MobType:       proto.MobType_MobTypeDemon,
+				MinBaseDamage: 1000,
+				DamageSpread:  0.4,
+				SwingSpeed:    2,
+			}},
+			Duration: 60,
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations:       30,
+			RandomSeed:       101,
+			IsTest:           true,
+			SaveAllValues:    true,
+			UseLabeledRands:  true,
+			NumWorkerThreads: 3,
+		},
+	}
+}
+
+func TestStatWeightSimsMatchSeparateRuns(t *testing.T) {
+	requests := []*proto.RaidSimRequest{
+		tankedStatWeightRequest(0),
+		tankedStatWeightRequest(-3200),
+		tankedStatWeightRequest(3200),
+	}
+
+	results, err := runStatWeightSims(requests, nil, simsignals.CreateSignals())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Message)
+	}
+
+	for i, rsr := range requests {
+		expected := runSimConcurrent(rsr, nil, simsignals.CreateSignals())
+		expectedDtps := expected.RaidMetrics.Parties[0].Players[0].Dtps
+		dtps := results[i].RaidMetrics.Parties[0].Players[0].Dtps
+
+		if len(dtps.AllValues) != int(rsr.SimOptions.Iterations) {
+			t.Fatalf("Request %d: expected %d values, got %d", i, rsr.SimOptions.Iterations, len(dtps.AllValues))
+		}
+		if !slices.Equal(expectedDtps.AllValues, dtps.AllValues) {
+			t.Fatalf("Request %d: expected the same values as a separate run", i)
+		}
+	}
+
+	// More armor, less damage taken, in every iteration.
+	low := results[1].RaidMetrics.Parties[0].Players[0].Dtps
+	high := results[2].RaidMetrics.Parties[0].Players[0].Dtps
+	if low.Avg <= high.Avg || high.Avg <= 0 {
+		t.Fatalf("Expected less damage taken with more armor, got %0.1f and %0.1f", low.Avg, high.Avg)
+	}
+}
@@ -32,26 +32,64 @@ type Encounter struct {
 
 	// Value to multiply by, for damage spells which are subject to the aoe cap.
 	aoeCapMultiplier float64
+
+	// Raid wide damage modifier phases registered by encounter scripts.
+	damageModifiers []*encounterDamageModifier
+
+	// Windows in which a target is immune to certain schools, registered by encounter scripts.
+	schoolImmunities []*targetSchoolImmunity
+
+	// Set for health fights with several bosses, which all have to die.
+	council *council
+
+	// Set for fights with adds which spawn or despawn during the fight.
+	script *encounterScript
+
+	// Recurring windows of forced movement or downtime.
+	mechanics []*encounterMechanic
+
+	// Damage dealt to all players, set for fights with modeled raid damage.
+	incomingDamage *incomingDamageSchedule
+
+	// Damage taken by each target in the current iteration, indexed by target index.
+	targetDamageTaken []float64
+
+	// Target whose execute proportions the execute phases of duration based
+	// fights follow, and the one it starts out as each iteration.
+	executeFocus        *Target
+	initialExecuteFocus *Target
+
+	// Maximum number of player debuffs on each target, unlimited if 0.
+	DebuffSlots int32
+
+	// Whether targets keep threat tables and switch targets on aggro pulls.
+	ModelThreat bool
 }
 
 func NewEncounter(options *proto.Encounter) Encounter {
-	options.ExecuteProportion_25 = max(options.ExecuteProportion_25, options.ExecuteProportion_20)
-	options.ExecuteProportion_35 = max(options.ExecuteProportion_35, options.ExecuteProportion_25)
-	options.ExecuteProportion_45 = max(options.ExecuteProportion_45, options.ExecuteProportion_35)
+	// Later phases can't last longer than earlier ones. Kept out of the options,
+	// which are part of the hashed request.
+	executeProportion_20 := max(options.ExecuteProportion_20, 0)
+	executeProportion_25 := max(options.ExecuteProportion_25, executeProportion_20)
+	executeProportion_35 := max(options.ExecuteProportion_35, executeProportion_25)
+	executeProportion_45 := max(options.ExecuteProportion_45, executeProportion_35)
 	totalTargetCount := max(len(options.Targets), 1)
 
 	encounter := Encounter{
 		Duration:             DurationFromSeconds(options.Duration),
 		DurationVariation:    DurationFromSeconds(options.DurationVariation),
-		ExecuteProportion_20: max(options.ExecuteProportion_20, 0),
-		ExecuteProportion_25: max(options.ExecuteProportion_25, 0),
-		ExecuteProportion_35: max(options.ExecuteProportion_35, 0),
-		ExecuteProportion_45: max(options.ExecuteProportion_45, 0),
+		ExecuteProportion_20: executeProportion_20,
+		ExecuteProportion_25: executeProportion_25,
+		ExecuteProportion_35: executeProportion_35,
+		ExecuteProportion_45: executeProportion_45,
 		ExecuteProportion_90: max(options.ExecuteProportion_90, 0),
 		AllTargets:           make([]*Target, 0, totalTargetCount),
 		ActiveTargets:        make([]*Target, 0, totalTargetCount),
 		AllTargetUnits:       make([]*Unit, 0, totalTargetCount),
 		ActiveTargetUnits:    make([]*Unit, 0, totalTargetCount),
+		DebuffSlots:          max(options.DebuffSlots, 0),
+		ModelThreat:          options.ModelThreat,
+		targetDamageTaken:    make([]float64, totalTargetCount),
 	}
 
 	for targetIndex, targetOptions := range options.Targets {
@@ -78,6 +116,12 @@ func NewEncounter(options *proto.Encounter) Encounter {
 	if len(encounter.ActiveTargets) == 0 {
 		panic("At least one target must be active at the start of the simulation!")
 	}
+	encounter.initialExecuteFocus = encounter.ActiveTargets[0]
+	encounter.executeFocus = encounter.initialExecuteFocus
+
+	if len(options.ScriptEvents) > 0 {
+		encounter.script = newEncounterScript(options.ScriptEvents, encounter.AllTargets)
+	}
 
 	// If UseHealth is set, we use the sum of targets health. After creating the targets to make sure stat modifications are done
 	if options.UseHealth {
@@ -87,6 +131,12 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		if encounter.EndFightAtHealth == 0 {
 			encounter.EndFightAtHealth = 1 // default to something so we don't instantly end without anything.
 		}
+
+		if options.Council != nil && len(options.Targets) > 0 {
+			// Only used for duration estimates, the fight ends once the last boss dies.
+			encounter.council = newCouncil(options.Council, options.Targets, encounter.AllTargets)
+			encounter.EndFightAtHealth = encounter.council.totalHealth()
+		}
 	}
 
 	if encounter.EndFightAtHealth > 0 {
@@ -100,6 +150,50 @@ func NewEncounter(options *proto.Encounter) Encounter {
 	return encounter
 }
 
+// Estimate of the remaining health of the target, from 0 to 1, based on the
+// damage it has taken this iteration. 1 for targets without health.
+func (encounter *Encounter) TargetHealthPercent(target *Unit) float64 {
+	health := target.stats[stats.Health]
+	if health <= 0 || int(target.Index) >= len(encounter.targetDamageTaken) {
+		return 1
+	}
+	return max(0, 1-encounter.targetDamageTaken[target.Index]/health)
+}
+
+// Share of the fight spent below the given execute threshold (20, 25, 35 or
+// 45), or above it for 90, with the overrides of the execute focus if it has any.
+func (encounter *Encounter) executeProportion(threshold int32) float64 {
+	if overrides := encounter.executeFocus.executeProportions; overrides != nil {
+		return overrides[threshold]
+	}
+
+	switch threshold {
+	case 20:
+		return encounter.ExecuteProportion_20
+	case 25:
+		return encounter.ExecuteProportion_25
+	case 35:
+		return encounter.ExecuteProportion_35
+	case 45:
+		return encounter.ExecuteProportion_45
+	default:
+		return encounter.ExecuteProportion_90
+	}
+}
+
+// Hands execute phases over to the next target when the execute focus
+// despawns, e.g. once the first boss of a council dies. The new focus's
+// proportions then apply to the rest of the fight.
+func (encounter *Encounter) onTargetDespawned(sim *Simulation, target *Target) {
+	if target != encounter.executeFocus || encounter.EndFightAtHealth > 0 || len(encounter.ActiveTargets) == 0 {
+		return
+	}
+
+	encounter.executeFocus = target.NextActiveTarget()
+	sim.executeStart = sim.CurrentTime
+	sim.restartExecutePhases()
+}
+
 func (encounter *Encounter) AOECapMultiplier() float64 {
 	return encounter.aoeCapMultiplier
 }
@@ -159,6 +253,9 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	// Execute proportions overriding the encounter's, by threshold. Nil if not overridden.
+	executeProportions map[int32]float64
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -184,6 +281,21 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 			enabled:               !options.DisabledAtStart,
 		},
 	}
+	if overrides := options.ExecuteProportions; overrides != nil {
+		// Clamped like the encounter's, so the phases are entered in order.
+		p20 := max(overrides.ExecuteProportion_20, 0)
+		p25 := max(overrides.ExecuteProportion_25, p20)
+		p35 := max(overrides.ExecuteProportion_35, p25)
+		p45 := max(overrides.ExecuteProportion_45, p35)
+		target.executeProportions = map[int32]float64{
+			20: p20,
+			25: p25,
+			35: p35,
+			45: p45,
+			90: max(overrides.ExecuteProportion_90, 0),
+		}
+	}
+
 	defaultRaidBossLevel := int32(CharacterLevel + 3)
 	target.GCD = target.NewTimer()
 	target.RotationTimer = target.NewTimer()
@@ -205,6 +317,20 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	target.PseudoStats.InFrontOfTarget = true
 	target.PseudoStats.DamageSpread = options.DamageSpread
 
+	if options.BleedImmune {
+		target.MechanicImmunities |= SpellFlagBleed
+	}
+	if options.PoisonImmune {
+		target.MechanicImmunities |= SpellFlagPoison
+	}
+	if options.DiseaseImmune {
+		target.MechanicImmunities |= SpellFlagDisease
+	}
+	for _, school := range options.SchoolImmunities {
+		target.SchoolImmunities |= SpellSchoolFromProto(school)
+	}
+	target.disabledAuras = protoToActionIDSet(options.DebuffImmunities)
+
 	preset := GetPresetTargetWithID(options.Id)
 	if preset != nil && preset.AI != nil {
 		target.AI = preset.AI()
@@ -213,6 +339,23 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	return target
 }
 
+// Returns whether the unit is immune to the mechanic of the spell, e.g. because
+// it is a bleed and the unit is bleed immune.
+func (unit *Unit) IsImmuneToMechanicOf(spell *Spell) bool {
+	return spell.Flags.Matches(unit.MechanicImmunities)
+}
+
+// Returns whether the unit is immune to every school of the spell. Multi-school
+// spells go through if the unit is vulnerable to any of their schools.
+func (unit *Unit) IsImmuneToSchoolOf(spell *Spell) bool {
+	return unit.SchoolImmunities != 0 && spell.SpellSchool != 0 && unit.SchoolImmunities.ContainsAll(spell.SpellSchool)
+}
+
+// Returns whether the unit is permanently immune to the spell, by its mechanic or school.
+func (unit *Unit) IsImmuneTo(spell *Spell) bool {
+	return unit.IsImmuneToMechanicOf(spell) || unit.IsImmuneToSchoolOf(spell)
+}
+
 func (target *Target) Reset(sim *Simulation) {
 	target.Unit.reset(sim, nil)
 	target.CurrentTarget = target.defaultTarget
@@ -244,6 +387,7 @@ func (target *Target) Enable(sim *Simulation) {
 	if !target.IsEnabled() {
 		target.enabled = true
 		sim.Encounter.addActiveTarget(target)
+		target.joinFight(sim)
 	}
 }
 
@@ -271,9 +415,7 @@ func (target *Target) Disable(sim *Simulation, expireAuras bool) {
 	target.enabled = false
 	sim.Encounter.removeInactiveTarget(target)
 
-	if expireAuras {
-		target.auraTracker.expireAll(sim)
-	}
+	target.leaveFight(sim, expireAuras)
 
 	if target.CurrentTarget != nil {
 		target.CurrentTarget.CurrentTarget = &target.NextActiveTarget().Unit
@@ -330,6 +472,30 @@ func (target *Target) GetMetricsProto() *proto.UnitMetrics {
 type DynamicDamageDoneByCaster func(sim *Simulation, spell *Spell, attackTable *AttackTable) float64
 type DynamicThreatDoneByCaster DynamicDamageDoneByCaster
 
+// Glancing blow and crit suppression values against an enemy, which depend on
+// how many levels it is above the player.
+type LevelDeltaValues struct {
+	GlanceChance         float64
+	GlanceMultiplier     float64
+	MeleeCritSuppression float64
+	SpellCritSuppression float64
+}
+
+// Indexed by the level delta, from +0 to +3 (boss level).
+var levelDeltaTable = [...]LevelDeltaValues{
+	{GlanceChance: 0.06, GlanceMultiplier: 0.95, MeleeCritSuppression: 0, SpellCritSuppression: 0},
+	{GlanceChance: 0.12, GlanceMultiplier: 0.95, MeleeCritSuppression: 0.01, SpellCritSuppression: 0.01},
+	{GlanceChance: 0.18, GlanceMultiplier: 0.85, MeleeCritSuppression: 0.02, SpellCritSuppression: 0.02},
+	{GlanceChance: 0.24, GlanceMultiplier: 0.75, MeleeCritSuppression: 0.03, SpellCritSuppression: 0.03},
+}
+
+// Returns the values for an enemy the given number of levels above the player.
+// Enemies below the player's level use the +0 values, and enemies more than 3
+// levels above use the boss values.
+func LevelDeltaValuesFor(levelDelta int32) LevelDeltaValues {
+	return levelDeltaTable[min(max(levelDelta, 0), int32(len(levelDeltaTable)-1))]
+}
+
 // Holds cached values for outcome/damage calculations, for a specific attacker+defender pair.
 // These are updated dynamically when attacker or defender stats change.
 type AttackTable struct {
@@ -382,11 +548,12 @@ func NewAttackTable(attacker *Unit, defender *Unit) *AttackTable {
 		table.BaseBlockChance = UnitLevelFloat64(defender.Level, 0.03, 0.045, 0.06, 0.075)
 		table.BaseDodgeChance = UnitLevelFloat64(defender.Level, 0.03, 0.045, 0.06, 0.075)
 		table.BaseParryChance = UnitLevelFloat64(defender.Level, 0.03, 0.045, 0.06, 0.075)
-		table.BaseGlanceChance = UnitLevelFloat64(defender.Level, 0.06, 0.12, 0.18, 0.24)
 
-		table.GlanceMultiplier = UnitLevelFloat64(defender.Level, 0.95, 0.95, 0.85, 0.75)
-		table.MeleeCritSuppression = UnitLevelFloat64(defender.Level, 0, 0.01, 0.02, 0.03)
-		table.SpellCritSuppression = UnitLevelFloat64(defender.Level, 0, 0.01, 0.02, 0.03)
+		levelDeltaValues := LevelDeltaValuesFor(defender.Level - CharacterLevel)
+		table.BaseGlanceChance = levelDeltaValues.GlanceChance
+		table.GlanceMultiplier = levelDeltaValues.GlanceMultiplier
+		table.MeleeCritSuppression = levelDeltaValues.MeleeCritSuppression
+		table.SpellCritSuppression = levelDeltaValues.SpellCritSuppression
 	} else {
 		table.BaseSpellMissChance = UnitLevelFloat64(attacker.Level, 0.06, 0.03, 0, -0.03)
 		table.BaseMissChance = UnitLevelFloat64(attacker.Level, 0.03, 0.015, 0, -0.015)
@@ -398,6 +565,15 @@ func NewAttackTable(attacker *Unit, defender *Unit) *AttackTable {
 	return table
 }
 
+// Logs the resolved base chances and level based values of the table.
+func (table *AttackTable) logValues(sim *Simulation) {
+	table.Attacker.LogAt(sim, LogCategoryOther, LogLevelDebug,
+		"[DEBUG] Attack table vs %s (level %+d): Miss %0.2f%%, Spell Miss %0.2f%%, Dodge %0.2f%%, Parry %0.2f%%, Block %0.2f%%, Glance %0.2f%% (x%0.2f), Melee Crit Suppression %0.2f%%, Spell Crit Suppression %0.2f%%",
+		table.Defender.Label, table.Defender.Level-CharacterLevel,
+		table.BaseMissChance*100, table.BaseSpellMissChance*100, table.BaseDodgeChance*100, table.BaseParryChance*100, table.BaseBlockChance*100,
+		table.BaseGlanceChance*100, table.GlanceMultiplier, table.MeleeCritSuppression*100, table.SpellCritSuppression*100)
+}
+
 func EnableDamageDoneByCaster(index int, maxIndex int, attackTable *AttackTable, handler DynamicDamageDoneByCaster) {
 	if attackTable.DamageDoneByCasterExtraMultiplier == nil {
 		attackTable.DamageDoneByCasterExtraMultiplier = make([]DynamicDamageDoneByCaster, maxIndex)
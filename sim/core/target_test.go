@@ -0,0 +1,35 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestLevelDeltaValues(t *testing.T) {
+	testCases := []struct {
+		targetLevel int32
+		expected    LevelDeltaValues
+	}{
+		{CharacterLevel - 1, LevelDeltaValues{GlanceChance: 0.06, GlanceMultiplier: 0.95}},
+		{CharacterLevel, LevelDeltaValues{GlanceChance: 0.06, GlanceMultiplier: 0.95}},
+		{CharacterLevel + 1, LevelDeltaValues{GlanceChance: 0.12, GlanceMultiplier: 0.95, MeleeCritSuppression: 0.01, SpellCritSuppression: 0.01}},
+		{CharacterLevel + 2, LevelDeltaValues{GlanceChance: 0.18, GlanceMultiplier: 0.85, MeleeCritSuppression: 0.02, SpellCritSuppression: 0.02}},
+		{CharacterLevel + 3, LevelDeltaValues{GlanceChance: 0.24, GlanceMultiplier: 0.75, MeleeCritSuppression: 0.03, SpellCritSuppression: 0.03}},
+		{CharacterLevel + 5, LevelDeltaValues{GlanceChance: 0.24, GlanceMultiplier: 0.75, MeleeCritSuppression: 0.03, SpellCritSuppression: 0.03}},
+	}
+
+	for _, testCase := range testCases {
+		attacker := &Unit{Type: PlayerUnit, Level: CharacterLevel}
+		defender := &Unit{Type: EnemyUnit, Level: testCase.targetLevel}
+		table := NewAttackTable(attacker, defender)
+
+		actual := LevelDeltaValues{
+			GlanceChance:         table.BaseGlanceChance,
+			GlanceMultiplier:     table.GlanceMultiplier,
+			MeleeCritSuppression: table.MeleeCritSuppression,
+			SpellCritSuppression: table.SpellCritSuppression,
+		}
+		if actual != testCase.expected {
+			t.Errorf("Level %d: expected %+v, got %+v", testCase.targetLevel, testCase.expected, actual)
+		}
+	}
+}
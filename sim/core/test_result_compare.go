@@ -31,7 +31,7 @@ func compareValue(t *testing.T, loc string, vst reflect.Value, vmt reflect.Value
 			t.Logf("%s: Expected %d but is %d for multi threaded result!", loc, vst.Int(), vmt.Int())
 			t.Fail()
 		}
-	case reflect.Float64:
+	case reflect.Float32, reflect.Float64:
 		if strings.Contains(loc, "CastTimeMs") {
 			absoluteFloatTolerance = 2.2 // Castime is rounded in results and may be off 1ms per thread. In test=true sims concurrency is set to 3, 2ms diff seems to never be broken then)
 		}
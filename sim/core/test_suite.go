@@ -178,6 +178,12 @@ func RunTestSuite(t *testing.T, suiteName string, generators []TestGenerator) {
 		}
 	}()
 
+	if soakConfig, ok := SoakConfigFromEnv(); ok {
+		// Soak tests are slow, so only those are run when enabled.
+		runSuiteSoakTest(t, generators, soakConfig)
+		return
+	}
+
 	expectedResults, err := testSuite.readExpectedResults()
 	if err != nil {
 		t.Logf("\n\n----- FAILURE LOADING RESULTS FILE TESTS WILL FAIL-----\n%s\n-----\n\n", err)
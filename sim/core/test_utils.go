@@ -29,6 +29,13 @@ var AverageDefaultSimTestOptions = &proto.SimOptions{
 	Debug:      false,
 	RandomSeed: 101,
 }
+var DeterministicSimTestOptions = &proto.SimOptions{
+	Iterations:    1,
+	IsTest:        true,
+	Debug:         false,
+	RandomSeed:    101,
+	Deterministic: true,
+}
 
 const ShortDuration = 60
 const LongDuration = 300
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"time"
+)
+
+// Threat a unit needs, relative to the threat of the current aggro holder, to
+// pull aggro from them.
+const (
+	MeleeAggroPullThreshold  = 1.1
+	RangedAggroPullThreshold = 1.3
+)
+
+// Duration for which a taunted target keeps attacking the taunter, regardless of threat.
+const TauntFixateDuration = time.Second * 3
+
+// Per-target threat state, only used when the encounter models threat.
+type threatState struct {
+	// Threat of each unit on this target, indexed by UnitIndex.
+	threatTable []float64
+
+	// Aggro can't be pulled from a taunter until this time.
+	tauntedUntil time.Duration
+
+	// Unit receiving the threat generated by this unit, e.g. from Tricks of the
+	// Trade. Nil if threat isn't redirected.
+	threatRedirect *Unit
+}
+
+func (env *Environment) setupThreatTables() {
+	if !env.Encounter.ModelThreat {
+		return
+	}
+	for _, target := range env.Encounter.AllTargetUnits {
+		target.threatTable = make([]float64, len(env.AllUnits))
+	}
+}
+
+func (unit *Unit) resetThreat() {
+	clear(unit.threatTable)
+	unit.tauntedUntil = 0
+	unit.threatRedirect = nil
+}
+
+// Redirects the threat generated by this unit to the recipient, e.g. for
+// Tricks of the Trade. Pass nil to stop redirecting.
+func (unit *Unit) SetThreatRedirect(recipient *Unit) {
+	unit.threatRedirect = recipient
+}
+
+// Returns the threat of the unit on the target, or 0 if threat isn't modeled.
+func (target *Unit) ThreatOf(unit *Unit) float64 {
+	if target.threatTable == nil {
+		return 0
+	}
+	return target.threatTable[unit.UnitIndex]
+}
+
+// Adds the threat of the result to the threat tables of the enemies it affects.
+// Healing threat is split between all active enemies.
+func (spell *Spell) addResultThreat(sim *Simulation, result *SpellResult) {
+	if !sim.Encounter.ModelThreat || result.Threat == 0 || spell.Unit.Type == EnemyUnit {
+		return
+	}
+
+	source := spell.Unit
+	if source.threatRedirect != nil {
+		source = source.threatRedirect
+	}
+
+	if result.Type == SpellResultTypeHealing {
+		targets := sim.Encounter.ActiveTargetUnits
+		for _, target := range targets {
+			target.addThreat(sim, source, result.Threat/float64(len(targets)))
+		}
+	} else if result.Target.Type == EnemyUnit {
+		result.Target.addThreat(sim, source, result.Threat)
+	}
+}
+
+func (target *Unit) addThreat(sim *Simulation, source *Unit, amount float64) {
+	if target.threatTable == nil {
+		return
+	}
+	target.threatTable[source.UnitIndex] += amount
+
+	// Untanked targets don't switch targets, so DPS sims without a tank are unaffected.
+	holder := target.CurrentTarget
+	if holder == nil || holder == source || sim.CurrentTime < target.tauntedUntil {
+		return
+	}
+
+	threshold := Ternary(source.DistanceFromTarget > MaxMeleeRange, RangedAggroPullThreshold, MeleeAggroPullThreshold)
+	if target.threatTable[source.UnitIndex] > target.threatTable[holder.UnitIndex]*threshold {
+		source.Metrics.AggroPulls++
+		if sim.Log != nil {
+			target.Log(sim, "%s pulled aggro from %s", source.Label, holder.Label)
+		}
+		target.CurrentTarget = source
+	}
+}
+
+// Gives the taunter aggro, with threat equal to the highest threat on the
+// target's table.
+func (target *Unit) Taunt(sim *Simulation, taunter *Unit) {
+	if target.threatTable != nil {
+		for _, threat := range target.threatTable {
+			target.threatTable[taunter.UnitIndex] = max(target.threatTable[taunter.UnitIndex], threat)
+		}
+		target.tauntedUntil = sim.CurrentTime + TauntFixateDuration
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "Taunted by %s", taunter.Label)
+	}
+	target.CurrentTarget = taunter
+}
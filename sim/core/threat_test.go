@@ -0,0 +1,40 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestThreatAggroPullAndTaunt(t *testing.T) {
+	sim := &Simulation{}
+
+	tank := &Unit{Type: PlayerUnit, UnitIndex: 0, Metrics: NewUnitMetrics()}
+	meleeDps := &Unit{Type: PlayerUnit, UnitIndex: 1, Metrics: NewUnitMetrics()}
+	rangedDps := &Unit{Type: PlayerUnit, UnitIndex: 2, Metrics: NewUnitMetrics(), DistanceFromTarget: 30}
+	target := &Unit{Type: EnemyUnit, UnitIndex: 3, CurrentTarget: tank}
+	target.threatTable = make([]float64, 4)
+
+	target.addThreat(sim, tank, 1000)
+	target.addThreat(sim, rangedDps, 1200)
+	if target.CurrentTarget != tank {
+		t.Fatalf("Expected ranged units to need 130%% of the tank's threat")
+	}
+
+	target.addThreat(sim, meleeDps, 1050)
+	if target.CurrentTarget != tank {
+		t.Fatalf("Expected melee units to need 110%% of the tank's threat")
+	}
+	target.addThreat(sim, meleeDps, 100)
+	if target.CurrentTarget != meleeDps || meleeDps.Metrics.AggroPulls != 1 {
+		t.Fatalf("Expected the melee unit to pull aggro")
+	}
+
+	target.Taunt(sim, tank)
+	if target.CurrentTarget != tank || target.ThreatOf(tank) != 1200 {
+		t.Fatalf("Expected the taunt to match the highest threat, got %f", target.ThreatOf(tank))
+	}
+
+	target.addThreat(sim, rangedDps, 1000)
+	if target.CurrentTarget != tank {
+		t.Fatalf("Expected aggro to stay on the taunter during the fixate")
+	}
+}
@@ -58,6 +58,12 @@ type Unit struct {
 
 	MobType proto.MobType
 
+	// Spell mechanics, out of SpellFlagMechanics, which this unit is immune to.
+	MechanicImmunities SpellFlag
+
+	// Schools which this unit is immune to.
+	SchoolImmunities SpellSchool
+
 	// Amount of time it takes for the human agent to react to in-game events.
 	// Used by certain APL values and actions.
 	ReactionTime time.Duration
@@ -65,6 +71,11 @@ type Unit struct {
 	// Amount of time following a post-GCD channel tick, to when the next action can be performed.
 	ChannelClipDelay time.Duration
 
+	// Server latency after each cast or GCD, before the next action can be
+	// performed, varying uniformly by up to LatencyJitter in either direction.
+	Latency       time.Duration
+	LatencyJitter time.Duration
+
 	// How far this unit is from its target(s). Measured in yards, this is used
 	// for calculating spell travel time for certain spells.
 	StartDistanceFromTarget float64
@@ -75,6 +86,18 @@ type Unit struct {
 	moveSpell               *Spell
 	movementAction          *MovementAction
 
+	// Spells and auras disabled by the user for what-if sims, e.g. without a set
+	// bonus or talent proc. Applied when the spells and auras are registered.
+	// For targets, the auras are the debuffs they are immune to.
+	disabledSpells actionIDSet
+	disabledAuras  actionIDSet
+
+	// Auras put into a given state at the start of the encounter.
+	precombatAuras []precombatAura
+
+	// Execute phase trackers shared by the unit's abilities, by percent.
+	executePhases map[int32]*ExecutePhaseTracker
+
 	// Environment in which this Unit exists. This will be nil until after the
 	// construction phase.
 	Env *Environment
@@ -102,6 +125,9 @@ type Unit struct {
 	// Provides aura tracking behavior.
 	auraTracker
 
+	// Tracks the current stance, form or presence.
+	stanceTracker
+
 	// Current stats, including temporary effects but not dependencies.
 	statsWithoutDeps stats.Stats
 
@@ -146,6 +172,11 @@ type Unit struct {
 
 	cdTimers []*Timer
 
+	chargedCooldowns []*ChargedCooldown
+
+	// All spell mods which can affect the unit's spells, for debug logs.
+	spellMods []*SpellMod
+
 	AttackTables                 []*AttackTable
 	DynamicDamageTakenModifiers  []DynamicDamageTakenModifier
 	DynamicHealingTakenModifiers []DynamicHealingTakenModifier
@@ -182,9 +213,14 @@ type Unit struct {
 	// The currently-channeled DOT spell, otherwise nil.
 	ChanneledDot *Dot
 
+	threatState
+
 	// Data about the most recently queued spell, otherwise nil.
 	QueuedSpell *QueuedSpell
 
+	// Used for reacting to units joining or leaving mid-fight
+	unitLifecycle
+
 	// Used for reacting to mastery stat changes
 	OnMasteryStatChanged []OnMasteryStatChanged
 
@@ -243,8 +279,17 @@ func (unit *Unit) LogLabel() string {
 	return "[" + unit.Label + "]"
 }
 
+// Logs an uncategorized message, see LogAt.
 func (unit *Unit) Log(sim *Simulation, message string, vals ...interface{}) {
-	sim.Log(unit.LogLabel()+" "+message, vals...)
+	unit.LogAt(sim, LogCategoryOther, LogLevelInfo, message, vals...)
+}
+
+// Logs a message with the given category and level, unless they're filtered out
+// by the sim options.
+func (unit *Unit) LogAt(sim *Simulation, category LogCategory, level LogLevel, message string, vals ...interface{}) {
+	if sim.LogEnabled(category, level) {
+		sim.Log(unit.LogLabel()+" "+message, vals...)
+	}
 }
 
 func (unit *Unit) GetInitialStat(stat stats.Stat) float64 {
@@ -347,8 +392,8 @@ func (unit *Unit) AddStatsDynamic(sim *Simulation, bonus stats.Stats) {
 		bonus = unit.ApplyStatDependencies(bonus)
 	}
 
-	if sim.Log != nil {
-		unit.Log(sim, "Dynamic stat change: %s", bonus.FlatString())
+	if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
+		unit.LogAt(sim, LogCategoryOther, LogLevelTrace, "Dynamic stat change: %s", bonus.FlatString())
 	}
 
 	unit.stats.AddInplace(&bonus)
@@ -399,8 +444,8 @@ func (unit *Unit) EnableDynamicStatDep(sim *Simulation, dep *stats.StatDependenc
 		unit.stats = unit.ApplyStatDependencies(unit.statsWithoutDeps)
 		unit.processDynamicBonus(sim, unit.stats.Subtract(oldStats))
 
-		if sim.Log != nil {
-			unit.Log(sim, "Dynamic dep enabled (%s): %s", dep.String(), unit.stats.Subtract(oldStats).FlatString())
+		if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
+			unit.LogAt(sim, LogCategoryOther, LogLevelTrace, "Dynamic dep enabled (%s): %s", dep.String(), unit.stats.Subtract(oldStats).FlatString())
 		}
 	}
 }
@@ -410,8 +455,8 @@ func (unit *Unit) DisableDynamicStatDep(sim *Simulation, dep *stats.StatDependen
 		unit.stats = unit.ApplyStatDependencies(unit.statsWithoutDeps)
 		unit.processDynamicBonus(sim, unit.stats.Subtract(oldStats))
 
-		if sim.Log != nil {
-			unit.Log(sim, "Dynamic dep disabled (%s): %s", dep.String(), unit.stats.Subtract(oldStats).FlatString())
+		if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
+			unit.LogAt(sim, LogCategoryOther, LogLevelTrace, "Dynamic dep disabled (%s): %s", dep.String(), unit.stats.Subtract(oldStats).FlatString())
 		}
 	}
 }
@@ -425,8 +470,8 @@ func (unit *Unit) UpdateDynamicStatDep(sim *Simulation, dep *stats.StatDependenc
 		statsChange := unit.stats.Subtract(oldStats)
 		unit.processDynamicBonus(sim, statsChange)
 
-		if sim.Log != nil {
-			unit.Log(sim, "Dynamic dep updated (%s): %s", dep.String(), statsChange.FlatString())
+		if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
+			unit.LogAt(sim, LogCategoryOther, LogLevelTrace, "Dynamic dep updated (%s): %s", dep.String(), statsChange.FlatString())
 		}
 	}
 }
@@ -484,7 +529,7 @@ func (unit *Unit) SpellGCD() time.Duration {
 }
 
 func (unit *Unit) TotalSpellHasteMultiplier() float64 {
-	return unit.PseudoStats.CastSpeedMultiplier * (1 + unit.stats[stats.HasteRating]/(HasteRatingPerHastePercent*100))
+	return unit.PseudoStats.CastSpeedMultiplier * Ratings.HasteMultiplier(unit.stats[stats.HasteRating])
 }
 
 func (unit *Unit) updateCastSpeed() {
@@ -526,17 +571,17 @@ func (unit *Unit) ApplyRealRangedHaste(dur time.Duration) time.Duration {
 	return time.Duration(float64(dur) / unit.TotalRealRangedHasteMultiplier())
 }
 func (unit *Unit) TotalMeleeHasteMultiplier() float64 {
-	return unit.PseudoStats.AttackSpeedMultiplier * unit.PseudoStats.MeleeSpeedMultiplier * (1 + (unit.stats[stats.HasteRating] / (HasteRatingPerHastePercent * 100)))
+	return unit.PseudoStats.AttackSpeedMultiplier * unit.PseudoStats.MeleeSpeedMultiplier * Ratings.HasteMultiplier(unit.stats[stats.HasteRating])
 }
 
 // Returns the melee haste multiplier only including equip haste and real haste modifiers like lust
 // Same value for ranged and melee
 func (unit *Unit) TotalRealHasteMultiplier() float64 {
-	return unit.PseudoStats.AttackSpeedMultiplier * (1 + (unit.stats[stats.HasteRating] / (HasteRatingPerHastePercent * 100)))
+	return unit.PseudoStats.AttackSpeedMultiplier * Ratings.HasteMultiplier(unit.stats[stats.HasteRating])
 }
 
 func (unit *Unit) TotalRealRangedHasteMultiplier() float64 {
-	return unit.PseudoStats.AttackSpeedMultiplier * unit.PseudoStats.RangedHasteMultiplier * (1 + (unit.stats[stats.HasteRating] / (HasteRatingPerHastePercent * 100)))
+	return unit.PseudoStats.AttackSpeedMultiplier * unit.PseudoStats.RangedHasteMultiplier * Ratings.HasteMultiplier(unit.stats[stats.HasteRating])
 }
 
 func (unit *Unit) Armor() float64 {
@@ -548,7 +593,7 @@ func (unit *Unit) BlockDamageReduction() float64 {
 }
 
 func (unit *Unit) TotalRangedHasteMultiplier() float64 {
-	return unit.PseudoStats.AttackSpeedMultiplier * unit.PseudoStats.RangedSpeedMultiplier * (1 + (unit.stats[stats.HasteRating] / (HasteRatingPerHastePercent * 100)))
+	return unit.PseudoStats.AttackSpeedMultiplier * unit.PseudoStats.RangedSpeedMultiplier * Ratings.HasteMultiplier(unit.stats[stats.HasteRating])
 }
 
 func (unit *Unit) updateMeleeAttackSpeed() {
@@ -677,11 +722,11 @@ func (unit *Unit) GetCurrentPowerBar() PowerBarType {
 // Stat dependencies that apply both to players/pets (represented as Character
 // structs) and to NPCs (represented as Target structs).
 func (unit *Unit) addUniversalStatDependencies() {
-	unit.AddStatDependency(stats.HitRating, stats.PhysicalHitPercent, 1/PhysicalHitRatingPerHitPercent)
-	unit.AddStatDependency(stats.HitRating, stats.SpellHitPercent, 1/SpellHitRatingPerHitPercent)
-	unit.AddStatDependency(stats.ExpertiseRating, stats.SpellHitPercent, 1/SpellHitRatingPerHitPercent)
-	unit.AddStatDependency(stats.CritRating, stats.PhysicalCritPercent, 1/CritRatingPerCritPercent)
-	unit.AddStatDependency(stats.CritRating, stats.SpellCritPercent, 1/CritRatingPerCritPercent)
+	unit.AddStatDependency(stats.HitRating, stats.PhysicalHitPercent, 1/Ratings.PhysicalHitPerPercent)
+	unit.AddStatDependency(stats.HitRating, stats.SpellHitPercent, 1/Ratings.SpellHitPerPercent)
+	unit.AddStatDependency(stats.ExpertiseRating, stats.SpellHitPercent, 1/Ratings.SpellHitPerPercent)
+	unit.AddStatDependency(stats.CritRating, stats.PhysicalCritPercent, 1/Ratings.CritPerPercent)
+	unit.AddStatDependency(stats.CritRating, stats.SpellCritPercent, 1/Ratings.CritPerPercent)
 }
 
 func (unit *Unit) finalize() {
@@ -732,6 +777,36 @@ func (unit *Unit) finalize() {
 	}
 }
 
+// Shifts the stats the unit was finalized with by delta, and re-derives everything
+// finalize() derives from them. This lets a sim run with slightly different stats
+// without rebuilding the unit. Must only be called in between sims, before sim is
+// reset.
+//
+// Values which were computed from the stats during Initialize, like mastery
+// spell mods, are updated through the same callbacks as for dynamic stat
+// changes, e.g. OnMasteryStatChanged.
+func (unit *Unit) shiftInitialStats(sim *Simulation, delta stats.Stats) {
+	oldInitialStats := unit.initialStats
+
+	unit.ResetStatDeps()
+	unit.stats = unit.initialStatsWithoutDeps.Add(delta)
+	unit.PseudoStats = unit.initialPseudoStats
+	unit.updateCastSpeed()
+	unit.updateAttackSpeed()
+	unit.updateMeleeAndRangedHaste()
+
+	unit.initialStatsWithoutDeps = unit.stats
+	unit.initialCastSpeed = unit.CastSpeed
+	unit.initialMeleeSwingSpeed = unit.TotalMeleeHasteMultiplier()
+	unit.initialRangedSwingSpeed = unit.TotalRangedHasteMultiplier()
+
+	unit.initialStats = unit.ApplyStatDependencies(unit.initialStatsWithoutDeps)
+	unit.statsWithoutDeps = unit.initialStatsWithoutDeps
+	unit.stats = unit.initialStats
+
+	unit.processDynamicBonus(sim, unit.initialStats.Subtract(oldInitialStats))
+}
+
 func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	if unit.Type != EnemyUnit {
 		unit.enabled = true
@@ -742,11 +817,13 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	unit.ChanneledDot = nil
 	unit.QueuedSpell = nil
 	unit.DistanceFromTarget = unit.StartDistanceFromTarget
+	unit.resetThreat()
 	unit.Metrics.reset()
 	unit.ResetStatDeps()
 	unit.statsWithoutDeps = unit.initialStatsWithoutDeps
 	unit.stats = unit.initialStats
 	unit.PseudoStats = unit.initialPseudoStats
+	unit.stance = unit.defaultStance
 	unit.auraTracker.reset(sim)
 	for _, spell := range unit.Spellbook {
 		spell.reset(sim)
@@ -774,7 +851,9 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	unit.DynamicStatsPets = unit.DynamicStatsPets[:0]
 	unit.DynamicMeleeSpeedPets = unit.DynamicMeleeSpeedPets[:0]
 
+	unit.inFight = false
 	if unit.Type != PetUnit {
+		unit.inFight = true
 		sim.addTracker(&unit.auraTracker)
 	}
 }
@@ -784,6 +863,7 @@ func (unit *Unit) onEncounterStart(sim *Simulation) {
 		agent.OnEncounterStart(sim)
 	}
 
+	unit.restorePrecombatAuras(sim)
 	unit.OnEncounterStart(sim)
 }
 
@@ -803,7 +883,13 @@ func (unit *Unit) doneIteration(sim *Simulation) {
 
 	unit.auraTracker.doneIteration(sim)
 	for _, spell := range unit.Spellbook {
-		spell.doneIteration()
+		spell.doneIteration(sim)
+	}
+	if unit.Rotation != nil {
+		unit.Rotation.doneIteration(sim)
+	}
+	if unit.Type == PlayerUnit {
+		unit.estimateMultiDotValues(sim)
 	}
 }
 
@@ -0,0 +1,76 @@
+package core
+
+// Invoked when a unit joins or leaves the fight mid-iteration.
+type OnUnitLifecycle func(sim *Simulation)
+
+type unitLifecycle struct {
+	// Whether the unit currently takes part in the fight, i.e. its aura tracker
+	// is registered with the sim. Players and enemies start every iteration in
+	// the fight, pets only once they are enabled.
+	inFight bool
+
+	onJoinFight  []OnUnitLifecycle
+	onLeaveFight []OnUnitLifecycle
+}
+
+func (unit *Unit) IsInFight() bool {
+	return unit.inFight
+}
+
+// Registers a callback which is invoked whenever this unit joins the fight
+// mid-iteration, e.g. a pet being summoned or an add spawning.
+func (unit *Unit) OnJoinFight(hook OnUnitLifecycle) {
+	unit.onJoinFight = append(unit.onJoinFight, hook)
+}
+
+// Registers a callback which is invoked whenever this unit leaves the fight
+// mid-iteration, e.g. a pet being dismissed or an add dying.
+func (unit *Unit) OnLeaveFight(hook OnUnitLifecycle) {
+	unit.onLeaveFight = append(unit.onLeaveFight, hook)
+}
+
+// Adds the unit to a running iteration, for units joining mid-fight such as
+// temporary allies or battle rezzed players. Auto attacks are started as well.
+func (unit *Unit) JoinFight(sim *Simulation) {
+	if unit.inFight {
+		return
+	}
+	unit.joinFight(sim)
+	unit.AutoAttacks.EnableAutoSwing(sim)
+}
+
+// Removes the unit from a running iteration and stops its auto attacks. If
+// expireAuras is false the unit's auras keep running out, e.g. debuffs on a
+// target which is only temporarily unattackable.
+func (unit *Unit) LeaveFight(sim *Simulation, expireAuras bool) {
+	if !unit.inFight {
+		return
+	}
+	unit.AutoAttacks.CancelAutoSwing(sim)
+	unit.leaveFight(sim, expireAuras)
+}
+
+// Registers the aura tracker with the sim and invokes the join hooks. Callers
+// are responsible for their own auto attack and GCD handling.
+func (unit *Unit) joinFight(sim *Simulation) {
+	unit.inFight = true
+	sim.addTracker(&unit.auraTracker)
+
+	for _, hook := range unit.onJoinFight {
+		hook(sim)
+	}
+}
+
+func (unit *Unit) leaveFight(sim *Simulation, expireAuras bool) {
+	for _, hook := range unit.onLeaveFight {
+		hook(sim)
+	}
+
+	unit.inFight = false
+
+	// Otherwise the tracker stays registered so the remaining auras can run out.
+	if expireAuras {
+		unit.auraTracker.expireAll(sim)
+		sim.removeTracker(&unit.auraTracker)
+	}
+}
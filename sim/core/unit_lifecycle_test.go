@@ -0,0 +1,92 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUnitLeaveAndJoinFight(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	var joins, leaves int
+	target.OnJoinFight(func(_ *Simulation) { joins++ })
+	target.OnLeaveFight(func(_ *Simulation) { leaves++ })
+
+	fa.Dot.Apply(sim)
+	if sim.minTrackerTime != fa.Dot.ExpiresAt() {
+		t.Fatalf("Expected the dot to schedule the target's tracker, got %s", sim.minTrackerTime)
+	}
+
+	target.LeaveFight(sim, true)
+	if fa.Dot.IsActive() {
+		t.Fatalf("Expected auras to expire when leaving the fight")
+	}
+	if target.IsInFight() || slices.Contains(sim.trackers, &target.auraTracker) {
+		t.Fatalf("Expected the target's tracker to be removed")
+	}
+	if sim.minTrackerTime != NeverExpires {
+		t.Fatalf("Expected no stale tracker expiration, got %s", sim.minTrackerTime)
+	}
+
+	// Leaving twice is a no-op.
+	target.LeaveFight(sim, true)
+
+	target.JoinFight(sim)
+	target.JoinFight(sim)
+	numTrackers := 0
+	for _, tracker := range sim.trackers {
+		if tracker == &target.auraTracker {
+			numTrackers++
+		}
+	}
+	if numTrackers != 1 {
+		t.Fatalf("Expected the target's tracker to be registered once, got %d", numTrackers)
+	}
+	if joins != 1 || leaves != 1 {
+		t.Fatalf("Expected 1 join and 1 leave, got %d and %d", joins, leaves)
+	}
+
+	fa.Dot.Apply(sim)
+	if sim.minTrackerTime != fa.Dot.ExpiresAt() {
+		t.Fatalf("Expected the rejoined target's tracker to be scheduled, got %s", sim.minTrackerTime)
+	}
+}
+
+func TestUnitLeaveFightKeepingAuras(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	var joins int
+	target.OnJoinFight(func(_ *Simulation) { joins++ })
+
+	fa.Dot.Apply(sim)
+	target.LeaveFight(sim, false)
+	if target.IsInFight() {
+		t.Fatalf("Expected the target to be out of the fight")
+	}
+	if !fa.Dot.IsActive() {
+		t.Fatalf("Expected auras to keep running when leaving the fight without expiring them")
+	}
+
+	sim.advance(fa.Dot.ExpiresAt())
+	if fa.Dot.IsActive() {
+		t.Fatalf("Expected the dot to run out while the target is out of the fight")
+	}
+
+	target.JoinFight(sim)
+	if !target.IsInFight() || joins != 1 {
+		t.Fatalf("Expected the target to rejoin the fight, in fight: %t, joins: %d", target.IsInFight(), joins)
+	}
+	numTrackers := 0
+	for _, tracker := range sim.trackers {
+		if tracker == &target.auraTracker {
+			numTrackers++
+		}
+	}
+	if numTrackers != 1 {
+		t.Fatalf("Expected the target's tracker to be registered once, got %d", numTrackers)
+	}
+}
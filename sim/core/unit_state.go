@@ -0,0 +1,162 @@
+package core
+
+import (
+	"time"
+)
+
+// State of a unit at a point in an iteration, which can be restored later in
+// the same iteration, e.g. to try out a branch of the rotation and then undo it.
+// Created with Unit.SaveStateSnapshot.
+//
+// Covers the unit's auras and their stacks, the debuffs on enemy targets,
+// cooldown timers, the dots and hots cast by the unit, and resource levels.
+// Debuffs include those applied by other units, but their dots aren't restored.
+// Runes, spell charges and pending actions scheduled by class code aren't
+// included.
+type UnitStateSnapshot struct {
+	auras  []savedAuraState
+	dots   []savedDotState
+	timers []time.Duration
+
+	health         float64
+	mana           float64
+	rage           float64
+	energy         float64
+	comboPoints    int32
+	focus          float64
+	runicPower     float64
+	secondaryValue int32
+}
+
+type savedAuraState struct {
+	aura   *Aura
+	active bool
+	state  AuraState
+}
+
+type savedDotState struct {
+	dot    *Dot
+	active bool
+	state  DotState
+}
+
+// Captures the current state of the unit, see UnitStateSnapshot.
+func (unit *Unit) SaveStateSnapshot(sim *Simulation) *UnitStateSnapshot {
+	snapshot := &UnitStateSnapshot{
+		timers: make([]time.Duration, len(unit.cdTimers)),
+
+		health:      unit.currentHealth,
+		mana:        unit.currentMana,
+		rage:        unit.currentRage,
+		energy:      unit.currentEnergy,
+		comboPoints: unit.comboPoints,
+		focus:       unit.currentFocus,
+		runicPower:  unit.currentRunicPower,
+	}
+	if unit.secondaryResourceBar != nil {
+		snapshot.secondaryValue = unit.secondaryResourceBar.Value()
+	}
+
+	unit.forEachOwnDot(func(dot *Dot) {
+		snapshot.dots = append(snapshot.dots, savedDotState{
+			dot:    dot,
+			active: dot.IsActive(),
+			state:  dot.SaveState(sim),
+		})
+	})
+
+	casters, auraOwners := []*Unit{unit}, []*Unit{unit}
+	if unit.Env != nil {
+		casters = unit.Env.AllUnits
+		for _, target := range unit.Env.Encounter.AllTargetUnits {
+			if target != unit {
+				auraOwners = append(auraOwners, target)
+			}
+		}
+	}
+
+	// Dot auras are restored with their dots, if at all.
+	dotAuras := make(map[*Aura]bool)
+	for _, caster := range casters {
+		caster.forEachOwnDot(func(dot *Dot) {
+			dotAuras[dot.Aura] = true
+		})
+	}
+
+	for _, owner := range auraOwners {
+		for _, aura := range owner.auras {
+			if dotAuras[aura] {
+				continue
+			}
+			snapshot.auras = append(snapshot.auras, savedAuraState{
+				aura:   aura,
+				active: aura.IsActive(),
+				state:  aura.SaveState(sim),
+			})
+		}
+	}
+
+	for i, timer := range unit.cdTimers {
+		snapshot.timers[i] = timer.TimeToReady(sim)
+	}
+
+	return snapshot
+}
+
+// Returns the unit to the state captured by the snapshot. Resources are set
+// directly, so the change doesn't show up in resource metrics. The channel in
+// progress, if any, is left alone so the APL stays consistent.
+func (unit *Unit) RestoreStateSnapshot(sim *Simulation, snapshot *UnitStateSnapshot) {
+	for _, saved := range snapshot.auras {
+		if saved.active {
+			saved.aura.RestoreState(saved.state, sim)
+		} else if saved.aura.IsActive() {
+			saved.aura.Deactivate(sim)
+		}
+	}
+
+	for _, saved := range snapshot.dots {
+		if saved.dot == unit.ChanneledDot {
+			continue
+		}
+		if saved.active {
+			saved.dot.RestoreState(saved.state, sim)
+		} else if saved.dot.IsActive() {
+			saved.dot.Deactivate(sim)
+		}
+	}
+
+	for i, timeToReady := range snapshot.timers {
+		timer := unit.cdTimers[i]
+		if timeToReady > 0 {
+			timer.Set(sim.CurrentTime + timeToReady)
+		} else if !timer.IsReady(sim) {
+			timer.Reset()
+		}
+	}
+
+	unit.currentHealth = snapshot.health
+	unit.currentMana = snapshot.mana
+	unit.currentRage = snapshot.rage
+	unit.currentEnergy = snapshot.energy
+	unit.comboPoints = snapshot.comboPoints
+	unit.currentFocus = snapshot.focus
+	unit.currentRunicPower = snapshot.runicPower
+	if unit.secondaryResourceBar != nil {
+		unit.secondaryResourceBar.ResetBarTo(sim, snapshot.secondaryValue)
+	}
+}
+
+// Calls the handler for every dot and hot cast by the unit, on any target.
+func (unit *Unit) forEachOwnDot(handler func(dot *Dot)) {
+	for _, spell := range unit.Spellbook {
+		for _, dot := range spell.dots {
+			if dot != nil {
+				handler(dot)
+			}
+		}
+		if spell.aoeDot != nil {
+			handler(spell.aoeDot)
+		}
+	}
+}
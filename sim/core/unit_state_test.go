@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnitStateSnapshotRestoresAurasAndTimers(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Type: PlayerUnit, auraTracker: newAuraTracker()}
+
+	stacking := unit.RegisterAura(Aura{
+		Label:     "Stacking",
+		ActionID:  ActionID{SpellID: 100},
+		Duration:  time.Second * 20,
+		MaxStacks: 5,
+	})
+	later := unit.RegisterAura(Aura{
+		Label:    "Later",
+		ActionID: ActionID{SpellID: 101},
+		Duration: time.Second * 10,
+	})
+	timer := unit.NewTimer()
+	timer.Reset()
+
+	stacking.Activate(sim)
+	stacking.SetStacks(sim, 3)
+	unit.currentMana = 1000
+	snapshot := unit.SaveStateSnapshot(sim)
+
+	sim.CurrentTime = time.Second * 5
+	stacking.SetStacks(sim, 5)
+	later.Activate(sim)
+	timer.Set(sim.CurrentTime + time.Minute)
+	unit.currentMana = 200
+
+	unit.RestoreStateSnapshot(sim, snapshot)
+
+	if !stacking.IsActive() || stacking.GetStacks() != 3 {
+		t.Fatalf("Expected the stacking aura to be restored to 3 stacks, got %d", stacking.GetStacks())
+	}
+	if stacking.RemainingDuration(sim) != time.Second*20 {
+		t.Fatalf("Expected the remaining duration to be restored, got %s", stacking.RemainingDuration(sim))
+	}
+	if later.IsActive() {
+		t.Fatalf("Expected auras gained after the snapshot to be removed")
+	}
+	if !timer.IsReady(sim) {
+		t.Fatalf("Expected cooldowns used after the snapshot to be reset")
+	}
+	if unit.currentMana != 1000 {
+		t.Fatalf("Expected mana to be restored, got %f", unit.currentMana)
+	}
+}
+
+func TestUnitStateSnapshotRestoresPermanentStacksAndTargetDebuffs(t *testing.T) {
+	var permanent, debuff *Aura
+	sim, _ := setupResultPipelineSim(func(env *Environment) {
+		permanent = env.Raid.Parties[0].Players[0].GetCharacter().RegisterAura(Aura{
+			Label:     "Permanent Stacks",
+			ActionID:  ActionID{SpellID: 100},
+			Duration:  NeverExpires,
+			MaxStacks: 5,
+		})
+		debuff = env.Encounter.AllTargetUnits[0].RegisterAura(Aura{
+			Label:     "Stacking Debuff",
+			ActionID:  ActionID{SpellID: 101},
+			Duration:  time.Second * 30,
+			MaxStacks: 3,
+		})
+	})
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+
+	permanent.Activate(sim)
+	permanent.SetStacks(sim, 2)
+	debuff.Activate(sim)
+	debuff.SetStacks(sim, 1)
+	snapshot := unit.SaveStateSnapshot(sim)
+
+	sim.CurrentTime = time.Second * 5
+	permanent.SetStacks(sim, 5)
+	debuff.Activate(sim)
+	debuff.SetStacks(sim, 3)
+
+	unit.RestoreStateSnapshot(sim, snapshot)
+
+	if !permanent.IsActive() || permanent.GetStacks() != 2 || permanent.ExpiresAt() != NeverExpires {
+		t.Fatalf("Expected the permanent aura to be restored to 2 stacks, got %d", permanent.GetStacks())
+	}
+	if debuff.GetStacks() != 1 || debuff.RemainingDuration(sim) != time.Second*30 {
+		t.Fatalf("Expected the target debuff to be restored to 1 stack with 30s left, got %d and %s", debuff.GetStacks(), debuff.RemainingDuration(sim))
+	}
+}
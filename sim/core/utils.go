@@ -236,7 +236,7 @@ func SetToSortedSlice[K cmp.Ordered](src map[K]bool) []K {
 }
 
 func MasteryRatingToMasteryPoints(masteryRating float64) float64 {
-	return masteryRating / MasteryRatingPerMasteryPoint
+	return Ratings.MasteryPoints(masteryRating)
 }
 
 func Clamp(val float64, min float64, max float64) float64 {
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Largest difference between two variances which are considered equal.
+const varianceAuditTolerance = 0.001
+
+// Damage rolls of the logged iteration, see SimOptions.audit_damage_variance.
+type varianceAudit struct {
+	// Innermost spell whose effects or dot ticks are being applied, which
+	// rolls are credited to.
+	spell *Spell
+
+	spells  map[*Spell]*spellRollAudit
+	ordered []*spellRollAudit
+}
+
+type spellRollAudit struct {
+	spell *Spell
+
+	rolls     int32
+	min       float64 // Lowest minimum of the rolls
+	max       float64 // Highest maximum of the rolls
+	actualMin float64
+	actualMax float64
+
+	// Variance of the rolls, i.e. their range relative to their average.
+	minVariance float64
+	maxVariance float64
+}
+
+func newVarianceAudit(simOptions *proto.SimOptions) *varianceAudit {
+	if !simOptions.AuditDamageVariance || !(simOptions.Debug || simOptions.DebugFirstIteration) {
+		return nil
+	}
+	return &varianceAudit{
+		spells: make(map[*Spell]*spellRollAudit),
+	}
+}
+
+func (audit *varianceAudit) record(sim *Simulation, min float64, max float64, roll float64) {
+	spell := audit.spell
+	if spell == nil {
+		// Rolls outside of spell effects and dot ticks can't be credited.
+		return
+	}
+
+	variance := 0.0
+	if avg := (min + max) / 2; avg != 0 {
+		variance = (max - min) / avg
+	}
+
+	spellAudit, ok := audit.spells[spell]
+	if !ok {
+		spellAudit = &spellRollAudit{
+			spell:       spell,
+			min:         min,
+			max:         max,
+			actualMin:   roll,
+			actualMax:   roll,
+			minVariance: variance,
+			maxVariance: variance,
+		}
+		audit.spells[spell] = spellAudit
+		audit.ordered = append(audit.ordered, spellAudit)
+	}
+
+	spellAudit.rolls++
+	spellAudit.min = math.Min(spellAudit.min, min)
+	spellAudit.max = math.Max(spellAudit.max, max)
+	spellAudit.actualMin = math.Min(spellAudit.actualMin, roll)
+	spellAudit.actualMax = math.Max(spellAudit.actualMax, roll)
+	spellAudit.minVariance = math.Min(spellAudit.minVariance, variance)
+	spellAudit.maxVariance = math.Max(spellAudit.maxVariance, variance)
+
+	if sim.LogEnabled(LogCategoryDamage, LogLevelTrace) {
+		spell.Unit.LogAt(sim, LogCategoryDamage, LogLevelTrace, "%s [VARIANCE] Damage roll: %0.2f in %0.2f - %0.2f (variance %0.4f)",
+			spell.ActionID, roll, min, max, variance)
+	}
+}
+
+// Problems with the rolls of the spell, empty if there are none.
+func (spellAudit *spellRollAudit) flags() []string {
+	var flags []string
+	if spellAudit.maxVariance-spellAudit.minVariance > varianceAuditTolerance {
+		flags = append(flags, fmt.Sprintf("variance drifts between rolls (%0.4f - %0.4f)", spellAudit.minVariance, spellAudit.maxVariance))
+	}
+	if dbVariance := spellAudit.spell.damageVariance; dbVariance != 0 {
+		if math.Abs(spellAudit.minVariance-dbVariance) > varianceAuditTolerance || math.Abs(spellAudit.maxVariance-dbVariance) > varianceAuditTolerance {
+			flags = append(flags, fmt.Sprintf("variance differs from the database value of %0.4f", dbVariance))
+		}
+	}
+	return flags
+}
+
+// Logs the table of the rolls of each spell, in the order they first rolled.
+func (audit *varianceAudit) logTable(sim *Simulation) {
+	sim.Log("[VARIANCE AUDIT] Unit | Spell | Rolls | Range | Actual | Variance | Database | Flags")
+	for _, spellAudit := range audit.ordered {
+		spell := spellAudit.spell
+
+		dbVariance := "-"
+		if spell.damageVariance != 0 {
+			dbVariance = fmt.Sprintf("%0.4f", spell.damageVariance)
+		}
+		flags := "-"
+		if spellFlags := spellAudit.flags(); len(spellFlags) > 0 {
+			flags = fmt.Sprintf("%q", spellFlags)
+		}
+
+		sim.Log("[VARIANCE AUDIT] %s | %s | %d | %0.2f - %0.2f | %0.2f - %0.2f | %0.4f - %0.4f | %s | %s",
+			spell.Unit.Label, spell.ActionID, spellAudit.rolls,
+			spellAudit.min, spellAudit.max, spellAudit.actualMin, spellAudit.actualMax,
+			spellAudit.minVariance, spellAudit.maxVariance, dbVariance, flags)
+	}
+}
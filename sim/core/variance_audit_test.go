@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestVarianceAudit(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.ActiveTargetUnits[0]
+
+	var logs []string
+	sim.Log = func(message string, vals ...interface{}) {
+		logs = append(logs, fmt.Sprintf(message, vals...))
+	}
+	sim.varianceAudit = newVarianceAudit(&proto.SimOptions{AuditDamageVariance: true, DebugFirstIteration: true})
+
+	// Rolls with a variance of 0.2, then one of 0.4.
+	fa.Spell.damageVariance = 0.2
+	rolls := [][2]float64{{90, 110}, {180, 220}, {80, 120}}
+	rollIdx := 0
+	fa.Spell.ApplyEffects = func(sim *Simulation, _ *Unit, _ *Spell) {
+		sim.Roll(rolls[rollIdx][0], rolls[rollIdx][1])
+		rollIdx++
+	}
+
+	fa.Spell.SkipCastAndApplyEffects(sim, target)
+	fa.Spell.SkipCastAndApplyEffects(sim, target)
+
+	spellAudit := sim.varianceAudit.spells[fa.Spell]
+	if spellAudit == nil || spellAudit.rolls != 2 {
+		t.Fatalf("Expected 2 rolls to be credited to the spell")
+	}
+	if flags := spellAudit.flags(); len(flags) != 0 {
+		t.Fatalf("Expected rolls matching the database variance to be fine, got %v", flags)
+	}
+
+	fa.Spell.SkipCastAndApplyEffects(sim, target)
+	if flags := spellAudit.flags(); len(flags) != 2 {
+		t.Fatalf("Expected the variance to be flagged as drifting and differing from the database, got %v", flags)
+	}
+
+	// Rolls of dot ticks are credited to the dot's spell.
+	fa.Dot.onTick = func(sim *Simulation, _ *Unit, _ *Dot) {
+		sim.Roll(90, 110)
+	}
+	fa.Dot.TickOnce(sim)
+	if spellAudit.rolls != 4 {
+		t.Fatalf("Expected the dot tick's roll to be credited to the spell, got %d rolls", spellAudit.rolls)
+	}
+
+	// Rolls outside of spell effects and dot ticks aren't credited.
+	sim.Roll(0, 1)
+	if len(sim.varianceAudit.ordered) != 1 {
+		t.Fatalf("Expected only the spell's rolls to be audited")
+	}
+
+	sim.varianceAudit.logTable(sim)
+	found := false
+	for _, log := range logs {
+		if strings.HasPrefix(log, "[VARIANCE AUDIT] "+fa.Label) && strings.Contains(log, "| 4 | 80.00 - 220.00 |") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected the audit table to contain the spell's rolls, got %v", logs)
+	}
+}
+
+func TestVarianceAuditNeedsLogs(t *testing.T) {
+	if newVarianceAudit(&proto.SimOptions{AuditDamageVariance: true}) != nil {
+		t.Fatalf("Expected no audit without a logged iteration")
+	}
+}
@@ -85,8 +85,8 @@ func (character *Character) RegisterVengeance(spellID int32, requiredAura *Aura)
 			inferredEquilibriumVengeance := VengeanceScaling * rawDamage * buffAura.Duration.Seconds() / inferredAttackInterval.Seconds()
 
 			if newVengeance < 0.5*inferredEquilibriumVengeance {
-				if sim.Log != nil {
-					result.Target.Log(sim, "Triggered Vengeance ramp-up mechanism because newVengeance = %.1f and inferredEquilibriumVengeance = %.1f .", newVengeance, inferredEquilibriumVengeance)
+				if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
+					result.Target.LogAt(sim, LogCategoryOther, LogLevelTrace, "Triggered Vengeance ramp-up mechanism because newVengeance = %.1f and inferredEquilibriumVengeance = %.1f .", newVengeance, inferredEquilibriumVengeance)
 				}
 
 				newVengeance = 0.5 * inferredEquilibriumVengeance
@@ -95,8 +95,8 @@ func (character *Character) RegisterVengeance(spellID int32, requiredAura *Aura)
 			// Apply HP cap.
 			newVengeance = min(newVengeance, result.Target.MaxHealth())
 
-			if sim.Log != nil {
-				result.Target.Log(sim, "Updated Vengeance for %s due to %s from %s. Raw damage value = %.1f, raw Vengeance contribution = %.1f, new Vengeance value = %.1f .", result.Target.Label, spell.ActionID, spell.Unit.Label, rawDamage, rawVengeance, newVengeance)
+			if sim.LogEnabled(LogCategoryOther, LogLevelTrace) {
+				result.Target.LogAt(sim, LogCategoryOther, LogLevelTrace, "Updated Vengeance for %s due to %s from %s. Raw damage value = %.1f, raw Vengeance contribution = %.1f, new Vengeance value = %.1f .", result.Target.Label, spell.ActionID, spell.Unit.Label, rawDamage, rawVengeance, newVengeance)
 			}
 
 			// Activate or refresh the buff Aura and set stacks.
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestSplitClearsWorkerThreads(t *testing.T) {
+	splitRes := SplitSimRequestForConcurrency(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			Iterations:       10,
+			RandomSeed:       100,
+			NumWorkerThreads: 4,
+		},
+	}, 4)
+
+	if splitRes.SplitsDone != 4 {
+		t.Fatalf("Expected 4 splits, got %d", splitRes.SplitsDone)
+	}
+
+	var iterations int32
+	for _, req := range splitRes.Requests {
+		if req.SimOptions.NumWorkerThreads != 0 {
+			t.Fatalf("Expected splits to run on a single thread, got %d threads", req.SimOptions.NumWorkerThreads)
+		}
+		iterations += req.SimOptions.Iterations
+	}
+	if iterations != 10 {
+		t.Fatalf("Expected 10 iterations over all splits, got %d", iterations)
+	}
+}
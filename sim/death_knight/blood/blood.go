@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/death_knight"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterBloodDeathKnight() {
@@ -22,6 +23,7 @@ func RegisterBloodDeathKnight() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecBloodDeathKnight, core.LoadAPLPresets(ui.APLs, "death_knight/blood/apls")...)
 }
 
 // Threat Done By Caster setup
@@ -86,7 +88,6 @@ func (bdk *BloodDeathKnight) Initialize() {
 	bdk.registerWillOfTheNecropolis()
 
 	bdk.RuneWeapon.AddCopySpell(HeartStrikeActionID, bdk.registerDrwHeartStrike())
-	bdk.RuneWeapon.AddCopySpell(RuneStrikeActionID, bdk.registerDrwRuneStrike())
 }
 
 func (bdk *BloodDeathKnight) ApplyTalents() {
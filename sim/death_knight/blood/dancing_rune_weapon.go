@@ -29,14 +29,7 @@ func (dk *BloodDeathKnight) registerDancingRuneWeapon() {
 		Duration: duration,
 		// Casts copy
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
-			copySpell := dk.RuneWeapon.RuneWeaponSpells[spell.ActionID]
-			if copySpell == nil {
-				return
-			}
-
-			death_knight.CopySpellMultipliers(spell, copySpell, dk.CurrentTarget)
-
-			copySpell.Cast(sim, dk.CurrentTarget)
+			dk.RuneWeapon.MirrorCast(sim, spell, dk.CurrentTarget)
 		},
 	}).AttachAdditivePseudoStatBuff(&dk.PseudoStats.BaseParryChance, 0.2)
 
@@ -0,0 +1,94 @@
+package blood
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/death_knight"
+)
+
+func setupDancingRuneWeaponSim() *core.Simulation {
+	sim := core.NewSim(&proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(&proto.Player{
+			Class:         proto.Class_ClassDeathKnight,
+			Race:          proto.Race_RaceOrc,
+			Equipment:     &proto.EquipmentSpec{},
+			Spec:          PlayerOptionsBlood,
+			TalentsString: BloodTalents,
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 180,
+			Targets:  []*proto.Target{{Level: 93}},
+		},
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+	return sim
+}
+
+func TestDancingRuneWeaponMirrorsDeathCoil(t *testing.T) {
+	sim := setupDancingRuneWeaponSim()
+	dk := sim.Raid.Parties[0].Players[0].(*BloodDeathKnight)
+	target := sim.Encounter.ActiveTargetUnits[0]
+	deathCoil := dk.GetSpell(death_knight.DeathCoilActionID)
+
+	copySpell := dk.RuneWeapon.MirroredSpell(deathCoil)
+	if copySpell == nil || copySpell.Unit != &dk.RuneWeapon.Unit {
+		t.Fatalf("Expected the Rune Weapon to have a copy of Death Coil")
+	}
+	if copySpell.Flags.Matches(core.SpellFlagAPL) || copySpell.Cost != nil {
+		t.Fatalf("Expected the copy to have no APL flag or cost")
+	}
+
+	dk.GetSpell(core.ActionID{SpellID: 49028}).Cast(sim, target)
+	dk.AddRunicPower(sim, 40, nil)
+	deathCoil.DamageMultiplier = 1.5
+	deathCoil.Cast(sim, target)
+
+	if casts := copySpell.SpellMetrics[target.UnitIndex].Casts; casts != 1 {
+		t.Fatalf("Expected the Rune Weapon to cast Death Coil once, got %d", casts)
+	}
+	if copySpell.DamageMultiplier != 1.5 {
+		t.Fatalf("Expected the copy to use the Death Coil multiplier of 1.5, got %0.2f", copySpell.DamageMultiplier)
+	}
+}
+
+func TestDancingRuneWeaponMirrorsRuneStrike(t *testing.T) {
+	sim := setupDancingRuneWeaponSim()
+	dk := sim.Raid.Parties[0].Players[0].(*BloodDeathKnight)
+	target := sim.Encounter.ActiveTargetUnits[0]
+	runeStrike := dk.GetSpell(RuneStrikeActionID)
+
+	copySpell := dk.RuneWeapon.MirroredSpell(runeStrike)
+	if copySpell == nil || copySpell.ClassSpellMask != runeStrike.ClassSpellMask || copySpell.MaxRange != runeStrike.MaxRange {
+		t.Fatalf("Expected the Rune Weapon to have a copy of Rune Strike with its class mask and range")
+	}
+
+	dk.GetSpell(core.ActionID{SpellID: 49028}).Cast(sim, target)
+	dk.AddRunicPower(sim, 30, nil)
+	runeStrike.Cast(sim, target)
+
+	// The copy replaces the refund of the Death Knight's spell, as it has no cost.
+	if casts := copySpell.SpellMetrics[target.UnitIndex].Casts; casts != 1 {
+		t.Fatalf("Expected the Rune Weapon to cast Rune Strike once, got %d", casts)
+	}
+}
+
+func TestMirroredSpellRejectsRelatedSpells(t *testing.T) {
+	sim := setupDancingRuneWeaponSim()
+	dk := sim.Raid.Parties[0].Players[0].(*BloodDeathKnight)
+
+	defer func() {
+		if err := recover(); err == nil || !strings.Contains(fmt.Sprint(err), "AddCopySpell") {
+			t.Fatalf("Expected a mirrored spell with a related dot spell to be rejected, got %v", err)
+		}
+	}()
+	dk.RegisterMirroredSpell(core.SpellConfig{
+		ActionID:        core.ActionID{SpellID: 1},
+		RelatedDotSpell: dk.GetSpell(death_knight.DeathCoilActionID),
+	}, death_knight.SpellMirrorConfig{})
+}
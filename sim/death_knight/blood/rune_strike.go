@@ -12,7 +12,7 @@ Strike the target for 200% weapon damage plus (<AP> * 0.2).
 This attack cannot be dodged, blocked, or parried.
 */
 func (bdk *BloodDeathKnight) registerRuneStrike() {
-	bdk.GetOrRegisterSpell(core.SpellConfig{
+	bdk.RegisterMirroredSpell(core.SpellConfig{
 		ActionID:       RuneStrikeActionID,
 		SpellSchool:    core.SpellSchoolPhysical,
 		ProcMask:       core.ProcMaskMeleeMH, // Rune Strike triggers white hit procs as well so we give it both masks.
@@ -45,18 +45,8 @@ func (bdk *BloodDeathKnight) registerRuneStrike() {
 
 			spell.DealDamage(sim, result)
 		},
-	})
-}
-
-func (bdk *BloodDeathKnight) registerDrwRuneStrike() *core.Spell {
-	return bdk.RuneWeapon.RegisterSpell(core.SpellConfig{
-		ActionID:    RuneStrikeActionID,
-		SpellSchool: core.SpellSchoolPhysical,
-		ProcMask:    core.ProcMaskMeleeMH,
-		Flags:       core.SpellFlagMeleeMetrics,
-
-		MaxRange: core.MaxMeleeRange,
-
+	}, death_knight.SpellMirrorConfig{
+		// The Rune Weapon's copy has no cost to refund.
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/death_knight"
 )
 
@@ -27,25 +28,22 @@ func (bdk *BloodDeathKnight) registerVampiricBlood() {
 	hasGlyph := bdk.HasMajorGlyph(proto.DeathKnightMajorGlyph_GlyphOfVampiricBlood)
 	healBonus := core.TernaryFloat64(hasGlyph, 1.40, 1.25)
 
-	var vampiricBloodBonusHealth float64
 	vampiricBloodAura := bdk.RegisterAura(core.Aura{
 		Label:    "Vampiric Blood" + bdk.Label,
 		ActionID: actionID,
 		Duration: time.Second * 10,
-
-		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			if !hasGlyph {
-				vampiricBloodBonusHealth = bdk.MaxHealth() * 0.15
-				bdk.UpdateMaxHealth(sim, vampiricBloodBonusHealth, healthMetrics)
-			}
-		},
-		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			if !hasGlyph {
-				bdk.UpdateMaxHealth(sim, -vampiricBloodBonusHealth, healthMetrics)
-			}
-		},
 	}).AttachMultiplicativePseudoStatBuff(&bdk.PseudoStats.HealingTakenMultiplier, healBonus)
 
+	if !hasGlyph {
+		vampiricBloodAura.AttachStatBonus(core.AuraStatBonusConfig{
+			Mode:          core.StatBonusDynamic,
+			SourceStat:    stats.Health,
+			TargetStat:    stats.Health,
+			Ratio:         0.15,
+			HealthMetrics: healthMetrics,
+		})
+	}
+
 	spell := bdk.RegisterSpell(core.SpellConfig{
 		ActionID:       actionID,
 		SpellSchool:    core.SpellSchoolPhysical,
@@ -16,7 +16,7 @@ Fire a blast of unholy energy, causing (929 + <AP> * 0.514) Shadow damage to an
 -- /Glyph of Death's Embrace --
 */
 func (dk *DeathKnight) registerDeathCoil() {
-	dk.RegisterSpell(core.SpellConfig{
+	dk.RegisterMirroredSpell(core.SpellConfig{
 		ActionID:       DeathCoilActionID,
 		SpellSchool:    core.SpellSchoolShadow,
 		ProcMask:       core.ProcMaskSpellDamage,
@@ -42,18 +42,5 @@ func (dk *DeathKnight) registerDeathCoil() {
 			baseDamage := dk.CalcScalingSpellDmg(0.74544) + spell.MeleeAttackPower()*0.514
 			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 		},
-	})
-}
-
-func (dk *DeathKnight) registerDrwDeathCoil() *core.Spell {
-	return dk.RuneWeapon.RegisterSpell(core.SpellConfig{
-		ActionID:    DeathCoilActionID,
-		SpellSchool: core.SpellSchoolShadow,
-		ProcMask:    core.ProcMaskSpellDamage,
-
-		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			baseDamage := dk.CalcScalingSpellDmg(0.74544) + spell.MeleeAttackPower()*0.514
-			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
-		},
-	})
+	}, SpellMirrorConfig{})
 }
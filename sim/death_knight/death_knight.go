@@ -54,6 +54,7 @@ type DeathKnight struct {
 	// Diseases
 	FrostFeverSpell  *core.Spell
 	BloodPlagueSpell *core.Spell
+	Diseases         DiseaseManager
 
 	// Runic power decay, used during pre pull
 	RunicPowerDecayAura *core.Aura
@@ -64,6 +65,10 @@ type DeathKnight struct {
 	// Modified by T14 Tank 4pc
 	deathStrikeHealingMultiplier float64
 
+	// Execute range of Soul Reaper, and its T15 Dps 4pc replacement
+	soulReaperPhase    *core.ExecutePhaseTracker
+	soulReaperT15Phase *core.ExecutePhaseTracker
+
 	// Modified by T15 Dps 4pc
 	soulReaper45Percent bool
 }
@@ -6,19 +6,58 @@ import (
 	"github.com/wowsims/mop/sim/core"
 )
 
-func (dk *DeathKnight) DiseasesAreActive(target *core.Unit) bool {
-	return dk.FrostFeverSpell.Dot(target).IsActive() || dk.BloodPlagueSpell.Dot(target).IsActive()
+// DiseaseManager owns a caster's diseases, so effects which depend on the
+// diseases on a target don't need to check each of them.
+type DiseaseManager struct {
+	diseases []*core.Spell
 }
 
-func (dk *DeathKnight) GetDiseaseMulti(target *core.Unit, base float64, increase float64) float64 {
-	count := 0
-	if dk.FrostFeverSpell.Dot(target).IsActive() {
-		count++
+func (dm *DiseaseManager) AddDisease(disease *core.Spell) {
+	dm.diseases = append(dm.diseases, disease)
+}
+
+// Number of diseases active on the target.
+func (dm *DiseaseManager) CountActive(target *core.Unit) int32 {
+	count := int32(0)
+	for _, disease := range dm.diseases {
+		if disease.Dot(target).IsActive() {
+			count++
+		}
 	}
-	if dk.BloodPlagueSpell.Dot(target).IsActive() {
-		count++
+	return count
+}
+
+func (dm *DiseaseManager) AnyActive(target *core.Unit) bool {
+	for _, disease := range dm.diseases {
+		if disease.Dot(target).IsActive() {
+			return true
+		}
+	}
+	return false
+}
+
+// Extends each disease active on the target by duration, along with the active
+// auras applied by that disease, e.g. Ebon Plaguebringer's Physical Vulnerability.
+func (dm *DiseaseManager) ExtendAll(sim *core.Simulation, target *core.Unit, duration time.Duration) {
+	for _, disease := range dm.diseases {
+		dot := disease.Dot(target)
+		if !dot.IsActive() {
+			continue
+		}
+
+		dot.Extend(sim, duration)
+		for _, relatedAuras := range disease.RelatedAuraArrays {
+			relatedAuras.Get(target).Extend(sim, duration)
+		}
 	}
-	return base + increase*float64(count)
+}
+
+func (dk *DeathKnight) DiseasesAreActive(target *core.Unit) bool {
+	return dk.Diseases.AnyActive(target)
+}
+
+func (dk *DeathKnight) GetDiseaseMulti(target *core.Unit, base float64, increase float64) float64 {
+	return base + increase*float64(dk.Diseases.CountActive(target))
 }
 
 func (dk *DeathKnight) getFrostFeverConfig(character *core.Character) core.SpellConfig {
@@ -66,6 +105,7 @@ func (dk *DeathKnight) registerFrostFever() {
 	}
 
 	dk.FrostFeverSpell = dk.RegisterSpell(config)
+	dk.Diseases.AddDisease(dk.FrostFeverSpell)
 }
 
 func (dk *DeathKnight) getBloodPlagueConfig(character *core.Character) core.SpellConfig {
@@ -114,6 +154,7 @@ func (dk *DeathKnight) registerBloodPlague() {
 	}
 
 	dk.BloodPlagueSpell = dk.RegisterSpell(config)
+	dk.Diseases.AddDisease(dk.BloodPlagueSpell)
 }
 
 func (dk *DeathKnight) registerDrwFrostFever() {
@@ -124,6 +165,7 @@ func (dk *DeathKnight) registerDrwFrostFever() {
 	}
 
 	dk.RuneWeapon.FrostFeverSpell = dk.RuneWeapon.RegisterSpell(config)
+	dk.RuneWeapon.Diseases.AddDisease(dk.RuneWeapon.FrostFeverSpell)
 }
 
 func (dk *DeathKnight) registerDrwBloodPlague() {
@@ -134,4 +176,5 @@ func (dk *DeathKnight) registerDrwBloodPlague() {
 	}
 
 	dk.RuneWeapon.BloodPlagueSpell = dk.RuneWeapon.RegisterSpell(config)
+	dk.RuneWeapon.Diseases.AddDisease(dk.RuneWeapon.BloodPlagueSpell)
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/death_knight"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterFrostDeathKnight() {
@@ -22,6 +23,7 @@ func RegisterFrostDeathKnight() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecFrostDeathKnight, core.LoadAPLPresets(ui.APLs, "death_knight/frost/apls")...)
 }
 
 type FrostDeathKnight struct {
@@ -122,6 +122,7 @@ var ItemSetBattleplateOfTheAllConsumingMaw = core.NewItemSet(core.ItemSet{
 			dk := agent.(DeathKnightAgent).GetDeathKnight()
 
 			// KM effect handled in sim/death_knight/frost/killing_machine.go
+			dk.soulReaperT15Phase = dk.ExecutePhase(45)
 			setBonusAura.ApplyOnGain(func(aura *core.Aura, sim *core.Simulation) {
 				dk.soulReaper45Percent = true
 			}).ApplyOnExpire(func(aura *core.Aura, sim *core.Simulation) {
@@ -8,6 +8,14 @@ import (
 
 const presenceEffectCategory = "Presence"
 
+// Presences are registered as stances only for the In Stance APL value, no
+// spell requires one.
+const (
+	BloodPresence core.StanceMask = 1 << iota
+	FrostPresence
+	UnholyPresence
+)
+
 func (dk *DeathKnight) registerBloodPresence() {
 	actionID := core.ActionID{SpellID: 48263}
 	rpMetrics := dk.NewRunicPowerMetrics(actionID)
@@ -55,6 +63,12 @@ func (dk *DeathKnight) registerBloodPresence() {
 
 		RelatedSelfBuff: presenceAura.Aura,
 	})
+
+	dk.RegisterStance(core.StanceConfig{
+		Stance: BloodPresence,
+		Aura:   presenceAura.Aura,
+		Spell:  dk.BloodPresenceSpell,
+	})
 }
 
 func (dk *DeathKnight) registerFrostPresence() {
@@ -91,6 +105,12 @@ func (dk *DeathKnight) registerFrostPresence() {
 
 		RelatedSelfBuff: presenceAura.Aura,
 	})
+
+	dk.RegisterStance(core.StanceConfig{
+		Stance: FrostPresence,
+		Aura:   presenceAura.Aura,
+		Spell:  dk.FrostPresenceSpell,
+	})
 }
 
 func (dk *DeathKnight) registerUnholyPresence() {
@@ -135,6 +155,12 @@ func (dk *DeathKnight) registerUnholyPresence() {
 
 		RelatedSelfBuff: presenceAura.Aura,
 	})
+
+	dk.RegisterStance(core.StanceConfig{
+		Stance: UnholyPresence,
+		Aura:   presenceAura.Aura,
+		Spell:  dk.UnholyPresenceSpell,
+	})
 }
 
 func (dk *DeathKnight) activatePresence(presence *core.Aura, rpMetrics *core.ResourceMetrics) core.ApplySpellResults {
@@ -36,6 +36,7 @@ type RuneWeaponPet struct {
 	// Diseases
 	FrostFeverSpell  *core.Spell
 	BloodPlagueSpell *core.Spell
+	Diseases         DiseaseManager
 
 	drwDmgSnapshot       float64
 	drwSchoolDmgSnapshot [stats.SchoolLen]float64
@@ -43,7 +44,7 @@ type RuneWeaponPet struct {
 	StrikeWeapon       *core.Weapon
 	StrikeWeaponDamage float64
 
-	RuneWeaponSpells map[core.ActionID]*core.Spell
+	Mirror SpellMirror
 }
 
 func (runeWeapon *RuneWeaponPet) Initialize() {
@@ -52,32 +53,21 @@ func (runeWeapon *RuneWeaponPet) Initialize() {
 	runeWeapon.dkOwner.registerDrwFrostFever()
 	runeWeapon.dkOwner.registerDrwBloodPlague()
 	runeWeapon.AddCopySpell(BloodBoilActionID, runeWeapon.dkOwner.registerDrwBloodBoil())
-	runeWeapon.AddCopySpell(DeathCoilActionID, runeWeapon.dkOwner.registerDrwDeathCoil())
 	runeWeapon.AddCopySpell(DeathStrikeActionID, runeWeapon.dkOwner.registerDrwDeathStrike())
 	runeWeapon.AddCopySpell(IcyTouchActionID, runeWeapon.dkOwner.registerDrwIcyTouch())
 	runeWeapon.AddCopySpell(OutbreakActionID, runeWeapon.dkOwner.registerDrwOutbreak())
 	runeWeapon.AddCopySpell(PestilenceActionID, runeWeapon.dkOwner.registerDrwPestilence())
 	runeWeapon.AddCopySpell(PlagueStrikeActionID, runeWeapon.dkOwner.registerDrwPlagueStrike())
 	runeWeapon.AddCopySpell(SoulReaperActionID.WithTag(1), runeWeapon.dkOwner.registerDrwSoulReaper())
+	runeWeapon.registerMirroredSpells()
 }
 
 func (runeWeapon *RuneWeaponPet) DiseasesAreActive(target *core.Unit) bool {
-	return runeWeapon.FrostFeverSpell.Dot(target).IsActive() || runeWeapon.BloodPlagueSpell.Dot(target).IsActive()
+	return runeWeapon.Diseases.AnyActive(target)
 }
 
 func (runeWeapon *RuneWeaponPet) GetDiseaseMulti(target *core.Unit, base float64, increase float64) float64 {
-	count := 0
-	if runeWeapon.FrostFeverSpell.Dot(target).IsActive() {
-		count++
-	}
-	if runeWeapon.BloodPlagueSpell.Dot(target).IsActive() {
-		count++
-	}
-	return base + increase*float64(count)
-}
-
-func (runeWeapon *RuneWeaponPet) AddCopySpell(actionId core.ActionID, spell *core.Spell) {
-	runeWeapon.RuneWeaponSpells[actionId] = spell
+	return base + increase*float64(runeWeapon.Diseases.CountActive(target))
 }
 
 func (dk *DeathKnight) NewRuneWeapon() *RuneWeaponPet {
@@ -96,8 +86,6 @@ func (dk *DeathKnight) NewRuneWeapon() *RuneWeaponPet {
 		dkOwner: dk,
 	}
 
-	runeWeapon.RuneWeaponSpells = map[core.ActionID]*core.Spell{}
-
 	runeWeapon.OnPetEnable = runeWeapon.enable
 	runeWeapon.OnPetDisable = runeWeapon.disable
 
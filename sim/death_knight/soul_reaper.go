@@ -9,7 +9,14 @@ import (
 
 var SoulReaperActionID = core.ActionID{SpellID: 114867}
 
+// Whether Soul Reaper deals its additional damage right now.
+func (dk *DeathKnight) soulReaperExecuteActive() bool {
+	return dk.soulReaperPhase.IsActive() || (dk.soulReaper45Percent && dk.soulReaperT15Phase.IsActive())
+}
+
 func (dk *DeathKnight) registerSoulReaper() {
+	dk.soulReaperPhase = dk.ExecutePhase(35)
+
 	dotTickSpell := dk.RegisterSpell(core.SpellConfig{
 		ActionID:       SoulReaperActionID,
 		SpellSchool:    core.SpellSchoolShadow,
@@ -25,7 +32,7 @@ func (dk *DeathKnight) registerSoulReaper() {
 			NumberOfTicks: 1,
 
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				if sim.IsExecutePhase35() || (dk.soulReaper45Percent && sim.IsExecutePhase45()) {
+				if dk.soulReaperExecuteActive() {
 					baseDamage := dk.CalcAndRollDamageRange(sim, 48, 0.15000000596) +
 						1.20000004768*dot.Spell.MeleeAttackPower()
 					dot.Snapshot(target, baseDamage)
@@ -117,7 +124,7 @@ func (dk *DeathKnight) registerDrwSoulReaper() *core.Spell {
 			NumberOfTicks: 1,
 
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				if sim.IsExecutePhase35() || (dk.soulReaper45Percent && sim.IsExecutePhase45()) {
+				if dk.soulReaperExecuteActive() {
 					baseDamage := dk.CalcAndRollDamageRange(sim, 48, 0.15000000596) +
 						1.20000004768*dot.Spell.MeleeAttackPower()
 					dot.Snapshot(target, baseDamage)
@@ -0,0 +1,127 @@
+package death_knight
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Flags kept on the Rune Weapon's copy of a mirrored spell. Everything tied to
+// casting the spell, like the APL and cooldown flags, only applies to the
+// Death Knight's spell.
+const mirroredSpellFlags = core.SpellFlagMeleeMetrics | core.SpellFlagMechanics | core.SpellFlagIgnoreArmor |
+	core.SpellFlagAoE | core.SpellFlagPassiveSpell
+
+type SpellMirrorConfig struct {
+	// Multiplies the damage of the copy, on top of the multipliers copied from
+	// the Death Knight's spell on each cast. Defaults to 1.
+	DamageCoefficient float64
+
+	// Replaces the effects of the copy, for spells whose effects also refer to
+	// Death Knight state such as costs or self heals. Defaults to the spell's
+	// own effects.
+	ApplyEffects core.ApplySpellResults
+}
+
+type mirroredSpell struct {
+	spell             *core.Spell
+	damageCoefficient float64
+}
+
+// Mirrors the Death Knight's casts onto the Rune Weapon while Dancing Rune
+// Weapon is active. Spells registered with RegisterMirroredSpell get a copy
+// automatically, spells whose effects refer to Death Knight state need a
+// bespoke copy added with AddCopySpell.
+type SpellMirror struct {
+	pending []spellMirrorRegistration
+	spells  map[core.ActionID]*mirroredSpell
+}
+
+type spellMirrorRegistration struct {
+	config core.SpellConfig
+	mirror SpellMirrorConfig
+}
+
+// Registers the spell, and a copy of it on the Rune Weapon if there is one. The
+// copy runs the same effects, so they must only refer to the spell and its unit.
+// Related spells can't be copied, those need a bespoke copy instead.
+func (dk *DeathKnight) RegisterMirroredSpell(config core.SpellConfig, mirror SpellMirrorConfig) *core.Spell {
+	if config.RelatedDotSpell != nil || config.RelatedSelfBuff != nil || config.RelatedAuraArrays != nil {
+		panic(fmt.Sprintf("Mirrored spell %s can't have related spells or auras, add a copy with AddCopySpell instead", config.ActionID))
+	}
+
+	if dk.RuneWeapon != nil {
+		dk.RuneWeapon.Mirror.pending = append(dk.RuneWeapon.Mirror.pending, spellMirrorRegistration{
+			config: config,
+			mirror: mirror,
+		})
+	}
+	return dk.RegisterSpell(config)
+}
+
+// Adds a bespoke copy of the Death Knight's spell with the given action ID.
+func (runeWeapon *RuneWeaponPet) AddCopySpell(actionId core.ActionID, spell *core.Spell) {
+	runeWeapon.Mirror.add(actionId, spell, 1)
+}
+
+func (mirror *SpellMirror) add(actionID core.ActionID, spell *core.Spell, damageCoefficient float64) {
+	if mirror.spells == nil {
+		mirror.spells = make(map[core.ActionID]*mirroredSpell)
+	}
+	mirror.spells[actionID] = &mirroredSpell{
+		spell:             spell,
+		damageCoefficient: core.TernaryFloat64(damageCoefficient == 0, 1, damageCoefficient),
+	}
+}
+
+// Registers the copies of all spells registered with RegisterMirroredSpell.
+func (runeWeapon *RuneWeaponPet) registerMirroredSpells() {
+	for _, registration := range runeWeapon.Mirror.pending {
+		config := registration.config
+		applyEffects := config.ApplyEffects
+		if registration.mirror.ApplyEffects != nil {
+			applyEffects = registration.mirror.ApplyEffects
+		}
+
+		copySpell := runeWeapon.RegisterSpell(core.SpellConfig{
+			ActionID:       config.ActionID,
+			SpellSchool:    config.SpellSchool,
+			ProcMask:       config.ProcMask,
+			Flags:          config.Flags & mirroredSpellFlags,
+			MissileSpeed:   config.MissileSpeed,
+			ClassSpellMask: config.ClassSpellMask,
+
+			MinRange: config.MinRange,
+			MaxRange: config.MaxRange,
+
+			ApplyEffects: applyEffects,
+
+			Dot: config.Dot,
+			Hot: config.Hot,
+		})
+		runeWeapon.Mirror.add(config.ActionID, copySpell, registration.mirror.DamageCoefficient)
+	}
+	runeWeapon.Mirror.pending = nil
+}
+
+// Returns the Rune Weapon's copy of the spell, or nil if it isn't mirrored.
+func (runeWeapon *RuneWeaponPet) MirroredSpell(spell *core.Spell) *core.Spell {
+	if mirrored := runeWeapon.Mirror.spells[spell.ActionID]; mirrored != nil {
+		return mirrored.spell
+	}
+	return nil
+}
+
+// Casts the Rune Weapon's copy of the spell on the target, with the spell's
+// current multipliers.
+func (runeWeapon *RuneWeaponPet) MirrorCast(sim *core.Simulation, spell *core.Spell, target *core.Unit) {
+	mirrored := runeWeapon.Mirror.spells[spell.ActionID]
+	if mirrored == nil {
+		return
+	}
+
+	CopySpellMultipliers(spell, mirrored.spell, target)
+	mirrored.spell.DamageMultiplier *= mirrored.damageCoefficient
+
+	mirrored.spell.Cast(sim, target)
+}
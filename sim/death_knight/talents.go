@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/wowsims/mop/sim/core"
-	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 )
 
@@ -517,7 +516,7 @@ func (dk *DeathKnight) registerDeathSiphon() {
 		},
 	})
 
-	dk.RegisterSpell(core.SpellConfig{
+	dk.RegisterMirroredSpell(core.SpellConfig{
 		ActionID:       DeathSiphonActionID,
 		SpellSchool:    core.SpellSchoolShadowFrost,
 		ProcMask:       core.ProcMaskSpellDamage,
@@ -551,22 +550,8 @@ func (dk *DeathKnight) registerDeathSiphon() {
 
 			spell.DealDamage(sim, result)
 		},
-	})
-
-	if dk.Spec == proto.Spec_SpecBloodDeathKnight {
-		dk.RuneWeapon.AddCopySpell(DeathSiphonActionID, dk.registerDrwDeathSiphon())
-	}
-}
-
-func (dk *DeathKnight) registerDrwDeathSiphon() *core.Spell {
-	return dk.RuneWeapon.RegisterSpell(core.SpellConfig{
-		ActionID:    DeathSiphonActionID,
-		SpellSchool: core.SpellSchoolShadowFrost,
-		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagAPL,
-
-		MaxRange: 40,
-
+	}, SpellMirrorConfig{
+		// The Rune Weapon doesn't heal the Death Knight.
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := dk.CalcAndRollDamageRange(sim, 6.59999990463, 0.15000000596) + 0.37400001287*spell.MeleeAttackPower()
 			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
@@ -755,9 +740,10 @@ func (dk *DeathKnight) registerRunicCorruption() {
 	multi := 2.0
 	// Runic Corruption gives rune regen speed
 	regenAura := core.BlockPrepull(dk.RegisterAura(core.Aura{
-		Label:    "Runic Corruption" + dk.Label,
-		ActionID: core.ActionID{SpellID: 51460},
-		Duration: duration,
+		Label:         "Runic Corruption" + dk.Label,
+		ActionID:      core.ActionID{SpellID: 51460},
+		Duration:      duration,
+		RefreshPolicy: core.RefreshPolicyExtend,
 
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
 			dk.MultiplyRuneRegenSpeed(sim, multi)
@@ -776,17 +762,13 @@ func (dk *DeathKnight) registerRunicCorruption() {
 		ProcChance:     0.45,
 
 		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
-			hasteMultiplier := 1.0 + dk.GetStat(stats.HasteRating)/(100*core.HasteRatingPerHastePercent)
+			hasteMultiplier := core.Ratings.HasteMultiplier(dk.GetStat(stats.HasteRating))
+			runeRegenMultiplier := dk.GetRuneRegenMultiplier()
 			if regenAura.IsActive() {
-				totalMultiplier := 1 / (hasteMultiplier * (dk.GetRuneRegenMultiplier() / multi))
-				hastedDuration := core.DurationFromSeconds(duration.Seconds() * totalMultiplier)
-				regenAura.UpdateExpires(regenAura.ExpiresAt() + hastedDuration)
-			} else {
-				totalMultiplier := 1 / (hasteMultiplier * dk.GetRuneRegenMultiplier())
-				hastedDuration := core.DurationFromSeconds(duration.Seconds() * totalMultiplier)
-				regenAura.Duration = hastedDuration
-				regenAura.Activate(sim)
+				runeRegenMultiplier /= multi
 			}
+			regenAura.Duration = core.DurationFromSeconds(duration.Seconds() / (hasteMultiplier * runeRegenMultiplier))
+			regenAura.Activate(sim)
 		},
 	})
 }
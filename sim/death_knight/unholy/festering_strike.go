@@ -9,10 +9,6 @@ import (
 
 var FesteringStrikeActionID = core.ActionID{SpellID: 85948}
 
-func festeringExtendHandler(aura *core.Aura) {
-	aura.UpdateExpires(aura.ExpiresAt() + time.Second*6)
-}
-
 // An instant attack that deals 200% weapon damage plus 540 and increases the duration of your Blood Plague, Frost Fever, and Chains of Ice effects on the target by up to 6 sec.
 func (uhdk *UnholyDeathKnight) registerFesteringStrike() {
 	uhdk.GetOrRegisterSpell(core.SpellConfig{
@@ -49,15 +45,7 @@ func (uhdk *UnholyDeathKnight) registerFesteringStrike() {
 			spell.SpendRefundableCostAndConvertBloodOrFrostRune(sim, result.Landed())
 
 			if result.Landed() {
-				if uhdk.FrostFeverSpell.Dot(target).IsActive() {
-					festeringExtendHandler(uhdk.FrostFeverSpell.Dot(target).Aura)
-				}
-				if uhdk.BloodPlagueSpell.Dot(target).IsActive() {
-					festeringExtendHandler(uhdk.BloodPlagueSpell.Dot(target).Aura)
-					for _, relatedAura := range uhdk.BloodPlagueSpell.RelatedAuraArrays {
-						festeringExtendHandler(relatedAura.Get(target))
-					}
-				}
+				uhdk.Diseases.ExtendAll(sim, target, time.Second*6)
 			}
 
 			spell.DealDamage(sim, result)
@@ -0,0 +1,64 @@
+package unholy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestFesteringStrikeExtendsDiseases(t *testing.T) {
+	sim := core.NewSim(&proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(&proto.Player{
+			Class:         proto.Class_ClassDeathKnight,
+			Race:          proto.Race_RaceOrc,
+			Equipment:     &proto.EquipmentSpec{},
+			Spec:          PlayerOptionsUnholy,
+			TalentsString: "200010",
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 180,
+			Targets:  []*proto.Target{{Level: 93}},
+		},
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	uhdk := sim.Raid.Parties[0].Players[0].(*UnholyDeathKnight)
+	target := sim.Encounter.ActiveTargetUnits[0]
+	diseases := &uhdk.Diseases
+
+	if diseases.AnyActive(target) {
+		t.Fatalf("Expected no diseases before they are applied")
+	}
+
+	uhdk.BloodPlagueSpell.Cast(sim, target)
+	if count := diseases.CountActive(target); count != 1 {
+		t.Fatalf("Expected 1 active disease, got %d", count)
+	}
+	uhdk.FrostFeverSpell.Cast(sim, target)
+	if count := diseases.CountActive(target); count != 2 {
+		t.Fatalf("Expected 2 active diseases, got %d", count)
+	}
+
+	physVuln := target.GetAuraByID(core.ActionID{SpellID: 81326})
+	if physVuln == nil || !physVuln.IsActive() {
+		t.Fatalf("Expected Blood Plague to apply Physical Vulnerability")
+	}
+
+	bloodPlagueExpires := uhdk.BloodPlagueSpell.Dot(target).ExpiresAt()
+	frostFeverExpires := uhdk.FrostFeverSpell.Dot(target).ExpiresAt()
+	physVulnExpires := physVuln.ExpiresAt()
+
+	diseases.ExtendAll(sim, target, time.Second*6)
+
+	if uhdk.BloodPlagueSpell.Dot(target).ExpiresAt() != bloodPlagueExpires+time.Second*6 ||
+		uhdk.FrostFeverSpell.Dot(target).ExpiresAt() != frostFeverExpires+time.Second*6 {
+		t.Fatalf("Expected both diseases to be extended by 6s")
+	}
+	if physVuln.ExpiresAt() != physVulnExpires+time.Second*6 {
+		t.Fatalf("Expected Physical Vulnerability to be extended with Blood Plague")
+	}
+}
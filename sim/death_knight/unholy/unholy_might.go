@@ -10,5 +10,10 @@ func (uhdk *UnholyDeathKnight) registerUnholyMight() {
 		Label:      "Unholy Might" + uhdk.Label,
 		ActionID:   core.ActionID{SpellID: 91107},
 		BuildPhase: core.CharacterBuildPhaseTalents,
-	})).AttachStatDependency(uhdk.NewDynamicMultiplyStat(stats.Strength, 1.35))
+	})).AttachStatBonus(core.AuraStatBonusConfig{
+		Mode:       core.StatBonusDynamic,
+		SourceStat: stats.Strength,
+		TargetStat: stats.Strength,
+		Ratio:      0.35,
+	})
 }
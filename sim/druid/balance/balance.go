@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/druid"
+	"github.com/wowsims/mop/ui"
 )
 
 const (
@@ -27,6 +28,7 @@ func RegisterBalanceDruid() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecBalanceDruid, core.LoadAPLPresets(ui.APLs, "druid/balance/apls")...)
 }
 
 func NewBalanceDruid(character *core.Character, options *proto.Player) *BalanceDruid {
@@ -59,6 +59,9 @@ type Druid struct {
 	CatForm  *DruidSpell
 	BearForm *DruidSpell
 
+	catStance  *core.StanceConfig
+	bearStance *core.StanceConfig
+
 	BarkskinAura             *core.Aura
 	BearFormAura             *core.Aura
 	BerserkBearAura          *core.Aura
@@ -72,11 +75,12 @@ type Druid struct {
 	ProwlAura                *core.Aura
 	SurvivalInstinctsAura    *core.Aura
 
-	form         DruidForm
-
 	// Guardian leather specialization is form-specific
 	GuardianLeatherSpecTracker *core.Aura
 	GuardianLeatherSpecDep     *stats.StatDependency
+
+	// Below 25% boss health, where Ferocious Bite refreshes Rip
+	BiteExecutePhase *core.ExecutePhaseTracker
 }
 
 const (
@@ -169,37 +173,15 @@ func (druid *Druid) HasMinorGlyph(glyph proto.DruidMinorGlyph) bool {
 }
 
 func (druid *Druid) RegisterSpell(formMask DruidForm, config core.SpellConfig) *DruidSpell {
-	prev := config.ExtraCastCondition
-	prevModify := config.Cast.ModifyCast
-
-	ds := &DruidSpell{FormMask: formMask}
-	config.ExtraCastCondition = func(sim *core.Simulation, target *core.Unit) bool {
-		// Check if we're in allowed form to cast
-		// Allow 'humanoid' auto unshift casts
-		if (ds.FormMask != Any && !druid.InForm(ds.FormMask)) && !ds.FormMask.Matches(Humanoid) {
-			if sim.Log != nil {
-				sim.Log("Failed cast to spell %s, wrong form", ds.ActionID)
-			}
-			return false
-		}
-		return prev == nil || prev(sim, target)
-	}
-	config.Cast.ModifyCast = func(sim *core.Simulation, s *core.Spell, c *core.Cast) {
-		if !druid.InForm(ds.FormMask) && ds.FormMask.Matches(Humanoid) {
-			druid.ClearForm(sim)
-		}
-		if prevModify != nil {
-			prevModify(sim, s, c)
-		}
-	}
+	config.RequiredStance = formMask
+	config.AutoSwitchStance = true
 
-	ds.Spell = druid.Unit.RegisterSpell(config)
-
-	return ds
+	return &DruidSpell{Spell: druid.Unit.RegisterSpell(config)}
 }
 
 func (druid *Druid) Initialize() {
-	druid.form = druid.StartingForm
+	druid.SetStance(druid.StartingForm)
+	druid.registerForms()
 
 	druid.Env.RegisterPostFinalizeEffect(func() {
 		druid.MHAutoSpell = druid.AutoAttacks.MHAuto()
@@ -276,7 +258,7 @@ func (druid *Druid) RegisterFeralTankSpells() {
 }
 
 func (druid *Druid) Reset(_ *core.Simulation) {
-	druid.form = druid.StartingForm
+	druid.SetStance(druid.StartingForm)
 
 	for target := range druid.BleedsActive {
 		druid.BleedsActive[target] = 0
@@ -292,13 +274,14 @@ func New(char *core.Character, form DruidForm, selfBuffs SelfBuffs, talents stri
 		SelfBuffs:         selfBuffs,
 		Talents:           &proto.DruidTalents{},
 		StartingForm:      form,
-		form:              form,
 		ClassSpellScaling: core.GetClassSpellScalingCoefficient(proto.Class_ClassDruid),
 		BleedsActive:      make(map[*core.Unit]int32),
 	}
 
 	core.FillTalentsProto(druid.Talents.ProtoReflect(), talents)
 	druid.EnableManaBar()
+	druid.SetDefaultStance(form)
+	druid.SetStance(form)
 
 	druid.AddStatDependency(stats.Strength, stats.AttackPower, 1)
 	druid.AddStatDependency(stats.BonusArmor, stats.Armor, 1)
@@ -318,12 +301,9 @@ func New(char *core.Character, form DruidForm, selfBuffs SelfBuffs, talents stri
 
 type DruidSpell struct {
 	*core.Spell
-	FormMask DruidForm
 
-	// Optional fields used in snapshotting calculations
-	CurrentSnapshotPower float64
-	NewSnapshotPower     float64
-	ShortName            string
+	// Optional name used in rotation logs
+	ShortName string
 }
 
 func (ds *DruidSpell) IsReady(sim *core.Simulation) bool {
@@ -347,26 +327,6 @@ func (ds *DruidSpell) IsEqual(s *core.Spell) bool {
 	return ds.Spell == s
 }
 
-func (druid *Druid) UpdateBleedPower(bleedSpell *DruidSpell, sim *core.Simulation, target *core.Unit, updateCurrent bool, updateNew bool) {
-	snapshotPower := bleedSpell.ExpectedTickDamage(sim, target)
-
-	if updateCurrent {
-		bleedSpell.CurrentSnapshotPower = snapshotPower
-
-		if sim.Log != nil {
-			druid.Log(sim, "%s Snapshot Power: %.1f", bleedSpell.ShortName, snapshotPower)
-		}
-	}
-
-	if updateNew {
-		bleedSpell.NewSnapshotPower = snapshotPower
-
-		if (sim.Log != nil) && !updateCurrent {
-			druid.Log(sim, "%s Projected Power: %.1f", bleedSpell.ShortName, snapshotPower)
-		}
-	}
-}
-
 // Agent is a generic way to access underlying druid on any of the agents (for example balance druid.)
 type DruidAgent interface {
 	GetDruid() *Druid
@@ -0,0 +1,67 @@
+package feral
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Decides whether a Bite now still leaves enough time and Energy to build 5
+// combo points and pay for the next Rip or Roar refresh, and for any Rake
+// refresh due before it. Replaces the static bite times when optimize_bite is
+// set. Energy income counts regeneration over the window, plus Tiger's Fury if
+// it comes off cooldown in time, and costs are halved if Berserk will be up
+// when the refresh is due.
+func (rotation *FeralDruidRotation) lookaheadBite(sim *core.Simulation, ripRefreshTime time.Duration, roarRefreshTime time.Duration, rakeRefreshTime time.Duration) bool {
+	cat := rotation.agent
+	deadline := min(ripRefreshTime, roarRefreshTime)
+	window := deadline - sim.CurrentTime
+	if window <= 0 {
+		return false
+	}
+
+	// Bite spends up to 25 Energy on top of its cost.
+	curEnergy := cat.CurrentEnergy()
+	biteCost := cat.CurrentFerociousBiteCost()
+	energyAfterBite := curEnergy - biteCost - min(max(curEnergy-biteCost, 0), 25)
+
+	tfInWindow := cat.tfExpectedBefore(sim, deadline-cat.ReactionTime)
+	income := cat.EnergyRegenPerSecond() * window.Seconds()
+	if tfInWindow {
+		income += 60
+	}
+
+	berserkAtDeadline := (cat.BerserkCatAura.IsActive() && (cat.BerserkCatAura.ExpiresAt() >= deadline)) ||
+		(rotation.UseBerserk && (cat.Berserk.ReadyAt()+cat.BerserkCatAura.Duration >= deadline) && (cat.Berserk.ReadyAt() < deadline))
+	costMultiplier := core.TernaryFloat64(berserkAtDeadline, 0.5, 1)
+
+	// Primal Fury adds a combo point on builder crits.
+	builder := core.Ternary(rotation.ForceMangleFiller, cat.MangleCat, cat.Shred)
+	attackTable := cat.AttackTables[cat.CurrentTarget.UnitIndex]
+	cpPerBuilder := 1 + min(builder.PhysicalCritChance(attackTable), 1)
+	numBuilders := int32(math.Ceil(5 / cpPerBuilder))
+
+	refreshSpell := core.Ternary(ripRefreshTime <= roarRefreshTime, cat.Rip, cat.SavageRoar)
+	spentBeforeRefresh := float64(numBuilders) * builder.DefaultCast.Cost * costMultiplier
+	if rakeRefreshTime < deadline {
+		spentBeforeRefresh += cat.Rake.DefaultCast.Cost * costMultiplier
+	}
+	refreshCost := refreshSpell.DefaultCast.Cost * costMultiplier
+
+	// Energy left for the refresh once the builders are paid for, which can't
+	// pool beyond a full bar.
+	energyAtRefresh := min(energyAfterBite+income-spentBeforeRefresh, cat.MaximumEnergy())
+
+	// The Bite and each builder take a GCD before the refresh can start.
+	timeNeeded := builder.DefaultCast.GCD * time.Duration(numBuilders+1)
+
+	biteNow := (timeNeeded <= window) && (energyAtRefresh >= refreshCost)
+
+	if sim.Log != nil {
+		cat.Log(sim, "Bite lookahead: %s until %s refresh, %.1f Energy after Bite + %.1f income (Tiger's Fury: %t, Berserk: %t) - %.1f for %d builders leaves %.1f vs %.1f needed, %s of GCDs: bite = %t",
+			window, refreshSpell.ActionID, energyAfterBite, income, tfInWindow, berserkAtDeadline, spentBeforeRefresh, numBuilders, energyAtRefresh, refreshCost, timeNeeded, biteNow)
+	}
+
+	return biteNow
+}
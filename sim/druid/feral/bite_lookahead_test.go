@@ -0,0 +1,72 @@
+package feral
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func setupBiteLookahead(t *testing.T) (*core.Simulation, *FeralDruidRotation) {
+	sim := core.NewSim(&proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(&proto.Player{
+			Class:         proto.Class_ClassDruid,
+			Race:          proto.Race_RaceWorgen,
+			Equipment:     &proto.EquipmentSpec{},
+			Spec:          PlayerOptionsMonoCat,
+			TalentsString: StandardTalents,
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 180,
+			Targets:  []*proto.Target{{Level: 93}},
+		},
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	cat := sim.Raid.Parties[0].Players[0].(*FeralDruid)
+	rotation := cat.newActionCatOptimalRotationAction(&proto.APLActionCatOptimalRotationAction{}).(*FeralDruidRotation)
+	rotation.UseBerserk = false
+
+	// Leave Tiger's Fury out of the Energy income.
+	cat.TigersFury.CD.Set(sim.CurrentTime + time.Minute)
+
+	if cat.CurrentEnergy() != cat.MaximumEnergy() {
+		t.Fatalf("Expected to start with full Energy, got %0.1f", cat.CurrentEnergy())
+	}
+	return sim, rotation
+}
+
+func TestBiteLookaheadBitesWithTimeToRefresh(t *testing.T) {
+	sim, rotation := setupBiteLookahead(t)
+
+	// 50 Energy after the Bite and 200 regenerated pay for the builders, with
+	// Energy to spare for the Rip.
+	if !rotation.lookaheadBite(sim, sim.CurrentTime+time.Second*20, sim.CurrentTime+time.Second*30, core.NeverExpires) {
+		t.Fatalf("Expected to Bite 20s before the next Rip refresh")
+	}
+}
+
+func TestBiteLookaheadHoldsBiteBeforeRefresh(t *testing.T) {
+	sim, rotation := setupBiteLookahead(t)
+
+	// Not enough GCDs to build 5 combo points.
+	if rotation.lookaheadBite(sim, sim.CurrentTime+time.Second*4, sim.CurrentTime+time.Second*30, core.NeverExpires) {
+		t.Fatalf("Expected no Bite 4s before the next Rip refresh")
+	}
+
+	// Enough GCDs, but not enough Energy for the builders and the Rip.
+	if rotation.lookaheadBite(sim, sim.CurrentTime+time.Second*30, sim.CurrentTime+time.Second*8, core.NeverExpires) {
+		t.Fatalf("Expected no Bite 8s before the next Savage Roar refresh")
+	}
+
+	// A Rake refresh before the Rip adds to the Energy needed.
+	if !rotation.lookaheadBite(sim, sim.CurrentTime+time.Second*19, sim.CurrentTime+time.Second*30, core.NeverExpires) {
+		t.Fatalf("Expected to Bite 19s before the next Rip refresh")
+	}
+	if rotation.lookaheadBite(sim, sim.CurrentTime+time.Second*19, sim.CurrentTime+time.Second*30, sim.CurrentTime+time.Second*10) {
+		t.Fatalf("Expected no Bite with a Rake refresh due before the Rip")
+	}
+}
@@ -0,0 +1,34 @@
+package feral
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+// Mirrors a bleed on each target onto the Druid, so that multi-target fights
+// report the bleed's uptime per target in the Druid's own aura metrics. The
+// target index is used as the tag.
+func (cat *FeralDruid) registerBleedUptimeMetrics(bleedSpell *druid.DruidSpell, bleedName string) {
+	targets := cat.Env.Encounter.AllTargetUnits
+	if len(targets) < 2 {
+		return
+	}
+
+	for _, target := range targets {
+		uptimeAura := cat.RegisterAura(core.Aura{
+			Label:    fmt.Sprintf("%s Uptime - %s", bleedName, target.Label),
+			ActionID: bleedSpell.ActionID.WithTag(target.UnitIndex + 1),
+			Duration: core.NeverExpires,
+		})
+
+		bleedDot := bleedSpell.Dot(target)
+		bleedDot.ApplyOnGain(func(_ *core.Aura, sim *core.Simulation) {
+			uptimeAura.Activate(sim)
+		})
+		bleedDot.ApplyOnExpire(func(_ *core.Aura, sim *core.Simulation) {
+			uptimeAura.Deactivate(sim)
+		})
+	}
+}
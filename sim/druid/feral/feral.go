@@ -7,6 +7,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/druid"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterFeralDruid() {
@@ -24,6 +25,7 @@ func RegisterFeralDruid() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecFeralDruid, core.LoadAPLPresets(ui.APLs, "druid/feral/apls")...)
 }
 
 func NewFeralDruid(character *core.Character, options *proto.Player) *FeralDruid {
@@ -100,14 +102,17 @@ func (cat *FeralDruid) Initialize() {
 	cat.ApplyNurturingInstinct()
 	cat.applyOmenOfClarity()
 	cat.applyPredatorySwiftness()
+	cat.registerBleedUptimeMetrics(cat.Rake, "Rake")
+	cat.registerBleedUptimeMetrics(cat.Rip, "Rip")
 
 	snapshotHandler := func(aura *core.Aura, sim *core.Simulation) {
-		previousRipSnapshotPower := cat.Rip.NewSnapshotPower
-		cat.UpdateBleedPower(cat.Rip, sim, cat.CurrentTarget, false, true)
-		cat.UpdateBleedPower(cat.Rake, sim, cat.CurrentTarget, false, true)
-		cat.UpdateBleedPower(cat.ThrashCat, sim, cat.CurrentTarget, false, true)
+		ripTracker := cat.Rip.CurDot().SnapshotTracker
+		previousRipSnapshotPower := ripTracker.NewSnapshotPower()
+		ripTracker.UpdateNewSnapshotPower(sim)
+		cat.Rake.CurDot().SnapshotTracker.UpdateNewSnapshotPower(sim)
+		cat.ThrashCat.CurDot().SnapshotTracker.UpdateNewSnapshotPower(sim)
 
-		if cat.Rip.NewSnapshotPower > previousRipSnapshotPower+0.001 {
+		if ripTracker.NewSnapshotPower() > previousRipSnapshotPower+0.001 {
 			if !cat.tempSnapshotAura.IsActive() || (aura.ExpiresAt() < cat.tempSnapshotAura.ExpiresAt()) {
 				cat.tempSnapshotAura = aura
 
@@ -162,10 +167,5 @@ func (cat *FeralDruid) Reset(sim *core.Simulation) {
 	cat.Druid.ClearForm(sim)
 	cat.CatFormAura.Activate(sim)
 
-	// Reset snapshot power values until first cast
-	cat.Rip.CurrentSnapshotPower = 0
-	cat.Rip.NewSnapshotPower = 0
-	cat.Rake.CurrentSnapshotPower = 0
-	cat.Rake.NewSnapshotPower = 0
 	cat.tempSnapshotAura = nil
 }
@@ -32,16 +32,19 @@ func TestFeral(t *testing.T) {
 		OtherRaces: []proto.Race{proto.Race_RaceTroll},
 		GearSet:    core.GetGearSet("../../../ui/druid/feral/gear_sets", "preraid"),
 
-		Talents:         StandardTalents,
-		Glyphs:          StandardGlyphs,
+		Talents: StandardTalents,
+		Glyphs:  StandardGlyphs,
 		OtherTalentSets: []core.TalentsCombo{
 			{Label: "SotF-HotW", Talents: "000101", Glyphs: StandardGlyphs},
 			{Label: "Incarn-NV", Talents: "000203", Glyphs: StandardGlyphs},
 		},
 
-		Consumables:      FullConsumesSpec,
-		SpecOptions:      core.SpecOptionsCombo{Label: "ExternalBleed", SpecOptions: PlayerOptionsMonoCat},
-		Rotation:         core.GetAplRotation("../../../ui/druid/feral/apls", "default"),
+		Consumables: FullConsumesSpec,
+		SpecOptions: core.SpecOptionsCombo{Label: "ExternalBleed", SpecOptions: PlayerOptionsMonoCat},
+		Rotation:    core.GetAplRotation("../../../ui/druid/feral/apls", "default"),
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/druid/feral/apls", "aoe"),
+		},
 		StartingDistance: 24,
 		ItemFilter:       FeralItemFilter,
 	}}))
@@ -142,8 +145,8 @@ var PlayerOptionsMonoCatNoBleed = &proto.Player_FeralDruid{
 // }
 
 var FullConsumesSpec = &proto.ConsumesSpec{
-	FlaskId:     76084, // Flask of Spring Blossoms
-	FoodId:      74648, // Sea Mist Rice Noodles
-	PotId:       76089, // Virmen's Bite
-	PrepotId:    76089, // Virmen's Bite
+	FlaskId:  76084, // Flask of Spring Blossoms
+	FoodId:   74648, // Sea Mist Rice Noodles
+	PotId:    76089, // Virmen's Bite
+	PrepotId: 76089, // Virmen's Bite
 }
@@ -16,13 +16,13 @@ func (cat *FeralDruid) applyPredatorySwiftness() {
 		OnGain: func(_ *core.Aura, _ *core.Simulation) {
 			cat.HealingTouch.CastTimeMultiplier -= 1
 			cat.HealingTouch.Cost.PercentModifier *= -1
-			cat.HealingTouch.FormMask |= druid.Cat
+			cat.HealingTouch.RequiredStance |= druid.Cat
 		},
 
 		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
 			cat.HealingTouch.CastTimeMultiplier += 1
 			cat.HealingTouch.Cost.PercentModifier /= -1
-			cat.HealingTouch.FormMask ^= druid.Cat
+			cat.HealingTouch.RequiredStance ^= druid.Cat
 		},
 
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
@@ -29,16 +29,21 @@ func (cat *FeralDruid) newActionCatOptimalRotationAction(config *proto.APLAction
 	rotation.UseHealingTouch = cat.Talents.DreamOfCenarius
 
 	if rotation.ManualParams {
+		rotation.UseBite = config.UseBite
 		rotation.BiteTime = core.DurationFromSeconds(config.BiteTime)
 		rotation.BerserkBiteTime = core.DurationFromSeconds(config.BerserkBiteTime)
 		rotation.MinRoarOffset = core.DurationFromSeconds(config.MinRoarOffset)
 		rotation.RipLeeway = core.DurationFromSeconds(config.RipLeeway)
+		rotation.MaxRakeTargets = config.MaxRakeTargets
+		rotation.RakeTargetThreshold = config.RakeTargetThreshold
 	} else {
 		rotation.UseBite = true
 		rotation.BiteTime = core.TernaryDuration(rotation.UseHealingTouch, time.Second * 9, time.Second * 12)
 		rotation.BerserkBiteTime = time.Second * 7
 		rotation.MinRoarOffset = time.Second * 40
 		rotation.RipLeeway = core.TernaryDuration(rotation.UseHealingTouch, time.Second * 2, time.Second * 6)
+		rotation.MaxRakeTargets = 3
+		rotation.RakeTargetThreshold = 5
 	}
 
 	// Pre-allocate PoolingActions
@@ -56,14 +61,17 @@ func (cat *FeralDruid) newActionCatOptimalRotationAction(config *proto.APLAction
 type FeralDruidRotation struct {
 	*proto.APLActionCatOptimalRotationAction
 
-	// Overwritten parameters
-	BiteTime          time.Duration
-	BerserkBiteTime   time.Duration
-	MinRoarOffset     time.Duration
-	RipLeeway         time.Duration
-	ForceMangleFiller bool
-	UseBerserk        bool
-	UseHealingTouch   bool
+	// Overwritten parameters, shadowing the request's so it isn't modified
+	UseBite             bool
+	BiteTime            time.Duration
+	BerserkBiteTime     time.Duration
+	MinRoarOffset       time.Duration
+	RipLeeway           time.Duration
+	MaxRakeTargets      int32
+	RakeTargetThreshold int32
+	ForceMangleFiller   bool
+	UseBerserk          bool
+	UseHealingTouch     bool
 
 	// Bookkeeping fields
 	agent             *FeralDruid
@@ -152,7 +160,7 @@ func (rotation *FeralDruidRotation) Execute(sim *core.Simulation) {
 	} else if rotation.RotationType == proto.FeralDruid_Rotation_SingleTarget {
 		rotation.PickSingleTargetGCDAction(sim)
 	} else {
-		panic("AoE rotation not yet supported!")
+		rotation.PickAoeGCDAction(sim)
 	}
 }
 
@@ -162,7 +170,7 @@ func (rotation *FeralDruidRotation) PickSingleTargetGCDAction(sim *core.Simulati
 	curEnergy := cat.CurrentEnergy()
 	curCp := cat.ComboPoints()
 	regenRate := cat.EnergyRegenPerSecond()
-	isExecutePhase := sim.IsExecutePhase25()
+	isExecutePhase := cat.BiteExecutePhase.IsActive()
 	isClearcast := cat.ClearcastingAura.IsActive()
 	isBerserk := cat.BerserkCatAura.IsActive()
 	anyBleedActive := cat.AssumeBleedActive || (cat.BleedsActive[cat.CurrentTarget] > 0)
@@ -177,21 +185,31 @@ func (rotation *FeralDruidRotation) PickSingleTargetGCDAction(sim *core.Simulati
 
 	// Rip logic
 	ripRefreshTime := cat.calcBleedRefreshTime(sim, cat.Rip, ripDot, isExecutePhase, true)
-	ripNow := (curCp >= 5) && (!ripDot.IsActive() || ((sim.CurrentTime > ripRefreshTime) && (!isExecutePhase || (cat.Rip.NewSnapshotPower > cat.Rip.CurrentSnapshotPower + 0.001))) || (!isExecutePhase && (roarDur < rotation.RipLeeway) && (ripDot.ExpiresAt() < roarBuff.ExpiresAt() + rotation.RipLeeway))) && (fightDur > ripDot.BaseTickLength) && (!isClearcast || !anyBleedActive || cat.DreamOfCenariusAura.IsActive()) && !cat.shouldDelayBleedRefreshForTf(sim, ripDot, true)
+	ripNow := (curCp >= 5) && (!ripDot.IsActive() || ((sim.CurrentTime > ripRefreshTime) && (!isExecutePhase || (ripDot.SnapshotTracker.RecastGain() > 0.001))) || (!isExecutePhase && (roarDur < rotation.RipLeeway) && (ripDot.ExpiresAt() < roarBuff.ExpiresAt() + rotation.RipLeeway))) && (fightDur > ripDot.BaseTickLength) && (!isClearcast || !anyBleedActive || cat.DreamOfCenariusAura.IsActive()) && !cat.shouldDelayBleedRefreshForTf(sim, ripDot, true)
 
 	// Roar logic
 	newRoarDur := cat.SavageRoarDurationTable[curCp]
 	roarRefreshTime := cat.calcRoarRefreshTime(sim, ripRefreshTime, rotation.RipLeeway, rotation.MinRoarOffset)
 	roarNow := (newRoarDur > 0) && (!roarBuff.IsActive() || (sim.CurrentTime > roarRefreshTime))
 
+	// Rake logic
+	rakeRefreshTime := cat.calcBleedRefreshTime(sim, cat.Rake, rakeDot, isExecutePhase, false)
+
 	// Bite logic
-	biteTime := core.TernaryDuration(isBerserk, rotation.BerserkBiteTime, rotation.BiteTime)
-	shouldBite := (curCp >= 5) && ripDot.IsActive() && roarBuff.IsActive() && ((rotation.UseBite && (min(ripRefreshTime, roarRefreshTime) - sim.CurrentTime >= biteTime)) || isExecutePhase) && !isClearcast
+	biteCandidate := (curCp >= 5) && ripDot.IsActive() && roarBuff.IsActive() && !isClearcast
+	var biteWindowOk bool
+	if rotation.UseBite && biteCandidate && !isExecutePhase {
+		if rotation.OptimizeBite {
+			biteWindowOk = rotation.lookaheadBite(sim, ripRefreshTime, roarRefreshTime, rakeRefreshTime)
+		} else {
+			biteTime := core.TernaryDuration(isBerserk, rotation.BerserkBiteTime, rotation.BiteTime)
+			biteWindowOk = min(ripRefreshTime, roarRefreshTime) - sim.CurrentTime >= biteTime
+		}
+	}
+	shouldBite := biteCandidate && (biteWindowOk || isExecutePhase)
 	shouldEmergencyBite := isExecutePhase && ripDot.IsActive() && (ripDur < ripDot.BaseTickLength) && (curCp >= 1)
 	biteNow := shouldBite || shouldEmergencyBite
 
-	// Rake logic
-	rakeRefreshTime := cat.calcBleedRefreshTime(sim, cat.Rake, rakeDot, isExecutePhase, false)
 	rakeNow := (!rakeDot.IsActive() || (sim.CurrentTime > rakeRefreshTime)) && (fightDur > rakeDot.BaseTickLength) && (!isClearcast || !rakeDot.IsActive() || (rakeDur < time.Second) || cat.DreamOfCenariusAura.IsActive()) && !cat.shouldDelayBleedRefreshForTf(sim, rakeDot, false) && roarBuff.IsActive()
 
 	// Pooling calcs
@@ -313,7 +331,7 @@ func (rotation *FeralDruidRotation) PickSingleTargetGCDAction(sim *core.Simulati
 		fillerDpc := fillerSpell.ExpectedInitialDamage(sim, cat.CurrentTarget)
 		rakeDpc := cat.Rake.ExpectedInitialDamage(sim, cat.CurrentTarget)
 
-		if ((fillerDpc < rakeDpc) || (!isBerserk && !isClearcast && (fillerDpc / fillerSpell.DefaultCast.Cost < rakeDpc / cat.Rake.DefaultCast.Cost))) && (cat.Rake.NewSnapshotPower > cat.Rake.CurrentSnapshotPower - 0.001) {
+		if ((fillerDpc < rakeDpc) || (!isBerserk && !isClearcast && (fillerDpc / fillerSpell.DefaultCast.Cost < rakeDpc / cat.Rake.DefaultCast.Cost))) && (cat.Rake.CurDot().SnapshotTracker.RecastGain() > -0.001) {
 			fillerSpell = cat.Rake
 		}
 
@@ -0,0 +1,154 @@
+package feral
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Targets to maintain Rake on, which is none once there are more active
+// targets than the threshold and Swipe alone is better.
+func (rotation *FeralDruidRotation) rakeTargets(sim *core.Simulation) []*core.Unit {
+	targets := sim.Encounter.ActiveTargetUnits
+	if int32(len(targets)) > rotation.RakeTargetThreshold {
+		return nil
+	}
+	return targets[:min(int32(len(targets)), rotation.MaxRakeTargets)]
+}
+
+func (cat *FeralDruid) calcExpectedSwipeDamage(sim *core.Simulation) (float64, float64) {
+	expectedSwipeDamage := 0.0
+	for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
+		expectedSwipeDamage += cat.SwipeCat.ExpectedInitialDamage(sim, aoeTarget)
+	}
+	swipeDPE := expectedSwipeDamage / cat.SwipeCat.DefaultCast.Cost
+	return expectedSwipeDamage, swipeDPE
+}
+
+func (rotation *FeralDruidRotation) PickAoeGCDAction(sim *core.Simulation) {
+	// Store state variables for re-use
+	cat := rotation.agent
+	curEnergy := cat.CurrentEnergy()
+	curCp := cat.ComboPoints()
+	regenRate := cat.EnergyRegenPerSecond()
+	isClearcast := cat.ClearcastingAura.IsActive()
+	fightDur := sim.GetRemainingDuration()
+	ripDot := cat.Rip.CurDot()
+	roarBuff := cat.SavageRoarBuff
+	thrashDot := cat.ThrashCat.CurDot()
+
+	// Roar logic
+	roarNow := (curCp >= 1) && !roarBuff.IsActive()
+
+	// Rip logic, which is only maintained on the primary target.
+	ripRefreshTime := cat.calcBleedRefreshTime(sim, cat.Rip, ripDot, false, true)
+	ripNow := (curCp >= 5) && (!ripDot.IsActive() || (sim.CurrentTime > ripRefreshTime)) && (fightDur > ripDot.BaseTickLength*2) && !isClearcast && !cat.shouldDelayBleedRefreshForTf(sim, ripDot, true)
+
+	// Thrash logic
+	thrashNow := (!thrashDot.IsActive() || (thrashDot.RemainingDuration(sim) < thrashDot.BaseTickLength)) && (fightDur > thrashDot.BaseTickLength)
+
+	// Rake logic, with a separate refresh time for each target.
+	rotation.pendingPool.reset()
+	var rakeTarget *core.Unit
+	rakeTargetRefreshTime := core.NeverExpires
+
+	for _, aoeTarget := range rotation.rakeTargets(sim) {
+		rakeDot := cat.Rake.Dot(aoeTarget)
+		rakeRefreshTime := cat.calcBleedRefreshTime(sim, cat.Rake, rakeDot, false, false)
+		rakeDue := (!rakeDot.IsActive() || (sim.CurrentTime > rakeRefreshTime)) && (!isClearcast || !rakeDot.IsActive() || (rakeDot.RemainingDuration(sim) < time.Second)) && !cat.shouldDelayBleedRefreshForTf(sim, rakeDot, false)
+
+		if rakeDue && (rakeRefreshTime < rakeTargetRefreshTime) {
+			rakeTarget = aoeTarget
+			rakeTargetRefreshTime = rakeRefreshTime
+		} else if rakeDot.IsActive() && (sim.CurrentTime < rakeRefreshTime) && (rakeDot.RemainingDuration(sim) < fightDur-rakeDot.BaseTickLength) {
+			rotation.pendingPool.addAction(rakeRefreshTime, cat.Rake.DefaultCast.Cost)
+		}
+	}
+
+	rakeNow := (rakeTarget != nil) && (fightDur > cat.Rake.Dot(rakeTarget).BaseTickLength)
+
+	if rakeNow && !isClearcast {
+		// Compare DPE versus Swipe to see if it's worth casting
+		rakeDot := cat.Rake.Dot(rakeTarget)
+		potentialRakeTicks := min(rakeDot.BaseTickCount, int32(fightDur/rakeDot.BaseTickLength))
+		expectedRakeDamage := cat.Rake.ExpectedInitialDamage(sim, rakeTarget) + cat.Rake.ExpectedTickDamage(sim, rakeTarget)*float64(potentialRakeTicks)
+		rakeDPE := expectedRakeDamage / cat.Rake.DefaultCast.Cost
+		_, swipeDPE := cat.calcExpectedSwipeDamage(sim)
+
+		if sim.Log != nil {
+			cat.Log(sim, "Rake DPE on %s = %.1f, Swipe DPE = %.1f", rakeTarget.Label, rakeDPE, swipeDPE)
+		}
+
+		rakeNow = rakeDPE > swipeDPE
+	}
+
+	ripRefreshPending := ripDot.IsActive() && (ripDot.RemainingDuration(sim) < fightDur-ripDot.BaseTickLength) && (curCp >= 5)
+	if ripRefreshPending && (sim.CurrentTime < ripRefreshTime) {
+		rotation.pendingPool.addAction(ripRefreshTime, cat.Rip.DefaultCast.Cost)
+	}
+
+	rotation.pendingPool.sort()
+	floatingEnergy := rotation.pendingPool.calcFloatingEnergy(cat, sim)
+	excessE := curEnergy - floatingEnergy
+
+	// Main decision tree starts here.
+	var timeToNextAction time.Duration
+
+	if !cat.CatFormAura.IsActive() {
+		// The AoE rotation doesn't weave, so head straight back to Cat Form.
+		rotation.readyToShift = true
+	} else if roarNow {
+		if cat.SavageRoar.CanCast(sim, cat.CurrentTarget) {
+			cat.SavageRoar.Cast(sim, nil)
+			return
+		}
+
+		timeToNextAction = core.DurationFromSeconds((cat.CurrentSavageRoarCost() - curEnergy) / regenRate)
+	} else if ripNow {
+		if cat.Rip.CanCast(sim, cat.CurrentTarget) {
+			cat.Rip.Cast(sim, cat.CurrentTarget)
+			return
+		}
+
+		timeToNextAction = core.DurationFromSeconds((cat.CurrentRipCost() - curEnergy) / regenRate)
+	} else if thrashNow {
+		if cat.ThrashCat.CanCast(sim, cat.CurrentTarget) {
+			cat.ThrashCat.Cast(sim, cat.CurrentTarget)
+			return
+		}
+
+		timeToNextAction = core.DurationFromSeconds((cat.ThrashCat.Cost.GetCurrentCost() - curEnergy) / regenRate)
+	} else if rakeNow {
+		if cat.Rake.CanCast(sim, rakeTarget) {
+			cat.Rake.Cast(sim, rakeTarget)
+			return
+		}
+
+		timeToNextAction = core.DurationFromSeconds((cat.CurrentRakeCost() - curEnergy) / regenRate)
+	} else {
+		swipeCost := cat.CurrentSwipeCatCost()
+		energyForCalc := core.TernaryFloat64(isClearcast || cat.BerserkCatAura.IsActive(), curEnergy, excessE)
+
+		if (energyForCalc >= swipeCost) || (curEnergy > cat.MaximumEnergy()-regenRate*cat.ReactionTime.Seconds()) {
+			if cat.SwipeCat.CanCast(sim, cat.CurrentTarget) {
+				cat.SwipeCat.Cast(sim, cat.CurrentTarget)
+				return
+			}
+		}
+
+		timeToNextAction = core.DurationFromSeconds((swipeCost - energyForCalc) / regenRate)
+	}
+
+	// Schedule next action based on any upcoming timers
+	nextActionAt := sim.CurrentTime + max(timeToNextAction, 0)
+
+	if roarBuff.IsActive() && (roarBuff.RemainingDuration(sim) < fightDur-cat.ReactionTime) && (curCp >= 1) {
+		nextActionAt = min(nextActionAt, roarBuff.ExpiresAt())
+	}
+
+	if isPooling, nextRefresh := rotation.pendingPool.nextRefreshTime(); isPooling {
+		nextActionAt = min(nextActionAt, nextRefresh)
+	}
+
+	rotation.ProcessNextPlannedAction(sim, nextActionAt)
+}
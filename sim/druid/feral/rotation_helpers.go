@@ -54,7 +54,7 @@ func (rotation *FeralDruidRotation) TryBerserk(sim *core.Simulation) {
 	waitForTf := !cat.TigersFuryAura.IsActive() && (tfCdRemain + cat.ReactionTime < simTimeRemain - cat.BerserkCatAura.Duration)
 	berserkNow := rotation.UseBerserk && cat.Berserk.IsReady(sim) && !waitForTf && !cat.ClearcastingAura.IsActive() && (cat.CurrentEnergy() > 60)
 
-	if berserkNow && (simTimeRemain / cat.Berserk.CD.Duration == 0) && !sim.IsExecutePhase25() {
+	if berserkNow && (simTimeRemain / cat.Berserk.CD.Duration == 0) && !cat.BiteExecutePhase.IsActive() {
 		projectedExecuteStart := core.DurationFromSeconds((1.0 - sim.Encounter.ExecuteProportion_25) * sim.Duration.Seconds())
 
 		if (sim.CurrentTime + tfCdRemain < projectedExecuteStart) && (tfCdRemain + cat.ReactionTime < simTimeRemain - cat.BerserkCatAura.Duration) {
@@ -108,7 +108,7 @@ func (cat *FeralDruid) calcBleedRefreshTime(sim *core.Simulation, bleedSpell *dr
 	}
 
 	// DoC takes priority over other logic.
-	if cat.DreamOfCenariusAura.IsActive() && (bleedSpell.NewSnapshotPower > bleedSpell.CurrentSnapshotPower + 0.001) {
+	if cat.DreamOfCenariusAura.IsActive() && (bleedDot.SnapshotTracker.RecastGain() > 0.001) {
 		return sim.CurrentTime - cat.ReactionTime
 	}
 
@@ -152,11 +152,12 @@ func (cat *FeralDruid) calcBleedRefreshTime(sim *core.Simulation, bleedSpell *dr
 	buffedTickCount := min(maxTickCount, int32((sim.Duration-targetClipTime)/bleedDot.BaseTickLength))
 
 	// Perform a DPE comparison vs. Shred
-	expectedDamageGain := (bleedSpell.NewSnapshotPower - bleedSpell.CurrentSnapshotPower) * float64(buffedTickCount)
+	snapshotTracker := bleedDot.SnapshotTracker
+	expectedDamageGain := snapshotTracker.RecastGain() * float64(buffedTickCount)
 
 	// For Rake specifically, we get 1 free "tick" immediately upon cast.
 	if !isRip {
-		expectedDamageGain += bleedSpell.NewSnapshotPower
+		expectedDamageGain += snapshotTracker.NewSnapshotPower()
 	}
 
 	shredDpc := cat.Shred.ExpectedInitialDamage(sim, cat.CurrentTarget)
@@ -252,7 +253,7 @@ func (cat *FeralDruid) calcRoarRefreshTime(sim *core.Simulation, ripRefreshTime
 	}
 
 	// Outside of Execute, use offset rule to determine whether to clip.
-	if !sim.IsExecutePhase25() {
+	if !cat.BiteExecutePhase.IsActive() {
 		return core.TernaryDuration(newRoarEnd >= ripEnd + minRoarOffset, targetClipTime, standardRefreshTime)
 	}
 
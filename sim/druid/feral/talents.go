@@ -130,8 +130,8 @@ func (cat *FeralDruid) registerHeartOfTheWild() {
 	healingMod, damageMod, costMod := cat.RegisterSharedFeralHotwMods()
 	bearFormDep := cat.NewDynamicMultiplyStat(stats.Agility, 1.5)
 	bearFormStatBuff := stats.Stats{
-		stats.HitRating:       7.5 * core.PhysicalHitRatingPerHitPercent,
-		stats.ExpertiseRating: 7.5 * 4 * core.ExpertisePerQuarterPercentReduction,
+		stats.HitRating:       core.Ratings.RatingForPercent(stats.HitRating, 7.5),
+		stats.ExpertiseRating: core.Ratings.RatingForPercent(stats.ExpertiseRating, 7.5),
 	}
 
 	// TODO: Implement Bear Form armor buff, Crit immunity, and Vengeance
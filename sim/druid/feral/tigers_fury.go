@@ -34,7 +34,7 @@ func (cat *FeralDruid) registerTigersFurySpell() {
 
 	cat.TigersFury = cat.RegisterSpell(druid.Cat, core.SpellConfig{
 		ActionID: actionID,
-		Flags:    core.SpellFlagAPL | core.SpellFlagReadinessTrinket,
+		Flags:    core.SpellFlagAPL | core.SpellFlagReadinessTrinket | core.SpellFlagTrackCooldownDrift,
 
 		Cast: core.CastConfig{
 			CD: core.Cooldown{
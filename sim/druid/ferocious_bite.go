@@ -7,6 +7,8 @@ import (
 )
 
 func (druid *Druid) registerFerociousBiteSpell() {
+	druid.BiteExecutePhase = druid.ExecutePhase(25)
+
 	// Raw parameters from spell database
 	const coefficient = 0.45699998736
 	const variance = 0.74000000954
@@ -50,8 +52,7 @@ func (druid *Druid) registerFerociousBiteSpell() {
 			attackPower := spell.MeleeAttackPower()
 			excessEnergy := min(druid.CurrentEnergy(), 25)
 
-			baseDamage := minBaseDamage +
-				sim.RandomFloat("Ferocious Bite")*damageSpread +
+			baseDamage := sim.RollWithLabel(minBaseDamage, minBaseDamage+damageSpread, "Ferocious Bite") +
 				dmgPerComboPoint*comboPoints +
 				attackPower*scalingPerComboPoint*comboPoints
 			baseDamage *= 1.0 + excessEnergy/25
@@ -71,7 +72,7 @@ func (druid *Druid) registerFerociousBiteSpell() {
 				// Blood in the Water
 				ripDot := druid.Rip.Dot(target)
 
-				if sim.IsExecutePhase25() && ripDot.IsActive() {
+				if druid.BiteExecutePhase.IsActive() && ripDot.IsActive() {
 					ripDot.BaseTickCount = RipBaseNumTicks
 					ripDot.ApplyRollover(sim)
 				}
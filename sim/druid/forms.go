@@ -7,7 +7,7 @@ import (
 	"github.com/wowsims/mop/sim/core/stats"
 )
 
-type DruidForm uint8
+type DruidForm = core.StanceMask
 
 const (
 	Humanoid DruidForm = 1 << iota
@@ -25,16 +25,34 @@ const AnimalSpiritRegenSuppression = 0.911337
 // and UI stats display.
 const BaseBearArmorMulti = 2.2
 
-func (form DruidForm) Matches(other DruidForm) bool {
-	return (form & other) != 0
-}
-
 // func (druid *Druid) GetForm() DruidForm {
-// 	return druid.form
+// 	return druid.Stance()
 // }
 
 func (druid *Druid) InForm(form DruidForm) bool {
-	return druid.form.Matches(form)
+	return druid.InStance(form)
+}
+
+// Humanoid spells shift out of Cat and Bear Form for free, while spells
+// requiring an animal form shift into it by casting the form spell, once the
+// spec registers it.
+func (druid *Druid) registerForms() {
+	druid.RegisterStance(core.StanceConfig{
+		Stance: Humanoid,
+		Enter:  druid.ClearForm,
+	})
+	druid.catStance = druid.RegisterStance(core.StanceConfig{
+		Stance:   Cat,
+		ActionID: core.ActionID{SpellID: 768},
+	})
+	druid.bearStance = druid.RegisterStance(core.StanceConfig{
+		Stance:   Bear,
+		ActionID: core.ActionID{SpellID: 5487},
+	})
+	druid.RegisterStance(core.StanceConfig{
+		Stance:   Moonkin,
+		ActionID: core.ActionID{SpellID: 24858},
+	})
 }
 
 func (druid *Druid) ClearForm(sim *core.Simulation) {
@@ -45,7 +63,7 @@ func (druid *Druid) ClearForm(sim *core.Simulation) {
 	} else if druid.InForm(Moonkin) {
 		panic("cant clear moonkin form")
 	}
-	druid.form = Humanoid
+	druid.SetStance(Humanoid)
 	druid.SetCurrentPowerBar(core.ManaBar)
 }
 
@@ -92,10 +110,10 @@ func (druid *Druid) RegisterCatFormAura() {
 		Duration:   core.NeverExpires,
 		BuildPhase: core.Ternary(druid.StartingForm.Matches(Cat), core.CharacterBuildPhaseBase, core.CharacterBuildPhaseNone),
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			if !druid.Env.MeasuringStats && druid.form != Humanoid {
+			if !druid.Env.MeasuringStats && druid.Stance() != Humanoid {
 				druid.ClearForm(sim)
 			}
-			druid.form = Cat
+			druid.SetStance(Cat)
 			druid.SetCurrentPowerBar(core.EnergyBar)
 
 			druid.PseudoStats.ThreatMultiplier *= 0.71
@@ -113,7 +131,7 @@ func (druid *Druid) RegisterCatFormAura() {
 			}
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			druid.form = Humanoid
+			druid.SetStance(Humanoid)
 
 			druid.PseudoStats.ThreatMultiplier /= 0.71
 			druid.PseudoStats.SpiritRegenMultiplier /= AnimalSpiritRegenSuppression
@@ -153,6 +171,7 @@ func (druid *Druid) registerCatFormSpell() {
 			druid.CatFormAura.Activate(sim)
 		},
 	})
+	druid.catStance.Spell = druid.CatForm.Spell
 }
 
 func (druid *Druid) RegisterBearFormAura() {
@@ -176,10 +195,10 @@ func (druid *Druid) RegisterBearFormAura() {
 		Duration:   core.NeverExpires,
 		BuildPhase: core.Ternary(druid.StartingForm.Matches(Bear), core.CharacterBuildPhaseBase, core.CharacterBuildPhaseNone),
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			if !druid.Env.MeasuringStats && druid.form != Humanoid {
+			if !druid.Env.MeasuringStats && druid.Stance() != Humanoid {
 				druid.ClearForm(sim)
 			}
-			druid.form = Bear
+			druid.SetStance(Bear)
 			druid.SetCurrentPowerBar(core.RageBar)
 
 			druid.PseudoStats.ThreatMultiplier *= 7
@@ -207,7 +226,7 @@ func (druid *Druid) RegisterBearFormAura() {
 			}
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			druid.form = Humanoid
+			druid.SetStance(Humanoid)
 
 			druid.PseudoStats.ThreatMultiplier /= 7
 			druid.PseudoStats.SpiritRegenMultiplier /= AnimalSpiritRegenSuppression
@@ -263,4 +282,5 @@ func (druid *Druid) registerBearFormSpell() {
 			druid.BearFormAura.Activate(sim)
 		},
 	})
+	druid.bearStance.Spell = druid.BearForm.Spell
 }
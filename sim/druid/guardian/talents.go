@@ -95,8 +95,8 @@ func (bear *GuardianDruid) registerHeartOfTheWild() {
 	healingMod, damageMod, costMod := bear.RegisterSharedFeralHotwMods()
 	catFormDep := bear.NewDynamicMultiplyStat(stats.Agility, 2.1)
 	catFormStatBuff := stats.Stats{
-		stats.HitRating:       7.5 * core.PhysicalHitRatingPerHitPercent,
-		stats.ExpertiseRating: 7.5 * 4 * core.ExpertisePerQuarterPercentReduction,
+		stats.HitRating:       core.Ratings.RatingForPercent(stats.HitRating, 7.5),
+		stats.ExpertiseRating: core.Ratings.RatingForPercent(stats.ExpertiseRating, 7.5),
 	}
 
 	bear.HeartOfTheWildAura = bear.RegisterAura(core.Aura{
@@ -108,7 +108,7 @@ func (bear *GuardianDruid) registerHeartOfTheWild() {
 			healingMod.Activate()
 			damageMod.Activate()
 			costMod.Activate()
-			bear.Rejuvenation.FormMask |= druid.Bear
+			bear.Rejuvenation.RequiredStance |= druid.Bear
 			bear.AddStatDynamic(sim, stats.SpellHitPercent, 15)
 
 			if bear.InForm(druid.Cat) {
@@ -121,7 +121,7 @@ func (bear *GuardianDruid) registerHeartOfTheWild() {
 			healingMod.Deactivate()
 			damageMod.Deactivate()
 			costMod.Deactivate()
-			bear.Rejuvenation.FormMask ^= druid.Bear
+			bear.Rejuvenation.RequiredStance ^= druid.Bear
 			bear.AddStatDynamic(sim, stats.SpellHitPercent, -15)
 
 			if bear.InForm(druid.Cat) {
@@ -191,7 +191,7 @@ func (bear *GuardianDruid) registerDreamOfCenarius() {
 		OnGain: func(_ *core.Aura, _ *core.Simulation) {
 			bear.HealingTouch.CastTimeMultiplier -= 1
 			bear.HealingTouch.Cost.PercentModifier *= -1
-			bear.HealingTouch.FormMask |= druid.Bear
+			bear.HealingTouch.RequiredStance |= druid.Bear
 
 			// https://www.mmo-champion.com/threads/1188383-Guardian-Patch-5-4-Survival-Guide
 			// TODO: Verify this
@@ -209,7 +209,7 @@ func (bear *GuardianDruid) registerDreamOfCenarius() {
 		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
 			bear.HealingTouch.CastTimeMultiplier += 1
 			bear.HealingTouch.Cost.PercentModifier /= -1
-			bear.HealingTouch.FormMask ^= druid.Bear
+			bear.HealingTouch.RequiredStance ^= druid.Bear
 			bear.GetSpellPowerValue = oldGetSpellPowerValue
 		},
 
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/druid"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterGuardianDruid() {
@@ -22,6 +23,7 @@ func RegisterGuardianDruid() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecGuardianDruid, core.LoadAPLPresets(ui.APLs, "druid/guardian/apls")...)
 }
 
 func NewGuardianDruid(character *core.Character, options *proto.Player) *GuardianDruid {
@@ -40,8 +40,7 @@ func (bear *GuardianDruid) registerToothAndClawPassive() {
 			return
 		}
 
-		absorbedDamage := min(float64(debuff.GetStacks()), result.Damage)
-		result.Damage -= absorbedDamage
+		absorbedDamage := result.Absorb(float64(debuff.GetStacks()))
 
 		if sim.Log != nil {
 			result.Target.Log(sim, "Tooth and Claw absorbed %.1f damage from incoming auto-attack.", absorbedDamage)
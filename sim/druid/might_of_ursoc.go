@@ -5,6 +5,7 @@ import (
 
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
 )
 
 func (druid *Druid) registerMightOfUrsocCD() {
@@ -13,21 +14,16 @@ func (druid *Druid) registerMightOfUrsocCD() {
 	isGlyphed := druid.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfMightOfUrsoc)
 	bonusHealthFrac := core.TernaryFloat64(isGlyphed, 0.5, 0.3)
 
-	var bonusHealth float64
-
 	druid.MightOfUrsocAura = druid.RegisterAura(core.Aura{
 		Label:    "Might of Ursoc",
 		ActionID: actionID,
 		Duration: time.Second * 20,
-
-		OnGain: func(_ *core.Aura, sim *core.Simulation) {
-			bonusHealth = druid.MaxHealth() * bonusHealthFrac
-			druid.UpdateMaxHealth(sim, bonusHealth, healthMetrics)
-		},
-
-		OnExpire: func(_ *core.Aura, sim *core.Simulation) {
-			druid.UpdateMaxHealth(sim, -bonusHealth, healthMetrics)
-		},
+	}).AttachStatBonus(core.AuraStatBonusConfig{
+		Mode:          core.StatBonusDynamic,
+		SourceStat:    stats.Health,
+		TargetStat:    stats.Health,
+		Ratio:         bonusHealthFrac,
+		HealthMetrics: healthMetrics,
 	})
 
 	druid.MightOfUrsoc = druid.RegisterSpell(Any, core.SpellConfig{
@@ -22,15 +22,15 @@ func (druid *Druid) registerNaturesSwiftness() {
 		Duration: core.NeverExpires,
 
 		OnReset: func(_ *core.Aura, _ *core.Simulation) {
-			druid.HealingTouch.FormMask = Humanoid | Moonkin
+			druid.HealingTouch.RequiredStance = Humanoid | Moonkin
 		},
 
 		OnGain: func(_ *core.Aura, _ *core.Simulation) {
-			druid.HealingTouch.FormMask |= Cat
+			druid.HealingTouch.RequiredStance |= Cat
 		},
 
 		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
-			druid.HealingTouch.FormMask ^= Cat
+			druid.HealingTouch.RequiredStance ^= Cat
 		},
 
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
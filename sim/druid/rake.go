@@ -44,12 +44,17 @@ func (druid *Druid) registerRakeSpell() {
 			}),
 			NumberOfTicks: 5,
 			TickLength:    time.Second * 3,
+			Mechanic:      core.SpellFlagBleed,
+
+			TrackSnapshots: true,
 
 			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
 				dot.SnapshotPhysical(target, flatBaseDamage+bonusCoefficientFromAP*dot.Spell.MeleeAttackPower())
 
-				// Store snapshot power parameters for later use.
-				druid.UpdateBleedPower(druid.Rake, sim, target, true, true)
+				// Rake re-snapshots on rollovers as well.
+				if isRollover {
+					dot.SnapshotTracker.Record(sim)
+				}
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeSnapshotCrit)
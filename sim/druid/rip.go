@@ -23,7 +23,7 @@ func (druid *Druid) registerRipSpell() {
 		ActionID:       core.ActionID{SpellID: 1079},
 		SpellSchool:    core.SpellSchoolPhysical,
 		ProcMask:       core.ProcMaskMeleeMHSpecial,
-		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL | core.SpellFlagBleed,
 		ClassSpellMask: DruidSpellRip,
 
 		EnergyCost: core.EnergyCostOptions{
@@ -55,6 +55,8 @@ func (druid *Druid) registerRipSpell() {
 			NumberOfTicks: RipBaseNumTicks,
 			TickLength:    time.Second * 2,
 
+			TrackSnapshots: true,
+
 			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
 				if isRollover {
 					return
@@ -63,9 +65,6 @@ func (druid *Druid) registerRipSpell() {
 				cp := float64(druid.ComboPoints())
 				ap := dot.Spell.MeleeAttackPower()
 				dot.SnapshotPhysical(target, baseDamage+comboPointCoeff*cp+attackPowerCoeff*cp*ap)
-
-				// Store snapshot power parameters for later use.
-				druid.UpdateBleedPower(druid.Rip, sim, target, true, true)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeSnapshotCrit)
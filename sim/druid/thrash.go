@@ -111,13 +111,14 @@ func (druid *Druid) registerThrashCatSpell() {
 			NumberOfTicks: 5,
 			TickLength:    time.Second * 3,
 
+			TrackSnapshots: true,
+
 			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
 				if isRollover {
 					panic("Thrash cannot roll-over snapshots!")
 				}
 
 				dot.SnapshotPhysical(target, flatTickDamage+0.141*dot.Spell.MeleeAttackPower())
-				druid.UpdateBleedPower(druid.ThrashCat, sim, target, true, true)
 			},
 
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
@@ -23,6 +23,8 @@ func (hunter *Hunter) registerAMOCSpell() {
 		sim.RegisterExecutePhaseCallback(func(sim *core.Simulation, executePhase int32) {
 			if executePhase == 20 {
 				executePhaseMod.Activate()
+			} else {
+				executePhaseMod.Deactivate()
 			}
 		})
 	})
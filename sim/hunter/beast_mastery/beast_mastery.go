@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/hunter"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterBeastMasteryHunter() {
@@ -22,6 +23,7 @@ func RegisterBeastMasteryHunter() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecBeastMasteryHunter, core.LoadAPLPresets(ui.APLs, "hunter/beast_mastery/apls")...)
 }
 
 func NewBeastMasteryHunter(character *core.Character, options *proto.Player) *BeastMasteryHunter {
@@ -108,7 +110,7 @@ func (bmHunter *BeastMasteryHunter) Initialize() {
 }
 
 func (hunter *BeastMasteryHunter) getMasteryBonus(masteryRating float64) float64 {
-	return 0.16 + ((masteryRating / core.MasteryRatingPerMasteryPoint) * 0.02)
+	return 0.16 + (core.Ratings.MasteryPoints(masteryRating) * 0.02)
 }
 
 type BeastMasteryHunter struct {
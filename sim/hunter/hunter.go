@@ -145,6 +145,7 @@ func (hunter *Hunter) RegisterSpells() {
 	hunter.RegisterDireBeastSpell()
 	hunter.RegisterStampedeSpell()
 	hunter.registerPowerShotSpell()
+	hunter.registerMisdirectionSpell()
 }
 
 func (hunter *Hunter) AddStatDependencies() {
@@ -247,6 +248,7 @@ const (
 	HunterSpellGlaiveToss
 	HunterSpellBarrage
 	HunterSpellPowershot
+	HunterSpellMisdirection
 	HunterSpellsTierTwelve = HunterSpellArcaneShot | HunterSpellKillCommand | HunterSpellChimeraShot | HunterSpellExplosiveShot |
 		HunterSpellMultiShot | HunterSpellAimedShot
 	HunterSpellsAll = HunterSpellSteadyShot | HunterSpellCobraShot |
@@ -7,6 +7,7 @@ import (
 )
 
 func (hunter *Hunter) registerKillShotSpell() {
+	executePhase := hunter.ExecutePhase(20)
 	icd := core.Cooldown{
 		Timer:    hunter.NewTimer(),
 		Duration: time.Second * 6,
@@ -34,7 +35,7 @@ func (hunter *Hunter) registerKillShotSpell() {
 			},
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sim.IsExecutePhase20()
+			return executePhase.IsActive()
 		},
 		DamageMultiplier: 4.2,
 		CritMultiplier:   hunter.DefaultCritMultiplier(),
@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/hunter"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterMarksmanshipHunter() {
@@ -21,6 +22,7 @@ func RegisterMarksmanshipHunter() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecMarksmanshipHunter, core.LoadAPLPresets(ui.APLs, "hunter/marksmanship/apls")...)
 }
 func (mm *MarksmanshipHunter) applyMastery() {
 	actionID := core.ActionID{SpellID: 76659}
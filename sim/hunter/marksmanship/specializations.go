@@ -28,7 +28,12 @@ func (mm *MarksmanshipHunter) ApplySpecialization() {
 	mm.RegisterResetEffect(func(sim *core.Simulation) {
 		caCritMod.Activate()
 		sim.RegisterExecutePhaseCallback(func(sim *core.Simulation, isExecute int32) {
-			caCritMod.Deactivate()
+			// Execute phases restart when switching to the next boss of a council fight.
+			if isExecute == 100 {
+				caCritMod.Activate()
+			} else {
+				caCritMod.Deactivate()
+			}
 		})
 	})
 	//bombardmentActionId := core.ActionID{SpellID: 35110}
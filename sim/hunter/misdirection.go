@@ -0,0 +1,65 @@
+package hunter
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+func (hunter *Hunter) registerMisdirectionSpell() {
+	var mdTarget *core.Unit
+	if hunter.Options.MisdirectionTarget != nil {
+		mdTarget = hunter.GetUnit(hunter.Options.MisdirectionTarget)
+	}
+
+	var castTarget *core.Unit
+	misdirectionThreatTransferAura := hunter.GetOrRegisterAura(core.Aura{
+		ActionID: core.ActionID{SpellID: 35079},
+		Label:    "MisdirectionThreatTransfer",
+		Duration: time.Second * 4,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			if castTarget != nil {
+				hunter.SetThreatRedirect(castTarget)
+			}
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			hunter.SetThreatRedirect(nil)
+		},
+	})
+
+	// The threat of the hunter's next damaging attack, and of everything done in
+	// the 4s after it, goes to the target.
+	misdirectionApplicationAura := hunter.GetOrRegisterAura(core.Aura{
+		ActionID: core.ActionID{SpellID: 34477},
+		Label:    "MisdirectionApplication",
+		Duration: time.Second * 30,
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Landed() {
+				misdirectionThreatTransferAura.Activate(sim)
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	hunter.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 34477},
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: HunterSpellMisdirection,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    hunter.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			castTarget = nil
+			if mdTarget != nil {
+				castTarget = mdTarget
+			} else if target.Type != core.EnemyUnit && target != &hunter.Unit { // Cant cast on ourself
+				castTarget = target
+			}
+			misdirectionApplicationAura.Activate(sim)
+		},
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/hunter"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterSurvivalHunter() {
@@ -22,6 +23,7 @@ func RegisterSurvivalHunter() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecSurvivalHunter, core.LoadAPLPresets(ui.APLs, "hunter/survival/apls")...)
 }
 
 func (hunter *SurvivalHunter) Initialize() {
@@ -54,7 +56,7 @@ func (hunter *SurvivalHunter) Initialize() {
 	//hunter.MultiplyStat(stats.Agility, 1.1)
 }
 func (hunter *SurvivalHunter) getMasteryBonus(masteryRating float64) float64 {
-	return 1.08 + ((masteryRating / core.MasteryRatingPerMasteryPoint) * 0.01)
+	return 1.08 + (core.Ratings.MasteryPoints(masteryRating) * 0.01)
 }
 
 func NewSurvivalHunter(character *core.Character, options *proto.Player) *SurvivalHunter {
@@ -0,0 +1,219 @@
+package sim
+
+// Trinkets which are known to have no effect implementation yet, see TestTrinketEffectCoverage.
+var trinketsWithoutEffects = map[int32]string{
+	77530:  "Ghost Iron Dragonling",
+	84450:  "Dreadful Gladiator's Medallion of Cruelty",
+	84451:  "Dreadful Gladiator's Medallion of Cruelty",
+	84452:  "Dreadful Gladiator's Medallion of Tenacity",
+	84453:  "Dreadful Gladiator's Medallion of Tenacity",
+	84454:  "Dreadful Gladiator's Medallion of Meditation",
+	84455:  "Dreadful Gladiator's Medallion of Meditation",
+	84931:  "Malevolent Gladiator's Medallion of Tenacity",
+	84932:  "Malevolent Gladiator's Medallion of Meditation",
+	84933:  "Malevolent Gladiator's Medallion of Meditation",
+	84943:  "Malevolent Gladiator's Medallion of Cruelty",
+	84944:  "Malevolent Gladiator's Medallion of Cruelty",
+	84945:  "Malevolent Gladiator's Medallion of Tenacity",
+	86525:  "Bloodsoaked Chitin Fragment",
+	86526:  "Swarmkeeper's Medallion",
+	86529:  "Manipulator's Talisman",
+	87575:  "Bubbliest Brightbrew Charm",
+	87576:  "Bitterest Balebrew Charm",
+	88583:  "Ban's Bag of Bombs",
+	88585:  "Dislodged Stinger",
+	88590:  "Nurong's Gun",
+	88995:  "Shado-Pan Dragon Gun",
+	89232:  "Mogu Rune of Paralysis",
+	89611:  "Quilen Statuette",
+	91682:  "Malevolent Gladiator's Medallion of Cruelty",
+	91683:  "Malevolent Gladiator's Medallion of Cruelty",
+	91684:  "Malevolent Gladiator's Medallion of Tenacity",
+	91685:  "Malevolent Gladiator's Medallion of Tenacity",
+	91686:  "Malevolent Gladiator's Medallion of Meditation",
+	91687:  "Malevolent Gladiator's Medallion of Meditation",
+	92786:  "Alliance Insignia of Conquering",
+	92787:  "Horde Insignia of Conquering",
+	93560:  "Crafted Dreadful Gladiator's Medallion of Cruelty",
+	93561:  "Crafted Dreadful Gladiator's Medallion of Cruelty",
+	93562:  "Crafted Dreadful Gladiator's Medallion of Tenacity",
+	93563:  "Crafted Dreadful Gladiator's Medallion of Tenacity",
+	93564:  "Crafted Dreadful Gladiator's Medallion of Meditation",
+	93565:  "Crafted Dreadful Gladiator's Medallion of Meditation",
+	94338:  "Tyrannical Gladiator's Medallion of Meditation",
+	94361:  "Tyrannical Gladiator's Medallion of Tenacity",
+	94386:  "Tyrannical Gladiator's Medallion of Cruelty",
+	94387:  "Tyrannical Gladiator's Medallion of Tenacity",
+	94388:  "Tyrannical Gladiator's Medallion of Meditation",
+	94454:  "Tyrannical Gladiator's Medallion of Cruelty",
+	94509:  "Soothing Talisman of the Shado-Pan Assault",
+	94525:  "Stolen Relic of Zuldazar",
+	94526:  "Spark of Zandalar",
+	94528:  "Soul Barrier",
+	94530:  "Lightning-Imbued Chalice",
+	94532:  "Rune of Re-Origination",
+	95654:  "Spark of Zandalar",
+	95763:  "Stolen Relic of Zuldazar",
+	95802:  "Rune of Re-Origination",
+	95811:  "Soul Barrier",
+	95817:  "Lightning-Imbued Chalice",
+	96026:  "Spark of Zandalar",
+	96135:  "Stolen Relic of Zuldazar",
+	96174:  "Rune of Re-Origination",
+	96183:  "Soul Barrier",
+	96189:  "Lightning-Imbued Chalice",
+	96398:  "Spark of Zandalar",
+	96507:  "Stolen Relic of Zuldazar",
+	96546:  "Rune of Re-Origination",
+	96555:  "Soul Barrier",
+	96561:  "Lightning-Imbued Chalice",
+	96770:  "Spark of Zandalar",
+	96879:  "Stolen Relic of Zuldazar",
+	96918:  "Rune of Re-Origination",
+	96927:  "Soul Barrier",
+	96933:  "Lightning-Imbued Chalice",
+	98875:  "Crafted Malevolent Gladiator's Medallion of Cruelty",
+	98876:  "Crafted Malevolent Gladiator's Medallion of Cruelty",
+	98877:  "Crafted Malevolent Gladiator's Medallion of Tenacity",
+	98878:  "Crafted Malevolent Gladiator's Medallion of Tenacity",
+	98879:  "Crafted Malevolent Gladiator's Medallion of Meditation",
+	98880:  "Crafted Malevolent Gladiator's Medallion of Meditation",
+	100006: "Tyrannical Gladiator's Medallion of Meditation",
+	100031: "Tyrannical Gladiator's Medallion of Tenacity",
+	100056: "Tyrannical Gladiator's Medallion of Cruelty",
+	100057: "Tyrannical Gladiator's Medallion of Tenacity",
+	100058: "Tyrannical Gladiator's Medallion of Meditation",
+	100124: "Tyrannical Gladiator's Medallion of Cruelty",
+	100568: "Grievous Gladiator's Medallion of Meditation",
+	100591: "Grievous Gladiator's Medallion of Tenacity",
+	100616: "Grievous Gladiator's Medallion of Cruelty",
+	100617: "Grievous Gladiator's Medallion of Tenacity",
+	100618: "Grievous Gladiator's Medallion of Meditation",
+	100684: "Grievous Gladiator's Medallion of Cruelty",
+	101038: "Springrain Idol of Wisdom",
+	101102: "Mistdancer Idol of Wisdom",
+	101135: "Sunsoul Idol of Wisdom",
+	101179: "Communal Idol of Wisdom",
+	101247: "Streamtalker Idol of Wisdom",
+	102293: "Purified Bindings of Immerseus",
+	102294: "Nazgrim's Burnished Insignia",
+	102295: "Fusion-Fire Core",
+	102296: "Rook's Unlucky Talisman",
+	102297: "Juggernaut's Focusing Crystal",
+	102299: "Prismatic Prison of Pride",
+	102300: "Kardris' Toxic Totem",
+	102301: "Haromm's Talisman",
+	102302: "Sigil of Rampage",
+	102303: "Frenzied Crystal of Rage",
+	102304: "Thok's Acid-Grooved Tooth",
+	102305: "Thok's Tail Tip",
+	102306: "Vial of Living Corruption",
+	102308: "Skeer's Bloodsoaked Talisman",
+	102309: "Dysmorphic Samophlange of Discontinuity",
+	102310: "Black Blood of Y'Shaarj",
+	102311: "Ticking Ebon Detonator",
+	102480: "Gladiator's Medallion",
+	102481: "Gladiator's Emblem",
+	102483: "Crafted Malevolent Gladiator's Medallion of Tenacity",
+	102625: "Prideful Gladiator's Medallion of Meditation",
+	102672: "Prideful Gladiator's Medallion of Tenacity",
+	102738: "Prideful Gladiator's Medallion of Cruelty",
+	102822: "Grievous Gladiator's Medallion of Meditation",
+	102869: "Grievous Gladiator's Medallion of Tenacity",
+	102935: "Grievous Gladiator's Medallion of Cruelty",
+	103333: "Grievous Gladiator's Medallion of Tenacity",
+	103334: "Grievous Gladiator's Medallion of Cruelty",
+	103335: "Grievous Gladiator's Medallion of Meditation",
+	103530: "Prideful Gladiator's Medallion of Tenacity",
+	103531: "Prideful Gladiator's Medallion of Cruelty",
+	103532: "Prideful Gladiator's Medallion of Meditation",
+	103678: "Time-Lost Artifact",
+	104298: "Ordon Death Chime",
+	104313: "Golden Moss",
+	104321: "Captain Zvezdan's Lost Leg",
+	104426: "Purified Bindings of Immerseus",
+	104442: "Rook's Unlucky Talisman",
+	104463: "Fusion-Fire Core",
+	104478: "Prismatic Prison of Pride",
+	104518: "Juggernaut's Focusing Crystal",
+	104531: "Haromm's Talisman",
+	104544: "Kardris' Toxic Totem",
+	104553: "Nazgrim's Burnished Insignia",
+	104572: "Vial of Living Corruption",
+	104576: "Frenzied Crystal of Rage",
+	104584: "Sigil of Rampage",
+	104611: "Thok's Acid-Grooved Tooth",
+	104613: "Thok's Tail Tip",
+	104616: "Ticking Ebon Detonator",
+	104619: "Dysmorphic Samophlange of Discontinuity",
+	104636: "Skeer's Bloodsoaked Talisman",
+	104652: "Black Blood of Y'Shaarj",
+	104675: "Purified Bindings of Immerseus",
+	104691: "Rook's Unlucky Talisman",
+	104712: "Fusion-Fire Core",
+	104727: "Prismatic Prison of Pride",
+	104767: "Juggernaut's Focusing Crystal",
+	104780: "Haromm's Talisman",
+	104793: "Kardris' Toxic Totem",
+	104802: "Nazgrim's Burnished Insignia",
+	104821: "Vial of Living Corruption",
+	104825: "Frenzied Crystal of Rage",
+	104833: "Sigil of Rampage",
+	104860: "Thok's Acid-Grooved Tooth",
+	104862: "Thok's Tail Tip",
+	104865: "Ticking Ebon Detonator",
+	104868: "Dysmorphic Samophlange of Discontinuity",
+	104885: "Skeer's Bloodsoaked Talisman",
+	104901: "Black Blood of Y'Shaarj",
+	104924: "Purified Bindings of Immerseus",
+	104940: "Rook's Unlucky Talisman",
+	104961: "Fusion-Fire Core",
+	104976: "Prismatic Prison of Pride",
+	105016: "Juggernaut's Focusing Crystal",
+	105029: "Haromm's Talisman",
+	105042: "Kardris' Toxic Totem",
+	105051: "Nazgrim's Burnished Insignia",
+	105070: "Vial of Living Corruption",
+	105074: "Frenzied Crystal of Rage",
+	105082: "Sigil of Rampage",
+	105109: "Thok's Acid-Grooved Tooth",
+	105111: "Thok's Tail Tip",
+	105114: "Ticking Ebon Detonator",
+	105117: "Dysmorphic Samophlange of Discontinuity",
+	105134: "Skeer's Bloodsoaked Talisman",
+	105150: "Black Blood of Y'Shaarj",
+	105173: "Purified Bindings of Immerseus",
+	105189: "Rook's Unlucky Talisman",
+	105210: "Fusion-Fire Core",
+	105225: "Prismatic Prison of Pride",
+	105265: "Juggernaut's Focusing Crystal",
+	105278: "Haromm's Talisman",
+	105291: "Kardris' Toxic Totem",
+	105300: "Nazgrim's Burnished Insignia",
+	105319: "Vial of Living Corruption",
+	105323: "Frenzied Crystal of Rage",
+	105331: "Sigil of Rampage",
+	105358: "Thok's Acid-Grooved Tooth",
+	105360: "Thok's Tail Tip",
+	105363: "Ticking Ebon Detonator",
+	105366: "Dysmorphic Samophlange of Discontinuity",
+	105383: "Skeer's Bloodsoaked Talisman",
+	105399: "Black Blood of Y'Shaarj",
+	105422: "Purified Bindings of Immerseus",
+	105438: "Rook's Unlucky Talisman",
+	105459: "Fusion-Fire Core",
+	105474: "Prismatic Prison of Pride",
+	105514: "Juggernaut's Focusing Crystal",
+	105527: "Haromm's Talisman",
+	105540: "Kardris' Toxic Totem",
+	105549: "Nazgrim's Burnished Insignia",
+	105568: "Vial of Living Corruption",
+	105572: "Frenzied Crystal of Rage",
+	105580: "Sigil of Rampage",
+	105607: "Thok's Acid-Grooved Tooth",
+	105609: "Thok's Tail Tip",
+	105612: "Ticking Ebon Detonator",
+	105615: "Dysmorphic Samophlange of Discontinuity",
+	105632: "Skeer's Bloodsoaked Talisman",
+	105648: "Black Blood of Y'Shaarj",
+}
@@ -0,0 +1,58 @@
+package sim
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/common/shared"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Fails for trinkets without a registered effect, which would otherwise sim as plain
+// stat sticks, unless they are listed in trinketsWithoutEffects. Run with -v to see
+// the allowed ones per tier, stubs can be generated with the database generator.
+func TestTrinketEffectCoverage(t *testing.T) {
+	if !core.WITH_DB {
+		t.Skip("Item database is not loaded, run with --tags=with_db")
+	}
+
+	missingByTier := map[string][]core.Item{}
+	for _, item := range core.ItemsByID {
+		if item.Type != proto.ItemType_ItemTypeTrinket {
+			continue
+		}
+
+		scaling, ok := item.ScalingOptions[int32(proto.ItemLevelState_Base)]
+		if !ok || scaling.Ilvl <= shared.MinEffectIlvl {
+			continue
+		}
+
+		_, allowed := trinketsWithoutEffects[item.ID]
+		if core.HasItemEffect(item.ID) {
+			if allowed {
+				t.Errorf("%d %s has an effect now, remove it from trinketsWithoutEffects", item.ID, item.Name)
+			}
+			continue
+		}
+		if !allowed {
+			t.Errorf("%d %s has no effect, implement one or add it to trinketsWithoutEffects", item.ID, item.Name)
+		}
+
+		tier := shared.ItemEffectTierForIlvl(scaling.Ilvl)
+		missingByTier[tier] = append(missingByTier[tier], item)
+	}
+
+	for _, tier := range slices.Sorted(maps.Keys(missingByTier)) {
+		missing := missingByTier[tier]
+		slices.SortFunc(missing, func(a, b core.Item) int {
+			return int(a.ID - b.ID)
+		})
+
+		t.Logf("%s: %d trinkets without an effect", tier, len(missing))
+		for _, item := range missing {
+			t.Logf("\t%d %s", item.ID, item.Name)
+		}
+	}
+}
@@ -3,9 +3,6 @@ package main
 // #include <stdlib.h>
 import "C"
 import (
-	"bytes"
-	"compress/zlib"
-	"encoding/base64"
 	"encoding/json"
 	"log"
 	"unsafe"
@@ -14,8 +11,8 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/report"
 	"google.golang.org/protobuf/encoding/protojson"
-	goproto "google.golang.org/protobuf/proto"
 )
 
 var _default_rsr = proto.RaidSimRequest{
@@ -70,26 +67,10 @@ func encodeSettings(json *C.char) *C.char {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
-	settings := &proto.IndividualSimSettings{
-		Settings: &proto.SimSettings{
-			Iterations: input.SimOptions.Iterations,
-		},
-		RaidBuffs:  input.Raid.Buffs,
-		Debuffs:    input.Raid.Debuffs,
-		Tanks:      input.Raid.Tanks,
-		PartyBuffs: input.Raid.Parties[0].Buffs,
-		Player:     input.Raid.Parties[0].Players[0],
-		Encounter:  input.Encounter,
-	}
-	var buffer bytes.Buffer
-	data, err := goproto.Marshal(settings)
+	out, err := report.SettingsHash(input)
 	if err != nil {
 		panic(err)
 	}
-	writer := zlib.NewWriter(&buffer)
-	writer.Write(data)
-	writer.Close()
-	out := base64.StdEncoding.EncodeToString(buffer.Bytes())
 	return C.CString(string(out))
 }
 
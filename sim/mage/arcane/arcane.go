@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/mage"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterArcaneMage() {
@@ -21,6 +22,7 @@ func RegisterArcaneMage() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecArcaneMage, core.LoadAPLPresets(ui.APLs, "mage/arcane/apls")...)
 }
 
 type ArcaneMage struct {
@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/mage"
+	"github.com/wowsims/mop/ui"
 )
 
 const (
@@ -26,6 +27,7 @@ func RegisterFireMage() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecFireMage, core.LoadAPLPresets(ui.APLs, "mage/fire/apls")...)
 }
 
 func NewFireMage(character *core.Character, options *proto.Player) *FireMage {
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/mage"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterFrostMage() {
@@ -22,6 +23,7 @@ func RegisterFrostMage() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecFrostMage, core.LoadAPLPresets(ui.APLs, "mage/frost/apls")...)
 }
 
 type FrostMage struct {
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/monk"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterBrewmasterMonk() {
@@ -22,6 +23,7 @@ func RegisterBrewmasterMonk() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecBrewmasterMonk, core.LoadAPLPresets(ui.APLs, "monk/brewmaster/apls")...)
 }
 
 func NewBrewmasterMonk(character *core.Character, options *proto.Player) *BrewmasterMonk {
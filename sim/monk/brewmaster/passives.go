@@ -45,9 +45,10 @@ func (bm *BrewmasterMonk) registerBrewmasterTraining() {
 	// and your Stagger amount by an additional 20% for 6 sec.
 	// Stagger amount is implemented in stagger.go
 	bm.ShuffleAura = core.BlockPrepull(bm.RegisterAura(core.Aura{
-		Label:    "Shuffle",
-		ActionID: core.ActionID{SpellID: 115307},
-		Duration: 6 * time.Second,
+		Label:         "Shuffle",
+		ActionID:      core.ActionID{SpellID: 115307},
+		Duration:      6 * time.Second,
+		RefreshPolicy: core.RefreshPolicyExtend,
 	})).AttachAdditivePseudoStatBuff(&bm.PseudoStats.BaseParryChance, 0.2)
 
 	core.MakeProcTriggerAura(&bm.Unit, core.ProcTrigger{
@@ -55,11 +56,7 @@ func (bm *BrewmasterMonk) registerBrewmasterTraining() {
 		ClassSpellMask: monk.MonkSpellBlackoutKick,
 		Callback:       core.CallbackOnCastComplete,
 		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if bm.ShuffleAura.IsActive() {
-				bm.ShuffleAura.UpdateExpires(bm.ShuffleAura.ExpiresAt() + 6*time.Second)
-			} else {
-				bm.ShuffleAura.Activate(sim)
-			}
+			bm.ShuffleAura.Activate(sim)
 		},
 	})
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
 )
 
 func (monk *Monk) registerFortifyingBrew() {
@@ -15,21 +16,16 @@ func (monk *Monk) registerFortifyingBrew() {
 	healthModifier := core.TernaryFloat64(hasGlyphOfFortifyingBrew, 0.10, 0.20)
 	damageTakenModifier := core.TernaryFloat64(hasGlyphOfFortifyingBrew, 0.75, 0.8)
 
-	var bonusHealth float64
 	monk.FortifyingBrewAura = monk.RegisterAura(core.Aura{
 		Label:    "Fortifying Brew" + monk.Label,
 		ActionID: actionID,
 		Duration: time.Second * 20,
-
-		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			bonusHealth = monk.MaxHealth() * healthModifier
-			monk.PseudoStats.DamageTakenMultiplier *= damageTakenModifier
-			monk.UpdateMaxHealth(sim, bonusHealth, healthMetrics)
-		},
-		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			monk.PseudoStats.DamageTakenMultiplier /= damageTakenModifier
-			monk.UpdateMaxHealth(sim, -bonusHealth, healthMetrics)
-		},
+	}).AttachMultiplicativePseudoStatBuff(&monk.PseudoStats.DamageTakenMultiplier, damageTakenModifier).AttachStatBonus(core.AuraStatBonusConfig{
+		Mode:          core.StatBonusDynamic,
+		SourceStat:    stats.Health,
+		TargetStat:    stats.Health,
+		Ratio:         healthModifier,
+		HealthMetrics: healthMetrics,
 	})
 
 	spell := monk.RegisterSpell(core.SpellConfig{
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/monk"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterWindwalkerMonk() {
@@ -22,6 +23,7 @@ func RegisterWindwalkerMonk() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecWindwalkerMonk, core.LoadAPLPresets(ui.APLs, "monk/windwalker/apls")...)
 }
 
 func NewWindwalkerMonk(character *core.Character, options *proto.Player) *WindwalkerMonk {
@@ -23,6 +23,8 @@ or during Avenging Wrath
 .
 */
 func (paladin *Paladin) registerHammerOfWrath() {
+	executePhase := paladin.ExecutePhase(20)
+
 	paladin.HammerOfWrath = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 24275},
 		SpellSchool:    core.SpellSchoolHoly,
@@ -47,7 +49,7 @@ func (paladin *Paladin) registerHammerOfWrath() {
 			},
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sim.IsExecutePhase20()
+			return executePhase.IsActive()
 		},
 
 		DamageMultiplier: 1,
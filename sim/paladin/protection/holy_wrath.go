@@ -36,6 +36,7 @@ func (prot *ProtectionPaladin) registerHolyWrath() {
 	hasGlyphOfFocusedWrath := prot.HasMinorGlyph(proto.PaladinMinorGlyph_GlyphOfFocusedWrath)
 
 	maxTargets := core.TernaryInt32(hasGlyphOfFocusedWrath, 1, prot.Env.TotalTargetCount())
+	executePhase := prot.ExecutePhase(20)
 
 	prot.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 119072},
@@ -75,7 +76,7 @@ func (prot *ProtectionPaladin) registerHolyWrath() {
 
 			multiplier := spell.DamageMultiplier
 
-			if hasGlyphOfFinalWrath && sim.IsExecutePhase20() {
+			if hasGlyphOfFinalWrath && executePhase.IsActive() {
 				spell.DamageMultiplier *= 1.5
 			}
 
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/paladin"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterProtectionPaladin() {
@@ -22,6 +23,7 @@ func RegisterProtectionPaladin() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecProtectionPaladin, core.LoadAPLPresets(ui.APLs, "paladin/protection/apls")...)
 }
 
 func NewProtectionPaladin(character *core.Character, options *proto.Player) *ProtectionPaladin {
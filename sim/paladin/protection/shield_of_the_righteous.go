@@ -55,10 +55,11 @@ func (prot *ProtectionPaladin) registerShieldOfTheRighteous() {
 
 	var snapshotDmgReduction float64
 	shieldOfTheRighteousAura := core.BlockPrepull(prot.RegisterAura(core.Aura{
-		ActionID:  core.ActionID{SpellID: 132403},
-		Label:     "Shield of the Righteous" + prot.Label,
-		Duration:  time.Second * 3,
-		MaxStacks: 100,
+		ActionID:      core.ActionID{SpellID: 132403},
+		Label:         "Shield of the Righteous" + prot.Label,
+		Duration:      time.Second * 3,
+		RefreshPolicy: core.RefreshPolicyExtend, // The damage taken mod is only recomputed on application, not on refresh
+		MaxStacks:     100,
 
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
 			snapshotDmgReduction = 1.0 +
@@ -118,12 +119,7 @@ func (prot *ProtectionPaladin) registerShieldOfTheRighteous() {
 			}
 
 			// Buff should apply even if the spell misses/dodges/parries
-			// It also extends on refresh and only recomputes the damage taken mod on application, not on refresh
-			if spell.RelatedSelfBuff.IsActive() {
-				spell.RelatedSelfBuff.UpdateExpires(spell.RelatedSelfBuff.ExpiresAt() + spell.RelatedSelfBuff.Duration)
-			} else {
-				spell.RelatedSelfBuff.Activate(sim)
-			}
+			spell.RelatedSelfBuff.Activate(sim)
 
 			prot.BastionOfGloryAura.Activate(sim)
 			prot.BastionOfGloryAura.AddStack(sim)
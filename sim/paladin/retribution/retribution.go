@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/paladin"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterRetributionPaladin() {
@@ -22,6 +23,7 @@ func RegisterRetributionPaladin() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecRetributionPaladin, core.LoadAPLPresets(ui.APLs, "paladin/retribution/apls")...)
 }
 
 func NewRetributionPaladin(character *core.Character, options *proto.Player) *RetributionPaladin {
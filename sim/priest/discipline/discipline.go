@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/priest"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterDisciplinePriest() {
@@ -21,6 +22,7 @@ func RegisterDisciplinePriest() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecDisciplinePriest, core.LoadAPLPresets(ui.APLs, "priest/discipline/apls")...)
 }
 
 type DisciplinePriest struct {
@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/priest"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterHolyPriest() {
@@ -21,6 +22,7 @@ func RegisterHolyPriest() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecHolyPriest, core.LoadAPLPresets(ui.APLs, "priest/holy/apls")...)
 }
 
 func NewHolyPriest(character *core.Character, options *proto.Player) *HolyPriest {
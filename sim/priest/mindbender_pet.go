@@ -66,8 +66,8 @@ func (priest *Priest) NewMindBender() *MindBender {
 
 	// never misses
 	mindbender.AddStats(stats.Stats{
-		stats.HitRating:       8 * core.PhysicalHitRatingPerHitPercent,
-		stats.ExpertiseRating: 14 * core.ExpertisePerQuarterPercentReduction * 4,
+		stats.HitRating:       core.Ratings.RatingForPercent(stats.HitRating, 8),
+		stats.ExpertiseRating: core.Ratings.RatingForPercent(stats.ExpertiseRating, 14),
 	})
 
 	mindbender.EnableAutoAttacks(mindbender, core.AutoAttackOptions{
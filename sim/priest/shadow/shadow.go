@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/priest"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterShadowPriest() {
@@ -22,10 +23,14 @@ func RegisterShadowPriest() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecShadowPriest, core.LoadAPLPresets(ui.APLs, "priest/shadow/apls")...)
 }
 
 const MaxShadowOrbs = 3
 
+// Only used by the In Stance APL value, no spell requires Shadowform.
+const Shadowform core.StanceMask = 1
+
 func NewShadowPriest(character *core.Character, options *proto.Player) *ShadowPriest {
 	shadowOptions := options.GetShadowPriest()
 
@@ -100,12 +105,15 @@ func (spriest *ShadowPriest) ApplyTalents() {
 		Kind:       core.SpellMod_DamageDone_Pct,
 	})
 
-	core.MakePermanent(spriest.RegisterAura(core.Aura{
-		Label: "Shadowform",
-		ActionID: core.ActionID{
-			SpellID: 15473,
-		},
-	}))
+	spriest.RegisterStance(core.StanceConfig{
+		Stance: Shadowform,
+		Aura: core.MakePermanent(spriest.RegisterAura(core.Aura{
+			Label: "Shadowform",
+			ActionID: core.ActionID{
+				SpellID: 15473,
+			},
+		})),
+	})
 
 	core.MakePermanent(core.MindQuickeningAura(&spriest.Unit))
 
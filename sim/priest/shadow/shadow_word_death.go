@@ -12,6 +12,7 @@ const swdCoeff = 2.157
 
 func (shadow *ShadowPriest) registerShadowWordDeathSpell() {
 	actionId := core.ActionID{SpellID: 32379}
+	executePhase := shadow.ExecutePhase(20)
 	swdAura := shadow.RegisterAura(core.Aura{
 		Label:    "Shadow Word: Death",
 		ActionID: actionId.WithTag(1),
@@ -56,7 +57,7 @@ func (shadow *ShadowPriest) registerShadowWordDeathSpell() {
 			spell.CD.Reset()
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sim.IsExecutePhase20()
+			return executePhase.IsActive()
 		},
 	})
 }
@@ -159,15 +159,16 @@ func (shadow *ShadowPriest) registerTwistOfFate() {
 	if !shadow.Talents.TwistOfFate {
 		return
 	}
+	executePhase := shadow.ExecutePhase(35)
 	core.MakePermanent(shadow.RegisterAura(core.Aura{
 		Label: "Twist of Fate (Talent)",
 		OnPeriodicDamageDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if sim.IsExecutePhase35() {
+			if executePhase.IsActive() {
 				tofAura.Activate(sim)
 			}
 		},
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if sim.IsExecutePhase35() {
+			if executePhase.IsActive() {
 				tofAura.Activate(sim)
 			}
 		},
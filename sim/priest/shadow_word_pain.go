@@ -60,6 +60,7 @@ func (priest *Priest) registerShadowWordPainSpell() {
 			}
 		},
 
+		ExpectedDamagePerSecond: true,
 		ExpectedTickDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, useSnapshot bool) *core.SpellResult {
 			dot := spell.Dot(target)
 			if useSnapshot {
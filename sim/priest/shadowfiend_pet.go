@@ -81,8 +81,8 @@ func (priest *Priest) NewShadowfiend() *Shadowfiend {
 
 	// never misses
 	shadowfiend.AddStats(stats.Stats{
-		stats.HitRating:       8 * core.PhysicalHitRatingPerHitPercent,
-		stats.ExpertiseRating: 14 * core.ExpertisePerQuarterPercentReduction * 4,
+		stats.HitRating:       core.Ratings.RatingForPercent(stats.HitRating, 8),
+		stats.ExpertiseRating: core.Ratings.RatingForPercent(stats.ExpertiseRating, 14),
 	})
 
 	shadowfiend.EnableAutoAttacks(shadowfiend, core.AutoAttackOptions{
@@ -59,6 +59,7 @@ func (priest *Priest) registerVampiricTouchSpell() {
 				spell.DealOutcome(sim, result)
 			}
 		},
+		ExpectedDamagePerSecond: true,
 		ExpectedTickDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, useSnapshot bool) *core.SpellResult {
 			dot := spell.Dot(target)
 			if useSnapshot {
@@ -0,0 +1,50 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+//go:embed report.html.tmpl
+var htmlTemplateSource string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(value float64) float64 {
+		return value * 100
+	},
+}).Parse(htmlTemplateSource))
+
+// Chart data for one player, embedded into the report as JSON.
+type uptimeChart struct {
+	Name    string    `json:"name"`
+	Auras   []string  `json:"auras"`
+	Uptimes []float64 `json:"uptimes"`
+}
+
+// Writes a standalone HTML page for the result, which can be opened without the web app.
+func WriteHTML(w io.Writer, result *proto.RaidSimResult) error {
+	summary, err := summarize(result)
+	if err != nil {
+		return err
+	}
+
+	charts := make([]uptimeChart, len(summary.Players))
+	for idx, player := range summary.Players {
+		charts[idx].Name = player.Name
+		for _, aura := range player.Auras {
+			charts[idx].Auras = append(charts[idx].Auras, aura.Label)
+			charts[idx].Uptimes = append(charts[idx].Uptimes, aura.Uptime*100)
+		}
+	}
+
+	return htmlTemplate.Execute(w, struct {
+		*raidSummary
+		Charts []uptimeChart
+	}{
+		raidSummary: summary,
+		Charts:      charts,
+	})
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+// How many entries of each list the markdown summary shows.
+const (
+	markdownTopActions = 5
+	markdownTopAuras   = 5
+)
+
+// Auras with at least this uptime are considered permanent, and left out of the
+// markdown summary's key uptimes.
+const permanentAuraUptime = 0.995
+
+// Encodes the individual sim settings of the request the same way the web
+// app's shareable links do, for use after the '#' of a sim link.
+func SettingsHash(request *proto.RaidSimRequest) (string, error) {
+	if len(request.GetRaid().GetParties()) == 0 || len(request.Raid.Parties[0].Players) == 0 {
+		return "", fmt.Errorf("request has no player")
+	}
+
+	settings := &proto.IndividualSimSettings{
+		Settings: &proto.SimSettings{
+			Iterations: request.GetSimOptions().GetIterations(),
+		},
+		RaidBuffs:  request.Raid.Buffs,
+		Debuffs:    request.Raid.Debuffs,
+		Tanks:      request.Raid.Tanks,
+		PartyBuffs: request.Raid.Parties[0].Buffs,
+		Player:     request.Raid.Parties[0].Players[0],
+		Encounter:  request.Encounter,
+	}
+
+	data, err := goproto.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// Writes a compact markdown summary of the result, suitable for chat messages
+// e.g. on Discord. If settingsLink is set, it is included so others can import
+// the settings which produced the result.
+func WriteMarkdown(w io.Writer, result *proto.RaidSimResult, settingsLink string) error {
+	summary, err := summarize(result)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	if len(summary.Players) != 1 {
+		fmt.Fprintf(&sb, "**Raid DPS: %s**\n", formatDps(summary.Dps, summary.DpsCI95))
+	}
+	for _, player := range summary.Players {
+		fmt.Fprintf(&sb, "**%s: %s DPS**\n", player.Name, formatDps(player.Dps, player.DpsCI95))
+
+		if len(player.Actions) > 0 {
+			sb.WriteString("Top spells:\n")
+			for _, action := range player.Actions[:min(len(player.Actions), markdownTopActions)] {
+				fmt.Fprintf(&sb, "- %s: %.1f%% (%.0f dmg)\n", markdownLink(action.Label, action.URL), action.DamageShare*100, action.Damage)
+			}
+		}
+
+		auras := 0
+		for _, aura := range player.Auras {
+			if aura.Uptime >= permanentAuraUptime {
+				continue
+			}
+			if auras == 0 {
+				sb.WriteString("Key uptimes: ")
+			} else {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%s %.1f%%", markdownLink(aura.Label, aura.URL), aura.Uptime*100)
+			if auras++; auras == markdownTopAuras {
+				break
+			}
+		}
+		if auras > 0 {
+			sb.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&sb, "-# %d iterations, %.0fs average duration", summary.Iterations, summary.Duration)
+	if settingsLink != "" {
+		fmt.Fprintf(&sb, ", [settings](<%s>)", settingsLink)
+	}
+	sb.WriteString("\n")
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func formatDps(dps float64, ci95 float64) string {
+	return fmt.Sprintf("%.0f ± %.0f", dps, ci95)
+}
+
+// Links are wrapped in <> so Discord doesn't embed a preview for each of them.
+func markdownLink(label string, url string) string {
+	if url == "" {
+		return label
+	}
+	return fmt.Sprintf("[%s](<%s>)", label, url)
+}
@@ -0,0 +1,199 @@
+// Package report renders sim results into formats meant for sharing outside of
+// the web app.
+package report
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const wowheadURL = "https://wowhead.com/mop-classic"
+
+// Flattened view of a RaidSimResult, shared by all report formats.
+type raidSummary struct {
+	Iterations int32
+	Duration   float64
+	Dps        float64
+	DpsStdev   float64
+	DpsCI95    float64
+	Hps        float64
+	Players    []*unitSummary
+}
+
+type unitSummary struct {
+	Name     string
+	Dps      float64
+	DpsStdev float64
+	// Half width of the 95% confidence interval of the mean dps.
+	DpsCI95 float64
+	Hps     float64
+
+	Actions     []*actionSummary // Sorted by damage, highest first.
+	Auras       []*auraSummary   // Sorted by uptime, highest first.
+	Suggestions []string
+}
+
+type actionSummary struct {
+	Label string
+	URL   string
+
+	// Per iteration averages, summed over all targets.
+	Damage float64
+	Casts  float64
+	// Share of the unit's total damage, from 0 to 1.
+	DamageShare float64
+}
+
+type auraSummary struct {
+	Label  string
+	URL    string
+	Uptime float64 // From 0 to 1.
+	Procs  float64
+}
+
+func summarize(result *proto.RaidSimResult) (*raidSummary, error) {
+	if result.Error != nil {
+		return nil, fmt.Errorf("cannot report on a failed sim: %s", result.Error.Message)
+	}
+	if result.RaidMetrics == nil {
+		return nil, fmt.Errorf("result has no raid metrics")
+	}
+
+	summary := &raidSummary{
+		Iterations: result.IterationsDone,
+		Duration:   result.AvgIterationDuration,
+		Dps:        result.RaidMetrics.Dps.GetAvg(),
+		DpsStdev:   result.RaidMetrics.Dps.GetStdev(),
+		Hps:        result.RaidMetrics.Hps.GetAvg(),
+	}
+	summary.DpsCI95 = ci95(summary.DpsStdev, summary.Iterations)
+
+	for _, party := range result.RaidMetrics.Parties {
+		for _, player := range party.Players {
+			summary.Players = append(summary.Players, summarizeUnit(player, summary.Iterations, summary.Duration))
+		}
+	}
+
+	return summary, nil
+}
+
+func summarizeUnit(unit *proto.UnitMetrics, iterations int32, duration float64) *unitSummary {
+	summary := &unitSummary{
+		Name:        unit.Name,
+		Dps:         unit.Dps.GetAvg(),
+		DpsStdev:    unit.Dps.GetStdev(),
+		Hps:         unit.Hps.GetAvg(),
+		Suggestions: unit.Suggestions,
+	}
+	summary.DpsCI95 = ci95(summary.DpsStdev, iterations)
+
+	summary.Actions = summarizeActions(unit.Actions, "", iterations)
+	for _, pet := range unit.Pets {
+		summary.Actions = append(summary.Actions, summarizeActions(pet.Actions, pet.Name, iterations)...)
+	}
+
+	totalDamage := 0.0
+	for _, action := range summary.Actions {
+		totalDamage += action.Damage
+	}
+	if totalDamage > 0 {
+		for _, action := range summary.Actions {
+			action.DamageShare = action.Damage / totalDamage
+		}
+	}
+	slices.SortStableFunc(summary.Actions, func(a, b *actionSummary) int {
+		return compareDesc(a.Damage, b.Damage)
+	})
+
+	for _, aura := range unit.Auras {
+		if aura.UptimeSecondsAvg <= 0 || duration <= 0 {
+			continue
+		}
+		label, url := actionLabel(aura.Id)
+		summary.Auras = append(summary.Auras, &auraSummary{
+			Label:  label,
+			URL:    url,
+			Uptime: min(aura.UptimeSecondsAvg/duration, 1),
+			Procs:  aura.ProcsAvg,
+		})
+	}
+	slices.SortStableFunc(summary.Auras, func(a, b *auraSummary) int {
+		return compareDesc(a.Uptime, b.Uptime)
+	})
+
+	return summary
+}
+
+func summarizeActions(actions []*proto.ActionMetrics, owner string, iterations int32) []*actionSummary {
+	if iterations <= 0 {
+		return nil
+	}
+
+	var summaries []*actionSummary
+	for _, action := range actions {
+		damage, casts := 0.0, 0.0
+		for _, target := range action.Targets {
+			damage += target.Damage
+			casts += float64(target.Casts)
+		}
+		if damage == 0 && casts == 0 {
+			continue
+		}
+
+		label, url := actionLabel(action.Id)
+		if owner != "" {
+			label = owner + ": " + label
+		}
+		summaries = append(summaries, &actionSummary{
+			Label:  label,
+			URL:    url,
+			Damage: damage / float64(iterations),
+			Casts:  casts / float64(iterations),
+		})
+	}
+	return summaries
+}
+
+// Returns a readable label for the action, and a wowhead link if it has one.
+// Results don't contain spell names, so spells and items are labeled by ID.
+func actionLabel(id *proto.ActionID) (string, string) {
+	var label, url string
+	switch rawID := id.GetRawId().(type) {
+	case *proto.ActionID_SpellId:
+		label = fmt.Sprintf("Spell %d", rawID.SpellId)
+		url = fmt.Sprintf("%s/spell=%d", wowheadURL, rawID.SpellId)
+	case *proto.ActionID_ItemId:
+		label = fmt.Sprintf("Item %d", rawID.ItemId)
+		url = fmt.Sprintf("%s/item=%d", wowheadURL, rawID.ItemId)
+	case *proto.ActionID_OtherId:
+		label = strings.TrimPrefix(rawID.OtherId.String(), "OtherAction")
+	default:
+		label = "Unknown"
+	}
+
+	if id.GetTag() != 0 {
+		label = fmt.Sprintf("%s (%d)", label, id.GetTag())
+	}
+	return label, url
+}
+
+// Half width of the 95% confidence interval of a mean.
+func ci95(stdev float64, iterations int32) float64 {
+	if iterations <= 0 {
+		return 0
+	}
+	return 1.96 * stdev / math.Sqrt(float64(iterations))
+}
+
+func compareDesc(a, b float64) int {
+	if a > b {
+		return -1
+	} else if a < b {
+		return 1
+	}
+	return 0
+}
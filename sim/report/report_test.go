@@ -0,0 +1,84 @@
+package report
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func testResult() *proto.RaidSimResult {
+	spellID := func(id int32) *proto.ActionID {
+		return &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: id}}
+	}
+
+	return &proto.RaidSimResult{
+		IterationsDone:       100,
+		AvgIterationDuration: 200,
+		RaidMetrics: &proto.RaidMetrics{
+			Dps: &proto.DistributionMetrics{Avg: 1000, Stdev: 100},
+			Parties: []*proto.PartyMetrics{{
+				Players: []*proto.UnitMetrics{{
+					Name: "Player",
+					Dps:  &proto.DistributionMetrics{Avg: 1000, Stdev: 100},
+					Actions: []*proto.ActionMetrics{
+						{Id: spellID(1), Targets: []*proto.TargetedActionMetrics{{Casts: 100, Damage: 100000}}},
+						{Id: spellID(2), Targets: []*proto.TargetedActionMetrics{{Casts: 200, Damage: 200000}, {Casts: 100, Damage: 100000}}},
+					},
+					Auras: []*proto.AuraMetrics{
+						{Id: spellID(3), UptimeSecondsAvg: 50},
+						{Id: spellID(4), UptimeSecondsAvg: 150},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary, err := summarize(testResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player := summary.Players[0]
+	if math.Abs(player.DpsCI95-19.6) > 1e-9 {
+		t.Errorf("Expected 95%% CI of 19.6, got %f", player.DpsCI95)
+	}
+
+	if player.Actions[0].Label != "Spell 2" || player.Actions[0].Damage != 3000 || player.Actions[0].Casts != 3 {
+		t.Errorf("Expected Spell 2 to be summed over targets and sorted first, got %+v", player.Actions[0])
+	}
+	if player.Actions[0].DamageShare != 0.75 {
+		t.Errorf("Expected Spell 2 to do 75%% of the damage, got %f", player.Actions[0].DamageShare)
+	}
+
+	if player.Auras[0].Label != "Spell 4" || player.Auras[0].Uptime != 0.75 {
+		t.Errorf("Expected Spell 4 to be sorted first with 75%% uptime, got %+v", player.Auras[0])
+	}
+}
+
+func TestSummarizeError(t *testing.T) {
+	_, err := summarize(&proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: "failed"}})
+	if err == nil {
+		t.Fatal("Expected an error for a failed sim")
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteMarkdown(&sb, testResult(), "https://example.com/#abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "**Player: 1000 ± 20 DPS**\n" +
+		"Top spells:\n" +
+		"- [Spell 2](<https://wowhead.com/mop-classic/spell=2>): 75.0% (3000 dmg)\n" +
+		"- [Spell 1](<https://wowhead.com/mop-classic/spell=1>): 25.0% (1000 dmg)\n" +
+		"Key uptimes: [Spell 4](<https://wowhead.com/mop-classic/spell=4>) 75.0%, [Spell 3](<https://wowhead.com/mop-classic/spell=3>) 25.0%\n" +
+		"-# 100 iterations, 200s average duration, [settings](<https://example.com/#abc>)\n"
+	if sb.String() != expected {
+		t.Errorf("Unexpected markdown summary:\n%s", sb.String())
+	}
+}
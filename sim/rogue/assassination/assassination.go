@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/rogue"
+	"github.com/wowsims/mop/ui"
 )
 
 // Damage Done By Caster setup
@@ -28,6 +29,7 @@ func RegisterAssassinationRogue() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecAssassinationRogue, core.LoadAPLPresets(ui.APLs, "rogue/assassination/apls")...)
 }
 
 func (sinRogue *AssassinationRogue) Initialize() {
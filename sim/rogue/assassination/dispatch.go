@@ -10,6 +10,7 @@ import (
 func (sinRogue *AssassinationRogue) registerDispatch() {
 	addedDamage := sinRogue.GetBaseDamageFromCoefficient(0.62900000811)
 	weaponPercent := 6.45
+	executePhase := sinRogue.ExecutePhase(35)
 
 	sinRogue.Dispatch = sinRogue.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 111240},
@@ -30,7 +31,7 @@ func (sinRogue *AssassinationRogue) registerDispatch() {
 			IgnoreHaste: true,
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sinRogue.HasDagger(core.MainHand) && (sim.IsExecutePhase35() || sinRogue.HasActiveAura("Blindside"))
+			return sinRogue.HasDagger(core.MainHand) && (executePhase.IsActive() || sinRogue.HasActiveAura("Blindside"))
 		},
 
 		DamageMultiplier:         weaponPercent,
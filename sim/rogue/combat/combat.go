@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/rogue"
+	"github.com/wowsims/mop/ui"
 )
 
 // Damage Done By Caster setup
@@ -30,6 +31,7 @@ func RegisterCombatRogue() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecCombatRogue, core.LoadAPLPresets(ui.APLs, "rogue/combat/apls")...)
 }
 
 func NewCombatRogue(character *core.Character, options *proto.Player) *CombatRogue {
@@ -13,7 +13,7 @@ func (rogue *Rogue) registerGarrote() {
 		ActionID:       core.ActionID{SpellID: 703},
 		SpellSchool:    core.SpellSchoolPhysical,
 		ProcMask:       core.ProcMaskMeleeMHSpecial,
-		Flags:          core.SpellFlagMeleeMetrics | SpellFlagBuilder | core.SpellFlagAPL,
+		Flags:          core.SpellFlagMeleeMetrics | SpellFlagBuilder | core.SpellFlagAPL | core.SpellFlagBleed,
 		ClassSpellMask: RogueSpellGarrote,
 
 		EnergyCost: core.EnergyCostOptions{
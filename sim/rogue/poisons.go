@@ -45,7 +45,7 @@ func (rogue *Rogue) registerDeadlyPoisonSpell() {
 		SpellSchool:    core.SpellSchoolNature,
 		ProcMask:       core.ProcMaskSpellDamageProc,
 		ClassSpellMask: RogueSpellDeadlyPoison,
-		Flags:          core.SpellFlagPassiveSpell,
+		Flags:          core.SpellFlagPassiveSpell | core.SpellFlagPoison,
 
 		DamageMultiplier:         1,
 		DamageMultiplierAdditive: 1,
@@ -66,7 +66,7 @@ func (rogue *Rogue) registerDeadlyPoisonSpell() {
 		SpellSchool:    core.SpellSchoolNature,
 		ProcMask:       core.ProcMaskSpellDamageProc,
 		ClassSpellMask: RogueSpellDeadlyPoison,
-		Flags:          core.SpellFlagPassiveSpell,
+		Flags:          core.SpellFlagPassiveSpell | core.SpellFlagPoison,
 
 		DamageMultiplier:         1,
 		DamageMultiplierAdditive: 1,
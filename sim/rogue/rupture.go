@@ -20,7 +20,7 @@ func (rogue *Rogue) registerRupture() {
 		ActionID:       core.ActionID{SpellID: RuptureSpellID},
 		SpellSchool:    core.SpellSchoolPhysical,
 		ProcMask:       core.ProcMaskMeleeMHSpecial,
-		Flags:          core.SpellFlagMeleeMetrics | SpellFlagFinisher | core.SpellFlagAPL,
+		Flags:          core.SpellFlagMeleeMetrics | SpellFlagFinisher | core.SpellFlagAPL | core.SpellFlagBleed,
 		MetricSplits:   6,
 		ClassSpellMask: RogueSpellRupture,
 
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/rogue"
+	"github.com/wowsims/mop/ui"
 )
 
 // Damage Done By Caster setup
@@ -29,6 +30,7 @@ func RegisterSubtletyRogue() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecSubtletyRogue, core.LoadAPLPresets(ui.APLs, "rogue/subtlety/apls")...)
 }
 
 func (subRogue *SubtletyRogue) Initialize() {
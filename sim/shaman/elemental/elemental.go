@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/shaman"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterElementalShaman() {
@@ -22,6 +23,7 @@ func RegisterElementalShaman() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecElementalShaman, core.LoadAPLPresets(ui.APLs, "shaman/elemental/apls")...)
 }
 
 func NewElementalShaman(character *core.Character, options *proto.Player) *ElementalShaman {
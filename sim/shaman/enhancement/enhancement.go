@@ -7,6 +7,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/shaman"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterEnhancementShaman() {
@@ -24,6 +25,7 @@ func RegisterEnhancementShaman() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecEnhancementShaman, core.LoadAPLPresets(ui.APLs, "shaman/enhancement/apls")...)
 }
 
 func NewEnhancementShaman(character *core.Character, options *proto.Player) *EnhancementShaman {
@@ -44,7 +44,12 @@ func (shaman *Shaman) ApplyEnhancementTalents() {
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
 			shaman.MultiplyMeleeSpeed(sim, 1/1.15)
 		},
-	}).AttachStatDependency(shaman.NewDynamicMultiplyStat(stats.HasteRating, 1.5))
+	}).AttachStatBonus(core.AuraStatBonusConfig{
+		Mode:       core.StatBonusDynamic,
+		SourceStat: stats.HasteRating,
+		TargetStat: stats.HasteRating,
+		Ratio:      0.5,
+	})
 
 	core.MakeProcTriggerAura(&shaman.Unit, core.ProcTrigger{
 		Name:     "Flurry",
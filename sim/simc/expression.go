@@ -0,0 +1,242 @@
+package simc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenIdentifier
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// Longest operators first, so e.g. "<=" isn't read as "<" followed by "=".
+var operators = []string{"<=", ">=", "!=", "<?", ">?", "!~", "&", "|", "^", "!", "=", "<", ">", "+", "-", "*", "%", "~", "@"}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")"})
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(expr) && (unicode.IsDigit(rune(expr[i])) || expr[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[start:i]})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(expr) && (unicode.IsLetter(rune(expr[i])) || unicode.IsDigit(rune(expr[i])) || expr[i] == '_' || expr[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: expr[start:i]})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(expr[i:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// Binary operators from lowest to highest precedence, as in SimulationCraft.
+var precedence = [][]string{
+	{"|"},
+	{"^"},
+	{"&"},
+	{"=", "!=", "<", "<=", ">", ">=", "~", "!~"},
+	{"+", "-", "<?", ">?"},
+	{"*", "%"},
+}
+
+// Recursive descent parser for SimulationCraft conditional expressions.
+type expressionParser struct {
+	importer *importer
+	tokens   []token
+	pos      int
+
+	// Spell of the action the expression belongs to, for operands such as
+	// "remains" which implicitly refer to it. Nil for non-spell actions.
+	actionSpell *proto.ActionID
+}
+
+func (parser *expressionParser) peek() *token {
+	if parser.pos < len(parser.tokens) {
+		return &parser.tokens[parser.pos]
+	}
+	return nil
+}
+
+func (parser *expressionParser) parseBinary(level int) (*proto.APLValue, error) {
+	if level == len(precedence) {
+		return parser.parseUnary()
+	}
+
+	lhs, err := parser.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		next := parser.peek()
+		if next == nil || next.kind != tokenOperator || !slices.Contains(precedence[level], next.text) {
+			return lhs, nil
+		}
+		parser.pos++
+
+		rhs, err := parser.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		if lhs, err = binaryValue(next.text, lhs, rhs); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (parser *expressionParser) parseUnary() (*proto.APLValue, error) {
+	next := parser.peek()
+	if next == nil {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	if next.kind == tokenOperator {
+		parser.pos++
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		switch next.text {
+		case "!":
+			return notValue(operand), nil
+		case "-":
+			return mathValue(proto.APLValueMath_OpSub, constValue("0"), operand), nil
+		case "+":
+			return operand, nil
+		default:
+			return nil, fmt.Errorf("unsupported operator %q", next.text)
+		}
+	}
+
+	return parser.parsePrimary()
+}
+
+func (parser *expressionParser) parsePrimary() (*proto.APLValue, error) {
+	next := parser.peek()
+	parser.pos++
+
+	switch next.kind {
+	case tokenLeftParen:
+		value, err := parser.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		if closing := parser.peek(); closing == nil || closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		parser.pos++
+		return value, nil
+	case tokenNumber:
+		return constValue(next.text), nil
+	case tokenIdentifier:
+		return parser.importer.operand(next.text, parser.actionSpell)
+	default:
+		return nil, fmt.Errorf("unexpected %q", next.text)
+	}
+}
+
+// Parses a conditional expression into an APL value. Expressions which use
+// anything without an APL equivalent are rejected as a whole, since dropping
+// part of a condition would change what the action does.
+func (importer *importer) parseExpression(expr string, actionSpell *proto.ActionID) (*proto.APLValue, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	parser := &expressionParser{
+		importer:    importer,
+		tokens:      tokens,
+		actionSpell: actionSpell,
+	}
+	value, err := parser.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(tokens) {
+		return nil, fmt.Errorf("unexpected %q", tokens[parser.pos].text)
+	}
+	return value, nil
+}
+
+func binaryValue(op string, lhs *proto.APLValue, rhs *proto.APLValue) (*proto.APLValue, error) {
+	switch op {
+	case "|":
+		return orValue(lhs, rhs), nil
+	case "&":
+		return andValue(lhs, rhs), nil
+	case "^":
+		// Exclusive or, which on two bools is the same as not equal.
+		return compareValue(proto.APLValueCompare_OpNe, andValue(lhs), andValue(rhs)), nil
+	case "=":
+		return compareValue(proto.APLValueCompare_OpEq, lhs, rhs), nil
+	case "!=":
+		return compareValue(proto.APLValueCompare_OpNe, lhs, rhs), nil
+	case "<":
+		return compareValue(proto.APLValueCompare_OpLt, lhs, rhs), nil
+	case "<=":
+		return compareValue(proto.APLValueCompare_OpLe, lhs, rhs), nil
+	case ">":
+		return compareValue(proto.APLValueCompare_OpGt, lhs, rhs), nil
+	case ">=":
+		return compareValue(proto.APLValueCompare_OpGe, lhs, rhs), nil
+	case "+":
+		return mathValue(proto.APLValueMath_OpAdd, lhs, rhs), nil
+	case "-":
+		return mathValue(proto.APLValueMath_OpSub, lhs, rhs), nil
+	case "*":
+		return mathValue(proto.APLValueMath_OpMul, lhs, rhs), nil
+	case "%":
+		// SimulationCraft uses % for division, since / separates actions.
+		return mathValue(proto.APLValueMath_OpDiv, lhs, rhs), nil
+	case "<?":
+		return &proto.APLValue{Value: &proto.APLValue_Max{Max: &proto.APLValueMax{Vals: []*proto.APLValue{lhs, rhs}}}}, nil
+	case ">?":
+		return &proto.APLValue{Value: &proto.APLValue_Min{Min: &proto.APLValueMin{Vals: []*proto.APLValue{lhs, rhs}}}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+}
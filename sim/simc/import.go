@@ -0,0 +1,312 @@
+// Package simc converts SimulationCraft action priority lists into the APL
+// format used by the sim.
+package simc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	googleproto "google.golang.org/protobuf/proto"
+)
+
+// Actions which the sim handles on its own, and are dropped without a warning.
+var implicitActions = map[string]bool{
+	"auto_attack":    true,
+	"auto_shot":      true,
+	"snapshot_stats": true,
+	"flask":          true,
+	"food":           true,
+	"augmentation":   true,
+}
+
+// Limit on nested call_action_list, which also guards against lists calling each other.
+const maxListDepth = 8
+
+type simcAction struct {
+	line    int
+	name    string
+	options map[string]string
+	order   []string // Option names in the order they were given.
+}
+
+type importer struct {
+	spells map[string]*proto.ActionID
+
+	lists     map[string][]*simcAction
+	variables map[string]*proto.APLValue
+	warnings  []string
+}
+
+// Converts the action lists of a SimulationCraft profile into an APL rotation.
+// Spells, auras and items are referenced by their SimulationCraft names, e.g.
+// "tigers_fury", which spells maps to action IDs.
+//
+// Actions which can't be converted are skipped, and the returned warnings
+// explain why. Lines of the profile other than actions are ignored, so a full
+// profile can be imported as is.
+func ImportAPL(profile string, spells map[string]*proto.ActionID) (*proto.APLRotation, []string) {
+	importer := &importer{
+		spells:    spells,
+		lists:     map[string][]*simcAction{},
+		variables: map[string]*proto.APLValue{},
+	}
+	importer.parseProfile(profile)
+
+	rotation := &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+	}
+	for _, action := range importer.convertList("precombat", nil, 0) {
+		rotation.PrepullActions = append(rotation.PrepullActions, &proto.APLPrepullAction{
+			Action:    action,
+			DoAtValue: constValue("-1s"),
+		})
+	}
+	for _, action := range importer.convertList("default", nil, 0) {
+		rotation.PriorityList = append(rotation.PriorityList, &proto.APLListItem{
+			Action: action,
+		})
+	}
+
+	if len(rotation.PriorityList) == 0 {
+		importer.warnings = append(importer.warnings, "no actions were imported")
+	}
+	return rotation, importer.warnings
+}
+
+func (importer *importer) warn(line int, format string, args ...any) {
+	importer.warnings = append(importer.warnings, fmt.Sprintf("line %d: ", line)+fmt.Sprintf(format, args...))
+}
+
+func (importer *importer) lookup(name string) (*proto.ActionID, error) {
+	actionId, ok := importer.spells[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown spell %q", name)
+	}
+	return googleproto.Clone(actionId).(*proto.ActionID), nil
+}
+
+// Reads lines such as "actions.cooldowns+=/berserk,if=buff.tigers_fury.up"
+// into the action lists. "actions=" and "actions+=" belong to the default list.
+func (importer *importer) parseProfile(profile string) {
+	for i, line := range strings.Split(profile, "\n") {
+		lineNumber := i + 1
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "actions") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			importer.warn(lineNumber, "expected an assignment")
+			continue
+		}
+
+		appending := strings.HasSuffix(key, "+")
+		key = strings.TrimSuffix(key, "+")
+		listName := "default"
+		if key != "actions" {
+			var isList bool
+			listName, isList = strings.CutPrefix(key, "actions.")
+			if !isList || listName == "" {
+				continue
+			}
+		}
+		if !appending {
+			importer.lists[listName] = nil
+		}
+
+		// Actions are separated by slashes, which is why expressions use % for division.
+		for _, actionString := range strings.Split(value, "/") {
+			if actionString == "" {
+				continue
+			}
+			if action := importer.parseAction(lineNumber, actionString); action != nil {
+				importer.lists[listName] = append(importer.lists[listName], action)
+			}
+		}
+	}
+}
+
+func (importer *importer) parseAction(line int, actionString string) *simcAction {
+	fields := strings.Split(actionString, ",")
+	action := &simcAction{
+		line:    line,
+		name:    strings.TrimSpace(fields[0]),
+		options: map[string]string{},
+	}
+
+	for _, field := range fields[1:] {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			importer.warn(line, "%s: option %q has no value", action.name, field)
+			return nil
+		}
+		action.options[name] = value
+		action.order = append(action.order, name)
+	}
+	return action
+}
+
+// Converts the actions of a list, with the condition of the calling
+// call_action_list (if any) added to each of them.
+func (importer *importer) convertList(listName string, condition *proto.APLValue, depth int) []*proto.APLAction {
+	var actions []*proto.APLAction
+	for _, simcAction := range importer.lists[listName] {
+		actions = append(actions, importer.convertAction(simcAction, condition, depth)...)
+	}
+	return actions
+}
+
+func (importer *importer) convertAction(action *simcAction, listCondition *proto.APLValue, depth int) []*proto.APLAction {
+	if implicitActions[action.name] {
+		return nil
+	}
+
+	var spellId *proto.ActionID
+	switch action.name {
+	case "variable", "call_action_list", "run_action_list", "wait", "cancel_buff":
+	case "use_item":
+		if itemId, err := importer.lookup(action.options["name"]); err == nil {
+			spellId = itemId
+		} else {
+			importer.warn(action.line, "use_item: %s", err)
+			return nil
+		}
+	default:
+		var err error
+		if spellId, err = importer.lookup(action.name); err != nil {
+			importer.warn(action.line, "%s", err)
+			return nil
+		}
+	}
+
+	for _, option := range action.order {
+		if !supportedOption(action.name, option) {
+			importer.warn(action.line, "%s: option %q is not supported and was ignored", action.name, option)
+		}
+	}
+
+	var condition *proto.APLValue
+	if listCondition != nil {
+		condition = googleproto.Clone(listCondition).(*proto.APLValue)
+	}
+	if expr, ok := action.options["if"]; ok {
+		value, err := importer.parseExpression(expr, spellId)
+		if err != nil {
+			importer.warn(action.line, "%s was skipped: %s", action.name, err)
+			return nil
+		}
+		if condition != nil {
+			condition = andValue(condition, value)
+		} else {
+			condition = value
+		}
+	}
+
+	switch action.name {
+	case "variable":
+		importer.setVariable(action, condition)
+		return nil
+
+	case "call_action_list", "run_action_list":
+		listName := action.options["name"]
+		if _, ok := importer.lists[listName]; !ok {
+			importer.warn(action.line, "%s: unknown action list %q", action.name, listName)
+			return nil
+		}
+		if depth >= maxListDepth {
+			importer.warn(action.line, "%s: action lists are nested too deeply", action.name)
+			return nil
+		}
+		if action.name == "run_action_list" {
+			importer.warn(action.line, "run_action_list was imported as call_action_list, so actions after it are still used when none of %q are ready", listName)
+		}
+		// APLs have a single priority list, so the called list is inlined.
+		return importer.convertList(listName, condition, depth+1)
+
+	case "wait":
+		duration, err := importer.parseExpression(action.options["sec"], nil)
+		if err != nil {
+			importer.warn(action.line, "wait was skipped: %s", err)
+			return nil
+		}
+		return []*proto.APLAction{{
+			Condition: condition,
+			Action:    &proto.APLAction_Wait{Wait: &proto.APLActionWait{Duration: duration}},
+		}}
+
+	case "cancel_buff":
+		auraId, err := importer.lookup(action.options["name"])
+		if err != nil {
+			importer.warn(action.line, "cancel_buff was skipped: %s", err)
+			return nil
+		}
+		return []*proto.APLAction{{
+			Condition: condition,
+			Action:    &proto.APLAction_CancelAura{CancelAura: &proto.APLActionCancelAura{AuraId: auraId}},
+		}}
+	}
+
+	if expr, ok := action.options["interrupt_if"]; ok {
+		interruptIf, err := importer.parseExpression(expr, spellId)
+		if err != nil {
+			importer.warn(action.line, "%s was skipped: %s", action.name, err)
+			return nil
+		}
+		return []*proto.APLAction{{
+			Condition: condition,
+			Action:    &proto.APLAction_ChannelSpell{ChannelSpell: &proto.APLActionChannelSpell{SpellId: spellId, InterruptIf: interruptIf}},
+		}}
+	}
+
+	return []*proto.APLAction{{
+		Condition: condition,
+		Action:    &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellId}},
+	}}
+}
+
+// Variables set once, without a condition, are constants, so they're replaced
+// by their value wherever they're used instead of becoming APL variables.
+// Other variables would need Set Variable actions and aren't supported.
+func (importer *importer) setVariable(action *simcAction, condition *proto.APLValue) {
+	name := action.options["name"]
+	if op, ok := action.options["op"]; ok && op != "set" {
+		importer.warn(action.line, "variable %q: op %q is not supported", name, op)
+		return
+	}
+	if condition != nil {
+		importer.warn(action.line, "variable %q: conditional variables are not supported", name)
+		return
+	}
+	if _, ok := importer.variables[name]; ok {
+		importer.warn(action.line, "variable %q: variables can only be set once", name)
+		return
+	}
+
+	value, err := importer.parseExpression(action.options["value"], nil)
+	if err != nil {
+		importer.warn(action.line, "variable %q: %s", name, err)
+		return
+	}
+	importer.variables[name] = value
+}
+
+func supportedOption(actionName string, option string) bool {
+	switch option {
+	case "if":
+		return true
+	case "name":
+		switch actionName {
+		case "variable", "call_action_list", "run_action_list", "cancel_buff", "use_item":
+			return true
+		}
+	case "value", "op":
+		return actionName == "variable"
+	case "sec":
+		return actionName == "wait"
+	case "interrupt_if":
+		return true
+	}
+	return false
+}
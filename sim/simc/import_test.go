@@ -0,0 +1,140 @@
+package simc
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleproto "google.golang.org/protobuf/proto"
+)
+
+var testSpells = map[string]*proto.ActionID{
+	"rake":        {RawId: &proto.ActionID_SpellId{SpellId: 1822}},
+	"shred":       {RawId: &proto.ActionID_SpellId{SpellId: 5221}},
+	"tigers_fury": {RawId: &proto.ActionID_SpellId{SpellId: 5217}},
+	"berserk":     {RawId: &proto.ActionID_SpellId{SpellId: 106951}},
+}
+
+// Parses the expected APL value from protojson, which is easier to read than proto literals.
+func parseValue(t *testing.T, json string) *proto.APLValue {
+	value := &proto.APLValue{}
+	if err := protojson.Unmarshal([]byte(json), value); err != nil {
+		t.Fatalf("Invalid expected value: %s", err)
+	}
+	return value
+}
+
+func TestImportAPL(t *testing.T) {
+	profile := strings.Join([]string{
+		`druid="Test"`,
+		`actions.precombat=flask`,
+		`actions.precombat+=/rake`,
+		`actions=auto_attack`,
+		`actions+=/variable,name=pool,value=energy.deficit>40`,
+		`actions+=/call_action_list,name=cooldowns,if=time>1`,
+		`actions+=/rake,if=!ticking|remains<4.5`,
+		`actions+=/shred,if=variable.pool&target.health.pct<=35`,
+		`actions+=/potion`,
+		`actions.cooldowns=tigers_fury,if=energy<35`,
+		`actions.cooldowns+=/berserk,if=buff.tigers_fury.up,line_cd=30`,
+	}, "\n")
+
+	rotation, warnings := ImportAPL(profile, testSpells)
+
+	if len(rotation.PrepullActions) != 1 || rotation.PrepullActions[0].Action.GetCastSpell().SpellId.GetSpellId() != 1822 {
+		t.Fatalf("Expected a single prepull rake, got %v", rotation.PrepullActions)
+	}
+
+	var spellIds []int32
+	for _, item := range rotation.PriorityList {
+		spellIds = append(spellIds, item.Action.GetCastSpell().SpellId.GetSpellId())
+	}
+	if expected := []int32{5217, 106951, 1822, 5221}; !slices.Equal(spellIds, expected) {
+		t.Fatalf("Expected priority list %v, got %v", expected, spellIds)
+	}
+
+	// Conditions of call_action_list are added to each action of the called list.
+	expectedTigersFury := parseValue(t, `{"and":{"vals":[
+		{"cmp":{"op":"OpGt","lhs":{"currentTime":{}},"rhs":{"const":{"val":"1"}}}},
+		{"cmp":{"op":"OpLt","lhs":{"currentEnergy":{}},"rhs":{"const":{"val":"35"}}}}
+	]}}`)
+	if condition := rotation.PriorityList[0].Action.Condition; !googleproto.Equal(condition, expectedTigersFury) {
+		t.Errorf("Unexpected tigers fury condition: %v", condition)
+	}
+
+	// Implicit operands refer to the action's own spell, and ! binds tighter than |.
+	expectedRake := parseValue(t, `{"or":{"vals":[
+		{"not":{"val":{"dotIsActive":{"spellId":{"spellId":1822}}}}},
+		{"cmp":{"op":"OpLt","lhs":{"dotRemainingTime":{"spellId":{"spellId":1822}}},"rhs":{"const":{"val":"4.5"}}}}
+	]}}`)
+	if condition := rotation.PriorityList[2].Action.Condition; !googleproto.Equal(condition, expectedRake) {
+		t.Errorf("Unexpected rake condition: %v", condition)
+	}
+
+	// Variables are inlined, and percentages are scaled to the APL's 0 to 1 range.
+	expectedShred := parseValue(t, `{"and":{"vals":[
+		{"cmp":{"op":"OpGt","lhs":{"math":{"op":"OpSub","lhs":{"maxEnergy":{}},"rhs":{"currentEnergy":{}}}},"rhs":{"const":{"val":"40"}}}},
+		{"cmp":{"op":"OpLe","lhs":{"math":{"op":"OpMul","lhs":{"currentHealthPercent":{"sourceUnit":{"type":"CurrentTarget"}}},"rhs":{"const":{"val":"100"}}}},"rhs":{"const":{"val":"35"}}}}
+	]}}`)
+	if condition := rotation.PriorityList[3].Action.Condition; !googleproto.Equal(condition, expectedShred) {
+		t.Errorf("Unexpected shred condition: %v", condition)
+	}
+
+	expectedWarnings := []string{
+		`line 11: berserk: option "line_cd" is not supported and was ignored`,
+		`line 9: unknown spell "potion"`,
+	}
+	if !slices.Equal(warnings, expectedWarnings) {
+		t.Errorf("Expected warnings %q, got %q", expectedWarnings, warnings)
+	}
+}
+
+func TestImportAPLUnsupported(t *testing.T) {
+	profile := strings.Join([]string{
+		`actions=rake,if=dot.rake.pmultiplier<1`,
+		`actions+=/shred,if=(energy>50`,
+		`actions+=/variable,name=x,op=add,value=1`,
+		`actions+=/call_action_list,name=missing`,
+	}, "\n")
+
+	rotation, warnings := ImportAPL(profile, testSpells)
+	if len(rotation.PriorityList) != 0 {
+		t.Errorf("Expected every action to be skipped, got %v", rotation.PriorityList)
+	}
+
+	expectedWarnings := []string{
+		`line 1: rake was skipped: unsupported operand "dot.rake.pmultiplier"`,
+		`line 2: shred was skipped: missing closing parenthesis`,
+		`line 3: variable "x": op "add" is not supported`,
+		`line 4: call_action_list: unknown action list "missing"`,
+		`no actions were imported`,
+	}
+	if !slices.Equal(warnings, expectedWarnings) {
+		t.Errorf("Expected warnings %q, got %q", expectedWarnings, warnings)
+	}
+}
+
+func TestParseExpressionPrecedence(t *testing.T) {
+	importer := &importer{spells: testSpells}
+
+	// a+b*c%d, with * and % binding tighter than + and evaluated left to right.
+	value, err := importer.parseExpression("1+2*3%4", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := parseValue(t, `{"math":{"op":"OpAdd","lhs":{"const":{"val":"1"}},"rhs":{"math":{"op":"OpDiv",
+		"lhs":{"math":{"op":"OpMul","lhs":{"const":{"val":"2"}},"rhs":{"const":{"val":"3"}}}},
+		"rhs":{"const":{"val":"4"}}}}}}`)
+	if !googleproto.Equal(value, expected) {
+		t.Errorf("Unexpected value: %v", value)
+	}
+
+	if _, err := importer.parseExpression("energy>", nil); err == nil {
+		t.Errorf("Expected an error for an incomplete expression")
+	}
+	if _, err := importer.parseExpression("@energy", nil); err == nil {
+		t.Errorf("Expected an error for the unsupported abs operator")
+	}
+}
@@ -0,0 +1,257 @@
+package simc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	googleproto "google.golang.org/protobuf/proto"
+)
+
+func constValue(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+func andValue(vals ...*proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: vals}}}
+}
+
+func orValue(vals ...*proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: vals}}}
+}
+
+func notValue(val *proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: val}}}
+}
+
+func compareValue(op proto.APLValueCompare_ComparisonOperator, lhs *proto.APLValue, rhs *proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: op, Lhs: lhs, Rhs: rhs}}}
+}
+
+func mathValue(op proto.APLValueMath_MathOperator, lhs *proto.APLValue, rhs *proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: op, Lhs: lhs, Rhs: rhs}}}
+}
+
+// SimulationCraft percentages go from 0 to 100, APL ones from 0 to 1.
+func percentValue(val *proto.APLValue) *proto.APLValue {
+	return mathValue(proto.APLValueMath_OpMul, val, constValue("100"))
+}
+
+func currentTarget() *proto.UnitReference {
+	return &proto.UnitReference{Type: proto.UnitReference_CurrentTarget}
+}
+
+// Constructors of the values of the player's resources, keyed by the SimulationCraft resource name.
+var resourceValues = map[string]struct {
+	current func() *proto.APLValue
+	max     func() *proto.APLValue
+	regen   func() *proto.APLValue
+}{
+	"energy": {
+		current: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_CurrentEnergy{CurrentEnergy: &proto.APLValueCurrentEnergy{}}}
+		},
+		max: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_MaxEnergy{MaxEnergy: &proto.APLValueMaxEnergy{}}}
+		},
+		regen: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_EnergyRegenPerSecond{EnergyRegenPerSecond: &proto.APLValueEnergyRegenPerSecond{}}}
+		},
+	},
+	"focus": {
+		current: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_CurrentFocus{CurrentFocus: &proto.APLValueCurrentFocus{}}}
+		},
+		max: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_MaxFocus{MaxFocus: &proto.APLValueMaxFocus{}}}
+		},
+		regen: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_FocusRegenPerSecond{FocusRegenPerSecond: &proto.APLValueFocusRegenPerSecond{}}}
+		},
+	},
+	"rage": {
+		current: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_CurrentRage{CurrentRage: &proto.APLValueCurrentRage{}}}
+		},
+		max: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_MaxRage{MaxRage: &proto.APLValueMaxRage{}}}
+		},
+	},
+	"runic_power": {
+		current: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_CurrentRunicPower{CurrentRunicPower: &proto.APLValueCurrentRunicPower{}}}
+		},
+		max: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_MaxRunicPower{MaxRunicPower: &proto.APLValueMaxRunicPower{}}}
+		},
+	},
+	"combo_points": {
+		current: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_CurrentComboPoints{CurrentComboPoints: &proto.APLValueCurrentComboPoints{}}}
+		},
+		max: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_MaxComboPoints{MaxComboPoints: &proto.APLValueMaxComboPoints{}}}
+		},
+	},
+	"chi": {
+		current: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_MonkCurrentChi{MonkCurrentChi: &proto.APLValueMonkCurrentChi{}}}
+		},
+		max: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_MonkMaxChi{MonkMaxChi: &proto.APLValueMonkMaxChi{}}}
+		},
+	},
+	"mana": {
+		current: func() *proto.APLValue {
+			return &proto.APLValue{Value: &proto.APLValue_CurrentMana{CurrentMana: &proto.APLValueCurrentMana{}}}
+		},
+	},
+}
+
+// Converts a SimulationCraft expression operand, e.g. "buff.berserk.up", into
+// the equivalent APL value.
+func (importer *importer) operand(name string, actionSpell *proto.ActionID) (*proto.APLValue, error) {
+	unsupported := fmt.Errorf("unsupported operand %q", name)
+	parts := strings.Split(name, ".")
+
+	switch parts[0] {
+	case "buff", "debuff":
+		if len(parts) != 3 {
+			return nil, unsupported
+		}
+		auraId, err := importer.lookup(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		var sourceUnit *proto.UnitReference
+		if parts[0] == "debuff" {
+			sourceUnit = currentTarget()
+		}
+		switch parts[2] {
+		case "up":
+			return auraIsActive(sourceUnit, auraId), nil
+		case "down":
+			return notValue(auraIsActive(sourceUnit, auraId)), nil
+		case "remains":
+			return &proto.APLValue{Value: &proto.APLValue_AuraRemainingTime{AuraRemainingTime: &proto.APLValueAuraRemainingTime{SourceUnit: sourceUnit, AuraId: auraId}}}, nil
+		case "stack", "react":
+			return &proto.APLValue{Value: &proto.APLValue_AuraNumStacks{AuraNumStacks: &proto.APLValueAuraNumStacks{SourceUnit: sourceUnit, AuraId: auraId}}}, nil
+		}
+		return nil, unsupported
+
+	case "dot":
+		if len(parts) != 3 {
+			return nil, unsupported
+		}
+		spellId, err := importer.lookup(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return dotValue(parts[2], spellId, unsupported)
+
+	case "cooldown":
+		if len(parts) != 3 {
+			return nil, unsupported
+		}
+		spellId, err := importer.lookup(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return cooldownValue(parts[2], spellId, unsupported)
+
+	case "talent":
+		if len(parts) != 3 || parts[2] != "enabled" {
+			return nil, unsupported
+		}
+		spellId, err := importer.lookup(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return &proto.APLValue{Value: &proto.APLValue_SpellIsKnown{SpellIsKnown: &proto.APLValueSpellIsKnown{SpellId: spellId}}}, nil
+
+	case "variable":
+		if len(parts) != 2 {
+			return nil, unsupported
+		}
+		value, ok := importer.variables[parts[1]]
+		if !ok {
+			return nil, fmt.Errorf("variable %q is used before it is set", parts[1])
+		}
+		// Variables are inlined, so each use needs its own copy.
+		return googleproto.Clone(value).(*proto.APLValue), nil
+
+	case "spell_targets":
+		return &proto.APLValue{Value: &proto.APLValue_NumberTargets{NumberTargets: &proto.APLValueNumberTargets{}}}, nil
+	}
+
+	switch name {
+	case "time":
+		return &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}}, nil
+	case "fight_remains", "target.time_to_die":
+		return &proto.APLValue{Value: &proto.APLValue_RemainingTime{RemainingTime: &proto.APLValueRemainingTime{}}}, nil
+	case "active_enemies":
+		return &proto.APLValue{Value: &proto.APLValue_NumberTargets{NumberTargets: &proto.APLValueNumberTargets{}}}, nil
+	case "gcd.remains":
+		return &proto.APLValue{Value: &proto.APLValue_GcdTimeToReady{GcdTimeToReady: &proto.APLValueGCDTimeToReady{}}}, nil
+	case "health.pct":
+		return percentValue(&proto.APLValue{Value: &proto.APLValue_CurrentHealthPercent{CurrentHealthPercent: &proto.APLValueCurrentHealthPercent{}}}), nil
+	case "target.health.pct":
+		return percentValue(&proto.APLValue{Value: &proto.APLValue_CurrentHealthPercent{CurrentHealthPercent: &proto.APLValueCurrentHealthPercent{SourceUnit: currentTarget()}}}), nil
+	case "mana.pct":
+		return percentValue(&proto.APLValue{Value: &proto.APLValue_CurrentManaPercent{CurrentManaPercent: &proto.APLValueCurrentManaPercent{}}}), nil
+	}
+
+	if resource, ok := resourceValues[parts[0]]; ok {
+		switch {
+		case len(parts) == 1:
+			return resource.current(), nil
+		case len(parts) == 2 && parts[1] == "max" && resource.max != nil:
+			return resource.max(), nil
+		case len(parts) == 2 && parts[1] == "deficit" && resource.max != nil:
+			return mathValue(proto.APLValueMath_OpSub, resource.max(), resource.current()), nil
+		case len(parts) == 2 && parts[1] == "regen" && resource.regen != nil:
+			return resource.regen(), nil
+		}
+		return nil, unsupported
+	}
+
+	// Operands which implicitly refer to the spell of the action.
+	if actionSpell != nil && len(parts) == 1 {
+		switch name {
+		case "ticking", "remains":
+			return dotValue(name, actionSpell, unsupported)
+		case "cast_time":
+			return &proto.APLValue{Value: &proto.APLValue_SpellCastTime{SpellCastTime: &proto.APLValueSpellCastTime{SpellId: actionSpell}}}, nil
+		case "charges":
+			return cooldownValue(name, actionSpell, unsupported)
+		}
+	}
+
+	return nil, unsupported
+}
+
+func auraIsActive(sourceUnit *proto.UnitReference, auraId *proto.ActionID) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_AuraIsActive{AuraIsActive: &proto.APLValueAuraIsActive{SourceUnit: sourceUnit, AuraId: auraId}}}
+}
+
+func dotValue(property string, spellId *proto.ActionID, unsupported error) (*proto.APLValue, error) {
+	switch property {
+	case "ticking":
+		return &proto.APLValue{Value: &proto.APLValue_DotIsActive{DotIsActive: &proto.APLValueDotIsActive{SpellId: spellId}}}, nil
+	case "remains":
+		return &proto.APLValue{Value: &proto.APLValue_DotRemainingTime{DotRemainingTime: &proto.APLValueDotRemainingTime{SpellId: spellId}}}, nil
+	}
+	return nil, unsupported
+}
+
+func cooldownValue(property string, spellId *proto.ActionID, unsupported error) (*proto.APLValue, error) {
+	switch property {
+	case "ready", "up":
+		return &proto.APLValue{Value: &proto.APLValue_SpellIsReady{SpellIsReady: &proto.APLValueSpellIsReady{SpellId: spellId}}}, nil
+	case "remains":
+		return &proto.APLValue{Value: &proto.APLValue_SpellTimeToReady{SpellTimeToReady: &proto.APLValueSpellTimeToReady{SpellId: spellId}}}, nil
+	case "charges":
+		return &proto.APLValue{Value: &proto.APLValue_SpellNumCharges{SpellNumCharges: &proto.APLValueSpellNumCharges{SpellId: spellId}}}, nil
+	}
+	return nil, unsupported
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/warlock"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterAfflictionWarlock() {
@@ -24,6 +25,7 @@ func RegisterAfflictionWarlock() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecAfflictionWarlock, core.LoadAPLPresets(ui.APLs, "warlock/affliction/apls")...)
 }
 
 func NewAfflictionWarlock(character *core.Character, options *proto.Player) *AfflictionWarlock {
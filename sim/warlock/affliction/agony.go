@@ -70,6 +70,7 @@ func (affliction *AfflictionWarlock) registerAgony() {
 			}
 		},
 
+		ExpectedDamagePerSecond: true,
 		ExpectedTickDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, useSnapshot bool) *core.SpellResult {
 			dot := spell.Dot(target)
 
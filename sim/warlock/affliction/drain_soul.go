@@ -11,6 +11,8 @@ const drainSoulScale = 0.257 * 1.5 // 2025.06.13 Changes to Beta - Drain Soul Da
 const drainSoulCoeff = 0.257 * 1.5
 
 func (affliction *AfflictionWarlock) registerDrainSoul() {
+	executePhase := affliction.ExecutePhase(20)
+
 	affliction.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 1120},
 		SpellSchool:    core.SpellSchoolShadow,
@@ -48,7 +50,7 @@ func (affliction *AfflictionWarlock) registerDrainSoul() {
 					affliction.SoulShards.Gain(sim, 1, dot.Spell.ActionID)
 				}
 
-				if !result.Landed() || !sim.IsExecutePhase20() {
+				if !result.Landed() || !executePhase.IsActive() {
 					return
 				}
 
@@ -76,6 +78,7 @@ func (affliction *AfflictionWarlock) registerDrainSoul() {
 		dmgMode.Deactivate()
 		s.RegisterExecutePhaseCallback(func(sim *core.Simulation, isExecute int32) {
 			if isExecute > 20 {
+				dmgMode.Deactivate()
 				return
 			}
 
@@ -53,6 +53,7 @@ func (affliction *AfflictionWarlock) registerUnstableAffliction() {
 			spell.DealOutcome(sim, result)
 		},
 
+		ExpectedDamagePerSecond: true,
 		ExpectedTickDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, useSnapshot bool) *core.SpellResult {
 			dot := spell.Dot(target)
 			if useSnapshot {
@@ -55,6 +55,7 @@ func (warlock *Warlock) RegisterCorruption(callback WarlockSpellCastedCallback)
 			}
 			spell.DealOutcome(sim, result)
 		},
+		ExpectedDamagePerSecond: true,
 		ExpectedTickDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, useSnapshot bool) *core.SpellResult {
 			dot := spell.Dot(target)
 			if useSnapshot {
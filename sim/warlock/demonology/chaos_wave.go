@@ -23,8 +23,8 @@ func (demonology *DemonologyWarlock) registerChaosWave() {
 			},
 		},
 
-		Charges:      2,
-		RechargeTime: time.Second * 15,
+		// Charges are shared with Hand of Gul'dan.
+		ChargedCD: demonology.HandOfGuldan.ChargedCD,
 
 		DamageMultiplier: 1,
 		CritMultiplier:   demonology.DefaultCritMultiplier(),
@@ -36,8 +36,6 @@ func (demonology *DemonologyWarlock) registerChaosWave() {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-			// keep stacks in sync as they're shared
-			demonology.HandOfGuldan.ConsumeCharge(sim)
 			demonology.DemonicFury.Spend(sim, core.TernaryInt32(demonology.T15_2pc.IsActive(), 56, 80), spell.ActionID)
 			pa := sim.GetConsumedPendingActionFromPool()
 			pa.NextActionAt = sim.CurrentTime + time.Millisecond*1300 // Fixed delay of 1.3 seconds
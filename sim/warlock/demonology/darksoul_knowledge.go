@@ -12,7 +12,7 @@ func (demonology *DemonologyWarlock) registerDarksoulKnowledge() {
 	buff := demonology.NewTemporaryStatsAura(
 		"Dark Soul: Knowledge",
 		core.ActionID{SpellID: 113861},
-		stats.Stats{stats.MasteryRating: 30 * core.MasteryRatingPerMasteryPoint},
+		stats.Stats{stats.MasteryRating: core.Ratings.RatingForPercent(stats.MasteryRating, 30)},
 		time.Second*20,
 	)
 
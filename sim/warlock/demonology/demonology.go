@@ -6,6 +6,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/warlock"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterDemonologyWarlock() {
@@ -23,6 +24,7 @@ func RegisterDemonologyWarlock() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecDemonologyWarlock, core.LoadAPLPresets(ui.APLs, "warlock/demonology/apls")...)
 }
 
 func NewDemonologyWarlock(character *core.Character, options *proto.Player) *DemonologyWarlock {
@@ -54,6 +54,7 @@ func (demonology *DemonologyWarlock) registerDoom() {
 			spell.DealOutcome(sim, result)
 		},
 
+		ExpectedDamagePerSecond: true,
 		ExpectedTickDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, useSnapshot bool) *core.SpellResult {
 			dot := spell.Dot(target)
 			if useSnapshot {
@@ -77,8 +77,6 @@ func (demonology *DemonologyWarlock) registerHandOfGuldan() {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-			// keep stacks in sync as they're shared
-			demonology.ChaosWave.ConsumeCharge(sim)
 			demonology.HandOfGuldanImpactTime = sim.CurrentTime + time.Millisecond*1300
 			pa := sim.GetConsumedPendingActionFromPool()
 			pa.NextActionAt = demonology.HandOfGuldanImpactTime // Fixed delay of 1.3 seconds
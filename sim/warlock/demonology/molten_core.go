@@ -8,6 +8,8 @@ import (
 )
 
 func (demonology *DemonologyWarlock) registerMoltenCore() {
+	decimationPhase := demonology.ExecutePhase(25)
+
 	buff := core.BlockPrepull(demonology.RegisterAura(core.Aura{
 		Label:     "Demonic Core",
 		ActionID:  core.ActionID{SpellID: 122355},
@@ -53,7 +55,7 @@ func (demonology *DemonologyWarlock) registerMoltenCore() {
 				}
 
 				// Decimation Passive effect, proc on cast
-				if sim.IsExecutePhase25() && spell.Matches(warlock.WarlockSpellShadowBolt|warlock.WarlockSpellSoulFire) && result == nil {
+				if decimationPhase.IsActive() && spell.Matches(warlock.WarlockSpellShadowBolt|warlock.WarlockSpellSoulFire) && result == nil {
 					buff.Activate(sim)
 					buff.AddStack(sim)
 				}
@@ -36,8 +36,6 @@ func (destruction *DestructionWarlock) registerConflagrate() {
 				destruction.FABAura.Deactivate(sim)
 			}
 
-			// keep charges in sync
-			destruction.FABConflagrate.ConsumeCharge(sim)
 			baseDamage := destruction.CalcAndRollDamageRange(sim, conflagrateScale, conflagrateVariance)
 			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 			var emberGain int32 = 1
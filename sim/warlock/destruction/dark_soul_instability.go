@@ -12,7 +12,7 @@ func (destruction *DestructionWarlock) registerDarkSoulInstability() {
 	buff := destruction.NewTemporaryStatsAura(
 		"Dark Soul: Instability",
 		core.ActionID{SpellID: 113858},
-		stats.Stats{stats.CritRating: 30 * core.CritRatingPerCritPercent},
+		stats.Stats{stats.CritRating: core.Ratings.RatingForPercent(stats.CritRating, 30)},
 		time.Second*20,
 	)
 
@@ -4,6 +4,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/warlock"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterDestructionWarlock() {
@@ -21,6 +22,7 @@ func RegisterDestructionWarlock() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecDestructionWarlock, core.LoadAPLPresets(ui.APLs, "warlock/destruction/apls")...)
 }
 
 const DefaultBurningEmbers = 10
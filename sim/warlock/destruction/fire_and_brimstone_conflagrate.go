@@ -1,8 +1,6 @@
 package destruction
 
 import (
-	"time"
-
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/warlock"
 )
@@ -20,10 +18,12 @@ func (destruction *DestructionWarlock) registerFireAndBrimstoneConflagrate() {
 				GCD: core.GCDDefault,
 			},
 		},
-		Charges:          2,
-		RechargeTime:     time.Second * 12,
 		ClassSpellMask:   warlock.WarlockSpellFaBConflagrate,
 		BonusCoefficient: conflagrateCoeff,
+
+		// Charges are shared with Conflagrate.
+		ChargedCD: destruction.Conflagrate.ChargedCD,
+
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
 			return destruction.BurningEmbers.CanSpend(10)
 		},
@@ -37,8 +37,6 @@ func (destruction *DestructionWarlock) registerFireAndBrimstoneConflagrate() {
 			reduction := destruction.getFABReduction()
 			spell.DamageMultiplier *= reduction
 
-			// keep charges in sync
-			destruction.Conflagrate.ConsumeCharge(sim)
 			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				result := spell.CalcAndDealDamage(
 					sim,
@@ -98,6 +98,7 @@ func (destruction *DestructionWarlock) registerImmolate() {
 			destruction.ApplyDotWithPandemic(spell.Dot(target), sim)
 		},
 
+		ExpectedDamagePerSecond: true,
 		ExpectedTickDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, useSnapshot bool) *core.SpellResult {
 			dot := spell.Dot(target)
 			if useSnapshot {
@@ -13,6 +13,7 @@ var shadowBurnCoeff = 3.5
 
 func (destruction *DestructionWarlock) registerShadowBurnSpell() {
 	manaMetric := destruction.NewManaMetrics(core.ActionID{SpellID: 17877})
+	executePhase := destruction.ExecutePhase(20)
 	destruction.Shadowburn = destruction.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 17877},
 		SpellSchool:    core.SpellSchoolShadow,
@@ -25,7 +26,7 @@ func (destruction *DestructionWarlock) registerShadowBurnSpell() {
 			},
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sim.IsExecutePhase20() && destruction.BurningEmbers.CanSpend(core.TernaryInt32(destruction.T15_2pc.IsActive(), 8, 10))
+			return executePhase.IsActive() && destruction.BurningEmbers.CanSpend(core.TernaryInt32(destruction.T15_2pc.IsActive(), 8, 10))
 		},
 
 		DamageMultiplierAdditive: 1,
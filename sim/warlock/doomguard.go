@@ -147,6 +147,8 @@ func (pet *DoomguardPet) registerDoomBolt() {
 		sim.RegisterExecutePhaseCallback(func(sim *core.Simulation, isExecute int32) {
 			if isExecute == 20 {
 				doomBoltExecuteMod.Activate()
+			} else {
+				doomBoltExecuteMod.Deactivate()
 			}
 		})
 	})
@@ -255,7 +255,7 @@ var ItemSetRegaliaOfTheHornedNightmare = core.NewItemSet(core.ItemSet{
 						Timer:    warlock.NewTimer(),
 						Duration: time.Second * 10,
 					},
-				}).AttachStatBuff(stats.CritRating, core.CritRatingPerCritPercent*15)
+				}).AttachStatBuff(stats.CritRating, core.Ratings.RatingForPercent(stats.CritRating, 15))
 
 				warlock.GetSecondaryResourceBar().RegisterOnGain(func(
 					sim *core.Simulation,
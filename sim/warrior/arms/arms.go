@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/warrior"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterArmsWarrior() {
@@ -22,6 +23,7 @@ func RegisterArmsWarrior() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecArmsWarrior, core.LoadAPLPresets(ui.APLs, "warrior/arms/apls")...)
 }
 
 type ArmsWarrior struct {
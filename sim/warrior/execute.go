@@ -5,6 +5,8 @@ import (
 )
 
 func (war *Warrior) registerExecuteSpell() {
+	executePhase := war.ExecutePhase(20)
+
 	war.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 5308},
 		SpellSchool:    core.SpellSchoolPhysical,
@@ -28,7 +30,7 @@ func (war *Warrior) registerExecuteSpell() {
 		DamageMultiplier: 1.0,
 
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sim.IsExecutePhase20() || war.T16Dps4P.IsActive()
+			return executePhase.IsActive() || war.T16Dps4P.IsActive()
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
@@ -5,6 +5,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/warrior"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterFuryWarrior() {
@@ -22,6 +23,7 @@ func RegisterFuryWarrior() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecFuryWarrior, core.LoadAPLPresets(ui.APLs, "warrior/fury/apls")...)
 }
 
 type FuryWarrior struct {
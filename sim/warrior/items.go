@@ -170,6 +170,7 @@ var ItemSetBattleplateOfThePrehistoricMarauder = core.NewItemSet(core.ItemSet{
 		4: func(agent core.Agent, setBonusAura *core.Aura) {
 			war := agent.(WarriorAgent).GetWarrior()
 
+			executePhase := war.ExecutePhase(20)
 			costMod := war.AddDynamicMod(core.SpellModConfig{
 				ClassMask: SpellMaskExecute,
 				Kind:      core.SpellMod_PowerCost_Flat,
@@ -181,7 +182,7 @@ var ItemSetBattleplateOfThePrehistoricMarauder = core.NewItemSet(core.ItemSet{
 				ActionID: core.ActionID{SpellID: 144442},
 				Duration: 12 * time.Second,
 			}).ApplyOnGain(func(aura *core.Aura, sim *core.Simulation) {
-				if sim.IsExecutePhase20() {
+				if executePhase.IsActive() {
 					costMod.Activate()
 				}
 			}).ApplyOnExpire(func(aura *core.Aura, sim *core.Simulation) {
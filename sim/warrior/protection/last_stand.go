@@ -12,19 +12,17 @@ func (war *ProtectionWarrior) registerLastStand() {
 	actionID := core.ActionID{SpellID: 12975}
 	healthMetrics := war.NewHealthMetrics(actionID)
 
-	var bonusHealth float64
+	// Grants a fixed amount of health, computed when it's used.
 	war.LastStandAura = war.RegisterAura(core.Aura{
 		Label:    "Last Stand",
 		ActionID: actionID,
 		Duration: time.Second * 20,
-		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			bonusHealth = war.MaxHealth() * 0.3
-			war.AddStatsDynamic(sim, stats.Stats{stats.Health: bonusHealth})
-			war.GainHealth(sim, bonusHealth, healthMetrics)
-		},
-		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			war.AddStatsDynamic(sim, stats.Stats{stats.Health: -bonusHealth})
-		},
+	}).AttachStatBonus(core.AuraStatBonusConfig{
+		Mode:          core.StatBonusSnapshot,
+		SourceStat:    stats.Health,
+		TargetStat:    stats.Health,
+		Ratio:         0.3,
+		HealthMetrics: healthMetrics,
 	})
 
 	spell := war.RegisterSpell(core.SpellConfig{
@@ -7,6 +7,7 @@ import (
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/warrior"
+	"github.com/wowsims/mop/ui"
 )
 
 func RegisterProtectionWarrior() {
@@ -24,6 +25,7 @@ func RegisterProtectionWarrior() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterAPLPresets(proto.Spec_SpecProtectionWarrior, core.LoadAPLPresets(ui.APLs, "warrior/protection/apls")...)
 }
 
 type ProtectionWarrior struct {
@@ -7,7 +7,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 )
 
-type Stance uint8
+type Stance = core.StanceMask
 
 const (
 	StanceNone          = 0
@@ -19,13 +19,13 @@ const (
 const stanceEffectCategory = "Stance"
 
 func (warrior *Warrior) StanceMatches(other Stance) bool {
-	return (warrior.Stance & other) != 0
+	return warrior.InStance(other)
 }
 
 func (warrior *Warrior) makeStanceSpell(stance Stance, aura *core.Aura, stanceCD *core.Timer) *core.Spell {
 	actionID := aura.ActionID
 
-	return warrior.RegisterSpell(core.SpellConfig{
+	stanceSpell := warrior.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
 		Flags:    core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
 
@@ -36,7 +36,7 @@ func (warrior *Warrior) makeStanceSpell(stance Stance, aura *core.Aura, stanceCD
 			},
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return warrior.Stance != stance
+			return warrior.Stance() != stance
 		},
 
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
@@ -52,9 +52,19 @@ func (warrior *Warrior) makeStanceSpell(stance Stance, aura *core.Aura, stanceCD
 				aura.Activate(sim)
 			}
 
-			warrior.Stance = stance
+			warrior.SetStance(stance)
 		},
 	})
+
+	// No warrior spell sets a RequiredStance, the stances are only registered
+	// for the In Stance APL value.
+	warrior.RegisterStance(core.StanceConfig{
+		Stance:   stance,
+		ActionID: actionID,
+		Spell:    stanceSpell,
+	})
+
+	return stanceSpell
 }
 
 func (warrior *Warrior) registerBattleStanceAura() {
@@ -11,7 +11,7 @@ type WarriorInputs struct {
 }
 
 const (
-	SpellFlagBleed = core.SpellFlagAgentReserved1
+	SpellFlagBleed = core.SpellFlagBleed
 )
 
 const (
@@ -89,7 +89,6 @@ type Warrior struct {
 	WarriorInputs
 
 	// Current state
-	Stance              Stance
 	CriticalBlockChance []float64 // Can be gained as non-prot via certain talents and spells
 	PrePullChargeGain   float64
 
@@ -192,7 +191,7 @@ func (warrior *Warrior) registerPassives() {
 }
 
 func (warrior *Warrior) Reset(_ *core.Simulation) {
-	warrior.Stance = StanceNone
+	warrior.SetStance(StanceNone)
 }
 
 func (warrior *Warrior) OnEncounterStart(sim *core.Simulation) {
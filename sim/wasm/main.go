@@ -17,8 +17,14 @@ import (
 	googleProto "google.golang.org/protobuf/proto"
 )
 
+// Set with -ldflags, like the server binaries.
+var Version string
+
 func init() {
 	core.SetRunningInWasm()
+	if Version != "" {
+		core.SimVersion = Version
+	}
 	sim.RegisterAll()
 }
 
@@ -37,8 +43,15 @@ func main() {
 	js.Global().Set("statWeightRequests", js.FuncOf(statWeightRequests))
 	js.Global().Set("statWeightCompute", js.FuncOf(statWeightCompute))
 	js.Global().Set("bulkSimAsync", js.FuncOf(bulkSimAsync))
+	js.Global().Set("gearProgressionAsync", js.FuncOf(gearProgressionAsync))
+	js.Global().Set("seedSensitivityAsync", js.FuncOf(seedSensitivityAsync))
 	js.Global().Set("abortById", js.FuncOf(abortById))
 	js.Global().Set("bulkSimCombos", js.FuncOf(bulkSimCombos))
+	js.Global().Set("exportAPL", js.FuncOf(exportAPL))
+	js.Global().Set("importAPL", js.FuncOf(importAPL))
+	js.Global().Set("encounterTimeline", js.FuncOf(encounterTimeline))
+	js.Global().Set("encounterPreset", js.FuncOf(encounterPreset))
+	js.Global().Set("aplPresets", js.FuncOf(aplPresets))
 	js.Global().Call("wasmready")
 	<-c
 }
@@ -280,6 +293,42 @@ func bulkSimAsync(this js.Value, args []js.Value) interface{} {
 	return js.Undefined()
 }
 
+func gearProgressionAsync(this js.Value, args []js.Value) interface{} {
+	rsr := &proto.GearProgressionRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), rsr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	go core.RunGearProgressionAsync(rsr, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
+func seedSensitivityAsync(this js.Value, args []js.Value) interface{} {
+	rsr := &proto.SeedSensitivityRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), rsr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	go core.RunSeedSensitivityAsync(rsr, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
 func raidSimRequestSplit(this js.Value, args []js.Value) interface{} {
 	splitRequest := &proto.RaidSimRequestSplitRequest{}
 	if err := googleProto.Unmarshal(getArgsBinary(args[0]), splitRequest); err != nil {
@@ -408,9 +457,114 @@ func processAsyncProgress(progFunc js.Value, reporter chan *proto.ProgressMetric
 			js.CopyBytesToJS(outArray, outbytes)
 			progFunc.Invoke(outArray)
 
-			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil || progMetric.FinalBulkResult != nil {
+			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalGearProgressionResult != nil || progMetric.FinalSeedSensitivityResult != nil {
 				return
 			}
 		}
 	}
 }
+
+func exportAPL(this js.Value, args []js.Value) interface{} {
+	request := &proto.ExportAPLRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), request); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	result := core.ExportAPL(request)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
+func importAPL(this js.Value, args []js.Value) interface{} {
+	request := &proto.ImportAPLRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), request); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	result := core.ImportAPL(request)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
+func encounterTimeline(this js.Value, args []js.Value) interface{} {
+	request := &proto.EncounterTimelineRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), request); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	result := core.EncounterTimeline(request)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
+func aplPresets(this js.Value, args []js.Value) interface{} {
+	request := &proto.APLPresetsRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), request); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	result := core.APLPresets(request)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
+func encounterPreset(this js.Value, args []js.Value) interface{} {
+	request := &proto.EncounterPresetRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), request); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	result := core.BuildEncounterPreset(request)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
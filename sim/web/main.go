@@ -42,6 +42,7 @@ func main() {
 	if Version == "" {
 		Version = "development"
 	}
+	core.SimVersion = Version
 	var useFS = flag.Bool("usefs", false, "Use local file system for client files. Set to true during development.")
 	var wasm = flag.Bool("wasm", false, "Use wasm for sim instead of web server apis. Can only be used with usefs=true")
 	var simName = flag.String("sim", "", "Name of simulator to launch (ex: balance_druid, elemental_shaman, etc)")
@@ -114,6 +115,21 @@ var handlers = map[string]apiHandler{
 	"/bulkSimCombos": {msg: func() googleProto.Message { return &proto.BulkSimCombosRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.RunBulkCombos(msg.(*proto.BulkSimCombosRequest))
 	}},
+	"/exportAPL": {msg: func() googleProto.Message { return &proto.ExportAPLRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ExportAPL(msg.(*proto.ExportAPLRequest))
+	}},
+	"/importAPL": {msg: func() googleProto.Message { return &proto.ImportAPLRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ImportAPL(msg.(*proto.ImportAPLRequest))
+	}},
+	"/encounterTimeline": {msg: func() googleProto.Message { return &proto.EncounterTimelineRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.EncounterTimeline(msg.(*proto.EncounterTimelineRequest))
+	}},
+	"/encounterPreset": {msg: func() googleProto.Message { return &proto.EncounterPresetRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.BuildEncounterPreset(msg.(*proto.EncounterPresetRequest))
+	}},
+	"/aplPresets": {msg: func() googleProto.Message { return &proto.APLPresetsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.APLPresets(msg.(*proto.APLPresetsRequest))
+	}},
 }
 
 var asyncAPIHandlers = map[string]asyncAPIHandler{
@@ -126,6 +142,12 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
 		core.RunBulkSimAsync(msg.(*proto.BulkSimRequest), reporter, requestId)
 	}},
+	"/gearProgressionAsync": {msg: func() googleProto.Message { return &proto.GearProgressionRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.RunGearProgressionAsync(msg.(*proto.GearProgressionRequest), reporter, requestId)
+	}},
+	"/seedSensitivityAsync": {msg: func() googleProto.Message { return &proto.SeedSensitivityRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.RunSeedSensitivityAsync(msg.(*proto.SeedSensitivityRequest), reporter, requestId)
+	}},
 }
 
 type server struct {
@@ -193,6 +215,8 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 	// Now launch a background process that pulls progress reports off the reporter channel
 	// and pushes it into the async progress cache.
 	go func() {
+		// Polling only returns the latest progress, so streamed logs are put back into the final result.
+		var logs strings.Builder
 		for {
 			select {
 			case <-time.After(time.Minute * 10):
@@ -205,8 +229,15 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 				if progMetric == nil {
 					return
 				}
+				if progMetric.LogChunk != "" {
+					logs.WriteString(progMetric.LogChunk)
+					continue
+				}
+				if progMetric.FinalRaidResult != nil && logs.Len() > 0 {
+					progMetric.FinalRaidResult.Logs = logs.String() + progMetric.FinalRaidResult.Logs
+				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalGearProgressionResult != nil || progMetric.FinalSeedSensitivityResult != nil {
 					return
 				}
 			}
@@ -264,7 +295,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
+		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil || latest.FinalGearProgressionResult != nil || latest.FinalSeedSensitivityResult != nil {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
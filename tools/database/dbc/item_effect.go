@@ -100,6 +100,7 @@ func assignTrigger(e *ItemEffect, statsSpellID int, pe *proto.ItemEffect) {
 			proc.ProcRate = &proto.ProcEffect_Ppm{
 				Ppm: 1,
 			}
+			proc.Estimated = true
 		} else {
 			proc.ProcRate = &proto.ProcEffect_ProcChance{
 				ProcChance: float64(spTop.ProcChance) / 100,
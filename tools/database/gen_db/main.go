@@ -23,10 +23,12 @@ import (
 // To do a full re-scrape, delete the previous output file first.
 // go run ./tools/database/gen_db -outDir=assets -gen=atlasloot
 // go run ./tools/database/gen_db -outDir=assets -gen=db
+// go run ./tools/database/gen_db -gen=proc-estimates -procLog=observed_procs.json
 
 var outDir = flag.String("outDir", "assets", "Path to output directory for writing generated .go files.")
-var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'mop-items', and 'wago-db2-items'")
+var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'mop-items', 'wago-db2-items', and 'proc-estimates'")
 var dbPath = flag.String("dbPath", "./tools/database/wowsims.db", "Location of wowsims.db file from the DB2ToSqliteTool")
+var procLog = flag.String("procLog", "", "Path to proc observations extracted from a combat log, used by 'proc-estimates'")
 
 func main() {
 	flag.Parse()
@@ -54,6 +56,20 @@ func main() {
 		//Todo: fill this when we have information from wowhead @ Neteyes - Gehennas
 		// For now, the version we have was taken from https://web.archive.org/web/20120201045249js_/http://www.wowhead.com/data=item-scaling
 		return
+	} else if *genAsset == "proc-estimates" {
+		observations, err := database.ReadProcObservations(*procLog)
+		if err != nil {
+			log.Fatalf("failed to read proc observations: %v", err)
+		}
+		existing, err := database.ReadProcEstimates(database.ProcEstimatesFileName)
+		if err != nil {
+			log.Fatalf("failed to read proc estimates: %v", err)
+		}
+		estimates := database.MergeProcEstimates(existing, database.EstimateProcParameters(observations))
+		if err := database.WriteProcEstimates(database.ProcEstimatesFileName, estimates); err != nil {
+			log.Fatalf("failed to write proc estimates: %v", err)
+		}
+		return
 	} else if *genAsset != "db" {
 		panic("Invalid gen value")
 	}
@@ -221,15 +237,28 @@ func main() {
 	db.MergeItems(database.ItemOverrides)
 	db.MergeGems(database.GemOverrides)
 	db.MergeEnchants(database.EnchantOverrides)
+
+	procEstimates, err := database.ReadProcEstimates(database.ProcEstimatesFileName)
+	if err != nil {
+		log.Fatalf("failed to read proc estimates: %v", err)
+	}
+	db.ApplyProcEstimates(procEstimates)
+
 	ApplyGlobalFilters(db)
 	leftovers := db.Clone()
 	ApplyNonSimmableFilters(leftovers)
 	leftovers.WriteBinaryAndJson(fmt.Sprintf("%s/leftover_db.bin", dbDir), fmt.Sprintf("%s/leftover_db.json", dbDir))
 	ApplySimmableFilters(db)
 
+	if err := database.GenerateRegisteredItemEffects(database.ItemEffectRegistry); err != nil {
+		log.Fatalf("failed to generate registered item effects: %v", err)
+	}
 	database.GenerateItemEffects(instance, db, dropSources)
 	database.GenerateEnchantEffects(instance, db)
 	database.GenerateMissingEffectsFile()
+	if err := database.GenerateTrinketStubs(db); err != nil {
+		log.Fatalf("failed to generate trinket stubs: %v", err)
+	}
 	database.GenerateItemEffectRandomPropPoints(instance, db)
 
 	for _, key := range slices.SortedFunc(maps.Keys(db.Enchants), func(l int32, r int32) int {
@@ -293,6 +322,11 @@ func main() {
 	db.MergeZones(atlasDBProto.Zones)
 	db.MergeNpcs(atlasDBProto.Npcs)
 	db.WriteBinaryAndJson(fmt.Sprintf("%s/db.bin", dbDir), fmt.Sprintf("%s/db.json", dbDir))
+
+	// Passed as a raid's spell_dataset to audit damage rolls against the game data.
+	if err := database.WriteSpellDataset(fmt.Sprintf("%s/spell_variances.json", dbDir), database.GenerateSpellVarianceDataset(instance)); err != nil {
+		log.Fatalf("failed to write spell variances: %v", err)
+	}
 }
 
 func InferPhase(item *proto.UIItem) int32 {
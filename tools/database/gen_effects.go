@@ -10,6 +10,7 @@ import (
 	"text/template"
 
 	_ "github.com/wowsims/mop/sim/common"
+	"github.com/wowsims/mop/sim/common/shared"
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/tools/database/dbc"
@@ -17,7 +18,7 @@ import (
 )
 
 // Sets the minimum itemlevel that should be considered for this expansions
-const MIN_EFFECT_ILVL = 416
+const MIN_EFFECT_ILVL = shared.MinEffectIlvl
 
 type ProcInfo struct {
 	Outcome  core.HitOutcome
@@ -159,6 +160,11 @@ func GenerateItemEffects(instance *dbc.DBC, db *WowDatabase, itemSources map[int
 
 	// Example loop over your items
 	for _, parsed := range db.Items {
+		// Generated from ItemEffectRegistry instead, see GenerateRegisteredItemEffects.
+		if _, ok := ItemEffectRegistry[parsed.Id]; ok {
+			continue
+		}
+
 		parsed.ItemEffect = dbc.MergeItemEffectsForAllStates(parsed)
 
 		result := TryParseOnUseEffect(parsed, groupMapOnUse)
@@ -158,3 +158,77 @@ export const MISSING_ENCHANT_EFFECTS = [
 {{- end }}
 ]
 `
+
+const TmplStrItemEffect = `package mop
+
+// This file is auto generated from database.ItemEffectRegistry
+// Changes will be overwritten on next database generation
+
+import (
+{{- if .NeedsTimeImport }}
+	"time"
+{{ end }}
+{{- if .NeedsCoreImport }}
+	"github.com/wowsims/mop/sim/core"
+{{- end }}
+	"github.com/wowsims/mop/sim/common/shared"
+)
+
+func init() {
+{{- with .Entry }}
+{{- if $.IsStatProc }}
+	shared.NewProcStatBonusEffect(shared.ProcStatBonusEffect{
+		Name:     {{ printf "%q" .Name }},
+		ItemID:   {{ $.ItemID }},
+		Callback: {{ .ProcInfo.Callback | asCoreCallback }},
+		ProcMask: {{ .ProcInfo.ProcMask | asCoreProcMask }},
+		Outcome:  {{ .ProcInfo.Outcome | asCoreOutcome }},
+		Harmful:  {{ .Harmful }},
+	})
+{{- else if $.IsOnUseStat }}
+	shared.NewSimpleStatActive({{ $.ItemID }}) // {{ .Name }}
+{{- else if $.IsProcDamage }}
+	shared.NewProcDamageEffect(shared.ProcDamageEffect{
+		ItemID:  {{ $.ItemID }},
+		SpellID: {{ .Damage.SpellID }},
+		Trigger: core.ProcTrigger{
+			Name:     {{ printf "%q" .Name }},
+			Callback: {{ .ProcInfo.Callback | asCoreCallback }},
+			ProcMask: {{ .ProcInfo.ProcMask | asCoreProcMask }},
+			Outcome:  {{ .ProcInfo.Outcome | asCoreOutcome }},
+			Harmful:  {{ .Harmful }},
+			{{- if .Damage.ICD }}
+			ICD:      {{ .Damage.ICD | asDurationLiteral }},
+			{{- end }}
+			{{- if .Damage.ProcChance }}
+			ProcChance: {{ .Damage.ProcChance }},
+			{{- end }}
+		},
+		School:  {{ .Damage.School | asCoreSpellSchool }},
+		MinDmg:  {{ .Damage.MinDmg }},
+		MaxDmg:  {{ .Damage.MaxDmg }},
+		Outcome: {{ .Damage.Outcome | asSharedOutcome }},
+	})
+{{- end }}
+{{- end }}
+}
+`
+
+const TmplStrTrinketStubs = `package mop
+
+// This file is auto generated
+// Changes will be overwritten on next database generation
+//
+// Trinkets below have no effect implementation and currently sim as stat sticks.
+// Move a stub into the matching trinkets_phase_*.go file when implementing it.
+{{- range .Tiers }}
+
+// {{ .Name }}
+{{- range .Trinkets }}
+//
+// TODO: {{ .Name }}
+// core.NewItemEffect({{ .Id }}, func(agent core.Agent, state proto.ItemLevelState) {
+// })
+{{- end }}
+{{- end }}
+`
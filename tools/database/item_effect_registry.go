@@ -0,0 +1,154 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/wowsims/mop/sim/common/shared"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const itemEffectRegistryDir = "sim/common/mop"
+
+var itemEffectFileRegex = regexp.MustCompile(`^item_\d+_auto_gen\.go$`)
+
+type ItemEffectPattern byte
+
+const (
+	ItemEffectPatternStatProc   ItemEffectPattern = iota // Stat buff proc, ICD and proc rate taken from the item's ItemEffect
+	ItemEffectPatternOnUseStat                           // On-use stat burst, cooldown taken from the item's ItemEffect
+	ItemEffectPatternProcDamage                          // Direct damage proc
+)
+
+type ItemEffectDamage struct {
+	SpellID    int32
+	School     proto.SpellSchool
+	MinDmg     float64
+	MaxDmg     float64
+	ICD        time.Duration
+	ProcChance float64
+	Outcome    shared.OutcomeType
+}
+
+// ItemEffectEntry describes an item effect that follows one of the common patterns,
+// so it can be generated instead of implemented by hand.
+type ItemEffectEntry struct {
+	Pattern  ItemEffectPattern
+	Name     string
+	ProcInfo ProcInfo
+	Harmful  bool
+
+	// Only used by ItemEffectPatternProcDamage
+	Damage ItemEffectDamage
+}
+
+// ItemEffectRegistry holds all data-driven item effects keyed by item ID.
+// Adding an entry here and re-running the generator produces sim/common/mop/item_<id>_auto_gen.go.
+// Generated files register in init(), so they take precedence over stat_bonus_procs_auto_gen.go.
+var ItemEffectRegistry = map[int32]ItemEffectEntry{
+	79327: {
+		Pattern: ItemEffectPatternStatProc,
+		Name:    "Relic of Xuen - Strength",
+		ProcInfo: ProcInfo{
+			Callback: core.CallbackOnSpellHitDealt,
+			ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskMeleeProc,
+			Outcome:  core.OutcomeLanded,
+		},
+		Harmful: true,
+	},
+}
+
+// GenerateRegisteredItemEffects writes one Go file per registry entry and removes
+// files for items that are no longer in the registry.
+func GenerateRegisteredItemEffects(registry map[int32]ItemEffectEntry) error {
+	existing, err := os.ReadDir(itemEffectRegistryDir)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", itemEffectRegistryDir, err)
+	}
+	for _, file := range existing {
+		if itemEffectFileRegex.MatchString(file.Name()) {
+			if err := os.Remove(filepath.Join(itemEffectRegistryDir, file.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	for itemID, entry := range registry {
+		outFile := filepath.Join(itemEffectRegistryDir, fmt.Sprintf("item_%d_auto_gen.go", itemID))
+		source, err := renderItemEffect(itemID, entry)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(outFile, source, 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", outFile, err)
+		}
+	}
+
+	return nil
+}
+
+// Returns the formatted source of the generated file for a single registry entry.
+func renderItemEffect(itemID int32, entry ItemEffectEntry) ([]byte, error) {
+	funcMap := map[string]any{
+		"asCoreCallback":    asCoreCallback,
+		"asCoreProcMask":    asCoreProcMask,
+		"asCoreOutcome":     asCoreOutcome,
+		"asSharedOutcome":   asSharedOutcome,
+		"asDurationLiteral": asDurationLiteral,
+		"asCoreSpellSchool": func(school proto.SpellSchool) string { return "core." + school.String() },
+	}
+	tmpl := template.Must(template.New("itemEffect").Funcs(funcMap).Parse(TmplStrItemEffect))
+
+	var buffer bytes.Buffer
+	err := tmpl.Execute(&buffer, map[string]any{
+		"ItemID":          itemID,
+		"Entry":           entry,
+		"IsStatProc":      entry.Pattern == ItemEffectPatternStatProc,
+		"IsOnUseStat":     entry.Pattern == ItemEffectPatternOnUseStat,
+		"IsProcDamage":    entry.Pattern == ItemEffectPatternProcDamage,
+		"NeedsTimeImport": entry.Pattern == ItemEffectPatternProcDamage && entry.Damage.ICD > 0,
+		"NeedsCoreImport": entry.Pattern != ItemEffectPatternOnUseStat,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template for item %d: %w", itemID, err)
+	}
+
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code for item %d: %w", itemID, err)
+	}
+	return formatted, nil
+}
+
+func asSharedOutcome(outcome shared.OutcomeType) string {
+	switch outcome {
+	case shared.OutcomeMeleeCanCrit:
+		return "shared.OutcomeMeleeCanCrit"
+	case shared.OutcomeMeleeNoCrit:
+		return "shared.OutcomeMeleeNoCrit"
+	case shared.OutcomeMeleeNoBlockDodgeParryCrit:
+		return "shared.OutcomeMeleeNoBlockDodgeParryCrit"
+	case shared.OutcomeSpellNoCrit:
+		return "shared.OutcomeSpellNoCrit"
+	case shared.OutcomeSpellNoMissCanCrit:
+		return "shared.OutcomeSpellNoMissCanCrit"
+	case shared.OutcomeRangedCanCrit:
+		return "shared.OutcomeRangedCanCrit"
+	default:
+		return "shared.OutcomeSpellCanCrit"
+	}
+}
+
+func asDurationLiteral(duration time.Duration) string {
+	if duration%time.Second == 0 {
+		return fmt.Sprintf("time.Second * %d", duration/time.Second)
+	}
+	return fmt.Sprintf("time.Millisecond * %d", duration/time.Millisecond)
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+func Test_WhenRegistryEntryGenerated_ThenCheckedInFileMatches(t *testing.T) {
+	for itemID, entry := range ItemEffectRegistry {
+		expected, err := renderItemEffect(itemID, entry)
+		if err != nil {
+			t.Fatalf("item %d: %v", itemID, err)
+		}
+
+		fileName := fmt.Sprintf("item_%d_auto_gen.go", itemID)
+		actual, err := os.ReadFile(filepath.Join("..", "..", itemEffectRegistryDir, fileName))
+		if err != nil {
+			t.Fatalf("item %d: %v", itemID, err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Fatalf("%s is out of date, re-run the database generator", fileName)
+		}
+	}
+}
+
+func Test_WhenRegistryEntryGenerated_ThenItemEffectIsRegistered(t *testing.T) {
+	for itemID := range ItemEffectRegistry {
+		if !core.HasItemEffect(itemID) {
+			t.Fatalf("expected item %d to have an effect from its generated file", itemID)
+		}
+	}
+}
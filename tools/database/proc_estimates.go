@@ -0,0 +1,208 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const ProcEstimatesFileName = "tools/database/proc_estimates.json"
+
+// Minimum number of observed procs before we trust a fitted estimate.
+const minProcSamples = 10
+
+// ProcObservation is a summary of a single item/enchant proc over one fight,
+// as extracted from a combat log.
+type ProcObservation struct {
+	ItemID    int32 `json:"itemId,omitempty"`
+	EnchantID int32 `json:"enchantId,omitempty"`
+
+	FightDurationMs int64 `json:"fightDurationMs"`
+	ProcCount       int   `json:"procCount"`
+	UptimeMs        int64 `json:"uptimeMs"`
+
+	// Optional, allows a much tighter ICD estimate when present.
+	ProcTimestampsMs []int64 `json:"procTimestampsMs,omitempty"`
+}
+
+// ProcEstimate holds fitted proc parameters for an item/enchant whose
+// ICD and/or proc rate is not known from game data.
+type ProcEstimate struct {
+	ItemID    int32 `json:"itemId,omitempty"`
+	EnchantID int32 `json:"enchantId,omitempty"`
+
+	IcdMs   int32   `json:"icdMs"`
+	Ppm     float64 `json:"ppm"`
+	Samples int     `json:"samples"`
+}
+
+func (obs ProcObservation) key() int64 {
+	return int64(obs.ItemID)<<32 | int64(obs.EnchantID)
+}
+
+func (estimate ProcEstimate) key() int64 {
+	return int64(estimate.ItemID)<<32 | int64(estimate.EnchantID)
+}
+
+// EstimateProcParameters fits an ICD and PPM per item/enchant from the given observations.
+// Entries with fewer than minProcSamples procs are dropped.
+func EstimateProcParameters(observations []ProcObservation) []ProcEstimate {
+	grouped := make(map[int64][]ProcObservation)
+	for _, obs := range observations {
+		grouped[obs.key()] = append(grouped[obs.key()], obs)
+	}
+
+	estimates := make([]ProcEstimate, 0, len(grouped))
+	for _, group := range grouped {
+		if estimate, ok := estimateProcGroup(group); ok {
+			estimates = append(estimates, estimate)
+		}
+	}
+
+	sortProcEstimates(estimates)
+	return estimates
+}
+
+// MergeProcEstimates returns the existing estimates with those for the same
+// item/enchant replaced by the updated ones, so fitting one log doesn't drop
+// the estimates fitted from earlier logs.
+func MergeProcEstimates(existing []ProcEstimate, updated []ProcEstimate) []ProcEstimate {
+	merged := make(map[int64]ProcEstimate, len(existing)+len(updated))
+	for _, estimate := range existing {
+		merged[estimate.key()] = estimate
+	}
+	for _, estimate := range updated {
+		merged[estimate.key()] = estimate
+	}
+
+	estimates := slices.Collect(maps.Values(merged))
+	sortProcEstimates(estimates)
+	return estimates
+}
+
+func sortProcEstimates(estimates []ProcEstimate) {
+	sort.Slice(estimates, func(i, j int) bool {
+		if estimates[i].ItemID != estimates[j].ItemID {
+			return estimates[i].ItemID < estimates[j].ItemID
+		}
+		return estimates[i].EnchantID < estimates[j].EnchantID
+	})
+}
+
+func estimateProcGroup(group []ProcObservation) (ProcEstimate, bool) {
+	var totalProcs int
+	var totalFightMs int64
+	var totalUptimeMs int64
+	minGapMs := int64(math.MaxInt64)
+	for _, obs := range group {
+		totalProcs += obs.ProcCount
+		totalFightMs += obs.FightDurationMs
+		totalUptimeMs += obs.UptimeMs
+
+		timestamps := slices.Clone(obs.ProcTimestampsMs)
+		slices.Sort(timestamps)
+		for i := 1; i < len(timestamps); i++ {
+			minGapMs = min(minGapMs, timestamps[i]-timestamps[i-1])
+		}
+	}
+
+	if totalProcs < minProcSamples || totalFightMs <= 0 {
+		return ProcEstimate{}, false
+	}
+
+	// The shortest gap between two procs is an upper bound on the ICD. Real
+	// ICDs are whole seconds, so round down to absorb log timestamp jitter.
+	var icdMs int64
+	if minGapMs != math.MaxInt64 {
+		icdMs = (minGapMs / 1000) * 1000
+	} else if totalUptimeMs > 0 {
+		// Without timestamps, fall back to the average buff duration per proc.
+		// Most procs can't trigger again while their buff is active, so this is
+		// a lower bound on the ICD.
+		icdMs = (totalUptimeMs / int64(totalProcs) / 1000) * 1000
+	}
+
+	// Only time spent off cooldown can produce procs.
+	availableMs := totalFightMs - int64(totalProcs)*icdMs
+	if availableMs <= 0 {
+		availableMs = totalFightMs
+	}
+
+	return ProcEstimate{
+		ItemID:    group[0].ItemID,
+		EnchantID: group[0].EnchantID,
+		IcdMs:     int32(icdMs),
+		Ppm:       math.Round(float64(totalProcs)/(float64(availableMs)/60000)*100) / 100,
+		Samples:   totalProcs,
+	}, true
+}
+
+func ReadProcObservations(filePath string) ([]ProcObservation, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proc observations %s: %w", filePath, err)
+	}
+
+	var observations []ProcObservation
+	if err := json.Unmarshal(data, &observations); err != nil {
+		return nil, fmt.Errorf("failed to parse proc observations %s: %w", filePath, err)
+	}
+	return observations, nil
+}
+
+func WriteProcEstimates(filePath string, estimates []ProcEstimate) error {
+	data, err := json.MarshalIndent(estimates, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, append(data, '\n'), 0644)
+}
+
+func ReadProcEstimates(filePath string) ([]ProcEstimate, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var estimates []ProcEstimate
+	if err := json.Unmarshal(data, &estimates); err != nil {
+		return nil, fmt.Errorf("failed to parse proc estimates %s: %w", filePath, err)
+	}
+	return estimates, nil
+}
+
+// ApplyProcEstimates fills in proc parameters that game data does not provide.
+// Only procs marked as estimated are fitted, so known ICDs and proc rates are
+// never overwritten, even when they happen to match the placeholders.
+func (db *WowDatabase) ApplyProcEstimates(estimates []ProcEstimate) {
+	for _, estimate := range estimates {
+		var effect *proto.ItemEffect
+		if estimate.EnchantID != 0 {
+			if enchant, ok := db.Enchants[estimate.EnchantID]; ok {
+				effect = enchant.EnchantEffect
+			}
+		} else if item, ok := db.Items[estimate.ItemID]; ok {
+			effect = item.ItemEffect
+		}
+
+		// DBC parsing falls back to 1 PPM, marked as estimated, when the proc
+		// chance is missing or nonsensical.
+		proc := effect.GetProc()
+		if proc == nil || !proc.Estimated {
+			continue
+		}
+
+		if proc.IcdMs == 0 {
+			proc.IcdMs = estimate.IcdMs
+		}
+		proc.ProcRate = &proto.ProcEffect_Ppm{Ppm: estimate.Ppm}
+	}
+}
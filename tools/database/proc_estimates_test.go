@@ -0,0 +1,106 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func Test_WhenProcTimestampsGiven_ThenEstimateIcdAndPpm(t *testing.T) {
+	// 12 procs in 10 minutes, never closer than 45.3s apart.
+	timestamps := make([]int64, 12)
+	for i := range timestamps {
+		timestamps[i] = int64(i) * 45300
+	}
+
+	estimates := EstimateProcParameters([]ProcObservation{
+		{ItemID: 1, FightDurationMs: 600000, ProcCount: 12, ProcTimestampsMs: timestamps},
+	})
+
+	if len(estimates) != 1 {
+		t.Fatalf("expected 1 estimate, got %d", len(estimates))
+	}
+	if estimates[0].IcdMs != 45000 {
+		t.Fatalf("expected 45000ms ICD, got %d", estimates[0].IcdMs)
+	}
+	// 12 procs over 600s - 12*45s = 60s of off-cooldown time.
+	if estimates[0].Ppm != 12 {
+		t.Fatalf("expected 12 ppm, got %f", estimates[0].Ppm)
+	}
+}
+
+func Test_WhenTooFewProcsObserved_ThenSkipEstimate(t *testing.T) {
+	estimates := EstimateProcParameters([]ProcObservation{
+		{ItemID: 1, FightDurationMs: 600000, ProcCount: minProcSamples - 1},
+	})
+
+	if len(estimates) != 0 {
+		t.Fatalf("expected no estimates, got %d", len(estimates))
+	}
+}
+
+func Test_WhenOnlyUptimeGiven_ThenEstimateIcdFromUptime(t *testing.T) {
+	// 10 procs of a 20s buff in 10 minutes, without timestamps.
+	estimates := EstimateProcParameters([]ProcObservation{
+		{ItemID: 1, FightDurationMs: 600000, ProcCount: 10, UptimeMs: 200400},
+	})
+
+	if len(estimates) != 1 {
+		t.Fatalf("expected 1 estimate, got %d", len(estimates))
+	}
+	if estimates[0].IcdMs != 20000 {
+		t.Fatalf("expected 20000ms ICD, got %d", estimates[0].IcdMs)
+	}
+	// 10 procs over 600s - 10*20s = 400s of off-cooldown time.
+	if estimates[0].Ppm != 1.5 {
+		t.Fatalf("expected 1.5 ppm, got %f", estimates[0].Ppm)
+	}
+}
+
+func Test_WhenEstimatesMerged_ThenExistingEstimatesAreKept(t *testing.T) {
+	merged := MergeProcEstimates(
+		[]ProcEstimate{{ItemID: 2, IcdMs: 45000, Ppm: 1}, {EnchantID: 3, Ppm: 2}},
+		[]ProcEstimate{{ItemID: 2, IcdMs: 50000, Ppm: 1.5}, {ItemID: 1, Ppm: 3}},
+	)
+
+	expected := []ProcEstimate{{EnchantID: 3, Ppm: 2}, {ItemID: 1, Ppm: 3}, {ItemID: 2, IcdMs: 50000, Ppm: 1.5}}
+	if !slices.Equal(merged, expected) {
+		t.Fatalf("expected %v, got %v", expected, merged)
+	}
+}
+
+func Test_WhenProcDataKnown_ThenEstimateIsNotApplied(t *testing.T) {
+	db := NewWowDatabase()
+	db.Items[1] = &proto.UIItem{Id: 1, ItemEffect: &proto.ItemEffect{Effect: &proto.ItemEffect_Proc{Proc: &proto.ProcEffect{
+		IcdMs:    50000,
+		ProcRate: &proto.ProcEffect_ProcChance{ProcChance: 0.15},
+	}}}}
+	db.Items[2] = &proto.UIItem{Id: 2, ItemEffect: &proto.ItemEffect{Effect: &proto.ItemEffect_Proc{Proc: &proto.ProcEffect{
+		ProcRate:  &proto.ProcEffect_Ppm{Ppm: 1},
+		Estimated: true,
+	}}}}
+	// A real 1 PPM proc without an ICD.
+	db.Items[3] = &proto.UIItem{Id: 3, ItemEffect: &proto.ItemEffect{Effect: &proto.ItemEffect_Proc{Proc: &proto.ProcEffect{
+		ProcRate: &proto.ProcEffect_Ppm{Ppm: 1},
+	}}}}
+
+	db.ApplyProcEstimates([]ProcEstimate{
+		{ItemID: 1, IcdMs: 45000, Ppm: 2},
+		{ItemID: 2, IcdMs: 45000, Ppm: 2},
+		{ItemID: 3, IcdMs: 45000, Ppm: 2},
+	})
+
+	known := db.Items[1].ItemEffect.GetProc()
+	if known.IcdMs != 50000 || known.GetProcChance() != 0.15 {
+		t.Fatalf("known proc data was overwritten: %v", known)
+	}
+	knownPpm := db.Items[3].ItemEffect.GetProc()
+	if knownPpm.IcdMs != 0 || knownPpm.GetPpm() != 1 {
+		t.Fatalf("known 1 PPM proc was overwritten: %v", knownPpm)
+	}
+	unknown := db.Items[2].ItemEffect.GetProc()
+	if unknown.IcdMs != 45000 || unknown.GetPpm() != 2 {
+		t.Fatalf("estimate was not applied: %v", unknown)
+	}
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/tools/database/dbc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Name of the dataset with the damage variances of the SpellEffect table.
+const SpellVarianceDatasetName = "DB2 Variances"
+
+// GenerateSpellVarianceDataset returns a SpellDataset with only the damage
+// variance of each spell set, for SimOptions.audit_damage_variance. Spells
+// with several varied effects use the one with the lowest index.
+func GenerateSpellVarianceDataset(instance *dbc.DBC) *proto.SpellDataset {
+	dataset := &proto.SpellDataset{Name: SpellVarianceDatasetName}
+	for _, spellID := range slices.Sorted(maps.Keys(instance.SpellEffects)) {
+		effects := instance.SpellEffects[spellID]
+		for _, index := range slices.Sorted(maps.Keys(effects)) {
+			if variance := effects[index].Variance; variance != 0 {
+				dataset.Spells = append(dataset.Spells, &proto.SpellTuning{
+					Id:       &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: int32(spellID)}},
+					Variance: variance,
+				})
+				break
+			}
+		}
+	}
+	return dataset
+}
+
+func WriteSpellDataset(filePath string, dataset *proto.SpellDataset) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "\t"}.Marshal(dataset)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, append(data, '\n'), 0644)
+}
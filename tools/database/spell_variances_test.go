@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/tools/database/dbc"
+)
+
+func Test_WhenSpellEffectsHaveVariance_ThenDatasetHasFirstVariance(t *testing.T) {
+	instance := dbc.NewDBC()
+	instance.SpellEffects = map[int]map[int]dbc.SpellEffect{
+		22568: {1: {Variance: 0.5}, 0: {Variance: 0.74}},
+		1079:  {0: {}},
+		100:   {0: {}, 1: {Variance: 0.2}},
+	}
+
+	dataset := GenerateSpellVarianceDataset(instance)
+	if len(dataset.Spells) != 2 {
+		t.Fatalf("expected 2 spells with a variance, got %d", len(dataset.Spells))
+	}
+	if dataset.Spells[0].Id.GetSpellId() != 100 || dataset.Spells[0].Variance != 0.2 {
+		t.Fatalf("expected spell 100 first with a variance of 0.2, got %v", dataset.Spells[0])
+	}
+	if dataset.Spells[1].Id.GetSpellId() != 22568 || dataset.Spells[1].Variance != 0.74 {
+		t.Fatalf("expected the lowest effect index's variance of 0.74 for spell 22568, got %v", dataset.Spells[1])
+	}
+	if dataset.Spells[0].BonusCoefficient != 0 || dataset.Spells[0].DamageMultiplier != 0 {
+		t.Fatalf("expected only variances to be set")
+	}
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"text/template"
+
+	"github.com/wowsims/mop/sim/common/shared"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const trinketStubsFileName = "sim/common/mop/trinket_stubs_auto_gen.go"
+
+type trinketStubTier struct {
+	Name     string
+	Trinkets []*proto.UIItem
+}
+
+// GenerateTrinketStubs writes commented implementation stubs for trinkets that have
+// neither a registered effect nor effect data the proc/on-use generators could pick up.
+func GenerateTrinketStubs(db *WowDatabase) error {
+	trinketsByTier := map[string][]*proto.UIItem{}
+	for _, item := range db.Items {
+		if item.Type != proto.ItemType_ItemTypeTrinket || item.ItemEffect != nil || core.HasItemEffect(item.Id) {
+			continue
+		}
+
+		scaling, ok := item.ScalingOptions[int32(proto.ItemLevelState_Base)]
+		if !ok || scaling.Ilvl <= MIN_EFFECT_ILVL {
+			continue
+		}
+
+		tier := shared.ItemEffectTierForIlvl(scaling.Ilvl)
+		trinketsByTier[tier] = append(trinketsByTier[tier], item)
+	}
+
+	var tiers []trinketStubTier
+	for _, name := range slices.Sorted(maps.Keys(trinketsByTier)) {
+		trinkets := trinketsByTier[name]
+		slices.SortFunc(trinkets, func(a, b *proto.UIItem) int {
+			return int(a.Id - b.Id)
+		})
+		tiers = append(tiers, trinketStubTier{Name: name, Trinkets: trinkets})
+	}
+
+	tmpl := template.Must(template.New("trinketStubs").Parse(TmplStrTrinketStubs))
+	f, err := os.Create(trinketStubsFileName)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", trinketStubsFileName, err)
+	}
+	defer f.Close()
+
+	if err := tmpl.Execute(f, map[string]any{"Tiers": tiers}); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Scaffolds the packages of a spec which isn't implemented yet.
+// go run ./tools/scaffold_spec -spec=SpecWindwalkerMonk
+//
+// Existing files are never overwritten, so this is safe to run for specs which
+// are partially implemented.
+
+var specFlag = flag.String("spec", "", "Spec enum name to scaffold, e.g. 'SpecWindwalkerMonk'")
+var simDir = flag.String("simDir", "sim", "Path to the sim directory, which holds the class packages.")
+var uiDir = flag.String("uiDir", "ui", "Path to the ui directory, which holds the APL and gear presets.")
+
+type specNames struct {
+	Spec    proto.Spec
+	Class   proto.Class
+	Name    string // e.g. 'WindwalkerMonk', as used by the proto messages.
+	SpecOf  string // e.g. 'Windwalker'
+	ClassOf string // e.g. 'Monk'
+
+	ClassDir string // e.g. 'monk'
+	Package  string // e.g. 'windwalker'
+	Receiver string // e.g. 'windwalker'
+}
+
+func newSpecNames(specName string) (*specNames, error) {
+	specValue, ok := proto.Spec_value[specName]
+	if !ok || proto.Spec(specValue) == proto.Spec_SpecUnknown {
+		return nil, fmt.Errorf("unknown spec %q", specName)
+	}
+
+	name := strings.TrimPrefix(specName, "Spec")
+	for classValue, className := range proto.Class_name {
+		classOf := strings.TrimPrefix(className, "Class")
+		if classValue == int32(proto.Class_ClassUnknown) || !strings.HasSuffix(name, classOf) || name == classOf {
+			continue
+		}
+
+		specOf := strings.TrimSuffix(name, classOf)
+		return &specNames{
+			Spec:     proto.Spec(specValue),
+			Class:    proto.Class(classValue),
+			Name:     name,
+			SpecOf:   specOf,
+			ClassOf:  classOf,
+			ClassDir: toSnakeCase(classOf),
+			Package:  toSnakeCase(specOf),
+			Receiver: strings.ToLower(specOf[:1]) + specOf[1:],
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no class found for spec %q", specName)
+}
+
+func toSnakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+func main() {
+	flag.Parse()
+
+	names, err := newSpecNames(*specFlag)
+	if err != nil {
+		log.Fatalf("failed to resolve spec: %v", err)
+	}
+
+	specDir := filepath.Join(*simDir, names.ClassDir, names.Package)
+	presetDir := filepath.Join(*uiDir, names.ClassDir, names.Package)
+
+	files := []struct {
+		path     string
+		template string
+		isGo     bool
+	}{
+		{filepath.Join(specDir, names.Package+".go"), TmplStrSpec, true},
+		{filepath.Join(specDir, "spells.go"), TmplStrSpells, true},
+		{filepath.Join(specDir, names.Package+"_test.go"), TmplStrTest, true},
+		{filepath.Join(presetDir, "apls", "default.apl.json"), TmplStrApl, false},
+		{filepath.Join(presetDir, "gear_sets", "p1.gear.json"), TmplStrGearSet, false},
+	}
+
+	for _, file := range files {
+		if err := writeTemplate(file.path, file.template, file.isGo, names); err != nil {
+			log.Fatalf("failed to write %s: %v", file.path, err)
+		}
+	}
+
+	log.Printf("Add %s.Register%s() to sim/register_all.go to enable the spec.", names.Package, names.Name)
+}
+
+func writeTemplate(path string, templateString string, isGo bool, names *specNames) error {
+	if _, err := os.Stat(path); err == nil {
+		log.Printf("Skipping %s, which already exists.", path)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New(filepath.Base(path)).Parse(templateString))
+	if err := tmpl.Execute(&buf, names); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	content := buf.Bytes()
+	if isGo {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("failed to format generated code: %w", err)
+		}
+		content = formatted
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	log.Printf("Writing %s", path)
+	return os.WriteFile(path, content, 0644)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestNewSpecNames(t *testing.T) {
+	names, err := newSpecNames("SpecBeastMasteryHunter")
+	if err != nil {
+		t.Fatalf("Failed to resolve spec: %v", err)
+	}
+	if names.Class != proto.Class_ClassHunter || names.ClassDir != "hunter" || names.Package != "beast_mastery" || names.Receiver != "beastMastery" {
+		t.Fatalf("Unexpected names for Beast Mastery Hunter: %+v", names)
+	}
+
+	names, err = newSpecNames("SpecFrostDeathKnight")
+	if err != nil {
+		t.Fatalf("Failed to resolve spec: %v", err)
+	}
+	if names.ClassDir != "death_knight" || names.Package != "frost" {
+		t.Fatalf("Unexpected names for Frost Death Knight: %+v", names)
+	}
+
+	if _, err := newSpecNames("SpecUnknown"); err == nil {
+		t.Fatalf("Expected an error for an unknown spec")
+	}
+}
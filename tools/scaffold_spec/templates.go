@@ -0,0 +1,128 @@
+package main
+
+const TmplStrSpec = `package {{ .Package }}
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func Register{{ .Name }}() {
+	core.RegisterAgentFactory(
+		proto.Player_{{ .Name }}{},
+		proto.Spec_Spec{{ .Name }},
+		func(character *core.Character, options *proto.Player) core.Agent {
+			return New{{ .Name }}(character, options)
+		},
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_{{ .Name }})
+			if !ok {
+				panic("Invalid spec value for {{ .SpecOf }} {{ .ClassOf }}!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+// TODO: Embed the shared class struct instead of core.Character once the
+// class package has a constructor for it.
+type {{ .Name }} struct {
+	core.Character
+
+	Talents *proto.{{ .ClassOf }}Talents
+}
+
+func New{{ .Name }}(character *core.Character, options *proto.Player) *{{ .Name }} {
+	{{ .Receiver }} := &{{ .Name }}{
+		Character: *character,
+		Talents:   &proto.{{ .ClassOf }}Talents{},
+	}
+	core.FillTalentsProto({{ .Receiver }}.Talents.ProtoReflect(), options.TalentsString)
+
+	return {{ .Receiver }}
+}
+
+func ({{ .Receiver }} *{{ .Name }}) GetCharacter() *core.Character {
+	return &{{ .Receiver }}.Character
+}
+
+func ({{ .Receiver }} *{{ .Name }}) Initialize() {
+	{{ .Receiver }}.registerSpells()
+}
+
+func ({{ .Receiver }} *{{ .Name }}) ApplyTalents() {
+}
+
+func ({{ .Receiver }} *{{ .Name }}) Reset(_ *core.Simulation) {
+}
+
+func ({{ .Receiver }} *{{ .Name }}) OnEncounterStart(_ *core.Simulation) {
+}
+`
+
+const TmplStrSpells = `package {{ .Package }}
+
+// Spells registered through RegisterSpell pick up the tuning of the raid's
+// SpellDataset, if it contains them, see core.SpellDataset.
+func ({{ .Receiver }} *{{ .Name }}) registerSpells() {
+	// TODO: Register the spells of the spec, e.g.
+	// {{ .Receiver }}.RegisterSpell(core.SpellConfig{
+	// 	ActionID:    core.ActionID{SpellID: 0},
+	// 	SpellSchool: core.SpellSchoolPhysical,
+	// 	ProcMask:    core.ProcMaskMeleeMHSpecial,
+	// 	Flags:       core.SpellFlagAPL,
+	// 	...
+	// })
+}
+`
+
+const TmplStrTest = `package {{ .Package }}
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/common"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func init() {
+	Register{{ .Name }}()
+	common.RegisterAllEffects()
+}
+
+func Test{{ .SpecOf }}(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class: proto.Class_{{ .Class }},
+			// TODO: Pick the races, talents and item filter of the spec.
+			Race: proto.Race_RaceHuman,
+
+			GearSet:     core.GetGearSet("../../../ui/{{ .ClassDir }}/{{ .Package }}/gear_sets", "p1"),
+			Talents:     "",
+			SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsBasic},
+			Rotation:    core.GetAplRotation("../../../ui/{{ .ClassDir }}/{{ .Package }}/apls", "default"),
+
+			ItemFilter: core.ItemFilter{},
+		},
+	}))
+}
+
+var PlayerOptionsBasic = &proto.Player_{{ .Name }}{
+	{{ .Name }}: &proto.{{ .Name }}{
+		Options: &proto.{{ .Name }}_Options{},
+	},
+}
+`
+
+const TmplStrApl = `{
+	"type": "TypeAPL",
+	"prepullActions": [],
+	"priorityList": []
+}
+`
+
+const TmplStrGearSet = `{
+	"items": []
+}
+`
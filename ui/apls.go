@@ -0,0 +1,9 @@
+// Package ui exposes the assets under ui/ which the sim needs at runtime.
+package ui
+
+import "embed"
+
+// The APL presets of every spec, laid out as <class>/<spec>/apls/<name>.apl.json.
+//
+//go:embed */*/apls/*.apl.json
+var APLs embed.FS
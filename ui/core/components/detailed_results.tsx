@@ -16,6 +16,7 @@ import { LogRunner } from './detailed_results/log_runner';
 import { PlayerDamageMetricsTable } from './detailed_results/player_damage';
 import { PlayerDamageTakenMetricsTable } from './detailed_results/player_damage_taken';
 import { ResourceMetricsTable } from './detailed_results/resource_metrics';
+import { RuneMetricsTable } from './detailed_results/rune_metrics';
 import { SimResultData } from './detailed_results/result_component';
 import { ResultsFilter } from './detailed_results/results_filter';
 import { Timeline } from './detailed_results/timeline';
@@ -226,6 +227,10 @@ export abstract class DetailedResults extends Component {
 			resultsEmitter: this.resultsEmitter,
 			secondaryResource: (simUI as IndividualSimUI<any>)?.player?.secondaryResource,
 		});
+		new RuneMetricsTable({
+			parent: this.rootElem.querySelector('.resource-metrics')!,
+			resultsEmitter: this.resultsEmitter,
+		});
 		new PlayerDamageMetricsTable(
 			{ parent: this.rootElem.querySelector('.player-damage-metrics')!, resultsEmitter: this.resultsEmitter },
 			this.resultsFilter,
@@ -265,6 +270,10 @@ export abstract class DetailedResults extends Component {
 			resultsEmitter: this.resultsEmitter,
 			secondaryResource: (simUI as IndividualSimUI<any>)?.player?.secondaryResource,
 		});
+		new RuneMetricsTable({
+			parent: this.rootElem.querySelector('.resource-metrics')!,
+			resultsEmitter: this.resultsEmitter,
+		});
 
 		const tabEl = document.querySelector('button[data-bs-target="#timelineTab"]');
 		tabEl?.addEventListener('shown.bs.tab', () => {
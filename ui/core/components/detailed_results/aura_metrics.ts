@@ -36,6 +36,23 @@ export class AuraMetricsTable extends MetricsTable<AuraMetrics> {
 				getValue: (metric: AuraMetrics) => metric.uptimePercent,
 				getDisplayString: (metric: AuraMetrics) => metric.uptimePercent.toFixed(2) + '%',
 			},
+			...(useDebuffs
+				? [
+						{
+							name: 'Overshadowed',
+							tooltip: 'Uptime during which the debuff had no effect, because a stronger debuff of the same category was applied instead.',
+							getValue: (metric: AuraMetrics) => metric.overshadowedPercent,
+							getDisplayString: (metric: AuraMetrics) => metric.overshadowedPercent.toFixed(2) + '%',
+						},
+					]
+				: [
+						{
+							name: 'Cancels',
+							tooltip: 'Average # of times per iteration the buff was cancelled by a Cancel Aura APL action.',
+							getValue: (metric: AuraMetrics) => metric.averageAplCancels,
+							getDisplayString: (metric: AuraMetrics) => metric.averageAplCancels.toFixed(2),
+						},
+					]),
 		]);
 		this.useDebuffs = useDebuffs;
 	}
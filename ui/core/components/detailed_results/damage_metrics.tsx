@@ -364,6 +364,35 @@ export class DamageMetricsTable extends MetricsTable<ActionMetrics> {
 										},
 									],
 								},
+								{
+									name: 'Longest streak',
+									spellSchool: metric.spellSchool,
+									totalPercentage: 100,
+									data: [
+										{
+											name: 'Max',
+											value: metric.maxAvoidanceStreak,
+											percentage: 100,
+										},
+										{
+											name: 'Average',
+											value: metric.avgAvoidanceStreak,
+											percentage: (metric.avgAvoidanceStreak / metric.maxAvoidanceStreak) * 100,
+										},
+									],
+								},
+								{
+									name: 'Lost to avoidance',
+									spellSchool: metric.spellSchool,
+									totalPercentage: 100,
+									data: [
+										{
+											name: 'DPS',
+											value: metric.avoidedDps,
+											percentage: 100,
+										},
+									],
+								},
 							]}
 						/>,
 					);
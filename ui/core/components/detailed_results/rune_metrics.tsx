@@ -0,0 +1,53 @@
+import { resourceNames } from '../../proto_utils/names';
+import { ResultComponent, ResultComponentConfig, SimResultData } from './result_component';
+
+// Shows how each rune pair regenerated, to diagnose rune starvation.
+export class RuneMetricsTable extends ResultComponent {
+	private readonly bodyElem: HTMLElement;
+
+	constructor(config: ResultComponentConfig) {
+		config.rootCssClass = 'rune-metrics-root resource-metrics-table-container hide';
+		super(config);
+
+		this.rootElem.appendChild(
+			<>
+				<span className="resource-metrics-table-title">Rune Regeneration</span>
+				<table className="metrics-table">
+					<thead className="metrics-table-header">
+						<tr className="metrics-table-header-row">
+							<th>Runes</th>
+							<th>Avg Rune Cooldown</th>
+							<th>Regenerated</th>
+							<th>Time Depleted</th>
+							<th>Wasted at Cap</th>
+						</tr>
+					</thead>
+					<tbody className="metrics-table-body"></tbody>
+				</table>
+			</>,
+		);
+		this.bodyElem = this.rootElem.querySelector('.metrics-table-body')!;
+	}
+
+	onSimResult(resultData: SimResultData) {
+		this.bodyElem.replaceChildren();
+
+		const players = resultData.result.getRaidIndexedPlayers(resultData.filter);
+		const runes = players.length == 1 ? players[0].runes : [];
+		this.rootElem.classList.toggle('hide', runes.length == 0);
+
+		const resourceCaps = players.length == 1 ? players[0].resourceCaps : [];
+		runes.forEach(runeMetrics => {
+			const wasted = resourceCaps.find(capMetrics => capMetrics.type == runeMetrics.type)?.wastedAvg || 0;
+			this.bodyElem.appendChild(
+				<tr>
+					<td>{resourceNames.get(runeMetrics.type)}</td>
+					<td>{runeMetrics.runeCooldownAvg.toFixed(2)}s</td>
+					<td>{runeMetrics.runesRegeneratedAvg.toFixed(1)}</td>
+					<td>{runeMetrics.secondsDepletedAvg.toFixed(1)}s</td>
+					<td>{wasted.toFixed(1)}</td>
+				</tr>,
+			);
+		});
+	}
+}
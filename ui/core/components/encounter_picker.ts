@@ -267,7 +267,11 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 	private readonly dualWieldPicker: Input<null, boolean>;
 	private readonly dwMissPenaltyPicker: Input<null, boolean>;
 	private readonly parryHastePicker: Input<null, boolean>;
+	private readonly bleedImmunePicker: Input<null, boolean>;
+	private readonly poisonImmunePicker: Input<null, boolean>;
+	private readonly diseaseImmunePicker: Input<null, boolean>;
 	private readonly spellSchoolPicker: Input<null, number>;
+	private readonly schoolImmunityPicker: Input<null, number>;
 	private readonly damageSpreadPicker: Input<null, number>;
 	private readonly targetInputPickers: ListPicker<Encounter, TargetInput>;
 
@@ -487,6 +491,45 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 				encounter.targetsChangeEmitter.emit(eventID);
 			},
 		});
+		this.bleedImmunePicker = new BooleanPicker(section3, null, {
+			id: `target-${this.targetIndex}-picker-bleed-immune`,
+			label: 'Bleed Immune',
+			labelTooltip: 'Whether this enemy is immune to bleeds, which then deal no damage and cannot be cast on it.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().bleedImmune,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().bleedImmune = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+		});
+		this.poisonImmunePicker = new BooleanPicker(section3, null, {
+			id: `target-${this.targetIndex}-picker-poison-immune`,
+			label: 'Poison Immune',
+			labelTooltip: 'Whether this enemy is immune to poisons, which then deal no damage and cannot be cast on it.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().poisonImmune,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().poisonImmune = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+		});
+		this.diseaseImmunePicker = new BooleanPicker(section3, null, {
+			id: `target-${this.targetIndex}-picker-disease-immune`,
+			label: 'Disease Immune',
+			labelTooltip: 'Whether this enemy is immune to diseases, which then deal no damage and cannot be cast on it.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().diseaseImmune,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().diseaseImmune = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+		});
 		this.spellSchoolPicker = new EnumPicker<null>(section3, null, {
 			id: `target-${this.targetIndex}-picker-spell-school`,
 			label: 'Spell School',
@@ -507,6 +550,27 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 				encounter.targetsChangeEmitter.emit(eventID);
 			},
 		});
+		this.schoolImmunityPicker = new EnumPicker<null>(section3, null, {
+			id: `target-${this.targetIndex}-picker-school-immunity`,
+			label: 'Immune To',
+			labelTooltip: 'Spell school this enemy is immune to. Spells of that school deal no damage and cannot be cast on it.',
+			values: [
+				{ name: 'None', value: -1 },
+				{ name: 'Physical', value: SpellSchool.SpellSchoolPhysical },
+				{ name: 'Arcane', value: SpellSchool.SpellSchoolArcane },
+				{ name: 'Fire', value: SpellSchool.SpellSchoolFire },
+				{ name: 'Frost', value: SpellSchool.SpellSchoolFrost },
+				{ name: 'Holy', value: SpellSchool.SpellSchoolHoly },
+				{ name: 'Nature', value: SpellSchool.SpellSchoolNature },
+				{ name: 'Shadow', value: SpellSchool.SpellSchoolShadow },
+			],
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().schoolImmunities[0] ?? -1,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getTarget().schoolImmunities = newValue == -1 ? [] : [newValue];
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+		});
 
 		this.init();
 	}
@@ -525,7 +589,14 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 			dualWield: this.dualWieldPicker.getInputValue(),
 			dualWieldPenalty: this.dwMissPenaltyPicker.getInputValue(),
 			parryHaste: this.parryHastePicker.getInputValue(),
+			bleedImmune: this.bleedImmunePicker.getInputValue(),
+			poisonImmune: this.poisonImmunePicker.getInputValue(),
+			diseaseImmune: this.diseaseImmunePicker.getInputValue(),
 			spellSchool: this.spellSchoolPicker.getInputValue(),
+			schoolImmunities: this.schoolImmunityPicker.getInputValue() == -1 ? [] : [this.schoolImmunityPicker.getInputValue()],
+			// Not editable here, so kept as they are.
+			debuffImmunities: this.getTarget().debuffImmunities,
+			executeProportions: this.getTarget().executeProportions,
 			damageSpread: this.damageSpreadPicker.getInputValue(),
 			stats: this.statPickers
 				.map(picker => picker.getInputValue())
@@ -548,7 +619,11 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 		this.dualWieldPicker.setInputValue(newValue.dualWield);
 		this.dwMissPenaltyPicker.setInputValue(newValue.dualWieldPenalty);
 		this.parryHastePicker.setInputValue(newValue.parryHaste);
+		this.bleedImmunePicker.setInputValue(newValue.bleedImmune);
+		this.poisonImmunePicker.setInputValue(newValue.poisonImmune);
+		this.diseaseImmunePicker.setInputValue(newValue.diseaseImmune);
 		this.spellSchoolPicker.setInputValue(newValue.spellSchool);
+		this.schoolImmunityPicker.setInputValue(newValue.schoolImmunities[0] ?? -1);
 		this.damageSpreadPicker.setInputValue(newValue.damageSpread);
 		ALL_TARGET_STATS.forEach((statData, i) => this.statPickers[i].setInputValue(newValue.stats[statData.stat]));
 		this.targetInputPickers.setInputValue(newValue.targetInputs);
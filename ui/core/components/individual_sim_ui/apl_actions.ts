@@ -24,19 +24,26 @@ import {
 	APLActionMultishield,
 	APLActionResetSequence,
 	APLActionSchedule,
+	APLActionSelectTarget,
+	APLActionSelectTarget_Policy as TargetPolicy,
 	APLActionSequence,
+	APLActionSetVariable,
 	APLActionStrictMultidot,
 	APLActionStrictSequence,
 	APLActionTriggerICD,
+	APLActionUseResourceConsumables,
 	APLActionWait,
 	APLActionWaitUntil,
 	APLValue,
 } from '../../proto/apl.js';
 import { Spec } from '../../proto/common.js';
 import { FeralDruid_Rotation_AplType } from '../../proto/druid.js';
+import { ResourceType } from '../../proto/spell.js';
+import { resourceNames } from '../../proto_utils/names.js';
 import { EventID } from '../../typed_event.js';
 import { randomUUID } from '../../utils';
 import { Input, InputConfig } from '../input.js';
+import { NumberPicker } from '../pickers/number_picker.js';
 import { TextDropdownPicker } from '../pickers/dropdown_picker.jsx';
 import { ListItemPickerConfig, ListPicker } from '../pickers/list_picker.jsx';
 import * as AplHelpers from './apl_helpers.js';
@@ -59,6 +66,7 @@ export class APLActionPicker extends Input<Player<any>, APLAction> {
 	private actionPicker: Input<Player<any>, any> | null;
 
 	private readonly conditionPicker: AplValues.APLValuePicker;
+	private readonly reactionTimePicker: NumberPicker<Player<any>>;
 
 	constructor(parent: HTMLElement, player: Player<any>, config: APLActionPickerConfig) {
 		super(parent, 'apl-action-picker-root', player, config);
@@ -83,6 +91,22 @@ export class APLActionPicker extends Input<Player<any>, APLAction> {
 		});
 		this.conditionPicker.rootElem.classList.add('apl-action-condition', 'apl-priority-list-only');
 
+		this.reactionTimePicker = new NumberPicker(this.rootElem, this.modObject, {
+			id: randomUUID(),
+			label: 'Reaction (ms):',
+			labelTooltip: 'Extra delay before this action is used once it becomes usable, i.e. its condition is <b>True</b> and it is ready, e.g. to model noticing a proc.',
+			extraCssClasses: ['apl-action-reaction-time', 'apl-priority-list-only', 'input-inline'],
+			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
+			getValue: (_player: Player<any>) => this.getSourceValue()?.reactionTimeMs || 0,
+			setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+				const srcVal = this.getSourceValue();
+				if (srcVal) {
+					srcVal.reactionTimeMs = newValue;
+					player.rotationChangeEmitter.emit(eventID);
+				}
+			},
+		});
+
 		this.actionDiv = document.createElement('div');
 		this.actionDiv.classList.add('apl-action-picker-action');
 		this.rootElem.appendChild(this.actionDiv);
@@ -176,6 +200,7 @@ export class APLActionPicker extends Input<Player<any>, APLAction> {
 		const actionKind = this.kindPicker.getInputValue();
 		return APLAction.create({
 			condition: this.conditionPicker.getInputValue(),
+			reactionTimeMs: this.reactionTimePicker.getInputValue(),
 			action: {
 				oneofKind: actionKind,
 				...(() => {
@@ -198,6 +223,8 @@ export class APLActionPicker extends Input<Player<any>, APLAction> {
 			uuid: { value: randomUUID() }
 		}));
 
+		this.reactionTimePicker.setInputValue(newValue.reactionTimeMs);
+
 		const newActionKind = newValue.action.oneofKind;
 		this.updateActionPicker(newActionKind);
 
@@ -277,6 +304,70 @@ function itemSwapSetFieldConfig(field: string): AplHelpers.APLPickerBuilderField
 	};
 }
 
+function consumableResourceTypeFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
+	return {
+		field: field,
+		newValue: () => ResourceType.ResourceTypeMana,
+		factory: (parent, player, config) =>
+			new TextDropdownPicker(parent, player, {
+				id: randomUUID(),
+				...config,
+				defaultLabel: 'None',
+				equals: (a, b) => a == b,
+				values: [ResourceType.ResourceTypeMana, ResourceType.ResourceTypeHealth].map(resourceType => ({
+					value: resourceType,
+					label: resourceNames.get(resourceType)!,
+				})),
+			}),
+	};
+}
+
+function targetPolicyFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
+	return {
+		field: field,
+		newValue: () => TargetPolicy.LowestHealth,
+		factory: (parent, player, config) =>
+			new TextDropdownPicker(parent, player, {
+				id: randomUUID(),
+				...config,
+				defaultLabel: 'None',
+				equals: (a, b) => a == b,
+				values: [
+					{ value: TargetPolicy.LowestHealth, label: 'Lowest Health' },
+					{ value: TargetPolicy.HighestHealth, label: 'Highest Health' },
+					{ value: TargetPolicy.MissingDot, label: 'Missing DoT' },
+					{ value: TargetPolicy.PriorityIndex, label: 'Priority List' },
+				],
+			}),
+	};
+}
+
+function targetIndexListFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
+	return {
+		field: field,
+		newValue: () => [],
+		factory: (parent, player, config) =>
+			new ListPicker<Player<any>, number>(parent, player, {
+				...config,
+				itemLabel: 'Target Index',
+				newItem: () => 0,
+				copyItem: (oldValue: number) => oldValue,
+				newItemPicker: (
+					parent: HTMLElement,
+					listPicker: ListPicker<Player<any>, number>,
+					index: number,
+					config: ListItemPickerConfig<Player<any>, number>,
+				) => new NumberPicker(parent, player, { ...config, extraCssClasses: ['input-inline'] }),
+				allowedActions: ['create', 'delete', 'move'],
+				actions: {
+					create: {
+						useIcon: true,
+					},
+				},
+			}),
+	};
+}
+
 function actionFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
 	return {
 		field: field,
@@ -513,6 +604,29 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: APLActionAutocastOtherCooldowns.create,
 		fields: [],
 	}),
+	['useResourceConsumables']: inputBuilder({
+		label: 'Use Resource Consumables',
+		submenu: ['Casting'],
+		shortDescription: 'Uses consumables which restore the resource, e.g. mana potions and runes.',
+		fullDescription: `
+			<ul>
+				<li>Does not use consumables which are already controlled by other actions in the priority list.</li>
+				<li>Consumables are used once enough of the resource is missing that none of the restore is wasted.</li>
+				<li>If <b>During Channels</b> is checked, consumables are also used on channel ticks, without interrupting the channel.</li>
+			</ul>
+		`,
+		includeIf: (player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: () =>
+			APLActionUseResourceConsumables.create({
+				resourceType: ResourceType.ResourceTypeMana,
+			}),
+		fields: [
+			consumableResourceTypeFieldConfig('resourceType'),
+			AplHelpers.booleanFieldConfig('useWhileChanneling', 'During Channels', {
+				labelTooltip: 'If checked, consumables are also used during channels, without interrupting them.',
+			}),
+		],
+	}),
 	['wait']: inputBuilder({
 		label: 'Wait',
 		submenu: ['Timing'],
@@ -565,11 +679,18 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		shortDescription: 'A list of sub-actions to execute in the specified order.',
 		fullDescription: `
 			<p>Once one of the sub-actions has been performed, the next sub-action will not necessarily be immediately executed next. The system will restart at the beginning of the whole actions list (not the sequence). If the sequence is executed again, it will perform the next sub-action.</p>
-			<p>When all actions have been performed, the sequence does NOT automatically reset; instead, it will be skipped from now on. Use the <b>Reset Sequence</b> action to reset it, if desired.</p>
+			<p>When all actions have been performed, the sequence does NOT automatically reset; instead, it will be skipped from now on. Use the <b>Reset Sequence</b> action or the <b>Reset If</b> condition to reset it, if desired.</p>
 		`,
 		includeIf: (_, isPrepull: boolean) => !isPrepull,
 		newValue: APLActionSequence.create,
-		fields: [AplHelpers.stringFieldConfig('name'), actionListFieldConfig('actions')],
+		fields: [
+			AplHelpers.stringFieldConfig('name'),
+			AplValues.valueFieldConfig('resetCondition', {
+				label: 'Reset If',
+				labelTooltip: 'If set, the sequence restarts from its first sub-action whenever this condition is true.',
+			}),
+			actionListFieldConfig('actions'),
+		],
 	}),
 	['resetSequence']: inputBuilder({
 		label: 'Reset Sequence',
@@ -589,10 +710,26 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 			'Like a regular <b>Sequence</b>, except all sub-actions are executed immediately after each other and the sequence resets automatically upon completion.',
 		fullDescription: `
 			<p>Strict Sequences do not begin unless ALL sub-actions are ready.</p>
+			<p>By default the sequence is abandoned when the next sub-action isn't ready once the GCD is. With <b>Wait for Resources</b> checked, it instead waits as long as the sub-action is only missing resources, like SimC's <b>strict_sequence</b>.</p>
 		`,
 		includeIf: (_, isPrepull: boolean) => !isPrepull,
 		newValue: APLActionStrictSequence.create,
-		fields: [actionListFieldConfig('actions')],
+		fields: [
+			AplHelpers.booleanFieldConfig('waitForResources', 'Wait for Resources', {
+				labelTooltip: 'If checked, waits for resources for the next sub-action instead of abandoning the sequence.',
+			}),
+			actionListFieldConfig('actions'),
+		],
+	}),
+	['setVariable']: inputBuilder({
+		label: 'Set Variable',
+		submenu: ['Misc'],
+		shortDescription: 'Sets a variable declared in the <b>Variables</b> list to a new value.',
+		fullDescription: `
+			<p>This action is only used when it would change the variable, so the rest of the priority list is still evaluated afterwards.</p>
+		`,
+		newValue: APLActionSetVariable.create,
+		fields: [AplHelpers.stringFieldConfig('name'), AplValues.valueFieldConfig('value')],
 	}),
 	['changeTarget']: inputBuilder({
 		label: 'Change Target',
@@ -601,6 +738,25 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: () => APLActionChangeTarget.create(),
 		fields: [AplHelpers.unitFieldConfig('newTarget', 'targets')],
 	}),
+	['selectTarget']: inputBuilder({
+		label: 'Select Target',
+		submenu: ['Misc'],
+		shortDescription: 'Changes the current target to the one chosen by a policy, re-evaluated on every decision.',
+		fullDescription: `
+		<ul>
+			<li><b>Lowest/Highest Health:</b> Active target with the lowest or highest estimated health.</li>
+			<li><b>Missing DoT:</b> Active target without the selected DoT.</li>
+			<li><b>Priority List:</b> First active target in the list, where 0 is the first target.</li>
+		</ul>
+		<p>The current target is kept on ties. Combine with a condition to stay on the primary target unless the condition is met.</p>
+		`,
+		newValue: () => APLActionSelectTarget.create({ policy: TargetPolicy.LowestHealth }),
+		fields: [
+			targetPolicyFieldConfig('policy'),
+			AplHelpers.actionIdFieldConfig('spellId', 'dot_spells', ''),
+			targetIndexListFieldConfig('targetIndices'),
+		],
+	}),
 	['activateAura']: inputBuilder({
 		label: 'Activate Aura',
 		submenu: ['Misc'],
@@ -712,6 +868,9 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 				useBite: true,
 				biteTime: 11,
 				berserkBiteTime: 7,
+				optimizeBite: false,
+				maxRakeTargets: 3,
+				rakeTargetThreshold: 5,
 				allowAoeBerserk: false,
 				bearWeave: true,
 				snekWeave: true,
@@ -758,6 +917,19 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 				label: 'Bite Time during Berserk',
 				labelTooltip: 'More aggressive threshold when Berserk is active.',
 			}),
+			AplHelpers.booleanFieldConfig('optimizeBite', 'Optimize Bite timing', {
+				labelTooltip:
+					"Decide on Bites with a lookahead over Energy income, upcoming Tiger's Fury and Berserk, and Rip/Roar timers instead of the Bite Times. Decisions are shown in the debug log.",
+			}),
+			AplHelpers.numberFieldConfig('maxRakeTargets', false, {
+				label: 'Max Rake Targets',
+				labelTooltip: 'Number of targets to maintain Rake on. Ignored for single target rotation or if not using manual advanced parameters.',
+			}),
+			AplHelpers.numberFieldConfig('rakeTargetThreshold', false, {
+				label: 'Rake Target Threshold',
+				labelTooltip:
+					'Rakes are dropped in favor of Swipe when more targets than this are active. Ignored for single target rotation or if not using manual advanced parameters.',
+			}),
 		],
 	}),
 
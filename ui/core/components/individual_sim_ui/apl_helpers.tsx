@@ -354,7 +354,7 @@ export class APLActionIDPicker extends DropdownPicker<Player<any>, ActionID, Act
 	}
 }
 
-export type UNIT_SET = 'aura_sources' | 'aura_sources_targets_first' | 'targets' | 'players';
+export type UNIT_SET = 'aura_sources' | 'aura_sources_targets_first' | 'all_units' | 'targets' | 'players';
 
 const unitSets: Record<
 	UNIT_SET,
@@ -397,6 +397,26 @@ const unitSets: Record<
 			].flat();
 		},
 	},
+	all_units: {
+		targetUI: true,
+		getUnits: player => {
+			return [
+				undefined,
+				player.sim.encounter.targetsMetadata.asList().map((_targetMetadata, i) => UnitReference.create({ type: UnitType.Target, index: i })),
+				UnitReference.create({ type: UnitType.PreviousTarget }),
+				UnitReference.create({ type: UnitType.NextTarget }),
+				UnitReference.create({ type: UnitType.Self }),
+				player
+					.getPetMetadatas()
+					.asList()
+					.map((_petMetadata, i) => UnitReference.create({ type: UnitType.Pet, index: i, owner: UnitReference.create({ type: UnitType.Self }) })),
+				player.sim.raid
+					.getActivePlayers()
+					.filter(filter => filter != player)
+					.map(mapPlayer => UnitReference.create({ type: UnitType.Player, index: mapPlayer.getRaidIndex() })),
+			].flat();
+		},
+	},
 	targets: {
 		targetUI: true,
 		getUnits: player => {
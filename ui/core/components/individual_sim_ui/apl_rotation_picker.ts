@@ -1,13 +1,15 @@
 import tippy, { Instance as TippyInstance } from 'tippy.js';
 
 import { Player } from '../../player';
-import { APLAction, APLListItem, APLPrepullAction, APLValue } from '../../proto/apl';
+import { APLAction, APLListItem, APLPrepullAction, APLValue, APLValueType, APLVariable, APLVariable_Scope as VariableScope } from '../../proto/apl';
 import { SimUI } from '../../sim_ui';
 import { EventID, TypedEvent } from '../../typed_event';
 import { randomUUID } from '../../utils';
 import { Component } from '../component';
 import { Input, InputConfig } from '../input';
 import { ListItemPickerConfig, ListPicker } from '../pickers/list_picker';
+import { NumberPicker } from '../pickers/number_picker';
+import { TextDropdownPicker } from '../pickers/dropdown_picker.jsx';
 import { AdaptiveStringPicker } from '../pickers/string_picker';
 import { APLActionPicker } from './apl_actions';
 import { APLValueImplStruct } from './apl_values';
@@ -16,6 +18,46 @@ export class APLRotationPicker extends Component {
 	constructor(parent: HTMLElement, simUI: SimUI, modPlayer: Player<any>) {
 		super(parent, 'apl-rotation-picker-root');
 
+		new NumberPicker(this.rootElem, modPlayer, {
+			id: 'apl-human-error-chance',
+			label: 'Human Error Chance (%)',
+			labelTooltip:
+				'Chance for each decision to take the second best ready action instead of the best one. Results then show the expected DPS loss compared to perfect play.',
+			float: true,
+			positive: true,
+			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
+			getValue: (player: Player<any>) => player.aplRotation.humanErrorChance * 100,
+			setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+				player.aplRotation.humanErrorChance = Math.min(newValue, 100) / 100;
+				player.rotationChangeEmitter.emit(eventID);
+			},
+		});
+
+		new ListPicker<Player<any>, APLVariable>(this.rootElem, modPlayer, {
+			extraCssClasses: ['apl-variable-picker'],
+			title: 'Variables',
+			titleTooltip: 'State which the <b>Set Variable</b> action can change and the <b>Variable</b> value can read, e.g. to pool resources.',
+			itemLabel: 'Variable',
+			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
+			getValue: (player: Player<any>) => player.aplRotation.variables,
+			setValue: (eventID: EventID, player: Player<any>, newValue: Array<APLVariable>) => {
+				player.aplRotation.variables = newValue;
+				player.rotationChangeEmitter.emit(eventID);
+			},
+			newItem: () =>
+				APLVariable.create({
+					type: APLValueType.ValueTypeBool,
+				}),
+			copyItem: (oldItem: APLVariable) => APLVariable.clone(oldItem),
+			newItemPicker: (
+				parent: HTMLElement,
+				listPicker: ListPicker<Player<any>, APLVariable>,
+				index: number,
+				config: ListItemPickerConfig<Player<any>, APLVariable>,
+			) => new APLVariablePicker(parent, modPlayer, config),
+			inlineMenuBar: true,
+		});
+
 		new ListPicker<Player<any>, APLPrepullAction>(this.rootElem, modPlayer, {
 			extraCssClasses: ['apl-prepull-action-picker'],
 			title: 'Prepull Actions',
@@ -239,6 +281,119 @@ class APLListItemPicker extends Input<Player<any>, APLListItem> {
 	}
 }
 
+class APLVariablePicker extends Input<Player<any>, APLVariable> {
+	private readonly player: Player<any>;
+
+	private readonly namePicker: Input<Player<any>, string>;
+	private readonly typePicker: Input<Player<any>, number>;
+	private readonly scopePicker: Input<Player<any>, number>;
+	private readonly initialValuePicker: Input<Player<any>, string>;
+
+	private getItem(): APLVariable {
+		return this.getSourceValue() || APLVariable.create();
+	}
+
+	constructor(parent: HTMLElement, player: Player<any>, config: ListItemPickerConfig<Player<any>, APLVariable>) {
+		super(parent, 'apl-list-item-picker-root', player, config);
+		this.player = player;
+
+		// Validations of all variables are reported together, and mention the name of the variable.
+		const itemHeaderElem = ListPicker.getItemHeaderElem(this);
+		ListPicker.makeListItemValidations(itemHeaderElem, player, player =>
+			(player.getCurrentStats().rotationStats?.variableValidations || []).filter(validation =>
+				this.getItem().name ? validation.validation.includes(`'${this.getItem().name}'`) : true,
+			),
+		);
+
+		const changedEvent = () => this.player.rotationChangeEmitter;
+		this.namePicker = new AdaptiveStringPicker(this.rootElem, this.player, {
+			id: randomUUID(),
+			label: 'Name',
+			extraCssClasses: ['input-inline'],
+			changedEvent,
+			getValue: () => this.getItem().name,
+			setValue: (eventID: EventID, player: Player<any>, newValue: string) => {
+				this.getItem().name = newValue;
+				this.player.rotationChangeEmitter.emit(eventID);
+			},
+			inline: true,
+		});
+		this.typePicker = new TextDropdownPicker(this.rootElem, this.player, {
+			id: randomUUID(),
+			label: 'Type',
+			changedEvent,
+			getValue: () => this.getItem().type,
+			setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+				this.getItem().type = newValue;
+				this.player.rotationChangeEmitter.emit(eventID);
+			},
+			defaultLabel: 'Bool',
+			equals: (a, b) => a == b,
+			values: [
+				{ value: APLValueType.ValueTypeBool, label: 'Bool' },
+				{ value: APLValueType.ValueTypeFloat, label: 'Number' },
+				{ value: APLValueType.ValueTypeDuration, label: 'Duration' },
+			],
+		});
+		this.scopePicker = new TextDropdownPicker(this.rootElem, this.player, {
+			id: randomUUID(),
+			label: 'Reset',
+			labelTooltip:
+				'<b>Each Iteration:</b> Reset to the initial value at the start of each iteration.<br><b>Shared:</b> Like Each Iteration, but shared with every raid member declaring the same variable.<br><b>Never:</b> Keeps its value across iterations.',
+			changedEvent,
+			getValue: () => this.getItem().scope,
+			setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+				this.getItem().scope = newValue;
+				this.player.rotationChangeEmitter.emit(eventID);
+			},
+			defaultLabel: 'Each Iteration',
+			equals: (a, b) => a == b,
+			values: [
+				{ value: VariableScope.ScopeIteration, label: 'Each Iteration' },
+				{ value: VariableScope.ScopeEncounter, label: 'Shared' },
+				{ value: VariableScope.ScopePermanent, label: 'Never' },
+			],
+		});
+		this.initialValuePicker = new AdaptiveStringPicker(this.rootElem, this.player, {
+			id: randomUUID(),
+			label: 'Initial Value',
+			labelTooltip: "Formatted like a constant value, e.g. 'true', '1.5' or '4s'.",
+			extraCssClasses: ['input-inline'],
+			changedEvent,
+			getValue: () => this.getItem().initialValue,
+			setValue: (eventID: EventID, player: Player<any>, newValue: string) => {
+				this.getItem().initialValue = newValue;
+				this.player.rotationChangeEmitter.emit(eventID);
+			},
+			inline: true,
+		});
+		this.init();
+	}
+
+	getInputElem(): HTMLElement | null {
+		return this.rootElem;
+	}
+
+	getInputValue(): APLVariable {
+		return APLVariable.create({
+			name: this.namePicker.getInputValue(),
+			type: this.typePicker.getInputValue(),
+			scope: this.scopePicker.getInputValue(),
+			initialValue: this.initialValuePicker.getInputValue(),
+		});
+	}
+
+	setInputValue(newValue: APLVariable) {
+		if (!newValue) {
+			return;
+		}
+		this.namePicker.setInputValue(newValue.name);
+		this.typePicker.setInputValue(newValue.type);
+		this.scopePicker.setInputValue(newValue.scope);
+		this.initialValuePicker.setInputValue(newValue.initialValue);
+	}
+}
+
 class HidePicker extends Input<Player<any>, boolean> {
 	private readonly inputElem: HTMLElement;
 	private readonly iconElem: HTMLElement;
@@ -5,6 +5,7 @@ import {
 	APLValueAnd,
 	APLValueAnyStatBuffCooldownsActive,
 	APLValueAnyTrinketStatProcsActive,
+	APLValueAuraExclusiveStrongestValue,
 	APLValueAuraICDIsReadyWithReactionTime,
 	APLValueAuraInternalCooldown,
 	APLValueAuraIsActive,
@@ -12,9 +13,11 @@ import {
 	APLValueAuraIsInactiveWithReactionTime,
 	APLValueAuraIsKnown,
 	APLValueAuraNumStacks,
+	APLValueAuraNumStacksOnUnit,
 	APLValueAuraRemainingTime,
 	APLValueAuraShouldRefresh,
 	APLValueAutoTimeToNext,
+	APLValueBossHealthPercent,
 	APLValueBossSpellIsCasting,
 	APLValueBossSpellTimeToReady,
 	APLValueCatExcessEnergy,
@@ -48,6 +51,7 @@ import {
 	APLValueDotPercentIncrease,
 	APLValueDotRemainingTime,
 	APLValueDotTickFrequency,
+	APLValueEncounterDamageModifier,
 	APLValueEnergyRegenPerSecond,
 	APLValueEnergyTimeToTarget,
 	APLValueFocusRegenPerSecond,
@@ -56,6 +60,7 @@ import {
 	APLValueGCDIsReady,
 	APLValueGCDTimeToReady,
 	APLValueInputDelay,
+	APLValueInStance,
 	APLValueIsExecutePhase,
 	APLValueIsExecutePhase_ExecutePhaseThreshold as ExecutePhaseThreshold,
 	APLValueMageCurrentCombustionDotEstimate,
@@ -78,6 +83,8 @@ import {
 	APLValueNumStatBuffCooldowns,
 	APLValueOr,
 	APLValueProtectionPaladinDamageTakenLastGlobal,
+	APLValueRatingPercent,
+	APLValueRatingPercent_RatingType as RatingType,
 	APLValueRemainingTime,
 	APLValueRemainingTimePercent,
 	APLValueRuneCooldown,
@@ -98,11 +105,15 @@ import {
 	APLValueSpellTimeToCharge,
 	APLValueSpellTimeToReady,
 	APLValueSpellTravelTime,
+	APLValueTargetImmuneToSpell,
+	APLValueThreatPercent,
+	APLValueTimeToRuneCount,
 	APLValueTotemRemainingTime,
 	APLValueTrinketProcsMaxRemainingICD,
 	APLValueTrinketProcsMinRemainingTime,
 	APLValueUnitDistance,
 	APLValueUnitIsMoving,
+	APLValueVariable,
 	APLValueWarlockHandOfGuldanInFlight,
 	APLValueWarlockHauntInFlight,
 } from '../../proto/apl.js';
@@ -408,6 +419,29 @@ function mathOperatorFieldConfig(field: string): AplHelpers.APLPickerBuilderFiel
 	};
 }
 
+function ratingTypeFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
+	return {
+		field: field,
+		newValue: () => RatingType.RatingHaste,
+		factory: (parent, player, config) =>
+			new TextDropdownPicker(parent, player, {
+				id: randomUUID(),
+				...config,
+				defaultLabel: 'None',
+				equals: (a, b) => a == b,
+				values: [
+					{ value: RatingType.RatingHaste, label: 'Haste' },
+					{ value: RatingType.RatingCrit, label: 'Crit' },
+					{ value: RatingType.RatingHit, label: 'Hit' },
+					{ value: RatingType.RatingExpertise, label: 'Expertise' },
+					{ value: RatingType.RatingMastery, label: 'Mastery' },
+					{ value: RatingType.RatingDodge, label: 'Dodge' },
+					{ value: RatingType.RatingParry, label: 'Parry' },
+				],
+			}),
+	};
+}
+
 function executePhaseThresholdFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
 	return {
 		field: field,
@@ -631,6 +665,16 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 		newValue: APLValueIsExecutePhase.create,
 		fields: [executePhaseThresholdFieldConfig('threshold')],
 	}),
+	bossHealthPercent: inputBuilder({
+		label: 'Boss Health (%)',
+		submenu: ['Encounter'],
+		shortDescription: 'Estimated health of the boss, from <b>100%</b> at the pull to <b>0%</b> at the end of the fight.',
+		fullDescription: `
+		<p>Unlike <b>Current Health (%)</b>, this also works when the boss has no health, in which case it is interpolated between the execute phase durations of the encounter, so it agrees with <b>Is Execute Phase</b>.</p>
+		`,
+		newValue: APLValueBossHealthPercent.create,
+		fields: [],
+	}),
 	numberTargets: inputBuilder({
 		label: 'Number of Targets',
 		submenu: ['Encounter'],
@@ -638,6 +682,41 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 		newValue: APLValueNumberTargets.create,
 		fields: [],
 	}),
+	encounterDamageModifier: inputBuilder({
+		label: 'Encounter Damage Modifier',
+		submenu: ['Encounter'],
+		shortDescription: 'Multiplier from raid wide damage modifier phases of the current encounter which are currently active, e.g. <b>2</b> during a 100% damage buff window.',
+		newValue: APLValueEncounterDamageModifier.create,
+		fields: [
+			AplHelpers.booleanFieldConfig('damageTaken', 'Damage Taken', {
+				labelTooltip: 'If checked, returns the damage taken multiplier instead of the damage dealt multiplier.',
+			}),
+		],
+	}),
+	targetImmuneToSpell: inputBuilder({
+		label: 'Target Immune To Spell',
+		submenu: ['Encounter'],
+		shortDescription: '<b>True</b> if the target is currently immune to, or reflects, the school of the spell, or is immune to its mechanic (e.g. bleeds), otherwise <b>False</b>.',
+		newValue: APLValueTargetImmuneToSpell.create,
+		fields: [AplHelpers.unitFieldConfig('targetUnit', 'targets'), AplHelpers.actionIdFieldConfig('spellId', 'castable_spells', '')],
+	}),
+	threatPercent: inputBuilder({
+		label: 'Threat Percent',
+		submenu: ['Encounter'],
+		shortDescription: 'Threat of the player on the target, as a percentage of the threat of the unit the target is attacking. Aggro is pulled above 110% in melee range, or 130% at range. Always <b>0</b> unless the encounter models threat.',
+		newValue: APLValueThreatPercent.create,
+		fields: [AplHelpers.unitFieldConfig('targetUnit', 'targets')],
+	}),
+	ratingPercent: inputBuilder({
+		label: 'Rating (%)',
+		submenu: ['Unit'],
+		shortDescription: 'Percentage granted by the current amount of a combat rating, e.g. <b>Haste</b> rating.',
+		fullDescription: `
+		<p>Only counts the rating itself, not percentage based effects like haste buffs. <b>Mastery</b> is in mastery points.</p>
+		`,
+		newValue: APLValueRatingPercent.create,
+		fields: [ratingTypeFieldConfig('rating')],
+	}),
 	frontOfTarget: inputBuilder({
 		label: 'Front of Target',
 		submenu: ['Encounter'],
@@ -976,6 +1055,20 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 		includeIf: (player: Player<any>, _isPrepull: boolean) => player.getClass() == Class.ClassDeathKnight,
 		fields: [AplHelpers.runeSlotFieldConfig('runeSlot')],
 	}),
+	timeToRuneCount: inputBuilder({
+		label: 'Time To Rune Count',
+		submenu: ['Resources', 'Runes'],
+		shortDescription:
+			'Amount of time until the given number of runes of certain type, including Death, is ready to use at the current regen rate.<br><b>NOTE:</b> Returns 0 if enough runes are available',
+		newValue: () => APLValueTimeToRuneCount.create({ count: 2 }),
+		includeIf: (player: Player<any>, _isPrepull: boolean) => player.getClass() == Class.ClassDeathKnight,
+		fields: [
+			AplHelpers.runeTypeFieldConfig('runeType', true),
+			AplHelpers.numberFieldConfig('count', false, {
+				label: 'runes',
+			}),
+		],
+	}),
 
 	// GCD
 	gcdIsReady: inputBuilder({
@@ -1162,6 +1255,16 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 		newValue: APLValueAuraNumStacks.create,
 		fields: [AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources'), AplHelpers.actionIdFieldConfig('auraId', 'stackable_auras', 'sourceUnit')],
 	}),
+	auraNumStacksOnUnit: inputBuilder({
+		label: 'Aura Num Stacks On Unit',
+		submenu: ['Aura'],
+		shortDescription: 'Number of stacks of the aura on the chosen unit, or <b>0</b> if the aura is not active on that unit.',
+		fullDescription: `
+		<p>The unit is resolved every time the value is evaluated, so <b>Current Target</b>, <b>Previous Target</b> and <b>Next Target</b> follow target swaps.</p>
+		`,
+		newValue: APLValueAuraNumStacksOnUnit.create,
+		fields: [AplHelpers.unitFieldConfig('unit', 'all_units'), AplHelpers.actionIdFieldConfig('auraId', 'stackable_auras', 'unit')],
+	}),
 	auraInternalCooldown: inputBuilder({
 		label: 'Aura Remaining ICD',
 		submenu: ['Aura'],
@@ -1205,6 +1308,27 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 			}),
 		],
 	}),
+	auraExclusiveStrongestValue: inputBuilder({
+		label: 'Strongest Exclusive Value',
+		submenu: ['Aura'],
+		shortDescription: 'Value of the strongest effect currently applied in the debuff category of this aura, or <b>0</b> if none is applied.',
+		fullDescription: `
+		<p>Includes auras applied by other raid members, e.g. to skip a debuff when a stronger one from the same category is already applied and yours would be wasted.</p>
+		`,
+		newValue: APLValueAuraExclusiveStrongestValue.create,
+		fields: [
+			AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources_targets_first'),
+			AplHelpers.actionIdFieldConfig('auraId', 'exclusive_effect_auras', 'sourceUnit', 'currentTarget'),
+		],
+	}),
+
+	inStance: inputBuilder({
+		label: 'In Stance',
+		submenu: ['Aura'],
+		shortDescription: '<b>True</b> if the player is currently in the specified stance, form or presence, otherwise <b>False</b>.',
+		newValue: APLValueInStance.create,
+		fields: [AplHelpers.actionIdFieldConfig('stanceId', 'auras')],
+	}),
 
 	// Aura Sets
 	allTrinketStatProcsActive: inputBuilder({
@@ -1400,6 +1524,15 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 		fields: [AplHelpers.stringFieldConfig('sequenceName')],
 	}),
 
+	// Variable values
+	variable: inputBuilder({
+		label: 'Variable',
+		submenu: ['Variable'],
+		shortDescription: 'Returns the current value of a variable declared in the <b>Variables</b> list.',
+		newValue: APLValueVariable.create,
+		fields: [AplHelpers.stringFieldConfig('name')],
+	}),
+
 	// Class/spec specific values
 	totemRemainingTime: inputBuilder({
 		label: 'Totem Remaining Time',
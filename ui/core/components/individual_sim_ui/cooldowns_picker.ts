@@ -1,11 +1,12 @@
 import tippy from 'tippy.js';
 
 import { Player } from '../../player.js';
-import { ActionID as ActionIdProto, Cooldown } from '../../proto/common.js';
+import { ActionID as ActionIdProto, Cooldown, CooldownStackingPolicy } from '../../proto/common.js';
 import { ActionId } from '../../proto_utils/action_id.js';
 import { EventID, TypedEvent } from '../../typed_event.js';
 import { existsInDOM } from '../../utils';
 import { Component } from '../component.js';
+import { EnumPicker } from '../pickers/enum_picker.js';
 import { IconEnumPicker, IconEnumValueConfig } from '../pickers/icon_enum_picker.jsx';
 import { NumberListPicker } from '../pickers/number_list_picker.js';
 
@@ -61,6 +62,8 @@ export class CooldownsPicker extends Component {
 			row.appendChild(label);
 
 			const timingsPicker = this.makeTimingsPicker(row, i);
+			this.makeStackingPicker(row, i);
+			this.makeAlignAuraPicker(row, i);
 
 			const deleteButtonFragment = document.createElement('fragment');
 			deleteButtonFragment.innerHTML = `
@@ -147,4 +150,61 @@ export class CooldownsPicker extends Component {
 		});
 		return actionPicker;
 	}
+
+	private makeStackingPicker(parentElem: HTMLElement, cooldownIndex: number): EnumPicker<Player<any>> {
+		return new EnumPicker(parentElem, this.player, {
+			id: `cooldown-stacking-${cooldownIndex}`,
+			extraCssClasses: ['cooldown-stacking-picker'],
+			values: [
+				{ name: 'Use when ready', value: CooldownStackingPolicy.CooldownStackingNone },
+				{
+					name: 'Stack',
+					value: CooldownStackingPolicy.CooldownStackingAlways,
+					tooltip: 'Hold until the other stacking cooldowns are ready too, unless waiting would cost a use.',
+				},
+				{ name: 'Stagger', value: CooldownStackingPolicy.CooldownStackingStagger, tooltip: 'Never use while another DPS cooldown is active.' },
+				{ name: 'Align with aura', value: CooldownStackingPolicy.CooldownStackingAlignWithAura, tooltip: 'Only use while the chosen aura is active.' },
+			],
+			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
+			getValue: (player: Player<any>) => player.getSimpleCooldowns().cooldowns[cooldownIndex]?.stackingPolicy || CooldownStackingPolicy.CooldownStackingNone,
+			setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+				const newCooldowns = player.getSimpleCooldowns();
+				newCooldowns.cooldowns[cooldownIndex].stackingPolicy = newValue;
+				player.setSimpleCooldowns(eventID, newCooldowns);
+			},
+			enableWhen: (player: Player<any>) => {
+				const curCooldown = player.getSimpleCooldowns().cooldowns[cooldownIndex];
+				return curCooldown && !ActionIdProto.equals(curCooldown.id, ActionIdProto.create());
+			},
+		});
+	}
+
+	private makeAlignAuraPicker(parentElem: HTMLElement, cooldownIndex: number): IconEnumPicker<Player<any>, ActionIdProto> {
+		const auras = this.player
+			.getMetadata()
+			.getAuras()
+			.map(aura => aura.id);
+
+		return new IconEnumPicker<Player<any>, ActionIdProto>(parentElem, this.player, {
+			extraCssClasses: ['cooldown-align-aura-picker'],
+			numColumns: 3,
+			values: ([{ color: '#grey', value: ActionIdProto.create() }] as Array<IconEnumValueConfig<Player<any>, ActionIdProto>>).concat(
+				auras.map(auraId => {
+					return { actionId: auraId, value: auraId.toProto() };
+				}),
+			),
+			equals: (a: ActionIdProto, b: ActionIdProto) => ActionIdProto.equals(a, b),
+			zeroValue: ActionIdProto.create(),
+			backupIconUrl: (value: ActionIdProto) => ActionId.fromProto(value),
+			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
+			getValue: (player: Player<any>) => player.getSimpleCooldowns().cooldowns[cooldownIndex]?.alignAuraId || ActionIdProto.create(),
+			setValue: (eventID: EventID, player: Player<any>, newValue: ActionIdProto) => {
+				const newCooldowns = player.getSimpleCooldowns();
+				newCooldowns.cooldowns[cooldownIndex].alignAuraId = newValue.rawId.oneofKind ? newValue : undefined;
+				player.setSimpleCooldowns(eventID, newCooldowns);
+			},
+			showWhen: (player: Player<any>) =>
+				player.getSimpleCooldowns().cooldowns[cooldownIndex]?.stackingPolicy == CooldownStackingPolicy.CooldownStackingAlignWithAura,
+		});
+	}
 }
@@ -332,6 +332,8 @@ export class SettingsTab extends SimTab {
 					);
 					simUI.player.setReactionTime(eventID, newSettings.reactionTimeMs);
 					simUI.player.setChannelClipDelay(eventID, newSettings.channelClipDelayMs);
+					simUI.player.setLatency(eventID, newSettings.latencyMs);
+					simUI.player.setLatencyJitter(eventID, newSettings.latencyJitterMs);
 					simUI.player.setInFrontOfTarget(eventID, newSettings.inFrontOfTarget);
 					simUI.player.setDistanceFromTarget(eventID, newSettings.distanceFromTarget);
 					simUI.player.setHealingModel(eventID, newSettings.healingModel || HealingModel.create());
@@ -404,6 +406,8 @@ export class SettingsTab extends SimTab {
 			itemSwap: this.simUI.player.itemSwapSettings.toProto(),
 			reactionTimeMs: this.simUI.player.getReactionTime(),
 			channelClipDelayMs: this.simUI.player.getChannelClipDelay(),
+			latencyMs: this.simUI.player.getLatency(),
+			latencyJitterMs: this.simUI.player.getLatencyJitter(),
 			inFrontOfTarget: this.simUI.player.getInFrontOfTarget(),
 			distanceFromTarget: this.simUI.player.getDistanceFromTarget(),
 			healingModel: this.simUI.player.getHealingModel(),
@@ -100,6 +100,18 @@ export const StaminaBuff = InputHelpers.makeMultiIconInput(
 
 // Misc Buffs
 export const ManaTideTotem = makeMultistateRaidBuffInput({ actionId: ActionId.fromSpellId(16190), numStates: 5, fieldName: 'manaTideTotemCount' });
+export const Innervate = makeMultistateIndividualBuffInput({
+	actionId: ActionId.fromSpellId(29166),
+	numStates: 11,
+	fieldName: 'innervateCount',
+	label: 'Innervate',
+});
+export const HymnOfHope = makeMultistateIndividualBuffInput({
+	actionId: ActionId.fromSpellId(64901),
+	numStates: 11,
+	fieldName: 'hymnOfHopeCount',
+	label: 'Hymn of Hope',
+});
 
 // External Damage Cooldowns
 export const MajorHasteBuff = makeBooleanRaidBuffInput({ actionId: ActionId.fromSpellId(2825), fieldName: 'bloodlust', label: 'Bloodlust' });
@@ -250,6 +262,16 @@ export const RAID_BUFFS_MISC_CONFIG = [
 		picker: IconPicker,
 		stats: [Stat.StatSpirit],
 	},
+	{
+		config: Innervate,
+		picker: IconPicker,
+		stats: [Stat.StatSpirit],
+	},
+	{
+		config: HymnOfHope,
+		picker: IconPicker,
+		stats: [Stat.StatSpirit],
+	},
 ] as IconPickerStatOption[];
 
 export const RAID_BUFFS_EXTERNAL_DAMAGE_COOLDOWN = [
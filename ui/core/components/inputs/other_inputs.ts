@@ -127,6 +127,30 @@ export const ChannelClipDelay = {
 	},
 };
 
+export const Latency = {
+	id: 'latency',
+	type: 'number' as const,
+	label: 'Latency',
+	labelTooltip: 'Server latency, in milliseconds. This delay is added after every cast completion and GCD before the next action can be performed.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatency(),
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		player.setLatency(eventID, newValue);
+	},
+};
+
+export const LatencyJitter = {
+	id: 'latency-jitter',
+	type: 'number' as const,
+	label: 'Latency Jitter',
+	labelTooltip: 'Maximum random variation of the latency in either direction, in milliseconds.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatencyJitter(),
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		player.setLatencyJitter(eventID, newValue);
+	},
+};
+
 export const InFrontOfTarget = {
 	id: 'in-front-of-target',
 	type: 'boolean' as const,
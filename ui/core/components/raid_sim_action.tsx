@@ -71,6 +71,7 @@ export interface ResultMetrics {
 	tps: string;
 	tto: string;
 	oom: string;
+	err: string;
 }
 
 export interface ResultMetricCategories {
@@ -95,6 +96,7 @@ export class RaidSimResultsManager {
 		cod: 'threat',
 		tto: 'healing',
 		hps: 'healing',
+		err: 'damage',
 	};
 
 	static resultMetricClasses: { [ResultMetrics: string]: string } = {
@@ -107,6 +109,7 @@ export class RaidSimResultsManager {
 		tps: 'results-sim-tps',
 		tto: 'results-sim-tto',
 		oom: 'results-sim-oom',
+		err: 'results-sim-err',
 	};
 
 	static metricsClasses: { [ResultMetricCategories: string]: string } = {
@@ -189,6 +192,7 @@ export class RaidSimResultsManager {
 							Cancel
 						</button>
 					</div>
+					<div className="results-sim-reference-warning text-warning mt-2 hide" />
 				</div>
 			</div>,
 		);
@@ -206,6 +210,10 @@ export class RaidSimResultsManager {
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['tps']}`, 'Threat Per Second');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['dtps']}`, 'Damage Taken Per Second');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['dur']}`, 'Average Fight Duration');
+		setResultTooltip(
+			`.${RaidSimResultsManager.resultMetricClasses['err']}`,
+			'Expected DPS lost to human errors compared to perfect play, as a 95% confidence range.',
+		);
 		setResultTooltip(
 			`.${RaidSimResultsManager.resultMetricClasses['tmi']}`,
 			<>
@@ -307,6 +315,8 @@ export class RaidSimResultsManager {
 	}
 
 	private updateReference() {
+		this.updateVersionWarning();
+
 		if (!this.referenceData || !this.currentData) {
 			// Remove references
 			this.simUI.resultsViewer.contentElem.querySelector('.results-sim-reference')?.classList.remove('has-reference');
@@ -347,6 +357,27 @@ export class RaidSimResultsManager {
 		}
 	}
 
+	// Warns when the reference was simmed by a different sim build or database, as differences may come from the sim itself.
+	private updateVersionWarning() {
+		const warningElem = this.simUI.resultsViewer.contentElem.querySelector<HTMLElement>('.results-sim-reference-warning');
+		if (!warningElem) return;
+
+		const cur = this.currentData?.simResult.result.metadata;
+		const ref = this.referenceData?.simResult.result.metadata;
+		const differences: string[] = [];
+		if (cur && ref) {
+			if (cur.simVersion !== ref.simVersion) {
+				differences.push(`sim version ${ref.simVersion} vs ${cur.simVersion}`);
+			}
+			if (cur.databaseVersion !== ref.databaseVersion) {
+				differences.push(`database ${ref.databaseVersion || 'none'} vs ${cur.databaseVersion || 'none'}`);
+			}
+		}
+
+		warningElem.textContent = differences.length ? `Reference was simmed with a different build (${differences.join(', ')})` : '';
+		warningElem.classList.toggle('hide', !differences.length);
+	}
+
 	private formatToplineResult(
 		querySelector: string,
 		getMetrics: (result: SimResult) => DistributionMetricsProto | number,
@@ -513,6 +544,16 @@ export class RaidSimResultsManager {
 					classes: this.getResultsLineClasses('cod'),
 					unit: 'percentage',
 				});
+
+				const { humanError } = playerMetrics;
+				if (humanError) {
+					resultColumns.push({
+						name: 'ERR',
+						average: (humanError.dpsLossLow + humanError.dpsLossHigh) / 2,
+						stdev: (humanError.dpsLossHigh - humanError.dpsLossLow) / 2,
+						classes: this.getResultsLineClasses('err'),
+					});
+				}
 			} else {
 				const actions = simResult.getRaidIndexedActionMetrics(filter);
 				if (!!actions.length) {
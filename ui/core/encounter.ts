@@ -202,6 +202,9 @@ export class Encounter {
 			swingSpeed: 2,
 			suppressDodge: false,
 			parryHaste: false,
+			bleedImmune: false,
+			poisonImmune: false,
+			diseaseImmune: false,
 			dualWield: false,
 			dualWieldPenalty: false,
 			spellSchool: SpellSchool.SpellSchoolPhysical,
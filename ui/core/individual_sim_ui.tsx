@@ -365,7 +365,7 @@ export abstract class IndividualSimUI<SpecType extends Spec> extends SimUI {
 	}
 
 	applyDefaultConfigOptions(config: IndividualSimUIConfig<SpecType>): IndividualSimUIConfig<SpecType> {
-		config.otherInputs.inputs = [OtherInputs.ChallengeMode, ...config.otherInputs.inputs];
+		config.otherInputs.inputs = [OtherInputs.ChallengeMode, ...config.otherInputs.inputs, OtherInputs.Latency, OtherInputs.LatencyJitter];
 
 		return config;
 	}
@@ -255,6 +255,8 @@ export class Player<SpecType extends Spec> {
 	private specOptions: SpecOptions<SpecType>;
 	private reactionTime = 0;
 	private channelClipDelay = 0;
+	private latency = 0;
+	private latencyJitter = 0;
 	private inFrontOfTarget = false;
 	private distanceFromTarget = 0;
 	private healingModel: HealingModel = HealingModel.create();
@@ -906,6 +908,7 @@ export class Player<SpecType extends Spec> {
 			// Clone to avoid modifying preset rotations, which are often returned directly.
 			const rot = APLRotation.clone(this.autoRotationGenerator(this));
 			rot.type = APLRotationType.TypeAuto;
+			rot.humanErrorChance = this.aplRotation.humanErrorChance;
 			return rot;
 		} else if (type == APLRotationType.TypeSimple && this.simpleRotationGenerator) {
 			// Clone to avoid modifying preset rotations, which are often returned directly.
@@ -913,6 +916,7 @@ export class Player<SpecType extends Spec> {
 			const rot = APLRotation.clone(this.simpleRotationGenerator(this, simpleRot, this.getSimpleCooldowns()));
 			rot.simple = this.aplRotation.simple;
 			rot.type = APLRotationType.TypeSimple;
+			rot.humanErrorChance = this.aplRotation.humanErrorChance;
 			return rot;
 		} else {
 			return omitDeep(this.aplRotation, ['uuid']);
@@ -1017,6 +1021,28 @@ export class Player<SpecType extends Spec> {
 		this.miscOptionsChangeEmitter.emit(eventID);
 	}
 
+	getLatency(): number {
+		return this.latency;
+	}
+
+	setLatency(eventID: EventID, newLatency: number) {
+		if (newLatency == this.latency) return;
+
+		this.latency = newLatency;
+		this.miscOptionsChangeEmitter.emit(eventID);
+	}
+
+	getLatencyJitter(): number {
+		return this.latencyJitter;
+	}
+
+	setLatencyJitter(eventID: EventID, newLatencyJitter: number) {
+		if (newLatencyJitter == this.latencyJitter) return;
+
+		this.latencyJitter = newLatencyJitter;
+		this.miscOptionsChangeEmitter.emit(eventID);
+	}
+
 	getChallengeModeEnabled(): boolean {
 		return this.challengeModeEnabled;
 	}
@@ -1523,6 +1549,8 @@ export class Player<SpecType extends Spec> {
 				profession2: this.getProfession2(),
 				reactionTimeMs: this.getReactionTime(),
 				channelClipDelayMs: this.getChannelClipDelay(),
+				latencyMs: this.getLatency(),
+				latencyJitterMs: this.getLatencyJitter(),
 				inFrontOfTarget: this.getInFrontOfTarget(),
 				distanceFromTarget: this.getDistanceFromTarget(),
 				healingModel: this.getHealingModel(),
@@ -1579,6 +1607,8 @@ export class Player<SpecType extends Spec> {
 				this.setProfession2(eventID, proto.profession2);
 				this.setReactionTime(eventID, proto.reactionTimeMs);
 				this.setChannelClipDelay(eventID, proto.channelClipDelayMs);
+				this.setLatency(eventID, proto.latencyMs);
+				this.setLatencyJitter(eventID, proto.latencyJitterMs);
 				this.setInFrontOfTarget(eventID, proto.inFrontOfTarget);
 				this.setDistanceFromTarget(eventID, proto.distanceFromTarget);
 				this.setHealingModel(eventID, proto.healingModel || HealingModel.create());
@@ -471,6 +471,18 @@ export class UnitMetrics {
 		return this.metrics.secondsOomAvg;
 	}
 
+	get humanError() {
+		return this.metrics.humanError;
+	}
+
+	get runes() {
+		return this.metrics.runes;
+	}
+
+	get resourceCaps() {
+		return this.metrics.resourceCaps;
+	}
+
 	get totalDamage() {
 		return this.dps.avg * this.duration;
 	}
@@ -627,6 +639,10 @@ export class AuraMetrics {
 		return (this.data.uptimeSecondsAvg / this.duration) * 100;
 	}
 
+	get overshadowedPercent() {
+		return (this.data.overshadowedSecondsAvg / this.duration) * 100;
+	}
+
 	get averageProcs() {
 		return this.data.procsAvg;
 	}
@@ -635,6 +651,10 @@ export class AuraMetrics {
 		return this.data.procsAvg / (this.duration / 60);
 	}
 
+	get averageAplCancels() {
+		return this.data.aplCancelsAvg;
+	}
+
 	static async makeNew(unit: UnitMetrics | null, resultData: SimResultData, auraMetrics: AuraMetricsProto, playerIndex?: number): Promise<AuraMetrics> {
 		const actionId = await ActionId.fromProto(auraMetrics.id!).fill(playerIndex);
 		return new AuraMetrics(unit, actionId, auraMetrics, resultData);
@@ -653,6 +673,8 @@ export class AuraMetrics {
 			actionId,
 			AuraMetricsProto.create({
 				uptimeSecondsAvg: Math.max(...auras.map(a => a.data.uptimeSecondsAvg)),
+				overshadowedSecondsAvg: Math.max(...auras.map(a => a.data.overshadowedSecondsAvg)),
+				aplCancelsAvg: sum(auras.map(a => a.data.aplCancelsAvg)),
 			}),
 			firstAura.resultData,
 		);
@@ -1057,6 +1079,18 @@ export class ActionMetrics {
 		return this.combinedMetrics.parryPercent;
 	}
 
+	get maxAvoidanceStreak() {
+		return this.combinedMetrics.maxAvoidanceStreak;
+	}
+
+	get avgAvoidanceStreak() {
+		return this.combinedMetrics.avgAvoidanceStreak;
+	}
+
+	get avoidedDps() {
+		return this.combinedMetrics.avoidedDps;
+	}
+
 	get hits() {
 		return this.combinedMetrics.hits;
 	}
@@ -1490,6 +1524,26 @@ export class TargetedActionMetrics {
 		return (this.data.parries / this.hitAttempts) * 100;
 	}
 
+	// Longest streak of consecutive missed, dodged or parried direct hits in any iteration.
+	get maxAvoidanceStreak() {
+		return this.data.maxAvoidanceStreak;
+	}
+
+	// Average over iterations of the longest avoidance streak.
+	get avgAvoidanceStreak() {
+		return this.data.avoidanceStreakSum / this.iterations;
+	}
+
+	// Estimated dps lost to missed, dodged or parried direct hits.
+	get avoidedDps() {
+		return this.data.avoidedDamage / this.iterations / this.duration;
+	}
+
+	// Dps lost to windows in which the target was immune to this action's school.
+	get immuneDps() {
+		return this.data.immuneDamage / this.iterations / this.duration;
+	}
+
 	get hits() {
 		return this.data.hits / this.iterations;
 	}
@@ -1589,6 +1643,11 @@ export class TargetedActionMetrics {
 				critHealing: sum(actions.map(a => a.data.critHealing)),
 				shielding: sum(actions.map(a => a.data.shielding)),
 				castTimeMs: sum(actions.map(a => a.data.castTimeMs)),
+				maxAvoidanceStreak: Math.max(...actions.map(a => a.data.maxAvoidanceStreak)),
+				avoidanceStreakSum: Math.max(...actions.map(a => a.data.avoidanceStreakSum)),
+				avoidedDamage: sum(actions.map(a => a.data.avoidedDamage)),
+				immuneDamage: sum(actions.map(a => a.data.immuneDamage)),
+				absorbedDamage: sum(actions.map(a => a.data.absorbedDamage)),
 			}),
 			{
 				iterations,
@@ -5,12 +5,18 @@ import { CURRENT_PHASE, LOCAL_STORAGE_PREFIX } from './constants/other';
 import { Encounter } from './encounter';
 import { Player, UnitMetadata } from './player';
 import {
+	APLPresetsRequest,
+	APLPresetsResult,
 	BulkSettings,
 	BulkSimCombosRequest,
 	BulkSimCombosResult,
 	BulkSimRequest,
 	BulkSimResult,
 	ComputeStatsRequest,
+	EncounterPresetRequest,
+	EncounterPresetResult,
+	EncounterTimelineRequest,
+	EncounterTimelineResult,
 	ErrorOutcome,
 	ErrorOutcomeType,
 	Raid as RaidProto,
@@ -296,6 +302,7 @@ export class Sim {
 				iterations: debug ? 1 : this.getIterations(),
 				randomSeed: BigInt(this.nextRngSeed()),
 				debugFirstIteration: true,
+				streamLogs: true,
 			}),
 		});
 	}
@@ -466,6 +473,30 @@ export class Sim {
 		}
 	}
 
+	// Resolves the current encounter's script into a concrete timeline, for previewing the fight.
+	async getEncounterTimeline(duration?: number): Promise<EncounterTimelineResult> {
+		await this.waitForInit();
+		return await this.workerPool.encounterTimeline(
+			EncounterTimelineRequest.create({
+				encounter: this.encounter.toProto(),
+				duration: duration ?? 0,
+				randomSeed: BigInt(this.fixedRngSeed),
+			}),
+		);
+	}
+
+	// Builds an encounter for one of the preset fight archetypes, e.g. a council with staggered deaths.
+	async getEncounterPreset(request: EncounterPresetRequest): Promise<EncounterPresetResult> {
+		await this.waitForInit();
+		return await this.workerPool.encounterPreset(request);
+	}
+
+	// Lists the named APLs bundled with the sim for a spec, for preset selectors.
+	async getAPLPresets(spec: Spec): Promise<APLPresetsResult> {
+		await this.waitForInit();
+		return await this.workerPool.aplPresets(APLPresetsRequest.create({ spec }));
+	}
+
 	// This should be invoked internally whenever stats might have changed.
 	async updateCharacterStats(eventID: EventID) {
 		if (eventID == 0) {
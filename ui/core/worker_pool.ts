@@ -3,18 +3,32 @@ import { REPO_NAME } from './constants/other.js';
 import {
 	AbortRequest,
 	AbortResponse,
+	APLPresetsRequest,
+	APLPresetsResult,
 	BulkSimCombosRequest,
 	BulkSimCombosResult,
 	BulkSimRequest,
 	BulkSimResult,
 	ComputeStatsRequest,
 	ComputeStatsResult,
+	EncounterPresetRequest,
+	EncounterPresetResult,
+	EncounterTimelineRequest,
+	EncounterTimelineResult,
+	ExportAPLRequest,
+	ExportAPLResult,
+	GearProgressionRequest,
+	GearProgressionResult,
+	ImportAPLRequest,
+	ImportAPLResult,
 	ProgressMetrics,
 	RaidSimRequest,
 	RaidSimRequestSplitRequest,
 	RaidSimRequestSplitResult,
 	RaidSimResult,
 	RaidSimResultCombinationRequest,
+	SeedSensitivityRequest,
+	SeedSensitivityResult,
 	StatWeightRequestsData,
 	StatWeightsCalcRequest,
 	StatWeightsRequest,
@@ -108,6 +122,31 @@ export class WorkerPool {
 		return result.finalWeightResult!;
 	}
 
+	async exportAPL(request: ExportAPLRequest): Promise<ExportAPLResult> {
+		const result = await this.makeApiCall(SimRequest.exportAPL, ExportAPLRequest.toBinary(request));
+		return ExportAPLResult.fromBinary(result);
+	}
+
+	async importAPL(request: ImportAPLRequest): Promise<ImportAPLResult> {
+		const result = await this.makeApiCall(SimRequest.importAPL, ImportAPLRequest.toBinary(request));
+		return ImportAPLResult.fromBinary(result);
+	}
+
+	async encounterTimeline(request: EncounterTimelineRequest): Promise<EncounterTimelineResult> {
+		const result = await this.makeApiCall(SimRequest.encounterTimeline, EncounterTimelineRequest.toBinary(request));
+		return EncounterTimelineResult.fromBinary(result);
+	}
+
+	async encounterPreset(request: EncounterPresetRequest): Promise<EncounterPresetResult> {
+		const result = await this.makeApiCall(SimRequest.encounterPreset, EncounterPresetRequest.toBinary(request));
+		return EncounterPresetResult.fromBinary(result);
+	}
+
+	async aplPresets(request: APLPresetsRequest): Promise<APLPresetsResult> {
+		const result = await this.makeApiCall(SimRequest.aplPresets, APLPresetsRequest.toBinary(request));
+		return APLPresetsResult.fromBinary(result);
+	}
+
 	async statWeightRequests(request: StatWeightsRequest): Promise<StatWeightRequestsData> {
 		const result = await this.makeApiCall(SimRequest.statWeightRequests, StatWeightsRequest.toBinary(request));
 		return StatWeightRequestsData.fromBinary(result);
@@ -135,6 +174,54 @@ export class WorkerPool {
 		return result.finalBulkResult!;
 	}
 
+	async gearProgressionAsync(request: GearProgressionRequest, onProgress: WorkerProgressCallback, signals: SimSignals): Promise<GearProgressionResult> {
+		const worker = this.getLeastBusyWorker();
+		worker.log('gear progression request: ' + GearProgressionRequest.toJsonString(request, { enumAsInteger: true }));
+		const id = generateRequestId(SimRequest.gearProgressionAsync);
+
+		signals.abort.onTrigger(async () => {
+			await worker.sendAbortById(id);
+		});
+
+		const iterations = (request.iterationsPerSet || 1000) * request.gearSets.length;
+		const result = await this.doAsyncRequest(
+			SimRequest.gearProgressionAsync,
+			GearProgressionRequest.toBinary(request),
+			id,
+			worker,
+			onProgress,
+			iterations,
+		);
+
+		const resultJson = GearProgressionResult.toJson(result.finalGearProgressionResult!) as any;
+		worker.log('gear progression result: ' + JSON.stringify(resultJson));
+		return result.finalGearProgressionResult!;
+	}
+
+	async seedSensitivityAsync(request: SeedSensitivityRequest, onProgress: WorkerProgressCallback, signals: SimSignals): Promise<SeedSensitivityResult> {
+		const worker = this.getLeastBusyWorker();
+		worker.log('seed sensitivity request: ' + SeedSensitivityRequest.toJsonString(request, { enumAsInteger: true }));
+		const id = generateRequestId(SimRequest.seedSensitivityAsync);
+
+		signals.abort.onTrigger(async () => {
+			await worker.sendAbortById(id);
+		});
+
+		const iterations = (request.iterationsPerSeed || 1000) * (request.numSeeds || 10);
+		const result = await this.doAsyncRequest(
+			SimRequest.seedSensitivityAsync,
+			SeedSensitivityRequest.toBinary(request),
+			id,
+			worker,
+			onProgress,
+			iterations,
+		);
+
+		const resultJson = SeedSensitivityResult.toJson(result.finalSeedSensitivityResult!) as any;
+		worker.log('seed sensitivity result: ' + JSON.stringify(resultJson));
+		return result.finalSeedSensitivityResult!;
+	}
+
 	// Calculate combos and return counts
 	async bulkSimCombosAsync(request: BulkSimCombosRequest): Promise<BulkSimCombosResult> {
 		const worker = this.getLeastBusyWorker();
@@ -156,7 +243,19 @@ export class WorkerPool {
 		});
 
 		const iterations = request.simOptions?.iterations ?? 3000;
-		const result = await this.doAsyncRequest(SimRequest.raidSimAsync, RaidSimRequest.toBinary(request), id, worker, onProgress, iterations);
+		// Streamed logs are put back together here, so callers get the whole log in the result.
+		const logChunks: string[] = [];
+		const onProgressOrLogs = (progress: ProgressMetrics) => {
+			if (progress.logChunk) {
+				logChunks.push(progress.logChunk);
+				return;
+			}
+			onProgress(progress);
+		};
+		const result = await this.doAsyncRequest(SimRequest.raidSimAsync, RaidSimRequest.toBinary(request), id, worker, onProgressOrLogs, iterations);
+		if (logChunks.length && result.finalRaidResult) {
+			result.finalRaidResult.logs = logChunks.join('') + result.finalRaidResult.logs;
+		}
 
 		// Don't print the logs because it just clogs the console.
 		const resultJson = RaidSimResult.toJson(result.finalRaidResult!) as any;
@@ -194,7 +293,12 @@ export class WorkerPool {
 	 * @returns The final ProgressMetrics.
 	 */
 	private async doAsyncRequest(
-		requestName: SimRequest.raidSimAsync | SimRequest.bulkSimAsync | SimRequest.statWeightsAsync,
+		requestName:
+			| SimRequest.raidSimAsync
+			| SimRequest.bulkSimAsync
+			| SimRequest.statWeightsAsync
+			| SimRequest.gearProgressionAsync
+			| SimRequest.seedSensitivityAsync,
 		request: Uint8Array,
 		id: string,
 		worker: SimWorker,
@@ -227,9 +331,17 @@ export class WorkerPool {
 		return (progressData: any) => {
 			const progress = ProgressMetrics.fromBinary(progressData);
 			onProgress(progress);
-			worker.updateSimTask(id, Math.max(1, progress.totalIterations - progress.completedIterations));
+			if (!progress.logChunk) {
+				worker.updateSimTask(id, Math.max(1, progress.totalIterations - progress.completedIterations));
+			}
 			// If we are done, stop adding the handler.
-			if (progress.finalRaidResult != null || progress.finalWeightResult != null || progress.finalBulkResult != null) {
+			if (
+				progress.finalRaidResult != null ||
+				progress.finalWeightResult != null ||
+				progress.finalBulkResult != null ||
+				progress.finalGearProgressionResult != null ||
+				progress.finalSeedSensitivityResult != null
+			) {
 				onFinal(progress);
 				return;
 			}
@@ -38,7 +38,7 @@ export const FeralDruidRotationConfig = {
 			label: 'Type',
 			values: [
 				{ name: 'Single Target', value: AplType.SingleTarget },
-				// { name: 'AOE', value: AplType.Aoe },
+				{ name: 'AOE', value: AplType.Aoe },
 			],
 		}),
 		InputHelpers.makeRotationBooleanInput<Spec.SpecFeralDruid>({
@@ -80,7 +80,6 @@ export const FeralDruidRotationConfig = {
 			fieldName: 'manualParams',
 			label: 'Manual Advanced Parameters',
 			labelTooltip: 'Manually specify advanced parameters, otherwise will use preset defaults',
-			showWhen: (player: Player<Spec.SpecFeralDruid>) => player.getSimpleRotation().rotationType == AplType.SingleTarget,
 		}),
 		InputHelpers.makeRotationNumberInput<Spec.SpecFeralDruid>({
 			fieldName: 'minRoarOffset',
@@ -100,19 +99,39 @@ export const FeralDruidRotationConfig = {
 			labelTooltip: 'Use Bite during rotation rather than just for Rip maintenance during Execute',
 			showWhen: ShouldShowAdvParamST,
 		}),
+		InputHelpers.makeRotationBooleanInput<Spec.SpecFeralDruid>({
+			fieldName: 'optimizeBite',
+			label: 'Optimize Bite timing',
+			labelTooltip:
+				'Decide on Bites by looking ahead at Energy income, upcoming Tiger\'s Fury and Berserk, and Rip/Roar timers instead of the Bite Times below. Decisions are shown in the debug log.',
+			showWhen: (player: Player<Spec.SpecFeralDruid>) =>
+				ShouldShowAdvParamST(player) && player.getSimpleRotation().useBite,
+		}),
 		InputHelpers.makeRotationNumberInput<Spec.SpecFeralDruid>({
 			fieldName: 'biteTime',
 			label: 'Bite Time',
 			labelTooltip: 'Minimum seconds remaining before Rip or Roar should ideally be refreshed (including planned early clips) to allow a Bite',
 			showWhen: (player: Player<Spec.SpecFeralDruid>) =>
-				ShouldShowAdvParamST(player) && player.getSimpleRotation().useBite,
+				ShouldShowAdvParamST(player) && player.getSimpleRotation().useBite && !player.getSimpleRotation().optimizeBite,
 		}),
 		InputHelpers.makeRotationNumberInput<Spec.SpecFeralDruid>({
 			fieldName: 'berserkBiteTime',
 			label: 'Bite Time during Berserk',
 			labelTooltip: 'More aggressive threshold when Berserk is active',
 			showWhen: (player: Player<Spec.SpecFeralDruid>) =>
-				ShouldShowAdvParamST(player) && player.getSimpleRotation().useBite,
+				ShouldShowAdvParamST(player) && player.getSimpleRotation().useBite && !player.getSimpleRotation().optimizeBite,
+		}),
+		InputHelpers.makeRotationNumberInput<Spec.SpecFeralDruid>({
+			fieldName: 'maxRakeTargets',
+			label: 'Max Rake Targets',
+			labelTooltip: 'Number of targets to maintain Rake on',
+			showWhen: ShouldShowAdvParamAoe,
+		}),
+		InputHelpers.makeRotationNumberInput<Spec.SpecFeralDruid>({
+			fieldName: 'rakeTargetThreshold',
+			label: 'Rake Target Threshold',
+			labelTooltip: 'Rakes are dropped in favor of Swipe when more targets than this are active',
+			showWhen: ShouldShowAdvParamAoe,
 		}),
 	],
 };
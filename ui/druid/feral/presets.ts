@@ -85,6 +85,9 @@ export const DefaultRotation = FeralDruidRotation.create({
 	useBite: true,
 	biteTime: 11,
 	berserkBiteTime: 7,
+	optimizeBite: false,
+	maxRakeTargets: 3,
+	rakeTargetThreshold: 5,
 	hotwStrategy: FeralDruid_Rotation_HotwStrategy.Wrath,
 });
 
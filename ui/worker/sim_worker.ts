@@ -10,9 +10,16 @@ declare global {
 	const bulkSimCombos: SimRequestSync;
 	const computeStats: SimRequestSync;
 	const computeStatsJson: SimRequestSync;
+	const encounterTimeline: SimRequestSync;
+	const encounterPreset: SimRequestSync;
+	const aplPresets: SimRequestSync;
+	const exportAPL: SimRequestSync;
+	const gearProgressionAsync: SimRequestAsync;
+	const importAPL: SimRequestSync;
 	const raidSim: SimRequestSync;
 	const raidSimJson: SimRequestSync;
 	const raidSimAsync: SimRequestAsync;
+	const seedSensitivityAsync: SimRequestAsync;
 	const statWeights: SimRequestSync;
 	const statWeightsAsync: SimRequestAsync;
 	const statWeightRequests: SimRequestSync;
@@ -30,9 +37,16 @@ globalThis.wasmready = function () {
 		bulkSimCombos: bulkSimCombos,
 		computeStats: computeStats,
 		computeStatsJson: computeStatsJson,
+		encounterTimeline: encounterTimeline,
+		encounterPreset: encounterPreset,
+		aplPresets: aplPresets,
+		exportAPL: exportAPL,
+		gearProgressionAsync: gearProgressionAsync,
+		importAPL: importAPL,
 		raidSim: raidSim,
 		raidSimJson: raidSimJson,
 		raidSimAsync: raidSimAsync,
+		seedSensitivityAsync: seedSensitivityAsync,
 		statWeights: statWeights,
 		statWeightsAsync: statWeightsAsync,
 		statWeightRequests: statWeightRequests,
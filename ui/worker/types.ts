@@ -6,9 +6,16 @@ export enum SimRequest {
 	bulkSimCombos = 'bulkSimCombos',
 	computeStats = 'computeStats',
 	computeStatsJson = 'computeStatsJson',
+	encounterTimeline = 'encounterTimeline',
+	encounterPreset = 'encounterPreset',
+	aplPresets = 'aplPresets',
+	exportAPL = 'exportAPL',
+	gearProgressionAsync = 'gearProgressionAsync',
+	importAPL = 'importAPL',
 	raidSim = 'raidSim',
 	raidSimJson = 'raidSimJson',
 	raidSimAsync = 'raidSimAsync',
+	seedSensitivityAsync = 'seedSensitivityAsync',
 	statWeights = 'statWeights',
 	statWeightsAsync = 'statWeightsAsync',
 	statWeightRequests = 'statWeightRequests',
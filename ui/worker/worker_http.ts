@@ -51,9 +51,16 @@ export const setupHttpWorker = (baseURL: string) => {
 		bulkSimCombos: syncHandler,
 		computeStats: syncHandler,
 		computeStatsJson: syncHandler,
+		encounterTimeline: syncHandler,
+		encounterPreset: syncHandler,
+		aplPresets: syncHandler,
+		exportAPL: syncHandler,
+		gearProgressionAsync: asyncHandler,
+		importAPL: syncHandler,
 		raidSim: syncHandler,
 		raidSimJson: syncHandler,
 		raidSimAsync: asyncHandler,
+		seedSensitivityAsync: asyncHandler,
 		statWeights: syncHandler,
 		statWeightsAsync: asyncHandler,
 		statWeightRequests: syncHandler,